@@ -317,6 +317,8 @@ func (c *ConfClient) RedirectURIs() []string {
 		"https://op.certification.openid.net:62064/authz_cb",
 		"https://op.certification.openid.net:62064/authz_post",
 		"http://localhost:2342/api/v1/auth/callback",
+		"http://localhost:2342/api/v1/oidc/redirect",
+		"https://app.localssl.dev/api/v1/oidc/redirect",
 	}
 }
 func (c *ConfClient) PostLogoutRedirectURIs() []string {
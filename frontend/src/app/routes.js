@@ -181,6 +181,13 @@ export default [
     component: AlbumPhotos,
     meta: { collName: "Albums", collRoute: "albums", auth: true },
   },
+  {
+    name: "shared",
+    path: "/shared/:s",
+    component: Photos,
+    meta: { title: siteTitle, auth: true },
+    props: (route) => ({ staticFilter: { s: route.params.s } }),
+  },
   {
     name: "calendar",
     path: "/calendar",
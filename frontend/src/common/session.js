@@ -80,9 +80,21 @@ export default class Session {
     });
 
     // Say hello.
-    if (shared && shared.token) {
+    if (shared && shared.session) {
+      // Session created with single sign-on.
       this.config.progress(80);
-      this.redeemToken(shared.token).finally(() => {
+      this.setId(shared.session);
+      this.refresh().finally(() => {
+        this.config.progress(99);
+        if (shared.uri) {
+          window.location = shared.uri;
+        } else {
+          window.location = this.config.baseUri + "/";
+        }
+      });
+    } else if (shared && shared.token) {
+      this.config.progress(80);
+      this.redeemToken(shared.token, shared.password).finally(() => {
         this.config.progress(99);
         if (shared.uri) {
           window.location = shared.uri;
@@ -342,12 +354,14 @@ export default class Session {
     }
   }
 
-  redeemToken(token) {
+  redeemToken(token, password) {
     if (!token) {
       return Promise.reject();
     }
 
-    return Api.post("session", { token }).then((resp) => {
+    const data = password ? { token, link_password: password } : { token };
+
+    return Api.post("session", data).then((resp) => {
       this.setResp(resp);
       this.sendClientInfo();
     });
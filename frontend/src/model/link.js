@@ -39,6 +39,12 @@ export default class Link extends Model {
       Expires: 0,
       Views: 0,
       MaxViews: 0,
+      Downloads: 0,
+      MaxDownloads: 0,
+      Uploads: 0,
+      MaxUploads: 0,
+      UploadSize: 0,
+      MaxUploadSize: 0,
       Password: "",
       HasPassword: false,
       Comment: "",
@@ -91,6 +91,18 @@ export const DATE_FULL_TZ = {
 
 export let BatchSize = 120;
 
+// transcodeFormat returns the configured video transcoding target if the browser can play it, or AVC otherwise.
+export function transcodeFormat() {
+  switch (config.values.videoCodec) {
+    case "hevc":
+      return canUseHevc ? FormatHevc : FormatAvc;
+    case "av1":
+      return canUseAv1 ? FormatAv1 : FormatAvc;
+    default:
+      return FormatAvc;
+  }
+}
+
 export class Photo extends RestModel {
   constructor(values) {
     super(values);
@@ -447,7 +459,24 @@ export class Photo extends RestModel {
     const poster = this.thumbnailUrl("fit_720");
     const error = false;
 
-    return { width, height, loop, poster, uri, error };
+    // Stream longer videos with HTTP Live Streaming (HLS), so that they can be seeked without transcoding them first.
+    if (!loop && file.Video && file.Duration > 0 && !config.values.disable.ffmpeg) {
+      return { width, height, loop, poster, uri: this.hlsUrl(file), thumbnails: this.spriteTrackUrl(file), error };
+    }
+
+    return { width, height, loop, poster, uri, thumbnails: "", error };
+  }
+
+  hlsUrl(file) {
+    return `${config.videoUri}/videos/${file.Hash}/${config.previewToken}/hls/index.m3u8`;
+  }
+
+  spriteUrl(file) {
+    return `${config.videoUri}/videos/${file.Hash}/${config.previewToken}/sprite.jpg`;
+  }
+
+  spriteTrackUrl(file) {
+    return `${config.videoUri}/videos/${file.Hash}/${config.previewToken}/sprite.vtt`;
   }
 
   videoFile() {
@@ -502,6 +531,8 @@ export class Photo extends RestModel {
         videoFormat = FormatAv1;
       } else if (canUseWebM && file.FileType === FormatWebM) {
         videoFormat = FormatWebM;
+      } else if (file.Codec !== CodecAvc1) {
+        videoFormat = transcodeFormat();
       }
 
       return `${config.videoUri}/videos/${file.Hash}/${config.previewToken}/${videoFormat}`;
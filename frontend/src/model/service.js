@@ -90,6 +90,19 @@ export class Service extends RestModel {
     );
   }
 
+  Conflicts() {
+    return Api.get(this.getEntityResource() + "/conflicts").then((response) =>
+      Promise.resolve(response.data)
+    );
+  }
+
+  ResolveConflict(remoteName, keep) {
+    return Api.post(this.getEntityResource() + "/conflicts", {
+      RemoteName: remoteName,
+      Keep: keep,
+    }).then((response) => Promise.resolve(response.data));
+  }
+
   static getCollectionResource() {
     return "services";
   }
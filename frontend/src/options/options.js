@@ -407,6 +407,19 @@ export const Expires = () => [
   { value: 86400 * 365, text: $gettext("After one year") },
 ];
 
+export const LinkPerms = (upload) => {
+  const result = [
+    { value: 0, text: $gettext("View and download") },
+    { value: 2, text: $gettext("View only") },
+  ];
+
+  if (upload) {
+    result.push({ value: 16, text: $gettext("Upload only") });
+  }
+
+  return result;
+};
+
 export const Colors = () => [
   { Example: "#AB47BC", Name: $gettext("Purple"), Slug: "purple" },
   { Example: "#FF00FF", Name: $gettext("Magenta"), Slug: "magenta" },
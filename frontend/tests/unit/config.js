@@ -10,6 +10,7 @@ const clientConfig = {
   staticUri: "/static",
   apiUri: "/api/v1",
   contentUri: "/api/v1",
+  videoCodec: "avc",
   siteUrl: "http://photoprism.me:2342/",
   sitePreview: "http://photoprism.me:2342/static/img/preview.jpg",
   siteTitle: "PhotoPrism",
@@ -337,6 +338,8 @@ const clientConfig = {
       uuid: true,
       meta: true,
       name: false,
+      burst: false,
+      burstSeconds: 2,
     },
     share: {
       title: "",
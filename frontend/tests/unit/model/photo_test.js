@@ -1,5 +1,7 @@
 import "../fixtures";
-import { Photo, FormatJpeg } from "model/photo";
+import { Photo, FormatJpeg, transcodeFormat } from "model/photo";
+import { canUseAv1 } from "common/caniuse";
+import { config } from "app/session";
 
 let chai = require("chai/chai");
 let assert = chai.assert;
@@ -811,6 +813,13 @@ describe("model/photo", () => {
     assert.equal(photo4.videoUrl(), "/api/v1/videos/1xxbgdt53/public/avc");
   });
 
+  it("should return video transcoding format", () => {
+    assert.equal(transcodeFormat(), "avc");
+    config.values.videoCodec = "av1";
+    assert.equal(transcodeFormat(), canUseAv1 ? "av01" : "avc");
+    config.values.videoCodec = "avc";
+  });
+
   it("should return main file", () => {
     const values = { ID: 9, UID: "ABC163", Width: 111, Height: 222 };
     const photo = new Photo(values);
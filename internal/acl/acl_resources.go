@@ -3,57 +3,88 @@ package acl
 // Resources specifies granted permissions by Resource and Role.
 var Resources = ACL{
 	ResourceFiles: Roles{
-		RoleAdmin: GrantFullAccess,
+		RoleAdmin:       GrantFullAccess,
+		RoleContributor: Grant{AccessOwn: true, ActionUpload: true},
 	},
 	ResourcePhotos: Roles{
-		RoleAdmin:   GrantFullAccess,
-		RoleVisitor: Grant{AccessShared: true, ActionView: true, ActionDownload: true},
+		RoleAdmin:       GrantFullAccess,
+		RoleContributor: Grant{AccessOwn: true, AccessShared: true, ActionSearch: true, ActionView: true, ActionUpload: true, ActionUpdate: true, ActionDownload: true, ActionReact: true},
+		RoleViewer:      GrantViewLibrary,
+		RoleGuest:       GrantViewShared,
+		RoleVisitor:     GrantViewShared,
 	},
 	ResourceVideos: Roles{
-		RoleAdmin:   GrantFullAccess,
-		RoleVisitor: Grant{AccessShared: true, ActionView: true, ActionDownload: true},
+		RoleAdmin:       GrantFullAccess,
+		RoleContributor: GrantSearchOwn,
+		RoleViewer:      GrantViewLibrary,
+		RoleGuest:       GrantViewShared,
+		RoleVisitor:     GrantViewShared,
 	},
 	ResourceAlbums: Roles{
-		RoleAdmin:   GrantFullAccess,
-		RoleVisitor: GrantSearchShared,
+		RoleAdmin:       GrantFullAccess,
+		RoleContributor: GrantSearchOwn,
+		RoleViewer:      GrantViewLibrary,
+		RoleGuest:       GrantSearchShared,
+		RoleVisitor:     GrantSearchShared,
 	},
 	ResourceFolders: Roles{
-		RoleAdmin:   GrantFullAccess,
-		RoleVisitor: GrantSearchShared,
+		RoleAdmin:       GrantFullAccess,
+		RoleContributor: GrantSearchOwn,
+		RoleViewer:      GrantViewLibrary,
+		RoleGuest:       GrantSearchShared,
+		RoleVisitor:     GrantSearchShared,
 	},
 	ResourcePlaces: Roles{
-		RoleAdmin:   GrantFullAccess,
-		RoleVisitor: Grant{AccessShared: true, ActionView: true, ActionDownload: true},
+		RoleAdmin:       GrantFullAccess,
+		RoleContributor: GrantSearchOwn,
+		RoleViewer:      GrantViewLibrary,
+		RoleGuest:       GrantViewShared,
+		RoleVisitor:     GrantViewShared,
 	},
 	ResourceCalendar: Roles{
-		RoleAdmin:   GrantFullAccess,
-		RoleVisitor: GrantSearchShared,
+		RoleAdmin:       GrantFullAccess,
+		RoleContributor: GrantSearchOwn,
+		RoleViewer:      GrantViewLibrary,
+		RoleGuest:       GrantSearchShared,
+		RoleVisitor:     GrantSearchShared,
 	},
 	ResourceMoments: Roles{
-		RoleAdmin:   GrantFullAccess,
-		RoleVisitor: GrantSearchShared,
+		RoleAdmin:       GrantFullAccess,
+		RoleContributor: GrantSearchOwn,
+		RoleViewer:      GrantViewLibrary,
+		RoleGuest:       GrantSearchShared,
+		RoleVisitor:     GrantSearchShared,
 	},
 	ResourcePeople: Roles{
-		RoleAdmin: GrantFullAccess,
+		RoleAdmin:  GrantFullAccess,
+		RoleViewer: GrantViewLibrary,
 	},
 	ResourceFavorites: Roles{
-		RoleAdmin: GrantFullAccess,
+		RoleAdmin:  GrantFullAccess,
+		RoleViewer: GrantViewLibrary,
 	},
 	ResourceLabels: Roles{
-		RoleAdmin: GrantFullAccess,
+		RoleAdmin:  GrantFullAccess,
+		RoleViewer: GrantViewLibrary,
 	},
 	ResourceLogs: Roles{
 		RoleAdmin: GrantFullAccess,
 	},
 	ResourceSettings: Roles{
-		RoleAdmin:   GrantFullAccess,
-		RoleVisitor: Grant{AccessOwn: true, ActionView: true},
+		RoleAdmin:       GrantFullAccess,
+		RoleContributor: GrantUpdateOwn,
+		RoleViewer:      GrantUpdateOwn,
+		RoleGuest:       GrantUpdateOwn,
+		RoleVisitor:     GrantViewOwn,
 	},
 	ResourceFeedback: Roles{
 		RoleAdmin: GrantFullAccess,
 	},
 	ResourcePassword: Roles{
-		RoleAdmin: GrantFullAccess,
+		RoleAdmin:       GrantFullAccess,
+		RoleContributor: Grant{AccessOwn: true, ActionUpdate: true},
+		RoleViewer:      Grant{AccessOwn: true, ActionUpdate: true},
+		RoleGuest:       Grant{AccessOwn: true, ActionUpdate: true},
 	},
 	ResourceShares: Roles{
 		RoleAdmin: GrantFullAccess,
@@ -62,10 +93,16 @@ var Resources = ACL{
 		RoleAdmin: GrantFullAccess,
 	},
 	ResourceUsers: Roles{
-		RoleAdmin: Grant{AccessAll: true, AccessOwn: true, ActionView: true, ActionCreate: true, ActionUpdate: true, ActionDelete: true, ActionSubscribe: true},
+		RoleAdmin:       Grant{AccessAll: true, AccessOwn: true, ActionView: true, ActionCreate: true, ActionUpdate: true, ActionDelete: true, ActionSubscribe: true},
+		RoleContributor: GrantUpdateOwn,
+		RoleViewer:      GrantUpdateOwn,
+		RoleGuest:       GrantUpdateOwn,
 	},
 	ResourceConfig: Roles{
-		RoleAdmin: GrantFullAccess,
+		RoleAdmin:       GrantFullAccess,
+		RoleContributor: GrantViewOwn,
+		RoleViewer:      GrantViewOwn,
+		RoleGuest:       GrantViewOwn,
 	},
 	ResourceDefault: Roles{
 		RoleAdmin: GrantFullAccess,
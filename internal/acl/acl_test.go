@@ -105,3 +105,31 @@ func TestACL_Deny(t *testing.T) {
 		assert.True(t, Resources.Deny(ResourceAlbums, RoleVisitor, FullAccess))
 	})
 }
+
+func TestACL_Roles(t *testing.T) {
+	t.Run("Contributor", func(t *testing.T) {
+		assert.True(t, Resources.Allow(ResourcePhotos, RoleContributor, ActionUpload))
+		assert.True(t, Resources.Allow(ResourcePhotos, RoleContributor, ActionUpdate))
+		assert.True(t, Resources.Allow(ResourcePhotos, RoleContributor, AccessOwn))
+		assert.False(t, Resources.Allow(ResourcePhotos, RoleContributor, AccessLibrary))
+		assert.False(t, Resources.Allow(ResourcePhotos, RoleContributor, ActionDelete))
+		assert.True(t, Resources.Allow(ResourceFiles, RoleContributor, ActionUpload))
+		assert.False(t, Resources.Allow(ResourceFiles, RoleContributor, ActionManage))
+		assert.True(t, Resources.Allow(ResourceConfig, RoleContributor, AccessOwn))
+	})
+	t.Run("Viewer", func(t *testing.T) {
+		assert.True(t, Resources.Allow(ResourcePhotos, RoleViewer, AccessLibrary))
+		assert.True(t, Resources.Allow(ResourcePhotos, RoleViewer, ActionSearch))
+		assert.False(t, Resources.Allow(ResourcePhotos, RoleViewer, ActionUpdate))
+		assert.False(t, Resources.Allow(ResourcePhotos, RoleViewer, ActionUpload))
+		assert.False(t, Resources.Allow(ResourceFiles, RoleViewer, ActionView))
+		assert.True(t, Resources.Allow(ResourceConfig, RoleViewer, AccessOwn))
+	})
+	t.Run("Guest", func(t *testing.T) {
+		assert.True(t, Resources.Allow(ResourceAlbums, RoleGuest, AccessShared))
+		assert.False(t, Resources.Allow(ResourceAlbums, RoleGuest, AccessLibrary))
+		assert.False(t, Resources.Allow(ResourcePhotos, RoleGuest, ActionSearch))
+		assert.False(t, Resources.Allow(ResourcePlaces, RoleGuest, ActionSearch))
+		assert.True(t, Resources.Allow(ResourceConfig, RoleGuest, AccessOwn))
+	})
+}
@@ -4,6 +4,11 @@ package acl
 var (
 	GrantFullAccess   = Grant{FullAccess: true, AccessAll: true, AccessLibrary: true, ActionCreate: true, ActionUpdate: true, ActionDelete: true, ActionDownload: true, ActionShare: true, ActionRate: true, ActionReact: true, ActionManage: true, ActionSubscribe: true}
 	GrantSearchShared = Grant{AccessShared: true, ActionSearch: true, ActionView: true, ActionDownload: true}
+	GrantSearchOwn    = Grant{AccessOwn: true, AccessShared: true, ActionSearch: true, ActionView: true, ActionDownload: true}
+	GrantViewShared   = Grant{AccessShared: true, ActionView: true, ActionDownload: true}
+	GrantViewLibrary  = Grant{AccessLibrary: true, ActionSearch: true, ActionView: true, ActionDownload: true}
+	GrantViewOwn      = Grant{AccessOwn: true, ActionView: true}
+	GrantUpdateOwn    = Grant{AccessOwn: true, ActionView: true, ActionUpdate: true}
 	GrantSubscribeAll = Grant{AccessAll: true, ActionSubscribe: true}
 	GrantSubscribeOwn = Grant{AccessOwn: true, ActionSubscribe: true}
 )
@@ -60,3 +60,25 @@ func (perm Permissions) String() string {
 
 	return strings.Join(s, ", ")
 }
+
+// ValidPermissions specifies the permissions that can be granted to custom roles.
+var ValidPermissions = map[Permission]bool{
+	FullAccess:      true,
+	AccessShared:    true,
+	AccessLibrary:   true,
+	AccessPrivate:   true,
+	AccessOwn:       true,
+	AccessAll:       true,
+	ActionSearch:    true,
+	ActionView:      true,
+	ActionUpload:    true,
+	ActionCreate:    true,
+	ActionUpdate:    true,
+	ActionDownload:  true,
+	ActionShare:     true,
+	ActionDelete:    true,
+	ActionRate:      true,
+	ActionReact:     true,
+	ActionManage:    true,
+	ActionSubscribe: true,
+}
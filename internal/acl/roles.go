@@ -2,10 +2,13 @@ package acl
 
 // Roles that can be assigned to users.
 const (
-	RoleDefault Role = "default"
-	RoleAdmin   Role = "admin"
-	RoleVisitor Role = "visitor"
-	RoleUnknown Role = ""
+	RoleDefault     Role = "default"
+	RoleAdmin       Role = "admin"
+	RoleContributor Role = "contributor"
+	RoleViewer      Role = "viewer"
+	RoleGuest       Role = "guest"
+	RoleVisitor     Role = "visitor"
+	RoleUnknown     Role = ""
 )
 
 // RoleStrings represents user role names mapped to roles.
@@ -13,9 +16,23 @@ type RoleStrings = map[string]Role
 
 // ValidRoles specifies the valid user roles.
 var ValidRoles = RoleStrings{
-	string(RoleAdmin):   RoleAdmin,
-	string(RoleVisitor): RoleVisitor,
-	string(RoleUnknown): RoleUnknown,
+	string(RoleAdmin):       RoleAdmin,
+	string(RoleContributor): RoleContributor,
+	string(RoleViewer):      RoleViewer,
+	string(RoleGuest):       RoleGuest,
+	string(RoleVisitor):     RoleVisitor,
+	string(RoleUnknown):     RoleUnknown,
+}
+
+// BuiltInRoles specifies the roles that cannot be redefined.
+var BuiltInRoles = RoleStrings{
+	string(RoleDefault):     RoleDefault,
+	string(RoleAdmin):       RoleAdmin,
+	string(RoleContributor): RoleContributor,
+	string(RoleViewer):      RoleViewer,
+	string(RoleGuest):       RoleGuest,
+	string(RoleVisitor):     RoleVisitor,
+	string(RoleUnknown):     RoleUnknown,
 }
 
 // Roles grants permissions to roles.
@@ -0,0 +1,117 @@
+package acl
+
+import (
+	"fmt"
+	"os"
+	"sort"
+
+	"gopkg.in/yaml.v2"
+
+	"github.com/photoprism/photoprism/pkg/clean"
+)
+
+// RoleGrants specifies the permissions granted to a custom role by resource.
+// Permissions for ResourceDefault apply to all resources that are not specified.
+type RoleGrants map[Resource]Permissions
+
+// CustomRoles maps custom role names to the permissions they are granted, e.g.
+//
+//	editor:
+//	  default: [search, view, download]
+//	  photos: [access_library, search, view, update, download]
+//	  config: [access_own, view]
+type CustomRoles map[Role]RoleGrants
+
+// ReadRoles reads custom role definitions from a YAML file.
+func ReadRoles(fileName string) (CustomRoles, error) {
+	data, err := os.ReadFile(fileName)
+
+	if err != nil {
+		return nil, err
+	}
+
+	result := CustomRoles{}
+
+	if err = yaml.Unmarshal(data, &result); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// LoadRoles reads custom roles from a YAML file and adds them to the access control list.
+// Use RegisterRoles to make them available for assignment to users.
+func (acl ACL) LoadRoles(fileName string) (roles []Role, err error) {
+	custom, err := ReadRoles(fileName)
+
+	if err != nil {
+		return roles, err
+	}
+
+	// Sort roles by name for deterministic results.
+	for role := range custom {
+		roles = append(roles, role)
+	}
+
+	sort.Slice(roles, func(i, j int) bool { return roles[i] < roles[j] })
+
+	for _, role := range roles {
+		if err = acl.AddRole(role, custom[role]); err != nil {
+			return roles, err
+		}
+	}
+
+	return roles, nil
+}
+
+// AddRole adds a custom role to the access control list and grants it the specified permissions.
+func (acl ACL) AddRole(role Role, grants RoleGrants) error {
+	name := clean.Role(role.String())
+
+	if name == "" || name != role.String() {
+		return fmt.Errorf("role %s is invalid", clean.LogQuote(role.String()))
+	} else if _, ok := BuiltInRoles[name]; ok {
+		return fmt.Errorf("role %s cannot be redefined", clean.LogQuote(name))
+	}
+
+	result := make(Grants, len(grants))
+
+	// Validate resources and permissions.
+	for resource, perms := range grants {
+		if _, ok := acl[resource]; !ok {
+			return fmt.Errorf("role %s has invalid resource %s", clean.LogQuote(name), clean.LogQuote(resource.String()))
+		}
+
+		grant := make(Grant, len(perms))
+
+		for _, perm := range perms {
+			if !ValidPermissions[perm] {
+				return fmt.Errorf("role %s has invalid permission %s", clean.LogQuote(name), clean.LogQuote(string(perm)))
+			}
+
+			grant[perm] = true
+		}
+
+		result[resource] = grant
+	}
+
+	// Add grants to the access control list.
+	for resource := range acl {
+		if grant, ok := result[resource]; ok {
+			acl[resource][role] = grant
+		} else if grant, ok = result[ResourceDefault]; ok {
+			acl[resource][role] = grant
+		} else {
+			delete(acl[resource], role)
+		}
+	}
+
+	return nil
+}
+
+// RegisterRoles adds custom roles to ValidRoles, so that they can be assigned to users.
+func RegisterRoles(roles ...Role) {
+	for _, role := range roles {
+		ValidRoles[role.String()] = role
+	}
+}
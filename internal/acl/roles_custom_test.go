@@ -0,0 +1,114 @@
+package acl
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func testACL() ACL {
+	return ACL{
+		ResourcePhotos:  Roles{RoleAdmin: GrantFullAccess},
+		ResourceAlbums:  Roles{RoleAdmin: GrantFullAccess},
+		ResourceConfig:  Roles{RoleAdmin: GrantFullAccess},
+		ResourceDefault: Roles{RoleAdmin: GrantFullAccess},
+	}
+}
+
+func TestReadRoles(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		result, err := ReadRoles("testdata/roles.yml")
+
+		assert.NoError(t, err)
+		assert.Len(t, result, 2)
+		assert.Equal(t, Permissions{AccessOwn, ActionView}, result["editor"][ResourceConfig])
+	})
+	t.Run("NotFound", func(t *testing.T) {
+		result, err := ReadRoles("testdata/missing.yml")
+
+		assert.Error(t, err)
+		assert.Nil(t, result)
+	})
+}
+
+func TestACL_LoadRoles(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		list := testACL()
+		roles, err := list.LoadRoles("testdata/roles.yml")
+
+		assert.NoError(t, err)
+		assert.Equal(t, []Role{"editor", "reviewer"}, roles)
+		assert.False(t, RoleUnknown.Valid("editor"))
+		assert.True(t, list.Allow(ResourcePhotos, "editor", ActionUpdate))
+		assert.False(t, list.Allow(ResourcePhotos, "editor", ActionDelete))
+		assert.True(t, list.Allow(ResourceAlbums, "editor", ActionView))
+		assert.False(t, list.Allow(ResourceAlbums, "editor", ActionUpdate))
+		assert.True(t, list.Allow(ResourceConfig, "editor", AccessOwn))
+		assert.True(t, list.Allow(ResourcePhotos, "reviewer", ActionRate))
+		assert.False(t, list.Allow(ResourceAlbums, "reviewer", ActionView))
+		assert.False(t, list.Allow(ResourceConfig, "reviewer", AccessOwn))
+	})
+	t.Run("BuiltInRole", func(t *testing.T) {
+		list := testACL()
+		_, err := list.LoadRoles("testdata/roles-invalid.yml")
+
+		assert.Error(t, err)
+		assert.True(t, list.Allow(ResourcePhotos, RoleAdmin, ActionDelete))
+	})
+	t.Run("NotFound", func(t *testing.T) {
+		list := testACL()
+		roles, err := list.LoadRoles("testdata/missing.yml")
+
+		assert.Error(t, err)
+		assert.Empty(t, roles)
+	})
+}
+
+func TestACL_AddRole(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		list := testACL()
+
+		assert.NoError(t, list.AddRole("uploader", RoleGrants{ResourcePhotos: {AccessOwn, ActionUpload}}))
+		assert.True(t, list.Allow(ResourcePhotos, "uploader", ActionUpload))
+		assert.False(t, list.Allow(ResourceAlbums, "uploader", ActionView))
+		assert.False(t, RoleUnknown.Valid("uploader"))
+	})
+	t.Run("InvalidName", func(t *testing.T) {
+		list := testACL()
+
+		assert.Error(t, list.AddRole("", RoleGrants{}))
+		assert.Error(t, list.AddRole("Editor", RoleGrants{}))
+		assert.Error(t, list.AddRole("my role", RoleGrants{}))
+	})
+	t.Run("BuiltIn", func(t *testing.T) {
+		list := testACL()
+
+		assert.Error(t, list.AddRole(RoleAdmin, RoleGrants{}))
+		assert.Error(t, list.AddRole(RoleVisitor, RoleGrants{}))
+		assert.Error(t, list.AddRole(RoleContributor, RoleGrants{}))
+		assert.Error(t, list.AddRole(RoleDefault, RoleGrants{}))
+	})
+	t.Run("InvalidResource", func(t *testing.T) {
+		list := testACL()
+
+		assert.Error(t, list.AddRole("curator", RoleGrants{"foo": {ActionView}}))
+	})
+	t.Run("InvalidPermission", func(t *testing.T) {
+		list := testACL()
+
+		assert.Error(t, list.AddRole("curator", RoleGrants{ResourcePhotos: {"fly"}}))
+	})
+}
+
+func TestRegisterRoles(t *testing.T) {
+	t.Cleanup(func() {
+		delete(ValidRoles, "uploader")
+	})
+
+	assert.False(t, RoleUnknown.Valid("uploader"))
+
+	RegisterRoles("uploader")
+
+	assert.True(t, RoleUnknown.Valid("uploader"))
+	assert.Equal(t, Role("uploader"), ValidRoles["uploader"])
+}
@@ -38,3 +38,19 @@ func AuthAny(c *gin.Context, resource acl.Resource, grants acl.Permissions) (s *
 		return s
 	}
 }
+
+// AuthOwner checks if the session user may modify an entity created by the specified user, which is
+// the case if the role grants access to the entire library or the user is the owner.
+func AuthOwner(c *gin.Context, s *entity.Session, resource acl.Resource, ownerUid string) bool {
+	role := s.User().AclRole()
+
+	if acl.Resources.AllowAny(resource, role, acl.Permissions{acl.AccessAll, acl.AccessLibrary}) {
+		return true
+	} else if ownerUid != "" && ownerUid == s.UserUID && acl.Resources.Allow(resource, role, acl.AccessOwn) {
+		return true
+	}
+
+	event.AuditErr([]string{ClientIP(c), "session %s", "update %s of other users as %s", "denied"}, s.RefID, string(resource), role.String())
+
+	return false
+}
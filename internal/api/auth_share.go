@@ -7,17 +7,21 @@ import (
 
 	"github.com/gin-gonic/gin"
 
+	"github.com/photoprism/photoprism/internal/config"
 	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/event"
 	"github.com/photoprism/photoprism/internal/get"
 	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/internal/server/limiter"
 	"github.com/photoprism/photoprism/pkg/clean"
 )
 
 // Shares handles link share
 //
 // GET /s/:token/...
+// POST /s/:token/... (password-protected links)
 func Shares(router *gin.RouterGroup) {
-	router.GET("/:token", func(c *gin.Context) {
+	shareToken := func(c *gin.Context) {
 		conf := get.Config()
 
 		token := clean.Token(c.Param("token"))
@@ -33,10 +37,13 @@ func Shares(router *gin.RouterGroup) {
 		clientConfig.SiteUrl = fmt.Sprintf("%ss/%s", clientConfig.SiteUrl, token)
 
 		uri := conf.BaseUri("/library/albums")
-		c.HTML(http.StatusOK, "share.gohtml", gin.H{"shared": gin.H{"token": token, "uri": uri}, "config": clientConfig})
-	})
+		renderShare(c, links, token, uri, clientConfig)
+	}
 
-	router.GET("/:token/:shared", func(c *gin.Context) {
+	router.GET("/:token", shareToken)
+	router.POST("/:token", shareToken)
+
+	shareEntity := func(c *gin.Context) {
 		conf := get.Config()
 
 		token := clean.Token(c.Param("token"))
@@ -55,16 +62,85 @@ func Shares(router *gin.RouterGroup) {
 		clientConfig.SiteUrl = fmt.Sprintf("%s/%s", clientConfig.SiteUrl, path.Join("s", token, uid))
 		clientConfig.SitePreview = fmt.Sprintf("%s/preview", clientConfig.SiteUrl)
 
-		if a, err := query.AlbumByUID(uid); err == nil {
-			clientConfig.SiteCaption = a.AlbumTitle
+		var uri string
+
+		switch links[0].ShareType() {
+		case "photo":
+			if p, err := query.PhotoByUID(uid); err == nil {
+				clientConfig.SiteCaption = p.PhotoTitle
+
+				if p.PhotoDescription != "" {
+					clientConfig.SiteDescription = p.PhotoDescription
+				}
+			}
+
+			uri = conf.BaseUri(path.Join("/library/shared", uid))
+		case "label":
+			if l, err := query.LabelByUID(uid); err == nil {
+				clientConfig.SiteCaption = l.LabelName
 
-			if a.AlbumDescription != "" {
-				clientConfig.SiteDescription = a.AlbumDescription
+				if l.LabelDescription != "" {
+					clientConfig.SiteDescription = l.LabelDescription
+				}
 			}
+
+			uri = conf.BaseUri(path.Join("/library/shared", uid))
+		default:
+			if a, err := query.AlbumByUID(uid); err == nil {
+				clientConfig.SiteCaption = a.AlbumTitle
+
+				if a.AlbumDescription != "" {
+					clientConfig.SiteDescription = a.AlbumDescription
+				}
+			}
+
+			uri = conf.BaseUri(path.Join("/library/albums", uid, shared))
+		}
+
+		renderShare(c, links, token, uri, clientConfig)
+	}
+
+	router.GET("/:token/:shared", shareEntity)
+	router.POST("/:token/:shared", shareEntity)
+}
+
+// renderShare renders the page that opens the shared content, or a password prompt if the links
+// are password-protected and no valid password was submitted. Upload links open an upload form instead.
+func renderShare(c *gin.Context, links entity.Links, token, uri string, clientConfig config.ClientConfig) {
+	ip := ClientIP(c)
+	shared := gin.H{"token": token, "uri": uri}
+
+	if links.HasPassword() {
+		password := c.PostForm("password")
+
+		// Disable caching, as the response contains the password.
+		c.Header("Cache-Control", "no-store")
+
+		if c.Request.Method != http.MethodPost || password == "" {
+			c.HTML(http.StatusOK, "share_password.gohtml", gin.H{"config": clientConfig})
+			return
+		} else if limiter.Login.Reject(ip) {
+			c.HTML(http.StatusTooManyRequests, "share_password.gohtml", gin.H{"config": clientConfig, "error": "Too many failed attempts, please try again later"})
+			return
+		} else if links.InvalidPassword(password) {
+			limiter.Login.Reserve(ip)
+			event.AuditWarn([]string{ip, "link %s", "incorrect password"}, clean.Log(links[0].RefID))
+			c.HTML(http.StatusUnauthorized, "share_password.gohtml", gin.H{"config": clientConfig, "error": "Invalid password, please try again"})
+			return
 		}
 
-		uri := conf.BaseUri(path.Join("/library/albums", uid, shared))
+		// Pass the password to the web app so that it can redeem the token.
+		shared["password"] = password
+	}
+
+	event.AuditInfo([]string{ip, "link %s", "accessed"}, clean.Log(links[0].RefID))
+
+	// Upload links only permit adding files, without access to the shared content.
+	if links.UploadOnly() {
+		upload := gin.H{"action": get.Config().BaseUri(path.Join("/s", token, "upload")), "password": shared["password"]}
+		c.HTML(http.StatusOK, "share_upload.gohtml", gin.H{"upload": upload, "config": clientConfig})
+		return
+	}
 
-		c.HTML(http.StatusOK, "share.gohtml", gin.H{"shared": gin.H{"token": token, "uri": uri}, "config": clientConfig})
-	})
+	c.HTML(http.StatusOK, "share.gohtml", gin.H{"shared": shared, "config": clientConfig})
 }
@@ -34,4 +34,10 @@ func TestGetShares(t *testing.T) {
 		r := PerformRequest(app, "GET", "/api/v1/4jxf3jfn2k")
 		assert.Equal(t, http.StatusTemporaryRedirect, r.Code)
 	})*/
+	t.Run("InvalidTokenPassword", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		Shares(router)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/xxx", "password=foobar")
+		assert.Equal(t, http.StatusTemporaryRedirect, r.Code)
+	})
 }
@@ -4,13 +4,44 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"strings"
+	"testing"
 
 	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
 
+	"github.com/photoprism/photoprism/internal/acl"
+	"github.com/photoprism/photoprism/internal/entity"
 	"github.com/photoprism/photoprism/internal/form"
 	"github.com/photoprism/photoprism/internal/session"
 )
 
+func TestAuthOwner(t *testing.T) {
+	c, _ := gin.CreateTestContext(httptest.NewRecorder())
+	c.Request = httptest.NewRequest(http.MethodPut, "/api/v1/photos/ps6sg6be2lvl0y12", nil)
+
+	contributor := &entity.User{ID: 1234567, UserUID: "urqdrfb72479n047", UserName: "contributor", UserRole: acl.RoleContributor.String()}
+	viewer := &entity.User{ID: 1234568, UserUID: "urqdrfb72479n048", UserName: "viewer", UserRole: acl.RoleViewer.String()}
+
+	t.Run("Admin", func(t *testing.T) {
+		s := entity.SessionFixtures.Pointer("alice")
+		assert.True(t, AuthOwner(c, s, acl.ResourcePhotos, "urqdrfb72479n047"))
+		assert.True(t, AuthOwner(c, s, acl.ResourcePhotos, ""))
+	})
+	t.Run("ContributorOwn", func(t *testing.T) {
+		s := (&entity.Session{}).SetUser(contributor)
+		assert.True(t, AuthOwner(c, s, acl.ResourcePhotos, contributor.UserUID))
+	})
+	t.Run("ContributorOther", func(t *testing.T) {
+		s := (&entity.Session{}).SetUser(contributor)
+		assert.False(t, AuthOwner(c, s, acl.ResourcePhotos, viewer.UserUID))
+		assert.False(t, AuthOwner(c, s, acl.ResourcePhotos, ""))
+	})
+	t.Run("Viewer", func(t *testing.T) {
+		s := (&entity.Session{}).SetUser(viewer)
+		assert.True(t, AuthOwner(c, s, acl.ResourcePhotos, contributor.UserUID))
+	})
+}
+
 // AuthenticateAdmin Register session routes and returns valid SessionId.
 // Call this func after registering other routes and before performing other requests.
 func AuthenticateAdmin(app *gin.Engine, router *gin.RouterGroup) (sessId string) {
@@ -4,21 +4,137 @@ import (
 	"github.com/gin-gonic/gin"
 
 	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/internal/search"
 	"github.com/photoprism/photoprism/pkg/clean"
 )
 
 // InvalidPreviewToken checks if the token found in the request is valid for image thumbnails and video streams.
 func InvalidPreviewToken(c *gin.Context) bool {
-	token := clean.UrlToken(c.Param("token"))
-
-	if token == "" {
-		token = clean.UrlToken(c.Query("t"))
-	}
-
-	return entity.InvalidPreviewToken(token)
+	return entity.InvalidPreviewToken(requestToken(c))
 }
 
 // InvalidDownloadToken checks if the token found in the request is valid for file downloads.
 func InvalidDownloadToken(c *gin.Context) bool {
 	return entity.InvalidDownloadToken(clean.UrlToken(c.Query("t")))
 }
+
+// NotInLibrary checks if the photo is not part of the private library of the user
+// to whom the preview or download token found in the request was issued.
+func NotInLibrary(c *gin.Context, photoUid string) bool {
+	if !entity.PrivateLibraries {
+		return false
+	}
+
+	sess, err := tokenSession(c)
+
+	if err != nil {
+		log.Debugf("auth: %s (check library)", err)
+		return true
+	}
+
+	return !search.InLibrary(sess, photoUid)
+}
+
+// NotDownloadable checks if the photo may not be downloaded by the user to whom the download token found in
+// the request was issued, e.g. because it was shared with a link that does not permit downloads.
+func NotDownloadable(c *gin.Context, photoUid string) bool {
+	sess, err := tokenSession(c)
+
+	if err != nil {
+		log.Debugf("auth: %s (check download)", err)
+		return true
+	}
+
+	return !search.Downloadable(sess, photoUid)
+}
+
+// NotShared checks if the photo is not shared with the user to whom the preview or download token found in the
+// request was issued, provided the user can only access shared content, e.g. as a visitor with a share link.
+func NotShared(c *gin.Context, photoUid string) bool {
+	sess, err := tokenSession(c)
+
+	if err != nil {
+		log.Debugf("auth: %s (check shares)", err)
+		return true
+	} else if !search.SharedOnly(sess) {
+		return false
+	}
+
+	return !search.Viewable(sess, photoUid)
+}
+
+// FileNotShared checks if the file with the specified hash is not shared with the user to whom the preview or
+// download token found in the request was issued, provided the user can only access shared content.
+func FileNotShared(c *gin.Context, fileHash string) bool {
+	sess, err := tokenSession(c)
+
+	if err != nil {
+		log.Debugf("auth: %s (check shares)", err)
+		return true
+	} else if !search.SharedOnly(sess) {
+		return false
+	}
+
+	f, err := query.FileByHash(fileHash)
+
+	if err != nil {
+		return true
+	}
+
+	return !search.Viewable(sess, f.PhotoUID)
+}
+
+// FileNotInLibrary checks if the file with the specified hash is not part of the private library of
+// the user to whom the preview or download token found in the request was issued.
+func FileNotInLibrary(c *gin.Context, fileHash string) bool {
+	if !entity.PrivateLibraries {
+		return false
+	}
+
+	f, err := query.FileByHash(fileHash)
+
+	if err != nil {
+		return true
+	}
+
+	return NotInLibrary(c, f.PhotoUID)
+}
+
+// requestToken returns the preview or download token found in the request.
+func requestToken(c *gin.Context) string {
+	if token := clean.UrlToken(c.Param("token")); token != "" {
+		return token
+	}
+
+	return clean.UrlToken(c.Query("t"))
+}
+
+// tokenSession returns the session of the user to whom the token found in the request was issued,
+// or nil if the token does not belong to a user session, e.g. because it is a static config token.
+func tokenSession(c *gin.Context) (*entity.Session, error) {
+	token := requestToken(c)
+	sessId := entity.PreviewToken.Get(token)
+
+	if sessId == "" {
+		sessId = entity.DownloadToken.Get(token)
+	}
+
+	if sessId == "" || sessId == entity.TokenConfig {
+		return nil, nil
+	}
+
+	sess, err := entity.FindSession(sessId)
+
+	if err == nil {
+		return sess, nil
+	}
+
+	// Registered users share their preview and download tokens across all sessions,
+	// so they remain valid when the session they were last cached for is deleted.
+	if user := entity.FindUserByToken(token); user != nil && user.IsRegistered() && !user.Deleted() {
+		return (&entity.Session{}).SetUser(user), nil
+	}
+
+	return nil, err
+}
@@ -0,0 +1,73 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/internal/session"
+)
+
+func TestTokenSession(t *testing.T) {
+	t.Run("OtherSessionDeleted", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		conf.SetAuthMode(config.AuthModePasswd)
+		defer conf.SetAuthMode(config.AuthModePublic)
+
+		DeleteSession(router)
+
+		// Log in twice as the same user, e.g. from two different devices.
+		sessId := AuthenticateUser(app, router, "alice", "Alice123!")
+		r := PerformRequestWithBody(app, http.MethodPost, "/api/v1/session", form.AsJson(form.Login{
+			UserName: "alice",
+			Password: "Alice123!",
+		}))
+		otherId := r.Header().Get(session.Header)
+
+		assert.NotEmpty(t, sessId)
+		assert.NotEmpty(t, otherId)
+		assert.NotEqual(t, sessId, otherId)
+
+		sess, err := entity.FindSession(otherId)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// Log out on one of the devices.
+		r = AuthenticatedRequest(app, http.MethodDelete, "/api/v1/session/"+sessId, sessId)
+		assert.Equal(t, http.StatusOK, r.Code)
+
+		// The tokens must remain usable on the other device.
+		for _, token := range []string{sess.PreviewToken, sess.DownloadToken} {
+			c, _ := gin.CreateTestContext(httptest.NewRecorder())
+			c.Request = httptest.NewRequest(http.MethodGet, "/?t="+token, nil)
+
+			s, err := tokenSession(c)
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			assert.NotNil(t, s)
+			assert.Equal(t, sess.UserUID, s.UserUID)
+			assert.False(t, NotShared(c, "ps6sg6be2lvl0yh7"))
+			assert.False(t, NotDownloadable(c, "ps6sg6be2lvl0yh7"))
+		}
+	})
+	t.Run("NoToken", func(t *testing.T) {
+		c, _ := gin.CreateTestContext(httptest.NewRecorder())
+		c.Request = httptest.NewRequest(http.MethodGet, "/", nil)
+
+		s, err := tokenSession(c)
+
+		assert.NoError(t, err)
+		assert.Nil(t, s)
+	})
+}
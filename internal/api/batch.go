@@ -182,7 +182,9 @@ func BatchPhotosApprove(router *gin.RouterGroup) {
 		var approved entity.Photos
 
 		for _, p := range photos {
-			if err = p.Approve(); err != nil {
+			if !AuthOwner(c, s, acl.ResourcePhotos, p.CreatedBy) {
+				continue
+			} else if err = p.Approve(); err != nil {
 				log.Errorf("approve: %s", err)
 			} else {
 				approved = append(approved, p)
@@ -265,7 +267,7 @@ func BatchPhotosPrivate(router *gin.RouterGroup) {
 		log.Infof("photos: updating private flag for %s", clean.Log(f.String()))
 
 		if err := entity.Db().Model(entity.Photo{}).Where("photo_uid IN (?)", f.Photos).UpdateColumn("photo_private",
-			gorm.Expr("CASE WHEN photo_private > 0 THEN 0 ELSE 1 END")).Error; err != nil {
+			gorm.Expr("NOT photo_private")).Error; err != nil {
 			log.Errorf("private: %s", err)
 			AbortSaveFailed(c)
 			return
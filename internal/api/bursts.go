@@ -0,0 +1,114 @@
+package api
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/photoprism/photoprism/internal/acl"
+	"github.com/photoprism/photoprism/internal/event"
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/internal/get"
+	"github.com/photoprism/photoprism/internal/i18n"
+	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/pkg/clean"
+	"github.com/photoprism/photoprism/pkg/phash"
+)
+
+// GetBursts finds bursts and bracketed exposures that may be stacked, with the best picture first.
+//
+// GET /api/v1/bursts
+//
+// Query:
+//
+//	seconds:  int  Maximum time between pictures in seconds (optional)
+//	distance: int  Maximum perceptual hash distance in bits (optional)
+//	path:     string  Originals subfolder (optional)
+func GetBursts(router *gin.RouterGroup) {
+	router.GET("/bursts", func(c *gin.Context) {
+		s := Auth(c, acl.ResourcePhotos, acl.ActionManage)
+
+		if s.Abort(c) {
+			return
+		}
+
+		conf := get.Config()
+
+		seconds, err := strconv.Atoi(c.DefaultQuery("seconds", strconv.Itoa(conf.Settings().StackBurstSeconds())))
+
+		if err != nil || seconds < 0 || seconds > 60 {
+			AbortBadRequest(c)
+			return
+		}
+
+		distance, err := strconv.Atoi(c.DefaultQuery("distance", strconv.Itoa(query.BurstDistance)))
+
+		if err != nil || distance < 0 || distance > phash.MaxDistance {
+			AbortBadRequest(c)
+			return
+		}
+
+		bursts, err := get.Bursts().Find(seconds, distance, clean.UserPath(c.Query("path")))
+
+		if err != nil {
+			log.Errorf("bursts: %s", err)
+			AbortUnexpected(c)
+			return
+		}
+
+		c.JSON(http.StatusOK, bursts)
+	})
+}
+
+// BurstsStack stacks the selected pictures with the best picture as primary.
+//
+// POST /api/v1/bursts/stack
+func BurstsStack(router *gin.RouterGroup) {
+	router.POST("/bursts/stack", func(c *gin.Context) {
+		s := Auth(c, acl.ResourcePhotos, acl.ActionManage)
+
+		if s.Abort(c) {
+			return
+		}
+
+		var f form.Selection
+
+		if err := c.BindJSON(&f); err != nil {
+			AbortBadRequest(c)
+			return
+		}
+
+		if len(f.Photos) < 2 {
+			Abort(c, http.StatusBadRequest, i18n.ErrNoItemsSelected)
+			return
+		}
+
+		burst, err := query.BurstPhotos(f.Photos)
+
+		if err != nil {
+			log.Debugf("bursts: %s", err)
+			AbortEntityNotFound(c)
+			return
+		}
+
+		w := get.Bursts()
+		w.Rank(&burst)
+
+		stacked, err := w.Stack(burst)
+
+		if err != nil {
+			log.Errorf("bursts: %s (stack)", err)
+			AbortSaveFailed(c)
+			return
+		}
+
+		UpdateClientConfig()
+
+		event.EntitiesDeleted("photos", stacked.UIDs())
+
+		PublishPhotoEvent(EntityUpdated, burst.Best().PhotoUID, c)
+
+		c.JSON(http.StatusOK, i18n.NewResponse(http.StatusOK, i18n.MsgSelectionStacked))
+	})
+}
@@ -0,0 +1,63 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tidwall/gjson"
+
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/i18n"
+)
+
+func TestGetBursts(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		GetBursts(router)
+		r := PerformRequest(app, "GET", "/api/v1/bursts?seconds=5")
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.True(t, gjson.Parse(r.Body.String()).IsArray())
+	})
+	t.Run("InvalidSeconds", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		GetBursts(router)
+		r := PerformRequest(app, "GET", "/api/v1/bursts?seconds=3600")
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+	t.Run("InvalidDistance", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		GetBursts(router)
+		r := PerformRequest(app, "GET", "/api/v1/bursts?distance=-1")
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+}
+
+func TestBurstsStack(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		photos := createDuplicates(t, 100, 200)
+		app, router, _ := NewApiTest()
+		BurstsStack(router)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/bursts/stack", fmt.Sprintf(`{"photos": ["%s", "%s"]}`, photos[0].PhotoUID, photos[1].PhotoUID))
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, i18n.Msg(i18n.MsgSelectionStacked), gjson.Get(r.Body.String(), "message").String())
+
+		if p := entity.FindPhoto(entity.Photo{ID: photos[0].ID}); assert.NotNil(t, p) {
+			assert.NotNil(t, p.DeletedAt)
+		}
+	})
+	t.Run("NoItemsSelected", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		BurstsStack(router)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/bursts/stack", `{"photos": ["pt9jtdre2lvl0yh7"]}`)
+		assert.Equal(t, i18n.Msg(i18n.ErrNoItemsSelected), gjson.Get(r.Body.String(), "error").String())
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+	t.Run("NotFound", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		BurstsStack(router)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/bursts/stack", `{"photos": ["pt9jtdre2lvl0xxx", "pt9jtdre2lvl0yyy"]}`)
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+}
@@ -85,7 +85,10 @@ func AlbumCover(router *gin.RouterGroup) {
 
 		fileName := photoprism.FileName(f.FileRoot, f.FileName)
 
-		if !fs.FileExists(fileName) {
+		exists, release := fileExists(f.FileRoot, f.FileName)
+		defer release()
+
+		if !exists {
 			log.Errorf("%s: found no original for %s", albumCover, clean.Log(fileName))
 			c.Data(http.StatusOK, "image/svg+xml", albumIconSvg)
 
@@ -198,7 +201,10 @@ func LabelCover(router *gin.RouterGroup) {
 
 		fileName := photoprism.FileName(f.FileRoot, f.FileName)
 
-		if !fs.FileExists(fileName) {
+		exists, release := fileExists(f.FileRoot, f.FileName)
+		defer release()
+
+		if !exists {
 			log.Errorf("%s: file %s is missing", labelCover, clean.Log(f.FileName))
 			c.Data(http.StatusOK, "image/svg+xml", labelIconSvg)
 
@@ -8,6 +8,8 @@ import (
 
 	"github.com/gin-gonic/gin"
 
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/event"
 	"github.com/photoprism/photoprism/internal/get"
 	"github.com/photoprism/photoprism/internal/i18n"
 	"github.com/photoprism/photoprism/internal/photoprism"
@@ -15,7 +17,6 @@ import (
 	"github.com/photoprism/photoprism/internal/search"
 
 	"github.com/photoprism/photoprism/pkg/clean"
-	"github.com/photoprism/photoprism/pkg/fs"
 )
 
 // DownloadAlbum streams the album contents as zip archive.
@@ -50,6 +51,32 @@ func DownloadAlbum(router *gin.RouterGroup) {
 			return
 		}
 
+		// Shared albums can only be downloaded if the share link permits it and the download limit has not been reached.
+		if sess, err := tokenSession(c); err != nil {
+			AbortForbidden(c)
+			return
+		} else if search.SharedOnly(sess) {
+			if !sess.DownloadUIDs().Contains(a.AlbumUID) {
+				event.AuditWarn([]string{ClientIP(c), "session %s", "download album %s", "denied"}, sess.RefID, clean.Log(a.AlbumUID))
+				AbortForbidden(c)
+				return
+			}
+
+			sess.CountDownload(a.AlbumUID)
+		}
+
+		// Only download files in the user's library unless the album was shared.
+		var sess *entity.Session
+
+		if !entity.PrivateLibraries {
+			// Access is not limited.
+		} else if sess, err = tokenSession(c); err != nil {
+			AbortForbidden(c)
+			return
+		} else if sess != nil && sess.HasShare(a.AlbumUID) {
+			sess = nil
+		}
+
 		zipFileName := a.ZipName()
 
 		AddDownloadHeader(c, zipFileName)
@@ -71,6 +98,9 @@ func DownloadAlbum(router *gin.RouterGroup) {
 			if file.FileSidecar {
 				log.Debugf("download: skipped sidecar %s", clean.Log(file.FileName))
 				continue
+			} else if !search.InLibrary(sess, file.PhotoUID) {
+				log.Debugf("download: skipped %s, not in library", clean.Log(file.FileName))
+				continue
 			}
 
 			fileName := photoprism.FileName(file.FileRoot, file.FileName)
@@ -83,8 +113,11 @@ func DownloadAlbum(router *gin.RouterGroup) {
 
 			aliases[key] += 1
 
-			if fs.FileExists(fileName) {
-				if err := addFileToZip(zipWriter, fileName, alias); err != nil {
+			if exists, release := fileExists(file.FileRoot, file.FileName); exists {
+				err := addFileToZip(zipWriter, fileName, alias)
+				release()
+
+				if err != nil {
 					log.Errorf("download: failed adding %s to album zip (%s)", clean.Log(file.FileName), err)
 					Abort(c, http.StatusInternalServerError, i18n.ErrZipFailed)
 					return
@@ -54,11 +54,23 @@ func GetDownload(router *gin.RouterGroup) {
 		if err != nil {
 			c.AbortWithStatusJSON(404, gin.H{"error": err.Error()})
 			return
+		} else if NotInLibrary(c, f.PhotoUID) || NotDownloadable(c, f.PhotoUID) {
+			c.Data(http.StatusForbidden, "image/svg+xml", brokenIconSvg)
+			return
 		}
 
 		fileName := photoprism.FileName(f.FileRoot, f.FileName)
 
-		if !fs.FileExists(fileName) {
+		if remoteOriginal(f.FileRoot, f.FileName) {
+			if err = sendOriginal(c, f.FileName, f.DownloadName(DownloadName(c), 0)); err == nil {
+				return
+			}
+
+			log.Errorf("download: %s (stream %s)", err, clean.Log(f.FileName))
+			c.Data(404, "image/svg+xml", brokenIconSvg)
+
+			return
+		} else if !fs.FileExists(fileName) {
 			log.Errorf("download: file %s is missing", clean.Log(f.FileName))
 			c.Data(404, "image/svg+xml", brokenIconSvg)
 
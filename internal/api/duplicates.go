@@ -0,0 +1,145 @@
+package api
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/photoprism/photoprism/internal/acl"
+	"github.com/photoprism/photoprism/internal/event"
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/internal/get"
+	"github.com/photoprism/photoprism/internal/i18n"
+	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/pkg/clean"
+	"github.com/photoprism/photoprism/pkg/phash"
+)
+
+// GetDuplicates finds groups of visually similar pictures with the best copy first.
+//
+// GET /api/v1/duplicates
+//
+// Query:
+//
+//	distance: int  Maximum perceptual hash distance in bits (optional)
+//	path:     string  Originals subfolder (optional)
+func GetDuplicates(router *gin.RouterGroup) {
+	router.GET("/duplicates", func(c *gin.Context) {
+		s := Auth(c, acl.ResourcePhotos, acl.ActionManage)
+
+		if s.Abort(c) {
+			return
+		}
+
+		distance, err := strconv.Atoi(c.DefaultQuery("distance", strconv.Itoa(phash.DefaultDistance)))
+
+		if err != nil || distance < 0 || distance > phash.MaxDistance {
+			AbortBadRequest(c)
+			return
+		}
+
+		groups, err := get.Duplicates().Similar(distance, clean.UserPath(c.Query("path")))
+
+		if err != nil {
+			log.Errorf("duplicates: %s", err)
+			AbortUnexpected(c)
+			return
+		}
+
+		c.JSON(http.StatusOK, groups)
+	})
+}
+
+// DuplicatesStack stacks the selected pictures with the best copy.
+//
+// POST /api/v1/duplicates/stack
+func DuplicatesStack(router *gin.RouterGroup) {
+	router.POST("/duplicates/stack", func(c *gin.Context) {
+		s := Auth(c, acl.ResourcePhotos, acl.ActionManage)
+
+		if s.Abort(c) {
+			return
+		}
+
+		similar, ok := similarSelection(c)
+
+		if !ok {
+			return
+		}
+
+		stacked, err := get.Duplicates().Stack(similar)
+
+		if err != nil {
+			log.Errorf("duplicates: %s (stack)", err)
+			AbortSaveFailed(c)
+			return
+		}
+
+		UpdateClientConfig()
+
+		event.EntitiesDeleted("photos", stacked.UIDs())
+
+		PublishPhotoEvent(EntityUpdated, similar.Best().PhotoUID, c)
+
+		c.JSON(http.StatusOK, i18n.NewResponse(http.StatusOK, i18n.MsgSelectionStacked))
+	})
+}
+
+// DuplicatesArchive archives all selected pictures except the best copy.
+//
+// POST /api/v1/duplicates/archive
+func DuplicatesArchive(router *gin.RouterGroup) {
+	router.POST("/duplicates/archive", func(c *gin.Context) {
+		s := Auth(c, acl.ResourcePhotos, acl.ActionManage)
+
+		if s.Abort(c) {
+			return
+		}
+
+		similar, ok := similarSelection(c)
+
+		if !ok {
+			return
+		}
+
+		archived, err := get.Duplicates().Archive(similar)
+
+		if err != nil {
+			log.Errorf("duplicates: %s (archive)", err)
+			AbortSaveFailed(c)
+			return
+		}
+
+		UpdateClientConfig()
+
+		event.EntitiesArchived("photos", archived.UIDs())
+
+		c.JSON(http.StatusOK, i18n.NewResponse(http.StatusOK, i18n.MsgSelectionArchived))
+	})
+}
+
+// similarSelection returns the selected pictures as a group of similar pictures, or aborts the request.
+func similarSelection(c *gin.Context) (result query.Similar, ok bool) {
+	var f form.Selection
+
+	if err := c.BindJSON(&f); err != nil {
+		AbortBadRequest(c)
+		return result, false
+	}
+
+	if len(f.Photos) < 2 {
+		Abort(c, http.StatusBadRequest, i18n.ErrNoItemsSelected)
+		return result, false
+	}
+
+	result, err := query.SimilarPhotos(f.Photos)
+
+	if err != nil {
+		log.Debugf("duplicates: %s", err)
+		AbortEntityNotFound(c)
+		return result, false
+	}
+
+	return result, true
+}
@@ -0,0 +1,113 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tidwall/gjson"
+
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/i18n"
+)
+
+// createDuplicates creates photos with a primary file each for testing.
+func createDuplicates(t *testing.T, widths ...int) (photos entity.Photos) {
+	for _, w := range widths {
+		p := entity.NewPhoto(false)
+
+		if err := p.Save(); err != nil {
+			t.Fatal(err)
+		}
+
+		f := entity.File{
+			PhotoID:     p.ID,
+			PhotoUID:    p.PhotoUID,
+			FileName:    "api-duplicates/" + p.PhotoUID + ".jpg",
+			FileRoot:    entity.RootOriginals,
+			FileType:    "jpg",
+			MediaType:   entity.MediaImage,
+			FilePrimary: true,
+			FileWidth:   w,
+			FileHeight:  w,
+		}
+
+		if err := f.Create(); err != nil {
+			t.Fatal(err)
+		}
+
+		photos = append(photos, p)
+	}
+
+	return photos
+}
+
+func TestGetDuplicates(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		GetDuplicates(router)
+		r := PerformRequest(app, "GET", "/api/v1/duplicates?distance=4")
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.True(t, gjson.Parse(r.Body.String()).IsArray())
+	})
+	t.Run("InvalidDistance", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		GetDuplicates(router)
+		r := PerformRequest(app, "GET", "/api/v1/duplicates?distance=65")
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+}
+
+func TestDuplicatesStack(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		photos := createDuplicates(t, 100, 200)
+		app, router, _ := NewApiTest()
+		DuplicatesStack(router)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/duplicates/stack", fmt.Sprintf(`{"photos": ["%s", "%s"]}`, photos[0].PhotoUID, photos[1].PhotoUID))
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, i18n.Msg(i18n.MsgSelectionStacked), gjson.Get(r.Body.String(), "message").String())
+
+		if p := entity.FindPhoto(entity.Photo{ID: photos[0].ID}); assert.NotNil(t, p) {
+			assert.NotNil(t, p.DeletedAt)
+		}
+	})
+	t.Run("NoItemsSelected", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		DuplicatesStack(router)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/duplicates/stack", `{"photos": ["pt9jtdre2lvl0yh7"]}`)
+		assert.Equal(t, i18n.Msg(i18n.ErrNoItemsSelected), gjson.Get(r.Body.String(), "error").String())
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+	t.Run("InvalidRequest", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		DuplicatesStack(router)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/duplicates/stack", `{"photos": 123}`)
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+}
+
+func TestDuplicatesArchive(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		photos := createDuplicates(t, 200, 100)
+		app, router, _ := NewApiTest()
+		DuplicatesArchive(router)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/duplicates/archive", fmt.Sprintf(`{"photos": ["%s", "%s"]}`, photos[0].PhotoUID, photos[1].PhotoUID))
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, i18n.Msg(i18n.MsgSelectionArchived), gjson.Get(r.Body.String(), "message").String())
+
+		if p := entity.FindPhoto(entity.Photo{ID: photos[0].ID}); assert.NotNil(t, p) {
+			assert.Nil(t, p.DeletedAt)
+		}
+
+		if p := entity.FindPhoto(entity.Photo{ID: photos[1].ID}); assert.NotNil(t, p) {
+			assert.NotNil(t, p.DeletedAt)
+		}
+	})
+	t.Run("NotFound", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		DuplicatesArchive(router)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/duplicates/archive", `{"photos": ["pt9jtdre2lvl0xxx", "pt9jtdre2lvl0yyy"]}`)
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+}
@@ -94,7 +94,10 @@ func FolderCover(router *gin.RouterGroup) {
 
 		fileName := photoprism.FileName(f.FileRoot, f.FileName)
 
-		if !fs.FileExists(fileName) {
+		exists, release := fileExists(f.FileRoot, f.FileName)
+		defer release()
+
+		if !exists {
 			log.Errorf("%s: could not find original for %s", folderCover, fileName)
 			c.Data(http.StatusOK, "image/svg+xml", folderIconSvg)
 
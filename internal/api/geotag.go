@@ -0,0 +1,164 @@
+package api
+
+import (
+	"net/http"
+	"path/filepath"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+
+	"github.com/photoprism/photoprism/internal/acl"
+	"github.com/photoprism/photoprism/internal/event"
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/internal/get"
+	"github.com/photoprism/photoprism/internal/i18n"
+	"github.com/photoprism/photoprism/internal/mutex"
+	"github.com/photoprism/photoprism/internal/photoprism"
+	"github.com/photoprism/photoprism/pkg/clean"
+	"github.com/photoprism/photoprism/pkg/geo"
+)
+
+// Geotag sets the location of photos based on uploaded GPX, KML, or GeoJSON tracks.
+//
+// POST /api/v1/geotag
+//
+// Form Fields:
+//
+//	files: The track files
+//	offset: int Camera clock offset in seconds, i.e. the camera time minus the actual time
+//	maxGap: int Maximum time between recorded positions in seconds
+//	store: bool Store the tracks for display on the map
+func Geotag(router *gin.RouterGroup) {
+	router.POST("/geotag", func(c *gin.Context) {
+		s := Auth(c, acl.ResourcePlaces, acl.ActionUpdate)
+
+		if s.Abort(c) {
+			return
+		}
+
+		// Abort if another worker is running.
+		if mutex.MainWorker.Running() {
+			AbortBusy(c)
+			return
+		}
+
+		var f form.Geotag
+
+		if err := c.ShouldBindWith(&f, binding.FormMultipart); err != nil {
+			AbortBadRequest(c)
+			return
+		}
+
+		mf, err := c.MultipartForm()
+
+		if err != nil {
+			log.Errorf("geotag: %s", err)
+			AbortBadRequest(c)
+			return
+		}
+
+		files := mf.File["files"]
+
+		if len(files) == 0 {
+			AbortBadRequest(c)
+			return
+		}
+
+		conf := get.Config()
+
+		var track geo.Track
+
+		for _, file := range files {
+			fileName := filepath.Base(file.Filename)
+			format := geo.TrackFormat(fileName)
+
+			if format == "" {
+				log.Warnf("geotag: unsupported file format %s", clean.Log(fileName))
+				continue
+			}
+
+			r, openErr := file.Open()
+
+			if openErr != nil {
+				log.Errorf("geotag: %s", openErr)
+				continue
+			}
+
+			t, readErr := geo.ReadTrack(r, format)
+			_ = r.Close()
+
+			if readErr != nil {
+				log.Warnf("geotag: %s in %s", readErr, clean.Log(fileName))
+				continue
+			}
+
+			if f.Store {
+				if _, saveErr := photoprism.SaveTrack(conf.TracksPath(), fileName, t); saveErr != nil {
+					log.Errorf("geotag: %s while storing %s", saveErr, clean.Log(fileName))
+				}
+			}
+
+			track = track.Merge(t)
+		}
+
+		if track.Empty() {
+			AbortBadRequest(c)
+			return
+		}
+
+		opt := photoprism.NewGeotagOptions(time.Duration(f.Offset)*time.Second, time.Duration(f.MaxGap)*time.Second)
+
+		updated, err := get.Geotag().Start(track, opt)
+
+		if err != nil {
+			log.Errorf("geotag: %s", err)
+			AbortUnexpected(c)
+			return
+		}
+
+		event.SuccessMsg(i18n.MsgChangesSaved)
+
+		if len(updated) > 0 {
+			UpdateClientConfig()
+		}
+
+		c.JSON(http.StatusOK, gin.H{"code": http.StatusOK, "message": i18n.Msg(i18n.MsgChangesSaved), "photos": updated, "positions": len(track)})
+	})
+}
+
+// GetTracks returns the stored tracks as GeoJSON feature collection, so that they can be displayed on the map.
+//
+// GET /api/v1/geo/tracks
+func GetTracks(router *gin.RouterGroup) {
+	router.GET("/geo/tracks", func(c *gin.Context) {
+		s := AuthAny(c, acl.ResourcePlaces, acl.Permissions{acl.ActionSearch, acl.ActionView})
+
+		if s.Abort(c) {
+			return
+		}
+
+		// Tracks may reveal home and travel routes, so they are not visible to guests and link visitors.
+		if acl.Resources.DenyAll(acl.ResourcePlaces, s.User().AclRole(), acl.Permissions{acl.AccessAll, acl.AccessLibrary}) {
+			AbortForbidden(c)
+			return
+		}
+
+		conf := get.Config()
+
+		if conf.DisablePlaces() {
+			AbortFeatureDisabled(c)
+			return
+		}
+
+		data, err := photoprism.TracksGeoJSON(conf.TracksPath())
+
+		if err != nil {
+			log.Errorf("geotag: %s", err)
+			AbortUnexpected(c)
+			return
+		}
+
+		c.Data(http.StatusOK, "application/json", data)
+	})
+}
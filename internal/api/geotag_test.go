@@ -0,0 +1,93 @@
+package api
+
+import (
+	"bytes"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tidwall/gjson"
+)
+
+const testTrackGPX = `<?xml version="1.0" encoding="UTF-8"?>
+<gpx version="1.1" creator="Test" xmlns="http://www.topografix.com/GPX/1/1">
+  <trk><trkseg>
+    <trkpt lat="52.5200" lon="13.4200"><time>1800-01-01T10:00:00Z</time></trkpt>
+    <trkpt lat="52.5300" lon="13.4300"><time>1800-01-01T10:05:00Z</time></trkpt>
+  </trkseg></trk>
+</gpx>`
+
+func geotagRequest(fields map[string]string, files map[string]string) *http.Request {
+	body := &bytes.Buffer{}
+	w := multipart.NewWriter(body)
+
+	for k, v := range fields {
+		_ = w.WriteField(k, v)
+	}
+
+	for name, data := range files {
+		part, _ := w.CreateFormFile("files", name)
+		_, _ = part.Write([]byte(data))
+	}
+
+	_ = w.Close()
+
+	req, _ := http.NewRequest("POST", "/api/v1/geotag", body)
+	req.Header.Set("Content-Type", w.FormDataContentType())
+
+	return req
+}
+
+func TestGeotag(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		Geotag(router)
+		GetTracks(router)
+
+		defer os.RemoveAll(conf.TracksPath())
+
+		w := httptest.NewRecorder()
+		app.ServeHTTP(w, geotagRequest(map[string]string{"offset": "60", "maxGap": "300", "store": "true"}, map[string]string{"hike.gpx": testTrackGPX}))
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, int64(2), gjson.Get(w.Body.String(), "positions").Int())
+		assert.Equal(t, 0, len(gjson.Get(w.Body.String(), "photos").Array()))
+
+		r := PerformRequest(app, "GET", "/api/v1/geo/tracks")
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, "FeatureCollection", gjson.Get(r.Body.String(), "type").String())
+		assert.Equal(t, "hike.gpx", gjson.Get(r.Body.String(), "features.0.properties.name").String())
+	})
+	t.Run("UnsupportedFormat", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		Geotag(router)
+
+		w := httptest.NewRecorder()
+		app.ServeHTTP(w, geotagRequest(nil, map[string]string{"hike.txt": testTrackGPX}))
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+	t.Run("NoFiles", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		Geotag(router)
+
+		w := httptest.NewRecorder()
+		app.ServeHTTP(w, geotagRequest(map[string]string{"offset": "0"}, nil))
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
+
+func TestGetTracks(t *testing.T) {
+	t.Run("Empty", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		GetTracks(router)
+
+		r := PerformRequest(app, "GET", "/api/v1/geo/tracks")
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, `{"type":"FeatureCollection","features":[]}`, r.Body.String())
+	})
+}
@@ -24,7 +24,7 @@ import (
 // POST /api/v1/index
 func StartIndexing(router *gin.RouterGroup) {
 	router.POST("/index", func(c *gin.Context) {
-		s := Auth(c, acl.ResourcePhotos, acl.ActionUpdate)
+		s := Auth(c, acl.ResourceFiles, acl.ActionManage)
 
 		if s.Abort(c) {
 			return
@@ -147,7 +147,7 @@ func StartIndexing(router *gin.RouterGroup) {
 // DELETE /api/v1/index
 func CancelIndexing(router *gin.RouterGroup) {
 	router.DELETE("/index", func(c *gin.Context) {
-		s := Auth(c, acl.ResourcePhotos, acl.ActionUpdate)
+		s := Auth(c, acl.ResourceFiles, acl.ActionManage)
 
 		if s.Abort(c) {
 			return
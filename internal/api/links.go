@@ -9,7 +9,9 @@ import (
 	"github.com/photoprism/photoprism/internal/acl"
 	"github.com/photoprism/photoprism/internal/entity"
 	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/internal/i18n"
 	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/internal/search"
 	"github.com/photoprism/photoprism/pkg/clean"
 	"github.com/photoprism/photoprism/pkg/txt"
 )
@@ -35,9 +37,18 @@ func UpdateLink(c *gin.Context) {
 
 	link := entity.FindLink(clean.Token(c.Param("link")))
 
+	if link == nil || link.ShareUID != clean.UID(c.Param("uid")) {
+		AbortEntityNotFound(c)
+		return
+	}
+
 	link.SetSlug(f.ShareSlug)
 	link.MaxViews = f.MaxViews
+	link.MaxDownloads = f.MaxDownloads
+	link.MaxUploads = f.MaxUploads
+	link.MaxUploadSize = f.MaxUploadSize
 	link.LinkExpires = f.LinkExpires
+	link.Perm = f.Perm
 
 	if f.LinkToken != "" {
 		link.LinkToken = strings.TrimSpace(strings.ToLower(f.LinkToken))
@@ -57,7 +68,7 @@ func UpdateLink(c *gin.Context) {
 
 	UpdateClientConfig()
 
-	PublishAlbumEvent(EntityUpdated, link.ShareUID, c)
+	PublishShareEvent(EntityUpdated, link, c)
 
 	c.JSON(http.StatusOK, link)
 }
@@ -75,6 +86,11 @@ func DeleteLink(c *gin.Context) {
 
 	link := entity.FindLink(clean.Token(c.Param("link")))
 
+	if link == nil || link.ShareUID != clean.UID(c.Param("uid")) {
+		AbortEntityNotFound(c)
+		return
+	}
+
 	if err := link.Delete(); err != nil {
 		c.AbortWithStatusJSON(http.StatusConflict, gin.H{"error": txt.UpperFirst(err.Error())})
 		return
@@ -82,7 +98,7 @@ func DeleteLink(c *gin.Context) {
 
 	UpdateClientConfig()
 
-	PublishAlbumEvent(EntityUpdated, link.ShareUID, c)
+	PublishShareEvent(EntityUpdated, link, c)
 
 	c.JSON(http.StatusOK, link)
 }
@@ -116,7 +132,11 @@ func CreateLink(c *gin.Context) {
 
 	link.SetSlug(f.ShareSlug)
 	link.MaxViews = f.MaxViews
+	link.MaxDownloads = f.MaxDownloads
+	link.MaxUploads = f.MaxUploads
+	link.MaxUploadSize = f.MaxUploadSize
 	link.LinkExpires = f.LinkExpires
+	link.Perm = f.Perm
 
 	if f.Password != "" {
 		if err := link.SetPassword(f.Password); err != nil {
@@ -132,11 +152,23 @@ func CreateLink(c *gin.Context) {
 
 	UpdateClientConfig()
 
-	PublishAlbumEvent(EntityUpdated, link.ShareUID, c)
+	PublishShareEvent(EntityUpdated, &link, c)
 
 	c.JSON(http.StatusOK, link)
 }
 
+// PublishShareEvent publishes updated data of the entity shared with the link.
+func PublishShareEvent(ev EntityEvent, link *entity.Link, c *gin.Context) {
+	switch link.ShareType() {
+	case "album":
+		PublishAlbumEvent(ev, link.ShareUID, c)
+	case "photo":
+		PublishPhotoEvent(ev, link.ShareUID, c)
+	case "label":
+		PublishLabelEvent(ev, link.ShareUID, c)
+	}
+}
+
 // CreateAlbumLink adds a new album share link and return it as JSON.
 //
 // POST /api/v1/albums/:uid/links
@@ -209,8 +241,6 @@ func GetAlbumLinks(router *gin.RouterGroup) {
 	})
 }
 
-/*
-
 // CreatePhotoLink adds a new photo share link and return it as JSON.
 //
 // POST /api/v1/photos/:uid/links
@@ -222,7 +252,10 @@ func CreatePhotoLink(router *gin.RouterGroup) {
 			return
 		}
 
-		if _, err := query.PhotoByUID(clean.UID(c.Param("uid"))); err != nil {
+		uid := clean.UID(c.Param("uid"))
+
+		// Only photos in the user's library can be shared.
+		if _, err := query.PhotoByUID(uid); err != nil || !search.InLibrary(s, uid) {
 			AbortEntityNotFound(c)
 			return
 		}
@@ -275,7 +308,7 @@ func GetPhotoLinks(router *gin.RouterGroup) {
 		m, err := query.PhotoByUID(clean.UID(c.Param("uid")))
 
 		if err != nil {
-			AbortAlbumNotFound(c)
+			AbortEntityNotFound(c)
 			return
 		}
 
@@ -347,11 +380,10 @@ func GetLabelLinks(router *gin.RouterGroup) {
 		m, err := query.LabelByUID(clean.UID(c.Param("uid")))
 
 		if err != nil {
-			AbortAlbumNotFound(c)
+			Abort(c, http.StatusNotFound, i18n.ErrLabelNotFound)
 			return
 		}
 
 		c.JSON(http.StatusOK, m.Links())
 	})
 }
-*/
@@ -34,6 +34,28 @@ func TestCreateAlbumLink(t *testing.T) {
 		assert.NotEmpty(t, link.LinkToken)
 		assert.Equal(t, 0, link.LinkExpires)
 	})
+	t.Run("download limit", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+
+		var link entity.Link
+
+		CreateAlbumLink(router)
+
+		resp := PerformRequestWithBody(app, "POST", "/api/v1/albums/at9lxuqxpogaaba7/links", `{"Password": "foobar", "MaxViews": 5, "MaxDownloads": 2}`)
+
+		if resp.Code != http.StatusOK {
+			t.Fatal(resp.Body.String())
+		}
+
+		if err := json.Unmarshal(resp.Body.Bytes(), &link); err != nil {
+			t.Fatal(err)
+		}
+
+		assert.True(t, link.HasPassword)
+		assert.Equal(t, uint(5), link.MaxViews)
+		assert.Equal(t, uint(2), link.MaxDownloads)
+		assert.Equal(t, uint(0), link.LinkDownloads)
+	})
 	t.Run("album does not exist", func(t *testing.T) {
 		app, router, _ := NewApiTest()
 		CreateAlbumLink(router)
@@ -146,7 +168,6 @@ func TestGetAlbumLinks(t *testing.T) {
 	})
 }
 
-/*
 func TestCreatePhotoLink(t *testing.T) {
 	t.Run("create share link", func(t *testing.T) {
 		app, router, _ := NewApiTest()
@@ -155,7 +176,7 @@ func TestCreatePhotoLink(t *testing.T) {
 
 		CreatePhotoLink(router)
 
-		resp := PerformRequestWithBody(app, "POST", "/api/v1/photos/pt9jtdre2lvl0yh7/links", `{"Password":"foobar","Expires":0,"CanEdit":true}`)
+		resp := PerformRequestWithBody(app, "POST", "/api/v1/photos/pt9jtdre2lvl0yh7/links", `{"Password": "foobar", "Expires": 0, "Perm": 256}`)
 		log.Debugf("BODY: %s", resp.Body.String())
 		assert.Equal(t, http.StatusOK, resp.Code)
 
@@ -167,8 +188,7 @@ func TestCreatePhotoLink(t *testing.T) {
 		assert.NotEmpty(t, link.ShareUID)
 		assert.NotEmpty(t, link.LinkToken)
 		assert.Equal(t, 0, link.LinkExpires)
-		assert.False(t, link.CanComment)
-		assert.True(t, link.CanEdit)
+		assert.Equal(t, entity.PermDownload, link.Perm)
 	})
 	t.Run("photo not found", func(t *testing.T) {
 		app, router, _ := NewApiTest()
@@ -285,7 +305,7 @@ func TestCreateLabelLink(t *testing.T) {
 
 		CreateLabelLink(router)
 
-		resp := PerformRequestWithBody(app, "POST", "/api/v1/labels/lt9k3pw1wowuy3c2/links", `{"Password": "foobar", "Expires": 0, "CanEdit": true}`)
+		resp := PerformRequestWithBody(app, "POST", "/api/v1/labels/lt9k3pw1wowuy3c2/links", `{"Password": "foobar", "Expires": 0, "Perm": 256}`)
 		assert.Equal(t, http.StatusOK, resp.Code)
 
 		if err := json.Unmarshal(resp.Body.Bytes(), &link); err != nil {
@@ -296,8 +316,7 @@ func TestCreateLabelLink(t *testing.T) {
 		assert.NotEmpty(t, link.ShareUID)
 		assert.NotEmpty(t, link.LinkToken)
 		assert.Equal(t, 0, link.LinkExpires)
-		assert.False(t, link.CanComment)
-		assert.True(t, link.CanEdit)
+		assert.Equal(t, entity.PermDownload, link.Perm)
 	})
 	t.Run("label not found", func(t *testing.T) {
 		app, router, _ := NewApiTest()
@@ -402,4 +421,3 @@ func TestGetLabelLinks(t *testing.T) {
 		assert.Equal(t, http.StatusNotFound, r.Code)
 	})
 }
-*/
@@ -143,6 +143,8 @@ func UpdateMarker(router *gin.RouterGroup) {
 		} else if err := p.UpdateAndSaveTitle(); err != nil {
 			log.Errorf("faces: %s (update photo title)", err)
 		} else {
+			SavePhotoAsXmp(p)
+
 			// Notify clients.
 			PublishPhotoEvent(EntityUpdated, file.PhotoUID, c)
 		}
@@ -202,6 +204,8 @@ func ClearMarkerSubject(router *gin.RouterGroup) {
 		} else if err := p.UpdateAndSaveTitle(); err != nil {
 			log.Errorf("faces: %s (update photo title)", err)
 		} else {
+			SavePhotoAsXmp(p)
+
 			// Notify clients.
 			PublishPhotoEvent(EntityUpdated, file.PhotoUID, c)
 		}
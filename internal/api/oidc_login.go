@@ -0,0 +1,43 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/photoprism/photoprism/internal/event"
+	"github.com/photoprism/photoprism/internal/get"
+	"github.com/photoprism/photoprism/internal/server/limiter"
+)
+
+// OIDCLogin redirects the browser to the OpenID Connect provider for authentication.
+//
+// GET /api/v1/oidc/login
+func OIDCLogin(router *gin.RouterGroup) {
+	router.GET("/oidc/login", func(c *gin.Context) {
+		conf := get.Config()
+
+		// Single sign-on enabled?
+		if !conf.OIDCEnabled() {
+			AbortFeatureDisabled(c)
+			return
+		}
+
+		// Check limit for failed auth requests (max. 10 per minute).
+		if limiter.Login.Reject(ClientIP(c)) {
+			limiter.Abort(c)
+			return
+		}
+
+		// Set state and code verifier cookies and get the provider URL.
+		authUrl, err := get.OIDC().AuthUrl(c.Writer)
+
+		if err != nil {
+			event.AuditErr([]string{ClientIP(c), "oidc", "%s"}, err)
+			AbortUnexpected(c)
+			return
+		}
+
+		c.Redirect(http.StatusFound, authUrl)
+	})
+}
@@ -0,0 +1,53 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/config"
+)
+
+func TestOIDCLogin(t *testing.T) {
+	t.Run("Disabled", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		OIDCLogin(router)
+		r := PerformRequest(app, http.MethodGet, "/api/v1/oidc/login")
+		assert.Equal(t, http.StatusForbidden, r.Code)
+	})
+	t.Run("Redirect", func(t *testing.T) {
+		var issuer *httptest.Server
+
+		issuer = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{
+				"issuer":                 issuer.URL,
+				"authorization_endpoint": issuer.URL + "/authorize",
+				"token_endpoint":         issuer.URL + "/oauth/token",
+				"userinfo_endpoint":      issuer.URL + "/userinfo",
+				"jwks_uri":               issuer.URL + "/keys",
+			})
+		}))
+		defer issuer.Close()
+
+		app, router, conf := NewApiTest()
+		conf.SetAuthMode(config.AuthModePasswd)
+		conf.Options().OIDCUri = issuer.URL
+		conf.Options().OIDCClient = "photoprism"
+		defer func() {
+			conf.SetAuthMode(config.AuthModePublic)
+			conf.Options().OIDCUri = ""
+			conf.Options().OIDCClient = ""
+		}()
+
+		OIDCLogin(router)
+		r := PerformRequest(app, http.MethodGet, "/api/v1/oidc/login")
+		assert.Equal(t, http.StatusFound, r.Code)
+		assert.True(t, strings.HasPrefix(r.Header().Get("Location"), issuer.URL+"/authorize?"))
+		assert.Len(t, r.Result().Cookies(), 2)
+	})
+}
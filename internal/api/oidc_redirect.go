@@ -0,0 +1,90 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/photoprism/photoprism/internal/event"
+	"github.com/photoprism/photoprism/internal/get"
+	"github.com/photoprism/photoprism/internal/server/limiter"
+	"github.com/photoprism/photoprism/pkg/authn"
+	"github.com/photoprism/photoprism/pkg/clean"
+)
+
+// OIDCRedirect creates a new client session after the user has been authenticated by the
+// OpenID Connect provider and passes the session id to the web app.
+//
+// GET /api/v1/oidc/redirect
+func OIDCRedirect(router *gin.RouterGroup) {
+	router.GET("/oidc/redirect", func(c *gin.Context) {
+		conf := get.Config()
+
+		// Single sign-on enabled?
+		if !conf.OIDCEnabled() {
+			AbortFeatureDisabled(c)
+			return
+		}
+
+		ip := ClientIP(c)
+
+		// Check limit for failed auth requests (max. 10 per minute).
+		if limiter.Login.Reject(ip) {
+			limiter.Abort(c)
+			return
+		}
+
+		client := get.OIDC()
+
+		// Verify state and get the user identity from the provider.
+		id, err := client.Identity(c.Writer, c.Request)
+
+		if err != nil {
+			limiter.Login.Reserve(ip)
+			event.AuditWarn([]string{ip, "oidc", "login failed", "%s"}, err)
+			event.LoginError(ip, "oidc", "", c.Request.UserAgent(), err.Error())
+			c.Redirect(http.StatusTemporaryRedirect, conf.LoginUri())
+			return
+		}
+
+		// Find, link, or create the user account.
+		user, err := client.User(id)
+
+		if err != nil {
+			limiter.Login.Reserve(ip)
+			event.AuditWarn([]string{ip, "oidc", "login as %s", "%s"}, id.String(), err)
+			event.LoginError(ip, "oidc", id.UserName(), c.Request.UserAgent(), err.Error())
+			c.Redirect(http.StatusTemporaryRedirect, conf.LoginUri())
+			return
+		} else if !user.CanLogIn() {
+			message := "account disabled"
+			event.AuditWarn([]string{ip, "oidc", "login as %s", message}, clean.LogQuote(user.Username()))
+			event.LoginError(ip, "oidc", user.Username(), c.Request.UserAgent(), message)
+			c.Redirect(http.StatusTemporaryRedirect, conf.LoginUri())
+			return
+		}
+
+		// Create new session.
+		sess := get.Session().New(c)
+		sess.SetUser(user)
+		sess.SetProvider(authn.ProviderOIDC)
+
+		if sess, err = get.Session().Save(sess); err != nil {
+			event.AuditErr([]string{ip, "%s"}, err)
+			c.Redirect(http.StatusTemporaryRedirect, conf.LoginUri())
+			return
+		}
+
+		user.UpdateLoginTime()
+
+		event.AuditInfo([]string{ip, "session %s", "login as %s", "succeeded"}, sess.RefID, clean.LogQuote(user.Username()))
+		event.LoginInfo(ip, "oidc", user.Username(), c.Request.UserAgent())
+
+		// Pass the session id to the web app, which stores it and then opens the library.
+		c.Header("Cache-Control", "no-store")
+		c.HTML(http.StatusOK, "share.gohtml", gin.H{
+			"shared": gin.H{"session": sess.ID, "uri": conf.BaseUri("/library/browse")},
+			"config": conf.ClientPublic(),
+		})
+	})
+}
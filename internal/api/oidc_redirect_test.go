@@ -0,0 +1,17 @@
+package api
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOIDCRedirect(t *testing.T) {
+	t.Run("Disabled", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		OIDCRedirect(router)
+		r := PerformRequest(app, http.MethodGet, "/api/v1/oidc/redirect?code=123&state=abc")
+		assert.Equal(t, http.StatusForbidden, r.Code)
+	})
+}
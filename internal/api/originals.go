@@ -0,0 +1,92 @@
+package api
+
+import (
+	"io"
+	"mime"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/get"
+	"github.com/photoprism/photoprism/internal/photoprism"
+	"github.com/photoprism/photoprism/pkg/clean"
+	"github.com/photoprism/photoprism/pkg/fs"
+)
+
+// remoteOriginal checks if the file is an original stored in a remote bucket without a local copy,
+// or with a copy that was fetched on demand and will be removed after use.
+func remoteOriginal(fileRoot, relName string) bool {
+	if fileRoot != entity.RootOriginals || !get.Config().S3Originals() {
+		return false
+	}
+
+	return !fs.FileExists(photoprism.FileName(fileRoot, relName)) || get.Originals().Staged(relName)
+}
+
+// fetchOriginal stores a local copy of an original from the remote bucket, e.g. to create thumbnails,
+// and returns a function that must be called to remove the copy once it is no longer needed.
+func fetchOriginal(relName string) (release func(), ok bool) {
+	_, release, err := get.Originals().Stage(relName)
+
+	if err != nil {
+		log.Warnf("originals: %s (fetch %s)", err, clean.Log(relName))
+		return release, false
+	}
+
+	return release, true
+}
+
+// fileExists checks if an indexed file exists and fetches remote originals on demand. The returned
+// function must be called to remove fetched copies once the file is no longer needed.
+func fileExists(fileRoot, relName string) (exists bool, release func()) {
+	if remoteOriginal(fileRoot, relName) {
+		release, exists = fetchOriginal(relName)
+		return exists, release
+	}
+
+	return fs.FileExists(photoprism.FileName(fileRoot, relName)), func() {}
+}
+
+// sendOriginal streams an original from the remote bucket as attachment without storing a local copy.
+// Range requests are served with ranged reads if supported by the storage backend.
+func sendOriginal(c *gin.Context, relName, downloadName string) error {
+	s := get.Originals().Storage()
+
+	obj, err := s.Stat(relName)
+
+	if err != nil {
+		return err
+	}
+
+	r, err := s.Open(relName)
+
+	if err != nil {
+		return err
+	}
+
+	defer r.Close()
+
+	contentType := obj.ContentType
+
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+
+	disposition := mime.FormatMediaType("attachment", map[string]string{"filename": downloadName})
+
+	if rs, ok := r.(io.ReadSeeker); ok {
+		c.Header("Content-Disposition", disposition)
+		c.Header("Content-Type", contentType)
+		http.ServeContent(c.Writer, c.Request, downloadName, obj.ModTime, rs)
+		return nil
+	}
+
+	extraHeaders := map[string]string{
+		"Content-Disposition": disposition,
+	}
+
+	c.DataFromReader(http.StatusOK, obj.Size, contentType, r, extraHeaders)
+
+	return nil
+}
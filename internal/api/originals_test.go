@@ -0,0 +1,21 @@
+package api
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/entity"
+)
+
+func TestRemoteOriginal(t *testing.T) {
+	assert.False(t, remoteOriginal(entity.RootOriginals, "missing/file.jpg"))
+	assert.False(t, remoteOriginal(entity.RootSidecar, "missing/file.jpg"))
+}
+
+func TestFileExists(t *testing.T) {
+	exists, release := fileExists(entity.RootOriginals, "missing/file.jpg")
+	assert.False(t, exists)
+	assert.NotNil(t, release)
+	release()
+}
@@ -34,6 +34,9 @@ func AddPhotoLabel(router *gin.RouterGroup) {
 		if err != nil {
 			AbortEntityNotFound(c)
 			return
+		} else if !AuthOwner(c, s, acl.ResourcePhotos, m.CreatedBy) {
+			AbortForbidden(c)
+			return
 		}
 
 		var f form.Label
@@ -82,6 +85,8 @@ func AddPhotoLabel(router *gin.RouterGroup) {
 			return
 		}
 
+		SavePhotoAsXmp(p)
+
 		PublishPhotoEvent(EntityUpdated, c.Param("uid"), c)
 
 		event.Success("label updated")
@@ -109,6 +114,9 @@ func RemovePhotoLabel(router *gin.RouterGroup) {
 		if err != nil {
 			AbortEntityNotFound(c)
 			return
+		} else if !AuthOwner(c, s, acl.ResourcePhotos, m.CreatedBy) {
+			AbortForbidden(c)
+			return
 		}
 
 		labelId, err := strconv.Atoi(clean.Token(c.Param("id")))
@@ -146,6 +154,8 @@ func RemovePhotoLabel(router *gin.RouterGroup) {
 			return
 		}
 
+		SavePhotoAsXmp(p)
+
 		PublishPhotoEvent(EntityUpdated, clean.UID(c.Param("uid")), c)
 
 		event.Success("label removed")
@@ -175,6 +185,9 @@ func UpdatePhotoLabel(router *gin.RouterGroup) {
 		if err != nil {
 			AbortEntityNotFound(c)
 			return
+		} else if !AuthOwner(c, s, acl.ResourcePhotos, m.CreatedBy) {
+			AbortForbidden(c)
+			return
 		}
 
 		labelId, err := strconv.Atoi(clean.Token(c.Param("id")))
@@ -213,6 +226,8 @@ func UpdatePhotoLabel(router *gin.RouterGroup) {
 			return
 		}
 
+		SavePhotoAsXmp(p)
+
 		PublishPhotoEvent(EntityUpdated, clean.UID(c.Param("uid")), c)
 
 		event.Success("label saved")
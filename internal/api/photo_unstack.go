@@ -43,7 +43,10 @@ func PhotoUnstack(router *gin.RouterGroup) {
 			return
 		}
 
-		if file.FilePrimary {
+		if file.Photo != nil && !AuthOwner(c, s, acl.ResourcePhotos, file.Photo.CreatedBy) {
+			AbortForbidden(c)
+			return
+		} else if file.FilePrimary {
 			log.Errorf("photo: cannot unstack primary file")
 			AbortBadRequest(c)
 			return
@@ -146,9 +149,9 @@ func PhotoUnstack(router *gin.RouterGroup) {
 			}
 
 			if err := entity.UnscopedDb().Exec(`UPDATE files 
-				SET photo_id = ?, photo_uid = ?, file_name = ?, file_missing = 0
+				SET photo_id = ?, photo_uid = ?, file_name = ?, file_missing = ?
 				WHERE file_name = ? AND file_root = ?`,
-				newPhoto.ID, newPhoto.PhotoUID, r.RootRelName(),
+				newPhoto.ID, newPhoto.PhotoUID, r.RootRelName(), false,
 				relName, relRoot).Error; err != nil {
 				// Handle error...
 				log.Errorf("photo: %s (unstack %s)", err.Error(), clean.Log(r.BaseName()))
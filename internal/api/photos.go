@@ -14,6 +14,7 @@ import (
 	"github.com/photoprism/photoprism/internal/i18n"
 	"github.com/photoprism/photoprism/internal/photoprism"
 	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/internal/search"
 	"github.com/photoprism/photoprism/pkg/clean"
 	"github.com/photoprism/photoprism/pkg/fs"
 )
@@ -36,6 +37,24 @@ func SavePhotoAsYaml(p entity.Photo) {
 	}
 }
 
+// SavePhotoAsXmp writes photo metadata to an XMP sidecar file so that it can be read by other apps.
+func SavePhotoAsXmp(p entity.Photo) {
+	c := get.Config()
+
+	// Write XMP sidecar file (optional).
+	if !c.SidecarXmp() {
+		return
+	}
+
+	fileName, srcName := p.XmpFileName(c.OriginalsPath(), c.SidecarPath(), c.ReadOnly())
+
+	if err := p.SaveAsXmp(fileName, srcName); err != nil {
+		log.Errorf("photo: %s (update xmp)", err)
+	} else {
+		log.Debugf("photo: updated xmp file %s", clean.Log(filepath.Base(fileName)))
+	}
+}
+
 // GetPhoto returns photo details as JSON.
 //
 // Route : GET /api/v1/photos/:uid
@@ -49,7 +68,15 @@ func GetPhoto(router *gin.RouterGroup) {
 			return
 		}
 
-		p, err := query.PhotoPreloadByUID(clean.UID(c.Param("uid")))
+		uid := clean.UID(c.Param("uid"))
+
+		// Only show photos in the user's library.
+		if !search.InLibrary(s, uid) {
+			AbortEntityNotFound(c)
+			return
+		}
+
+		p, err := query.PhotoPreloadByUID(uid)
 
 		if err != nil {
 			AbortEntityNotFound(c)
@@ -77,6 +104,9 @@ func UpdatePhoto(router *gin.RouterGroup) {
 		if err != nil {
 			AbortEntityNotFound(c)
 			return
+		} else if !AuthOwner(c, s, acl.ResourcePhotos, m.CreatedBy) {
+			AbortForbidden(c)
+			return
 		}
 
 		// 1) Init form with model values
@@ -113,6 +143,7 @@ func UpdatePhoto(router *gin.RouterGroup) {
 		}
 
 		SavePhotoAsYaml(p)
+		SavePhotoAsXmp(p)
 
 		UpdateClientConfig()
 
@@ -132,7 +163,14 @@ func GetPhotoDownload(router *gin.RouterGroup) {
 			return
 		}
 
-		f, err := query.FileByPhotoUID(clean.UID(c.Param("uid")))
+		uid := clean.UID(c.Param("uid"))
+
+		if NotInLibrary(c, uid) || NotDownloadable(c, uid) {
+			c.Data(http.StatusForbidden, "image/svg+xml", brokenIconSvg)
+			return
+		}
+
+		f, err := query.FileByPhotoUID(uid)
 
 		if err != nil {
 			c.Data(http.StatusNotFound, "image/svg+xml", photoIconSvg)
@@ -141,7 +179,16 @@ func GetPhotoDownload(router *gin.RouterGroup) {
 
 		fileName := photoprism.FileName(f.FileRoot, f.FileName)
 
-		if !fs.FileExists(fileName) {
+		if remoteOriginal(f.FileRoot, f.FileName) {
+			if err = sendOriginal(c, f.FileName, f.DownloadName(DownloadName(c), 0)); err == nil {
+				return
+			}
+
+			log.Errorf("photo: %s (stream %s)", err, clean.Log(f.FileName))
+			c.Data(http.StatusNotFound, "image/svg+xml", photoIconSvg)
+
+			return
+		} else if !fs.FileExists(fileName) {
 			log.Errorf("photo: file %s is missing", clean.Log(f.FileName))
 			c.Data(http.StatusNotFound, "image/svg+xml", photoIconSvg)
 
@@ -211,6 +258,9 @@ func ApprovePhoto(router *gin.RouterGroup) {
 		if err != nil {
 			AbortEntityNotFound(c)
 			return
+		} else if !AuthOwner(c, s, acl.ResourcePhotos, m.CreatedBy) {
+			AbortForbidden(c)
+			return
 		}
 
 		if err := m.Approve(); err != nil {
@@ -244,6 +294,15 @@ func PhotoPrimary(router *gin.RouterGroup) {
 
 		uid := clean.UID(c.Param("uid"))
 		fileUid := clean.UID(c.Param("file_uid"))
+
+		if m, err := query.PhotoByUID(uid); err != nil {
+			AbortEntityNotFound(c)
+			return
+		} else if !AuthOwner(c, s, acl.ResourcePhotos, m.CreatedBy) {
+			AbortForbidden(c)
+			return
+		}
+
 		err := query.SetPhotoPrimary(uid, fileUid)
 
 		if err != nil {
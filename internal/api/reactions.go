@@ -35,7 +35,7 @@ func LikePhoto(router *gin.RouterGroup) {
 			logWarn("react", m.React(s.User(), react.Find("love")))
 		}
 
-		if acl.Resources.Allow(acl.ResourcePhotos, s.User().AclRole(), acl.ActionUpdate) {
+		if acl.Resources.Allow(acl.ResourcePhotos, s.User().AclRole(), acl.ActionUpdate) && AuthOwner(c, s, acl.ResourcePhotos, m.CreatedBy) {
 			err = m.SetFavorite(true)
 
 			if err != nil {
@@ -45,6 +45,7 @@ func LikePhoto(router *gin.RouterGroup) {
 			}
 
 			SavePhotoAsYaml(m)
+			SavePhotoAsXmp(m)
 			PublishPhotoEvent(EntityUpdated, id, c)
 		}
 
@@ -75,7 +76,7 @@ func DislikePhoto(router *gin.RouterGroup) {
 			logWarn("react", m.UnReact(s.User()))
 		}
 
-		if acl.Resources.Allow(acl.ResourcePhotos, s.User().AclRole(), acl.ActionUpdate) {
+		if acl.Resources.Allow(acl.ResourcePhotos, s.User().AclRole(), acl.ActionUpdate) && AuthOwner(c, s, acl.ResourcePhotos, m.CreatedBy) {
 			err = m.SetFavorite(false)
 
 			if err != nil {
@@ -85,6 +86,7 @@ func DislikePhoto(router *gin.RouterGroup) {
 			}
 
 			SavePhotoAsYaml(m)
+			SavePhotoAsXmp(m)
 			PublishPhotoEvent(EntityUpdated, id, c)
 		}
 
@@ -0,0 +1,112 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/photoprism/photoprism/internal/acl"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/internal/get"
+	"github.com/photoprism/photoprism/internal/i18n"
+	"github.com/photoprism/photoprism/internal/mutex"
+	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/internal/workers"
+	"github.com/photoprism/photoprism/pkg/clean"
+)
+
+// GetServiceConflicts returns files that have been modified both locally and remotely as JSON.
+//
+// GET /api/v1/services/:id/conflicts
+func GetServiceConflicts(router *gin.RouterGroup) {
+	router.GET("/services/:id/conflicts", func(c *gin.Context) {
+		s := Auth(c, acl.ResourceServices, acl.ActionView)
+
+		if s.Abort(c) {
+			return
+		}
+
+		conf := get.Config()
+
+		if conf.Demo() || conf.DisableSettings() {
+			AbortForbidden(c)
+			return
+		}
+
+		id := clean.IdUint(c.Param("id"))
+
+		m, err := query.AccountByID(id)
+
+		if err != nil {
+			Abort(c, http.StatusNotFound, i18n.ErrAccountNotFound)
+			return
+		}
+
+		files, err := query.FileSyncs(m.ID, entity.FileSyncConflict, 0)
+
+		if err != nil {
+			log.Errorf("sync: %s", err)
+			AbortUnexpected(c)
+			return
+		}
+
+		c.JSON(http.StatusOK, files)
+	})
+}
+
+// ResolveServiceConflict resolves a sync conflict by keeping the local version, the remote version, or both.
+//
+// POST /api/v1/services/:id/conflicts
+func ResolveServiceConflict(router *gin.RouterGroup) {
+	router.POST("/services/:id/conflicts", func(c *gin.Context) {
+		s := Auth(c, acl.ResourceServices, acl.ActionUpdate)
+
+		if s.Abort(c) {
+			return
+		}
+
+		conf := get.Config()
+
+		if conf.Demo() || conf.DisableSettings() {
+			AbortForbidden(c)
+			return
+		}
+
+		id := clean.IdUint(c.Param("id"))
+
+		m, err := query.AccountByID(id)
+
+		if err != nil {
+			Abort(c, http.StatusNotFound, i18n.ErrAccountNotFound)
+			return
+		}
+
+		var f form.ServiceConflict
+
+		if err = c.BindJSON(&f); err != nil || f.RemoteName == "" {
+			AbortBadRequest(c)
+			return
+		}
+
+		if mutex.SyncWorker.Running() {
+			AbortBusy(c)
+			return
+		}
+
+		if err = workers.NewSync(conf).Resolve(m, f.RemoteName, f.Keep); err != nil {
+			Error(c, http.StatusBadRequest, err, i18n.ErrBadRequest)
+			return
+		}
+
+		files, err := query.FileSyncs(m.ID, entity.FileSyncConflict, 0)
+
+		if err != nil {
+			log.Errorf("sync: %s", err)
+			AbortUnexpected(c)
+			return
+		}
+
+		c.JSON(http.StatusOK, files)
+	})
+}
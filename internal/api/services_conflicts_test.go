@@ -0,0 +1,55 @@
+package api
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tidwall/gjson"
+
+	"github.com/photoprism/photoprism/internal/i18n"
+)
+
+func TestGetServiceConflicts(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		GetServiceConflicts(router)
+		r := PerformRequest(app, "GET", "/api/v1/services/1000000/conflicts")
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.True(t, gjson.Parse(r.Body.String()).IsArray())
+	})
+	t.Run("AccountNotFound", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		GetServiceConflicts(router)
+		r := PerformRequest(app, "GET", "/api/v1/services/999000/conflicts")
+		val := gjson.Get(r.Body.String(), "error")
+		assert.Equal(t, i18n.Msg(i18n.ErrAccountNotFound), val.String())
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+}
+
+func TestResolveServiceConflict(t *testing.T) {
+	t.Run("InvalidRequest", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		ResolveServiceConflict(router)
+		r := PerformRequest(app, "POST", "/api/v1/services/1000000/conflicts")
+		val := gjson.Get(r.Body.String(), "error")
+		assert.Equal(t, i18n.Msg(i18n.ErrBadRequest), val.String())
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+	t.Run("NoConflict", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		ResolveServiceConflict(router)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/services/1000000/conflicts", `{"RemoteName": "/missing.jpg", "Keep": "local"}`)
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+		assert.Contains(t, gjson.Get(r.Body.String(), "details").String(), "not found")
+	})
+	t.Run("AccountNotFound", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		ResolveServiceConflict(router)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/services/999000/conflicts", `{"RemoteName": "/missing.jpg", "Keep": "local"}`)
+		val := gjson.Get(r.Body.String(), "error")
+		assert.Equal(t, i18n.Msg(i18n.ErrAccountNotFound), val.String())
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+}
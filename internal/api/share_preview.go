@@ -40,6 +40,12 @@ func SharePreview(router *gin.RouterGroup) {
 			return
 		}
 
+		// Don't reveal the content of password-protected shares.
+		if links[0].HasPassword {
+			c.Redirect(http.StatusTemporaryRedirect, conf.SitePreview())
+			return
+		}
+
 		thumbPath := path.Join(conf.ThumbCachePath(), "share")
 
 		if err := os.MkdirAll(thumbPath, fs.ModeDir); err != nil {
@@ -65,8 +71,8 @@ func SharePreview(router *gin.RouterGroup) {
 
 		var f form.SearchPhotos
 
-		// Covers may only contain public content in shared albums.
-		f.Album = shared
+		// Covers may only contain public content of the shared album, photo, or label.
+		f.Scope = links[0].ShareUID
 		f.Public = true
 		f.Private = false
 		f.Hidden = false
@@ -101,7 +107,10 @@ func SharePreview(router *gin.RouterGroup) {
 
 			fileName := photoprism.FileName(f.FileRoot, f.FileName)
 
-			if !fs.FileExists(fileName) {
+			exists, release := fileExists(f.FileRoot, f.FileName)
+			defer release()
+
+			if !exists {
 				log.Errorf("share: file %s is missing (preview)", clean.Log(f.FileName))
 				c.Redirect(http.StatusTemporaryRedirect, conf.SitePreview())
 				return
@@ -131,13 +140,17 @@ func SharePreview(router *gin.RouterGroup) {
 		for _, f := range p {
 			fileName := photoprism.FileName(f.FileRoot, f.FileName)
 
-			if !fs.FileExists(fileName) {
+			exists, release := fileExists(f.FileRoot, f.FileName)
+
+			if !exists {
+				release()
 				log.Errorf("share: file %s is missing (preview)", clean.Log(f.FileName))
 				c.Redirect(http.StatusTemporaryRedirect, conf.SitePreview())
 				return
 			}
 
 			thumbnail, err := thumb.FromFile(fileName, f.FileHash, conf.ThumbCachePath(), size.Width, size.Height, f.FileOrientation, size.Options...)
+			release()
 
 			if err != nil {
 				log.Error(err)
@@ -0,0 +1,187 @@
+package api
+
+import (
+	"net/http"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/dustin/go-humanize/english"
+	"github.com/gin-gonic/gin"
+
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/event"
+	"github.com/photoprism/photoprism/internal/get"
+	"github.com/photoprism/photoprism/internal/i18n"
+	"github.com/photoprism/photoprism/internal/photoprism"
+	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/internal/server/limiter"
+	"github.com/photoprism/photoprism/pkg/clean"
+	"github.com/photoprism/photoprism/pkg/rnd"
+)
+
+// ShareUpload adds files to the album shared with an upload link. Uploaded files are
+// hidden in the album and remain in review until they are approved.
+//
+// POST /s/:token/upload
+func ShareUpload(router *gin.RouterGroup) {
+	router.POST("/:token/upload", func(c *gin.Context) {
+		conf := get.Config()
+		ip := ClientIP(c)
+		token := clean.Token(c.Param("token"))
+
+		var link *entity.Link
+
+		for _, l := range entity.FindValidLinks(token, "") {
+			if l.CanUpload() {
+				link = &l
+				break
+			}
+		}
+
+		if link == nil {
+			log.Debugf("share: invalid token (upload)")
+			c.Redirect(http.StatusSeeOther, conf.BaseUri(""))
+			return
+		}
+
+		// Limit the request size before the form is parsed, as the request body is otherwise stored
+		// on disk in full, regardless of the upload limits of the link.
+		if limit := uploadBodyLimit(link, conf.OriginalsByteLimit()); limit > 0 {
+			c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, limit)
+		}
+
+		clientConfig := conf.ClientShare()
+		password := c.PostForm("password")
+		upload := gin.H{"action": conf.BaseUri(path.Join("/s", token, "upload")), "password": password}
+
+		if a, err := query.AlbumByUID(link.ShareUID); err == nil {
+			clientConfig.SiteCaption = a.AlbumTitle
+		}
+
+		// Disable caching, as the response may contain the password.
+		c.Header("Cache-Control", "no-store")
+
+		render := func(status int, values gin.H) {
+			values["config"] = clientConfig
+			values["upload"] = upload
+			c.HTML(status, "share_upload.gohtml", values)
+		}
+
+		if conf.ReadOnly() || !conf.Settings().Features.Upload {
+			render(http.StatusForbidden, gin.H{"error": i18n.Msg(i18n.ErrReadOnly)})
+			return
+		} else if limiter.Login.Reject(ip) {
+			render(http.StatusTooManyRequests, gin.H{"error": "Too many failed attempts, please try again later"})
+			return
+		} else if link.InvalidPassword(password) {
+			limiter.Login.Reserve(ip)
+			event.AuditWarn([]string{ip, "link %s", "upload", "incorrect password"}, clean.Log(link.RefID))
+			c.HTML(http.StatusUnauthorized, "share_password.gohtml", gin.H{"config": clientConfig, "action": conf.BaseUri(path.Join("/s", token)), "error": "Invalid password, please try again"})
+			return
+		}
+
+		f, err := c.MultipartForm()
+
+		if err != nil && strings.Contains(err.Error(), "request body too large") {
+			event.AuditWarn([]string{ip, "link %s", "upload", "limit reached"}, clean.Log(link.RefID))
+			render(http.StatusRequestEntityTooLarge, gin.H{"error": "Upload limit reached"})
+			return
+		} else if err != nil {
+			log.Errorf("upload: %s", err)
+			render(http.StatusBadRequest, gin.H{"error": i18n.Msg(i18n.ErrUploadFailed)})
+			return
+		}
+
+		files := f.File["files"]
+
+		if len(files) == 0 {
+			render(http.StatusBadRequest, gin.H{"error": i18n.Msg(i18n.ErrUploadFailed)})
+			return
+		}
+
+		var size int64
+
+		for _, file := range files {
+			size += file.Size
+		}
+
+		// Reserve upload quota.
+		if !link.ReserveUpload(uint(len(files)), size) {
+			event.AuditWarn([]string{ip, "link %s", "upload %s", "limit reached"}, clean.Log(link.RefID), english.Plural(len(files), "file", "files"))
+			render(http.StatusForbidden, gin.H{"error": "Upload limit reached"})
+			return
+		}
+
+		// Compose upload path.
+		uploadPath, err := conf.UserUploadPath(entity.Visitor.UserUID, link.LinkUID+rnd.GenerateToken(8))
+
+		if err != nil {
+			log.Errorf("upload: failed to create storage folder (%s)", err)
+			link.ReleaseUpload(uint(len(files)), size)
+			render(http.StatusBadRequest, gin.H{"error": i18n.Msg(i18n.ErrUploadFailed)})
+			return
+		}
+
+		var uploads []string
+
+		// Save uploaded files.
+		for _, file := range files {
+			fileName := filepath.Base(file.Filename)
+			filePath := path.Join(uploadPath, fileName)
+
+			if err = c.SaveUploadedFile(file, filePath); err != nil {
+				log.Errorf("upload: failed saving file %s", clean.Log(fileName))
+				link.ReleaseUpload(uint(len(files)), size)
+				render(http.StatusBadRequest, gin.H{"error": i18n.Msg(i18n.ErrUploadFailed)})
+				return
+			} else {
+				log.Debugf("upload: saved file %s", clean.Log(fileName))
+			}
+
+			uploads = append(uploads, filePath)
+		}
+
+		// Check if uploaded file is safe.
+		if RemoveOffensiveUploads(conf, uploads) {
+			link.ReleaseUpload(uint(len(files)), size)
+			render(http.StatusForbidden, gin.H{"error": i18n.Msg(i18n.ErrOffensiveUpload)})
+			return
+		}
+
+		// Import files to the shared album as visitor, so they remain in review until approved.
+		opt := photoprism.ImportOptionsUpload(uploadPath, conf.ImportDest())
+		opt.Albums = []string{link.ShareUID}
+		opt.UID = entity.Visitor.UserUID
+
+		imported := ImportUpload(opt, uploadPath)
+
+		event.AuditInfo([]string{ip, "link %s", "uploaded %s", "%d imported"}, clean.Log(link.RefID), english.Plural(len(uploads), "file", "files"), imported)
+
+		// Update the user interface.
+		UpdateClientConfig()
+
+		render(http.StatusOK, gin.H{"message": english.Plural(len(uploads), "file", "files") + " uploaded, thank you!"})
+	})
+}
+
+// uploadFormOverhead is the additional request size allowed for form fields and multipart headers.
+const uploadFormOverhead = 1024 * 1024
+
+// uploadBodyLimit returns the maximum request size for uploads with the specified link,
+// based on its remaining quota and the size limit for originals, or -1 if there is no limit.
+func uploadBodyLimit(link *entity.Link, fileLimit int64) int64 {
+	if link.MaxUploadSize > 0 {
+		if remaining := link.MaxUploadSize - link.UploadSize; remaining > 0 {
+			return remaining + uploadFormOverhead
+		}
+
+		return uploadFormOverhead
+	} else if fileLimit <= 0 || link.MaxUploads == 0 {
+		return -1
+	} else if link.LinkUploads >= link.MaxUploads {
+		return uploadFormOverhead
+	}
+
+	return fileLimit*int64(link.MaxUploads-link.LinkUploads) + uploadFormOverhead
+}
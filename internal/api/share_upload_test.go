@@ -0,0 +1,168 @@
+package api
+
+import (
+	"bytes"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/entity"
+)
+
+func TestShareUpload(t *testing.T) {
+	uploadLink := func(t *testing.T) entity.Link {
+		link := entity.NewLink("at9lxuqxpogaaba9", false, false)
+		link.Perm = entity.PermUpload
+
+		if err := link.Save(); err != nil {
+			t.Fatal(err)
+		}
+
+		return link
+	}
+
+	multipartRequest := func(path string, fields map[string]string, files map[string][]byte) *http.Request {
+		body := &bytes.Buffer{}
+		w := multipart.NewWriter(body)
+
+		for k, v := range fields {
+			_ = w.WriteField(k, v)
+		}
+
+		for name, data := range files {
+			part, _ := w.CreateFormFile("files", name)
+			_, _ = part.Write(data)
+		}
+
+		_ = w.Close()
+
+		req, _ := http.NewRequest("POST", path, body)
+		req.Header.Set("Content-Type", w.FormDataContentType())
+
+		return req
+	}
+
+	t.Run("InvalidToken", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		ShareUpload(router)
+		r := PerformRequest(app, "POST", "/api/v1/xxx/upload")
+		assert.Equal(t, http.StatusSeeOther, r.Code)
+	})
+	t.Run("ViewOnlyLink", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		ShareUpload(router)
+		r := PerformRequest(app, "POST", "/api/v1/4jxf3jfn2k/upload")
+		assert.Equal(t, http.StatusSeeOther, r.Code)
+	})
+	t.Run("UploadPage", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		app.LoadHTMLFiles(conf.TemplateFiles()...)
+		Shares(router)
+
+		link := uploadLink(t)
+		defer link.Delete()
+
+		r := PerformRequest(app, "GET", "/api/v1/"+link.LinkToken)
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Contains(t, r.Body.String(), "/s/"+link.LinkToken+"/upload")
+	})
+	t.Run("NoFiles", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		app.LoadHTMLFiles(conf.TemplateFiles()...)
+		ShareUpload(router)
+
+		link := uploadLink(t)
+		defer link.Delete()
+
+		r := PerformRequest(app, "POST", "/api/v1/"+link.LinkToken+"/upload")
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+	t.Run("InvalidPassword", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		app.LoadHTMLFiles(conf.TemplateFiles()...)
+		ShareUpload(router)
+
+		link := uploadLink(t)
+		defer link.Delete()
+
+		if err := link.SetPassword("secret"); err != nil {
+			t.Fatal(err)
+		} else if err = link.Save(); err != nil {
+			t.Fatal(err)
+		}
+
+		req := multipartRequest("/api/v1/"+link.LinkToken+"/upload", map[string]string{"password": "wrong"}, nil)
+		w := httptest.NewRecorder()
+		app.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+	})
+	t.Run("LimitReached", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		app.LoadHTMLFiles(conf.TemplateFiles()...)
+		ShareUpload(router)
+
+		link := uploadLink(t)
+		defer link.Delete()
+
+		link.MaxUploads = 1
+
+		if err := link.Save(); err != nil {
+			t.Fatal(err)
+		}
+
+		files := map[string][]byte{"a.jpg": []byte("foo"), "b.jpg": []byte("bar")}
+		req := multipartRequest("/api/v1/"+link.LinkToken+"/upload", nil, files)
+		w := httptest.NewRecorder()
+		app.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusForbidden, w.Code)
+		assert.Contains(t, w.Body.String(), "Upload limit reached")
+		assert.Equal(t, uint(0), entity.FindLink(link.LinkUID).LinkUploads)
+	})
+	t.Run("TooLarge", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		app.LoadHTMLFiles(conf.TemplateFiles()...)
+		ShareUpload(router)
+
+		link := uploadLink(t)
+		defer link.Delete()
+
+		link.MaxUploadSize = 1
+
+		if err := link.Save(); err != nil {
+			t.Fatal(err)
+		}
+
+		files := map[string][]byte{"a.jpg": bytes.Repeat([]byte("x"), uploadFormOverhead+1024)}
+		req := multipartRequest("/api/v1/"+link.LinkToken+"/upload", nil, files)
+		w := httptest.NewRecorder()
+		app.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusRequestEntityTooLarge, w.Code)
+		assert.Contains(t, w.Body.String(), "Upload limit reached")
+		assert.Equal(t, int64(0), entity.FindLink(link.LinkUID).UploadSize)
+	})
+}
+
+func TestUploadBodyLimit(t *testing.T) {
+	t.Run("Unlimited", func(t *testing.T) {
+		assert.Equal(t, int64(-1), uploadBodyLimit(&entity.Link{}, -1))
+		assert.Equal(t, int64(-1), uploadBodyLimit(&entity.Link{}, 1000))
+	})
+	t.Run("MaxUploadSize", func(t *testing.T) {
+		link := &entity.Link{MaxUploadSize: 5000, UploadSize: 1000}
+		assert.Equal(t, int64(4000+uploadFormOverhead), uploadBodyLimit(link, 1000))
+		link.UploadSize = 5000
+		assert.Equal(t, int64(uploadFormOverhead), uploadBodyLimit(link, 1000))
+	})
+	t.Run("MaxUploads", func(t *testing.T) {
+		link := &entity.Link{MaxUploads: 3, LinkUploads: 1}
+		assert.Equal(t, int64(2000+uploadFormOverhead), uploadBodyLimit(link, 1000))
+		link.LinkUploads = 3
+		assert.Equal(t, int64(uploadFormOverhead), uploadBodyLimit(link, 1000))
+	})
+}
@@ -32,7 +32,7 @@ func SearchSubjects(router *gin.RouterGroup) {
 			return
 		}
 
-		result, err := search.Subjects(f)
+		result, err := search.UserSubjects(f, s)
 
 		if err != nil {
 			c.AbortWithStatusJSON(400, gin.H{"error": txt.UpperFirst(err.Error())})
@@ -39,6 +39,12 @@ func GetThumb(router *gin.RouterGroup) {
 		download := c.Query("download") != ""
 		fileHash, cropArea := crop.ParseThumb(clean.Token(c.Param("thumb")))
 
+		// Only show thumbnails of photos in the user's library or shared with the user.
+		if FileNotInLibrary(c, fileHash) || FileNotShared(c, fileHash) {
+			c.Data(http.StatusForbidden, "image/svg+xml", brokenIconSvg)
+			return
+		}
+
 		// Is cropped thumbnail?
 		if cropArea != "" {
 			cropName := crop.Name(clean.Token(c.Param("size")))
@@ -69,7 +75,7 @@ func GetThumb(router *gin.RouterGroup) {
 			if download {
 				c.FileAttachment(fileName, cropName.Jpeg())
 			} else {
-				c.File(fileName)
+				c.File(thumbVariant(c, fileName))
 			}
 
 			return
@@ -115,7 +121,7 @@ func GetThumb(router *gin.RouterGroup) {
 			if download {
 				c.FileAttachment(cached.FileName, cached.ShareName)
 			} else {
-				c.File(cached.FileName)
+				c.File(thumbVariant(c, cached.FileName))
 			}
 
 			return
@@ -128,7 +134,7 @@ func GetThumb(router *gin.RouterGroup) {
 				AddImmutableCacheHeader(c)
 
 				// Return requested content.
-				c.File(fileName)
+				c.File(thumbVariant(c, fileName))
 				return
 			}
 		}
@@ -159,6 +165,12 @@ func GetThumb(router *gin.RouterGroup) {
 
 		fileName := photoprism.FileName(f.FileRoot, f.FileName)
 
+		// Fetch original from remote bucket, if needed.
+		if remoteOriginal(f.FileRoot, f.FileName) {
+			release, _ := fetchOriginal(f.FileName)
+			defer release()
+		}
+
 		if fileName, err = fs.Resolve(fileName); err != nil {
 			log.Errorf("%s: file %s is missing", logPrefix, clean.Log(f.FileName))
 			c.Data(http.StatusOK, "image/svg+xml", brokenIconSvg)
@@ -227,7 +239,33 @@ func GetThumb(router *gin.RouterGroup) {
 		if download {
 			c.FileAttachment(thumbName, f.DownloadName(DownloadName(c), 0))
 		} else {
-			c.File(thumbName)
+			c.File(thumbVariant(c, thumbName))
 		}
 	})
 }
+
+// thumbVariant returns the file name of a thumbnail variant in the preferred format accepted by the client,
+// e.g. AVIF or WebP, or the JPEG thumbnail if no additional formats are enabled or supported.
+func thumbVariant(c *gin.Context, fileName string) string {
+	if len(thumb.Formats) == 0 {
+		return fileName
+	}
+
+	// The response depends on the formats accepted by the client.
+	c.Header("Vary", "Accept")
+
+	format := thumb.Negotiate(c.GetHeader("Accept"))
+
+	if format == "" || fs.FileType(fileName) != fs.ImageJPEG {
+		return fileName
+	}
+
+	variantName, err := thumb.Variant(fileName, format)
+
+	if err != nil {
+		log.Warnf("thumb: %s", err)
+		return fileName
+	}
+
+	return variantName
+}
@@ -2,11 +2,17 @@ package api
 
 import (
 	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
 	"testing"
 
+	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/assert"
 
 	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/thumb"
+	"github.com/photoprism/photoprism/pkg/fs"
 )
 
 func TestGetThumb(t *testing.T) {
@@ -59,3 +65,51 @@ func TestGetThumb(t *testing.T) {
 	})
 
 }
+
+func TestThumbVariant(t *testing.T) {
+	defer func(formats []fs.Type, bin string) { thumb.Formats, thumb.WebPEncoderBin = formats, bin }(thumb.Formats, thumb.WebPEncoderBin)
+
+	dir := t.TempDir()
+	fileName := filepath.Join(dir, "example_720x720_fit.jpg")
+	encoder := filepath.Join(dir, "cwebp")
+
+	if err := os.WriteFile(fileName, []byte("jpeg"), fs.ModeFile); err != nil {
+		t.Fatal(err)
+	} else if err = os.WriteFile(encoder, []byte("#!/bin/sh\nfor a in \"$@\"; do out=\"$a\"; done\necho webp > \"$out\"\n"), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	newContext := func(accept string) *gin.Context {
+		c, _ := gin.CreateTestContext(httptest.NewRecorder())
+		c.Request = httptest.NewRequest("GET", "/api/v1/t/abc/public/fit_720", nil)
+		c.Request.Header.Set("Accept", accept)
+		return c
+	}
+
+	t.Run("Disabled", func(t *testing.T) {
+		thumb.Formats = nil
+		c := newContext("image/webp,*/*")
+		assert.Equal(t, fileName, thumbVariant(c, fileName))
+		assert.Equal(t, "", c.Writer.Header().Get("Vary"))
+	})
+	t.Run("NotAccepted", func(t *testing.T) {
+		thumb.Formats = []fs.Type{fs.ImageWebP}
+		c := newContext("image/png,*/*")
+		assert.Equal(t, fileName, thumbVariant(c, fileName))
+		assert.Equal(t, "Accept", c.Writer.Header().Get("Vary"))
+	})
+	t.Run("WebP", func(t *testing.T) {
+		thumb.Formats = []fs.Type{fs.ImageWebP}
+		thumb.WebPEncoderBin = encoder
+		c := newContext("image/avif,image/webp,*/*")
+		assert.Equal(t, thumb.VariantName(fileName, fs.ImageWebP), thumbVariant(c, fileName))
+		assert.Equal(t, "Accept", c.Writer.Header().Get("Vary"))
+	})
+	t.Run("EncoderFailed", func(t *testing.T) {
+		thumb.Formats = []fs.Type{fs.ImageWebP}
+		thumb.WebPEncoderBin = filepath.Join(dir, "missing")
+		c := newContext("image/webp,*/*")
+		otherName := filepath.Join(dir, "other_720x720_fit.jpg")
+		assert.Equal(t, otherName, thumbVariant(c, otherName))
+	})
+}
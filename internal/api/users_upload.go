@@ -12,6 +12,7 @@ import (
 	"github.com/gin-gonic/gin"
 
 	"github.com/photoprism/photoprism/internal/acl"
+	"github.com/photoprism/photoprism/internal/config"
 	"github.com/photoprism/photoprism/internal/event"
 	"github.com/photoprism/photoprism/internal/form"
 	"github.com/photoprism/photoprism/internal/get"
@@ -97,38 +98,9 @@ func UploadUserFiles(router *gin.RouterGroup) {
 		}
 
 		// Check if uploaded file is safe.
-		if !conf.UploadNSFW() {
-			nd := get.NsfwDetector()
-
-			containsNSFW := false
-
-			for _, filename := range uploads {
-				labels, err := nd.File(filename)
-
-				if err != nil {
-					log.Debug(err)
-					continue
-				}
-
-				if labels.IsSafe() {
-					continue
-				}
-
-				log.Infof("nsfw: %s might be offensive", clean.Log(filename))
-
-				containsNSFW = true
-			}
-
-			if containsNSFW {
-				for _, filename := range uploads {
-					if err := os.Remove(filename); err != nil {
-						log.Errorf("nsfw: could not delete %s", clean.Log(filename))
-					}
-				}
-
-				Abort(c, http.StatusForbidden, i18n.ErrOffensiveUpload)
-				return
-			}
+		if RemoveOffensiveUploads(conf, uploads) {
+			Abort(c, http.StatusForbidden, i18n.ErrOffensiveUpload)
+			return
 		}
 
 		elapsed := int(time.Since(start).Seconds())
@@ -183,8 +155,6 @@ func ProcessUserUpload(router *gin.RouterGroup) {
 			return
 		}
 
-		imp := get.Import()
-
 		// Get destination folder.
 		var destFolder string
 		if destFolder = s.User().GetUploadPath(); destFolder == "" {
@@ -208,28 +178,7 @@ func ProcessUserUpload(router *gin.RouterGroup) {
 		}
 
 		// Start import.
-		imported := imp.Start(opt)
-
-		// Delete empty import directory.
-		if fs.DirIsEmpty(uploadPath) {
-			if err := os.Remove(uploadPath); err != nil {
-				log.Errorf("upload: failed deleting empty folder %s: %s", clean.Log(uploadPath), err)
-			} else {
-				log.Infof("upload: deleted empty folder %s", clean.Log(uploadPath))
-			}
-		}
-
-		// Update moments if files have been imported.
-		if n := len(imported); n == 0 {
-			log.Infof("upload: no new files imported", clean.Log(uploadPath))
-		} else {
-			log.Infof("upload: imported %s", english.Plural(n, "file", "files"))
-			if moments := get.Moments(); moments == nil {
-				log.Warnf("upload: moments service not set - possible bug")
-			} else if err := moments.Start(); err != nil {
-				log.Warnf("moments: %s", err)
-			}
-		}
+		ImportUpload(opt, uploadPath)
 
 		elapsed := int(time.Since(start).Seconds())
 
@@ -256,3 +205,75 @@ func ProcessUserUpload(router *gin.RouterGroup) {
 		c.JSON(http.StatusOK, i18n.Response{Code: http.StatusOK, Msg: msg})
 	})
 }
+
+// RemoveOffensiveUploads checks if any of the uploaded files might be offensive and deletes all of them if so,
+// unless offensive uploads are allowed.
+func RemoveOffensiveUploads(conf *config.Config, uploads []string) bool {
+	if conf.UploadNSFW() {
+		return false
+	}
+
+	nd := get.NsfwDetector()
+
+	containsNSFW := false
+
+	for _, filename := range uploads {
+		labels, err := nd.File(filename)
+
+		if err != nil {
+			log.Debug(err)
+			continue
+		}
+
+		if labels.IsSafe() {
+			continue
+		}
+
+		log.Infof("nsfw: %s might be offensive", clean.Log(filename))
+
+		containsNSFW = true
+	}
+
+	if !containsNSFW {
+		return false
+	}
+
+	for _, filename := range uploads {
+		if err := os.Remove(filename); err != nil {
+			log.Errorf("nsfw: could not delete %s", clean.Log(filename))
+		}
+	}
+
+	return true
+}
+
+// ImportUpload moves the uploaded files to the destination folder and indexes them,
+// then deletes the empty upload folder and returns the number of imported files.
+func ImportUpload(opt photoprism.ImportOptions, uploadPath string) int {
+	imported := get.Import().Start(opt)
+
+	// Delete empty import directory.
+	if fs.DirIsEmpty(uploadPath) {
+		if err := os.Remove(uploadPath); err != nil {
+			log.Errorf("upload: failed deleting empty folder %s: %s", clean.Log(uploadPath), err)
+		} else {
+			log.Infof("upload: deleted empty folder %s", clean.Log(uploadPath))
+		}
+	}
+
+	// Update moments if files have been imported.
+	n := len(imported)
+
+	if n == 0 {
+		log.Infof("upload: no new files imported", clean.Log(uploadPath))
+	} else {
+		log.Infof("upload: imported %s", english.Plural(n, "file", "files"))
+		if moments := get.Moments(); moments == nil {
+			log.Warnf("upload: moments service not set - possible bug")
+		} else if err := moments.Start(); err != nil {
+			log.Warnf("moments: %s", err)
+		}
+	}
+
+	return n
+}
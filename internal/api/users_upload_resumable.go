@@ -0,0 +1,239 @@
+package api
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/photoprism/photoprism/internal/acl"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/event"
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/internal/get"
+	"github.com/photoprism/photoprism/internal/i18n"
+	"github.com/photoprism/photoprism/internal/upload"
+	"github.com/photoprism/photoprism/pkg/clean"
+)
+
+// Resumable upload request and response headers.
+const (
+	UploadOffsetHeader   = "Upload-Offset"
+	UploadChecksumHeader = "Upload-Checksum"
+)
+
+// resumableUploadAuth checks if the current user may upload files and returns the session and upload folder token.
+func resumableUploadAuth(c *gin.Context) (s *entity.Session, folder string, ok bool) {
+	conf := get.Config()
+
+	// Abort in public mode or when the upload feature is disabled.
+	if conf.ReadOnly() || !conf.Settings().Features.Upload {
+		Abort(c, http.StatusForbidden, i18n.ErrReadOnly)
+		return s, "", false
+	}
+
+	// Check permission.
+	s = AuthAny(c, acl.ResourceFiles, acl.Permissions{acl.ActionManage, acl.ActionUpload})
+
+	if s.Abort(c) {
+		return s, "", false
+	}
+
+	// Users may only upload their own files.
+	if s.User().UserUID != clean.UID(c.Param("uid")) {
+		event.AuditErr([]string{ClientIP(c), "session %s", "upload files", "user does not match"}, s.RefID)
+		AbortForbidden(c)
+		return s, "", false
+	}
+
+	return s, s.RefID + clean.Token(c.Param("token")), true
+}
+
+// findResumableUpload returns the upload with the id specified in the request, if it belongs to the current user.
+func findResumableUpload(c *gin.Context, store *upload.Store, s *entity.Session, folder string) (*upload.Session, bool) {
+	m, err := store.Find(clean.Token(c.Param("id")))
+
+	if err != nil || !m.BelongsTo(s.UserUID, folder) {
+		AbortEntityNotFound(c)
+		return nil, false
+	}
+
+	return m, true
+}
+
+// CreateResumableUpload starts a new chunked upload, or returns the upload of the same file so that it can be resumed.
+//
+// POST /api/v1/users/:uid/upload/:token/resumable
+func CreateResumableUpload(router *gin.RouterGroup) {
+	router.POST("/users/:uid/upload/:token/resumable", func(c *gin.Context) {
+		s, folder, ok := resumableUploadAuth(c)
+
+		if !ok {
+			return
+		}
+
+		var f form.ResumableUpload
+
+		if err := c.BindJSON(&f); err != nil {
+			AbortBadRequest(c)
+			return
+		}
+
+		conf := get.Config()
+
+		if limit := conf.OriginalsByteLimit(); limit > 0 && f.Size > limit {
+			Abort(c, http.StatusRequestEntityTooLarge, i18n.ErrFileTooLarge)
+			return
+		}
+
+		m, err := upload.NewSession(s.UserUID, folder, f.Name, f.Size, f.Hash)
+
+		if err != nil {
+			log.Errorf("upload: %s", err)
+			AbortBadRequest(c)
+			return
+		}
+
+		if m, err = upload.NewStore(conf.PartialUploadPath()).Create(m); err != nil {
+			log.Errorf("upload: %s", err)
+			Abort(c, http.StatusInternalServerError, i18n.ErrUploadFailed)
+			return
+		}
+
+		c.Header(UploadOffsetHeader, strconv.FormatInt(m.Offset, 10))
+		c.JSON(http.StatusOK, m)
+	})
+}
+
+// GetResumableUpload returns the upload progress, so that the client knows where to resume.
+//
+// GET /api/v1/users/:uid/upload/:token/resumable/:id
+func GetResumableUpload(router *gin.RouterGroup) {
+	router.GET("/users/:uid/upload/:token/resumable/:id", func(c *gin.Context) {
+		s, folder, ok := resumableUploadAuth(c)
+
+		if !ok {
+			return
+		}
+
+		m, ok := findResumableUpload(c, upload.NewStore(get.Config().PartialUploadPath()), s, folder)
+
+		if !ok {
+			return
+		}
+
+		c.Header(UploadOffsetHeader, strconv.FormatInt(m.Offset, 10))
+		c.JSON(http.StatusOK, m)
+	})
+}
+
+// UploadResumableChunk appends the request body to an upload, starting at the offset specified in the
+// Upload-Offset header. An optional Upload-Checksum header, e.g. "sha1 <base64>", is used to verify the chunk.
+// Once all chunks have been received, the file is moved to the upload folder so that it can be processed.
+//
+// PATCH /api/v1/users/:uid/upload/:token/resumable/:id
+func UploadResumableChunk(router *gin.RouterGroup) {
+	router.PATCH("/users/:uid/upload/:token/resumable/:id", func(c *gin.Context) {
+		s, folder, ok := resumableUploadAuth(c)
+
+		if !ok {
+			return
+		}
+
+		conf := get.Config()
+		store := upload.NewStore(conf.PartialUploadPath())
+
+		m, ok := findResumableUpload(c, store, s, folder)
+
+		if !ok {
+			return
+		}
+
+		offset, err := strconv.ParseInt(c.GetHeader(UploadOffsetHeader), 10, 64)
+
+		if err != nil || offset < 0 {
+			AbortBadRequest(c)
+			return
+		}
+
+		m, err = store.Write(m.ID, offset, c.Request.Body, c.GetHeader(UploadChecksumHeader))
+
+		if m != nil {
+			c.Header(UploadOffsetHeader, strconv.FormatInt(m.Offset, 10))
+		}
+
+		switch err {
+		case nil:
+		case upload.ErrInvalidOffset:
+			Abort(c, http.StatusConflict, i18n.ErrUploadFailed)
+			return
+		case upload.ErrTooLarge:
+			Abort(c, http.StatusRequestEntityTooLarge, i18n.ErrFileTooLarge)
+			return
+		default:
+			Error(c, http.StatusBadRequest, err, i18n.ErrUploadFailed)
+			return
+		}
+
+		if !m.Complete() {
+			c.JSON(http.StatusOK, m)
+			return
+		}
+
+		// Move the complete file to the upload folder, from where it can be processed.
+		uploadDir, err := conf.UserUploadPath(s.UserUID, folder)
+
+		if err != nil {
+			log.Errorf("upload: failed to create storage folder (%s)", err)
+			Abort(c, http.StatusBadRequest, i18n.ErrUploadFailed)
+			return
+		}
+
+		fileName, err := store.Finish(m.ID, uploadDir)
+
+		if err != nil {
+			Error(c, http.StatusBadRequest, err, i18n.ErrUploadFailed)
+			return
+		}
+
+		log.Debugf("upload: saved file %s", clean.Log(m.FileName))
+
+		// Check if uploaded file is safe.
+		if RemoveOffensiveUploads(conf, []string{fileName}) {
+			Abort(c, http.StatusForbidden, i18n.ErrOffensiveUpload)
+			return
+		}
+
+		event.Publish("upload.saved", event.Data{"uid": s.UserUID, "file": m.FileName})
+
+		c.JSON(http.StatusOK, m)
+	})
+}
+
+// DeleteResumableUpload cancels an upload and removes the data received so far.
+//
+// DELETE /api/v1/users/:uid/upload/:token/resumable/:id
+func DeleteResumableUpload(router *gin.RouterGroup) {
+	router.DELETE("/users/:uid/upload/:token/resumable/:id", func(c *gin.Context) {
+		s, folder, ok := resumableUploadAuth(c)
+
+		if !ok {
+			return
+		}
+
+		store := upload.NewStore(get.Config().PartialUploadPath())
+
+		m, ok := findResumableUpload(c, store, s, folder)
+
+		if !ok {
+			return
+		}
+
+		if err := store.Delete(m.ID); err != nil {
+			Error(c, http.StatusInternalServerError, err, i18n.ErrDeleteFailed)
+			return
+		}
+
+		c.JSON(http.StatusOK, m)
+	})
+}
@@ -0,0 +1,126 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tidwall/gjson"
+
+	"github.com/photoprism/photoprism/internal/entity"
+)
+
+func TestResumableUpload(t *testing.T) {
+	app, router, conf := NewApiTest()
+
+	CreateResumableUpload(router)
+	GetResumableUpload(router)
+	UploadResumableChunk(router)
+	DeleteResumableUpload(router)
+
+	adminUid := entity.Admin.UserUID
+	reqUrl := fmt.Sprintf("/api/v1/users/%s/upload/resumable123/resumable", adminUid)
+
+	patch := func(id, offset, body string) *httptest.ResponseRecorder {
+		req, _ := http.NewRequest("PATCH", reqUrl+"/"+id, strings.NewReader(body))
+		req.Header.Set(UploadOffsetHeader, offset)
+		w := httptest.NewRecorder()
+		app.ServeHTTP(w, req)
+		return w
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		r := PerformRequestWithBody(app, "POST", reqUrl, `{"name": "resumable.txt", "size": 10, "hash": "87acec17cd9dcd20a716cc2cf67417b71c8a7016"}`)
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, "0", r.Header().Get(UploadOffsetHeader))
+
+		id := gjson.Get(r.Body.String(), "ID").String()
+		folder := gjson.Get(r.Body.String(), "Folder").String()
+		assert.NotEmpty(t, id)
+		assert.True(t, strings.HasSuffix(folder, "resumable123"))
+
+		r = patch(id, "0", "01234")
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, "5", r.Header().Get(UploadOffsetHeader))
+
+		// Resume upload of the same file.
+		r = PerformRequestWithBody(app, "POST", reqUrl, `{"name": "resumable.txt", "size": 10, "hash": "87acec17cd9dcd20a716cc2cf67417b71c8a7016"}`)
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, id, gjson.Get(r.Body.String(), "ID").String())
+		assert.Equal(t, int64(5), gjson.Get(r.Body.String(), "Offset").Int())
+
+		r = PerformRequest(app, "GET", reqUrl+"/"+id)
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, "5", r.Header().Get(UploadOffsetHeader))
+
+		// Chunks must start at the current offset.
+		r = patch(id, "3", "34567")
+		assert.Equal(t, http.StatusConflict, r.Code)
+		assert.Equal(t, "5", r.Header().Get(UploadOffsetHeader))
+
+		r = patch(id, "5", "56789")
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, "10", r.Header().Get(UploadOffsetHeader))
+
+		uploadDir, err := conf.UserUploadPath(adminUid, folder)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		fileName := filepath.Join(uploadDir, "resumable.txt")
+
+		defer os.RemoveAll(uploadDir)
+
+		if data, err := os.ReadFile(fileName); err != nil {
+			t.Fatal(err)
+		} else {
+			assert.Equal(t, "0123456789", string(data))
+		}
+
+		r = PerformRequest(app, "GET", reqUrl+"/"+id)
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+	t.Run("Delete", func(t *testing.T) {
+		r := PerformRequestWithBody(app, "POST", reqUrl, `{"name": "delete.txt", "size": 10}`)
+		assert.Equal(t, http.StatusOK, r.Code)
+
+		id := gjson.Get(r.Body.String(), "ID").String()
+
+		r = PerformRequest(app, "DELETE", reqUrl+"/"+id)
+		assert.Equal(t, http.StatusOK, r.Code)
+
+		r = PerformRequest(app, "DELETE", reqUrl+"/"+id)
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+	t.Run("InvalidRequest", func(t *testing.T) {
+		r := PerformRequestWithBody(app, "POST", reqUrl, `{"name": "", "size": 10}`)
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+
+		r = PerformRequestWithBody(app, "POST", reqUrl, `{"name": "foo.jpg", "size": 0}`)
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+	t.Run("NotFound", func(t *testing.T) {
+		r := patch("abc123", "0", "01234")
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+	t.Run("InvalidOffset", func(t *testing.T) {
+		r := PerformRequestWithBody(app, "POST", reqUrl, `{"name": "offset.txt", "size": 10}`)
+		assert.Equal(t, http.StatusOK, r.Code)
+
+		id := gjson.Get(r.Body.String(), "ID").String()
+
+		defer PerformRequest(app, "DELETE", reqUrl+"/"+id)
+
+		r = patch(id, "foo", "01234")
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+
+		r = patch(id, "0", "0123456789ABC")
+		assert.Equal(t, http.StatusRequestEntityTooLarge, r.Code)
+	})
+}
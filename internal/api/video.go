@@ -7,6 +7,8 @@ import (
 
 	"github.com/gin-gonic/gin"
 
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/ffmpeg"
 	"github.com/photoprism/photoprism/internal/get"
 	"github.com/photoprism/photoprism/internal/photoprism"
 	"github.com/photoprism/photoprism/internal/query"
@@ -14,6 +16,52 @@ import (
 	"github.com/photoprism/photoprism/pkg/video"
 )
 
+// videoCodec returns the codec for transcoding a video in the requested format. Unless the client
+// requested the configured target codec, AVC is used for compatibility.
+func videoCodec(target ffmpeg.Codec, format video.Type) ffmpeg.Codec {
+	if target != ffmpeg.CodecAvc && format.Codec == target.Video() {
+		return target
+	}
+
+	return ffmpeg.CodecAvc
+}
+
+// findVideoFile returns the video file with the hash specified in the request, if it can be streamed.
+func findVideoFile(c *gin.Context) (*entity.File, bool) {
+	if InvalidPreviewToken(c) {
+		AbortForbidden(c)
+		return nil, false
+	} else if !get.Config().FFmpegEnabled() {
+		AbortFeatureDisabled(c)
+		return nil, false
+	}
+
+	f, err := query.FileByHash(clean.Token(c.Param("hash")))
+
+	if err != nil {
+		AbortEntityNotFound(c)
+		return nil, false
+	} else if NotInLibrary(c, f.PhotoUID) || NotShared(c, f.PhotoUID) {
+		AbortForbidden(c)
+		return nil, false
+	}
+
+	if !f.FileVideo {
+		if f, err = query.VideoByPhotoUID(f.PhotoUID); err != nil {
+			AbortEntityNotFound(c)
+			return nil, false
+		}
+	}
+
+	if f.FileError != "" || f.FileMissing || f.FileDuration <= 0 {
+		log.Debugf("video: %s cannot be streamed", clean.Log(f.FileName))
+		AbortEntityNotFound(c)
+		return nil, false
+	}
+
+	return f, true
+}
+
 // GetVideo streams videos.
 //
 // GET /api/v1/videos/:hash/:token/:type
@@ -46,6 +94,9 @@ func GetVideo(router *gin.RouterGroup) {
 			log.Errorf("video: requested file not found (%s)", err)
 			c.Data(http.StatusOK, "image/svg+xml", videoIconSvg)
 			return
+		} else if NotInLibrary(c, f.PhotoUID) || NotShared(c, f.PhotoUID) {
+			c.Data(http.StatusForbidden, "image/svg+xml", brokenIconSvg)
+			return
 		}
 
 		if !f.FileVideo {
@@ -67,6 +118,12 @@ func GetVideo(router *gin.RouterGroup) {
 		fileName := photoprism.FileName(f.FileRoot, f.FileName)
 		fileBitrate := f.Bitrate()
 
+		// Fetch original from remote bucket, if needed.
+		if remoteOriginal(f.FileRoot, f.FileName) {
+			release, _ := fetchOriginal(f.FileName)
+			defer release()
+		}
+
 		// File format supported by the client/browser?
 		supported := f.FileCodec != "" && f.FileCodec == string(format.Codec) || format.Codec == video.UnknownCodec && f.FileType == string(format.File)
 
@@ -90,16 +147,25 @@ func GetVideo(router *gin.RouterGroup) {
 			}
 
 			conv := get.Convert()
+			codec := videoCodec(conf.FFmpegCodec(), format)
+			videoFile, err := conv.ToVideo(mf, codec, conf.FFmpegEncoder(), false, false)
+
+			// Fall back to AVC if the target codec is not supported by FFmpeg.
+			if err != nil && codec != ffmpeg.CodecAvc {
+				log.Warnf("video: transcoding %s to %s failed, trying avc", clean.Log(f.FileName), codec)
+				codec = ffmpeg.CodecAvc
+				videoFile, err = conv.ToVideo(mf, codec, conf.FFmpegEncoder(), false, false)
+			}
 
-			if avcFile, err := conv.ToAvc(mf, get.Config().FFmpegEncoder(), false, false); err != nil {
+			if err != nil {
 				// Log error and default to 404.mp4
 				log.Errorf("video: transcoding %s failed", clean.Log(f.FileName))
 				fileName = get.Config().StaticFile("video/404.mp4")
 			} else {
-				fileName = avcFile.FileName()
+				fileName = videoFile.FileName()
 			}
 
-			AddContentTypeHeader(c, ContentTypeAvc)
+			AddContentTypeHeader(c, fmt.Sprintf("video/mp4; codecs=\"%s\"", codec.Video()))
 		} else {
 			if f.FileCodec != "" && f.FileCodec != f.FileType {
 				log.Debugf("video: %s is %s compressed and requires no transcoding, average bitrate %.1f MBit/s", clean.Log(f.FileName), clean.Log(strings.ToUpper(f.FileCodec)), fileBitrate)
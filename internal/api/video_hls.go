@@ -0,0 +1,101 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/photoprism/photoprism/internal/ffmpeg"
+	"github.com/photoprism/photoprism/internal/get"
+	"github.com/photoprism/photoprism/internal/photoprism"
+	"github.com/photoprism/photoprism/pkg/clean"
+)
+
+// HTTP Live Streaming (HLS) content types.
+const (
+	ContentTypeHlsPlaylist = "application/vnd.apple.mpegurl"
+	ContentTypeHlsSegment  = "video/mp2t"
+)
+
+// GetVideoPlaylist returns the HLS master playlist of a video, which lists the available renditions.
+//
+// GET /api/v1/videos/:hash/:token/hls/index.m3u8
+//
+// Parameters:
+//
+//	hash: string The photo or video file hash as returned by the search API
+func GetVideoPlaylist(router *gin.RouterGroup) {
+	router.GET("/videos/:hash/:token/hls/index.m3u8", func(c *gin.Context) {
+		f, ok := findVideoFile(c)
+
+		if !ok {
+			return
+		}
+
+		AddImmutableCacheHeader(c)
+		c.Data(http.StatusOK, ContentTypeHlsPlaylist, []byte(ffmpeg.HlsMasterPlaylist(f.FileWidth, f.FileHeight)))
+	})
+}
+
+// GetVideoStream returns the HLS media playlist of a video rendition, or a single video segment
+// that is transcoded on demand and then kept in the cache.
+//
+// GET /api/v1/videos/:hash/:token/hls/:rendition/:name
+//
+// Parameters:
+//
+//	hash: string The photo or video file hash as returned by the search API
+//	rendition: string Rendition name, e.g. 720p
+//	name: string Either index.m3u8 or the segment name, e.g. 12.ts
+func GetVideoStream(router *gin.RouterGroup) {
+	router.GET("/videos/:hash/:token/hls/:rendition/:name", func(c *gin.Context) {
+		r, found := ffmpeg.FindRendition(clean.Token(c.Param("rendition")))
+
+		if !found {
+			AbortEntityNotFound(c)
+			return
+		}
+
+		f, ok := findVideoFile(c)
+
+		if !ok {
+			return
+		}
+
+		name := c.Param("name")
+
+		// Return media playlist?
+		if name == "index.m3u8" {
+			AddImmutableCacheHeader(c)
+			c.Data(http.StatusOK, ContentTypeHlsPlaylist, []byte(ffmpeg.HlsMediaPlaylist(f.FileDuration)))
+			return
+		}
+
+		index := ffmpeg.HlsSegmentIndex(name)
+
+		if index < 0 || index >= ffmpeg.HlsSegments(f.FileDuration) {
+			AbortEntityNotFound(c)
+			return
+		}
+
+		fileName := photoprism.FileName(f.FileRoot, f.FileName)
+
+		// Fetch original from remote bucket, if needed.
+		if remoteOriginal(f.FileRoot, f.FileName) {
+			release, _ := fetchOriginal(f.FileName)
+			defer release()
+		}
+
+		segmentName, err := get.Convert().ToHls(fileName, f, r, index)
+
+		if err != nil {
+			log.Errorf("video: %s", err)
+			AbortEntityNotFound(c)
+			return
+		}
+
+		AddImmutableCacheHeader(c)
+		AddContentTypeHeader(c, ContentTypeHlsSegment)
+		c.File(segmentName)
+	})
+}
@@ -0,0 +1,104 @@
+package api
+
+import (
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/config"
+)
+
+// enableFFmpeg configures a fake ffmpeg binary and returns a function to restore the previous options.
+func enableFFmpeg(t *testing.T, conf *config.Config) func() {
+	binName := filepath.Join(t.TempDir(), "ffmpeg")
+
+	if err := os.WriteFile(binName, []byte("#!/bin/sh\nexit 1\n"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	bin, disabled := conf.Options().FFmpegBin, conf.Options().DisableFFmpeg
+	conf.Options().FFmpegBin = binName
+	conf.Options().DisableFFmpeg = false
+
+	return func() {
+		conf.Options().FFmpegBin = bin
+		conf.Options().DisableFFmpeg = disabled
+	}
+}
+
+func TestGetVideoPlaylist(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		defer enableFFmpeg(t, conf)()
+		GetVideoPlaylist(router)
+		GetVideo(router)
+		r := PerformRequest(app, "GET", "/api/v1/videos/acad9168fa6acc5c5c2965ddf6ec465ca42fd831/"+conf.PreviewToken()+"/hls/index.m3u8")
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, ContentTypeHlsPlaylist, r.Header().Get("Content-Type"))
+		assert.True(t, strings.HasPrefix(r.Body.String(), "#EXTM3U\n"))
+		assert.Contains(t, r.Body.String(), "RESOLUTION=360x480")
+		assert.Contains(t, r.Body.String(), "1080p/index.m3u8")
+		assert.NotContains(t, r.Body.String(), "2160p")
+	})
+	t.Run("NotFound", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		defer enableFFmpeg(t, conf)()
+		GetVideoPlaylist(router)
+		r := PerformRequest(app, "GET", "/api/v1/videos/xxx/"+conf.PreviewToken()+"/hls/index.m3u8")
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+	t.Run("FileError", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		defer enableFFmpeg(t, conf)()
+		GetVideoPlaylist(router)
+		r := PerformRequest(app, "GET", "/api/v1/videos/acad9168fa6acc5c5c2965ddf6ec465ca42fd832/"+conf.PreviewToken()+"/hls/index.m3u8")
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+	t.Run("InvalidToken", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		conf.SetAuthMode(config.AuthModePasswd)
+		defer conf.SetAuthMode(config.AuthModePublic)
+		GetVideoPlaylist(router)
+		r := PerformRequest(app, "GET", "/api/v1/videos/acad9168fa6acc5c5c2965ddf6ec465ca42fd831/xxx/hls/index.m3u8")
+		assert.Equal(t, http.StatusForbidden, r.Code)
+	})
+}
+
+func TestGetVideoStream(t *testing.T) {
+	t.Run("MediaPlaylist", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		defer enableFFmpeg(t, conf)()
+		GetVideoStream(router)
+		r := PerformRequest(app, "GET", "/api/v1/videos/acad9168fa6acc5c5c2965ddf6ec465ca42fd831/"+conf.PreviewToken()+"/hls/720p/index.m3u8")
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, ContentTypeHlsPlaylist, r.Header().Get("Content-Type"))
+		assert.Contains(t, r.Body.String(), "#EXTINF:6.000,\n0.ts\n")
+		assert.Contains(t, r.Body.String(), "#EXTINF:5.000,\n2.ts\n")
+		assert.True(t, strings.HasSuffix(r.Body.String(), "#EXT-X-ENDLIST\n"))
+	})
+	t.Run("InvalidRendition", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		defer enableFFmpeg(t, conf)()
+		GetVideoStream(router)
+		r := PerformRequest(app, "GET", "/api/v1/videos/acad9168fa6acc5c5c2965ddf6ec465ca42fd831/"+conf.PreviewToken()+"/hls/480p/index.m3u8")
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+	t.Run("InvalidSegment", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		defer enableFFmpeg(t, conf)()
+		GetVideoStream(router)
+		r := PerformRequest(app, "GET", "/api/v1/videos/acad9168fa6acc5c5c2965ddf6ec465ca42fd831/"+conf.PreviewToken()+"/hls/720p/3.ts")
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+	t.Run("FileMissing", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		defer enableFFmpeg(t, conf)()
+		GetVideoStream(router)
+		r := PerformRequest(app, "GET", "/api/v1/videos/acad9168fa6acc5c5c2965ddf6ec465ca42fd831/"+conf.PreviewToken()+"/hls/720p/0.ts")
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+}
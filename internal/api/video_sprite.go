@@ -0,0 +1,96 @@
+package api
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"github.com/photoprism/photoprism/internal/get"
+	"github.com/photoprism/photoprism/internal/photoprism"
+	"github.com/photoprism/photoprism/pkg/clean"
+	"github.com/photoprism/photoprism/pkg/fs"
+)
+
+// ContentTypeVTT is the content type of WebVTT thumbnail tracks.
+const ContentTypeVTT = "text/vtt; charset=utf-8"
+
+// videoSprite returns the file name of a video sprite sheet image or thumbnail track, and creates it if needed.
+func videoSprite(c *gin.Context, ext string) (string, bool) {
+	f, ok := findVideoFile(c)
+
+	if !ok {
+		return "", false
+	}
+
+	conf := get.Config()
+
+	fileName, err := photoprism.SpriteFileName(f.FileHash, conf.ThumbCachePath(), ext)
+
+	if err != nil {
+		log.Errorf("video: %s", err)
+		AbortEntityNotFound(c)
+		return "", false
+	} else if fs.FileExists(fileName) {
+		return fileName, true
+	}
+
+	videoName := photoprism.FileName(f.FileRoot, f.FileName)
+
+	// Fetch original from remote bucket, if needed.
+	if remoteOriginal(f.FileRoot, f.FileName) {
+		release, _ := fetchOriginal(f.FileName)
+		defer release()
+	}
+
+	if !fs.FileExists(videoName) {
+		log.Errorf("video: file %s is missing", clean.Log(f.FileName))
+		AbortEntityNotFound(c)
+		return "", false
+	} else if err = photoprism.CreateSprite(videoName, f.FileHash, conf.ThumbCachePath(), f.FileDuration, f.FileWidth, f.FileHeight, false); err != nil {
+		log.Errorf("video: %s", err)
+		AbortEntityNotFound(c)
+		return "", false
+	}
+
+	return fileName, true
+}
+
+// GetVideoSprite returns a sprite sheet image with frames taken at regular intervals, e.g. for hover previews.
+//
+// GET /api/v1/videos/:hash/:token/sprite.jpg
+//
+// Parameters:
+//
+//	hash: string The photo or video file hash as returned by the search API
+func GetVideoSprite(router *gin.RouterGroup) {
+	router.GET("/videos/:hash/:token/"+photoprism.SpriteImage, func(c *gin.Context) {
+		fileName, ok := videoSprite(c, fs.ExtJPEG)
+
+		if !ok {
+			return
+		}
+
+		AddImmutableCacheHeader(c)
+		c.File(fileName)
+	})
+}
+
+// GetVideoSpriteTrack returns a WebVTT thumbnail track that refers to the frames in the sprite sheet image,
+// so that the player can show scrubbing previews.
+//
+// GET /api/v1/videos/:hash/:token/sprite.vtt
+//
+// Parameters:
+//
+//	hash: string The photo or video file hash as returned by the search API
+func GetVideoSpriteTrack(router *gin.RouterGroup) {
+	router.GET("/videos/:hash/:token/sprite.vtt", func(c *gin.Context) {
+		fileName, ok := videoSprite(c, ".vtt")
+
+		if !ok {
+			return
+		}
+
+		AddImmutableCacheHeader(c)
+		AddContentTypeHeader(c, ContentTypeVTT)
+		c.File(fileName)
+	})
+}
@@ -0,0 +1,78 @@
+package api
+
+import (
+	"net/http"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/photoprism"
+	"github.com/photoprism/photoprism/pkg/fs"
+)
+
+func TestGetVideoSprite(t *testing.T) {
+	t.Run("Cached", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		defer enableFFmpeg(t, conf)()
+		GetVideoSprite(router)
+
+		fileName, err := photoprism.SpriteFileName("acad9168fa6acc5c5c2965ddf6ec465ca42fd831", conf.ThumbCachePath(), fs.ExtJPEG)
+
+		if err != nil {
+			t.Fatal(err)
+		} else if err = os.WriteFile(fileName, []byte("sprite"), fs.ModeFile); err != nil {
+			t.Fatal(err)
+		}
+
+		defer os.Remove(fileName)
+
+		r := PerformRequest(app, "GET", "/api/v1/videos/acad9168fa6acc5c5c2965ddf6ec465ca42fd831/"+conf.PreviewToken()+"/sprite.jpg")
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, "sprite", r.Body.String())
+	})
+	t.Run("FileMissing", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		defer enableFFmpeg(t, conf)()
+		GetVideoSprite(router)
+		r := PerformRequest(app, "GET", "/api/v1/videos/acad9168fa6acc5c5c2965ddf6ec465ca42fd831/"+conf.PreviewToken()+"/sprite.jpg")
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+	t.Run("NotFound", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		defer enableFFmpeg(t, conf)()
+		GetVideoSprite(router)
+		r := PerformRequest(app, "GET", "/api/v1/videos/xxx/"+conf.PreviewToken()+"/sprite.jpg")
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+}
+
+func TestGetVideoSpriteTrack(t *testing.T) {
+	t.Run("Cached", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		defer enableFFmpeg(t, conf)()
+		GetVideoSpriteTrack(router)
+
+		fileName, err := photoprism.SpriteFileName("acad9168fa6acc5c5c2965ddf6ec465ca42fd831", conf.ThumbCachePath(), ".vtt")
+
+		if err != nil {
+			t.Fatal(err)
+		} else if err = os.WriteFile(fileName, []byte("WEBVTT\n"), fs.ModeFile); err != nil {
+			t.Fatal(err)
+		}
+
+		defer os.Remove(fileName)
+
+		r := PerformRequest(app, "GET", "/api/v1/videos/acad9168fa6acc5c5c2965ddf6ec465ca42fd831/"+conf.PreviewToken()+"/sprite.vtt")
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, ContentTypeVTT, r.Header().Get("Content-Type"))
+		assert.Equal(t, "WEBVTT\n", r.Body.String())
+	})
+	t.Run("FileMissing", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		defer enableFFmpeg(t, conf)()
+		GetVideoSpriteTrack(router)
+		r := PerformRequest(app, "GET", "/api/v1/videos/acad9168fa6acc5c5c2965ddf6ec465ca42fd831/"+conf.PreviewToken()+"/sprite.vtt")
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+}
@@ -8,7 +8,9 @@ import (
 	"github.com/stretchr/testify/assert"
 
 	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/ffmpeg"
 	"github.com/photoprism/photoprism/pkg/clean"
+	"github.com/photoprism/photoprism/pkg/video"
 )
 
 func TestGetVideo(t *testing.T) {
@@ -59,3 +61,11 @@ func TestGetVideo(t *testing.T) {
 		assert.Equal(t, http.StatusOK, r.Code)
 	})
 }
+
+func TestVideoCodec(t *testing.T) {
+	assert.Equal(t, ffmpeg.CodecAvc, videoCodec(ffmpeg.CodecAvc, video.HEVC))
+	assert.Equal(t, ffmpeg.CodecHevc, videoCodec(ffmpeg.CodecHevc, video.HEVC))
+	assert.Equal(t, ffmpeg.CodecAvc, videoCodec(ffmpeg.CodecHevc, video.AVC))
+	assert.Equal(t, ffmpeg.CodecAvc, videoCodec(ffmpeg.CodecHevc, video.AV1))
+	assert.Equal(t, ffmpeg.CodecAv1, videoCodec(ffmpeg.CodecAv1, video.AV1))
+}
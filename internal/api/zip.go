@@ -19,6 +19,7 @@ import (
 	"github.com/photoprism/photoprism/internal/i18n"
 	"github.com/photoprism/photoprism/internal/photoprism"
 	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/internal/search"
 	"github.com/photoprism/photoprism/pkg/clean"
 	"github.com/photoprism/photoprism/pkg/fs"
 	"github.com/photoprism/photoprism/pkg/rnd"
@@ -104,9 +105,20 @@ func ZipCreate(router *gin.RouterGroup) {
 		}(zipWriter)
 
 		var aliases = make(map[string]int)
+		var photoUids []string
 
 		// Add files to zip.
 		for _, file := range files {
+			if !search.InLibrary(s, file.PhotoUID) {
+				log.Warnf("zip: skipped %s, not in library", clean.Log(file.FileName))
+				continue
+			} else if !search.Downloadable(s, file.PhotoUID) {
+				log.Warnf("zip: skipped %s, download not permitted", clean.Log(file.FileName))
+				continue
+			}
+
+			photoUids = append(photoUids, file.PhotoUID)
+
 			fileName := photoprism.FileName(file.FileRoot, file.FileName)
 			alias := file.DownloadName(dlName, 0)
 			key := strings.ToLower(alias)
@@ -117,8 +129,11 @@ func ZipCreate(router *gin.RouterGroup) {
 
 			aliases[key] += 1
 
-			if fs.FileExists(fileName) {
-				if err := addFileToZip(zipWriter, fileName, alias); err != nil {
+			if exists, release := fileExists(file.FileRoot, file.FileName); exists {
+				err := addFileToZip(zipWriter, fileName, alias)
+				release()
+
+				if err != nil {
 					log.Errorf("zip: failed adding %s to zip (%s)", clean.Log(file.FileName), err)
 					Abort(c, http.StatusInternalServerError, i18n.ErrZipFailed)
 					return
@@ -131,6 +146,13 @@ func ZipCreate(router *gin.RouterGroup) {
 			}
 		}
 
+		// Count the download against the limits of the share links used, if any.
+		if search.SharedOnly(s) {
+			for _, uid := range search.DownloadShares(s, photoUids) {
+				s.CountDownload(uid)
+			}
+		}
+
 		elapsed := int(time.Since(start).Seconds())
 
 		log.Infof("zip: created %s [%s]", clean.Log(zipBaseName), time.Since(start))
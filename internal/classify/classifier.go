@@ -0,0 +1,145 @@
+package classify
+
+import (
+	"bufio"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/photoprism/photoprism/pkg/clean"
+	"github.com/photoprism/photoprism/pkg/fs"
+)
+
+// Classifier represents an image classification backend.
+type Classifier interface {
+	Init() error
+	File(filename string) (Labels, error)
+	ModelName() string
+	ModelLoaded() bool
+}
+
+// Load returns a classifier for the TensorFlow or ONNX model in the specified directory, which must contain
+// a label file and may contain a model.yml configuration as well as custom label rules.
+func Load(modelPath string, disabled bool) (Classifier, error) {
+	m, err := LoadModel(modelPath)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if !fs.FileExists(filepath.Join(modelPath, ModelLabelsFile)) {
+		return nil, fmt.Errorf("classify: found no %s in %s", ModelLabelsFile, clean.Log(filepath.Base(modelPath)))
+	}
+
+	switch m.Type {
+	case ModelTensorFlow:
+		return NewTensorFlow(modelPath, m, disabled), nil
+	case ModelONNX:
+		return NewONNX(modelPath, m, disabled), nil
+	default:
+		return nil, fmt.Errorf("classify: unsupported model type %s", clean.Log(m.Type))
+	}
+}
+
+// NewClassifier returns a classifier for the model in the specified directory,
+// or for the built-in model if no path was specified or the model could not be loaded.
+func NewClassifier(assetsPath, modelPath string, disabled bool) Classifier {
+	if modelPath == "" {
+		return New(assetsPath, disabled)
+	}
+
+	c, err := Load(modelPath, disabled)
+
+	if err != nil {
+		log.Errorf("%s, using built-in model", err)
+		return New(assetsPath, disabled)
+	}
+
+	log.Debugf("classify: using %s model", clean.Log(c.ModelName()))
+
+	return c
+}
+
+// modelLabels returns the labels of the model in the specified directory, which are separated by newlines.
+func modelLabels(modelPath string) (labels []string, err error) {
+	log.Infof("classify: loading labels from %s", ModelLabelsFile)
+
+	f, err := os.Open(filepath.Join(modelPath, ModelLabelsFile))
+
+	if err != nil {
+		return labels, err
+	}
+
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+
+	for scanner.Scan() {
+		labels = append(labels, scanner.Text())
+	}
+
+	return labels, scanner.Err()
+}
+
+// modelRules returns the custom label rules of the model in the specified directory, or the bundled rules otherwise.
+func modelRules(modelPath string) (LabelRules, error) {
+	fileName := filepath.Join(modelPath, ModelRulesFile)
+
+	if !fs.FileExists(fileName) {
+		return Rules, nil
+	}
+
+	log.Infof("classify: loading label rules from %s", ModelRulesFile)
+
+	return LoadRules(fileName)
+}
+
+// topLabels returns the best 5 labels (if enough high probability labels) from the prediction of a model.
+func topLabels(probabilities []float32, labels []string, rules LabelRules, modelName string) Labels {
+	var result Labels
+
+	for i, p := range probabilities {
+		if i >= len(labels) {
+			// break if probabilities and labels does not match
+			break
+		}
+
+		// discard labels with low probabilities
+		if p < 0.1 {
+			continue
+		}
+
+		labelText := strings.ToLower(labels[i])
+
+		rule, _ := rules.Find(labelText)
+
+		// discard labels that don't met the threshold
+		if p < rule.Threshold {
+			continue
+		}
+
+		// Get rule label name instead of the model label name if it exists
+		if rule.Label != "" {
+			labelText = rule.Label
+		}
+
+		labelText = strings.TrimSpace(labelText)
+
+		uncertainty := 100 - int(math.Round(float64(p*100)))
+
+		result = append(result, Label{Name: labelText, Source: SrcImage, Model: modelName, Uncertainty: uncertainty, Priority: rule.Priority, Categories: rule.Categories})
+	}
+
+	// Sort by probability
+	sort.Sort(result)
+
+	// Return the best labels only.
+	if l := len(result); l < 5 {
+		return result[:l]
+	} else {
+		return result[:5]
+	}
+}
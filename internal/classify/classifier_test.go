@@ -0,0 +1,64 @@
+package classify
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoad(t *testing.T) {
+	t.Run("TensorFlow", func(t *testing.T) {
+		c, err := Load("testdata/efficientnet", true)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.IsType(t, &TensorFlow{}, c)
+		assert.Equal(t, "efficientnet", c.ModelName())
+		assert.False(t, c.ModelLoaded())
+		assert.Nil(t, c.Init())
+	})
+	t.Run("ONNX", func(t *testing.T) {
+		c, err := Load("testdata/onnx", true)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.IsType(t, &ONNX{}, c)
+		assert.Equal(t, "onnx", c.ModelName())
+		assert.False(t, c.ModelLoaded())
+		assert.Nil(t, c.Init())
+	})
+	t.Run("UnsupportedType", func(t *testing.T) {
+		c, err := Load("testdata/pytorch", false)
+
+		assert.Nil(t, c)
+		assert.EqualError(t, err, "classify: unsupported model type pytorch")
+	})
+	t.Run("NoLabels", func(t *testing.T) {
+		c, err := Load("testdata/empty", false)
+
+		assert.Nil(t, c)
+		assert.Error(t, err)
+	})
+}
+
+func TestNewClassifier(t *testing.T) {
+	t.Run("Default", func(t *testing.T) {
+		c := NewClassifier(assetsPath, "", true)
+
+		assert.Equal(t, DefaultModel, c.ModelName())
+	})
+	t.Run("Custom", func(t *testing.T) {
+		c := NewClassifier(assetsPath, "testdata/efficientnet", true)
+
+		assert.Equal(t, "efficientnet", c.ModelName())
+	})
+	t.Run("Fallback", func(t *testing.T) {
+		c := NewClassifier(assetsPath, "testdata/pytorch", true)
+
+		assert.Equal(t, DefaultModel, c.ModelName())
+	})
+}
@@ -1,6 +1,9 @@
 /*
 Package classify encapsulates image classification using TensorFlow.
 
+Custom TensorFlow SavedModels and ONNX models with their own labels and rules can be used instead
+of the built-in model. ONNX models are run with the ONNX Runtime shared library, which is loaded on demand.
+
 Copyright (c) 2018 - 2023 PhotoPrism UG. All rights reserved.
 
 	This program is free software: you can redistribute it and/or modify
@@ -10,6 +10,7 @@ import (
 type Label struct {
 	Name        string   `json:"label"`       // Label name
 	Source      string   `json:"source"`      // Where was this label found / detected?
+	Model       string   `json:"model"`       // Name of the classification model, if any
 	Uncertainty int      `json:"uncertainty"` // >= 0
 	Priority    int      `json:"priority"`    // >= 0
 	Categories  []string `json:"categories"`  // List of similar labels
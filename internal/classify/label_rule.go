@@ -1,5 +1,17 @@
 package classify
 
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+
+	"github.com/photoprism/photoprism/pkg/clean"
+	"github.com/photoprism/photoprism/pkg/fs"
+)
+
 // LabelRule defines the rule for a given Label
 type LabelRule struct {
 	Label      string
@@ -19,3 +31,61 @@ func (rules LabelRules) Find(label string) (rule LabelRule, ok bool) {
 
 	return LabelRule{Threshold: 0.1}, false
 }
+
+// LoadRules reads label rules from a YAML file in the same format as the bundled rules.yml,
+// so that custom models can map their own labels.
+func LoadRules(fileName string) (LabelRules, error) {
+	if !fs.FileExists(fileName) {
+		return nil, fmt.Errorf("classify: found no label rules in %s", clean.Log(filepath.Base(fileName)))
+	}
+
+	yamlConfig, err := os.ReadFile(fileName)
+
+	if err != nil {
+		return nil, err
+	}
+
+	var values map[string]struct {
+		Label      string   `yaml:"label"`
+		See        string   `yaml:"see"`
+		Threshold  float32  `yaml:"threshold"`
+		Categories []string `yaml:"categories"`
+		Priority   int      `yaml:"priority"`
+	}
+
+	if err = yaml.Unmarshal(yamlConfig, &values); err != nil {
+		return nil, fmt.Errorf("classify: %s in %s", err, clean.Log(filepath.Base(fileName)))
+	}
+
+	rules := make(LabelRules, len(values))
+
+	for label, value := range values {
+		if value.See != "" {
+			continue
+		}
+
+		rules[strings.ToLower(label)] = LabelRule{
+			Label:      value.Label,
+			Threshold:  value.Threshold,
+			Categories: value.Categories,
+			Priority:   value.Priority,
+		}
+	}
+
+	// Resolve references to the rules of other labels.
+	for label, value := range values {
+		if value.See == "" {
+			continue
+		}
+
+		rule, ok := rules[strings.ToLower(value.See)]
+
+		if !ok {
+			return nil, fmt.Errorf("classify: missing label %s in %s", clean.Log(value.See), clean.Log(filepath.Base(fileName)))
+		}
+
+		rules[strings.ToLower(label)] = rule
+	}
+
+	return rules, nil
+}
@@ -36,3 +36,36 @@ func TestLabelRule_Find(t *testing.T) {
 		assert.Equal(t, float32(0.1), result.Threshold)
 	})
 }
+
+func TestLoadRules(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		rules, err := LoadRules("testdata/efficientnet/rules.yml")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Len(t, rules, 4)
+
+		cat, ok := rules.Find("tabby")
+		assert.True(t, ok)
+		assert.Equal(t, "cat", cat.Label)
+		assert.Equal(t, 5, cat.Priority)
+		assert.Equal(t, float32(0.2), cat.Threshold)
+		assert.Equal(t, []string{"animal"}, cat.Categories)
+
+		beach, ok := rules.Find("seashore")
+		assert.True(t, ok)
+		assert.Equal(t, "beach", beach.Label)
+
+		coast, ok := rules.Find("coast")
+		assert.True(t, ok)
+		assert.Equal(t, beach, coast)
+	})
+	t.Run("NotFound", func(t *testing.T) {
+		rules, err := LoadRules("testdata/foo.yml")
+
+		assert.Nil(t, rules)
+		assert.EqualError(t, err, "classify: found no label rules in foo.yml")
+	})
+}
@@ -0,0 +1,144 @@
+package classify
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+
+	"github.com/photoprism/photoprism/pkg/clean"
+	"github.com/photoprism/photoprism/pkg/fs"
+)
+
+// Supported model types.
+const (
+	ModelTensorFlow = "tensorflow"
+	ModelONNX       = "onnx"
+)
+
+// Supported input tensor layouts.
+const (
+	LayoutNHWC = "nhwc"
+	LayoutNCHW = "nchw"
+)
+
+// Model file names.
+const (
+	ModelConfigFile = "model.yml"
+	ModelLabelsFile = "labels.txt"
+	ModelRulesFile  = "rules.yml"
+	ModelONNXFile   = "model.onnx"
+	ModelSavedFile  = "saved_model.pb"
+)
+
+// DefaultModel is the name of the bundled Nasnet model.
+const DefaultModel = "nasnet"
+
+// Model represents the configuration of an image classification model, as found in model.yml.
+type Model struct {
+	Name       string   `yaml:"Name,omitempty" json:"name"`
+	Type       string   `yaml:"Type,omitempty" json:"type"`
+	Tags       []string `yaml:"Tags,omitempty" json:"tags"`
+	Input      string   `yaml:"Input,omitempty" json:"input"`
+	Output     string   `yaml:"Output,omitempty" json:"output"`
+	Layout     string   `yaml:"Layout,omitempty" json:"layout"`
+	Resolution int      `yaml:"Resolution,omitempty" json:"resolution"`
+	Mean       float32  `yaml:"Mean,omitempty" json:"mean"`
+	Std        float32  `yaml:"Std,omitempty" json:"std"`
+	Softmax    bool     `yaml:"Softmax,omitempty" json:"softmax"`
+}
+
+// NewModel returns the configuration of the bundled Nasnet model.
+func NewModel() Model {
+	return Model{
+		Name:       DefaultModel,
+		Type:       ModelTensorFlow,
+		Tags:       []string{"photoprism"},
+		Input:      "input_1",
+		Output:     "predictions/Softmax",
+		Layout:     LayoutNHWC,
+		Resolution: 224,
+		Mean:       127.5,
+		Std:        127.5,
+	}
+}
+
+// LoadModel returns the configuration of the model in the specified directory.
+// Missing values default to those of the bundled model, so that model.yml is optional
+// for TensorFlow models exported in the same way. ONNX models use their first input and
+// output if no names are configured, and expect images in NCHW layout by default.
+func LoadModel(modelPath string) (m Model, err error) {
+	m = NewModel()
+	m.Name = clean.TypeLower(filepath.Base(modelPath))
+	m.Type = ""
+	m.Input = ""
+	m.Output = ""
+	m.Layout = ""
+
+	if !fs.PathExists(modelPath) {
+		return m, fmt.Errorf("classify: model %s not found", clean.Log(filepath.Base(modelPath)))
+	}
+
+	if fileName := filepath.Join(modelPath, ModelConfigFile); fs.FileExists(fileName) {
+		yamlConfig, err := os.ReadFile(fileName)
+
+		if err != nil {
+			return m, err
+		}
+
+		if err = yaml.Unmarshal(yamlConfig, &m); err != nil {
+			return m, fmt.Errorf("classify: %s in %s", err, clean.Log(ModelConfigFile))
+		}
+	}
+
+	m.Type = strings.ToLower(strings.TrimSpace(m.Type))
+	m.Layout = strings.ToLower(strings.TrimSpace(m.Layout))
+
+	// Detect the model type based on the files found if not specified.
+	if m.Type == "" {
+		if fs.FileExists(filepath.Join(modelPath, ModelONNXFile)) {
+			m.Type = ModelONNX
+		} else if fs.FileExists(filepath.Join(modelPath, ModelSavedFile)) {
+			m.Type = ModelTensorFlow
+		} else {
+			return m, fmt.Errorf("classify: unknown model type in %s", clean.Log(filepath.Base(modelPath)))
+		}
+	}
+
+	// Set type specific defaults.
+	switch m.Type {
+	case ModelTensorFlow:
+		defaults := NewModel()
+
+		if m.Input == "" {
+			m.Input = defaults.Input
+		}
+
+		if m.Output == "" {
+			m.Output = defaults.Output
+		}
+
+		m.Layout = LayoutNHWC
+	case ModelONNX:
+		if m.Layout == "" {
+			m.Layout = LayoutNCHW
+		}
+	}
+
+	if m.Resolution <= 0 {
+		return m, fmt.Errorf("classify: invalid model resolution %d", m.Resolution)
+	} else if m.Layout != "" && m.Layout != LayoutNHWC && m.Layout != LayoutNCHW {
+		return m, fmt.Errorf("classify: invalid input layout %s", clean.Log(m.Layout))
+	} else if m.Std == 0 {
+		m.Std = 1
+	}
+
+	return m, nil
+}
+
+// Normalize converts a 16-bit color channel value to the input range of the model.
+func (m Model) Normalize(value uint32) float32 {
+	return (float32(value>>8) - m.Mean) / m.Std
+}
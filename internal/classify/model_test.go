@@ -0,0 +1,71 @@
+package classify
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewModel(t *testing.T) {
+	m := NewModel()
+
+	assert.Equal(t, DefaultModel, m.Name)
+	assert.Equal(t, ModelTensorFlow, m.Type)
+	assert.Equal(t, []string{"photoprism"}, m.Tags)
+	assert.Equal(t, 224, m.Resolution)
+}
+
+func TestLoadModel(t *testing.T) {
+	t.Run("TensorFlow", func(t *testing.T) {
+		m, err := LoadModel("testdata/efficientnet")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "efficientnet", m.Name)
+		assert.Equal(t, ModelTensorFlow, m.Type)
+		assert.Equal(t, []string{"serve"}, m.Tags)
+		assert.Equal(t, "serving_default_input_1", m.Input)
+		assert.Equal(t, "StatefulPartitionedCall", m.Output)
+		assert.Equal(t, 260, m.Resolution)
+		assert.Equal(t, float32(0), m.Mean)
+		assert.Equal(t, float32(1), m.Std)
+		assert.Equal(t, LayoutNHWC, m.Layout)
+	})
+	t.Run("ONNX", func(t *testing.T) {
+		m, err := LoadModel("testdata/onnx")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "onnx", m.Name)
+		assert.Equal(t, ModelONNX, m.Type)
+		assert.Equal(t, "", m.Input)
+		assert.Equal(t, "", m.Output)
+		assert.Equal(t, LayoutNCHW, m.Layout)
+		assert.Equal(t, 224, m.Resolution)
+		assert.False(t, m.Softmax)
+	})
+	t.Run("UnknownType", func(t *testing.T) {
+		_, err := LoadModel("testdata/empty")
+
+		assert.EqualError(t, err, "classify: unknown model type in empty")
+	})
+	t.Run("NotFound", func(t *testing.T) {
+		_, err := LoadModel("testdata/foo")
+
+		assert.EqualError(t, err, "classify: model foo not found")
+	})
+}
+
+func TestModel_Normalize(t *testing.T) {
+	t.Run("Default", func(t *testing.T) {
+		assert.Equal(t, float32(3024.898), NewModel().Normalize(uint32(98765432)))
+	})
+	t.Run("Unscaled", func(t *testing.T) {
+		m := Model{Mean: 0, Std: 1}
+		assert.Equal(t, float32(255), m.Normalize(uint32(65535)))
+	})
+}
@@ -0,0 +1,281 @@
+package classify
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"math"
+	"os"
+	"path/filepath"
+	"runtime/debug"
+	"sync"
+
+	"github.com/disintegration/imaging"
+	ort "github.com/yalue/onnxruntime_go"
+
+	"github.com/photoprism/photoprism/pkg/clean"
+)
+
+// ONNXRuntime is the ONNX Runtime shared library that is loaded when the first ONNX model is used.
+var ONNXRuntime = "libonnxruntime.so"
+
+var onnxMutex = sync.Mutex{}
+
+// ONNX is an image classification backend for models in ONNX format.
+type ONNX struct {
+	session   *ort.DynamicAdvancedSession
+	modelPath string
+	disabled  bool
+	config    Model
+	labels    []string
+	rules     LabelRules
+}
+
+// NewONNX returns a new ONNX instance with the model in the specified directory.
+func NewONNX(modelPath string, config Model, disabled bool) *ONNX {
+	return &ONNX{modelPath: modelPath, disabled: disabled, config: config}
+}
+
+// ModelName returns the model name, which is stored with the labels it detects.
+func (t *ONNX) ModelName() string {
+	return t.config.Name
+}
+
+// Init loads the ONNX model if not disabled.
+func (t *ONNX) Init() (err error) {
+	if t.disabled {
+		return nil
+	}
+
+	return t.loadModel()
+}
+
+// File returns matching labels for a jpeg media file.
+func (t *ONNX) File(filename string) (result Labels, err error) {
+	if t.disabled {
+		return result, nil
+	}
+
+	imageBuffer, err := os.ReadFile(filename)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return t.Labels(imageBuffer)
+}
+
+// Labels returns matching labels for a jpeg media string.
+func (t *ONNX) Labels(img []byte) (result Labels, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("classify: %s (inference panic)\nstack: %s", r, debug.Stack())
+		}
+	}()
+
+	if t.disabled {
+		return result, nil
+	}
+
+	if err = t.loadModel(); err != nil {
+		return nil, err
+	}
+
+	// Create tensor from image.
+	input, err := t.createTensor(img)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer input.Destroy()
+
+	// Run inference, the output tensor is allocated by the runtime.
+	output := []ort.Value{nil}
+
+	if err = t.session.Run([]ort.Value{input}, output); err != nil {
+		return result, fmt.Errorf("classify: %s (run inference)", err)
+	} else if output[0] == nil {
+		return result, fmt.Errorf("classify: inference failed, no output")
+	}
+
+	defer output[0].Destroy()
+
+	tensor, ok := output[0].(*ort.Tensor[float32])
+
+	if !ok {
+		return result, fmt.Errorf("classify: unsupported output type %s", output[0].GetONNXType())
+	}
+
+	probabilities := tensor.GetData()
+
+	if t.config.Softmax {
+		probabilities = softmax(probabilities)
+	}
+
+	// Return best labels
+	result = topLabels(probabilities, t.labels, t.rules, t.ModelName())
+
+	if len(result) > 0 {
+		log.Tracef("classify: image classified as %+v", result)
+	}
+
+	return result, nil
+}
+
+// ModelLoaded tests if the ONNX model is loaded.
+func (t *ONNX) ModelLoaded() bool {
+	return t.session != nil
+}
+
+func (t *ONNX) loadModel() (err error) {
+	if t.ModelLoaded() {
+		return nil
+	}
+
+	if err = initONNXRuntime(); err != nil {
+		return err
+	}
+
+	modelPath := t.modelPath
+	fileName := filepath.Join(modelPath, ModelONNXFile)
+
+	log.Infof("classify: loading %s", clean.Log(filepath.Base(modelPath)))
+
+	// Use the first input and output of the model if not configured.
+	input, output := t.config.Input, t.config.Output
+
+	if input == "" || output == "" {
+		inputs, outputs, err := ort.GetInputOutputInfo(fileName)
+
+		if err != nil {
+			return fmt.Errorf("classify: %s (read model)", err)
+		} else if len(inputs) == 0 || len(outputs) == 0 {
+			return fmt.Errorf("classify: %s has no inputs or outputs", clean.Log(filepath.Base(modelPath)))
+		}
+
+		if input == "" {
+			input = inputs[0].Name
+		}
+
+		if output == "" {
+			output = outputs[0].Name
+		}
+	}
+
+	// Load model
+	session, err := ort.NewDynamicAdvancedSession(fileName, []string{input}, []string{output}, nil)
+
+	if err != nil {
+		return fmt.Errorf("classify: %s (load model)", err)
+	}
+
+	if t.labels, err = modelLabels(modelPath); err != nil {
+		session.Destroy()
+		return err
+	} else if t.rules, err = modelRules(modelPath); err != nil {
+		session.Destroy()
+		return err
+	}
+
+	t.session = session
+
+	return nil
+}
+
+// createTensor converts bytes jpeg image in a tensor object required as ONNX model input.
+func (t *ONNX) createTensor(image []byte) (*ort.Tensor[float32], error) {
+	img, err := imaging.Decode(bytes.NewReader(image), imaging.AutoOrientation(true))
+
+	if err != nil {
+		return nil, err
+	}
+
+	width, height := t.config.Resolution, t.config.Resolution
+
+	img = imaging.Fill(img, width, height, imaging.Center, imaging.Lanczos)
+
+	var shape ort.Shape
+
+	if t.config.Layout == LayoutNCHW {
+		shape = ort.NewShape(1, 3, int64(height), int64(width))
+	} else {
+		shape = ort.NewShape(1, int64(height), int64(width), 3)
+	}
+
+	return ort.NewTensor(shape, imageToData(img, width, height, t.config))
+}
+
+// initONNXRuntime loads the ONNX Runtime shared library if it has not been loaded yet.
+func initONNXRuntime() error {
+	onnxMutex.Lock()
+	defer onnxMutex.Unlock()
+
+	if ort.IsInitialized() {
+		return nil
+	}
+
+	ort.SetSharedLibraryPath(ONNXRuntime)
+
+	if err := ort.InitializeEnvironment(); err != nil {
+		return fmt.Errorf("classify: %s (load onnx runtime)", err)
+	}
+
+	return nil
+}
+
+// imageToData returns the normalized color values of an image in the input layout of the model.
+func imageToData(img image.Image, width, height int, m Model) []float32 {
+	size := width * height
+	data := make([]float32, 3*size)
+
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			r, g, b, _ := img.At(x, y).RGBA()
+			i := y*width + x
+
+			if m.Layout == LayoutNCHW {
+				data[i] = m.Normalize(r)
+				data[size+i] = m.Normalize(g)
+				data[2*size+i] = m.Normalize(b)
+			} else {
+				data[3*i] = m.Normalize(r)
+				data[3*i+1] = m.Normalize(g)
+				data[3*i+2] = m.Normalize(b)
+			}
+		}
+	}
+
+	return data
+}
+
+// softmax converts the raw output values of a model to probabilities.
+func softmax(values []float32) []float32 {
+	result := make([]float32, len(values))
+
+	if len(values) == 0 {
+		return result
+	}
+
+	max := values[0]
+
+	for _, v := range values {
+		if v > max {
+			max = v
+		}
+	}
+
+	var sum float64
+
+	for i, v := range values {
+		e := math.Exp(float64(v - max))
+		result[i] = float32(e)
+		sum += e
+	}
+
+	for i := range result {
+		result[i] = float32(float64(result[i]) / sum)
+	}
+
+	return result
+}
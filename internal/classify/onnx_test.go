@@ -0,0 +1,159 @@
+package classify
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestONNX_Init(t *testing.T) {
+	t.Run("Disabled", func(t *testing.T) {
+		m, err := LoadModel("testdata/onnx")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		c := NewONNX("testdata/onnx", m, true)
+
+		assert.Nil(t, c.Init())
+		assert.False(t, c.ModelLoaded())
+
+		result, err := c.File(examplesPath + "/cat_brown.jpg")
+
+		assert.NoError(t, err)
+		assert.Empty(t, result)
+	})
+	t.Run("RuntimeNotFound", func(t *testing.T) {
+		m, err := LoadModel("testdata/onnx")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		runtime := ONNXRuntime
+		ONNXRuntime = "testdata/libonnxruntime-missing.so"
+		defer func() { ONNXRuntime = runtime }()
+
+		c := NewONNX("testdata/onnx", m, false)
+
+		assert.Error(t, c.Init())
+		assert.False(t, c.ModelLoaded())
+	})
+}
+
+// testImage returns a JPEG image filled with the specified color.
+func testImage(t *testing.T, c color.Color) []byte {
+	img := image.NewRGBA(image.Rect(0, 0, 32, 32))
+
+	for y := 0; y < 32; y++ {
+		for x := 0; x < 32; x++ {
+			img.Set(x, y, c)
+		}
+	}
+
+	var buf bytes.Buffer
+
+	if err := jpeg.Encode(&buf, img, &jpeg.Options{Quality: 95}); err != nil {
+		t.Fatal(err)
+	}
+
+	return buf.Bytes()
+}
+
+// The test model returns the softmax of the mean red, green, and blue values, so that
+// red images are classified as "cat", green images as "dog", and blue images as "bird".
+func TestONNX_Labels(t *testing.T) {
+	if err := initONNXRuntime(); err != nil {
+		t.Skip(err)
+	}
+
+	m, err := LoadModel("testdata/onnx")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	c := NewONNX("testdata/onnx", m, false)
+
+	if err = c.Init(); err != nil {
+		t.Fatal(err)
+	}
+
+	assert.True(t, c.ModelLoaded())
+
+	t.Run("Red", func(t *testing.T) {
+		result, err := c.Labels(testImage(t, color.RGBA{R: 255, A: 255}))
+
+		assert.NoError(t, err)
+
+		if assert.Len(t, result, 1) {
+			assert.Equal(t, "cat", result[0].Name)
+			assert.Equal(t, "onnx", result[0].Model)
+			assert.Equal(t, SrcImage, result[0].Source)
+			assert.Equal(t, 0, result[0].Uncertainty)
+		}
+	})
+	t.Run("Blue", func(t *testing.T) {
+		result, err := c.Labels(testImage(t, color.RGBA{B: 255, A: 255}))
+
+		assert.NoError(t, err)
+
+		if assert.Len(t, result, 1) {
+			assert.Equal(t, "bird", result[0].Name)
+		}
+	})
+	t.Run("File", func(t *testing.T) {
+		fileName := filepath.Join(t.TempDir(), "green.jpg")
+
+		if err := os.WriteFile(fileName, testImage(t, color.RGBA{G: 255, A: 255}), 0644); err != nil {
+			t.Fatal(err)
+		}
+
+		result, err := c.File(fileName)
+
+		assert.NoError(t, err)
+
+		if assert.Len(t, result, 1) {
+			assert.Equal(t, "dog", result[0].Name)
+		}
+	})
+	t.Run("InvalidImage", func(t *testing.T) {
+		_, err := c.Labels([]byte("invalid"))
+		assert.Error(t, err)
+	})
+}
+
+func TestImageToData(t *testing.T) {
+	img := image.NewRGBA(image.Rect(0, 0, 2, 1))
+	img.Set(0, 0, color.RGBA{R: 255, G: 0, B: 0, A: 255})
+	img.Set(1, 0, color.RGBA{R: 0, G: 255, B: 255, A: 255})
+
+	t.Run("NCHW", func(t *testing.T) {
+		m := Model{Layout: LayoutNCHW, Mean: 0, Std: 255}
+		assert.Equal(t, []float32{1, 0, 0, 1, 0, 1}, imageToData(img, 2, 1, m))
+	})
+	t.Run("NHWC", func(t *testing.T) {
+		m := Model{Layout: LayoutNHWC, Mean: 0, Std: 255}
+		assert.Equal(t, []float32{1, 0, 0, 0, 1, 1}, imageToData(img, 2, 1, m))
+	})
+}
+
+func TestSoftmax(t *testing.T) {
+	t.Run("Logits", func(t *testing.T) {
+		result := softmax([]float32{1, 2, 3})
+
+		assert.InDelta(t, 0.090, result[0], 0.001)
+		assert.InDelta(t, 0.245, result[1], 0.001)
+		assert.InDelta(t, 0.665, result[2], 0.001)
+	})
+	t.Run("Empty", func(t *testing.T) {
+		assert.Empty(t, softmax(nil))
+	})
+}
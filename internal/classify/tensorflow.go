@@ -1,17 +1,13 @@
 package classify
 
 import (
-	"bufio"
 	"bytes"
 	"fmt"
 	"image"
-	"math"
 	"os"
 	"path"
 	"path/filepath"
 	"runtime/debug"
-	"sort"
-	"strings"
 
 	"github.com/disintegration/imaging"
 	"github.com/photoprism/photoprism/pkg/clean"
@@ -20,17 +16,27 @@ import (
 
 // TensorFlow is a wrapper for tensorflow low-level API.
 type TensorFlow struct {
-	model      *tf.SavedModel
-	modelsPath string
-	disabled   bool
-	modelName  string
-	modelTags  []string
-	labels     []string
+	model     *tf.SavedModel
+	modelPath string
+	disabled  bool
+	config    Model
+	labels    []string
+	rules     LabelRules
 }
 
 // New returns new TensorFlow instance with Nasnet model.
 func New(modelsPath string, disabled bool) *TensorFlow {
-	return &TensorFlow{modelsPath: modelsPath, disabled: disabled, modelName: "nasnet", modelTags: []string{"photoprism"}}
+	return NewTensorFlow(path.Join(modelsPath, DefaultModel), NewModel(), disabled)
+}
+
+// NewTensorFlow returns a new TensorFlow instance with the model in the specified directory.
+func NewTensorFlow(modelPath string, config Model, disabled bool) *TensorFlow {
+	return &TensorFlow{modelPath: modelPath, disabled: disabled, config: config}
+}
+
+// ModelName returns the model name, which is stored with the labels it detects.
+func (t *TensorFlow) ModelName() string {
+	return t.config.Name
 }
 
 // Init initialises tensorflow models if not disabled
@@ -83,10 +89,10 @@ func (t *TensorFlow) Labels(img []byte) (result Labels, err error) {
 	// Run inference.
 	output, err := t.model.Session.Run(
 		map[tf.Output]*tf.Tensor{
-			t.model.Graph.Operation("input_1").Output(0): tensor,
+			t.model.Graph.Operation(t.config.Input).Output(0): tensor,
 		},
 		[]tf.Output{
-			t.model.Graph.Operation("predictions/Softmax").Output(0),
+			t.model.Graph.Operation(t.config.Output).Output(0),
 		},
 		nil)
 
@@ -108,32 +114,14 @@ func (t *TensorFlow) Labels(img []byte) (result Labels, err error) {
 	return result, nil
 }
 
-func (t *TensorFlow) loadLabels(path string) error {
-	modelLabels := path + "/labels.txt"
-
-	log.Infof("classify: loading labels from labels.txt")
-
-	// Load labels
-	f, err := os.Open(modelLabels)
-
-	if err != nil {
+func (t *TensorFlow) loadLabels(path string) (err error) {
+	if t.labels, err = modelLabels(path); err != nil {
 		return err
 	}
 
-	defer f.Close()
-
-	scanner := bufio.NewScanner(f)
+	t.rules, err = modelRules(path)
 
-	// Labels are separated by newlines
-	for scanner.Scan() {
-		t.labels = append(t.labels, scanner.Text())
-	}
-
-	if err := scanner.Err(); err != nil {
-		return err
-	}
-
-	return nil
+	return err
 }
 
 // ModelLoaded tests if the TensorFlow model is loaded.
@@ -146,12 +134,12 @@ func (t *TensorFlow) loadModel() error {
 		return nil
 	}
 
-	modelPath := path.Join(t.modelsPath, t.modelName)
+	modelPath := t.modelPath
 
 	log.Infof("classify: loading %s", clean.Log(filepath.Base(modelPath)))
 
 	// Load model
-	model, err := tf.LoadSavedModel(modelPath, t.modelTags, nil)
+	model, err := tf.LoadSavedModel(modelPath, t.config.Tags, nil)
 
 	if err != nil {
 		return err
@@ -164,49 +152,7 @@ func (t *TensorFlow) loadModel() error {
 
 // bestLabels returns the best 5 labels (if enough high probability labels) from the prediction of the model
 func (t *TensorFlow) bestLabels(probabilities []float32) Labels {
-	var result Labels
-
-	for i, p := range probabilities {
-		if i >= len(t.labels) {
-			// break if probabilities and labels does not match
-			break
-		}
-
-		// discard labels with low probabilities
-		if p < 0.1 {
-			continue
-		}
-
-		labelText := strings.ToLower(t.labels[i])
-
-		rule, _ := Rules.Find(labelText)
-
-		// discard labels that don't met the threshold
-		if p < rule.Threshold {
-			continue
-		}
-
-		// Get rule label name instead of t.labels name if it exists
-		if rule.Label != "" {
-			labelText = rule.Label
-		}
-
-		labelText = strings.TrimSpace(labelText)
-
-		uncertainty := 100 - int(math.Round(float64(p*100)))
-
-		result = append(result, Label{Name: labelText, Source: SrcImage, Uncertainty: uncertainty, Priority: rule.Priority, Categories: rule.Categories})
-	}
-
-	// Sort by probability
-	sort.Sort(result)
-
-	// Return the best labels only.
-	if l := len(result); l < 5 {
-		return result[:l]
-	} else {
-		return result[:5]
-	}
+	return topLabels(probabilities, t.labels, t.rules, t.ModelName())
 }
 
 // createTensor converts bytes jpeg image in a tensor object required as tensorflow model input
@@ -217,14 +163,14 @@ func (t *TensorFlow) createTensor(image []byte, imageFormat string) (*tf.Tensor,
 		return nil, err
 	}
 
-	width, height := 224, 224
+	width, height := t.config.Resolution, t.config.Resolution
 
 	img = imaging.Fill(img, width, height, imaging.Center, imaging.Lanczos)
 
-	return imageToTensor(img, width, height)
+	return imageToTensor(img, width, height, t.config.Normalize)
 }
 
-func imageToTensor(img image.Image, imageHeight, imageWidth int) (tfTensor *tf.Tensor, err error) {
+func imageToTensor(img image.Image, imageHeight, imageWidth int, convert func(uint32) float32) (tfTensor *tf.Tensor, err error) {
 	defer func() {
 		if r := recover(); r != nil {
 			err = fmt.Errorf("classify: %s (panic)\nstack: %s", r, debug.Stack())
@@ -244,15 +190,11 @@ func imageToTensor(img image.Image, imageHeight, imageWidth int) (tfTensor *tf.T
 	for i := 0; i < imageWidth; i++ {
 		for j := 0; j < imageHeight; j++ {
 			r, g, b, _ := img.At(i, j).RGBA()
-			tfImage[0][j][i][0] = convertValue(r)
-			tfImage[0][j][i][1] = convertValue(g)
-			tfImage[0][j][i][2] = convertValue(b)
+			tfImage[0][j][i][0] = convert(r)
+			tfImage[0][j][i][1] = convert(g)
+			tfImage[0][j][i][2] = convert(b)
 		}
 	}
 
 	return tf.NewTensor(tfImage)
 }
-
-func convertValue(value uint32) float32 {
-	return (float32(value>>8) - float32(127.5)) / float32(127.5)
-}
@@ -246,8 +246,3 @@ func TestTensorFlow_MakeTensor(t *testing.T) {
 		assert.EqualError(t, err, "image: unknown format")
 	})
 }
-
-func Test_convertValue(t *testing.T) {
-	result := convertValue(uint32(98765432))
-	assert.Equal(t, float32(3024.898), result)
-}
@@ -1,19 +1,14 @@
 package commands
 
 import (
-	"bytes"
 	"context"
-	"errors"
-	"fmt"
 	"os"
-	"os/exec"
 	"path/filepath"
 	"time"
 
 	"github.com/dustin/go-humanize/english"
 	"github.com/urfave/cli"
 
-	"github.com/photoprism/photoprism/internal/config"
 	"github.com/photoprism/photoprism/internal/photoprism"
 	"github.com/photoprism/photoprism/pkg/clean"
 	"github.com/photoprism/photoprism/pkg/fs"
@@ -22,7 +17,9 @@ import (
 const backupDescription = "A user-defined filename or - for stdout can be passed as the first argument. " +
 	"The -i parameter can be omitted in this case.\n" +
 	"   Make sure to run the command with exec -T when using Docker to prevent log messages from being sent to stdout.\n" +
-	"   The index backup and album file paths are automatically detected if not specified explicitly."
+	"   The index backup and album file paths are automatically detected if not specified explicitly.\n" +
+	"   Incremental index backups only contain the changes since the last full backup in the same folder,\n" +
+	"   which is required to restore them."
 
 // BackupCommand configures the command name, flags, and action.
 var BackupCommand = cli.Command{
@@ -32,6 +29,9 @@ var BackupCommand = cli.Command{
 	ArgsUsage:   "[filename]",
 	Flags:       backupFlags,
 	Action:      backupAction,
+	Subcommands: []cli.Command{
+		BackupListCommand,
+	},
 }
 
 var backupFlags = []cli.Flag{
@@ -55,6 +55,18 @@ var backupFlags = []cli.Flag{
 		Name:  "index-path",
 		Usage: "custom index backup `PATH`",
 	},
+	cli.BoolFlag{
+		Name:  "gzip, z",
+		Usage: "compress index backup with gzip",
+	},
+	cli.BoolFlag{
+		Name:  "incremental, n",
+		Usage: "only back up index changes since the last full backup",
+	},
+	cli.IntFlag{
+		Name:  "retain, r",
+		Usage: "`NUMBER` of index backups to keep (0 to keep all)",
+	},
 }
 
 // backupAction creates a database backup.
@@ -87,6 +99,10 @@ func backupAction(ctx *cli.Context) error {
 	defer conf.Shutdown()
 
 	if backupIndex {
+		// Incremental backups are based on the last full backup in the same folder.
+		incremental := ctx.Bool("incremental")
+		var baseName string
+
 		// If empty, use default backup file name.
 		if indexFileName == "" {
 			if !fs.PathWritable(indexPath) {
@@ -94,74 +110,49 @@ func backupAction(ctx *cli.Context) error {
 					log.Warnf("custom index backup path not writable, using default")
 				}
 
-				indexPath = filepath.Join(conf.BackupPath(), conf.DatabaseDriver())
-			}
-
-			backupFile := time.Now().UTC().Format("2006-01-02") + ".sql"
-			indexFileName = filepath.Join(indexPath, backupFile)
-		}
-
-		if indexFileName != "-" {
-			if _, err := os.Stat(indexFileName); err == nil && !ctx.Bool("force") {
-				return fmt.Errorf("%s already exists", clean.Log(indexFileName))
-			} else if err == nil {
-				log.Warnf("replacing existing backup")
+				indexPath = conf.BackupIndexPath()
 			}
 
-			// Create backup directory if not exists.
-			if dir := filepath.Dir(indexFileName); dir != "." {
-				if err := os.MkdirAll(dir, fs.ModeDir); err != nil {
+			if incremental {
+				if baseName, _, err = photoprism.BackupBase(indexPath); err != nil {
 					return err
 				}
 			}
+
+			if baseName != "" {
+				indexFileName = photoprism.BackupIncFileName(indexPath)
+			} else {
+				indexFileName = photoprism.BackupFileName(indexPath, ctx.Bool("gzip"))
+			}
+		} else if incremental && indexFileName != "-" {
+			if baseName, _, err = photoprism.BackupBase(filepath.Dir(indexFileName)); err != nil {
+				return err
+			}
 		}
 
-		var cmd *exec.Cmd
-
-		switch conf.DatabaseDriver() {
-		case config.MySQL, config.MariaDB:
-			cmd = exec.Command(
-				conf.MysqldumpBin(),
-				"--protocol", "tcp",
-				"-h", conf.DatabaseHost(),
-				"-P", conf.DatabasePortString(),
-				"-u", conf.DatabaseUser(),
-				"-p"+conf.DatabasePassword(),
-				conf.DatabaseName(),
-			)
-		case config.SQLite3:
-			cmd = exec.Command(
-				conf.SqliteBin(),
-				conf.DatabaseFile(),
-				".dump",
-			)
-		default:
-			return fmt.Errorf("unsupported database type: %s", conf.DatabaseDriver())
+		if incremental && baseName == "" && indexFileName != "-" {
+			log.Infof("no full index backup found, creating one")
 		}
 
 		// Write to stdout or file.
-		var f *os.File
 		if indexFileName == "-" {
 			log.Infof("writing backup to stdout")
-			f = os.Stdout
-		} else if f, err = os.OpenFile(indexFileName, os.O_TRUNC|os.O_RDWR|os.O_CREATE, fs.ModeFile); err != nil {
-			return fmt.Errorf("failed to create %s: %s", clean.Log(indexFileName), err)
-		} else {
-			log.Infof("writing backup to %s", clean.Log(indexFileName))
-			defer f.Close()
-		}
 
-		var stderr bytes.Buffer
-		cmd.Stderr = &stderr
-		cmd.Stdout = f
-
-		// Log exact command for debugging in trace mode.
-		log.Trace(cmd.String())
+			if err = photoprism.BackupIndex(os.Stdout); err != nil {
+				return err
+			}
+		} else if baseName != "" {
+			if err = photoprism.BackupIndexIncrement(indexFileName, baseName, ctx.Bool("force")); err != nil {
+				return err
+			}
+		} else if err = photoprism.BackupIndexFile(indexFileName, ctx.Bool("force")); err != nil {
+			return err
+		}
 
-		// Run backup command.
-		if err := cmd.Run(); err != nil {
-			if stderr.String() != "" {
-				return errors.New(stderr.String())
+		if retain := ctx.Int("retain"); retain > 0 && filepath.Dir(indexFileName) == indexPath {
+			// Remove old backups from the index backup path.
+			if _, err = photoprism.RotateBackups(indexPath, retain); err != nil {
+				return err
 			}
 		}
 	}
@@ -0,0 +1,83 @@
+package commands
+
+import (
+	"fmt"
+
+	"github.com/dustin/go-humanize"
+	"github.com/dustin/go-humanize/english"
+	"github.com/urfave/cli"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/photoprism"
+	"github.com/photoprism/photoprism/pkg/report"
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+// BackupListCommand configures the command name, flags, and action.
+var BackupListCommand = cli.Command{
+	Name:    "list",
+	Aliases: []string{"ls"},
+	Usage:   "Displays existing index backups, newest first",
+	Flags: append(report.CliFlags, cli.StringFlag{
+		Name:  "index-path",
+		Usage: "custom index backup `PATH`",
+	}, cli.BoolFlag{
+		Name:  "verify, v",
+		Usage: "verify the integrity of each backup",
+	}),
+	Action: backupListAction,
+}
+
+// backupListAction displays existing index backups.
+func backupListAction(ctx *cli.Context) error {
+	return CallWithDependencies(ctx, func(conf *config.Config) error {
+		indexPath := ctx.String("index-path")
+
+		if indexPath == "" {
+			indexPath = conf.BackupIndexPath()
+		}
+
+		backups, err := photoprism.Backups(indexPath)
+
+		if err != nil {
+			return err
+		}
+
+		verify := ctx.Bool("verify")
+		cols := []string{"Filename", "Size", "Compressed", "Base", "Created"}
+
+		if verify {
+			cols = append(cols, "Status")
+		}
+
+		rows := make([][]string, len(backups))
+
+		// Show log message.
+		log.Infof("found %s in %s", english.Plural(len(backups), "backup", "backups"), indexPath)
+
+		// Display report.
+		for i, b := range backups {
+			rows[i] = []string{
+				b.Name(),
+				humanize.Bytes(uint64(b.Size)),
+				report.Bool(b.Compressed, report.Yes, report.No),
+				b.Base,
+				txt.TimeStamp(&b.ModTime),
+			}
+
+			if !verify {
+				continue
+			} else if err := photoprism.VerifyBackup(b.FileName); err != nil {
+				rows[i] = append(rows[i], err.Error())
+			} else {
+				rows[i] = append(rows[i], "OK")
+			}
+		}
+
+		result, err := report.RenderFormat(rows, cols, report.CliFormat(ctx))
+
+		fmt.Printf("\n%s\n", result)
+
+		return err
+	})
+}
@@ -0,0 +1,153 @@
+package commands
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/dustin/go-humanize/english"
+	"github.com/manifoldco/promptui"
+	"github.com/urfave/cli"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/get"
+	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/pkg/clean"
+	"github.com/photoprism/photoprism/pkg/phash"
+	"github.com/photoprism/photoprism/pkg/report"
+)
+
+// BurstsFlags specifies the maximum time and perceptual hash distance between pictures of a burst.
+var BurstsFlags = []cli.Flag{
+	cli.IntFlag{
+		Name:  "seconds, s",
+		Usage: "maximum time between pictures in `SECONDS` (default from stack settings)",
+	},
+	cli.IntFlag{
+		Name:  "distance, d",
+		Usage: fmt.Sprintf("maximum perceptual hash `DISTANCE` in bits, from 0 (identical) to %d", phash.MaxDistance),
+		Value: query.BurstDistance,
+	},
+}
+
+// BurstsCommand configures the command name, flags, and action.
+var BurstsCommand = cli.Command{
+	Name:  "bursts",
+	Usage: "Burst and bracketed exposure stacking subcommands",
+	Subcommands: []cli.Command{
+		{
+			Name:      "ls",
+			Usage:     "Lists pictures taken in quick succession with the same camera that may be stacked",
+			ArgsUsage: "[subfolder]",
+			Flags:     append(report.CliFlags, BurstsFlags...),
+			Action:    burstsListAction,
+		},
+		{
+			Name:      "stack",
+			Usage:     "Stacks bursts and bracketed exposures with the best picture as primary",
+			ArgsUsage: "[subfolder]",
+			Flags: append(BurstsFlags, cli.BoolFlag{
+				Name:  "yes, y",
+				Usage: "assume \"yes\" as answer to all prompts and run non-interactively",
+			}),
+			Action: burstsStackAction,
+		},
+	},
+}
+
+// findBursts returns the bursts matching the command line flags and arguments.
+func findBursts(ctx *cli.Context, conf *config.Config) (query.Bursts, error) {
+	seconds := ctx.Int("seconds")
+
+	if seconds == 0 {
+		seconds = conf.Settings().StackBurstSeconds()
+	} else if seconds < 0 || seconds > 60 {
+		return nil, fmt.Errorf("seconds must be between 1 and 60")
+	}
+
+	distance := ctx.Int("distance")
+
+	if distance < 0 || distance > phash.MaxDistance {
+		return nil, fmt.Errorf("distance must be between 0 and %d", phash.MaxDistance)
+	}
+
+	return get.Bursts().Find(seconds, distance, strings.TrimSpace(ctx.Args().First()))
+}
+
+// burstsListAction displays bursts and bracketed exposures for review.
+func burstsListAction(ctx *cli.Context) error {
+	return CallWithDependencies(ctx, func(conf *config.Config) error {
+		bursts, err := findBursts(ctx, conf)
+
+		if err != nil {
+			return err
+		}
+
+		log.Infof("found %s", english.Plural(len(bursts), "burst", "bursts"))
+
+		cols := []string{"Burst", "Taken At", "Seconds", "Photo UID", "File Name", "Resolution", "Primary"}
+		rows := make([][]string, 0, len(bursts)*3)
+
+		for i, b := range bursts {
+			for j, f := range b.Files {
+				rows = append(rows, []string{
+					fmt.Sprintf("%d", i+1),
+					b.TakenAt.Format("2006-01-02 15:04:05"),
+					fmt.Sprintf("%d", b.Seconds),
+					f.PhotoUID,
+					f.FileName,
+					fmt.Sprintf("%dx%d", f.FileWidth, f.FileHeight),
+					report.Bool(j == 0, report.Yes, report.No),
+				})
+			}
+		}
+
+		result, err := report.RenderFormat(rows, cols, report.CliFormat(ctx))
+
+		fmt.Printf("\n%s\n", result)
+
+		return err
+	})
+}
+
+// burstsStackAction stacks bursts and bracketed exposures after confirmation.
+func burstsStackAction(ctx *cli.Context) error {
+	return CallWithDependencies(ctx, func(conf *config.Config) error {
+		start := time.Now()
+
+		bursts, err := findBursts(ctx, conf)
+
+		if err != nil {
+			return err
+		} else if len(bursts) == 0 {
+			log.Infof("found no bursts")
+			return nil
+		}
+
+		if !ctx.Bool("yes") {
+			confirmPrompt := promptui.Prompt{
+				Label:     fmt.Sprintf("Stack %s?", english.Plural(len(bursts), "burst", "bursts")),
+				IsConfirm: true,
+			}
+
+			// Abort?
+			if _, err = confirmPrompt.Run(); err != nil {
+				return nil
+			}
+		}
+
+		var count int
+
+		for _, b := range bursts {
+			if stacked, err := get.Bursts().Stack(b); err != nil {
+				log.Errorf("bursts: %s (stack %s)", err, clean.Log(b.Best().PhotoUID))
+			} else {
+				count += len(stacked)
+			}
+		}
+
+		log.Infof("stacked %s in %s", english.Plural(count, "picture", "pictures"), time.Since(start))
+
+		return nil
+	})
+}
@@ -48,7 +48,10 @@ var PhotoPrism = []cli.Command{
 	ImportCommand,
 	CopyCommand,
 	FacesCommand,
+	DuplicatesCommand,
+	BurstsCommand,
 	PlacesCommand,
+	GeotagCommand,
 	PurgeCommand,
 	CleanUpCommand,
 	OptimizeCommand,
@@ -59,6 +62,8 @@ var PhotoPrism = []cli.Command{
 	MigrationsCommand,
 	BackupCommand,
 	RestoreCommand,
+	ExportCommand,
+	ImportMetadataCommand,
 	ResetCommand,
 	PasswdCommand,
 	UsersCommand,
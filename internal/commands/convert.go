@@ -9,6 +9,7 @@ import (
 	"github.com/urfave/cli"
 
 	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/ffmpeg"
 	"github.com/photoprism/photoprism/internal/get"
 	"github.com/photoprism/photoprism/pkg/clean"
 )
@@ -16,7 +17,7 @@ import (
 // ConvertCommand configures the command name, flags, and action.
 var ConvertCommand = cli.Command{
 	Name:      "convert",
-	Usage:     "Converts files in other formats to JPEG and AVC as needed",
+	Usage:     "Converts files in other formats to JPEG and AVC, HEVC, or AV1 as needed",
 	ArgsUsage: "[subfolder]",
 	Flags: []cli.Flag{
 		cli.StringSliceFlag{
@@ -27,6 +28,10 @@ var ConvertCommand = cli.Command{
 			Name:  "force, f",
 			Usage: "replace existing JPEG files in the sidecar folder",
 		},
+		cli.StringFlag{
+			Name:  "codec, c",
+			Usage: "video `CODEC` for transcoded videos (avc, hevc, av1), overrides the configured codec",
+		},
 	},
 	Action: convertAction,
 }
@@ -51,6 +56,11 @@ func convertAction(ctx *cli.Context) error {
 	conf.RegisterDb()
 	defer conf.Shutdown()
 
+	// Override the configured target codec for transcoded videos?
+	if codec := ctx.String("codec"); codec != "" {
+		conf.Options().FFmpegCodec = ffmpeg.FindCodec(codec).String()
+	}
+
 	convertPath := conf.OriginalsPath()
 
 	// Use first argument to limit scope if set.
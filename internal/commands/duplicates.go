@@ -0,0 +1,210 @@
+package commands
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/dustin/go-humanize"
+	"github.com/dustin/go-humanize/english"
+	"github.com/manifoldco/promptui"
+	"github.com/urfave/cli"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/get"
+	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/pkg/clean"
+	"github.com/photoprism/photoprism/pkg/phash"
+	"github.com/photoprism/photoprism/pkg/report"
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+// DuplicatesDistanceFlag sets the maximum number of different bits for pictures to be considered similar.
+var DuplicatesDistanceFlag = cli.IntFlag{
+	Name:  "distance, d",
+	Usage: fmt.Sprintf("maximum perceptual hash `DISTANCE` in bits, from 0 (identical) to %d", phash.MaxDistance),
+	Value: phash.DefaultDistance,
+}
+
+// DuplicatesCommand configures the command name, flags, and action.
+var DuplicatesCommand = cli.Command{
+	Name:  "duplicates",
+	Usage: "Visually similar pictures subcommands",
+	Subcommands: []cli.Command{
+		{
+			Name:      "ls",
+			Usage:     "Lists groups of visually similar pictures",
+			ArgsUsage: "[subfolder]",
+			Flags:     append(report.CliFlags, DuplicatesDistanceFlag),
+			Action:    duplicatesListAction,
+		},
+		{
+			Name:   "update",
+			Usage:  "Creates missing perceptual hashes based on existing thumbnails",
+			Action: duplicatesUpdateAction,
+		},
+		{
+			Name:      "stack",
+			Usage:     "Stacks lesser copies with the best copy of visually similar pictures",
+			ArgsUsage: "[subfolder]",
+			Flags: []cli.Flag{
+				DuplicatesDistanceFlag,
+				cli.BoolFlag{
+					Name:  "yes, y",
+					Usage: "assume \"yes\" as answer to all prompts and run non-interactively",
+				},
+			},
+			Action: duplicatesStackAction,
+		},
+		{
+			Name:      "archive",
+			Usage:     "Archives lesser copies of visually similar pictures",
+			ArgsUsage: "[subfolder]",
+			Flags: []cli.Flag{
+				DuplicatesDistanceFlag,
+				cli.BoolFlag{
+					Name:  "yes, y",
+					Usage: "assume \"yes\" as answer to all prompts and run non-interactively",
+				},
+			},
+			Action: duplicatesArchiveAction,
+		},
+	},
+}
+
+// duplicatesDistance returns the maximum distance specified on the command line.
+func duplicatesDistance(ctx *cli.Context) (int, error) {
+	distance := ctx.Int("distance")
+
+	if distance < 0 || distance > phash.MaxDistance {
+		return distance, fmt.Errorf("distance must be between 0 and %d", phash.MaxDistance)
+	}
+
+	return distance, nil
+}
+
+// duplicatesListAction displays groups of visually similar pictures.
+func duplicatesListAction(ctx *cli.Context) error {
+	return CallWithDependencies(ctx, func(conf *config.Config) error {
+		distance, err := duplicatesDistance(ctx)
+
+		if err != nil {
+			return err
+		}
+
+		subPath := strings.TrimSpace(ctx.Args().First())
+
+		groups, err := get.Duplicates().Similar(distance, subPath)
+
+		if err != nil {
+			return err
+		}
+
+		log.Infof("found %s", english.Plural(len(groups), "group of similar pictures", "groups of similar pictures"))
+
+		cols := []string{"Group", "Distance", "Photo UID", "File Name", "Resolution", "Size", "Best Copy"}
+		rows := make([][]string, 0, len(groups)*2)
+
+		for i, g := range groups {
+			for j, f := range g.Files {
+				rows = append(rows, []string{
+					fmt.Sprintf("%d", i+1),
+					fmt.Sprintf("%d", g.Distance),
+					f.PhotoUID,
+					f.FileName,
+					fmt.Sprintf("%dx%d", f.FileWidth, f.FileHeight),
+					humanize.Bytes(uint64(f.FileSize)),
+					report.Bool(j == 0, report.Yes, report.No),
+				})
+			}
+		}
+
+		result, err := report.RenderFormat(rows, cols, report.CliFormat(ctx))
+
+		fmt.Printf("\n%s\n", result)
+
+		return err
+	})
+}
+
+// duplicatesUpdateAction creates missing perceptual hashes.
+func duplicatesUpdateAction(ctx *cli.Context) error {
+	return CallWithDependencies(ctx, func(conf *config.Config) error {
+		start := time.Now()
+
+		updated, err := get.Duplicates().Hashes()
+
+		if err != nil {
+			return err
+		}
+
+		log.Infof("updated %s in %s", english.Plural(updated, "hash", "hashes"), time.Since(start))
+
+		return nil
+	})
+}
+
+// duplicatesStackAction stacks lesser copies of visually similar pictures.
+func duplicatesStackAction(ctx *cli.Context) error {
+	return duplicatesResolve(ctx, "stack", "stacked", func(s query.Similar) (int, error) {
+		stacked, err := get.Duplicates().Stack(s)
+		return len(stacked), err
+	})
+}
+
+// duplicatesArchiveAction archives lesser copies of visually similar pictures.
+func duplicatesArchiveAction(ctx *cli.Context) error {
+	return duplicatesResolve(ctx, "archive", "archived", func(s query.Similar) (int, error) {
+		archived, err := get.Duplicates().Archive(s)
+		return len(archived), err
+	})
+}
+
+// duplicatesResolve performs an action on each group of visually similar pictures after confirmation.
+func duplicatesResolve(ctx *cli.Context, action, done string, resolve func(s query.Similar) (int, error)) error {
+	return CallWithDependencies(ctx, func(conf *config.Config) error {
+		distance, err := duplicatesDistance(ctx)
+
+		if err != nil {
+			return err
+		}
+
+		start := time.Now()
+		subPath := strings.TrimSpace(ctx.Args().First())
+
+		groups, err := get.Duplicates().Similar(distance, subPath)
+
+		if err != nil {
+			return err
+		} else if len(groups) == 0 {
+			log.Infof("found no similar pictures")
+			return nil
+		}
+
+		if !ctx.Bool("yes") {
+			confirmPrompt := promptui.Prompt{
+				Label:     fmt.Sprintf("%s lesser copies in %s?", txt.UpperFirst(action), english.Plural(len(groups), "group", "groups")),
+				IsConfirm: true,
+			}
+
+			// Abort?
+			if _, err = confirmPrompt.Run(); err != nil {
+				return nil
+			}
+		}
+
+		var count int
+
+		for _, g := range groups {
+			if n, err := resolve(g); err != nil {
+				log.Errorf("duplicates: %s (%s %s)", err, action, clean.Log(g.Best().PhotoUID))
+			} else {
+				count += n
+			}
+		}
+
+		log.Infof("%s %s in %s", done, english.Plural(count, "copy", "copies"), time.Since(start))
+
+		return nil
+	})
+}
@@ -0,0 +1,68 @@
+package commands
+
+import (
+	"path/filepath"
+	"time"
+
+	"github.com/urfave/cli"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/photoprism"
+	"github.com/photoprism/photoprism/pkg/clean"
+)
+
+const exportDescription = "Writes labels, people, faces, photos with their files and markers, albums, and user reactions\n" +
+	"   to a versioned archive with one file per entity, so that the library metadata can be migrated\n" +
+	"   to another instance or database without an SQL dump. The archive is written to the \"library\"\n" +
+	"   folder in the backup path if no PATH is specified."
+
+// ExportCommand configures the command name, flags, and action.
+var ExportCommand = cli.Command{
+	Name:        "export",
+	Description: exportDescription,
+	Usage:       "Exports the library metadata to a portable archive",
+	ArgsUsage:   "[path]",
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "format, t",
+			Usage: "archive file `FORMAT` (yaml, json)",
+			Value: photoprism.LibraryYaml,
+		},
+		cli.BoolFlag{
+			Name:  "force, f",
+			Usage: "replace existing archive",
+		},
+	},
+	Action: exportAction,
+}
+
+// exportAction exports the library metadata to a portable archive.
+func exportAction(ctx *cli.Context) error {
+	return CallWithDependencies(ctx, func(conf *config.Config) error {
+		start := time.Now()
+
+		exportPath := ctx.Args().First()
+
+		if exportPath == "" {
+			exportPath = filepath.Join(conf.BackupPath(), "library")
+		}
+
+		conf.InitDb()
+
+		opt := photoprism.LibraryExportOptions{
+			Path:     exportPath,
+			Encoding: ctx.String("format"),
+			Force:    ctx.Bool("force"),
+		}
+
+		info, err := photoprism.NewLibraryExport(conf).Start(opt)
+
+		if err != nil {
+			return err
+		}
+
+		log.Infof("exported %s to %s in %s", info.Counts, clean.Log(exportPath), time.Since(start))
+
+		return nil
+	})
+}
@@ -0,0 +1,137 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/dustin/go-humanize/english"
+	"github.com/urfave/cli"
+
+	"github.com/photoprism/photoprism/internal/get"
+	"github.com/photoprism/photoprism/internal/photoprism"
+	"github.com/photoprism/photoprism/pkg/clean"
+	"github.com/photoprism/photoprism/pkg/geo"
+)
+
+// GeotagCommand configures the command name, flags, and action.
+var GeotagCommand = cli.Command{
+	Name:      "geotag",
+	Usage:     "Sets the location of photos based on recorded GPX, KML, or GeoJSON tracks",
+	ArgsUsage: "[files or folders]",
+	Flags: []cli.Flag{
+		cli.DurationFlag{
+			Name:  "offset, o",
+			Usage: "camera clock `OFFSET`, i.e. the camera time minus the actual time, e.g. 1h or -30s",
+		},
+		cli.DurationFlag{
+			Name:  "max-gap, g",
+			Usage: "maximum `DURATION` between recorded positions",
+			Value: photoprism.DefaultGeotagMaxGap,
+		},
+		cli.BoolFlag{
+			Name:  "store, s",
+			Usage: "store the tracks for display on the map",
+		},
+	},
+	Action: geotagAction,
+}
+
+// geotagAction sets the location of photos based on recorded tracks.
+func geotagAction(ctx *cli.Context) error {
+	start := time.Now()
+
+	if !ctx.Args().Present() {
+		return cli.ShowSubcommandHelp(ctx)
+	}
+
+	conf, err := InitConfig(ctx)
+
+	_, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	if err != nil {
+		return err
+	}
+
+	conf.InitDb()
+	defer conf.Shutdown()
+
+	fileNames, err := trackFileNames(ctx.Args())
+
+	if err != nil {
+		return err
+	} else if len(fileNames) == 0 {
+		return fmt.Errorf("found no track files")
+	}
+
+	var track geo.Track
+
+	for _, fileName := range fileNames {
+		t, readErr := geo.ReadTrackFile(fileName)
+
+		if readErr != nil {
+			log.Errorf("geotag: %s in %s", readErr, clean.Log(filepath.Base(fileName)))
+			continue
+		}
+
+		log.Infof("geotag: found %s in %s", english.Plural(len(t), "position", "positions"), clean.Log(filepath.Base(fileName)))
+
+		if ctx.Bool("store") {
+			if _, saveErr := photoprism.SaveTrack(conf.TracksPath(), filepath.Base(fileName), t); saveErr != nil {
+				log.Errorf("geotag: %s while storing %s", saveErr, clean.Log(filepath.Base(fileName)))
+			}
+		}
+
+		track = track.Merge(t)
+	}
+
+	if track.Empty() {
+		return fmt.Errorf("found no positions with time information")
+	}
+
+	opt := photoprism.NewGeotagOptions(ctx.Duration("offset"), ctx.Duration("max-gap"))
+
+	updated, err := get.Geotag().Start(track, opt)
+
+	if err != nil {
+		return err
+	}
+
+	log.Infof("updated %s in %s", english.Plural(len(updated), "photo", "photos"), time.Since(start))
+
+	return nil
+}
+
+// trackFileNames returns the names of supported track files, folders are searched non-recursively.
+func trackFileNames(args []string) (result []string, err error) {
+	for _, arg := range args {
+		info, statErr := os.Stat(arg)
+
+		if statErr != nil {
+			return result, statErr
+		} else if !info.IsDir() {
+			result = append(result, arg)
+			continue
+		}
+
+		entries, readErr := os.ReadDir(arg)
+
+		if readErr != nil {
+			return result, readErr
+		}
+
+		for _, e := range entries {
+			if !e.IsDir() && geo.TrackFormat(e.Name()) != "" {
+				result = append(result, filepath.Join(arg, e.Name()))
+			}
+		}
+	}
+
+	sort.Strings(result)
+
+	return result, nil
+}
@@ -26,6 +26,10 @@ var ImportCommand = cli.Command{
 			Name:  "dest, d",
 			Usage: "relative originals `PATH` to which the files should be imported",
 		},
+		cli.BoolFlag{
+			Name:  "takeout, t",
+			Usage: "import a Google Photos Takeout folder or zip archive, including albums and metadata",
+		},
 	},
 	Action: importAction,
 }
@@ -77,6 +81,35 @@ func importAction(ctx *cli.Context) error {
 		destFolder = conf.ImportDest()
 	}
 
+	if ctx.Bool("takeout") {
+		log.Infof("importing google takeout from %s to %s", sourcePath, filepath.Join(conf.OriginalsPath(), destFolder))
+
+		w := photoprism.NewTakeoutImport(conf, get.Import())
+		opt := photoprism.TakeoutImportOptions{Path: sourcePath, DestFolder: destFolder, Move: true}
+
+		report, err := w.Start(opt)
+
+		if err != nil {
+			return err
+		}
+
+		for _, fileName := range report.Unmatched {
+			log.Warnf("takeout: no metadata found for %s", clean.Log(fileName))
+		}
+
+		for _, fileName := range report.Orphaned {
+			log.Warnf("takeout: no media file found for %s", clean.Log(fileName))
+		}
+
+		for _, fileName := range report.Failed {
+			log.Errorf("takeout: failed importing %s", clean.Log(fileName))
+		}
+
+		log.Infof("completed in %s", time.Since(start))
+
+		return nil
+	}
+
 	log.Infof("moving media files from %s to %s", sourcePath, filepath.Join(conf.OriginalsPath(), destFolder))
 
 	w := get.Import()
@@ -0,0 +1,54 @@
+package commands
+
+import (
+	"path/filepath"
+	"time"
+
+	"github.com/urfave/cli"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/photoprism"
+	"github.com/photoprism/photoprism/pkg/clean"
+)
+
+const importMetadataDescription = "Imports the library metadata from an archive created with the export command.\n" +
+	"   Photos are matched with indexed files by hash or file name, so originals should be indexed first.\n" +
+	"   The archive is read from the \"library\" folder in the backup path if no PATH is specified."
+
+// ImportMetadataCommand configures the command name, flags, and action.
+var ImportMetadataCommand = cli.Command{
+	Name:        "import-metadata",
+	Description: importMetadataDescription,
+	Usage:       "Imports the library metadata from a portable archive",
+	ArgsUsage:   "[path]",
+	Action:      importMetadataAction,
+}
+
+// importMetadataAction imports the library metadata from a portable archive.
+func importMetadataAction(ctx *cli.Context) error {
+	return CallWithDependencies(ctx, func(conf *config.Config) error {
+		start := time.Now()
+
+		importPath := ctx.Args().First()
+
+		if importPath == "" {
+			importPath = filepath.Join(conf.BackupPath(), "library")
+		}
+
+		conf.InitDb()
+
+		opt := photoprism.LibraryImportOptions{
+			Path: importPath,
+		}
+
+		counts, err := photoprism.NewLibraryImport(conf).Start(opt)
+
+		if err != nil {
+			return err
+		}
+
+		log.Infof("imported %s from %s in %s", counts, clean.Log(importPath), time.Since(start))
+
+		return nil
+	})
+}
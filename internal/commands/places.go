@@ -9,6 +9,7 @@ import (
 
 	"github.com/photoprism/photoprism/internal/config"
 	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/gazetteer"
 	"github.com/photoprism/photoprism/internal/get"
 	"github.com/photoprism/photoprism/internal/query"
 )
@@ -30,6 +31,11 @@ var PlacesCommand = cli.Command{
 			},
 			Action: placesUpdateAction,
 		},
+		{
+			Name:   "download",
+			Usage:  "Downloads the dataset for offline reverse geocoding",
+			Action: placesDownloadAction,
+		},
 	},
 }
 
@@ -45,7 +51,8 @@ func placesUpdateAction(ctx *cli.Context) error {
 		return err
 	}
 
-	if !conf.Sponsor() && !conf.Test() {
+	// The offline gazetteer does not depend on the places service.
+	if !conf.Sponsor() && !conf.Test() && conf.GeoApi() != gazetteer.ApiName {
 		log.Errorf(config.MsgSponsorCommand)
 		return nil
 	}
@@ -104,3 +111,29 @@ func placesUpdateAction(ctx *cli.Context) error {
 
 	return nil
 }
+
+// placesDownloadAction downloads the dataset for offline reverse geocoding.
+func placesDownloadAction(ctx *cli.Context) error {
+	// Load config.
+	conf, err := InitConfig(ctx)
+
+	if err != nil {
+		return err
+	}
+
+	defer conf.Shutdown()
+
+	start := time.Now()
+
+	if err = gazetteer.Download(conf.GazetteerPath()); err != nil {
+		return err
+	}
+
+	if conf.GeoApi() != gazetteer.ApiName {
+		log.Infof("set geo-api to %s to use the dataset for reverse geocoding", gazetteer.ApiName)
+	}
+
+	log.Infof("completed in %s", time.Since(start))
+
+	return nil
+}
@@ -7,8 +7,6 @@ import (
 	"io"
 	"os"
 	"os/exec"
-	"path/filepath"
-	"regexp"
 	"time"
 
 	"github.com/dustin/go-humanize/english"
@@ -24,7 +22,8 @@ import (
 
 const restoreDescription = "A user-defined filename or - for stdin can be passed as the first argument. " +
 	"The -i parameter can be omitted in this case.\n" +
-	"   The index backup and album file paths are automatically detected if not specified explicitly."
+	"   The index backup and album file paths are automatically detected if not specified explicitly.\n" +
+	"   Incremental index backups are restored together with the full backup they are based on."
 
 // RestoreCommand configures the command name, flags, and action.
 var RestoreCommand = cli.Command{
@@ -91,21 +90,22 @@ func restoreAction(ctx *cli.Context) error {
 		// If empty, use default backup file name.
 		if indexFileName == "" {
 			if indexPath == "" {
-				indexPath = filepath.Join(conf.BackupPath(), conf.DatabaseDriver())
+				indexPath = conf.BackupIndexPath()
 			}
 
-			matches, err := filepath.Glob(filepath.Join(regexp.QuoteMeta(indexPath), "*.sql"))
+			backups, err := photoprism.Backups(indexPath)
 
 			if err != nil {
 				return err
 			}
 
-			if len(matches) == 0 {
+			if len(backups) == 0 {
 				log.Errorf("no backup files found in %s", indexPath)
 				return nil
 			}
 
-			indexFileName = matches[len(matches)-1]
+			// Use the most recent backup.
+			indexFileName = backups[0].FileName
 		}
 
 		counts := struct{ Photos int }{}
@@ -138,6 +138,16 @@ func restoreAction(ctx *cli.Context) error {
 				"-f",
 				conf.DatabaseName(),
 			)
+		case config.Postgres:
+			cmd = exec.Command(
+				conf.PsqlBin(),
+				"-q",
+				"-h", conf.DatabaseHost(),
+				"-p", conf.DatabasePortString(),
+				"-U", conf.DatabaseUser(),
+				"-d", conf.DatabaseName(),
+			)
+			cmd.Env = append(os.Environ(), "PGPASSWORD="+conf.DatabasePassword())
 		case config.SQLite3:
 			log.Infoln("dropping existing tables")
 			tables.Drop(conf.Db())
@@ -149,16 +159,17 @@ func restoreAction(ctx *cli.Context) error {
 			return fmt.Errorf("unsupported database type: %s", conf.DatabaseDriver())
 		}
 
-		// Read from stdin or file.
-		var f *os.File
+		// Read from stdin or file, compressed and incremental backups are restored as complete dump.
+		var f io.Reader
 		if indexFileName == "-" {
 			log.Infof("restoring index from stdin")
 			f = os.Stdin
-		} else if f, err = os.OpenFile(indexFileName, os.O_RDONLY, 0); err != nil {
+		} else if file, err := photoprism.OpenBackup(indexFileName); err != nil {
 			return fmt.Errorf("failed to open %s: %s", clean.Log(indexFileName), err)
 		} else {
 			log.Infof("restoring index from %s", clean.Log(indexFileName))
-			defer f.Close()
+			defer file.Close()
+			f = file
 		}
 
 		var stderr bytes.Buffer
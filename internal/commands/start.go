@@ -132,7 +132,7 @@ func startAction(ctx *cli.Context) error {
 	auto.Start(conf)
 
 	// Wait for signal to initiate server shutdown.
-	quit := make(chan os.Signal)
+	quit := make(chan os.Signal, 1)
 	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM, syscall.SIGUSR1)
 
 	sig := <-quit
@@ -11,7 +11,7 @@ const (
 	UserNameUsage     = "full `NAME` for display in the interface"
 	UserEmailUsage    = "unique `EMAIL` address of the user"
 	UserPasswordUsage = "`PASSWORD` for local authentication"
-	UserRoleUsage     = "user role `NAME` (leave blank for default)"
+	UserRoleUsage     = "user role `NAME`, e.g. admin, contributor, viewer, guest, or a custom role (leave blank for default)"
 	UserAdminUsage    = "make user super admin with full access"
 	UserNoLoginUsage  = "disable login on the web interface"
 	UserWebDAVUsage   = "allow to sync files via WebDAV"
@@ -38,6 +38,7 @@ type ClientConfig struct {
 	ApiUri           string              `json:"apiUri"`
 	ContentUri       string              `json:"contentUri"`
 	VideoUri         string              `json:"videoUri"`
+	VideoCodec       string              `json:"videoCodec"`
 	WallpaperUri     string              `json:"wallpaperUri"`
 	SiteUrl          string              `json:"siteUrl"`
 	SiteDomain       string              `json:"siteDomain"`
@@ -67,6 +68,7 @@ type ClientConfig struct {
 	RegisterUri      string              `json:"registerUri"`
 	PasswordLength   int                 `json:"passwordLength"`
 	PasswordResetUri string              `json:"passwordResetUri"`
+	OIDCLoginUri     string              `json:"oidcLoginUri"`
 	Experimental     bool                `json:"experimental"`
 	AlbumCategories  []string            `json:"albumCategories"`
 	Albums           entity.Albums       `json:"albums"`
@@ -259,6 +261,7 @@ func (c *Config) ClientPublic() ClientConfig {
 		ApiUri:           c.ApiUri(),
 		ContentUri:       c.ContentUri(),
 		VideoUri:         c.VideoUri(),
+		VideoCodec:       c.FFmpegCodec().String(),
 		SiteUrl:          c.SiteUrl(),
 		SiteDomain:       c.SiteDomain(),
 		SiteAuthor:       c.SiteAuthor(),
@@ -288,6 +291,7 @@ func (c *Config) ClientPublic() ClientConfig {
 		LoginUri:         c.LoginUri(),
 		RegisterUri:      c.RegisterUri(),
 		PasswordResetUri: c.PasswordResetUri(),
+		OIDCLoginUri:     c.OIDCLoginUri(),
 		Experimental:     c.Experimental(),
 		Albums:           entity.Albums{},
 		Cameras:          entity.Cameras{},
@@ -348,6 +352,7 @@ func (c *Config) ClientShare() ClientConfig {
 		ApiUri:           c.ApiUri(),
 		ContentUri:       c.ContentUri(),
 		VideoUri:         c.VideoUri(),
+		VideoCodec:       c.FFmpegCodec().String(),
 		SiteUrl:          c.SiteUrl(),
 		SiteDomain:       c.SiteDomain(),
 		SiteAuthor:       c.SiteAuthor(),
@@ -378,6 +383,7 @@ func (c *Config) ClientShare() ClientConfig {
 		LoginUri:         c.LoginUri(),
 		RegisterUri:      c.RegisterUri(),
 		PasswordResetUri: c.PasswordResetUri(),
+		OIDCLoginUri:     c.OIDCLoginUri(),
 		Experimental:     c.Experimental(),
 		Albums:           entity.Albums{},
 		Cameras:          entity.Cameras{},
@@ -443,6 +449,7 @@ func (c *Config) ClientUser(withSettings bool) ClientConfig {
 		ApiUri:           c.ApiUri(),
 		ContentUri:       c.ContentUri(),
 		VideoUri:         c.VideoUri(),
+		VideoCodec:       c.FFmpegCodec().String(),
 		SiteUrl:          c.SiteUrl(),
 		SiteDomain:       c.SiteDomain(),
 		SiteAuthor:       c.SiteAuthor(),
@@ -474,6 +481,7 @@ func (c *Config) ClientUser(withSettings bool) ClientConfig {
 		RegisterUri:      c.RegisterUri(),
 		PasswordLength:   c.PasswordLength(),
 		PasswordResetUri: c.PasswordResetUri(),
+		OIDCLoginUri:     c.OIDCLoginUri(),
 		Experimental:     c.Experimental(),
 		Albums:           entity.Albums{},
 		Cameras:          entity.Cameras{},
@@ -519,25 +527,26 @@ func (c *Config) ClientUser(withSettings bool) ClientConfig {
 	if hidePrivate {
 		c.Db().
 			Table("photos").
-			Select("SUM(photo_type = 'video' AND photo_quality > -1 AND photo_private = 0) AS videos, " +
-				"SUM(photo_type = 'live' AND photo_quality > -1 AND photo_private = 0) AS live, " +
-				"SUM(photo_quality = -1) AS hidden, SUM(photo_type IN ('image','animated','vector','raw') AND photo_private = 0 AND photo_quality > -1) AS photos, " +
-				"SUM(photo_type IN ('image','live','animated','vector','raw') AND photo_quality < 3 AND photo_quality > -1 AND photo_private = 0) AS review, " +
-				"SUM(photo_favorite = 1 AND photo_private = 0 AND photo_quality > -1) AS favorites, " +
-				"SUM(photo_private = 1 AND photo_quality > -1) AS private").
-			Where("photos.id NOT IN (SELECT photo_id FROM files WHERE file_primary = 1 AND (file_missing = 1 OR file_error <> ''))").
+			Select("COUNT(CASE WHEN photo_type = 'video' AND photo_quality > -1 AND photo_private = ? THEN 1 END) AS videos, "+
+				"COUNT(CASE WHEN photo_type = 'live' AND photo_quality > -1 AND photo_private = ? THEN 1 END) AS live, "+
+				"COUNT(CASE WHEN photo_quality = -1 THEN 1 END) AS hidden, COUNT(CASE WHEN photo_type IN ('image','animated','vector','raw') AND photo_private = ? AND photo_quality > -1 THEN 1 END) AS photos, "+
+				"COUNT(CASE WHEN photo_type IN ('image','live','animated','vector','raw') AND photo_quality < 3 AND photo_quality > -1 AND photo_private = ? THEN 1 END) AS review, "+
+				"COUNT(CASE WHEN photo_favorite = ? AND photo_private = ? AND photo_quality > -1 THEN 1 END) AS favorites, "+
+				"COUNT(CASE WHEN photo_private = ? AND photo_quality > -1 THEN 1 END) AS private",
+				false, false, false, false, true, false, true).
+			Where("photos.id NOT IN (SELECT photo_id FROM files WHERE file_primary = ? AND (file_missing = ? OR file_error <> ''))", true, true).
 			Where("deleted_at IS NULL").
 			Take(&cfg.Count)
 	} else {
 		c.Db().
 			Table("photos").
-			Select("SUM(photo_type = 'video' AND photo_quality > -1) AS videos, " +
-				"SUM(photo_type = 'live' AND photo_quality > -1) AS live, " +
-				"SUM(photo_quality = -1) AS hidden, SUM(photo_type IN ('image','raw','animated') AND photo_quality > -1) AS photos, " +
-				"SUM(photo_type IN ('image','raw','live','animated') AND photo_quality < 3 AND photo_quality > -1) AS review, " +
-				"SUM(photo_favorite = 1 AND photo_quality > -1) AS favorites, " +
-				"0 AS private").
-			Where("photos.id NOT IN (SELECT photo_id FROM files WHERE file_primary = 1 AND (file_missing = 1 OR file_error <> ''))").
+			Select("COUNT(CASE WHEN photo_type = 'video' AND photo_quality > -1 THEN 1 END) AS videos, "+
+				"COUNT(CASE WHEN photo_type = 'live' AND photo_quality > -1 THEN 1 END) AS live, "+
+				"COUNT(CASE WHEN photo_quality = -1 THEN 1 END) AS hidden, COUNT(CASE WHEN photo_type IN ('image','raw','animated') AND photo_quality > -1 THEN 1 END) AS photos, "+
+				"COUNT(CASE WHEN photo_type IN ('image','raw','live','animated') AND photo_quality < 3 AND photo_quality > -1 THEN 1 END) AS review, "+
+				"COUNT(CASE WHEN photo_favorite = ? AND photo_quality > -1 THEN 1 END) AS favorites, "+
+				"0 AS private", true).
+			Where("photos.id NOT IN (SELECT photo_id FROM files WHERE file_primary = ? AND (file_missing = ? OR file_error <> ''))", true, true).
 			Where("deleted_at IS NULL").
 			Take(&cfg.Count)
 	}
@@ -555,21 +564,21 @@ func (c *Config) ClientUser(withSettings bool) ClientConfig {
 		Select("MAX(photo_count) AS label_max_photos, COUNT(*) AS labels").
 		Where("photo_count > 0").
 		Where("deleted_at IS NULL").
-		Where("(label_priority >= 0 OR label_favorite = 1)").
+		Where("(label_priority >= 0 OR label_favorite = ?)", true).
 		Take(&cfg.Count)
 
 	if hidePrivate {
 		c.Db().
 			Table("albums").
-			Select("SUM(album_type = ?) AS albums, SUM(album_type = ?) AS moments, SUM(album_type = ?) AS months, SUM(album_type = ?) AS states, SUM(album_type = ?) AS folders, "+
-				"SUM(album_type = ? AND album_private = 1) AS private_albums, SUM(album_type = ? AND album_private = 1) AS private_moments, SUM(album_type = ? AND album_private = 1) AS private_months, SUM(album_type = ? AND album_private = 1) AS private_states, SUM(album_type = ? AND album_private = 1) AS private_folders",
-				entity.AlbumManual, entity.AlbumMoment, entity.AlbumMonth, entity.AlbumState, entity.AlbumFolder, entity.AlbumManual, entity.AlbumMoment, entity.AlbumMonth, entity.AlbumState, entity.AlbumFolder).
-			Where("deleted_at IS NULL AND (albums.album_type <> 'folder' OR albums.album_path IN (SELECT photos.photo_path FROM photos WHERE photos.photo_private = 0 AND photos.deleted_at IS NULL))").
+			Select("COUNT(CASE WHEN album_type = ? THEN 1 END) AS albums, COUNT(CASE WHEN album_type = ? THEN 1 END) AS moments, COUNT(CASE WHEN album_type = ? THEN 1 END) AS months, COUNT(CASE WHEN album_type = ? THEN 1 END) AS states, COUNT(CASE WHEN album_type = ? THEN 1 END) AS folders, "+
+				"COUNT(CASE WHEN album_type = ? AND album_private = ? THEN 1 END) AS private_albums, COUNT(CASE WHEN album_type = ? AND album_private = ? THEN 1 END) AS private_moments, COUNT(CASE WHEN album_type = ? AND album_private = ? THEN 1 END) AS private_months, COUNT(CASE WHEN album_type = ? AND album_private = ? THEN 1 END) AS private_states, COUNT(CASE WHEN album_type = ? AND album_private = ? THEN 1 END) AS private_folders",
+				entity.AlbumManual, entity.AlbumMoment, entity.AlbumMonth, entity.AlbumState, entity.AlbumFolder, entity.AlbumManual, true, entity.AlbumMoment, true, entity.AlbumMonth, true, entity.AlbumState, true, entity.AlbumFolder, true).
+			Where("deleted_at IS NULL AND (albums.album_type <> 'folder' OR albums.album_path IN (SELECT photos.photo_path FROM photos WHERE photos.photo_private = ? AND photos.deleted_at IS NULL))", false).
 			Take(&cfg.Count)
 	} else {
 		c.Db().
 			Table("albums").
-			Select("SUM(album_type = ?) AS albums, SUM(album_type = ?) AS moments, SUM(album_type = ?) AS months, SUM(album_type = ?) AS states, SUM(album_type = ?) AS folders", entity.AlbumManual, entity.AlbumMoment, entity.AlbumMonth, entity.AlbumState, entity.AlbumFolder).
+			Select("COUNT(CASE WHEN album_type = ? THEN 1 END) AS albums, COUNT(CASE WHEN album_type = ? THEN 1 END) AS moments, COUNT(CASE WHEN album_type = ? THEN 1 END) AS months, COUNT(CASE WHEN album_type = ? THEN 1 END) AS states, COUNT(CASE WHEN album_type = ? THEN 1 END) AS folders", entity.AlbumManual, entity.AlbumMoment, entity.AlbumMonth, entity.AlbumState, entity.AlbumFolder).
 			Where("deleted_at IS NULL AND (albums.album_type <> 'folder' OR albums.album_path IN (SELECT photos.photo_path FROM photos WHERE photos.deleted_at IS NULL))").
 			Take(&cfg.Count)
 	}
@@ -577,7 +586,7 @@ func (c *Config) ClientUser(withSettings bool) ClientConfig {
 	c.Db().
 		Table("files").
 		Select("COUNT(*) AS files").
-		Where("file_missing = 0 AND file_root = ? AND deleted_at IS NULL", entity.RootOriginals).
+		Where("file_missing = ? AND file_root = ? AND deleted_at IS NULL", false, entity.RootOriginals).
 		Take(&cfg.Count)
 
 	c.Db().
@@ -587,7 +596,7 @@ func (c *Config) ClientUser(withSettings bool) ClientConfig {
 
 	c.Db().
 		Table("places").
-		Select("SUM(photo_count > 0) AS places").
+		Select("COUNT(CASE WHEN photo_count > 0 THEN 1 END) AS places").
 		Where("id <> 'zz'").
 		Take(&cfg.Count)
 
@@ -611,7 +620,7 @@ func (c *Config) ClientUser(withSettings bool) ClientConfig {
 		Find(&cfg.Lenses)
 
 	c.Db().
-		Where("deleted_at IS NULL AND album_favorite = 1").
+		Where("deleted_at IS NULL AND album_favorite = ?", true).
 		Limit(20).Order("album_title").
 		Find(&cfg.Albums)
 
@@ -28,6 +28,7 @@ import (
 	"github.com/photoprism/photoprism/internal/entity"
 	"github.com/photoprism/photoprism/internal/event"
 	"github.com/photoprism/photoprism/internal/face"
+	"github.com/photoprism/photoprism/internal/gazetteer"
 	"github.com/photoprism/photoprism/internal/hub"
 	"github.com/photoprism/photoprism/internal/hub/places"
 	"github.com/photoprism/photoprism/internal/i18n"
@@ -180,11 +181,15 @@ func (c *Config) Propagate() {
 	thumb.SizeUncached = c.ThumbSizeUncached()
 	thumb.Filter = c.ThumbFilter()
 	thumb.JpegQuality = c.JpegQuality()
+	thumb.Formats = c.ThumbFormats()
+	thumb.AvifEncoderBin = c.AvifEncoderBin()
+	thumb.WebPEncoderBin = c.WebPEncoderBin()
 	thumb.CacheMaxAge = c.HttpCacheMaxAge()
 	thumb.CachePublic = c.HttpCachePublic()
 
 	// Set geocoding parameters.
 	places.UserAgent = c.UserAgent()
+	gazetteer.Path = c.GazetteerPath()
 	entity.GeoApi = c.GeoApi()
 
 	// Set minimum password length.
@@ -193,6 +198,9 @@ func (c *Config) Propagate() {
 	// Set path for user assets.
 	entity.UsersPath = c.UsersPath()
 
+	// Limit users to their private libraries?
+	entity.PrivateLibraries = c.PrivateLibraries()
+
 	// Set API preview and download default tokens.
 	entity.PreviewToken.Set(c.PreviewToken(), entity.TokenConfig)
 	entity.DownloadToken.Set(c.DownloadToken(), entity.TokenConfig)
@@ -260,6 +268,11 @@ func (c *Config) Init() error {
 		log.Warnf("config: the wakeup interval is %s, but must be 1h or less for face recognition to work", c.WakeupInterval().String())
 	}
 
+	// Show warning if the index backup schedule is invalid.
+	if c.options.BackupSchedule != "" && c.BackupSchedule() == "" {
+		log.Warnf("config: invalid backup schedule %s, scheduled backups are disabled", clean.Log(c.options.BackupSchedule))
+	}
+
 	// Set HTTPS proxy for outgoing connections.
 	if httpsProxy := c.HttpsProxy(); httpsProxy != "" {
 		http.DefaultTransport.(*http.Transport).TLSClientConfig = &tls.Config{
@@ -272,6 +285,10 @@ func (c *Config) Init() error {
 	// Set HTTP user agent.
 	places.UserAgent = c.UserAgent()
 
+	if err := c.initRoles(); err != nil {
+		return err
+	}
+
 	c.initSettings()
 	c.initHub()
 
@@ -748,13 +765,20 @@ func (c *Config) AutoImport() time.Duration {
 	return time.Duration(c.options.AutoImport) * time.Second
 }
 
-// GeoApi returns the preferred geocoding api (places, or none).
+// GeoApi returns the preferred geocoding api (places, gazetteer, or none).
 func (c *Config) GeoApi() string {
 	if c.options.DisablePlaces {
 		return ""
 	}
 
-	return "places"
+	switch strings.ToLower(strings.TrimSpace(c.options.GeoApi)) {
+	case gazetteer.ApiName, "offline", "local":
+		return gazetteer.ApiName
+	case "none", "false", "off":
+		return ""
+	default:
+		return places.ApiName
+	}
 }
 
 // OriginalsLimit returns the maximum size of originals in MB.
@@ -61,6 +61,12 @@ func (c *Config) SessionTimeout() int64 {
 	return c.options.SessionTimeout
 }
 
+// PrivateLibraries checks if users can only access their own pictures, pictures in albums shared with them,
+// and the family library, which includes all pictures without an owner.
+func (c *Config) PrivateLibraries() bool {
+	return c.options.PrivateLibraries && !c.Public()
+}
+
 // Public checks if app runs in public mode and requires no authentication.
 func (c *Config) Public() bool {
 	return c.AuthMode() == AuthModePublic
@@ -84,6 +84,18 @@ func TestSessTimeout(t *testing.T) {
 	assert.Equal(t, DefaultSessionTimeout, c.SessionTimeout())
 }
 
+func TestPrivateLibraries(t *testing.T) {
+	c := NewConfig(CliTestContext())
+	assert.False(t, c.PrivateLibraries())
+	c.options.PrivateLibraries = true
+	assert.True(t, c.PrivateLibraries())
+	c.options.Public = true
+	assert.False(t, c.PrivateLibraries())
+	c.options.Public = false
+	assert.True(t, c.PrivateLibraries())
+	c.options.PrivateLibraries = false
+}
+
 func TestUtils_CheckPassword(t *testing.T) {
 	c := NewConfig(CliTestContext())
 
@@ -0,0 +1,44 @@
+package config
+
+import (
+	"path/filepath"
+	"strings"
+
+	"github.com/photoprism/photoprism/pkg/cron"
+)
+
+// BackupSchedule returns the index backup schedule in cron format, or an empty string if disabled or invalid.
+func (c *Config) BackupSchedule() string {
+	s := strings.TrimSpace(c.options.BackupSchedule)
+
+	if s == "" {
+		return ""
+	} else if _, err := cron.Parse(s); err != nil {
+		return ""
+	}
+
+	return s
+}
+
+// BackupRetain returns the number of full index backups to keep, including their incremental backups, or 0 to keep all.
+func (c *Config) BackupRetain() int {
+	if c.options.BackupRetain < 0 {
+		return 0
+	}
+
+	return c.options.BackupRetain
+}
+
+// BackupIncremental returns the number of scheduled incremental index backups between full backups, or 0 if disabled.
+func (c *Config) BackupIncremental() int {
+	if c.options.BackupIncremental < 0 {
+		return 0
+	}
+
+	return c.options.BackupIncremental
+}
+
+// BackupIndexPath returns the default path for index backup files.
+func (c *Config) BackupIndexPath() string {
+	return filepath.Join(c.BackupPath(), c.DatabaseDriver())
+}
@@ -0,0 +1,51 @@
+package config
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConfig_BackupSchedule(t *testing.T) {
+	c := NewConfig(CliTestContext())
+
+	assert.Equal(t, "", c.BackupSchedule())
+
+	c.options.BackupSchedule = " 0 3 * * * "
+	assert.Equal(t, "0 3 * * *", c.BackupSchedule())
+
+	c.options.BackupSchedule = "@daily"
+	assert.Equal(t, "@daily", c.BackupSchedule())
+
+	c.options.BackupSchedule = "invalid"
+	assert.Equal(t, "", c.BackupSchedule())
+}
+
+func TestConfig_BackupRetain(t *testing.T) {
+	c := NewConfig(CliTestContext())
+
+	c.options.BackupRetain = 5
+	assert.Equal(t, 5, c.BackupRetain())
+
+	c.options.BackupRetain = -1
+	assert.Equal(t, 0, c.BackupRetain())
+}
+
+func TestConfig_BackupIncremental(t *testing.T) {
+	c := NewConfig(CliTestContext())
+
+	assert.Equal(t, 0, c.BackupIncremental())
+
+	c.options.BackupIncremental = 6
+	assert.Equal(t, 6, c.BackupIncremental())
+
+	c.options.BackupIncremental = -1
+	assert.Equal(t, 0, c.BackupIncremental())
+}
+
+func TestConfig_BackupIndexPath(t *testing.T) {
+	c := NewConfig(CliTestContext())
+
+	assert.Equal(t, filepath.Join(c.BackupPath(), c.DatabaseDriver()), c.BackupIndexPath())
+}
@@ -16,6 +16,9 @@ const CustomStaticUri = "/c/static"
 const DefaultAutoIndexDelay = int(5 * 60)  // 5 Minutes
 const DefaultAutoImportDelay = int(3 * 60) // 3 Minutes
 
+// DefaultBackupRetain is the default number of index backups to keep.
+const DefaultBackupRetain = 3
+
 // MinWakeupInterval and MaxWakeupInterval limit the interval duration
 // in which the background worker can be invoked.
 const MinWakeupInterval = time.Minute             // 1 Minute
@@ -38,6 +41,9 @@ const RecommendedMem = 3 * Gigabyte // 3,000,000,000 Bytes
 // DefaultResolutionLimit defines the default resolution limit.
 const DefaultResolutionLimit = 150 // 150 Megapixels
 
+// DefaultTripsDistance defines the default minimum distance from home for trips.
+const DefaultTripsDistance = 100 // 100 km
+
 // serialName is the name of the unique storage serial.
 const serialName = "serial"
 
@@ -56,5 +62,8 @@ const DefaultSessionMaxAge = UnixWeek * 2
 // DefaultSessionTimeout is the default session timeout time in seconds.
 const DefaultSessionTimeout = UnixWeek
 
+// OIDCDefaultScopes are the default scopes requested from an OpenID Connect provider.
+const OIDCDefaultScopes = "openid email profile"
+
 const Essentials = "essentials"
 const Plus = "plus"
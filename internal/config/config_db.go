@@ -12,6 +12,7 @@ import (
 
 	"github.com/jinzhu/gorm"
 	_ "github.com/jinzhu/gorm/dialects/mysql"
+	_ "github.com/jinzhu/gorm/dialects/postgres"
 	_ "github.com/jinzhu/gorm/dialects/sqlite"
 
 	"github.com/photoprism/photoprism/internal/entity"
@@ -22,7 +23,6 @@ import (
 )
 
 // SQL Databases.
-// TODO: PostgresSQL support requires upgrading GORM, so generic column data types can be used.
 const (
 	MySQL    = "mysql"
 	MariaDB  = "mariadb"
@@ -41,6 +41,8 @@ func (c *Config) DatabaseDriver() string {
 	switch strings.ToLower(c.options.DatabaseDriver) {
 	case MySQL, MariaDB:
 		c.options.DatabaseDriver = MySQL
+	case Postgres, "postgresql", "pgsql", "pg":
+		c.options.DatabaseDriver = Postgres
 	case SQLite3, "sqlite", "sqllite", "test", "file", "":
 		c.options.DatabaseDriver = SQLite3
 	case "tidb":
@@ -81,10 +83,10 @@ func (c *Config) DatabaseDsn() string {
 		case Postgres:
 			return fmt.Sprintf(
 				"user=%s password=%s dbname=%s host=%s port=%d sslmode=disable TimeZone=UTC",
-				c.DatabaseUser(),
-				c.DatabasePassword(),
-				c.DatabaseName(),
-				c.DatabaseHost(),
+				PostgresValue(c.DatabaseUser()),
+				PostgresValue(c.DatabasePassword()),
+				PostgresValue(c.DatabaseName()),
+				PostgresValue(c.DatabaseHost()),
 				c.DatabasePort(),
 			)
 		case SQLite3:
@@ -146,7 +148,11 @@ func (c *Config) DatabaseHost() string {
 
 // DatabasePort the database server port.
 func (c *Config) DatabasePort() int {
-	const defaultPort = 3306
+	defaultPort := 3306
+
+	if c.DatabaseDriver() == Postgres {
+		defaultPort = 5432
+	}
 
 	if server := c.DatabaseServer(); server == "" {
 		return 0
@@ -267,7 +273,7 @@ func (c *Config) SetDbOptions() {
 	case MySQL, MariaDB:
 		c.Db().Set("gorm:table_options", "ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci")
 	case Postgres:
-		// Ignore for now.
+		// Not required as the encoding is set when the database is created.
 	case SQLite3:
 		// Not required as unicode is default.
 	}
@@ -336,6 +342,18 @@ func (c *Config) checkDb(db *gorm.DB) error {
 		} else if sub := txt.UInt(v[1]); sub < 5 || sub == 5 && txt.UInt(v[2]) < 12 {
 			return fmt.Errorf("config: MariaDB %s is not supported, see https://docs.photoprism.app/getting-started/#databases", res.Value)
 		}
+	case Postgres:
+		type Res struct {
+			Value string `gorm:"column:server_version_num;"`
+		}
+		var res Res
+		if err := db.Raw("SHOW server_version_num").Scan(&res).Error; err != nil {
+			return nil
+		} else if v := txt.UInt(res.Value); v == 0 {
+			log.Warnf("config: unknown database server version")
+		} else if v < 120000 {
+			return fmt.Errorf("config: PostgreSQL %d.%d is not supported, see https://docs.photoprism.app/getting-started/#databases", v/10000, v%10000)
+		}
 	}
 
 	return nil
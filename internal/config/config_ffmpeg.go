@@ -28,6 +28,16 @@ func (c *Config) FFmpegEncoder() ffmpeg.AvcEncoder {
 	return ffmpeg.FindEncoder(c.options.FFmpegEncoder)
 }
 
+// FFmpegCodec returns the target codec for transcoded videos.
+func (c *Config) FFmpegCodec() ffmpeg.Codec {
+	return ffmpeg.FindCodec(c.options.FFmpegCodec)
+}
+
+// FFmpegPreset returns the quality preset for transcoding videos with the HEVC and AV1 software encoders.
+func (c *Config) FFmpegPreset() ffmpeg.Preset {
+	return ffmpeg.FindPreset(c.options.FFmpegPreset)
+}
+
 // FFmpegBitrate returns the ffmpeg bitrate limit in MBit/s.
 func (c *Config) FFmpegBitrate() int {
 	switch {
@@ -75,6 +85,8 @@ func (c *Config) FFmpegOptions(encoder ffmpeg.AvcEncoder, bitrate string) (ffmpe
 	opt := ffmpeg.Options{
 		Bin:      c.FFmpegBin(),
 		Encoder:  encoder,
+		Codec:    ffmpeg.CodecAvc,
+		Preset:   c.FFmpegPreset(),
 		Bitrate:  bitrate,
 		MapVideo: c.FFmpegMapVideo(),
 		MapAudio: c.FFmpegMapAudio(),
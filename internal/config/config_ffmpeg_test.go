@@ -29,6 +29,28 @@ func TestConfig_FFmpegEnabled(t *testing.T) {
 	assert.Equal(t, false, c.FFmpegEnabled())
 }
 
+func TestConfig_FFmpegCodec(t *testing.T) {
+	c := NewConfig(CliTestContext())
+	assert.Equal(t, ffmpeg.CodecAvc, c.FFmpegCodec())
+	c.options.FFmpegCodec = "hevc"
+	assert.Equal(t, ffmpeg.CodecHevc, c.FFmpegCodec())
+	c.options.FFmpegCodec = "AV1"
+	assert.Equal(t, ffmpeg.CodecAv1, c.FFmpegCodec())
+	c.options.FFmpegCodec = "xxx"
+	assert.Equal(t, ffmpeg.CodecAvc, c.FFmpegCodec())
+}
+
+func TestConfig_FFmpegPreset(t *testing.T) {
+	c := NewConfig(CliTestContext())
+	assert.Equal(t, ffmpeg.PresetBalanced, c.FFmpegPreset())
+	c.options.FFmpegPreset = "quality"
+	assert.Equal(t, ffmpeg.PresetQuality, c.FFmpegPreset())
+	c.options.FFmpegPreset = "fast"
+	assert.Equal(t, ffmpeg.PresetFast, c.FFmpegPreset())
+	c.options.FFmpegPreset = "xxx"
+	assert.Equal(t, ffmpeg.PresetBalanced, c.FFmpegPreset())
+}
+
 func TestConfig_FFmpegBitrate(t *testing.T) {
 	c := NewConfig(CliTestContext())
 	assert.Equal(t, 50, c.FFmpegBitrate())
@@ -81,6 +103,8 @@ func TestConfig_FFmpegOptions(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, c.FFmpegBin(), opt.Bin)
 	assert.Equal(t, ffmpeg.SoftwareEncoder, opt.Encoder)
+	assert.Equal(t, ffmpeg.CodecAvc, opt.Codec)
+	assert.Equal(t, ffmpeg.PresetBalanced, opt.Preset)
 	assert.Equal(t, bitrate, opt.Bitrate)
 	assert.Equal(t, ffmpeg.MapVideoDefault, opt.MapVideo)
 	assert.Equal(t, ffmpeg.MapAudioDefault, opt.MapAudio)
@@ -226,6 +226,15 @@ func (c *Config) DefaultsYaml() string {
 	return fs.Abs(c.options.DefaultsYaml)
 }
 
+// RolesYaml returns the custom user roles YAML filename.
+func (c *Config) RolesYaml() string {
+	if c.options.RolesYaml == "" {
+		return filepath.Join(c.ConfigPath(), "roles.yml")
+	}
+
+	return fs.Abs(c.options.RolesYaml)
+}
+
 // HubConfigFile returns the backend api config file name.
 func (c *Config) HubConfigFile() string {
 	return filepath.Join(c.ConfigPath(), "hub.yml")
@@ -325,6 +334,16 @@ func (c *Config) SidecarWritable() bool {
 	return !c.ReadOnly() || c.SidecarPathIsAbs()
 }
 
+// GazetteerPath returns the path of the local dataset used for offline reverse geocoding.
+func (c *Config) GazetteerPath() string {
+	return filepath.Join(c.StoragePath(), "gazetteer")
+}
+
+// TracksPath returns the path of recorded tracks that have been imported for geotagging.
+func (c *Config) TracksPath() string {
+	return filepath.Join(c.StoragePath(), "tracks")
+}
+
 // UsersPath returns the relative base path for user assets.
 func (c *Config) UsersPath() string {
 	// Set default.
@@ -375,6 +394,11 @@ func (c *Config) UserUploadPath(userUid, token string) (string, error) {
 	return dir, nil
 }
 
+// PartialUploadPath returns the storage path for resumable uploads that have not been completed yet.
+func (c *Config) PartialUploadPath() string {
+	return filepath.Join(c.UsersStoragePath(), ".partial")
+}
+
 // TempPath returns the cached temporary directory name e.g. for uploads and downloads.
 func (c *Config) TempPath() string {
 	// Return cached value?
@@ -468,6 +492,11 @@ func (c *Config) ThumbCachePath() string {
 	return filepath.Join(c.CachePath(), "thumbnails")
 }
 
+// HlsCachePath returns the cache path for HTTP Live Streaming (HLS) video segments.
+func (c *Config) HlsCachePath() string {
+	return filepath.Join(c.CachePath(), "hls")
+}
+
 // StoragePath returns the path for generated files like cache and index.
 func (c *Config) StoragePath() string {
 	if c.options.StoragePath == "" {
@@ -601,6 +630,16 @@ func (c *Config) MysqldumpBin() string {
 	return findBin("", "mysqldump")
 }
 
+// PsqlBin returns the psql executable file name.
+func (c *Config) PsqlBin() string {
+	return findBin("", "psql")
+}
+
+// PgDumpBin returns the pg_dump executable file name.
+func (c *Config) PgDumpBin() string {
+	return findBin("", "pg_dump")
+}
+
 // SqliteBin returns the sqlite executable file name.
 func (c *Config) SqliteBin() string {
 	return findBin("", "sqlite3")
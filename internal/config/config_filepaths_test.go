@@ -69,6 +69,11 @@ func TestConfig_UserUploadPath(t *testing.T) {
 	}
 }
 
+func TestConfig_PartialUploadPath(t *testing.T) {
+	c := NewConfig(CliTestContext())
+	assert.Contains(t, c.PartialUploadPath(), "users/.partial")
+}
+
 func TestConfig_SidecarPathIsAbs(t *testing.T) {
 	c := NewConfig(CliTestContext())
 
@@ -427,6 +432,16 @@ func TestConfig_MysqldumpBin(t *testing.T) {
 	assert.Contains(t, c.MysqldumpBin(), "mysqldump")
 }
 
+func TestConfig_PsqlBin(t *testing.T) {
+	c := NewConfig(CliTestContext())
+	assert.IsType(t, "", c.PsqlBin())
+}
+
+func TestConfig_PgDumpBin(t *testing.T) {
+	c := NewConfig(CliTestContext())
+	assert.IsType(t, "", c.PgDumpBin())
+}
+
 func TestConfig_SqliteBin(t *testing.T) {
 	c := NewConfig(CliTestContext())
 	assert.Contains(t, c.SqliteBin(), "sqlite")
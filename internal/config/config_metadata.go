@@ -15,6 +15,11 @@ func (c *Config) ExifToolJson() bool {
 	return !c.DisableExifTool()
 }
 
+// SidecarXmp checks if metadata changes should be written to XMP sidecar files.
+func (c *Config) SidecarXmp() bool {
+	return c.options.SidecarXmp
+}
+
 // BackupYaml checks if creating YAML files is enabled.
 func (c *Config) BackupYaml() bool {
 	return !c.DisableBackups()
@@ -41,3 +41,13 @@ func TestConfig_SidecarYaml(t *testing.T) {
 	assert.Equal(t, false, c.BackupYaml())
 	assert.Equal(t, c.DisableBackups(), !c.BackupYaml())
 }
+
+func TestConfig_SidecarXmp(t *testing.T) {
+	c := NewConfig(CliTestContext())
+
+	assert.False(t, c.SidecarXmp())
+
+	c.options.SidecarXmp = true
+
+	assert.True(t, c.SidecarXmp())
+}
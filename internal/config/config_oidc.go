@@ -0,0 +1,99 @@
+package config
+
+import (
+	"net/url"
+	"strings"
+
+	"github.com/photoprism/photoprism/pkg/clean"
+)
+
+// OIDCEnabled checks if sign-on with OpenID Connect is enabled.
+func (c *Config) OIDCEnabled() bool {
+	if c.Public() {
+		return false
+	}
+
+	return c.OIDCUri() != "" && c.OIDCClient() != ""
+}
+
+// OIDCUri returns the OpenID Connect issuer URL or an empty string if it is not set or invalid.
+func (c *Config) OIDCUri() string {
+	if c.options.OIDCUri == "" {
+		return ""
+	}
+
+	u, err := url.Parse(strings.TrimRight(c.options.OIDCUri, "/"))
+
+	if err != nil {
+		log.Warnf("config: invalid oidc uri (%s)", err)
+		return ""
+	} else if u.Scheme != "https" && u.Scheme != "http" || u.Host == "" {
+		log.Warnf("config: invalid oidc uri %s", clean.Log(c.options.OIDCUri))
+		return ""
+	}
+
+	return u.String()
+}
+
+// OIDCInsecure checks if the OpenID Connect issuer's HTTPS certificate should not be verified.
+func (c *Config) OIDCInsecure() bool {
+	return c.options.OIDCInsecure
+}
+
+// OIDCClient returns the OpenID Connect client ID.
+func (c *Config) OIDCClient() string {
+	return strings.TrimSpace(c.options.OIDCClient)
+}
+
+// OIDCSecret returns the OpenID Connect client secret.
+func (c *Config) OIDCSecret() string {
+	return strings.TrimSpace(c.options.OIDCSecret)
+}
+
+// OIDCScopes returns the OpenID Connect scopes to request.
+func (c *Config) OIDCScopes() []string {
+	scopes := strings.Fields(strings.ReplaceAll(c.options.OIDCScopes, ",", " "))
+
+	if len(scopes) == 0 {
+		scopes = strings.Fields(OIDCDefaultScopes)
+	}
+
+	// The "openid" scope is required.
+	for _, s := range scopes {
+		if s == "openid" {
+			return scopes
+		}
+	}
+
+	return append([]string{"openid"}, scopes...)
+}
+
+// OIDCRole returns the name of the OpenID Connect claim that contains the user role.
+func (c *Config) OIDCRole() string {
+	return strings.TrimSpace(c.options.OIDCRole)
+}
+
+// OIDCRegister checks if new user accounts should be created when they first sign in with OpenID Connect.
+func (c *Config) OIDCRegister() bool {
+	return c.options.OIDCRegister
+}
+
+// OIDCLink checks if existing accounts may be linked to an OpenID Connect identity with a matching username
+// and verified email. Linked accounts can no longer sign in with a local password.
+func (c *Config) OIDCLink() bool {
+	return c.options.OIDCLink
+}
+
+// OIDCLoginUri returns the URI that starts the OpenID Connect sign-on flow, or an empty string if disabled.
+func (c *Config) OIDCLoginUri() string {
+	if !c.OIDCEnabled() {
+		return ""
+	}
+
+	return c.ApiUri() + "/oidc/login"
+}
+
+// OIDCRedirectUrl returns the callback URL to which the OpenID Connect provider redirects after authentication.
+func (c *Config) OIDCRedirectUrl() string {
+	return strings.TrimRight(c.SiteUrl(), "/") + ApiUri + "/oidc/redirect"
+}
@@ -0,0 +1,118 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConfig_OIDCEnabled(t *testing.T) {
+	c := NewConfig(CliTestContext())
+	c.options.Public = false
+
+	assert.False(t, c.OIDCEnabled())
+
+	c.options.OIDCUri = "https://keycloak.localssl.dev/auth/realms/master"
+	c.options.OIDCClient = "photoprism-develop"
+
+	assert.True(t, c.OIDCEnabled())
+
+	c.options.Public = true
+
+	assert.False(t, c.OIDCEnabled())
+}
+
+func TestConfig_OIDCUri(t *testing.T) {
+	c := NewConfig(CliTestContext())
+
+	assert.Equal(t, "", c.OIDCUri())
+
+	c.options.OIDCUri = "https://keycloak.localssl.dev/auth/realms/master/"
+	assert.Equal(t, "https://keycloak.localssl.dev/auth/realms/master", c.OIDCUri())
+
+	c.options.OIDCUri = "keycloak.localssl.dev"
+	assert.Equal(t, "", c.OIDCUri())
+
+	c.options.OIDCUri = "ftp://keycloak.localssl.dev/"
+	assert.Equal(t, "", c.OIDCUri())
+}
+
+func TestConfig_OIDCInsecure(t *testing.T) {
+	c := NewConfig(CliTestContext())
+
+	assert.False(t, c.OIDCInsecure())
+	c.options.OIDCInsecure = true
+	assert.True(t, c.OIDCInsecure())
+}
+
+func TestConfig_OIDCClient(t *testing.T) {
+	c := NewConfig(CliTestContext())
+
+	assert.Equal(t, "", c.OIDCClient())
+	c.options.OIDCClient = " photoprism-develop "
+	assert.Equal(t, "photoprism-develop", c.OIDCClient())
+}
+
+func TestConfig_OIDCSecret(t *testing.T) {
+	c := NewConfig(CliTestContext())
+
+	assert.Equal(t, "", c.OIDCSecret())
+	c.options.OIDCSecret = "9d8351a0-ca01-4556-9c37-85eb634869b9"
+	assert.Equal(t, "9d8351a0-ca01-4556-9c37-85eb634869b9", c.OIDCSecret())
+}
+
+func TestConfig_OIDCScopes(t *testing.T) {
+	c := NewConfig(CliTestContext())
+
+	c.options.OIDCScopes = ""
+	assert.Equal(t, []string{"openid", "email", "profile"}, c.OIDCScopes())
+
+	c.options.OIDCScopes = "openid, email groups"
+	assert.Equal(t, []string{"openid", "email", "groups"}, c.OIDCScopes())
+
+	c.options.OIDCScopes = "email"
+	assert.Equal(t, []string{"openid", "email"}, c.OIDCScopes())
+}
+
+func TestConfig_OIDCRole(t *testing.T) {
+	c := NewConfig(CliTestContext())
+
+	assert.Equal(t, "", c.OIDCRole())
+	c.options.OIDCRole = " photoprism_role "
+	assert.Equal(t, "photoprism_role", c.OIDCRole())
+}
+
+func TestConfig_OIDCRegister(t *testing.T) {
+	c := NewConfig(CliTestContext())
+
+	assert.False(t, c.OIDCRegister())
+	c.options.OIDCRegister = true
+	assert.True(t, c.OIDCRegister())
+}
+
+func TestConfig_OIDCLink(t *testing.T) {
+	c := NewConfig(CliTestContext())
+
+	assert.False(t, c.OIDCLink())
+	c.options.OIDCLink = true
+	assert.True(t, c.OIDCLink())
+	c.options.OIDCLink = false
+}
+
+func TestConfig_OIDCLoginUri(t *testing.T) {
+	c := NewConfig(CliTestContext())
+	c.options.Public = false
+
+	assert.Equal(t, "", c.OIDCLoginUri())
+
+	c.options.OIDCUri = "https://keycloak.localssl.dev/auth/realms/master"
+	c.options.OIDCClient = "photoprism-develop"
+
+	assert.Equal(t, "/api/v1/oidc/login", c.OIDCLoginUri())
+}
+
+func TestConfig_OIDCRedirectUrl(t *testing.T) {
+	c := NewConfig(CliTestContext())
+
+	assert.Equal(t, "http://photoprism.me:2342/api/v1/oidc/redirect", c.OIDCRedirectUrl())
+}
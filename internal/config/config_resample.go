@@ -4,6 +4,7 @@ import (
 	"strings"
 
 	"github.com/photoprism/photoprism/internal/thumb"
+	"github.com/photoprism/photoprism/pkg/fs"
 )
 
 // JpegSize returns the size limit for automatically converted files in `PIXELS` (720-30000).
@@ -93,3 +94,45 @@ func (c *Config) ThumbSizeUncached() int {
 
 	return limit
 }
+
+// AvifEncoderBin returns the AVIF encoder executable file name.
+func (c *Config) AvifEncoderBin() string {
+	return findBin("", "avifenc")
+}
+
+// WebPEncoderBin returns the WebP encoder executable file name.
+func (c *Config) WebPEncoderBin() string {
+	return findBin("", "cwebp")
+}
+
+// ThumbFormats returns the additional thumbnail formats for which an encoder is available, in order of preference.
+func (c *Config) ThumbFormats() (result []fs.Type) {
+	for _, format := range thumb.ParseFormats(c.options.ThumbFormats) {
+		switch format {
+		case fs.ImageAVIF:
+			if c.AvifEncoderBin() == "" {
+				continue
+			}
+		case fs.ImageWebP:
+			if c.WebPEncoderBin() == "" {
+				continue
+			}
+		}
+
+		result = append(result, format)
+	}
+
+	return result
+}
+
+// ThumbFormatsString returns the additional thumbnail formats as comma-separated string.
+func (c *Config) ThumbFormatsString() string {
+	formats := c.ThumbFormats()
+	result := make([]string, len(formats))
+
+	for i, format := range formats {
+		result[i] = string(format)
+	}
+
+	return strings.Join(result, ", ")
+}
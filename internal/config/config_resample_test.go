@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"github.com/photoprism/photoprism/internal/thumb"
+	"github.com/photoprism/photoprism/pkg/fs"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -81,3 +82,26 @@ func TestConfig_ThumbSizeUncached2(t *testing.T) {
 	c.options.ThumbSize = 900
 	assert.Equal(t, int(900), c.ThumbSizeUncached())
 }
+
+func TestConfig_ThumbFormats(t *testing.T) {
+	c := NewConfig(CliTestContext())
+
+	assert.Empty(t, c.ThumbFormats())
+	assert.Equal(t, "", c.ThumbFormatsString())
+
+	c.options.ThumbFormats = "foo"
+	assert.Empty(t, c.ThumbFormats())
+
+	c.options.ThumbFormats = "webp, avif"
+
+	for _, format := range c.ThumbFormats() {
+		switch format {
+		case fs.ImageAVIF:
+			assert.NotEmpty(t, c.AvifEncoderBin())
+		case fs.ImageWebP:
+			assert.NotEmpty(t, c.WebPEncoderBin())
+		default:
+			t.Errorf("unexpected format %s", format)
+		}
+	}
+}
@@ -0,0 +1,82 @@
+package config
+
+import (
+	"strings"
+
+	"github.com/photoprism/photoprism/internal/storage"
+)
+
+// S3Endpoint returns the S3-compatible object storage endpoint URL.
+func (c *Config) S3Endpoint() string {
+	return strings.TrimRight(strings.TrimSpace(c.options.S3Endpoint), "/")
+}
+
+// S3Region returns the S3 region for signing requests.
+func (c *Config) S3Region() string {
+	if region := strings.TrimSpace(c.options.S3Region); region != "" {
+		return region
+	}
+
+	return storage.S3DefaultRegion
+}
+
+// S3Bucket returns the S3 bucket name.
+func (c *Config) S3Bucket() string {
+	return strings.TrimSpace(c.options.S3Bucket)
+}
+
+// S3Prefix returns the S3 object key prefix for originals.
+func (c *Config) S3Prefix() string {
+	return storage.CleanName(c.options.S3Prefix)
+}
+
+// S3AccessKey returns the S3 access key ID.
+func (c *Config) S3AccessKey() string {
+	return strings.TrimSpace(c.options.S3AccessKey)
+}
+
+// S3SecretKey returns the S3 secret access key.
+func (c *Config) S3SecretKey() string {
+	return strings.TrimSpace(c.options.S3SecretKey)
+}
+
+// S3PathStyle checks if path-style S3 requests should be used instead of bucket subdomains.
+func (c *Config) S3PathStyle() bool {
+	return c.options.S3PathStyle
+}
+
+// S3Enabled checks if an S3-compatible object storage is configured.
+func (c *Config) S3Enabled() bool {
+	return c.S3Endpoint() != "" && c.S3Bucket() != ""
+}
+
+// S3Originals checks if originals are stored in an S3-compatible bucket.
+func (c *Config) S3Originals() bool {
+	return c.options.S3Originals && c.S3Enabled()
+}
+
+// S3Storage returns the S3-compatible bucket storage.
+func (c *Config) S3Storage() (*storage.S3, error) {
+	return storage.NewS3(storage.S3Options{
+		Endpoint:  c.S3Endpoint(),
+		Region:    c.S3Region(),
+		Bucket:    c.S3Bucket(),
+		Prefix:    c.S3Prefix(),
+		AccessKey: c.S3AccessKey(),
+		SecretKey: c.S3SecretKey(),
+		PathStyle: c.S3PathStyle(),
+	})
+}
+
+// OriginalsStorage returns the storage backend for originals, which is either
+// an S3-compatible bucket or the local originals folder.
+func (c *Config) OriginalsStorage() storage.Storage {
+	if !c.S3Originals() {
+		return storage.NewLocal(c.OriginalsPath())
+	} else if s, err := c.S3Storage(); err != nil {
+		log.Errorf("config: %s", err)
+		return storage.NewLocal(c.OriginalsPath())
+	} else {
+		return s
+	}
+}
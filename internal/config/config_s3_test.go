@@ -0,0 +1,68 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/storage"
+)
+
+func TestConfig_S3Endpoint(t *testing.T) {
+	c := NewConfig(CliTestContext())
+
+	assert.Equal(t, "", c.S3Endpoint())
+
+	c.options.S3Endpoint = " http://localhost:9000/ "
+	assert.Equal(t, "http://localhost:9000", c.S3Endpoint())
+}
+
+func TestConfig_S3Region(t *testing.T) {
+	c := NewConfig(CliTestContext())
+
+	c.options.S3Region = ""
+	assert.Equal(t, storage.S3DefaultRegion, c.S3Region())
+
+	c.options.S3Region = "eu-central-1"
+	assert.Equal(t, "eu-central-1", c.S3Region())
+}
+
+func TestConfig_S3Prefix(t *testing.T) {
+	c := NewConfig(CliTestContext())
+
+	assert.Equal(t, "", c.S3Prefix())
+
+	c.options.S3Prefix = "/photos/originals/"
+	assert.Equal(t, "photos/originals", c.S3Prefix())
+}
+
+func TestConfig_S3Originals(t *testing.T) {
+	c := NewConfig(CliTestContext())
+
+	assert.False(t, c.S3Enabled())
+	assert.False(t, c.S3Originals())
+	assert.IsType(t, &storage.Local{}, c.OriginalsStorage())
+
+	c.options.S3Originals = true
+	assert.False(t, c.S3Originals())
+
+	c.options.S3Endpoint = "http://localhost:9000"
+	c.options.S3Bucket = "photos"
+	c.options.S3AccessKey = "minio"
+	c.options.S3SecretKey = "minio123"
+	c.options.S3PathStyle = true
+
+	assert.True(t, c.S3Enabled())
+	assert.True(t, c.S3Originals())
+	assert.True(t, c.S3PathStyle())
+	assert.Equal(t, "photos", c.S3Bucket())
+	assert.Equal(t, "minio", c.S3AccessKey())
+	assert.Equal(t, "minio123", c.S3SecretKey())
+
+	if s, ok := c.OriginalsStorage().(*storage.S3); assert.True(t, ok) {
+		assert.Equal(t, "s3://photos", s.String())
+	}
+
+	c.options.S3Originals = false
+	assert.False(t, c.S3Originals())
+}
@@ -2,7 +2,10 @@ package config
 
 import (
 	"path/filepath"
+	"runtime"
+	"strings"
 
+	"github.com/photoprism/photoprism/pkg/clean"
 	tf "github.com/tensorflow/tensorflow/tensorflow/go"
 )
 
@@ -16,6 +19,51 @@ func (c *Config) TensorFlowModelPath() string {
 	return filepath.Join(c.AssetsPath(), "nasnet")
 }
 
+// ModelsPath returns the path of custom image classification models, one per subdirectory.
+func (c *Config) ModelsPath() string {
+	return filepath.Join(c.StoragePath(), "models")
+}
+
+// ClassificationModel returns the name of the image classification model, or an empty string for the built-in model.
+func (c *Config) ClassificationModel() string {
+	if c.options.ClassificationModel == "" {
+		return ""
+	}
+
+	name := clean.Type(filepath.Base(c.options.ClassificationModel))
+
+	if name == "." || strings.EqualFold(name, "nasnet") {
+		return ""
+	}
+
+	return name
+}
+
+// ClassificationModelPath returns the image classification model path.
+func (c *Config) ClassificationModelPath() string {
+	if name := c.ClassificationModel(); name != "" {
+		return filepath.Join(c.ModelsPath(), name)
+	}
+
+	return c.TensorFlowModelPath()
+}
+
+// ONNXRuntime returns the ONNX Runtime shared library file name, which is required for ONNX classification models.
+func (c *Config) ONNXRuntime() string {
+	if c.options.ONNXRuntime != "" {
+		return c.options.ONNXRuntime
+	}
+
+	switch runtime.GOOS {
+	case "darwin":
+		return "libonnxruntime.dylib"
+	case "windows":
+		return "onnxruntime.dll"
+	default:
+		return "libonnxruntime.so"
+	}
+}
+
 // NSFWModelPath returns the "not safe for work" TensorFlow model path.
 func (c *Config) NSFWModelPath() string {
 	return filepath.Join(c.AssetsPath(), "nsfw")
@@ -2,6 +2,7 @@ package config
 
 import (
 	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 	"time"
@@ -131,6 +132,19 @@ func TestConfig_OptionsYaml(t *testing.T) {
 	})
 }
 
+func TestConfig_RolesYaml(t *testing.T) {
+	t.Run("Default", func(t *testing.T) {
+		c := NewConfig(CliTestContext())
+		assert.Equal(t, filepath.Join(c.ConfigPath(), "roles.yml"), c.RolesYaml())
+	})
+
+	t.Run("Custom", func(t *testing.T) {
+		c := NewConfig(CliTestContext())
+		c.options.RolesYaml = "/etc/photoprism/roles.yml"
+		assert.Equal(t, "/etc/photoprism/roles.yml", c.RolesYaml())
+	})
+}
+
 func TestConfig_BackupPath(t *testing.T) {
 	c := NewConfig(CliTestContext())
 
@@ -213,6 +227,27 @@ func TestConfig_ThumbCachePath(t *testing.T) {
 	assert.True(t, strings.HasSuffix(c.ThumbCachePath(), "storage/testdata/cache/thumbnails"))
 }
 
+func TestConfig_GazetteerPath(t *testing.T) {
+	c := NewConfig(CliTestContext())
+
+	assert.True(t, strings.HasPrefix(c.GazetteerPath(), "/"))
+	assert.True(t, strings.HasSuffix(c.GazetteerPath(), "storage/testdata/gazetteer"))
+}
+
+func TestConfig_TracksPath(t *testing.T) {
+	c := NewConfig(CliTestContext())
+
+	assert.True(t, strings.HasPrefix(c.TracksPath(), "/"))
+	assert.True(t, strings.HasSuffix(c.TracksPath(), "storage/testdata/tracks"))
+}
+
+func TestConfig_HlsCachePath(t *testing.T) {
+	c := NewConfig(CliTestContext())
+
+	assert.True(t, strings.HasPrefix(c.HlsCachePath(), "/"))
+	assert.True(t, strings.HasSuffix(c.HlsCachePath(), "storage/testdata/cache/hls"))
+}
+
 func TestConfig_AssetsPath(t *testing.T) {
 	c := NewConfig(CliTestContext())
 
@@ -357,6 +392,14 @@ func TestConfig_AutoImport(t *testing.T) {
 func TestConfig_GeoApi(t *testing.T) {
 	c := NewConfig(CliTestContext())
 
+	assert.Equal(t, "places", c.GeoApi())
+	c.options.GeoApi = "gazetteer"
+	assert.Equal(t, "gazetteer", c.GeoApi())
+	c.options.GeoApi = "Offline"
+	assert.Equal(t, "gazetteer", c.GeoApi())
+	c.options.GeoApi = "none"
+	assert.Equal(t, "", c.GeoApi())
+	c.options.GeoApi = "foo"
 	assert.Equal(t, "places", c.GeoApi())
 	c.options.DisablePlaces = true
 	assert.Equal(t, "", c.GeoApi())
@@ -616,3 +659,37 @@ func TestConfigOptions(t *testing.T) {
 	assert.Equal(t, r2.AutoImport, 0)
 	assert.Equal(t, r2.AutoIndex, 0)
 }
+
+func TestConfig_ModelsPath(t *testing.T) {
+	c := NewConfig(CliTestContext())
+
+	assert.Equal(t, filepath.Join(c.StoragePath(), "models"), c.ModelsPath())
+}
+
+func TestConfig_ClassificationModel(t *testing.T) {
+	c := NewConfig(CliTestContext())
+
+	assert.Equal(t, "", c.ClassificationModel())
+	assert.Equal(t, c.TensorFlowModelPath(), c.ClassificationModelPath())
+
+	c.options.ClassificationModel = "NasNet"
+	assert.Equal(t, "", c.ClassificationModel())
+	assert.Equal(t, c.TensorFlowModelPath(), c.ClassificationModelPath())
+
+	c.options.ClassificationModel = "../EfficientNet"
+	assert.Equal(t, "EfficientNet", c.ClassificationModel())
+	assert.Equal(t, filepath.Join(c.ModelsPath(), "EfficientNet"), c.ClassificationModelPath())
+
+	c.options.ClassificationModel = ""
+}
+
+func TestConfig_ONNXRuntime(t *testing.T) {
+	c := NewConfig(CliTestContext())
+
+	assert.NotEmpty(t, c.ONNXRuntime())
+
+	c.options.ONNXRuntime = "/opt/onnxruntime/lib/libonnxruntime.so.1.20.0"
+	assert.Equal(t, "/opt/onnxruntime/lib/libonnxruntime.so.1.20.0", c.ONNXRuntime())
+
+	c.options.ONNXRuntime = ""
+}
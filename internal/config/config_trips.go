@@ -0,0 +1,50 @@
+package config
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/photoprism/photoprism/pkg/geo"
+)
+
+// TripsHome returns the home location used for detecting trips as "lat,lng", or an empty string if disabled.
+func (c *Config) TripsHome() string {
+	if p := c.TripsHomePosition(); p.Lat == 0 && p.Lng == 0 {
+		return ""
+	} else {
+		return fmt.Sprintf("%f,%f", p.Lat, p.Lng)
+	}
+}
+
+// TripsHomePosition returns the home location used for detecting trips.
+func (c *Config) TripsHomePosition() geo.Position {
+	coords := strings.Split(strings.TrimSpace(c.options.TripsHome), ",")
+
+	if len(coords) != 2 {
+		return geo.Position{}
+	}
+
+	lat, latErr := strconv.ParseFloat(strings.TrimSpace(coords[0]), 64)
+	lng, lngErr := strconv.ParseFloat(strings.TrimSpace(coords[1]), 64)
+
+	if latErr != nil || lngErr != nil || lat < -90 || lat > 90 || lng < -180 || lng > 180 {
+		return geo.Position{}
+	}
+
+	return geo.Position{Name: "home", Lat: lat, Lng: lng}
+}
+
+// TripsDistance returns the minimum distance from home in km for pictures to be considered part of a trip.
+func (c *Config) TripsDistance() int {
+	if c.options.TripsDistance <= 0 || c.options.TripsDistance > 20000 {
+		return DefaultTripsDistance
+	}
+
+	return c.options.TripsDistance
+}
+
+// DetectTrips checks if trips should be detected and added as albums.
+func (c *Config) DetectTrips() bool {
+	return c.TripsHome() != "" && !c.DisablePlaces()
+}
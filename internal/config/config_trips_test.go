@@ -0,0 +1,56 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConfig_TripsHome(t *testing.T) {
+	c := NewConfig(CliTestContext())
+
+	assert.Equal(t, "", c.TripsHome())
+	assert.False(t, c.DetectTrips())
+
+	c.options.TripsHome = "52.5200, 13.4050"
+	assert.Equal(t, "52.520000,13.405000", c.TripsHome())
+	assert.True(t, c.DetectTrips())
+
+	c.options.TripsHome = "-33.8688,151.2093"
+	assert.Equal(t, "-33.868800,151.209300", c.TripsHome())
+
+	c.options.TripsHome = "91,13"
+	assert.Equal(t, "", c.TripsHome())
+
+	c.options.TripsHome = "berlin"
+	assert.Equal(t, "", c.TripsHome())
+
+	c.options.TripsHome = "52.52,13.405"
+	c.options.DisablePlaces = true
+	assert.False(t, c.DetectTrips())
+}
+
+func TestConfig_TripsHomePosition(t *testing.T) {
+	c := NewConfig(CliTestContext())
+
+	assert.Equal(t, 0.0, c.TripsHomePosition().Lat)
+
+	c.options.TripsHome = "52.52,13.405"
+
+	p := c.TripsHomePosition()
+
+	assert.Equal(t, 52.52, p.Lat)
+	assert.Equal(t, 13.405, p.Lng)
+}
+
+func TestConfig_TripsDistance(t *testing.T) {
+	c := NewConfig(CliTestContext())
+
+	assert.Equal(t, DefaultTripsDistance, c.TripsDistance())
+
+	c.options.TripsDistance = 250
+	assert.Equal(t, 250, c.TripsDistance())
+
+	c.options.TripsDistance = -1
+	assert.Equal(t, DefaultTripsDistance, c.TripsDistance())
+}
@@ -1,6 +1,9 @@
 package config
 
-import "regexp"
+import (
+	"regexp"
+	"strings"
+)
 
 // dsnPattern is a regular expression matching a database DSN string.
 var dsnPattern = regexp.MustCompile(
@@ -9,6 +12,9 @@ var dsnPattern = regexp.MustCompile(
 		`\/(?P<name>.*?)` +
 		`(?:\?(?P<params>[^\?]*))?$`)
 
+// dsnKeyValuePattern is a regular expression matching a PostgreSQL connection string with key/value pairs.
+var dsnKeyValuePattern = regexp.MustCompile(`^\s*[a-z_]+\s*=`)
+
 // DSN represents parts of a data source name.
 type DSN struct {
 	Driver   string
@@ -31,6 +37,9 @@ func NewDSN(dsn string) DSN {
 func (d *DSN) Parse(dsn string) {
 	if dsn == "" {
 		return
+	} else if dsnKeyValuePattern.MatchString(dsn) {
+		d.parseKeyValues(dsn)
+		return
 	}
 
 	matches := dsnPattern.FindStringSubmatch(dsn)
@@ -60,3 +69,80 @@ func (d *DSN) Parse(dsn string) {
 		d.Net = ""
 	}
 }
+
+// parseKeyValues parses a PostgreSQL connection string with key/value pairs,
+// e.g. "user=photoprism password=secret dbname=photoprism host=localhost port=5432".
+func (d *DSN) parseKeyValues(dsn string) {
+	var host, port string
+	var params []string
+
+	for _, kv := range splitKeyValues(dsn) {
+		k, v, _ := strings.Cut(kv, "=")
+
+		switch strings.TrimSpace(k) {
+		case "user":
+			d.User = v
+		case "password":
+			d.Password = v
+		case "dbname":
+			d.Name = v
+		case "host":
+			host = v
+		case "port":
+			port = v
+		default:
+			params = append(params, kv)
+		}
+	}
+
+	if port != "" {
+		d.Server = host + ":" + port
+	} else {
+		d.Server = host
+	}
+
+	d.Params = strings.Join(params, " ")
+}
+
+// splitKeyValues splits a key/value connection string into pairs and removes quotes from the values.
+func splitKeyValues(dsn string) (result []string) {
+	var kv strings.Builder
+	var quoted, escaped bool
+
+	for _, r := range dsn {
+		switch {
+		case escaped:
+			kv.WriteRune(r)
+			escaped = false
+		case r == '\\':
+			escaped = true
+		case r == '\'':
+			quoted = !quoted
+		case r == ' ' && !quoted:
+			if kv.Len() > 0 {
+				result = append(result, kv.String())
+				kv.Reset()
+			}
+		default:
+			kv.WriteRune(r)
+		}
+	}
+
+	if kv.Len() > 0 {
+		result = append(result, kv.String())
+	}
+
+	return result
+}
+
+// PostgresValue quotes a value for use in a PostgreSQL connection string, if needed.
+func PostgresValue(s string) string {
+	if s != "" && !strings.ContainsAny(s, " '\\") {
+		return s
+	}
+
+	s = strings.ReplaceAll(s, "\\", "\\\\")
+	s = strings.ReplaceAll(s, "'", "\\'")
+
+	return "'" + s + "'"
+}
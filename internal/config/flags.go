@@ -9,8 +9,10 @@ import (
 	"github.com/photoprism/photoprism/internal/entity"
 	"github.com/photoprism/photoprism/internal/face"
 	"github.com/photoprism/photoprism/internal/ffmpeg"
+	"github.com/photoprism/photoprism/internal/hub/places"
 	"github.com/photoprism/photoprism/internal/i18n"
 	"github.com/photoprism/photoprism/internal/server/header"
+	"github.com/photoprism/photoprism/internal/storage"
 	"github.com/photoprism/photoprism/internal/thumb"
 	"github.com/photoprism/photoprism/pkg/txt"
 )
@@ -53,6 +55,52 @@ var Flags = CliFlags{
 			Usage:  "time in `SECONDS` until API sessions expire due to inactivity (-1 to disable)",
 			EnvVar: EnvVar("SESSION_TIMEOUT"),
 		}}, {
+		Flag: cli.BoolFlag{
+			Name:   "private-libraries",
+			Usage:  "users can only access their own pictures, albums shared with them, and pictures without an owner",
+			EnvVar: EnvVar("PRIVATE_LIBRARIES"),
+		}}, {
+		Flag: cli.StringFlag{
+			Name:   "oidc-uri",
+			Usage:  "OpenID Connect issuer `URL` for single sign-on *optional*",
+			EnvVar: EnvVar("OIDC_URI"),
+		}}, {
+		Flag: cli.BoolFlag{
+			Name:   "oidc-insecure",
+			Usage:  "skip verification of the OpenID Connect issuer's HTTPS certificate",
+			EnvVar: EnvVar("OIDC_INSECURE"),
+		}}, {
+		Flag: cli.StringFlag{
+			Name:   "oidc-client",
+			Usage:  "OpenID Connect client `ID`",
+			EnvVar: EnvVar("OIDC_CLIENT"),
+		}}, {
+		Flag: cli.StringFlag{
+			Name:   "oidc-secret",
+			Usage:  "OpenID Connect client `SECRET`",
+			EnvVar: EnvVar("OIDC_SECRET"),
+		}}, {
+		Flag: cli.StringFlag{
+			Name:   "oidc-scopes",
+			Usage:  "OpenID Connect `SCOPES` to request, separated by spaces",
+			Value:  OIDCDefaultScopes,
+			EnvVar: EnvVar("OIDC_SCOPES"),
+		}}, {
+		Flag: cli.StringFlag{
+			Name:   "oidc-role",
+			Usage:  "OpenID Connect claim `NAME` that contains the user role, new accounts get the guest role if empty",
+			EnvVar: EnvVar("OIDC_ROLE"),
+		}}, {
+		Flag: cli.BoolFlag{
+			Name:   "oidc-register",
+			Usage:  "create new user accounts when they first sign in with OpenID Connect",
+			EnvVar: EnvVar("OIDC_REGISTER"),
+		}}, {
+		Flag: cli.BoolFlag{
+			Name:   "oidc-link",
+			Usage:  "link existing accounts with a matching username and verified email on first sign-in, which disables their password login",
+			EnvVar: EnvVar("OIDC_LINK"),
+		}}, {
 		Flag: cli.StringFlag{
 			Name:   "log-level, l",
 			Usage:  "log message verbosity `LEVEL` (trace, debug, info, warning, error, fatal, panic)",
@@ -115,6 +163,11 @@ var Flags = CliFlags{
 			Value:  "/etc/photoprism/defaults.yml",
 			EnvVar: EnvVar("DEFAULTS_YAML"),
 		}}, {
+		Flag: cli.StringFlag{
+			Name:   "roles-yaml",
+			Usage:  "load custom user roles from `FILE` if exists (default: roles.yml in the config path)",
+			EnvVar: EnvVar("ROLES_YAML"),
+		}}, {
 		Flag: cli.StringFlag{
 			Name:   "originals-path, o",
 			Usage:  "storage `PATH` of your original media files (photos and videos)",
@@ -132,6 +185,47 @@ var Flags = CliFlags{
 			Usage:  "maximum resolution of media files in `MEGAPIXELS` (1-900; -1 to disable)",
 			EnvVar: EnvVar("RESOLUTION_LIMIT"),
 		}}, {
+		Flag: cli.StringFlag{
+			Name:   "s3-endpoint",
+			Usage:  "S3-compatible object storage endpoint `URL`, e.g. https://s3.amazonaws.com *optional*",
+			EnvVar: EnvVar("S3_ENDPOINT"),
+		}}, {
+		Flag: cli.StringFlag{
+			Name:   "s3-region",
+			Usage:  "S3 `REGION` for signing requests",
+			Value:  storage.S3DefaultRegion,
+			EnvVar: EnvVar("S3_REGION"),
+		}}, {
+		Flag: cli.StringFlag{
+			Name:   "s3-bucket",
+			Usage:  "S3 bucket `NAME`",
+			EnvVar: EnvVar("S3_BUCKET"),
+		}}, {
+		Flag: cli.StringFlag{
+			Name:   "s3-prefix",
+			Usage:  "S3 object key `PREFIX` for originals *optional*",
+			EnvVar: EnvVar("S3_PREFIX"),
+		}}, {
+		Flag: cli.StringFlag{
+			Name:   "s3-access-key",
+			Usage:  "S3 access key `ID`",
+			EnvVar: EnvVar("S3_ACCESS_KEY"),
+		}}, {
+		Flag: cli.StringFlag{
+			Name:   "s3-secret-key",
+			Usage:  "S3 secret access `KEY`",
+			EnvVar: EnvVar("S3_SECRET_KEY"),
+		}}, {
+		Flag: cli.BoolFlag{
+			Name:   "s3-path-style",
+			Usage:  "use path-style S3 requests instead of bucket subdomains, e.g. for MinIO",
+			EnvVar: EnvVar("S3_PATH_STYLE"),
+		}}, {
+		Flag: cli.BoolFlag{
+			Name:   "s3-originals",
+			Usage:  "store originals in the S3 bucket and use the originals path as local staging cache for indexing",
+			EnvVar: EnvVar("S3_ORIGINALS"),
+		}}, {
 		Flag: cli.StringFlag{
 			Name:   "users-path",
 			Usage:  "relative `PATH` to create base and upload subdirectories for users",
@@ -153,6 +247,22 @@ var Flags = CliFlags{
 			Usage:  "custom backup `PATH` for index backup files *optional*",
 			EnvVar: EnvVar("BACKUP_PATH"),
 		}}, {
+		Flag: cli.StringFlag{
+			Name:   "backup-schedule",
+			Usage:  "index backup `SCHEDULE` in cron format, e.g. \"0 3 * * *\" or @daily (leave empty to disable)",
+			EnvVar: EnvVar("BACKUP_SCHEDULE"),
+		}}, {
+		Flag: cli.IntFlag{
+			Name:   "backup-retain",
+			Usage:  "`NUMBER` of full index backups to keep, including their incremental backups (0 to keep all)",
+			Value:  DefaultBackupRetain,
+			EnvVar: EnvVar("BACKUP_RETAIN"),
+		}}, {
+		Flag: cli.IntFlag{
+			Name:   "backup-incremental",
+			Usage:  "`NUMBER` of scheduled incremental index backups between full backups (0 to disable)",
+			EnvVar: EnvVar("BACKUP_INCREMENTAL"),
+		}}, {
 		Flag: cli.StringFlag{
 			Name:   "cache-path, ca",
 			Usage:  "custom cache `PATH` for sessions and thumbnail files *optional*",
@@ -307,6 +417,28 @@ var Flags = CliFlags{
 			Usage:  "always perform a brute-force search if no Exif headers were found",
 			EnvVar: EnvVar("EXIF_BRUTEFORCE"),
 		}}, {
+		Flag: cli.BoolFlag{
+			Name:   "sidecar-xmp",
+			Usage:  "write metadata changes to XMP sidecar files so that they can be read by other apps",
+			EnvVar: EnvVar("SIDECAR_XMP"),
+		}}, {
+		Flag: cli.StringFlag{
+			Name:   "geo-api",
+			Usage:  "reverse geocoding `SERVICE` (places, gazetteer), the gazetteer works offline with a local dataset",
+			Value:  places.ApiName,
+			EnvVar: EnvVar("GEO_API"),
+		}}, {
+		Flag: cli.StringFlag{
+			Name:   "trips-home",
+			Usage:  "home `LAT,LNG` for detecting trips and creating trip albums (leave empty to disable)",
+			EnvVar: EnvVar("TRIPS_HOME"),
+		}}, {
+		Flag: cli.IntFlag{
+			Name:   "trips-distance",
+			Value:  DefaultTripsDistance,
+			Usage:  "minimum distance from home in `KM` for pictures to be considered part of a trip",
+			EnvVar: EnvVar("TRIPS_DISTANCE"),
+		}}, {
 		Flag: cli.BoolFlag{
 			Name:   "detect-nsfw",
 			Usage:  "automatically flag photos as private that MAY be offensive (requires TensorFlow)",
@@ -317,6 +449,16 @@ var Flags = CliFlags{
 			Usage:  "allow uploads that MAY be offensive (no effect without TensorFlow)",
 			EnvVar: EnvVar("UPLOAD_NSFW"),
 		}}, {
+		Flag: cli.StringFlag{
+			Name:   "classification-model",
+			Usage:  "image classification model `NAME` in the storage models folder, uses the built-in model if empty",
+			EnvVar: EnvVar("CLASSIFICATION_MODEL"),
+		}}, {
+		Flag: cli.StringFlag{
+			Name:   "onnx-runtime",
+			Usage:  "ONNX Runtime shared library `FILE` for classification models in ONNX format",
+			EnvVar: EnvVar("ONNX_RUNTIME"),
+		}}, {
 		Flag: cli.StringFlag{
 			Name:   "default-locale, lang",
 			Usage:  "standard user interface language `CODE`",
@@ -519,13 +661,13 @@ var Flags = CliFlags{
 		}}, {
 		Flag: cli.StringFlag{
 			Name:   "database-driver, db",
-			Usage:  "database `DRIVER` (sqlite, mysql)",
+			Usage:  "database `DRIVER` (sqlite, mysql, postgres)",
 			Value:  "sqlite",
 			EnvVar: EnvVar("DATABASE_DRIVER"),
 		}}, {
 		Flag: cli.StringFlag{
 			Name:   "database-dsn, dsn",
-			Usage:  "database connection `DSN` (sqlite file, optional for mysql)",
+			Usage:  "database connection `DSN` (sqlite file, optional for mysql and postgres)",
 			EnvVar: EnvVar("DATABASE_DSN"),
 		}}, {
 		Flag: cli.StringFlag{
@@ -585,6 +727,18 @@ var Flags = CliFlags{
 			EnvVar: EnvVar("FFMPEG_ENCODER"),
 		},
 		Tags: []string{Essentials}}, {
+		Flag: cli.StringFlag{
+			Name:   "ffmpeg-codec",
+			Usage:  "FFmpeg target `CODEC` for transcoded videos (avc, hevc, av1), AVC is used as fallback for incompatible clients",
+			Value:  ffmpeg.CodecAvc.String(),
+			EnvVar: EnvVar("FFMPEG_CODEC"),
+		}}, {
+		Flag: cli.StringFlag{
+			Name:   "ffmpeg-preset",
+			Usage:  "FFmpeg HEVC and AV1 software encoding quality `PRESET` (fast, balanced, quality)",
+			Value:  ffmpeg.PresetBalanced.String(),
+			EnvVar: EnvVar("FFMPEG_PRESET"),
+		}}, {
 		Flag: cli.IntFlag{
 			Name:   "ffmpeg-bitrate, vb",
 			Usage:  "maximum FFmpeg encoding `BITRATE` (Mbit/s)",
@@ -702,6 +856,11 @@ var Flags = CliFlags{
 			Usage:  "enable on-demand creation of missing thumbnails (high memory and cpu usage)",
 			EnvVar: EnvVar("THUMB_UNCACHED"),
 		}}, {
+		Flag: cli.StringFlag{
+			Name:   "thumb-formats",
+			Usage:  "additional thumbnail `FORMATS` for browsers that support them, in order of preference (avif, webp)",
+			EnvVar: EnvVar("THUMB_FORMATS"),
+		}}, {
 		Flag: cli.StringFlag{
 			Name:   "jpeg-quality, q",
 			Usage:  "a higher value increases the `QUALITY` and file size of JPEG images and thumbnails (25-100)",
@@ -33,6 +33,15 @@ type Options struct {
 	AdminPassword         string        `yaml:"AdminPassword" json:"-" flag:"admin-password"`
 	SessionMaxAge         int64         `yaml:"SessionMaxAge" json:"-" flag:"session-maxage"`
 	SessionTimeout        int64         `yaml:"SessionTimeout" json:"-" flag:"session-timeout"`
+	PrivateLibraries      bool          `yaml:"PrivateLibraries" json:"-" flag:"private-libraries"`
+	OIDCUri               string        `yaml:"OIDCUri" json:"-" flag:"oidc-uri"`
+	OIDCInsecure          bool          `yaml:"OIDCInsecure" json:"-" flag:"oidc-insecure"`
+	OIDCClient            string        `yaml:"OIDCClient" json:"-" flag:"oidc-client"`
+	OIDCSecret            string        `yaml:"OIDCSecret" json:"-" flag:"oidc-secret"`
+	OIDCScopes            string        `yaml:"OIDCScopes" json:"-" flag:"oidc-scopes"`
+	OIDCRole              string        `yaml:"OIDCRole" json:"-" flag:"oidc-role"`
+	OIDCRegister          bool          `yaml:"OIDCRegister" json:"-" flag:"oidc-register"`
+	OIDCLink              bool          `yaml:"OIDCLink" json:"-" flag:"oidc-link"`
 	LogLevel              string        `yaml:"LogLevel" json:"-" flag:"log-level"`
 	Prod                  bool          `yaml:"Prod" json:"Prod" flag:"prod"`
 	Debug                 bool          `yaml:"Debug" json:"Debug" flag:"debug"`
@@ -43,13 +52,25 @@ type Options struct {
 	Sponsor               bool          `yaml:"-" json:"-" flag:"sponsor"`
 	ConfigPath            string        `yaml:"ConfigPath" json:"-" flag:"config-path"`
 	DefaultsYaml          string        `json:"-" yaml:"-" flag:"defaults-yaml"`
+	RolesYaml             string        `yaml:"RolesYaml" json:"-" flag:"roles-yaml"`
 	OriginalsPath         string        `yaml:"OriginalsPath" json:"-" flag:"originals-path"`
 	OriginalsLimit        int           `yaml:"OriginalsLimit" json:"OriginalsLimit" flag:"originals-limit"`
 	ResolutionLimit       int           `yaml:"ResolutionLimit" json:"ResolutionLimit" flag:"resolution-limit"`
+	S3Endpoint            string        `yaml:"S3Endpoint" json:"-" flag:"s3-endpoint"`
+	S3Region              string        `yaml:"S3Region" json:"-" flag:"s3-region"`
+	S3Bucket              string        `yaml:"S3Bucket" json:"-" flag:"s3-bucket"`
+	S3Prefix              string        `yaml:"S3Prefix" json:"-" flag:"s3-prefix"`
+	S3AccessKey           string        `yaml:"S3AccessKey" json:"-" flag:"s3-access-key"`
+	S3SecretKey           string        `yaml:"S3SecretKey" json:"-" flag:"s3-secret-key"`
+	S3PathStyle           bool          `yaml:"S3PathStyle" json:"-" flag:"s3-path-style"`
+	S3Originals           bool          `yaml:"S3Originals" json:"-" flag:"s3-originals"`
 	UsersPath             string        `yaml:"UsersPath" json:"-" flag:"users-path"`
 	StoragePath           string        `yaml:"StoragePath" json:"-" flag:"storage-path"`
 	SidecarPath           string        `yaml:"SidecarPath" json:"-" flag:"sidecar-path"`
 	BackupPath            string        `yaml:"BackupPath" json:"-" flag:"backup-path"`
+	BackupSchedule        string        `yaml:"BackupSchedule" json:"BackupSchedule" flag:"backup-schedule"`
+	BackupRetain          int           `yaml:"BackupRetain" json:"BackupRetain" flag:"backup-retain"`
+	BackupIncremental     int           `yaml:"BackupIncremental" json:"BackupIncremental" flag:"backup-incremental"`
 	CachePath             string        `yaml:"CachePath" json:"-" flag:"cache-path"`
 	ImportPath            string        `yaml:"ImportPath" json:"-" flag:"import-path"`
 	ImportDest            string        `yaml:"ImportDest" json:"-" flag:"import-dest"`
@@ -82,8 +103,14 @@ type Options struct {
 	DisableRaw            bool          `yaml:"DisableRaw" json:"DisableRaw" flag:"disable-raw"`
 	RawPresets            bool          `yaml:"RawPresets" json:"RawPresets" flag:"raw-presets"`
 	ExifBruteForce        bool          `yaml:"ExifBruteForce" json:"ExifBruteForce" flag:"exif-bruteforce"`
+	SidecarXmp            bool          `yaml:"SidecarXmp" json:"SidecarXmp" flag:"sidecar-xmp"`
+	GeoApi                string        `yaml:"GeoApi" json:"GeoApi" flag:"geo-api"`
+	TripsHome             string        `yaml:"TripsHome" json:"-" flag:"trips-home"`
+	TripsDistance         int           `yaml:"TripsDistance" json:"TripsDistance" flag:"trips-distance"`
 	DetectNSFW            bool          `yaml:"DetectNSFW" json:"DetectNSFW" flag:"detect-nsfw"`
 	UploadNSFW            bool          `yaml:"UploadNSFW" json:"-" flag:"upload-nsfw"`
+	ClassificationModel   string        `yaml:"ClassificationModel" json:"ClassificationModel" flag:"classification-model"`
+	ONNXRuntime           string        `yaml:"ONNXRuntime" json:"-" flag:"onnx-runtime"`
 	DefaultTheme          string        `yaml:"DefaultTheme" json:"DefaultTheme" flag:"default-theme"`
 	DefaultLocale         string        `yaml:"DefaultLocale" json:"DefaultLocale" flag:"default-locale"`
 	AppName               string        `yaml:"AppName" json:"AppName" flag:"app-name"`
@@ -128,6 +155,8 @@ type Options struct {
 	SipsBlacklist         string        `yaml:"SipsBlacklist" json:"-" flag:"sips-blacklist"`
 	FFmpegBin             string        `yaml:"FFmpegBin" json:"-" flag:"ffmpeg-bin"`
 	FFmpegEncoder         string        `yaml:"FFmpegEncoder" json:"FFmpegEncoder" flag:"ffmpeg-encoder"`
+	FFmpegCodec           string        `yaml:"FFmpegCodec" json:"FFmpegCodec" flag:"ffmpeg-codec"`
+	FFmpegPreset          string        `yaml:"FFmpegPreset" json:"FFmpegPreset" flag:"ffmpeg-preset"`
 	FFmpegBitrate         int           `yaml:"FFmpegBitrate" json:"FFmpegBitrate" flag:"ffmpeg-bitrate"`
 	FFmpegMapVideo        string        `yaml:"FFmpegMapVideo" json:"FFmpegMapVideo" flag:"ffmpeg-map-video"`
 	FFmpegMapAudio        string        `yaml:"FFmpegMapAudio" json:"FFmpegMapAudio" flag:"ffmpeg-map-audio"`
@@ -149,6 +178,7 @@ type Options struct {
 	ThumbSize             int           `yaml:"ThumbSize" json:"ThumbSize" flag:"thumb-size"`
 	ThumbSizeUncached     int           `yaml:"ThumbSizeUncached" json:"ThumbSizeUncached" flag:"thumb-size-uncached"`
 	ThumbUncached         bool          `yaml:"ThumbUncached" json:"ThumbUncached" flag:"thumb-uncached"`
+	ThumbFormats          string        `yaml:"ThumbFormats" json:"ThumbFormats" flag:"thumb-formats"`
 	JpegQuality           string        `yaml:"JpegQuality" json:"JpegQuality" flag:"jpeg-quality"`
 	JpegSize              int           `yaml:"JpegSize" json:"JpegSize" flag:"jpeg-size"`
 	PngSize               int           `yaml:"PngSize" json:"PngSize" flag:"png-size"`
@@ -27,10 +27,19 @@ func (c *Config) Report() (rows [][]string, cols []string) {
 		{"public", fmt.Sprintf("%t", c.Public())},
 		{"session-maxage", fmt.Sprintf("%d", c.SessionMaxAge())},
 		{"session-timeout", fmt.Sprintf("%d", c.SessionTimeout())},
+		{"private-libraries", fmt.Sprintf("%t", c.PrivateLibraries())},
 		{"login-uri", c.LoginUri()},
 		{"register-uri", c.RegisterUri()},
 		{"password-length", fmt.Sprintf("%d", c.PasswordLength())},
 		{"password-reset-uri", c.PasswordResetUri()},
+		{"oidc-uri", c.OIDCUri()},
+		{"oidc-insecure", fmt.Sprintf("%t", c.OIDCInsecure())},
+		{"oidc-client", c.OIDCClient()},
+		{"oidc-secret", strings.Repeat("*", utf8.RuneCountInString(c.OIDCSecret()))},
+		{"oidc-scopes", strings.Join(c.OIDCScopes(), " ")},
+		{"oidc-role", c.OIDCRole()},
+		{"oidc-register", fmt.Sprintf("%t", c.OIDCRegister())},
+		{"oidc-link", fmt.Sprintf("%t", c.OIDCLink())},
 
 		// Logging.
 		{"log-level", c.LogLevel().String()},
@@ -42,6 +51,7 @@ func (c *Config) Report() (rows [][]string, cols []string) {
 		{"certificates-path", c.CertificatesPath()},
 		{"options-yaml", c.OptionsYaml()},
 		{"defaults-yaml", c.DefaultsYaml()},
+		{"roles-yaml", c.RolesYaml()},
 	}
 
 	// Settings.
@@ -56,6 +66,14 @@ func (c *Config) Report() (rows [][]string, cols []string) {
 		{"originals-path", c.OriginalsPath()},
 		{"originals-limit", fmt.Sprintf("%d", c.OriginalsLimit())},
 		{"resolution-limit", fmt.Sprintf("%d", c.ResolutionLimit())},
+		{"s3-endpoint", c.S3Endpoint()},
+		{"s3-region", c.S3Region()},
+		{"s3-bucket", c.S3Bucket()},
+		{"s3-prefix", c.S3Prefix()},
+		{"s3-access-key", c.S3AccessKey()},
+		{"s3-secret-key", strings.Repeat("*", utf8.RuneCountInString(c.S3SecretKey()))},
+		{"s3-path-style", fmt.Sprintf("%t", c.S3PathStyle())},
+		{"s3-originals", fmt.Sprintf("%t", c.S3Originals())},
 		{"users-path", c.UsersPath()},
 		{"users-originals-path", c.UsersOriginalsPath()},
 
@@ -65,10 +83,16 @@ func (c *Config) Report() (rows [][]string, cols []string) {
 		{"sidecar-path", c.SidecarPath()},
 		{"albums-path", c.AlbumsPath()},
 		{"backup-path", c.BackupPath()},
+		{"backup-schedule", c.BackupSchedule()},
+		{"backup-retain", fmt.Sprintf("%d", c.BackupRetain())},
+		{"backup-incremental", fmt.Sprintf("%d", c.BackupIncremental())},
 		{"cache-path", c.CachePath()},
 		{"cmd-cache-path", c.CmdCachePath()},
 		{"media-cache-path", c.MediaCachePath()},
 		{"thumb-cache-path", c.ThumbCachePath()},
+		{"hls-cache-path", c.HlsCachePath()},
+		{"gazetteer-path", c.GazetteerPath()},
+		{"tracks-path", c.TracksPath()},
 		{"import-path", c.ImportPath()},
 		{"import-dest", c.ImportDest()},
 		{"assets-path", c.AssetsPath()},
@@ -109,12 +133,19 @@ func (c *Config) Report() (rows [][]string, cols []string) {
 		// Format Flags.
 		{"raw-presets", fmt.Sprintf("%t", c.RawPresets())},
 		{"exif-bruteforce", fmt.Sprintf("%t", c.ExifBruteForce())},
+		{"sidecar-xmp", fmt.Sprintf("%t", c.SidecarXmp())},
+		{"geo-api", c.GeoApi()},
+		{"trips-home", c.TripsHome()},
+		{"trips-distance", fmt.Sprintf("%d", c.TripsDistance())},
 
 		// TensorFlow.
 		{"detect-nsfw", fmt.Sprintf("%t", c.DetectNSFW())},
 		{"upload-nsfw", fmt.Sprintf("%t", c.UploadNSFW())},
 		{"tensorflow-version", c.TensorFlowVersion()},
 		{"tensorflow-model-path", c.TensorFlowModelPath()},
+		{"classification-model", c.ClassificationModel()},
+		{"onnx-runtime", c.ONNXRuntime()},
+		{"models-path", c.ModelsPath()},
 
 		// Customization.
 		{"default-locale", c.DefaultLocale()},
@@ -181,6 +212,8 @@ func (c *Config) Report() (rows [][]string, cols []string) {
 		{"sips-blacklist", c.SipsBlacklist()},
 		{"ffmpeg-bin", c.FFmpegBin()},
 		{"ffmpeg-encoder", c.FFmpegEncoder().String()},
+		{"ffmpeg-codec", c.FFmpegCodec().String()},
+		{"ffmpeg-preset", c.FFmpegPreset().String()},
 		{"ffmpeg-bitrate", fmt.Sprintf("%d", c.FFmpegBitrate())},
 		{"ffmpeg-map-video", c.FFmpegMapVideo()},
 		{"ffmpeg-map-audio", c.FFmpegMapAudio()},
@@ -196,6 +229,8 @@ func (c *Config) Report() (rows [][]string, cols []string) {
 		{"heifconvert-bin", c.HeifConvertBin()},
 		{"rsvgconvert-bin", c.RsvgConvertBin()},
 		{"jpegxldecoder-bin", c.JpegXLDecoderBin()},
+		{"avifencoder-bin", c.AvifEncoderBin()},
+		{"webpencoder-bin", c.WebPEncoderBin()},
 
 		// Thumbnails.
 		{"download-token", c.DownloadToken()},
@@ -205,6 +240,7 @@ func (c *Config) Report() (rows [][]string, cols []string) {
 		{"thumb-size", fmt.Sprintf("%d", c.ThumbSizePrecached())},
 		{"thumb-size-uncached", fmt.Sprintf("%d", c.ThumbSizeUncached())},
 		{"thumb-uncached", fmt.Sprintf("%t", c.ThumbUncached())},
+		{"thumb-formats", c.ThumbFormatsString()},
 		{"jpeg-quality", fmt.Sprintf("%d", c.JpegQuality())},
 		{"jpeg-size", fmt.Sprintf("%d", c.JpegSize())},
 		{"png-size", fmt.Sprintf("%d", c.PngSize())},
@@ -0,0 +1,32 @@
+package config
+
+import (
+	"fmt"
+
+	"github.com/dustin/go-humanize/english"
+
+	"github.com/photoprism/photoprism/internal/acl"
+	"github.com/photoprism/photoprism/pkg/clean"
+	"github.com/photoprism/photoprism/pkg/fs"
+)
+
+// initRoles adds custom user roles from a config file, if it exists.
+func (c *Config) initRoles() error {
+	fileName := c.RolesYaml()
+
+	if !fs.FileExists(fileName) {
+		return nil
+	}
+
+	roles, err := acl.Resources.LoadRoles(fileName)
+
+	if err != nil {
+		return fmt.Errorf("config: %s in %s", err, clean.Log(fileName))
+	}
+
+	acl.RegisterRoles(roles...)
+
+	log.Infof("config: loaded %s from %s", english.Plural(len(roles), "custom role", "custom roles"), clean.Log(fileName))
+
+	return nil
+}
@@ -0,0 +1,43 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/acl"
+)
+
+func TestConfig_initRoles(t *testing.T) {
+	t.Run("NotFound", func(t *testing.T) {
+		c := NewConfig(CliTestContext())
+		c.options.RolesYaml = "testdata/missing.yml"
+
+		assert.NoError(t, c.initRoles())
+	})
+	t.Run("Success", func(t *testing.T) {
+		c := NewConfig(CliTestContext())
+		c.options.RolesYaml = "testdata/roles.yml"
+
+		// Remove custom roles from the global access control list when done.
+		t.Cleanup(func() {
+			for _, roles := range acl.Resources {
+				delete(roles, "editor")
+			}
+
+			delete(acl.ValidRoles, "editor")
+		})
+
+		assert.NoError(t, c.initRoles())
+		assert.Equal(t, acl.Role("editor"), acl.ValidRoles["editor"])
+		assert.True(t, acl.Resources.Allow(acl.ResourcePhotos, "editor", acl.ActionUpdate))
+		assert.True(t, acl.Resources.Allow(acl.ResourceAlbums, "editor", acl.ActionView))
+		assert.False(t, acl.Resources.Allow(acl.ResourceAlbums, "editor", acl.ActionUpdate))
+	})
+	t.Run("Invalid", func(t *testing.T) {
+		c := NewConfig(CliTestContext())
+		c.options.RolesYaml = "testdata/roles-invalid.yml"
+
+		assert.Error(t, c.initRoles())
+	})
+}
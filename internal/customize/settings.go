@@ -89,9 +89,11 @@ func NewSettings(theme, lang string) *Settings {
 			Convert: true,
 		},
 		Stack: StackSettings{
-			UUID: true,
-			Meta: true,
-			Name: false,
+			UUID:         true,
+			Meta:         true,
+			Name:         false,
+			Burst:        false,
+			BurstSeconds: DefaultBurstSeconds,
 		},
 		Share: ShareSettings{
 			Title: "",
@@ -123,6 +125,20 @@ func (s Settings) StackMeta() bool {
 	return s.Stack.Meta
 }
 
+// StackBursts checks if pictures taken in quick succession with the same camera should be suggested as stacks.
+func (s Settings) StackBursts() bool {
+	return s.Stack.Burst
+}
+
+// StackBurstSeconds returns the maximum time in seconds between pictures of the same burst.
+func (s Settings) StackBurstSeconds() int {
+	if s.Stack.BurstSeconds <= 0 || s.Stack.BurstSeconds > 60 {
+		return DefaultBurstSeconds
+	}
+
+	return s.Stack.BurstSeconds
+}
+
 // Load user settings from file.
 func (s *Settings) Load(fileName string) error {
 	if fileName == "" {
@@ -86,4 +86,16 @@ func TestSettings_Stacks(t *testing.T) {
 	assert.False(t, s.StackSequences())
 	assert.True(t, s.StackUUID())
 	assert.True(t, s.StackMeta())
+	assert.False(t, s.StackBursts())
+	assert.Equal(t, DefaultBurstSeconds, s.StackBurstSeconds())
+
+	s.Stack.Burst = true
+	s.Stack.BurstSeconds = 5
+
+	assert.True(t, s.StackBursts())
+	assert.Equal(t, 5, s.StackBurstSeconds())
+
+	s.Stack.BurstSeconds = 3600
+
+	assert.Equal(t, DefaultBurstSeconds, s.StackBurstSeconds())
 }
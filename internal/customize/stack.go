@@ -1,8 +1,13 @@
 package customize
 
+// DefaultBurstSeconds is the default maximum time between pictures of the same burst or bracketed exposure.
+const DefaultBurstSeconds = 2
+
 // StackSettings represents settings for files that belong to the same photo.
 type StackSettings struct {
-	UUID bool `json:"uuid" yaml:"UUID"`
-	Meta bool `json:"meta" yaml:"Meta"`
-	Name bool `json:"name" yaml:"Name"`
+	UUID         bool `json:"uuid" yaml:"UUID"`
+	Meta         bool `json:"meta" yaml:"Meta"`
+	Name         bool `json:"name" yaml:"Name"`
+	Burst        bool `json:"burst" yaml:"Burst"`
+	BurstSeconds int  `json:"burstSeconds" yaml:"BurstSeconds,omitempty"`
 }
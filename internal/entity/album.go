@@ -120,7 +120,8 @@ func AddPhotoToUserAlbums(photoUid string, albums []string, userUid string) (err
 		}
 
 		if albumUid != "" {
-			entry := PhotoAlbum{AlbumUID: albumUid, PhotoUID: photoUid, Hidden: false}
+			// Photos uploaded by visitors remain hidden in albums until they are approved.
+			entry := PhotoAlbum{AlbumUID: albumUid, PhotoUID: photoUid, Hidden: userUid == Visitor.UserUID}
 
 			if err = entry.Save(); err != nil {
 				log.Errorf("album: %s (add photo %s to albums)", err.Error(), photoUid)
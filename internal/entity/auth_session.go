@@ -475,6 +475,44 @@ func (m *Session) SharedUIDs() UIDs {
 	}
 }
 
+// DownloadUIDs returns the shared entity UIDs that may be downloaded.
+func (m *Session) DownloadUIDs() UIDs {
+	if user := m.User(); user.IsRegistered() {
+		return user.DownloadUIDs()
+	} else if data := m.Data(); data == nil {
+		return UIDs{}
+	} else {
+		return data.DownloadUIDs()
+	}
+}
+
+// ShareLinks returns the active links through which the session has access to the shared entity.
+func (m *Session) ShareLinks(shareUid string) (links Links) {
+	links = Links{}
+
+	if shareUid == "" {
+		return links
+	} else if user := m.User(); user.IsRegistered() {
+		return user.ShareLinks(shareUid)
+	} else if data := m.Data(); data != nil {
+		for _, token := range data.Tokens {
+			links = append(links, FindActiveLinks(token, shareUid)...)
+		}
+	}
+
+	return links
+}
+
+// CountDownload increases the download counter of the links through which the session has access to the shared entity.
+func (m *Session) CountDownload(shareUid string) {
+	links := m.ShareLinks(shareUid)
+
+	for i := range links {
+		link := links[i].Download()
+		event.AuditInfo([]string{m.IP(), "session %s", "link %s", "download %d of %d"}, m.RefID, clean.Log(link.RefID), link.LinkDownloads, link.MaxDownloads)
+	}
+}
+
 // RedeemToken updates shared entity UIDs using the specified token.
 func (m *Session) RedeemToken(token string) (n int) {
 	if user := m.User(); user.IsRegistered() {
@@ -17,6 +17,21 @@ func (u UIDs) Join(s string) string {
 	return strings.Join(u, s)
 }
 
+// Contains checks if the specified UID is included.
+func (u UIDs) Contains(uid string) bool {
+	if uid == "" {
+		return false
+	}
+
+	for _, s := range u {
+		if s == uid {
+			return true
+		}
+	}
+
+	return false
+}
+
 // SessionData represents User Session data.
 type SessionData struct {
 	Tokens []string `json:"tokens"` // Share Tokens.
@@ -33,7 +48,8 @@ func (data *SessionData) RefreshShares() *SessionData {
 	var shares []string
 
 	for _, token := range data.Tokens {
-		links := FindValidLinks(token, "")
+		// Links that have reached the maximum number of views remain accessible for existing visitors.
+		links := FindActiveLinks(token, "").Viewable()
 
 		if len(links) == 0 {
 			continue
@@ -51,7 +67,7 @@ func (data *SessionData) RefreshShares() *SessionData {
 
 // RedeemToken appends a new token and updates the list of shared UIDs in the session data.
 func (data *SessionData) RedeemToken(token string) (n int) {
-	links := FindValidLinks(token, "")
+	links := FindValidLinks(token, "").Viewable()
 
 	// No valid links found?
 	if n = len(links); n == 0 {
@@ -95,6 +111,21 @@ func (data SessionData) HasShare(uid string) bool {
 	return false
 }
 
+// DownloadUIDs returns the shared entity UIDs that may be downloaded.
+func (data SessionData) DownloadUIDs() UIDs {
+	var result UIDs
+
+	for _, token := range data.Tokens {
+		for _, link := range FindActiveLinks(token, "").Viewable() {
+			if link.CanDownload() {
+				result = append(result, link.ShareUID)
+			}
+		}
+	}
+
+	return result
+}
+
 // SharedUIDs returns shared entity UIDs.
 func (data SessionData) SharedUIDs() UIDs {
 	if len(data.Tokens) > 0 && len(data.Shares) == 0 {
@@ -16,8 +16,57 @@ func TestUIDs_Join(t *testing.T) {
 	assert.Equal(t, "dghjkfd|dfgehrih", uid.Join("|"))
 }
 
+func TestUIDs_Contains(t *testing.T) {
+	uid := UIDs{"dghjkfd", "dfgehrih"}
+	assert.True(t, uid.Contains("dfgehrih"))
+	assert.False(t, uid.Contains("xxx"))
+	assert.False(t, uid.Contains(""))
+}
+
 func TestData_HasShare(t *testing.T) {
 	data := SessionData{Shares: []string{"abc123", "def444"}}
 	assert.True(t, data.HasShare("def444"))
 	assert.False(t, data.HasShare("xxx"))
 }
+
+func TestData_DownloadUIDs(t *testing.T) {
+	t.Run("Default", func(t *testing.T) {
+		data := SessionData{Tokens: []string{"1jxf3jfn2k"}}
+		assert.Equal(t, UIDs{"at9lxuqxpogaaba8"}, data.DownloadUIDs())
+	})
+	t.Run("ViewOnly", func(t *testing.T) {
+		link := NewLink("pt9jtdre2lvl0yh7", false, false)
+		link.Perm = PermView
+
+		if err := link.Save(); err != nil {
+			t.Fatal(err)
+		}
+
+		defer link.Delete()
+
+		data := SessionData{Tokens: []string{link.LinkToken}}
+		assert.Empty(t, data.DownloadUIDs())
+	})
+	t.Run("NoTokens", func(t *testing.T) {
+		data := SessionData{}
+		assert.Empty(t, data.DownloadUIDs())
+	})
+}
+
+func TestData_RedeemToken(t *testing.T) {
+	t.Run("UploadOnly", func(t *testing.T) {
+		link := NewLink("at9lxuqxpogaaba9", false, false)
+		link.Perm = PermUpload
+
+		if err := link.Save(); err != nil {
+			t.Fatal(err)
+		}
+
+		defer link.Delete()
+
+		data := SessionData{}
+
+		assert.Equal(t, 0, data.RedeemToken(link.LinkToken))
+		assert.False(t, data.HasShare("at9lxuqxpogaaba9"))
+	})
+}
@@ -115,6 +115,15 @@ func (m *Session) LogIn(f form.Login, c *gin.Context) (err error) {
 	if f.HasToken() {
 		user = m.User()
 
+		// Password-protected links can only be redeemed with the correct password.
+		if FindValidLinks(f.AuthToken, "").InvalidPassword(f.LinkPassword) {
+			limiter.Login.Reserve(m.IP())
+			event.AuditWarn([]string{m.IP(), "session %s", "share token %s", "incorrect password"}, m.RefID, clean.LogQuote(f.AuthToken))
+			event.LoginError(m.IP(), "api", "", m.UserAgent, "incorrect share password")
+			m.Status = http.StatusUnauthorized
+			return i18n.Error(i18n.ErrInvalidPassword)
+		}
+
 		// Redeem token.
 		if user.IsRegistered() {
 			if shares := user.RedeemToken(f.AuthToken); shares == 0 {
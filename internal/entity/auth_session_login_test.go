@@ -6,6 +6,7 @@ import (
 	"testing"
 
 	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
 
 	"github.com/photoprism/photoprism/internal/form"
 )
@@ -73,4 +74,46 @@ func TestSessionLogIn(t *testing.T) {
 			t.Fatal("login should fail")
 		}
 	})
+	t.Run("LinkPassword", func(t *testing.T) {
+		link := NewLink("at9lxuqxpogaaba9", false, false)
+
+		if err := link.SetPassword("secret"); err != nil {
+			t.Fatal(err)
+		} else if err = link.Save(); err != nil {
+			t.Fatal(err)
+		}
+
+		defer link.Delete()
+
+		for _, pw := range []string{"", "wrong"} {
+			m := NewSession(UnixDay, UnixHour*6)
+			m.SetClientIP(clientIp)
+
+			frm := form.Login{AuthToken: link.LinkToken, LinkPassword: pw}
+
+			ctx.Request = httptest.NewRequest(http.MethodPost, "/api/v1/session", form.AsReader(frm))
+			ctx.Request.RemoteAddr = "1.2.3.4"
+
+			if err := m.LogIn(frm, ctx); err == nil {
+				t.Fatal("login should fail")
+			}
+
+			assert.Equal(t, http.StatusUnauthorized, m.HttpStatus())
+			assert.False(t, m.HasShare("at9lxuqxpogaaba9"))
+		}
+
+		m := NewSession(UnixDay, UnixHour*6)
+		m.SetClientIP(clientIp)
+
+		frm := form.Login{AuthToken: link.LinkToken, LinkPassword: "secret"}
+
+		ctx.Request = httptest.NewRequest(http.MethodPost, "/api/v1/session", form.AsReader(frm))
+		ctx.Request.RemoteAddr = "1.2.3.4"
+
+		if err := m.LogIn(frm, ctx); err != nil {
+			t.Fatal(err)
+		}
+
+		assert.True(t, m.HasShare("at9lxuqxpogaaba9"))
+	})
 }
@@ -158,3 +158,38 @@ func TestSession_Expired(t *testing.T) {
 		assert.Equal(t, m.ExpiresAt(), m.TimeoutAt())
 	})
 }
+
+func TestSession_ShareLinks(t *testing.T) {
+	t.Run("Visitor", func(t *testing.T) {
+		m := NewSession(UnixDay, UnixHour*6)
+		m.SetData(&SessionData{Tokens: []string{"1jxf3jfn2k"}})
+
+		links := m.ShareLinks("at9lxuqxpogaaba8")
+
+		assert.Len(t, links, 1)
+		assert.Equal(t, "1jxf3jfn2k", links[0].LinkToken)
+		assert.Empty(t, m.ShareLinks("at9lxuqxpogaaba9"))
+		assert.Empty(t, m.ShareLinks(""))
+	})
+}
+
+func TestSession_CountDownload(t *testing.T) {
+	link := NewLink("at9lxuqxpogaaba9", false, false)
+	link.MaxDownloads = 1
+
+	if err := link.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	defer link.Delete()
+
+	m := NewSession(UnixDay, UnixHour*6)
+	m.SetData(&SessionData{Tokens: []string{link.LinkToken}})
+
+	assert.True(t, m.DownloadUIDs().Contains("at9lxuqxpogaaba9"))
+
+	m.CountDownload("at9lxuqxpogaaba9")
+
+	assert.False(t, m.DownloadUIDs().Contains("at9lxuqxpogaaba9"))
+	assert.True(t, m.HasShare("at9lxuqxpogaaba9"))
+}
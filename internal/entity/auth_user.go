@@ -37,6 +37,10 @@ var PasswordLength = 4
 // UsersPath is the relative path for user assets.
 var UsersPath = "users"
 
+// PrivateLibraries specifies whether users can only access their own pictures, pictures in
+// albums shared with them, and the family library, which includes all pictures without an owner.
+var PrivateLibraries = false
+
 // Users represents a list of users.
 type Users []User
 
@@ -109,6 +113,15 @@ func LdapUser(username, dn string) User {
 	}
 }
 
+// OidcUser creates an OpenID Connect user entity.
+func OidcUser(username, subject string) User {
+	return User{
+		UserName:     clean.Username(username),
+		AuthID:       subject,
+		AuthProvider: authn.ProviderOIDC.String(),
+	}
+}
+
 // FindUser returns the matching user or nil if it was not found.
 func FindUser(find User) *User {
 	m := &User{}
@@ -200,6 +213,26 @@ func FindUserByUID(uid string) *User {
 	return FindUser(User{UserUID: uid})
 }
 
+// FindUserByToken returns the registered user to whom the preview or download token was issued,
+// or nil if it was not found.
+func FindUserByToken(token string) *User {
+	if token == "" {
+		return nil
+	}
+
+	m := &User{}
+
+	// Build query.
+	if err := UnscopedDb().
+		Where("preview_token = ? OR download_token = ?", token, token).
+		First(m).Error; err != nil {
+		return nil
+	}
+
+	// Return with related records.
+	return m.LoadRelated()
+}
+
 // UID returns the unique id as string.
 func (m *User) UID() string {
 	if m == nil {
@@ -415,7 +448,7 @@ func (m *User) CanUseWebDAV() bool {
 	} else if role := m.AclRole(); m.Disabled() || !m.WebDAV || m.ID <= 0 || m.UserName == "" || role == acl.RoleUnknown {
 		return false
 	} else {
-		return acl.Resources.Allow(acl.ResourcePhotos, role, acl.ActionUpload)
+		return acl.Resources.Allow(acl.ResourceFiles, role, acl.ActionManage)
 	}
 }
 
@@ -443,7 +476,7 @@ func (m *User) DefaultBasePath() string {
 
 // GetBasePath returns the user's relative base path.
 func (m *User) GetBasePath() string {
-	if m.BasePath == "" && m.HasRole("contributor") {
+	if m.BasePath == "" && m.HasRole(acl.RoleContributor.String()) {
 		m.BasePath = m.DefaultBasePath()
 	}
 
@@ -724,6 +757,16 @@ func (m *User) IsSuperAdmin() bool {
 	return m.SuperAdmin
 }
 
+// HasPrivateLibrary checks if the user can only access their own pictures, pictures in albums shared
+// with them, and the family library. Super admins always have access to the entire library.
+func (m *User) HasPrivateLibrary() bool {
+	if m == nil {
+		return false
+	}
+
+	return PrivateLibraries && m.IsRegistered() && !m.IsSuperAdmin()
+}
+
 // IsVisitor checks if the user is a sharing link visitor.
 func (m *User) IsVisitor() bool {
 	return m.AclRole() == acl.RoleVisitor || m.ID == Visitor.ID
@@ -957,6 +1000,47 @@ func (m *User) SharedUIDs() UIDs {
 	return m.UserShares.UIDs()
 }
 
+// DownloadUIDs returns the shared entity UIDs that may be downloaded.
+func (m *User) DownloadUIDs() UIDs {
+	if !m.IsRegistered() {
+		return UIDs{}
+	} else if m.UserShares.Empty() {
+		m.RefreshShares()
+	}
+
+	result := make(UIDs, 0, len(m.UserShares))
+
+	// Skip shares whose link has reached its download limit.
+	for _, uid := range m.UserShares.DownloadUIDs() {
+		if links := m.ShareLinks(uid); len(links) == 0 || !links[0].DownloadLimitReached() {
+			result = append(result, uid)
+		}
+	}
+
+	return result
+}
+
+// ShareLinks returns the links through which the entity with the specified UID was shared with the user.
+func (m *User) ShareLinks(shareUid string) (links Links) {
+	links = Links{}
+
+	if shareUid == "" || !m.IsRegistered() {
+		return links
+	} else if m.UserShares.Empty() {
+		m.RefreshShares()
+	}
+
+	for _, share := range m.UserShares {
+		if share.ShareUID != shareUid || share.LinkUID == "" {
+			continue
+		} else if link := FindLink(share.LinkUID); link != nil && !link.Elapsed() {
+			links = append(links, *link)
+		}
+	}
+
+	return links
+}
+
 // RedeemToken updates shared entity UIDs using the specified token.
 func (m *User) RedeemToken(token string) (n int) {
 	if !m.IsRegistered() {
@@ -964,7 +1048,7 @@ func (m *User) RedeemToken(token string) (n int) {
 	}
 
 	// Find links.
-	links := FindValidLinks(token, "")
+	links := FindValidLinks(token, "").Viewable()
 
 	// Found?
 	if n = len(links); n == 0 {
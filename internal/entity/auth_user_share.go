@@ -20,8 +20,15 @@ const (
 	PermEdit
 	PermShare
 	PermAll
+	PermDownload
 )
 
+// PermCanDownload checks if the permissions allow downloading shared content,
+// which is the case by default for backward compatibility.
+func PermCanDownload(perm uint) bool {
+	return perm == PermDefault || perm&(PermDownload|PermAll) != 0
+}
+
 // SharePrefix for RefID.
 const (
 	SharePrefix = "share"
@@ -61,6 +68,19 @@ func (m UserShares) Contains(uid string) bool {
 	return false
 }
 
+// DownloadUIDs returns the shared UIDs that may be downloaded.
+func (m UserShares) DownloadUIDs() UIDs {
+	result := make(UIDs, 0, len(m))
+
+	for _, share := range m {
+		if share.CanDownload() {
+			result = append(result, share.ShareUID)
+		}
+	}
+
+	return result
+}
+
 // UserShare represents content shared with a user.
 type UserShare struct {
 	UserUID   string     `gorm:"type:VARBINARY(42);primary_key;auto_increment:false;" json:"-" yaml:"UserUID"`
@@ -132,6 +152,11 @@ func (m *UserShare) HasID() bool {
 	return rnd.IsUID(m.UserUID, UserUID) && rnd.IsUID(m.ShareUID, 0)
 }
 
+// CanDownload checks if the shared content may be downloaded.
+func (m *UserShare) CanDownload() bool {
+	return PermCanDownload(m.Perm)
+}
+
 // Create inserts a new record into the database.
 func (m *UserShare) Create() error {
 	return Db().Create(m).Error
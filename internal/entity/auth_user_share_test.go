@@ -33,6 +33,26 @@ func TestPerm(t *testing.T) {
 	assert.Equal(t, uint(32), PermEdit)
 	assert.Equal(t, uint(64), PermShare)
 	assert.Equal(t, uint(128), PermAll)
+	assert.Equal(t, uint(256), PermDownload)
+}
+
+func TestPermCanDownload(t *testing.T) {
+	assert.True(t, PermCanDownload(PermDefault))
+	assert.True(t, PermCanDownload(PermAll))
+	assert.True(t, PermCanDownload(PermView|PermDownload))
+	assert.False(t, PermCanDownload(PermView))
+	assert.False(t, PermCanDownload(PermNone))
+}
+
+func TestUserShares_DownloadUIDs(t *testing.T) {
+	shares := UserShares{
+		{ShareUID: "at9lxuqxpogaaba8", Perm: PermDefault},
+		{ShareUID: "pt9jtdre2lvl0yh7", Perm: PermView},
+		{ShareUID: "lt9k3pw1wowuy3c3", Perm: PermView | PermDownload},
+	}
+
+	assert.Equal(t, UIDs{"at9lxuqxpogaaba8", "lt9k3pw1wowuy3c3"}, shares.DownloadUIDs())
+	assert.Empty(t, UserShares{}.DownloadUIDs())
 }
 
 func TestFindUserShare(t *testing.T) {
@@ -18,6 +18,15 @@ func TestNewUser(t *testing.T) {
 	assert.True(t, rnd.IsUID(m.UserUID, UserUID))
 }
 
+func TestOidcUser(t *testing.T) {
+	m := OidcUser("Jens.Mander", "f1a2b3c4-0000-4000-8000-000000000001")
+
+	assert.Equal(t, "jens.mander", m.UserName)
+	assert.Equal(t, "f1a2b3c4-0000-4000-8000-000000000001", m.AuthID)
+	assert.Equal(t, authn.ProviderOIDC, m.Provider())
+	assert.True(t, m.Provider().IsRemote())
+}
+
 func TestFindLocalUser(t *testing.T) {
 	t.Run("Admin", func(t *testing.T) {
 		m := FindLocalUser("admin")
@@ -406,6 +415,34 @@ func TestFindUser(t *testing.T) {
 	})
 }
 
+func TestFindUserByToken(t *testing.T) {
+	t.Run("Alice", func(t *testing.T) {
+		alice := FindUserByName("alice")
+
+		if alice == nil {
+			t.Fatal("alice should not be nil")
+		}
+
+		if m := FindUserByToken(alice.PreviewToken); m == nil {
+			t.Fatal("result should not be nil")
+		} else {
+			assert.Equal(t, alice.UserUID, m.UserUID)
+		}
+
+		if m := FindUserByToken(alice.DownloadToken); m == nil {
+			t.Fatal("result should not be nil")
+		} else {
+			assert.Equal(t, alice.UserUID, m.UserUID)
+		}
+	})
+	t.Run("Empty", func(t *testing.T) {
+		assert.Nil(t, FindUserByToken(""))
+	})
+	t.Run("NotFound", func(t *testing.T) {
+		assert.Nil(t, FindUserByToken("xxx"))
+	})
+}
+
 func TestFindUserByUID(t *testing.T) {
 	t.Run("Visitor", func(t *testing.T) {
 		m := FindUserByUID("u000000000000002")
@@ -835,6 +872,16 @@ func TestUser_CanLogIn(t *testing.T) {
 	assert.True(t, alice.CanLogIn())
 
 	assert.False(t, UserFixtures.Pointer("deleted").CanLogIn())
+
+	t.Run("Roles", func(t *testing.T) {
+		for _, role := range []acl.Role{acl.RoleContributor, acl.RoleViewer, acl.RoleGuest} {
+			m := User{ID: 1234567, UserUID: "urqdrfb72479n047", UserName: "test", UserRole: role.String(), CanLogin: true}
+			assert.True(t, m.CanLogIn(), role.String())
+		}
+
+		m := User{ID: 1234567, UserUID: "urqdrfb72479n047", UserName: "test", UserRole: acl.RoleVisitor.String(), CanLogin: true}
+		assert.False(t, m.CanLogIn())
+	})
 }
 
 func TestUser_CanUseWebDAV(t *testing.T) {
@@ -847,6 +894,9 @@ func TestUser_CanUseWebDAV(t *testing.T) {
 
 	assert.False(t, UserFixtures.Pointer("deleted").CanUseWebDAV())
 	assert.False(t, UserFixtures.Pointer("friend").CanUseWebDAV())
+
+	contributor := User{ID: 1234567, UserUID: "urqdrfb72479n047", UserName: "test", UserRole: acl.RoleContributor.String(), WebDAV: true}
+	assert.False(t, contributor.CanUseWebDAV())
 }
 
 func TestUser_CanUpload(t *testing.T) {
@@ -859,6 +909,35 @@ func TestUser_CanUpload(t *testing.T) {
 
 	assert.False(t, UserFixtures.Pointer("deleted").CanUpload())
 	assert.True(t, UserFixtures.Pointer("friend").CanUpload())
+
+	t.Run("Roles", func(t *testing.T) {
+		contributor := User{ID: 1234567, UserUID: "urqdrfb72479n047", UserName: "test", UserRole: acl.RoleContributor.String()}
+		assert.True(t, contributor.CanUpload())
+		viewer := User{ID: 1234567, UserUID: "urqdrfb72479n047", UserName: "test", UserRole: acl.RoleViewer.String()}
+		assert.False(t, viewer.CanUpload())
+		guest := User{ID: 1234567, UserUID: "urqdrfb72479n047", UserName: "test", UserRole: acl.RoleGuest.String()}
+		assert.False(t, guest.CanUpload())
+	})
+}
+
+func TestUser_HasPrivateLibrary(t *testing.T) {
+	t.Run("Disabled", func(t *testing.T) {
+		assert.False(t, UserFixtures.Pointer("bob").HasPrivateLibrary())
+	})
+	t.Run("Enabled", func(t *testing.T) {
+		PrivateLibraries = true
+		defer func() { PrivateLibraries = false }()
+
+		assert.False(t, UserFixtures.Pointer("alice").HasPrivateLibrary())
+		assert.True(t, UserFixtures.Pointer("bob").HasPrivateLibrary())
+		assert.True(t, UserFixtures.Pointer("friend").HasPrivateLibrary())
+		assert.False(t, Visitor.HasPrivateLibrary())
+		assert.False(t, UnknownUser.HasPrivateLibrary())
+	})
+	t.Run("Nil", func(t *testing.T) {
+		var m *User
+		assert.False(t, m.HasPrivateLibrary())
+	})
 }
 
 func TestUser_SharedUIDs(t *testing.T) {
@@ -1021,6 +1100,10 @@ func TestUser_GetBasePath(t *testing.T) {
 	t.Run("Admin", func(t *testing.T) {
 		assert.Equal(t, "", Admin.GetBasePath())
 	})
+	t.Run("Contributor", func(t *testing.T) {
+		m := User{ID: 1234567, UserUID: "urqdrfb72479n047", UserName: "test", UserRole: acl.RoleContributor.String()}
+		assert.Equal(t, "users/test", m.GetBasePath())
+	})
 }
 
 func TestUser_SetBasePath(t *testing.T) {
@@ -0,0 +1,69 @@
+package entity
+
+import (
+	"reflect"
+	"regexp"
+	"strings"
+
+	"github.com/jinzhu/gorm"
+)
+
+// postgresTypes maps MySQL specific column types used in struct tags to their PostgreSQL equivalents.
+var postgresTypes = []struct {
+	pattern *regexp.Regexp
+	replace string
+}{
+	{regexp.MustCompile(`(?i)^VARBINARY\b`), "VARCHAR"},
+	{regexp.MustCompile(`(?i)^(TINY|MEDIUM|LONG)TEXT\b`), "TEXT"},
+	{regexp.MustCompile(`(?i)^(TINY|MEDIUM|LONG)?BLOB\b`), "BYTEA"},
+	{regexp.MustCompile(`(?i)^DATETIME\b`), "TIMESTAMP"},
+	{regexp.MustCompile(`(?i)^FLOAT\b`), "REAL"},
+}
+
+// PostgresType returns the PostgreSQL column type for the specified MySQL column type.
+func PostgresType(sqlType string) string {
+	sqlType = strings.TrimSpace(sqlType)
+
+	for _, t := range postgresTypes {
+		if t.pattern.MatchString(sqlType) {
+			return t.pattern.ReplaceAllString(sqlType, t.replace)
+		}
+	}
+
+	return sqlType
+}
+
+// postgresBase is the default PostgreSQL dialect provided by Gorm.
+var postgresBase gorm.Dialect
+
+// postgresDialect wraps the default Gorm PostgreSQL dialect so that the
+// MySQL column types in the entity struct tags can be used with PostgreSQL.
+type postgresDialect struct {
+	gorm.Dialect
+}
+
+// SetDB creates a new default dialect instance and sets the database connection.
+func (d *postgresDialect) SetDB(db gorm.SQLCommon) {
+	d.Dialect = reflect.New(reflect.TypeOf(postgresBase).Elem()).Interface().(gorm.Dialect)
+	d.Dialect.SetDB(db)
+}
+
+// DataTypeOf returns the PostgreSQL column type for the specified struct field.
+func (d *postgresDialect) DataTypeOf(field *gorm.StructField) string {
+	// Struct fields are cached and shared with other dialects,
+	// so the original type must be restored afterwards.
+	if sqlType, ok := field.TagSettingsGet("TYPE"); ok {
+		field.TagSettingsSet("TYPE", PostgresType(sqlType))
+		defer field.TagSettingsSet("TYPE", sqlType)
+	}
+
+	return d.Dialect.DataTypeOf(field)
+}
+
+// init replaces the default PostgreSQL dialect with a compatible wrapper.
+func init() {
+	if base, ok := gorm.GetDialect(Postgres); ok {
+		postgresBase = base
+		gorm.RegisterDialect(Postgres, &postgresDialect{})
+	}
+}
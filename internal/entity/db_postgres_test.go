@@ -0,0 +1,39 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/jinzhu/gorm"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPostgresType(t *testing.T) {
+	t.Run("VarBinary", func(t *testing.T) {
+		assert.Equal(t, "VARCHAR(42)", PostgresType("VARBINARY(42)"))
+		assert.Equal(t, "VARCHAR(42)", PostgresType("varbinary(42)"))
+	})
+	t.Run("Text", func(t *testing.T) {
+		assert.Equal(t, "TEXT", PostgresType("LONGTEXT"))
+		assert.Equal(t, "TEXT", PostgresType("MEDIUMTEXT"))
+		assert.Equal(t, "TEXT", PostgresType("TEXT"))
+		assert.Equal(t, "VARCHAR(255)", PostgresType("VARCHAR(255)"))
+	})
+	t.Run("Blob", func(t *testing.T) {
+		assert.Equal(t, "BYTEA", PostgresType("MEDIUMBLOB"))
+		assert.Equal(t, "BYTEA", PostgresType("BLOB"))
+	})
+	t.Run("DateTime", func(t *testing.T) {
+		assert.Equal(t, "TIMESTAMP", PostgresType("DATETIME"))
+	})
+	t.Run("Numbers", func(t *testing.T) {
+		assert.Equal(t, "REAL", PostgresType("FLOAT"))
+		assert.Equal(t, "SMALLINT", PostgresType("SMALLINT"))
+	})
+}
+
+func TestPostgresDialect(t *testing.T) {
+	dialect, ok := gorm.GetDialect(Postgres)
+
+	assert.True(t, ok)
+	assert.IsType(t, &postgresDialect{}, dialect)
+}
@@ -29,7 +29,7 @@ func LabelCounts() LabelPhotoCounts {
 		JOIN photos ph ON pl.photo_id = ph.id
 		WHERE pl.uncertainty < 100
 		AND ph.photo_quality > -1
-		AND ph.photo_private = 0
+		AND ph.photo_private = ?
 		AND ph.deleted_at IS NULL GROUP BY l.id
 		UNION ALL
 		SELECT l.id AS label_id, COUNT(*) AS photo_count FROM labels l
@@ -38,9 +38,9 @@ func LabelCounts() LabelPhotoCounts {
 		JOIN photos ph ON pl.photo_id = ph.id
 		WHERE pl.uncertainty < 100
 		AND ph.photo_quality > -1
-		AND ph.photo_private = 0
+		AND ph.photo_private = ?
 		AND ph.deleted_at IS NULL GROUP BY l.id) counts GROUP BY label_id
-		`).Scan(&result).Error; err != nil {
+		`, false, false).Scan(&result).Error; err != nil {
 		log.Errorf("label-count: %s", err.Error())
 	}
 
@@ -59,8 +59,8 @@ func UpdatePlacesCounts() (err error) {
 		UpdateColumn("photo_count", gorm.Expr("(SELECT COUNT(*) FROM photos p "+
 			"WHERE places.id = p.place_id "+
 			"AND p.photo_quality > -1 "+
-			"AND p.photo_private = 0 "+
-			"AND p.deleted_at IS NULL)"))
+			"AND p.photo_private = ? "+
+			"AND p.deleted_at IS NULL)", false))
 
 	if res.Error != nil {
 		return res.Error
@@ -91,17 +91,17 @@ func UpdateSubjectCounts() (err error) {
 		res = Db().Exec(`UPDATE ? LEFT JOIN (
 		SELECT m.subj_uid, COUNT(DISTINCT f.id) AS subj_files, COUNT(DISTINCT f.photo_id) AS subj_photos FROM ? f
 			JOIN ? m ON f.file_uid = m.file_uid AND m.subj_uid IS NOT NULL AND m.subj_uid <> '' AND m.subj_uid IS NOT NULL
-			WHERE m.marker_invalid = 0 AND f.deleted_at IS NULL GROUP BY m.subj_uid
+			WHERE m.marker_invalid = ? AND f.deleted_at IS NULL GROUP BY m.subj_uid
 		) b ON b.subj_uid = subjects.subj_uid
 		SET subjects.file_count = CASE WHEN b.subj_files IS NULL THEN 0 ELSE b.subj_files END, 
 			subjects.photo_count = CASE WHEN b.subj_photos IS NULL THEN 0 ELSE b.subj_photos END
-		WHERE ?`, gorm.Expr(subjTable), gorm.Expr(filesTable), gorm.Expr(markerTable), condition)
-	case SQLite3:
+		WHERE ?`, gorm.Expr(subjTable), gorm.Expr(filesTable), gorm.Expr(markerTable), false, condition)
+	case SQLite3, Postgres:
 		// Update files count.
 		res = Db().Table(subjTable).
 			UpdateColumn("file_count", gorm.Expr("(SELECT COUNT(DISTINCT f.id) FROM files f "+
 				fmt.Sprintf("JOIN %s m ON f.file_uid = m.file_uid AND m.subj_uid = %s.subj_uid ",
-					markerTable, subjTable)+" WHERE m.marker_invalid = 0 AND f.deleted_at IS NULL) WHERE ?", condition))
+					markerTable, subjTable)+" WHERE m.marker_invalid = ? AND f.deleted_at IS NULL) WHERE ?", false, condition))
 
 		// Update photo count.
 		if res.Error != nil {
@@ -110,7 +110,7 @@ func UpdateSubjectCounts() (err error) {
 			photosRes := Db().Table(subjTable).
 				UpdateColumn("photo_count", gorm.Expr("(SELECT COUNT(DISTINCT f.photo_id) FROM files f "+
 					fmt.Sprintf("JOIN %s m ON f.file_uid = m.file_uid AND m.subj_uid = %s.subj_uid ",
-						markerTable, subjTable)+" WHERE m.marker_invalid = 0 AND f.deleted_at IS NULL) WHERE ?", condition))
+						markerTable, subjTable)+" WHERE m.marker_invalid = ? AND f.deleted_at IS NULL) WHERE ?", false, condition))
 			res.RowsAffected += photosRes.RowsAffected
 		}
 	default:
@@ -138,16 +138,16 @@ func UpdateLabelCounts() (err error) {
 		SELECT p2.label_id, COUNT(DISTINCT photo_id) AS label_photos FROM (
 			SELECT pl.label_id as label_id, p.id AS photo_id FROM photos p
 				JOIN photos_labels pl ON pl.photo_id = p.id AND pl.uncertainty < 100
-			WHERE p.photo_quality > -1 AND p.photo_private = 0 AND p.deleted_at IS NULL
+			WHERE p.photo_quality > -1 AND p.photo_private = ? AND p.deleted_at IS NULL
 			UNION
 			SELECT c.category_id as label_id, p.id AS photo_id FROM photos p
 				JOIN photos_labels pl ON pl.photo_id = p.id AND pl.uncertainty < 100
 				JOIN categories c ON c.label_id = pl.label_id
-			WHERE p.photo_quality > -1 AND p.photo_private = 0 AND p.deleted_at IS NULL
+			WHERE p.photo_quality > -1 AND p.photo_private = ? AND p.deleted_at IS NULL
 			) p2 GROUP BY p2.label_id
 		) b ON b.label_id = labels.id
-		SET photo_count = CASE WHEN b.label_photos IS NULL THEN 0 ELSE b.label_photos END`)
-	} else if IsDialect(SQLite3) {
+		SET photo_count = CASE WHEN b.label_photos IS NULL THEN 0 ELSE b.label_photos END`, false, false)
+	} else if IsDialect(SQLite3) || IsDialect(Postgres) {
 		res = Db().
 			Table("labels").
 			UpdateColumn("photo_count",
@@ -157,7 +157,7 @@ func UpdateLabelCounts() (err error) {
 					JOIN photos ph ON pl.photo_id = ph.id
 					WHERE pl.uncertainty < 100
 					AND ph.photo_quality > -1
-					AND ph.photo_private = 0
+					AND ph.photo_private = ?
 					AND ph.deleted_at IS NULL GROUP BY l.id
 					UNION ALL
 					SELECT l.id AS label_id, COUNT(*) AS photo_count FROM labels l
@@ -166,8 +166,8 @@ func UpdateLabelCounts() (err error) {
 					JOIN photos ph ON pl.photo_id = ph.id
 					WHERE pl.uncertainty < 100
 					AND ph.photo_quality > -1
-					AND ph.photo_private = 0
-					AND ph.deleted_at IS NULL GROUP BY l.id) counts GROUP BY label_id) label_counts WHERE label_id = labels.id)`))
+					AND ph.photo_private = ?
+					AND ph.deleted_at IS NULL GROUP BY l.id) counts GROUP BY label_id) label_counts WHERE label_id = labels.id)`, false, false))
 	} else {
 		return fmt.Errorf("sql: unsupported dialect %s", DbDialect())
 	}
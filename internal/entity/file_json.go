@@ -42,6 +42,7 @@ func (m *File) MarshalJSON() ([]byte, error) {
 		Colors         string        `json:",omitempty"`
 		Luminance      string        `json:",omitempty"`
 		Diff           int           `json:",omitempty"`
+		PHash          string        `json:",omitempty"`
 		Chroma         int16         `json:",omitempty"`
 		HDR            bool          `json:",omitempty"`
 		Watermark      bool          `json:",omitempty"`
@@ -89,6 +90,7 @@ func (m *File) MarshalJSON() ([]byte, error) {
 		Colors:         m.FileColors,
 		Luminance:      m.FileLuminance,
 		Diff:           m.FileDiff,
+		PHash:          m.FilePHash,
 		Chroma:         m.FileChroma,
 		HDR:            m.FileHDR,
 		Watermark:      m.FileWatermark,
@@ -10,22 +10,38 @@ const (
 	FileSyncExists     = "exists"
 	FileSyncDownloaded = "downloaded"
 	FileSyncUploaded   = "uploaded"
+	FileSyncConflict   = "conflict"
+)
+
+// Options for resolving sync conflicts.
+const (
+	KeepBoth   = "both"
+	KeepLocal  = "local"
+	KeepRemote = "remote"
 )
 
 // FileSync represents a one-to-many relation between File and Account for syncing with remote services.
+//
+// Field Descriptions:
+//   - RemoteDate and RemoteSize describe the remote file at the time of the last sync.
+//   - LocalHash holds the SHA1 hash of the local file at the time of the last sync, if known.
+//   - ConflictName holds the base name of the conflicting copy if the file was modified on both sides,
+//     the copy is stored next to the original, either locally or on the remote service.
 type FileSync struct {
-	RemoteName string `gorm:"primary_key;auto_increment:false;type:VARBINARY(255)"`
-	ServiceID  uint   `gorm:"primary_key;auto_increment:false"`
-	FileID     uint   `gorm:"index;"`
-	RemoteDate time.Time
-	RemoteSize int64
-	Status     string `gorm:"type:VARBINARY(16);"`
-	Error      string `gorm:"type:VARBINARY(512);"`
-	Errors     int
-	File       *File
-	Account    *Service
-	CreatedAt  time.Time
-	UpdatedAt  time.Time
+	RemoteName   string `gorm:"primary_key;auto_increment:false;type:VARBINARY(255)"`
+	ServiceID    uint   `gorm:"primary_key;auto_increment:false"`
+	FileID       uint   `gorm:"index;"`
+	RemoteDate   time.Time
+	RemoteSize   int64
+	LocalHash    string `gorm:"type:VARBINARY(128);"`
+	Status       string `gorm:"type:VARBINARY(16);"`
+	ConflictName string `gorm:"type:VARBINARY(255);"`
+	Error        string `gorm:"type:VARBINARY(512);"`
+	Errors       int
+	File         *File
+	Account      *Service
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
 }
 
 // TableName returns the entity table name.
@@ -44,6 +60,21 @@ func NewFileSync(accountID uint, remoteName string) *FileSync {
 	return result
 }
 
+// RemoteModified checks if the remote file has changed since the last sync, based on its size and modification time.
+func (m *FileSync) RemoteModified(date time.Time, size int64) bool {
+	return m.RemoteSize != size || !m.RemoteDate.UTC().Truncate(time.Second).Equal(date.UTC().Truncate(time.Second))
+}
+
+// LocalModified checks if the local file has changed since the last sync, based on its hash.
+func (m *FileSync) LocalModified(hash string) bool {
+	return m.LocalHash != "" && hash != "" && m.LocalHash != hash
+}
+
+// Conflicting checks if the file was modified on both sides and the conflict has not been resolved yet.
+func (m *FileSync) Conflicting() bool {
+	return m.Status == FileSyncConflict
+}
+
 // Updates multiple columns in the database.
 func (m *FileSync) Updates(values interface{}) error {
 	return UnscopedDb().Model(m).UpdateColumns(values).Error
@@ -2,6 +2,7 @@ package entity
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -104,3 +105,31 @@ func TestFileSync_Save(t *testing.T) {
 		assert.True(t, afterDate.After(initialDate))
 	})
 }
+
+func TestFileSync_RemoteModified(t *testing.T) {
+	date := time.Date(2023, 1, 2, 15, 4, 5, 0, time.UTC)
+	m := FileSync{RemoteDate: date, RemoteSize: 100}
+
+	assert.False(t, m.RemoteModified(date, 100))
+	assert.False(t, m.RemoteModified(date.Add(500*time.Millisecond), 100))
+	assert.False(t, m.RemoteModified(date.In(time.FixedZone("CET", 3600)), 100))
+	assert.True(t, m.RemoteModified(date, 101))
+	assert.True(t, m.RemoteModified(date.Add(time.Second), 100))
+}
+
+func TestFileSync_LocalModified(t *testing.T) {
+	m := FileSync{}
+
+	assert.False(t, m.LocalModified("abc"))
+
+	m.LocalHash = "abc"
+
+	assert.False(t, m.LocalModified("abc"))
+	assert.False(t, m.LocalModified(""))
+	assert.True(t, m.LocalModified("def"))
+}
+
+func TestFileSync_Conflicting(t *testing.T) {
+	assert.False(t, NewFileSync(123, "test").Conflicting())
+	assert.True(t, (&FileSync{Status: FileSyncConflict}).Conflicting())
+}
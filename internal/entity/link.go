@@ -22,20 +22,26 @@ type Links []Link
 
 // Link represents a link to share content.
 type Link struct {
-	LinkUID     string    `gorm:"type:VARBINARY(42);primary_key;" json:"UID,omitempty" yaml:"UID,omitempty"`
-	ShareUID    string    `gorm:"type:VARBINARY(42);unique_index:idx_links_uid_token;" json:"ShareUID" yaml:"ShareUID"`
-	ShareSlug   string    `gorm:"type:VARBINARY(160);index;" json:"Slug" yaml:"Slug,omitempty"`
-	LinkToken   string    `gorm:"type:VARBINARY(160);unique_index:idx_links_uid_token;" json:"Token" yaml:"Token,omitempty"`
-	LinkExpires int       `json:"Expires" yaml:"Expires,omitempty"`
-	LinkViews   uint      `json:"Views" yaml:"-"`
-	MaxViews    uint      `json:"MaxViews" yaml:"-"`
-	HasPassword bool      `json:"HasPassword" yaml:"HasPassword,omitempty"`
-	Comment     string    `gorm:"size:512;" json:"Comment,omitempty" yaml:"Comment,omitempty"`
-	Perm        uint      `json:"Perm,omitempty" yaml:"Perm,omitempty"`
-	RefID       string    `gorm:"type:VARBINARY(16);" json:"-" yaml:"-"`
-	CreatedBy   string    `gorm:"type:VARBINARY(42);index" json:"CreatedBy,omitempty" yaml:"CreatedBy,omitempty"`
-	CreatedAt   time.Time `deepcopier:"skip" json:"CreatedAt" yaml:"CreatedAt"`
-	ModifiedAt  time.Time `deepcopier:"skip" json:"ModifiedAt" yaml:"ModifiedAt"`
+	LinkUID       string    `gorm:"type:VARBINARY(42);primary_key;" json:"UID,omitempty" yaml:"UID,omitempty"`
+	ShareUID      string    `gorm:"type:VARBINARY(42);unique_index:idx_links_uid_token;" json:"ShareUID" yaml:"ShareUID"`
+	ShareSlug     string    `gorm:"type:VARBINARY(160);index;" json:"Slug" yaml:"Slug,omitempty"`
+	LinkToken     string    `gorm:"type:VARBINARY(160);unique_index:idx_links_uid_token;" json:"Token" yaml:"Token,omitempty"`
+	LinkExpires   int       `json:"Expires" yaml:"Expires,omitempty"`
+	LinkViews     uint      `json:"Views" yaml:"-"`
+	MaxViews      uint      `json:"MaxViews" yaml:"-"`
+	LinkDownloads uint      `json:"Downloads" yaml:"-"`
+	MaxDownloads  uint      `json:"MaxDownloads" yaml:"-"`
+	LinkUploads   uint      `json:"Uploads" yaml:"-"`
+	MaxUploads    uint      `json:"MaxUploads" yaml:"-"`
+	UploadSize    int64     `json:"UploadSize" yaml:"-"`
+	MaxUploadSize int64     `json:"MaxUploadSize" yaml:"-"`
+	HasPassword   bool      `json:"HasPassword" yaml:"HasPassword,omitempty"`
+	Comment       string    `gorm:"size:512;" json:"Comment,omitempty" yaml:"Comment,omitempty"`
+	Perm          uint      `json:"Perm,omitempty" yaml:"Perm,omitempty"`
+	RefID         string    `gorm:"type:VARBINARY(16);" json:"-" yaml:"-"`
+	CreatedBy     string    `gorm:"type:VARBINARY(42);index" json:"CreatedBy,omitempty" yaml:"CreatedBy,omitempty"`
+	CreatedAt     time.Time `deepcopier:"skip" json:"CreatedAt" yaml:"CreatedAt"`
+	ModifiedAt    time.Time `deepcopier:"skip" json:"ModifiedAt" yaml:"ModifiedAt"`
 }
 
 // TableName returns the entity table name.
@@ -89,6 +95,76 @@ func (m *Link) Redeem() *Link {
 	return m
 }
 
+// Download increases the number of zip downloads by one.
+func (m *Link) Download() *Link {
+	m.LinkDownloads += 1
+
+	if err := Db().Model(m).UpdateColumn("link_downloads", gorm.Expr("link_downloads + 1")).Error; err != nil {
+		event.AuditWarn([]string{"link %s", "failed to update download counter"}, clean.Log(m.RefID), err)
+	}
+
+	return m
+}
+
+// Upload increases the number of uploaded files and their total size.
+func (m *Link) Upload(files uint, size int64) *Link {
+	m.LinkUploads += files
+	m.UploadSize += size
+
+	if err := Db().Model(m).UpdateColumns(map[string]interface{}{
+		"link_uploads": gorm.Expr("link_uploads + ?", files),
+		"upload_size":  gorm.Expr("upload_size + ?", size),
+	}).Error; err != nil {
+		event.AuditWarn([]string{"link %s", "failed to update upload counter"}, clean.Log(m.RefID), err)
+	}
+
+	return m
+}
+
+// ReserveUpload atomically increases the number of uploaded files and their total size, unless this
+// would exceed the upload limits of the link, so that concurrent uploads cannot exceed them either.
+func (m *Link) ReserveUpload(files uint, size int64) bool {
+	result := Db().Model(&Link{}).
+		Where("link_uid = ?", m.LinkUID).
+		Where("max_uploads = 0 OR link_uploads + ? <= max_uploads", files).
+		Where("max_upload_size = 0 OR upload_size + ? <= max_upload_size", size).
+		UpdateColumns(map[string]interface{}{
+			"link_uploads": gorm.Expr("link_uploads + ?", files),
+			"upload_size":  gorm.Expr("upload_size + ?", size),
+		})
+
+	if result.Error != nil {
+		event.AuditWarn([]string{"link %s", "failed to reserve upload quota"}, clean.Log(m.RefID), result.Error)
+		return false
+	} else if result.RowsAffected < 1 {
+		return false
+	}
+
+	m.LinkUploads += files
+	m.UploadSize += size
+
+	return true
+}
+
+// ReleaseUpload returns upload quota that was reserved for files that could not be uploaded.
+func (m *Link) ReleaseUpload(files uint, size int64) *Link {
+	if m.LinkUploads < files || m.UploadSize < size {
+		return m
+	}
+
+	m.LinkUploads -= files
+	m.UploadSize -= size
+
+	if err := Db().Model(m).UpdateColumns(map[string]interface{}{
+		"link_uploads": gorm.Expr("link_uploads - ?", files),
+		"upload_size":  gorm.Expr("upload_size - ?", size),
+	}).Error; err != nil {
+		event.AuditWarn([]string{"link %s", "failed to update upload counter"}, clean.Log(m.RefID), err)
+	}
+
+	return m
+}
+
 // ExpiresAt returns the time when the share link expires or nil if it never expires.
 func (m *Link) ExpiresAt() *time.Time {
 	if m.LinkExpires <= 0 {
@@ -107,6 +183,11 @@ func (m *Link) Expired() bool {
 		return true
 	}
 
+	return m.Elapsed()
+}
+
+// Elapsed checks if the lifetime of the share link has elapsed, regardless of the number of views.
+func (m *Link) Elapsed() bool {
 	if expires := m.ExpiresAt(); expires == nil {
 		return false
 	} else {
@@ -114,6 +195,54 @@ func (m *Link) Expired() bool {
 	}
 }
 
+// DownloadLimitReached checks if the maximum number of zip downloads has been reached.
+func (m *Link) DownloadLimitReached() bool {
+	return m.MaxDownloads > 0 && m.LinkDownloads >= m.MaxDownloads
+}
+
+// CanDownload checks if the shared content may be downloaded.
+func (m *Link) CanDownload() bool {
+	return PermCanDownload(m.Perm) && !m.DownloadLimitReached()
+}
+
+// CanUpload checks if files may be uploaded to the shared album.
+func (m *Link) CanUpload() bool {
+	return m.Perm&PermUpload != 0 && m.ShareType() == "album"
+}
+
+// UploadOnly checks if the link only permits uploading files, without access to the shared content.
+func (m *Link) UploadOnly() bool {
+	return m.Perm == PermUpload
+}
+
+// UploadLimitReached checks if uploading the specified number of files with the specified total size would
+// exceed the upload limits of the link.
+func (m *Link) UploadLimitReached(files uint, size int64) bool {
+	if m.MaxUploads > 0 && m.LinkUploads+files > m.MaxUploads {
+		return true
+	}
+
+	return m.MaxUploadSize > 0 && m.UploadSize+size > m.MaxUploadSize
+}
+
+// ShareType returns the type of the shared entity, e.g. "album", "photo", or "label".
+func (m *Link) ShareType() string {
+	if m.ShareUID == "" {
+		return ""
+	}
+
+	switch m.ShareUID[0] {
+	case AlbumUID:
+		return "album"
+	case PhotoUID:
+		return "photo"
+	case LabelUID:
+		return "label"
+	default:
+		return ""
+	}
+}
+
 // SetSlug sets the URL slug of the link.
 func (m *Link) SetSlug(s string) {
 	m.ShareSlug = txt.Slug(s)
@@ -147,6 +276,56 @@ func (m *Link) InvalidPassword(password string) bool {
 	return pw.IsWrong(password)
 }
 
+// UploadOnly checks if all links only permit uploading files.
+func (m Links) UploadOnly() bool {
+	if len(m) == 0 {
+		return false
+	}
+
+	for i := range m {
+		if !m[i].UploadOnly() {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Viewable returns the links that grant access to the shared content.
+func (m Links) Viewable() (found Links) {
+	found = Links{}
+
+	for i := range m {
+		if !m[i].UploadOnly() {
+			found = append(found, m[i])
+		}
+	}
+
+	return found
+}
+
+// HasPassword checks if any of the links is password-protected.
+func (m Links) HasPassword() bool {
+	for i := range m {
+		if m[i].HasPassword {
+			return true
+		}
+	}
+
+	return false
+}
+
+// InvalidPassword checks if any of the links requires a password that does not match the password provided.
+func (m Links) InvalidPassword(password string) bool {
+	for i := range m {
+		if m[i].InvalidPassword(password) {
+			return true
+		}
+	}
+
+	return false
+}
+
 // Save updates the record in the database or inserts a new record if it does not already exist.
 func (m *Link) Save() error {
 	if !rnd.IsUID(m.ShareUID, 0) {
@@ -238,6 +417,23 @@ func FindLinks(token, shared string) (found Links) {
 	return found
 }
 
+// FindActiveLinks returns a slice of links whose lifetime has not elapsed for a token and share UID (at least one
+// must be provided). Unlike FindValidLinks, it includes links that have reached the maximum number of views, so
+// that visitors who already redeemed the token keep their access.
+func FindActiveLinks(token, shared string) (found Links) {
+	found = Links{}
+
+	for _, link := range FindLinks(token, shared) {
+		if link.Elapsed() {
+			continue
+		}
+
+		found = append(found, link)
+	}
+
+	return found
+}
+
 // FindValidLinks returns a slice of non-expired links for a token and share UID (at least one must be provided).
 func FindValidLinks(token, shared string) (found Links) {
 	found = Links{}
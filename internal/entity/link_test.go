@@ -43,6 +43,242 @@ func TestLink_Expired(t *testing.T) {
 	assert.True(t, link.Expired())
 }
 
+func TestLink_Elapsed(t *testing.T) {
+	const oneDay = 60 * 60 * 24
+
+	link := NewLink("st9lxuqxpogaaba1", true, false)
+
+	link.ModifiedAt = TimeStamp().Add(-7 * Day)
+	link.LinkExpires = 0
+
+	assert.False(t, link.Elapsed())
+
+	link.LinkExpires = oneDay
+
+	assert.True(t, link.Elapsed())
+
+	link.LinkExpires = oneDay * 8
+	link.LinkViews = 10
+	link.MaxViews = 10
+
+	assert.True(t, link.Expired())
+	assert.False(t, link.Elapsed())
+}
+
+func TestLink_CanDownload(t *testing.T) {
+	link := NewLink("pt9jtdre2lvl0yh7", false, false)
+
+	assert.True(t, link.CanDownload())
+
+	link.Perm = PermView
+
+	assert.False(t, link.CanDownload())
+
+	link.Perm = PermView | PermDownload
+
+	assert.True(t, link.CanDownload())
+
+	link.Perm = PermAll
+
+	assert.True(t, link.CanDownload())
+}
+
+func TestLink_DownloadLimitReached(t *testing.T) {
+	link := NewLink("pt9jtdre2lvl0yh7", false, false)
+
+	assert.False(t, link.DownloadLimitReached())
+	assert.True(t, link.CanDownload())
+
+	link.MaxDownloads = 2
+	link.LinkDownloads = 1
+
+	assert.False(t, link.DownloadLimitReached())
+	assert.True(t, link.CanDownload())
+
+	link.LinkDownloads = 2
+
+	assert.True(t, link.DownloadLimitReached())
+	assert.False(t, link.CanDownload())
+}
+
+func TestLink_Download(t *testing.T) {
+	link := NewLink("pt9jtdre2lvl0yh7", false, false)
+	link.MaxDownloads = 1
+
+	if err := link.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	defer link.Delete()
+
+	link.Download()
+
+	assert.Equal(t, uint(1), link.LinkDownloads)
+
+	found := FindLink(link.LinkUID)
+
+	if found == nil {
+		t.Fatal("link not found")
+	}
+
+	assert.Equal(t, uint(1), found.LinkDownloads)
+	assert.True(t, found.DownloadLimitReached())
+}
+
+func TestLinks_Password(t *testing.T) {
+	link := NewLink("pt9jtdre2lvl0yh7", false, false)
+
+	if err := link.SetPassword("secret"); err != nil {
+		t.Fatal(err)
+	}
+
+	links := Links{NewLink("at9lxuqxpogaaba8", false, false), link}
+
+	assert.True(t, links.HasPassword())
+	assert.True(t, links.InvalidPassword(""))
+	assert.True(t, links.InvalidPassword("wrong"))
+	assert.False(t, links.InvalidPassword("secret"))
+	assert.False(t, links[:1].HasPassword())
+	assert.False(t, links[:1].InvalidPassword(""))
+}
+
+func TestLink_CanUpload(t *testing.T) {
+	link := NewLink("at9lxuqxpogaaba8", false, false)
+
+	assert.False(t, link.CanUpload())
+	assert.False(t, link.UploadOnly())
+
+	link.Perm = PermUpload
+
+	assert.True(t, link.CanUpload())
+	assert.True(t, link.UploadOnly())
+
+	link.Perm = PermView | PermUpload
+
+	assert.True(t, link.CanUpload())
+	assert.False(t, link.UploadOnly())
+
+	photo := NewLink("pt9jtdre2lvl0yh7", false, false)
+	photo.Perm = PermUpload
+
+	assert.False(t, photo.CanUpload())
+}
+
+func TestLink_UploadLimitReached(t *testing.T) {
+	link := NewLink("at9lxuqxpogaaba8", false, false)
+
+	assert.False(t, link.UploadLimitReached(100, 1000000))
+
+	link.MaxUploads = 10
+	link.LinkUploads = 8
+
+	assert.False(t, link.UploadLimitReached(2, 0))
+	assert.True(t, link.UploadLimitReached(3, 0))
+
+	link.MaxUploadSize = 1000
+	link.UploadSize = 500
+
+	assert.False(t, link.UploadLimitReached(1, 500))
+	assert.True(t, link.UploadLimitReached(1, 501))
+}
+
+func TestLink_Upload(t *testing.T) {
+	link := NewLink("at9lxuqxpogaaba9", false, false)
+	link.Perm = PermUpload
+
+	if err := link.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	defer link.Delete()
+
+	link.Upload(2, 2048)
+
+	assert.Equal(t, uint(2), link.LinkUploads)
+	assert.Equal(t, int64(2048), link.UploadSize)
+
+	found := FindLink(link.LinkUID)
+
+	if found == nil {
+		t.Fatal("link not found")
+	}
+
+	assert.Equal(t, uint(2), found.LinkUploads)
+	assert.Equal(t, int64(2048), found.UploadSize)
+}
+
+func TestLink_ReserveUpload(t *testing.T) {
+	link := NewLink("at9lxuqxpogaaba9", false, false)
+	link.Perm = PermUpload
+	link.MaxUploads = 3
+	link.MaxUploadSize = 4096
+
+	if err := link.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	defer link.Delete()
+
+	t.Run("Reserve", func(t *testing.T) {
+		assert.True(t, link.ReserveUpload(2, 2048))
+		assert.Equal(t, uint(2), link.LinkUploads)
+		assert.Equal(t, int64(2048), link.UploadSize)
+	})
+	t.Run("TooManyFiles", func(t *testing.T) {
+		assert.False(t, link.ReserveUpload(2, 1024))
+		assert.Equal(t, uint(2), link.LinkUploads)
+	})
+	t.Run("TooLarge", func(t *testing.T) {
+		assert.False(t, link.ReserveUpload(1, 4096))
+		assert.Equal(t, int64(2048), link.UploadSize)
+	})
+	t.Run("Stale", func(t *testing.T) {
+		// Another request with an outdated copy of the link must not exceed the limits either.
+		stale := link
+		stale.LinkUploads = 0
+		stale.UploadSize = 0
+
+		assert.False(t, stale.ReserveUpload(2, 1024))
+	})
+	t.Run("Release", func(t *testing.T) {
+		link.ReleaseUpload(2, 2048)
+
+		found := FindLink(link.LinkUID)
+
+		if found == nil {
+			t.Fatal("link not found")
+		}
+
+		assert.Equal(t, uint(0), found.LinkUploads)
+		assert.Equal(t, int64(0), found.UploadSize)
+		assert.True(t, link.ReserveUpload(3, 4096))
+	})
+}
+
+func TestLinks_UploadOnly(t *testing.T) {
+	upload := NewLink("at9lxuqxpogaaba9", false, false)
+	upload.Perm = PermUpload
+	view := NewLink("at9lxuqxpogaaba8", false, false)
+
+	assert.True(t, Links{upload}.UploadOnly())
+	assert.False(t, Links{upload, view}.UploadOnly())
+	assert.False(t, Links{}.UploadOnly())
+	assert.Equal(t, Links{view}, Links{upload, view}.Viewable())
+}
+
+func TestLink_ShareType(t *testing.T) {
+	for uid, expected := range map[string]string{
+		"at9lxuqxpogaaba8": "album",
+		"pt9jtdre2lvl0yh7": "photo",
+		"lt9k3pw1wowuy3c3": "label",
+		"st9lxuqxpogaaba1": "",
+		"":                 "",
+	} {
+		link := NewLink(uid, false, false)
+		assert.Equal(t, expected, link.ShareType())
+	}
+}
+
 func TestLink_Redeem(t *testing.T) {
 	link := NewLink(rnd.GenerateUID(AlbumUID), false, false)
 
@@ -186,6 +422,30 @@ func TestFindValidLinksLinks(t *testing.T) {
 	})
 }
 
+func TestFindActiveLinks(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		r := FindActiveLinks("1jxf3jfn2k", "")
+		assert.Equal(t, "at9lxuqxpogaaba8", r[0].ShareUID)
+	})
+	t.Run("MaxViews", func(t *testing.T) {
+		link := NewLink("pt9jtdre2lvl0yh7", false, false)
+		link.LinkViews = 1
+		link.MaxViews = 1
+
+		if err := link.Save(); err != nil {
+			t.Fatal(err)
+		}
+
+		defer link.Delete()
+
+		assert.Empty(t, FindValidLinks(link.LinkToken, ""))
+		assert.Len(t, FindActiveLinks(link.LinkToken, ""), 1)
+	})
+	t.Run("NotFound", func(t *testing.T) {
+		assert.Empty(t, FindActiveLinks("lkjh", ""))
+	})
+}
+
 func TestLink_String(t *testing.T) {
 	t.Run("success", func(t *testing.T) {
 		link := NewLink("jhgko", false, false)
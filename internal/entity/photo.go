@@ -601,7 +601,9 @@ func (m *Photo) AddLabels(labels classify.Labels) {
 			log.Errorf("index: failed updating label %s (%s)", clean.Log(classifyLabel.Title()), err)
 		}
 
-		photoLabel := FirstOrCreatePhotoLabel(NewPhotoLabel(m.ID, labelEntity.ID, classifyLabel.Uncertainty, classifyLabel.Source))
+		photoLabel := NewPhotoLabel(m.ID, labelEntity.ID, classifyLabel.Uncertainty, classifyLabel.Source)
+		photoLabel.LabelModel = classifyLabel.Model
+		photoLabel = FirstOrCreatePhotoLabel(photoLabel)
 
 		if photoLabel == nil {
 			log.Errorf("index: photo-label %d should not be nil - possible bug (%s)", labelEntity.ID, m)
@@ -612,6 +614,7 @@ func (m *Photo) AddLabels(labels classify.Labels) {
 			if err := photoLabel.Updates(map[string]interface{}{
 				"Uncertainty": classifyLabel.Uncertainty,
 				"LabelSrc":    classifyLabel.Source,
+				"LabelModel":  classifyLabel.Model,
 			}); err != nil {
 				log.Errorf("index: %s", err)
 			}
@@ -706,7 +709,7 @@ func (m *Photo) AllFilesMissing() bool {
 	count := 0
 
 	if err := Db().Model(&File{}).
-		Where("photo_id = ? AND file_missing = 0", m.ID).
+		Where("photo_id = ? AND file_missing = ?", m.ID, false).
 		Count(&count).Error; err != nil {
 		log.Error(err)
 	}
@@ -893,6 +896,14 @@ func (m *Photo) Approve() error {
 		return err
 	}
 
+	// Show photos uploaded by visitors in the albums they were uploaded to.
+	if m.UploadedByVisitor() {
+		if err := UnscopedDb().Model(&PhotoAlbum{}).Where("photo_uid = ? AND missing = ?", m.PhotoUID, false).
+			UpdateColumn("hidden", false).Error; err != nil {
+			log.Warnf("photo: %s (show %s in albums)", err, m.String())
+		}
+	}
+
 	// Update precalculated photo and file counts.
 	if err := UpdateCounts(); err != nil {
 		log.Warnf("index: %s (update counts)", err)
@@ -926,7 +937,7 @@ func (m *Photo) SetPrimary(fileUid string) (err error) {
 	if fileUid != "" {
 		// Do nothing.
 	} else if err = Db().Model(File{}).
-		Where("photo_uid = ? AND file_type IN (?) AND file_missing = 0 AND file_error = ''", m.PhotoUID, media.PreviewExpr).
+		Where("photo_uid = ? AND file_type IN (?) AND file_missing = ? AND file_error = ''", m.PhotoUID, media.PreviewExpr, false).
 		Order("file_width DESC, file_hdr DESC").Limit(1).
 		Pluck("file_uid", &files).Error; err != nil {
 		return err
@@ -942,10 +953,10 @@ func (m *Photo) SetPrimary(fileUid string) (err error) {
 
 	if err = Db().Model(File{}).
 		Where("photo_uid = ? AND file_uid <> ?", m.PhotoUID, fileUid).
-		UpdateColumn("file_primary", 0).Error; err != nil {
+		UpdateColumn("file_primary", false).Error; err != nil {
 		return err
 	} else if err = Db().Model(File{}).Where("photo_uid = ? AND file_uid = ?", m.PhotoUID, fileUid).
-		UpdateColumn("file_primary", 1).Error; err != nil {
+		UpdateColumn("file_primary", true).Error; err != nil {
 		return err
 	} else if m.PhotoQuality < 0 {
 		m.PhotoQuality = 0
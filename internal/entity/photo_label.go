@@ -12,6 +12,7 @@ type PhotoLabel struct {
 	PhotoID     uint   `gorm:"primary_key;auto_increment:false"`
 	LabelID     uint   `gorm:"primary_key;auto_increment:false;index"`
 	LabelSrc    string `gorm:"type:VARBINARY(8);"`
+	LabelModel  string `gorm:"type:VARBINARY(64);"`
 	Uncertainty int    `gorm:"type:SMALLINT"`
 	Photo       *Photo `gorm:"PRELOAD:false"`
 	Label       *Label `gorm:"PRELOAD:true"`
@@ -94,6 +95,7 @@ func (m *PhotoLabel) ClassifyLabel() classify.Label {
 	result := classify.Label{
 		Name:        m.Label.LabelName,
 		Source:      m.LabelSrc,
+		Model:       m.LabelModel,
 		Uncertainty: m.Uncertainty,
 		Priority:    m.Label.LabelPriority,
 	}
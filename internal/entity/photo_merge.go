@@ -1,6 +1,7 @@
 package entity
 
 import (
+	"fmt"
 	"sync"
 
 	"github.com/jinzhu/gorm"
@@ -13,7 +14,7 @@ var photoMergeMutex = sync.Mutex{}
 func (m *Photo) ResolvePrimary() error {
 	var file File
 
-	if err := Db().Where("file_primary = 1 AND photo_id = ?", m.ID).
+	if err := Db().Where("file_primary = ? AND photo_id = ?", true, m.ID).
 		Order("file_width DESC, file_hdr DESC").
 		First(&file).Error; err == nil && file.ID > 0 {
 		return file.ResolvePrimary()
@@ -87,13 +88,6 @@ func (m *Photo) Merge(mergeMeta, mergeUuid bool) (original Photo, merged Photos,
 		return Photo{}, merged, err
 	}
 
-	logResult := func(res *gorm.DB) {
-		if res.Error != nil {
-			log.Errorf("merge: %s", res.Error.Error())
-			err = res.Error
-		}
-	}
-
 	for i, merge := range identical {
 		if i == 0 {
 			original = merge
@@ -101,27 +95,10 @@ func (m *Photo) Merge(mergeMeta, mergeUuid bool) (original Photo, merged Photos,
 			continue
 		}
 
-		deleted := TimeStamp()
-
-		logResult(UnscopedDb().Exec("UPDATE files SET photo_id = ?, photo_uid = ?, file_primary = 0 WHERE photo_id = ?", original.ID, original.PhotoUID, merge.ID))
-		logResult(UnscopedDb().Exec("UPDATE photos SET photo_quality = -1, deleted_at = ? WHERE id = ?", TimeStamp(), merge.ID))
-
-		switch DbDialect() {
-		case MySQL:
-			logResult(UnscopedDb().Exec("UPDATE IGNORE photos_keywords SET photo_id = ? WHERE photo_id = ?", original.ID, merge.ID))
-			logResult(UnscopedDb().Exec("UPDATE IGNORE photos_labels SET photo_id = ? WHERE photo_id = ?", original.ID, merge.ID))
-			logResult(UnscopedDb().Exec("UPDATE IGNORE photos_albums SET photo_uid = ? WHERE photo_uid = ?", original.PhotoUID, merge.PhotoUID))
-		case SQLite3:
-			logResult(UnscopedDb().Exec("UPDATE OR IGNORE photos_keywords SET photo_id = ? WHERE photo_id = ?", original.ID, merge.ID))
-			logResult(UnscopedDb().Exec("UPDATE OR IGNORE photos_labels SET photo_id = ? WHERE photo_id = ?", original.ID, merge.ID))
-			logResult(UnscopedDb().Exec("UPDATE OR IGNORE photos_albums SET photo_uid = ? WHERE photo_uid = ?", original.PhotoUID, merge.PhotoUID))
-		default:
-			log.Warnf("sql: unsupported dialect %s", DbDialect())
+		if mergeErr := original.mergePhoto(&merge); mergeErr != nil {
+			err = mergeErr
 		}
 
-		merge.DeletedAt = &deleted
-		merge.PhotoQuality = -1
-
 		merged = append(merged, merge)
 	}
 
@@ -135,3 +112,69 @@ func (m *Photo) Merge(mergeMeta, mergeUuid bool) (original Photo, merged Photos,
 
 	return original, merged, err
 }
+
+// Stack moves the files of other photos to this photo, e.g. to stack visually similar copies.
+func (m *Photo) Stack(photos Photos) (stacked Photos, err error) {
+	if !m.HasID() {
+		return stacked, fmt.Errorf("photo id must not be empty")
+	}
+
+	photoMergeMutex.Lock()
+	defer photoMergeMutex.Unlock()
+
+	for _, merge := range photos {
+		if !merge.HasID() || merge.ID == m.ID {
+			continue
+		}
+
+		if mergeErr := m.mergePhoto(&merge); mergeErr != nil {
+			err = mergeErr
+		}
+
+		stacked = append(stacked, merge)
+	}
+
+	if len(stacked) > 0 {
+		File{PhotoID: m.ID, PhotoUID: m.PhotoUID}.RegenerateIndex()
+	}
+
+	return stacked, err
+}
+
+// mergePhoto moves the files, keywords, labels, and album entries of another photo to this photo
+// and flags the other photo as deleted.
+func (m *Photo) mergePhoto(merge *Photo) (err error) {
+	logResult := func(res *gorm.DB) {
+		if res.Error != nil {
+			log.Errorf("merge: %s", res.Error.Error())
+			err = res.Error
+		}
+	}
+
+	deleted := TimeStamp()
+
+	logResult(UnscopedDb().Exec("UPDATE files SET photo_id = ?, photo_uid = ?, file_primary = ? WHERE photo_id = ?", m.ID, m.PhotoUID, false, merge.ID))
+	logResult(UnscopedDb().Exec("UPDATE photos SET photo_quality = -1, deleted_at = ? WHERE id = ?", TimeStamp(), merge.ID))
+
+	switch DbDialect() {
+	case MySQL:
+		logResult(UnscopedDb().Exec("UPDATE IGNORE photos_keywords SET photo_id = ? WHERE photo_id = ?", m.ID, merge.ID))
+		logResult(UnscopedDb().Exec("UPDATE IGNORE photos_labels SET photo_id = ? WHERE photo_id = ?", m.ID, merge.ID))
+		logResult(UnscopedDb().Exec("UPDATE IGNORE photos_albums SET photo_uid = ? WHERE photo_uid = ?", m.PhotoUID, merge.PhotoUID))
+	case SQLite3:
+		logResult(UnscopedDb().Exec("UPDATE OR IGNORE photos_keywords SET photo_id = ? WHERE photo_id = ?", m.ID, merge.ID))
+		logResult(UnscopedDb().Exec("UPDATE OR IGNORE photos_labels SET photo_id = ? WHERE photo_id = ?", m.ID, merge.ID))
+		logResult(UnscopedDb().Exec("UPDATE OR IGNORE photos_albums SET photo_uid = ? WHERE photo_uid = ?", m.PhotoUID, merge.PhotoUID))
+	case Postgres:
+		logResult(UnscopedDb().Exec("UPDATE photos_keywords SET photo_id = ? WHERE photo_id = ? AND keyword_id NOT IN (SELECT keyword_id FROM photos_keywords WHERE photo_id = ?)", m.ID, merge.ID, m.ID))
+		logResult(UnscopedDb().Exec("UPDATE photos_labels SET photo_id = ? WHERE photo_id = ? AND label_id NOT IN (SELECT label_id FROM photos_labels WHERE photo_id = ?)", m.ID, merge.ID, m.ID))
+		logResult(UnscopedDb().Exec("UPDATE photos_albums SET photo_uid = ? WHERE photo_uid = ? AND album_uid NOT IN (SELECT album_uid FROM photos_albums WHERE photo_uid = ?)", m.PhotoUID, merge.PhotoUID, m.PhotoUID))
+	default:
+		log.Warnf("sql: unsupported dialect %s", DbDialect())
+	}
+
+	merge.DeletedAt = &deleted
+	merge.PhotoQuality = -1
+
+	return err
+}
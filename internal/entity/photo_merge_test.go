@@ -95,3 +95,46 @@ func TestPhoto_Merge(t *testing.T) {
 		assert.Equal(t, 1000024, int(merged[0].ID))
 	})
 }
+
+func TestPhoto_Stack(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		best := NewPhoto(true)
+		copy1 := NewPhoto(true)
+
+		if err := best.Save(); err != nil {
+			t.Fatal(err)
+		} else if err = copy1.Save(); err != nil {
+			t.Fatal(err)
+		}
+
+		file := &File{PhotoID: copy1.ID, PhotoUID: copy1.PhotoUID, FileName: "stack/copy1.jpg", FileRoot: RootOriginals, FileType: "jpg", FilePrimary: true}
+
+		if err := file.Create(); err != nil {
+			t.Fatal(err)
+		}
+
+		stacked, err := best.Stack(Photos{best, copy1})
+
+		assert.NoError(t, err)
+		assert.Len(t, stacked, 1)
+		assert.Equal(t, copy1.ID, stacked[0].ID)
+		assert.NotNil(t, stacked[0].DeletedAt)
+
+		var result File
+
+		if err = Db().Where("id = ?", file.ID).First(&result).Error; err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, best.ID, result.PhotoID)
+		assert.Equal(t, best.PhotoUID, result.PhotoUID)
+		assert.False(t, result.FilePrimary)
+	})
+	t.Run("NoID", func(t *testing.T) {
+		photo := Photo{}
+		stacked, err := photo.Stack(Photos{PhotoFixtures.Get("Photo01")})
+
+		assert.Error(t, err)
+		assert.Empty(t, stacked)
+	})
+}
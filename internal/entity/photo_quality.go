@@ -18,8 +18,18 @@ var (
 	year2012 = time.Date(2012, 1, 1, 0, 0, 0, 0, time.UTC)
 )
 
+// UploadedByVisitor checks if the photo was uploaded by a visitor with a share link.
+func (m *Photo) UploadedByVisitor() bool {
+	return m.CreatedBy == Visitor.UserUID
+}
+
 // QualityScore returns a score based on photo properties like size and metadata.
 func (m *Photo) QualityScore() (score int) {
+	// Photos uploaded by visitors remain in review until they are approved.
+	if m.UploadedByVisitor() && m.EditedAt == nil {
+		return 0
+	}
+
 	if m.PhotoFavorite {
 		score += 3
 	}
@@ -7,6 +7,18 @@ import (
 )
 
 func TestPhoto_QualityScore(t *testing.T) {
+	t.Run("VisitorUpload", func(t *testing.T) {
+		photo := NewUserPhoto(false, Visitor.UserUID)
+		photo.PhotoFavorite = true
+
+		assert.True(t, photo.UploadedByVisitor())
+		assert.Equal(t, 0, photo.QualityScore())
+
+		edited := TimeStamp()
+		photo.EditedAt = &edited
+
+		assert.GreaterOrEqual(t, photo.QualityScore(), 3)
+	})
 	t.Run("PhotoFixture19800101_000002_D640C559", func(t *testing.T) {
 		assert.Equal(t, 3, PhotoFixtures.Pointer("19800101_000002_D640C559").QualityScore())
 	})
@@ -251,6 +251,24 @@ func TestPhoto_AddLabels(t *testing.T) {
 		assert.Equal(t, 10, m.Labels[0].Uncertainty)
 		assert.Equal(t, SrcManual, m.Labels[0].LabelSrc)
 	})
+	t.Run("Model", func(t *testing.T) {
+		m := PhotoFixtures.Get("19800101_000002_D640C559")
+		classifyLabels := classify.Labels{{Name: "succulent", Uncertainty: 20, Source: SrcImage, Model: "efficientnet", Priority: 5}}
+		m.AddLabels(classifyLabels)
+
+		found := false
+
+		for _, l := range m.Labels {
+			if l.Label != nil && l.Label.LabelName == "Succulent" {
+				found = true
+				assert.Equal(t, SrcImage, l.LabelSrc)
+				assert.Equal(t, "efficientnet", l.LabelModel)
+				assert.Equal(t, "efficientnet", l.ClassifyLabel().Model)
+			}
+		}
+
+		assert.True(t, found)
+	})
 }
 
 func TestPhoto_SetDescription(t *testing.T) {
@@ -593,6 +611,42 @@ func TestPhoto_Approve(t *testing.T) {
 
 		assert.Equal(t, 3, photo.PhotoQuality)
 	})
+	t.Run("VisitorUpload", func(t *testing.T) {
+		photo := NewUserPhoto(false, Visitor.UserUID)
+		photo.PhotoQuality = photo.QualityScore()
+
+		if err := photo.Create(); err != nil {
+			t.Fatal(err)
+		}
+
+		defer photo.DeletePermanently()
+
+		assert.Equal(t, 0, photo.PhotoQuality)
+
+		if err := AddPhotoToUserAlbums(photo.PhotoUID, []string{"at9lxuqxpogaaba9"}, Visitor.UserUID); err != nil {
+			t.Fatal(err)
+		}
+
+		entry := PhotoAlbum{}
+
+		if err := Db().Where("photo_uid = ? AND album_uid = ?", photo.PhotoUID, "at9lxuqxpogaaba9").First(&entry).Error; err != nil {
+			t.Fatal(err)
+		}
+
+		assert.True(t, entry.Hidden)
+
+		if err := photo.Approve(); err != nil {
+			t.Fatal(err)
+		}
+
+		assert.GreaterOrEqual(t, photo.PhotoQuality, 3)
+
+		if err := Db().Where("photo_uid = ? AND album_uid = ?", photo.PhotoUID, "at9lxuqxpogaaba9").First(&entry).Error; err != nil {
+			t.Fatal(err)
+		}
+
+		assert.False(t, entry.Hidden)
+	})
 }
 
 func TestPhoto_Links(t *testing.T) {
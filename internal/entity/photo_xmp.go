@@ -0,0 +1,133 @@
+package entity
+
+import (
+	"path/filepath"
+	"strings"
+
+	"github.com/photoprism/photoprism/internal/meta"
+	"github.com/photoprism/photoprism/pkg/fs"
+)
+
+// trustedSrc tests if a value was set by a user or read from file metadata, so that generated
+// values such as estimated locations and titles are not written to XMP sidecar files.
+func trustedSrc(src string) bool {
+	return SrcPriority[src] >= SrcPriority[SrcMeta]
+}
+
+// XmpUpdate returns the photo metadata to be written to an XMP sidecar file. Since trusted
+// values are read from the sidecar file when indexing, existing values that are no longer
+// present, e.g. because they have been cleared by the user, are removed from the file.
+func (m *Photo) XmpUpdate() meta.XmpUpdate {
+	result := meta.XmpUpdate{Replace: true, Favorite: m.PhotoFavorite}
+
+	if trustedSrc(m.TitleSrc) {
+		result.Title = m.PhotoTitle
+	}
+
+	if trustedSrc(m.DescriptionSrc) {
+		result.Description = m.PhotoDescription
+	}
+
+	if trustedSrc(m.TakenSrc) {
+		result.TakenAt = m.TakenAt
+		result.TakenAtLocal = m.TakenAtLocal
+		result.TimeZone = m.TimeZone
+	}
+
+	if m.HasLatLng() && trustedSrc(m.PlaceSrc) {
+		result.Lat = float64(m.PhotoLat)
+		result.Lng = float64(m.PhotoLng)
+		result.Altitude = float64(m.PhotoAltitude)
+	}
+
+	// Add label names and keywords.
+	var labels PhotoLabels
+
+	if m.HasID() {
+		if err := Db().Where("photo_id = ? AND uncertainty < 100", m.ID).Preload("Label").Find(&labels).Error; err != nil {
+			log.Warnf("photo: %s (find labels)", err)
+		}
+	}
+
+	for _, l := range labels {
+		if l.Label != nil && l.Label.LabelName != "" {
+			result.Keywords = append(result.Keywords, l.Label.LabelName)
+		}
+	}
+
+	if details := m.GetDetails(); trustedSrc(details.KeywordsSrc) {
+		for _, w := range strings.Split(details.Keywords, ",") {
+			if w = strings.TrimSpace(w); w != "" {
+				result.Keywords = append(result.Keywords, w)
+			}
+		}
+	}
+
+	// Add named faces as image regions.
+	if f, err := m.PrimaryFile(); err == nil {
+		result.Width = f.FileWidth
+		result.Height = f.FileHeight
+
+		for _, marker := range *f.Markers() {
+			if marker.MarkerInvalid || marker.MarkerType != MarkerFace {
+				continue
+			} else if name := marker.SubjectName(); name != "" {
+				result.Regions = append(result.Regions, meta.XmpRegion{
+					Name: name,
+					Type: "Face",
+					X:    float64(marker.X),
+					Y:    float64(marker.Y),
+					W:    float64(marker.W),
+					H:    float64(marker.H),
+				})
+			}
+		}
+	}
+
+	return result
+}
+
+// XmpFileName returns the name of the XMP sidecar file to write and, if it is a new file, the name
+// of an existing file whose contents should be kept. Sidecar files in the originals folder
+// are not modified in read-only mode; instead, a copy is created in the sidecar folder.
+func (m *Photo) XmpFileName(originalsPath, sidecarPath string, readOnly bool) (fileName, srcName string) {
+	var files Files
+
+	if m.HasID() {
+		if err := Db().Where("photo_id = ? AND file_type = ? AND file_missing = ?", m.ID, fs.SidecarXMP, false).
+			Order("file_root DESC, id").Find(&files).Error; err != nil {
+			log.Warnf("photo: %s (find xmp files)", err)
+		}
+	}
+
+	for _, f := range files {
+		switch f.FileRoot {
+		case RootSidecar:
+			return filepath.Join(sidecarPath, f.FileName), ""
+		case RootOriginals:
+			if existing := filepath.Join(originalsPath, f.FileName); !readOnly {
+				return existing, ""
+			} else if fileName = fs.FileName(existing, sidecarPath, originalsPath, ""); fs.FileExists(fileName) {
+				return fileName, ""
+			} else {
+				return fileName, existing
+			}
+		}
+	}
+
+	baseName := filepath.Join(originalsPath, m.PhotoPath, m.PhotoName)
+
+	if readOnly {
+		return fs.FileName(baseName, sidecarPath, originalsPath, fs.ExtXMP), ""
+	}
+
+	return baseName + fs.ExtXMP, ""
+}
+
+// SaveAsXmp writes photo metadata to an XMP sidecar file while keeping existing contents,
+// which are read from srcName if not empty.
+func (m *Photo) SaveAsXmp(fileName, srcName string) error {
+	update := m.XmpUpdate()
+
+	return update.Save(fileName, srcName)
+}
@@ -0,0 +1,95 @@
+package entity
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/meta"
+)
+
+func TestPhoto_XmpUpdate(t *testing.T) {
+	t.Run("Photo01", func(t *testing.T) {
+		m := PhotoFixtures.Get("Photo01")
+
+		result := m.XmpUpdate()
+
+		assert.True(t, result.Replace)
+		assert.True(t, result.Favorite)
+		assert.Equal(t, "", result.Title)
+		assert.Equal(t, "", result.Description)
+		assert.Equal(t, "Europe/Berlin", result.TimeZone)
+		assert.Equal(t, time.Date(2006, 1, 1, 2, 0, 0, 0, time.UTC), result.TakenAt)
+		assert.InDelta(t, 48.519234, result.Lat, 0.00001)
+		assert.InDelta(t, 9.057997, result.Lng, 0.00001)
+	})
+	t.Run("Photo04", func(t *testing.T) {
+		m := PhotoFixtures.Get("Photo04")
+
+		result := m.XmpUpdate()
+
+		// Estimated locations are not written.
+		assert.Equal(t, float64(0), result.Lat)
+		assert.Equal(t, float64(0), result.Lng)
+	})
+}
+
+func TestPhoto_XmpFileName(t *testing.T) {
+	originals := t.TempDir()
+	sidecar := t.TempDir()
+
+	t.Run("Existing", func(t *testing.T) {
+		m := PhotoFixtures.Get("Photo01")
+
+		fileName, srcName := m.XmpFileName(originals, sidecar, false)
+
+		assert.Equal(t, filepath.Join(originals, "2790/02/Photo01.xmp"), fileName)
+		assert.Equal(t, "", srcName)
+	})
+	t.Run("ExistingReadOnly", func(t *testing.T) {
+		m := PhotoFixtures.Get("Photo01")
+
+		fileName, srcName := m.XmpFileName(originals, sidecar, true)
+
+		assert.Equal(t, filepath.Join(sidecar, "2790/02/Photo01.xmp"), fileName)
+		assert.Equal(t, filepath.Join(originals, "2790/02/Photo01.xmp"), srcName)
+	})
+	t.Run("New", func(t *testing.T) {
+		m := PhotoFixtures.Get("Photo02")
+
+		fileName, srcName := m.XmpFileName(originals, sidecar, false)
+
+		assert.Equal(t, filepath.Join(originals, "London/bridge1.xmp"), fileName)
+		assert.Equal(t, "", srcName)
+	})
+	t.Run("NewReadOnly", func(t *testing.T) {
+		m := PhotoFixtures.Get("Photo02")
+
+		fileName, srcName := m.XmpFileName(originals, sidecar, true)
+
+		assert.Equal(t, filepath.Join(sidecar, "London/bridge1.xmp"), fileName)
+		assert.Equal(t, "", srcName)
+	})
+}
+
+func TestPhoto_SaveAsXmp(t *testing.T) {
+	m := PhotoFixtures.Get("Photo01")
+
+	fileName := filepath.Join(t.TempDir(), "Photo01.xmp")
+
+	if err := m.SaveAsXmp(fileName, ""); err != nil {
+		t.Fatal(err)
+	}
+
+	doc := meta.XmpDocument{}
+
+	if err := doc.Load(fileName); err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, "5", doc.RDF.Description.Rating)
+	assert.Equal(t, time.Date(2006, 1, 1, 2, 0, 0, 0, time.UTC), doc.TakenAt("").UTC())
+	assert.InDelta(t, 48.519234, meta.GpsToDecimal(doc.RDF.Description.GPSLatitude), 0.00001)
+}
@@ -139,8 +139,8 @@ func (m *Service) SaveForm(form form.Service) error {
 		return err
 	}
 
-	// TODO: Support for other remote services in addition to WebDAV.
-	if m.AccType != remote.ServiceWebDAV {
+	// Disable upload and sync for services without client support.
+	if !remote.Supported(m.AccType) {
 		m.AccShare = false // Disable manual upload.
 		m.AccSync = false  // Disable background sync.
 	}
@@ -190,14 +190,33 @@ func (m *Service) Delete() error {
 	return Db().Delete(m).Error
 }
 
+// Account returns the remote account details.
+func (m *Service) Account() remote.Account {
+	return remote.Account{
+		AccName: m.AccName,
+		AccURL:  m.AccURL,
+		AccType: m.AccType,
+		AccKey:  m.AccKey,
+		AccUser: m.AccUser,
+		AccPass: m.AccPass,
+	}
+}
+
+// Client returns a new client for the remote service, which must be closed after use.
+func (m *Service) Client() (remote.Client, error) {
+	return remote.NewClient(m.Account(), webdav.Timeout(m.AccTimeout))
+}
+
 // Directories returns a list of directories or albums in an account.
 func (m *Service) Directories() (result fs.FileInfos, err error) {
-	if m.AccType == remote.ServiceWebDAV {
-		var client *webdav.Client
-		if client, err = webdav.NewClient(m.AccURL, m.AccUser, m.AccPass, webdav.Timeout(m.AccTimeout)); err != nil {
+	if remote.Supported(m.AccType) {
+		var client remote.Client
+		if client, err = m.Client(); err != nil {
 			return result, err
 		}
 
+		defer client.Close()
+
 		result, err = client.Directories("/", true, 0)
 	}
 
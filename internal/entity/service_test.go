@@ -1,6 +1,8 @@
 package entity
 
 import (
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/photoprism/photoprism/internal/form"
@@ -146,6 +148,41 @@ func TestService_Directories(t *testing.T) {
 		assert.Contains(t, result.Abs(), "/Photos")
 
 	})
+	t.Run("local", func(t *testing.T) {
+		dir := t.TempDir()
+
+		if err := os.MkdirAll(filepath.Join(dir, "Photos", "2023"), os.ModePerm); err != nil {
+			t.Fatal(err)
+		} else if err = os.WriteFile(filepath.Join(dir, "Photos", "2023", "cat.jpg"), []byte("cat"), 0644); err != nil {
+			t.Fatal(err)
+		}
+
+		account := Service{AccName: "LocalAccount", AccOwner: "Owner", AccURL: "file://" + dir, AccType: "local", AccSync: true, SyncDownload: true}
+
+		accountForm, err := form.NewService(account)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		model, err := AddService(accountForm)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		defer model.Delete()
+
+		assert.True(t, model.AccSync)
+
+		result, err := model.Directories()
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, []string{"/Photos", "/Photos/2023"}, result.Abs())
+	})
 	t.Run("no directory", func(t *testing.T) {
 		account := Service{AccName: "DirectoriesAccount", AccOwner: "Owner", AccURL: "http://dummy-webdav/", AccType: "xxx", AccKey: "123", AccUser: "admin", AccPass: "photoprism",
 			AccError: "", AccShare: true, AccSync: true, RetryLimit: 4, SharePath: "/home", ShareSize: "500", ShareExpires: 3500, SyncPath: "/sync",
@@ -172,6 +209,38 @@ func TestService_Directories(t *testing.T) {
 	})
 }
 
+func TestService_Account(t *testing.T) {
+	m := ServiceFixtureWebdavDummy
+	acc := m.Account()
+
+	assert.Equal(t, m.AccName, acc.AccName)
+	assert.Equal(t, m.AccURL, acc.AccURL)
+	assert.Equal(t, m.AccType, acc.AccType)
+	assert.Equal(t, m.AccUser, acc.AccUser)
+	assert.Equal(t, m.AccPass, acc.AccPass)
+}
+
+func TestService_Client(t *testing.T) {
+	t.Run("Local", func(t *testing.T) {
+		m := Service{AccName: "Local", AccURL: t.TempDir(), AccType: "local"}
+
+		client, err := m.Client()
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.NoError(t, client.Close())
+	})
+	t.Run("Unsupported", func(t *testing.T) {
+		m := Service{AccName: "Facebook", AccURL: "https://www.facebook.com/", AccType: "facebook"}
+
+		_, err := m.Client()
+
+		assert.Error(t, err)
+	})
+}
+
 func TestService_Updates(t *testing.T) {
 	t.Run("success", func(t *testing.T) {
 		account := Service{AccName: "DeleteAccount", AccOwner: "Delete", AccURL: "test.com", AccType: "test", AccKey: "123", AccUser: "testuser", AccPass: "testpass",
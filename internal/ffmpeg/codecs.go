@@ -0,0 +1,100 @@
+package ffmpeg
+
+import (
+	"strings"
+
+	"github.com/photoprism/photoprism/pkg/clean"
+	"github.com/photoprism/photoprism/pkg/fs"
+	"github.com/photoprism/photoprism/pkg/video"
+)
+
+// Codec represents a supported video transcoding target.
+type Codec string
+
+// String returns the codec name as string.
+func (c Codec) String() string {
+	return string(c)
+}
+
+// Supported video transcoding targets, AVC is the most compatible.
+const (
+	CodecAvc  Codec = "avc"  // CodecAvc is H.264, Advanced Video Coding (AVC).
+	CodecHevc Codec = "hevc" // CodecHevc is H.265, High Efficiency Video Coding (HEVC).
+	CodecAv1  Codec = "av1"  // CodecAv1 is AOMedia Video 1 (AV1).
+)
+
+// Codecs maps names and aliases to supported video transcoding targets.
+var Codecs = map[string]Codec{
+	"":      CodecAvc,
+	"avc":   CodecAvc,
+	"avc1":  CodecAvc,
+	"h264":  CodecAvc,
+	"h.264": CodecAvc,
+	"hevc":  CodecHevc,
+	"hvc1":  CodecHevc,
+	"h265":  CodecHevc,
+	"h.265": CodecHevc,
+	"av1":   CodecAv1,
+	"av01":  CodecAv1,
+}
+
+// FindCodec finds a video transcoding target by name and falls back to AVC if it is not supported.
+func FindCodec(s string) Codec {
+	if c, ok := Codecs[strings.ToLower(strings.TrimSpace(s))]; ok {
+		return c
+	} else {
+		log.Warnf("ffmpeg: unsupported codec %s", clean.Log(s))
+	}
+
+	return CodecAvc
+}
+
+// FileType returns the sidecar file type of videos transcoded with this codec.
+func (c Codec) FileType() fs.Type {
+	switch c {
+	case CodecHevc:
+		return fs.VideoHEVC
+	case CodecAv1:
+		return fs.VideoAV1
+	default:
+		return fs.VideoAVC
+	}
+}
+
+// Ext returns the sidecar file extension of videos transcoded with this codec.
+func (c Codec) Ext() string {
+	return "." + string(c.FileType())
+}
+
+// Video returns the video codec identifier, as stored in the index and used by clients.
+func (c Codec) Video() video.Codec {
+	switch c {
+	case CodecHevc:
+		return video.CodecHEVC
+	case CodecAv1:
+		return video.CodecAV1
+	default:
+		return video.CodecAVC
+	}
+}
+
+// Encoder returns the FFmpeg encoder name for this codec on the same platform as the specified AVC encoder.
+// Platforms without a matching hardware encoder fall back to the software encoder.
+func (c Codec) Encoder(avc AvcEncoder) string {
+	var encoders map[AvcEncoder]string
+
+	switch c {
+	case CodecHevc:
+		encoders = HevcEncoders
+	case CodecAv1:
+		encoders = Av1Encoders
+	default:
+		return avc.String()
+	}
+
+	if encoder, ok := encoders[avc]; ok {
+		return encoder
+	}
+
+	return encoders[SoftwareEncoder]
+}
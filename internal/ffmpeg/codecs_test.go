@@ -0,0 +1,53 @@
+package ffmpeg
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/pkg/fs"
+	"github.com/photoprism/photoprism/pkg/video"
+)
+
+func TestFindCodec(t *testing.T) {
+	assert.Equal(t, CodecAvc, FindCodec(""))
+	assert.Equal(t, CodecAvc, FindCodec("h264"))
+	assert.Equal(t, CodecHevc, FindCodec("HEVC"))
+	assert.Equal(t, CodecHevc, FindCodec("hvc1"))
+	assert.Equal(t, CodecAv1, FindCodec(" av1 "))
+	assert.Equal(t, CodecAvc, FindCodec("vp9"))
+}
+
+func TestCodec_FileType(t *testing.T) {
+	assert.Equal(t, fs.VideoAVC, CodecAvc.FileType())
+	assert.Equal(t, fs.VideoHEVC, CodecHevc.FileType())
+	assert.Equal(t, fs.VideoAV1, CodecAv1.FileType())
+}
+
+func TestCodec_Ext(t *testing.T) {
+	assert.Equal(t, ".avc", CodecAvc.Ext())
+	assert.Equal(t, ".hevc", CodecHevc.Ext())
+	assert.Equal(t, ".av1", CodecAv1.Ext())
+}
+
+func TestCodec_Video(t *testing.T) {
+	assert.Equal(t, video.CodecAVC, CodecAvc.Video())
+	assert.Equal(t, video.CodecHEVC, CodecHevc.Video())
+	assert.Equal(t, video.CodecAV1, CodecAv1.Video())
+}
+
+func TestCodec_Encoder(t *testing.T) {
+	t.Run("Avc", func(t *testing.T) {
+		assert.Equal(t, "h264_nvenc", CodecAvc.Encoder(NvidiaEncoder))
+	})
+	t.Run("Hevc", func(t *testing.T) {
+		assert.Equal(t, "libx265", CodecHevc.Encoder(SoftwareEncoder))
+		assert.Equal(t, "hevc_videotoolbox", CodecHevc.Encoder(AppleEncoder))
+		assert.Equal(t, "libx265", CodecHevc.Encoder(Video4LinuxEncoder))
+	})
+	t.Run("Av1", func(t *testing.T) {
+		assert.Equal(t, "libsvtav1", CodecAv1.Encoder(SoftwareEncoder))
+		assert.Equal(t, "av1_nvenc", CodecAv1.Encoder(NvidiaEncoder))
+		assert.Equal(t, "libsvtav1", CodecAv1.Encoder(AppleEncoder))
+	})
+}
@@ -4,6 +4,8 @@ package ffmpeg
 type Options struct {
 	Bin      string
 	Encoder  AvcEncoder
+	Codec    Codec
+	Preset   Preset
 	Bitrate  string
 	MapVideo string
 	MapAudio string
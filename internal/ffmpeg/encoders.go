@@ -61,3 +61,20 @@ func FindEncoder(s string) AvcEncoder {
 
 	return SoftwareEncoder
 }
+
+// HevcEncoders maps AVC encoders to the HEVC encoder of the same platform.
+var HevcEncoders = map[AvcEncoder]string{
+	SoftwareEncoder: "libx265",
+	IntelEncoder:    "hevc_qsv",
+	AppleEncoder:    "hevc_videotoolbox",
+	VAAPIEncoder:    "hevc_vaapi",
+	NvidiaEncoder:   "hevc_nvenc",
+}
+
+// Av1Encoders maps AVC encoders to the AV1 encoder of the same platform.
+var Av1Encoders = map[AvcEncoder]string{
+	SoftwareEncoder: "libsvtav1",
+	IntelEncoder:    "av1_qsv",
+	VAAPIEncoder:    "av1_vaapi",
+	NvidiaEncoder:   "av1_nvenc",
+}
@@ -0,0 +1,252 @@
+package ffmpeg
+
+import (
+	"fmt"
+	"math"
+	"os/exec"
+	"strings"
+	"time"
+)
+
+// HlsSegmentDuration is the duration of HTTP Live Streaming (HLS) video segments.
+var HlsSegmentDuration = 6 * time.Second
+
+// HlsAudioBitrate is the AAC audio bitrate of HLS video segments.
+const HlsAudioBitrate = "128k"
+
+// Rendition represents an HLS video rendition with a fixed resolution and bitrate.
+type Rendition struct {
+	Name    string
+	Size    int
+	Bitrate int
+}
+
+// Renditions is the list of supported HLS video renditions, ordered by size.
+var Renditions = []Rendition{
+	{Name: "360p", Size: 360, Bitrate: 800},
+	{Name: "720p", Size: 720, Bitrate: 2800},
+	{Name: "1080p", Size: 1080, Bitrate: 5000},
+	{Name: "2160p", Size: 2160, Bitrate: 14000},
+}
+
+// FindRendition returns the rendition with the specified name.
+func FindRendition(name string) (r Rendition, ok bool) {
+	for _, r = range Renditions {
+		if r.Name == name {
+			return r, true
+		}
+	}
+
+	return Rendition{}, false
+}
+
+// VideoRenditions returns the renditions that are suitable for a video with the specified resolution,
+// so that it is never upscaled. The smallest rendition is always included.
+func VideoRenditions(width, height int) (result []Rendition) {
+	short := width
+
+	if height < width {
+		short = height
+	}
+
+	for i, r := range Renditions {
+		if i == 0 || r.Size <= short {
+			result = append(result, r)
+		}
+	}
+
+	return result
+}
+
+// Resolution returns the output resolution for a video with the specified size, so that the
+// shorter side matches the rendition size and the aspect ratio is preserved.
+func (r Rendition) Resolution(width, height int) (w, h int) {
+	if width <= 0 || height <= 0 {
+		return even(float64(r.Size) * 16 / 9), r.Size
+	}
+
+	portrait := height > width
+	short, long := height, width
+
+	if portrait {
+		short, long = width, height
+	}
+
+	// Never upscale videos.
+	size := r.Size
+
+	if short < size {
+		size = short
+	}
+
+	scaledShort := even(float64(size))
+	scaledLong := even(float64(long) * float64(size) / float64(short))
+
+	if portrait {
+		return scaledShort, scaledLong
+	}
+
+	return scaledLong, scaledShort
+}
+
+// Bandwidth returns the peak bandwidth of the rendition in bits per second, including audio.
+func (r Rendition) Bandwidth() int {
+	return (r.Bitrate*3/2 + 128) * 1000
+}
+
+// Codecs returns the codecs of the rendition as RFC 6381 string, i.e. H.264 High Profile and AAC-LC audio.
+func (r Rendition) Codecs() string {
+	if r.Size > 1080 {
+		return "avc1.640033,mp4a.40.2"
+	}
+
+	return "avc1.640028,mp4a.40.2"
+}
+
+// even rounds a number to the nearest even integer, as required by most video encoders.
+func even(n float64) int {
+	result := int(math.Round(n/2) * 2)
+
+	if result < 2 {
+		return 2
+	}
+
+	return result
+}
+
+// HlsSegments returns the number of HLS segments for a video with the specified duration.
+func HlsSegments(d time.Duration) int {
+	if d <= 0 || HlsSegmentDuration <= 0 {
+		return 0
+	}
+
+	return int(math.Ceil(float64(d) / float64(HlsSegmentDuration)))
+}
+
+// HlsSegmentName returns the file name of an HLS segment.
+func HlsSegmentName(index int) string {
+	return fmt.Sprintf("%d.ts", index)
+}
+
+// HlsSegmentIndex returns the segment index for an HLS segment name, or -1 if it is invalid.
+func HlsSegmentIndex(name string) int {
+	if !strings.HasSuffix(name, ".ts") {
+		return -1
+	}
+
+	var index int
+
+	if _, err := fmt.Sscanf(name, "%d.ts", &index); err != nil || index < 0 || HlsSegmentName(index) != name {
+		return -1
+	}
+
+	return index
+}
+
+// HlsMasterPlaylist returns the HLS master playlist, which lists the renditions for a video
+// with the specified resolution. Rendition playlists are referenced with relative URLs.
+func HlsMasterPlaylist(width, height int) string {
+	var b strings.Builder
+
+	b.WriteString("#EXTM3U\n")
+	b.WriteString("#EXT-X-VERSION:3\n")
+
+	for _, r := range VideoRenditions(width, height) {
+		w, h := r.Resolution(width, height)
+		b.WriteString(fmt.Sprintf("#EXT-X-STREAM-INF:BANDWIDTH=%d,RESOLUTION=%dx%d,CODECS=\"%s\",NAME=\"%s\"\n", r.Bandwidth(), w, h, r.Codecs(), r.Name))
+		b.WriteString(fmt.Sprintf("%s/index.m3u8\n", r.Name))
+	}
+
+	return b.String()
+}
+
+// HlsMediaPlaylist returns the HLS media playlist for a video with the specified duration.
+// Since all segments have a fixed duration, clients can seek before they have been transcoded.
+func HlsMediaPlaylist(d time.Duration) string {
+	var b strings.Builder
+
+	segments := HlsSegments(d)
+
+	b.WriteString("#EXTM3U\n")
+	b.WriteString("#EXT-X-VERSION:3\n")
+	b.WriteString("#EXT-X-PLAYLIST-TYPE:VOD\n")
+	b.WriteString(fmt.Sprintf("#EXT-X-TARGETDURATION:%d\n", int(math.Ceil(HlsSegmentDuration.Seconds()))))
+	b.WriteString("#EXT-X-MEDIA-SEQUENCE:0\n")
+
+	for i := 0; i < segments; i++ {
+		b.WriteString(fmt.Sprintf("#EXTINF:%.3f,\n", HlsSegmentLength(d, i).Seconds()))
+		b.WriteString(HlsSegmentName(i) + "\n")
+	}
+
+	b.WriteString("#EXT-X-ENDLIST\n")
+
+	return b.String()
+}
+
+// HlsSegmentStart returns the start time of an HLS segment.
+func HlsSegmentStart(index int) time.Duration {
+	return time.Duration(index) * HlsSegmentDuration
+}
+
+// HlsSegmentLength returns the duration of an HLS segment, which may be shorter for the last segment.
+func HlsSegmentLength(d time.Duration, index int) time.Duration {
+	start := HlsSegmentStart(index)
+
+	if start >= d {
+		return 0
+	} else if remaining := d - start; remaining < HlsSegmentDuration {
+		return remaining
+	}
+
+	return HlsSegmentDuration
+}
+
+// HlsSegmentCommand returns the command for transcoding a single HLS segment with the software encoder.
+// The output timestamps are offset by the segment start time so that segments can be played back-to-back.
+func HlsSegmentCommand(fileName, segmentName string, d time.Duration, r Rendition, width, height, index int, opt Options) (*exec.Cmd, error) {
+	if fileName == "" {
+		return nil, fmt.Errorf("empty input filename")
+	} else if segmentName == "" {
+		return nil, fmt.Errorf("empty output filename")
+	} else if index < 0 || index >= HlsSegments(d) {
+		return nil, fmt.Errorf("invalid segment index %d", index)
+	}
+
+	start := fmt.Sprintf("%.3f", HlsSegmentStart(index).Seconds())
+	bitrate := fmt.Sprintf("%dk", r.Bitrate)
+
+	// Scale the shorter side to the rendition size, so that rotated videos keep their aspect ratio.
+	w, h := r.Resolution(width, height)
+	size := h
+
+	if w < h {
+		size = w
+	}
+
+	scale := fmt.Sprintf("scale='if(gt(iw,ih),-2,%d)':'if(gt(iw,ih),%d,-2)'", size, size)
+
+	return exec.Command(
+		opt.Bin,
+		"-ss", start,
+		"-i", fileName,
+		"-t", fmt.Sprintf("%.3f", HlsSegmentLength(d, index).Seconds()),
+		"-map", opt.MapVideo,
+		"-map", opt.MapAudio,
+		"-c:v", SoftwareEncoder.String(),
+		"-preset", "veryfast",
+		"-profile:v", "high",
+		"-pix_fmt", "yuv420p",
+		"-vf", scale,
+		"-b:v", bitrate,
+		"-maxrate", fmt.Sprintf("%dk", r.Bitrate*3/2),
+		"-bufsize", fmt.Sprintf("%dk", r.Bitrate*2),
+		"-c:a", "aac",
+		"-b:a", HlsAudioBitrate,
+		"-ac", "2",
+		"-output_ts_offset", start,
+		"-muxdelay", "0",
+		"-f", "mpegts",
+		"-y",
+		segmentName,
+	), nil
+}
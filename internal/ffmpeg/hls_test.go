@@ -0,0 +1,136 @@
+package ffmpeg
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFindRendition(t *testing.T) {
+	t.Run("720p", func(t *testing.T) {
+		r, ok := FindRendition("720p")
+		assert.True(t, ok)
+		assert.Equal(t, 720, r.Size)
+	})
+	t.Run("Invalid", func(t *testing.T) {
+		_, ok := FindRendition("480p")
+		assert.False(t, ok)
+	})
+}
+
+func TestVideoRenditions(t *testing.T) {
+	t.Run("4K", func(t *testing.T) {
+		assert.Len(t, VideoRenditions(3840, 2160), 4)
+	})
+	t.Run("Portrait", func(t *testing.T) {
+		result := VideoRenditions(1200, 1600)
+		assert.Len(t, result, 3)
+		assert.Equal(t, "1080p", result[2].Name)
+	})
+	t.Run("Small", func(t *testing.T) {
+		result := VideoRenditions(320, 240)
+		assert.Len(t, result, 1)
+		assert.Equal(t, "360p", result[0].Name)
+	})
+}
+
+func TestRendition_Resolution(t *testing.T) {
+	r, _ := FindRendition("720p")
+
+	t.Run("Landscape", func(t *testing.T) {
+		w, h := r.Resolution(3840, 2160)
+		assert.Equal(t, 1280, w)
+		assert.Equal(t, 720, h)
+	})
+	t.Run("Portrait", func(t *testing.T) {
+		w, h := r.Resolution(1200, 1600)
+		assert.Equal(t, 720, w)
+		assert.Equal(t, 960, h)
+	})
+	t.Run("NoUpscale", func(t *testing.T) {
+		w, h := r.Resolution(640, 360)
+		assert.Equal(t, 640, w)
+		assert.Equal(t, 360, h)
+	})
+	t.Run("Unknown", func(t *testing.T) {
+		w, h := r.Resolution(0, 0)
+		assert.Equal(t, 1280, w)
+		assert.Equal(t, 720, h)
+	})
+}
+
+func TestRendition_Codecs(t *testing.T) {
+	r, _ := FindRendition("1080p")
+	assert.Equal(t, "avc1.640028,mp4a.40.2", r.Codecs())
+	r, _ = FindRendition("2160p")
+	assert.Equal(t, "avc1.640033,mp4a.40.2", r.Codecs())
+}
+
+func TestHlsSegments(t *testing.T) {
+	assert.Equal(t, 0, HlsSegments(0))
+	assert.Equal(t, 1, HlsSegments(time.Second))
+	assert.Equal(t, 1, HlsSegments(6*time.Second))
+	assert.Equal(t, 3, HlsSegments(17*time.Second))
+}
+
+func TestHlsSegmentIndex(t *testing.T) {
+	assert.Equal(t, 0, HlsSegmentIndex("0.ts"))
+	assert.Equal(t, 12, HlsSegmentIndex("12.ts"))
+	assert.Equal(t, -1, HlsSegmentIndex("012.ts"))
+	assert.Equal(t, -1, HlsSegmentIndex("-1.ts"))
+	assert.Equal(t, -1, HlsSegmentIndex("1.mp4"))
+	assert.Equal(t, -1, HlsSegmentIndex("index.m3u8"))
+}
+
+func TestHlsSegmentLength(t *testing.T) {
+	d := 17 * time.Second
+	assert.Equal(t, 6*time.Second, HlsSegmentLength(d, 0))
+	assert.Equal(t, 5*time.Second, HlsSegmentLength(d, 2))
+	assert.Equal(t, time.Duration(0), HlsSegmentLength(d, 3))
+}
+
+func TestHlsMasterPlaylist(t *testing.T) {
+	result := HlsMasterPlaylist(1920, 1080)
+
+	assert.True(t, strings.HasPrefix(result, "#EXTM3U\n"))
+	assert.Contains(t, result, "RESOLUTION=640x360")
+	assert.Contains(t, result, "RESOLUTION=1920x1080")
+	assert.Contains(t, result, "1080p/index.m3u8\n")
+	assert.NotContains(t, result, "2160p")
+}
+
+func TestHlsMediaPlaylist(t *testing.T) {
+	result := HlsMediaPlaylist(17 * time.Second)
+
+	assert.Contains(t, result, "#EXT-X-PLAYLIST-TYPE:VOD\n")
+	assert.Contains(t, result, "#EXT-X-TARGETDURATION:6\n")
+	assert.Contains(t, result, "#EXTINF:6.000,\n0.ts\n")
+	assert.Contains(t, result, "#EXTINF:5.000,\n2.ts\n")
+	assert.NotContains(t, result, "3.ts")
+	assert.True(t, strings.HasSuffix(result, "#EXT-X-ENDLIST\n"))
+}
+
+func TestHlsSegmentCommand(t *testing.T) {
+	opt := Options{Bin: "ffmpeg", MapVideo: MapVideoDefault, MapAudio: MapAudioDefault}
+	r, _ := FindRendition("720p")
+
+	t.Run("Success", func(t *testing.T) {
+		cmd, err := HlsSegmentCommand("/video.mp4", "/cache/1.ts", 17*time.Second, r, 1920, 1080, 1, opt)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "ffmpeg -ss 6.000 -i /video.mp4 -t 6.000 -map 0:v:0 -map 0:a:0? -c:v libx264 -preset veryfast -profile:v high -pix_fmt yuv420p -vf scale='if(gt(iw,ih),-2,720)':'if(gt(iw,ih),720,-2)' -b:v 2800k -maxrate 4200k -bufsize 5600k -c:a aac -b:a 128k -ac 2 -output_ts_offset 6.000 -muxdelay 0 -f mpegts -y /cache/1.ts", cmd.String())
+	})
+	t.Run("InvalidIndex", func(t *testing.T) {
+		_, err := HlsSegmentCommand("/video.mp4", "/cache/3.ts", 17*time.Second, r, 1920, 1080, 3, opt)
+		assert.Error(t, err)
+	})
+	t.Run("EmptyFileName", func(t *testing.T) {
+		_, err := HlsSegmentCommand("", "/cache/0.ts", 17*time.Second, r, 1920, 1080, 0, opt)
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,69 @@
+package ffmpeg
+
+import (
+	"strings"
+
+	"github.com/photoprism/photoprism/pkg/clean"
+)
+
+// Preset represents a quality preset for transcoding videos with a software encoder.
+type Preset string
+
+// String returns the preset name as string.
+func (p Preset) String() string {
+	return string(p)
+}
+
+// Supported quality presets, faster presets result in larger files.
+const (
+	PresetFast     Preset = "fast"
+	PresetBalanced Preset = "balanced"
+	PresetQuality  Preset = "quality"
+)
+
+// Presets maps names to supported quality presets.
+var Presets = map[string]Preset{
+	"":                     PresetBalanced,
+	"default":              PresetBalanced,
+	string(PresetFast):     PresetFast,
+	string(PresetBalanced): PresetBalanced,
+	string(PresetQuality):  PresetQuality,
+	"best":                 PresetQuality,
+	"slow":                 PresetQuality,
+}
+
+// FindPreset finds a quality preset by name and falls back to the balanced preset if it is not supported.
+func FindPreset(s string) Preset {
+	if p, ok := Presets[strings.ToLower(strings.TrimSpace(s))]; ok {
+		return p
+	} else {
+		log.Warnf("ffmpeg: unsupported preset %s", clean.Log(s))
+	}
+
+	return PresetBalanced
+}
+
+// PresetArgs maps software encoders to the encoder speed and constant rate factor (CRF) of each preset.
+var PresetArgs = map[string]map[Preset][]string{
+	"libx265": {
+		PresetFast:     {"-preset", "fast", "-crf", "28"},
+		PresetBalanced: {"-preset", "medium", "-crf", "26"},
+		PresetQuality:  {"-preset", "slow", "-crf", "23"},
+	},
+	"libsvtav1": {
+		PresetFast:     {"-preset", "10", "-crf", "35"},
+		PresetBalanced: {"-preset", "8", "-crf", "32"},
+		PresetQuality:  {"-preset", "5", "-crf", "28"},
+	},
+}
+
+// Args returns the encoder arguments of the preset, or nil if the encoder has no presets.
+func (p Preset) Args(encoder string) []string {
+	if presets, ok := PresetArgs[encoder]; !ok {
+		return nil
+	} else if args, ok := presets[p]; ok {
+		return args
+	} else {
+		return presets[PresetBalanced]
+	}
+}
@@ -0,0 +1,21 @@
+package ffmpeg
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFindPreset(t *testing.T) {
+	assert.Equal(t, PresetBalanced, FindPreset(""))
+	assert.Equal(t, PresetFast, FindPreset("Fast"))
+	assert.Equal(t, PresetQuality, FindPreset("best"))
+	assert.Equal(t, PresetBalanced, FindPreset("xxx"))
+}
+
+func TestPreset_Args(t *testing.T) {
+	assert.Equal(t, []string{"-preset", "slow", "-crf", "23"}, PresetQuality.Args("libx265"))
+	assert.Equal(t, []string{"-preset", "10", "-crf", "35"}, PresetFast.Args("libsvtav1"))
+	assert.Equal(t, []string{"-preset", "8", "-crf", "32"}, Preset("xxx").Args("libsvtav1"))
+	assert.Nil(t, PresetBalanced.Args("hevc_nvenc"))
+}
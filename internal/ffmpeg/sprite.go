@@ -0,0 +1,142 @@
+package ffmpeg
+
+import (
+	"fmt"
+	"math"
+	"os/exec"
+	"strings"
+	"time"
+)
+
+// Sprite sheet layout defaults.
+var (
+	SpriteColumns   = 10
+	SpriteMaxFrames = 100
+	SpriteFrameSize = 160
+	SpriteInterval  = 2 * time.Second
+)
+
+// Sprite represents a sprite sheet with video frames taken at regular intervals,
+// e.g. for showing scrubbing previews.
+type Sprite struct {
+	Interval time.Duration
+	Frames   int
+	Columns  int
+	Rows     int
+	Width    int
+	Height   int
+}
+
+// NewSprite returns the sprite sheet layout for a video with the specified duration and resolution.
+// Longer videos use longer intervals, so that the number of frames does not exceed the limit.
+func NewSprite(d time.Duration, width, height int) Sprite {
+	s := Sprite{Interval: SpriteInterval}
+
+	if d <= 0 {
+		return s
+	}
+
+	if frames := int(math.Ceil(float64(d) / float64(s.Interval))); frames > SpriteMaxFrames {
+		s.Interval = time.Duration(math.Ceil(d.Seconds()/float64(SpriteMaxFrames))) * time.Second
+	}
+
+	s.Frames = int(math.Ceil(float64(d) / float64(s.Interval)))
+
+	if s.Frames < 1 {
+		s.Frames = 1
+	}
+
+	s.Columns = SpriteColumns
+
+	if s.Frames < s.Columns {
+		s.Columns = s.Frames
+	}
+
+	s.Rows = int(math.Ceil(float64(s.Frames) / float64(s.Columns)))
+
+	// The longer side of each frame matches the frame size.
+	s.Width, s.Height = SpriteFrameSize, SpriteFrameSize
+
+	switch {
+	case width <= 0 || height <= 0:
+		s.Height = even(float64(SpriteFrameSize) * 9 / 16)
+	case width > height:
+		s.Height = even(float64(SpriteFrameSize) * float64(height) / float64(width))
+	case height > width:
+		s.Width = even(float64(SpriteFrameSize) * float64(width) / float64(height))
+	}
+
+	return s
+}
+
+// Empty checks if the sprite sheet has no frames.
+func (s Sprite) Empty() bool {
+	return s.Frames <= 0
+}
+
+// Position returns the pixel offset of a frame in the sprite sheet.
+func (s Sprite) Position(frame int) (x, y int) {
+	if s.Columns <= 0 {
+		return 0, 0
+	}
+
+	return (frame % s.Columns) * s.Width, (frame / s.Columns) * s.Height
+}
+
+// VTT returns a WebVTT thumbnail track, where each cue refers to a frame in the sprite sheet image
+// with the specified URL, e.g. "sprite.jpg#xywh=160,0,160,90".
+func (s Sprite) VTT(d time.Duration, imageUrl string) string {
+	var b strings.Builder
+
+	b.WriteString("WEBVTT\n")
+
+	for i := 0; i < s.Frames; i++ {
+		start := time.Duration(i) * s.Interval
+		end := start + s.Interval
+
+		if end > d {
+			end = d
+		}
+
+		x, y := s.Position(i)
+
+		b.WriteString(fmt.Sprintf("\n%s --> %s\n%s#xywh=%d,%d,%d,%d\n", vttTime(start), vttTime(end), imageUrl, x, y, s.Width, s.Height))
+	}
+
+	return b.String()
+}
+
+// vttTime formats a duration as WebVTT timestamp, e.g. "00:01:02.500".
+func vttTime(d time.Duration) string {
+	ms := d.Milliseconds()
+
+	return fmt.Sprintf("%02d:%02d:%02d.%03d", ms/3600000, (ms/60000)%60, (ms/1000)%60, ms%1000)
+}
+
+// SpriteCommand returns the command for creating a sprite sheet image from the keyframes of a video.
+// Since only keyframes are decoded, this is much faster than extracting frames at exact positions.
+func SpriteCommand(fileName, spriteName string, s Sprite, bin string) (*exec.Cmd, error) {
+	if fileName == "" {
+		return nil, fmt.Errorf("empty input filename")
+	} else if spriteName == "" {
+		return nil, fmt.Errorf("empty output filename")
+	} else if s.Empty() {
+		return nil, fmt.Errorf("sprite has no frames")
+	}
+
+	filter := fmt.Sprintf("fps=1/%d,scale=%d:%d,tile=%dx%d", int(s.Interval.Seconds()), s.Width, s.Height, s.Columns, s.Rows)
+
+	return exec.Command(
+		bin,
+		"-skip_frame", "nokey",
+		"-i", fileName,
+		"-an",
+		"-sn",
+		"-vf", filter,
+		"-frames:v", "1",
+		"-q:v", "5",
+		"-f", "image2",
+		"-y",
+		spriteName,
+	), nil
+}
@@ -0,0 +1,88 @@
+package ffmpeg
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewSprite(t *testing.T) {
+	t.Run("Short", func(t *testing.T) {
+		s := NewSprite(17*time.Second, 1920, 1080)
+		assert.Equal(t, 2*time.Second, s.Interval)
+		assert.Equal(t, 9, s.Frames)
+		assert.Equal(t, 9, s.Columns)
+		assert.Equal(t, 1, s.Rows)
+		assert.Equal(t, 160, s.Width)
+		assert.Equal(t, 90, s.Height)
+	})
+	t.Run("Long", func(t *testing.T) {
+		s := NewSprite(time.Hour, 1080, 1920)
+		assert.Equal(t, 36*time.Second, s.Interval)
+		assert.Equal(t, 100, s.Frames)
+		assert.Equal(t, 10, s.Columns)
+		assert.Equal(t, 10, s.Rows)
+		assert.Equal(t, 90, s.Width)
+		assert.Equal(t, 160, s.Height)
+	})
+	t.Run("UnknownSize", func(t *testing.T) {
+		s := NewSprite(time.Minute, 0, 0)
+		assert.Equal(t, 30, s.Frames)
+		assert.Equal(t, 3, s.Rows)
+		assert.Equal(t, 160, s.Width)
+		assert.Equal(t, 90, s.Height)
+	})
+	t.Run("NoDuration", func(t *testing.T) {
+		assert.True(t, NewSprite(0, 1920, 1080).Empty())
+	})
+}
+
+func TestSprite_Position(t *testing.T) {
+	s := NewSprite(time.Minute, 1920, 1080)
+
+	x, y := s.Position(0)
+	assert.Equal(t, 0, x)
+	assert.Equal(t, 0, y)
+
+	x, y = s.Position(12)
+	assert.Equal(t, 320, x)
+	assert.Equal(t, 90, y)
+}
+
+func TestSprite_VTT(t *testing.T) {
+	s := NewSprite(5*time.Second, 1920, 1080)
+	result := s.VTT(5*time.Second, "sprite.jpg")
+
+	assert.True(t, strings.HasPrefix(result, "WEBVTT\n"))
+	assert.Contains(t, result, "\n00:00:00.000 --> 00:00:02.000\nsprite.jpg#xywh=0,0,160,90\n")
+	assert.Contains(t, result, "\n00:00:04.000 --> 00:00:05.000\nsprite.jpg#xywh=320,0,160,90\n")
+}
+
+func TestVttTime(t *testing.T) {
+	assert.Equal(t, "00:00:00.000", vttTime(0))
+	assert.Equal(t, "01:02:03.500", vttTime(time.Hour+2*time.Minute+3500*time.Millisecond))
+}
+
+func TestSpriteCommand(t *testing.T) {
+	s := NewSprite(17*time.Second, 1920, 1080)
+
+	t.Run("Success", func(t *testing.T) {
+		cmd, err := SpriteCommand("/video.mp4", "/sprite.jpg", s, "ffmpeg")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "ffmpeg -skip_frame nokey -i /video.mp4 -an -sn -vf fps=1/2,scale=160:90,tile=9x1 -frames:v 1 -q:v 5 -f image2 -y /sprite.jpg", cmd.String())
+	})
+	t.Run("Empty", func(t *testing.T) {
+		_, err := SpriteCommand("/video.mp4", "/sprite.jpg", Sprite{}, "ffmpeg")
+		assert.Error(t, err)
+	})
+	t.Run("EmptyFileName", func(t *testing.T) {
+		_, err := SpriteCommand("", "/sprite.jpg", s, "ffmpeg")
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,85 @@
+package ffmpeg
+
+import (
+	"fmt"
+	"os/exec"
+)
+
+// TranscodeCommand returns the command for converting video files to the codec specified in the options,
+// using the encoder of the same platform as the configured AVC encoder.
+func TranscodeCommand(fileName, outName string, opt Options) (result *exec.Cmd, useMutex bool, err error) {
+	switch opt.Codec {
+	case CodecHevc, CodecAv1:
+	default:
+		return AvcConvertCommand(fileName, outName, opt)
+	}
+
+	if fileName == "" {
+		return nil, false, fmt.Errorf("empty input filename")
+	} else if outName == "" {
+		return nil, false, fmt.Errorf("empty output filename")
+	}
+
+	// Don't transcode more than one video at the same time.
+	useMutex = true
+
+	encoder := opt.Codec.Encoder(opt.Encoder)
+
+	// Display encoder info.
+	if encoder != opt.Codec.Encoder(SoftwareEncoder) {
+		log.Infof("convert: ffmpeg encoder %s selected", encoder)
+	}
+
+	var args []string
+
+	// Input options.
+	switch encoder {
+	case "hevc_qsv", "av1_qsv":
+		args = append(args, "-qsv_device", "/dev/dri/renderD128")
+	case "hevc_vaapi", "av1_vaapi":
+		args = append(args, "-hwaccel", "vaapi")
+	case "hevc_nvenc", "av1_nvenc":
+		args = append(args, "-hwaccel", "auto")
+	}
+
+	args = append(args,
+		"-i", fileName,
+		"-map", opt.MapVideo,
+		"-map", opt.MapAudio,
+		"-c:v", encoder,
+	)
+
+	// Encoder options.
+	if presetArgs := opt.Preset.Args(encoder); len(presetArgs) > 0 {
+		// Software encoders use a constant quality, limited by the maximum bitrate.
+		args = append(args, presetArgs...)
+		args = append(args, "-maxrate", opt.Bitrate, "-bufsize", opt.Bitrate)
+	} else {
+		args = append(args, "-b:v", opt.Bitrate)
+	}
+
+	switch encoder {
+	case "hevc_vaapi", "av1_vaapi":
+		args = append(args, "-vf", "format=nv12,hwupload")
+	case "hevc_qsv", "av1_qsv":
+		args = append(args, "-vf", "format=nv12")
+	default:
+		args = append(args, "-pix_fmt", "yuv420p")
+	}
+
+	// Use the hvc1 tag so that HEVC videos can be played on Apple devices.
+	if opt.Codec == CodecHevc {
+		args = append(args, "-tag:v", "hvc1")
+	}
+
+	args = append(args,
+		"-c:a", "aac",
+		"-max_muxing_queue_size", "1024",
+		"-movflags", "faststart",
+		"-f", "mp4",
+		"-y",
+		outName,
+	)
+
+	return exec.Command(opt.Bin, args...), useMutex, nil
+}
@@ -0,0 +1,76 @@
+package ffmpeg
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTranscodeCommand(t *testing.T) {
+	opt := Options{
+		Bin:      "ffmpeg",
+		Encoder:  SoftwareEncoder,
+		Preset:   PresetBalanced,
+		Bitrate:  "8M",
+		MapVideo: MapVideoDefault,
+		MapAudio: MapAudioDefault,
+	}
+
+	t.Run("Avc", func(t *testing.T) {
+		o := opt
+		o.Codec = CodecAvc
+		cmd, useMutex, err := TranscodeCommand("/video.mov", "/video.mov.avc", o)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.True(t, useMutex)
+		assert.Contains(t, cmd.String(), "-c:v libx264")
+	})
+	t.Run("Hevc", func(t *testing.T) {
+		o := opt
+		o.Codec = CodecHevc
+		cmd, useMutex, err := TranscodeCommand("/video.mov", "/video.mov.hevc", o)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.True(t, useMutex)
+		assert.Equal(t, "ffmpeg -i /video.mov -map 0:v:0 -map 0:a:0? -c:v libx265 -preset medium -crf 26 -maxrate 8M -bufsize 8M -pix_fmt yuv420p -tag:v hvc1 -c:a aac -max_muxing_queue_size 1024 -movflags faststart -f mp4 -y /video.mov.hevc", cmd.String())
+	})
+	t.Run("Av1Nvidia", func(t *testing.T) {
+		o := opt
+		o.Codec = CodecAv1
+		o.Encoder = NvidiaEncoder
+		cmd, _, err := TranscodeCommand("/video.mov", "/video.mov.av1", o)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "ffmpeg -hwaccel auto -i /video.mov -map 0:v:0 -map 0:a:0? -c:v av1_nvenc -b:v 8M -pix_fmt yuv420p -c:a aac -max_muxing_queue_size 1024 -movflags faststart -f mp4 -y /video.mov.av1", cmd.String())
+	})
+	t.Run("HevcVAAPI", func(t *testing.T) {
+		o := opt
+		o.Codec = CodecHevc
+		o.Encoder = VAAPIEncoder
+		cmd, _, err := TranscodeCommand("/video.mov", "/video.mov.hevc", o)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Contains(t, cmd.String(), "-hwaccel vaapi -i /video.mov")
+		assert.Contains(t, cmd.String(), "-c:v hevc_vaapi -b:v 8M -vf format=nv12,hwupload")
+	})
+	t.Run("EmptyFileName", func(t *testing.T) {
+		o := opt
+		o.Codec = CodecHevc
+		_, _, err := TranscodeCommand("", "/video.mov.hevc", o)
+		assert.Error(t, err)
+		_, _, err = TranscodeCommand("/video.mov", "", o)
+		assert.Error(t, err)
+	})
+}
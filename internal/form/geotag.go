@@ -0,0 +1,8 @@
+package form
+
+// Geotag represents options for setting the location of photos based on uploaded tracks.
+type Geotag struct {
+	Offset int  `form:"offset" json:"offset"` // Camera clock offset in seconds.
+	MaxGap int  `form:"maxGap" json:"maxGap"` // Maximum time between recorded positions in seconds.
+	Store  bool `form:"store" json:"store"`   // Store tracks for display on the map.
+}
@@ -39,7 +39,7 @@ func Serialize(f interface{}, all bool) string {
 			switch t := fieldValue.Interface().(type) {
 			case time.Time:
 				if val := fieldValue.Interface().(time.Time); !val.IsZero() {
-					if val.Hour() == 0 && val.Minute() == 0 {
+					if val.Hour() == 0 && val.Minute() == 0 && val.Second() == 0 {
 						q = append(q, fmt.Sprintf("%s:%s", fieldName, val.Format("2006-01-02")))
 					} else {
 						q = append(q, fmt.Sprintf("%s:\"%s\"", fieldName, val.String()))
@@ -0,0 +1,7 @@
+package form
+
+// ServiceConflict represents a request to resolve a sync conflict by keeping the local version, the remote version, or both.
+type ServiceConflict struct {
+	RemoteName string `json:"RemoteName"`
+	Keep       string `json:"Keep"`
+}
@@ -0,0 +1,8 @@
+package form
+
+// ResumableUpload represents a request to start or resume a chunked file upload.
+type ResumableUpload struct {
+	Name string `json:"name"`
+	Size int64  `json:"size"`
+	Hash string `json:"hash"`
+}
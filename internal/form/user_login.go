@@ -6,10 +6,11 @@ import (
 
 // Login represents a login form.
 type Login struct {
-	UserName  string `json:"username,omitempty"`
-	UserEmail string `json:"email,omitempty"`
-	Password  string `json:"password,omitempty"`
-	AuthToken string `json:"token,omitempty"`
+	UserName     string `json:"username,omitempty"`
+	UserEmail    string `json:"email,omitempty"`
+	Password     string `json:"password,omitempty"`
+	AuthToken    string `json:"token,omitempty"`
+	LinkPassword string `json:"link_password,omitempty"`
 }
 
 // Username returns the sanitized username in lowercase.
@@ -0,0 +1,59 @@
+package gazetteer
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// City represents a populated place in the dataset.
+type City struct {
+	ID         int
+	Name       string
+	Lat        float64
+	Lng        float64
+	Country    string
+	Admin1     string
+	Population int
+	District   bool
+}
+
+// ParseCity parses a line in the GeoNames cities format, see https://download.geonames.org/export/dump/.
+func ParseCity(line string) (c City, err error) {
+	fields := strings.Split(line, "\t")
+
+	if len(fields) < 15 {
+		return c, fmt.Errorf("expected 15 or more fields, found %d", len(fields))
+	} else if fields[6] != "P" {
+		return c, fmt.Errorf("feature class %s is not a populated place", fields[6])
+	}
+
+	if c.ID, err = strconv.Atoi(fields[0]); err != nil {
+		return c, fmt.Errorf("invalid id %s", fields[0])
+	} else if c.Lat, err = strconv.ParseFloat(fields[4], 64); err != nil {
+		return c, fmt.Errorf("invalid latitude %s", fields[4])
+	} else if c.Lng, err = strconv.ParseFloat(fields[5], 64); err != nil {
+		return c, fmt.Errorf("invalid longitude %s", fields[5])
+	}
+
+	c.Name = strings.TrimSpace(fields[1])
+	c.Country = strings.ToLower(strings.TrimSpace(fields[8]))
+	c.Admin1 = strings.TrimSpace(fields[10])
+	c.Population, _ = strconv.Atoi(fields[14])
+
+	// Sections of populated places are used as city districts.
+	c.District = fields[7] == "PPLX"
+
+	if c.Name == "" {
+		return c, fmt.Errorf("empty name")
+	} else if len(c.Country) != 2 {
+		return c, fmt.Errorf("invalid country code %s", fields[8])
+	}
+
+	return c, nil
+}
+
+// AdminKey returns the key of the first-level administrative division, e.g. "DE.16".
+func (c City) AdminKey() string {
+	return strings.ToUpper(c.Country) + "." + c.Admin1
+}
@@ -0,0 +1,48 @@
+package gazetteer
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseCity(t *testing.T) {
+	t.Run("Berlin", func(t *testing.T) {
+		c, err := ParseCity("2950159\tBerlin\tBerlin\tBerlim,Berlin\t52.52437\t13.41053\tP\tPPLC\tDE\t\t16\t00\t11000\t11000000\t3426354\t74\t43\tEurope/Berlin\t2022-12-06")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, 2950159, c.ID)
+		assert.Equal(t, "Berlin", c.Name)
+		assert.Equal(t, 52.52437, c.Lat)
+		assert.Equal(t, 13.41053, c.Lng)
+		assert.Equal(t, "de", c.Country)
+		assert.Equal(t, "16", c.Admin1)
+		assert.Equal(t, 3426354, c.Population)
+		assert.False(t, c.District)
+		assert.Equal(t, "DE.16", c.AdminKey())
+	})
+	t.Run("District", func(t *testing.T) {
+		c, err := ParseCity("6545310\tMitte\tMitte\t\t52.52003\t13.40489\tP\tPPLX\tDE\t\t16\t\t\t\t98000\t\t34\tEurope/Berlin\t2022-01-01")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.True(t, c.District)
+	})
+	t.Run("NotPopulated", func(t *testing.T) {
+		_, err := ParseCity("2950157\tLand Berlin\tLand Berlin\t\t52.5\t13.41667\tA\tADM1\tDE\t\t16\t\t\t\t3574830\t\t34\tEurope/Berlin\t2022-01-01")
+		assert.Error(t, err)
+	})
+	t.Run("InvalidLatitude", func(t *testing.T) {
+		_, err := ParseCity("1\tFoo\tFoo\t\tabc\t13.4\tP\tPPL\tDE\t\t16\t\t\t\t1000\t\t34\tEurope/Berlin\t2022-01-01")
+		assert.Error(t, err)
+	})
+	t.Run("TooFewFields", func(t *testing.T) {
+		_, err := ParseCity("2950159\tBerlin")
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,131 @@
+package gazetteer
+
+import (
+	"archive/zip"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/photoprism/photoprism/pkg/clean"
+	"github.com/photoprism/photoprism/pkg/fs"
+)
+
+// Download URLs of the dataset files, the cities file may be a zip archive.
+var (
+	CitiesUrl = "https://download.geonames.org/export/dump/cities1000.zip"
+	AdminUrl  = "https://download.geonames.org/export/dump/admin1CodesASCII.txt"
+)
+
+// Download fetches the dataset files and saves them in the specified folder.
+func Download(dir string) error {
+	if dir == "" {
+		return fmt.Errorf("gazetteer: dataset path is empty")
+	} else if err := os.MkdirAll(dir, fs.ModeDir); err != nil {
+		return err
+	}
+
+	start := time.Now()
+
+	if err := download(CitiesUrl, filepath.Join(dir, CitiesFile)); err != nil {
+		return err
+	} else if err = download(AdminUrl, filepath.Join(dir, AdminFile)); err != nil {
+		return err
+	}
+
+	// Reload the dataset on next use.
+	Default.Reset()
+
+	log.Infof("gazetteer: downloaded dataset to %s [%s]", clean.Log(dir), time.Since(start))
+
+	return nil
+}
+
+// download fetches a file and saves it, zip archives are extracted if needed.
+func download(url, fileName string) error {
+	log.Infof("gazetteer: downloading %s", clean.Log(url))
+
+	client := &http.Client{Timeout: 10 * time.Minute}
+
+	r, err := client.Get(url)
+
+	if err != nil {
+		return fmt.Errorf("gazetteer: %s", err)
+	}
+
+	defer r.Body.Close()
+
+	if r.StatusCode >= 400 {
+		return fmt.Errorf("gazetteer: request failed with code %d", r.StatusCode)
+	}
+
+	tmpName := fileName + ".download"
+
+	defer os.Remove(tmpName)
+
+	if err = writeFile(tmpName, r.Body); err != nil {
+		return err
+	}
+
+	if !strings.HasSuffix(strings.ToLower(url), ".zip") {
+		return os.Rename(tmpName, fileName)
+	}
+
+	return extract(tmpName, filepath.Base(fileName), fileName)
+}
+
+// extract copies the file with the specified name from a zip archive.
+func extract(zipName, name, fileName string) error {
+	z, err := zip.OpenReader(zipName)
+
+	if err != nil {
+		return fmt.Errorf("gazetteer: %s", err)
+	}
+
+	defer z.Close()
+
+	for _, f := range z.File {
+		if filepath.Base(f.Name) != name {
+			continue
+		}
+
+		rc, err := f.Open()
+
+		if err != nil {
+			return fmt.Errorf("gazetteer: %s", err)
+		}
+
+		defer rc.Close()
+
+		tmpName := fileName + ".tmp"
+
+		defer os.Remove(tmpName)
+
+		if err = writeFile(tmpName, rc); err != nil {
+			return err
+		}
+
+		return os.Rename(tmpName, fileName)
+	}
+
+	return fmt.Errorf("gazetteer: %s not found in archive", clean.Log(name))
+}
+
+// writeFile saves the data read from r to a file.
+func writeFile(fileName string, r io.Reader) error {
+	f, err := os.OpenFile(fileName, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, fs.ModeFile)
+
+	if err != nil {
+		return err
+	}
+
+	if _, err = io.Copy(f, r); err != nil {
+		_ = f.Close()
+		return fmt.Errorf("gazetteer: %s", err)
+	}
+
+	return f.Close()
+}
@@ -0,0 +1,89 @@
+package gazetteer
+
+import (
+	"archive/zip"
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDownload(t *testing.T) {
+	cities, err := os.ReadFile(filepath.Join("testdata", CitiesFile))
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	admin, err := os.ReadFile(filepath.Join("testdata", AdminFile))
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var archive bytes.Buffer
+
+	zw := zip.NewWriter(&archive)
+
+	if w, err := zw.Create(CitiesFile); err != nil {
+		t.Fatal(err)
+	} else if _, err = w.Write(cities); err != nil {
+		t.Fatal(err)
+	} else if err = zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/cities1000.zip":
+			_, _ = w.Write(archive.Bytes())
+		case "/admin1CodesASCII.txt":
+			_, _ = w.Write(admin)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+
+	defer server.Close()
+
+	citiesUrl, adminUrl := CitiesUrl, AdminUrl
+
+	defer func() {
+		CitiesUrl, AdminUrl = citiesUrl, adminUrl
+	}()
+
+	t.Run("Success", func(t *testing.T) {
+		CitiesUrl = server.URL + "/cities1000.zip"
+		AdminUrl = server.URL + "/admin1CodesASCII.txt"
+
+		dir := t.TempDir()
+
+		if err := Download(dir); err != nil {
+			t.Fatal(err)
+		}
+
+		result, err := os.ReadFile(filepath.Join(dir, CitiesFile))
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, cities, result)
+		assert.FileExists(t, filepath.Join(dir, AdminFile))
+		assert.NoFileExists(t, filepath.Join(dir, CitiesFile+".download"))
+
+		g := New()
+		assert.NoError(t, g.Load(dir))
+	})
+	t.Run("NotFound", func(t *testing.T) {
+		CitiesUrl = server.URL + "/missing.zip"
+		assert.Error(t, Download(t.TempDir()))
+	})
+	t.Run("EmptyPath", func(t *testing.T) {
+		assert.Error(t, Download(""))
+	})
+}
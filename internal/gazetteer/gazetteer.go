@@ -0,0 +1,59 @@
+/*
+Package gazetteer provides offline reverse geocoding based on a local GeoNames dataset.
+
+Copyright (c) 2018 - 2023 PhotoPrism UG. All rights reserved.
+
+	This program is free software: you can redistribute it and/or modify
+	it under Version 3 of the GNU Affero General Public License (the "AGPL"):
+	<https://docs.photoprism.app/license/agpl>
+
+	This program is distributed in the hope that it will be useful,
+	but WITHOUT ANY WARRANTY; without even the implied warranty of
+	MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+	GNU Affero General Public License for more details.
+
+	The AGPL is supplemented by our Trademark and Brand Guidelines,
+	which describe how our Brand Assets may be used:
+	<https://www.photoprism.app/trademark>
+
+Feel free to send an email to hello@photoprism.app if you have questions,
+want to support our work, or just want to say hello.
+
+Additional information can be found in our Developer Guide:
+<https://docs.photoprism.app/developer-guide/>
+*/
+package gazetteer
+
+import (
+	"github.com/photoprism/photoprism/internal/event"
+	"github.com/photoprism/photoprism/internal/maps"
+)
+
+var log = event.Log
+
+// ApiName is the geocoder API name.
+const ApiName = "gazetteer"
+
+// Dataset file names in the GeoNames tab-separated text format.
+const (
+	CitiesFile = "cities1000.txt"
+	AdminFile  = "admin1CodesASCII.txt"
+)
+
+// Path specifies the folder that contains the dataset files.
+var Path = ""
+
+// MaxDistance specifies the maximum distance in km between a location and the nearest populated place.
+var MaxDistance = 50.0
+
+// DistrictDistance specifies the maximum distance in km between a location and the nearest city district.
+var DistrictDistance = 3.0
+
+// Default is the gazetteer used for reverse geocoding, the dataset is loaded on first use.
+var Default = New()
+
+func init() {
+	maps.Geocoders[ApiName] = maps.GeocoderFunc(func(id string) (maps.LocationSource, error) {
+		return FindLocation(id)
+	})
+}
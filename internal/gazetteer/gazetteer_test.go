@@ -0,0 +1,19 @@
+package gazetteer
+
+import (
+	"os"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+)
+
+func TestMain(m *testing.M) {
+	log = logrus.StandardLogger()
+	log.SetLevel(logrus.TraceLevel)
+
+	Path = "testdata"
+
+	code := m.Run()
+
+	os.Exit(code)
+}
@@ -0,0 +1,307 @@
+package gazetteer
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/photoprism/photoprism/pkg/clean"
+	"github.com/photoprism/photoprism/pkg/fs"
+	"github.com/photoprism/photoprism/pkg/s2"
+)
+
+// earthRadius is the mean radius of the earth in km.
+const earthRadius = 6371.0
+
+// cellKey identifies a one by one degree grid cell.
+type cellKey struct {
+	Lat int
+	Lng int
+}
+
+// Gazetteer represents a searchable index of populated places.
+type Gazetteer struct {
+	mu     sync.RWMutex
+	path   string
+	cities []City
+	admin  map[string]string
+	grid   map[cellKey][]int
+}
+
+// New returns a new, empty gazetteer.
+func New() *Gazetteer {
+	return &Gazetteer{}
+}
+
+// Loaded checks if a dataset has been loaded.
+func (g *Gazetteer) Loaded() bool {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	return g.grid != nil
+}
+
+// Len returns the number of indexed places.
+func (g *Gazetteer) Len() int {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	return len(g.cities)
+}
+
+// Reset removes the loaded dataset, so that it is loaded again on next use.
+func (g *Gazetteer) Reset() {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	g.path = ""
+	g.cities = nil
+	g.admin = nil
+	g.grid = nil
+}
+
+// Load reads the dataset files from the specified folder, unless they have already been loaded.
+func (g *Gazetteer) Load(dir string) error {
+	if dir == "" {
+		return errors.New("gazetteer: dataset path is empty")
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if g.grid != nil && g.path == dir {
+		return nil
+	}
+
+	start := time.Now()
+
+	citiesName := filepath.Join(dir, CitiesFile)
+
+	if !fs.FileExistsNotEmpty(citiesName) {
+		return fmt.Errorf("gazetteer: %s not found, run 'photoprism places download' to download it", clean.Log(citiesName))
+	}
+
+	admin, err := readAdmin(filepath.Join(dir, AdminFile))
+
+	if err != nil {
+		return err
+	}
+
+	cities, err := readCities(citiesName)
+
+	if err != nil {
+		return err
+	}
+
+	grid := make(map[cellKey][]int, 1<<14)
+
+	for i, c := range cities {
+		k := gridKey(c.Lat, c.Lng)
+		grid[k] = append(grid[k], i)
+	}
+
+	g.path = dir
+	g.cities = cities
+	g.admin = admin
+	g.grid = grid
+
+	log.Infof("gazetteer: indexed %d places [%s]", len(cities), time.Since(start))
+
+	return nil
+}
+
+// readCities reads populated places from a file in the GeoNames cities format.
+func readCities(fileName string) (result []City, err error) {
+	f, err := os.Open(fileName)
+
+	if err != nil {
+		return result, err
+	}
+
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+
+	// The alternate names column may exceed the default buffer size.
+	scanner.Buffer(make([]byte, 64*1024), 4*1024*1024)
+
+	for scanner.Scan() {
+		line := scanner.Text()
+
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		if c, parseErr := ParseCity(line); parseErr == nil {
+			result = append(result, c)
+		}
+	}
+
+	if err = scanner.Err(); err != nil {
+		return result, fmt.Errorf("gazetteer: %s while reading %s", err, clean.Log(filepath.Base(fileName)))
+	} else if len(result) == 0 {
+		return result, fmt.Errorf("gazetteer: %s contains no places", clean.Log(filepath.Base(fileName)))
+	}
+
+	return result, nil
+}
+
+// readAdmin reads the names of first-level administrative divisions, e.g. states, if the file exists.
+func readAdmin(fileName string) (map[string]string, error) {
+	result := make(map[string]string)
+
+	if !fs.FileExists(fileName) {
+		log.Debugf("gazetteer: %s not found, state names will be missing", clean.Log(filepath.Base(fileName)))
+		return result, nil
+	}
+
+	f, err := os.Open(fileName)
+
+	if err != nil {
+		return result, err
+	}
+
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+
+	for scanner.Scan() {
+		fields := strings.Split(scanner.Text(), "\t")
+
+		if len(fields) < 2 || fields[0] == "" {
+			continue
+		}
+
+		result[strings.ToUpper(fields[0])] = strings.TrimSpace(fields[1])
+	}
+
+	return result, scanner.Err()
+}
+
+// gridKey returns the grid cell key for the specified coordinates.
+func gridKey(lat, lng float64) cellKey {
+	return cellKey{Lat: int(math.Floor(lat)), Lng: wrapLng(int(math.Floor(lng)))}
+}
+
+// wrapLng normalizes a grid cell longitude to the range from -180 to 179.
+func wrapLng(lng int) int {
+	return ((lng+180)%360+360)%360 - 180
+}
+
+// Distance returns the great-circle distance between two coordinates in km.
+func Distance(lat1, lng1, lat2, lng2 float64) float64 {
+	const rad = math.Pi / 180
+
+	dLat := (lat2 - lat1) * rad
+	dLng := (lng2 - lng1) * rad
+
+	a := math.Sin(dLat/2)*math.Sin(dLat/2) + math.Cos(lat1*rad)*math.Cos(lat2*rad)*math.Sin(dLng/2)*math.Sin(dLng/2)
+
+	return 2 * earthRadius * math.Asin(math.Min(1, math.Sqrt(a)))
+}
+
+// Nearest returns the nearest city and city district within the maximum distance, if any.
+func (g *Gazetteer) Nearest(lat, lng float64) (city, district *City) {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	if g.grid == nil {
+		return nil, nil
+	}
+
+	// Number of grid cells to search in each direction.
+	latCells := int(math.Ceil(MaxDistance / 111.2))
+	lngCells := 180
+
+	if c := math.Cos(lat * math.Pi / 180); c > 0.01 {
+		lngCells = int(math.Min(180, math.Ceil(MaxDistance/(111.2*c))))
+	}
+
+	cityDist, districtDist := MaxDistance, DistrictDistance
+	center := gridKey(lat, lng)
+
+	for y := center.Lat - latCells; y <= center.Lat+latCells; y++ {
+		for x := center.Lng - lngCells; x <= center.Lng+lngCells; x++ {
+			for _, i := range g.grid[cellKey{Lat: y, Lng: wrapLng(x)}] {
+				c := &g.cities[i]
+				d := Distance(lat, lng, c.Lat, c.Lng)
+
+				if c.District {
+					if d <= districtDist {
+						district, districtDist = c, d
+					}
+				} else if d <= cityDist {
+					city, cityDist = c, d
+				}
+			}
+		}
+	}
+
+	// Districts without a nearby city are used as city.
+	if city == nil && district != nil {
+		return district, nil
+	} else if city != nil && district != nil && city.Country != district.Country {
+		return city, nil
+	}
+
+	return city, district
+}
+
+// FindLocation returns the location details for an S2 cell ID.
+func (g *Gazetteer) FindLocation(id string) (result Location, err error) {
+	id = s2.NormalizeToken(id)
+
+	if len(id) == 0 {
+		return result, fmt.Errorf("empty cell id")
+	} else if n := len(id); n < 4 || n > 16 {
+		return result, fmt.Errorf("invalid cell id %s", clean.Log(id))
+	}
+
+	lat, lng := s2.LatLng(id)
+
+	if lat == 0.0 || lng == 0.0 {
+		return result, fmt.Errorf("skipping lat %f, lng %f", lat, lng)
+	}
+
+	city, district := g.Nearest(lat, lng)
+
+	if city == nil {
+		return result, fmt.Errorf("no result for %s", id)
+	}
+
+	result = Location{
+		ID:         id,
+		LocLat:     lat,
+		LocLng:     lng,
+		LocPlaceID: fmt.Sprintf("%s:gn%d", city.Country, city.ID),
+		LocCity:    city.Name,
+		LocCountry: city.Country,
+	}
+
+	g.mu.RLock()
+	result.LocState = g.admin[city.AdminKey()]
+	g.mu.RUnlock()
+
+	if district != nil {
+		result.LocPlaceID = fmt.Sprintf("%s-%d", result.LocPlaceID, district.ID)
+		result.LocDistrict = district.Name
+	}
+
+	return result, nil
+}
+
+// FindLocation returns the location details for an S2 cell ID based on the default gazetteer,
+// and loads the dataset from the configured path if needed.
+func FindLocation(id string) (result Location, err error) {
+	if err = Default.Load(Path); err != nil {
+		return result, err
+	}
+
+	return Default.FindLocation(id)
+}
@@ -0,0 +1,140 @@
+package gazetteer
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/pkg/s2"
+)
+
+func TestGazetteer_Load(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		g := New()
+
+		if err := g.Load("testdata"); err != nil {
+			t.Fatal(err)
+		}
+
+		assert.True(t, g.Loaded())
+		assert.Equal(t, 6, g.Len())
+
+		g.Reset()
+
+		assert.False(t, g.Loaded())
+	})
+	t.Run("NotFound", func(t *testing.T) {
+		g := New()
+		assert.Error(t, g.Load(t.TempDir()))
+		assert.False(t, g.Loaded())
+	})
+	t.Run("EmptyPath", func(t *testing.T) {
+		assert.Error(t, New().Load(""))
+	})
+}
+
+func TestGazetteer_Nearest(t *testing.T) {
+	g := New()
+
+	if err := g.Load("testdata"); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("BerlinMitte", func(t *testing.T) {
+		city, district := g.Nearest(52.5208, 13.40953)
+
+		if city == nil || district == nil {
+			t.Fatal("city and district expected")
+		}
+
+		assert.Equal(t, "Berlin", city.Name)
+		assert.Equal(t, "Mitte", district.Name)
+	})
+	t.Run("Potsdam", func(t *testing.T) {
+		city, district := g.Nearest(52.39886, 13.06566)
+
+		if city == nil {
+			t.Fatal("city expected")
+		}
+
+		assert.Equal(t, "Berlin", city.Name)
+		assert.Nil(t, district)
+	})
+	t.Run("Ocean", func(t *testing.T) {
+		city, district := g.Nearest(40.0, -40.0)
+
+		assert.Nil(t, city)
+		assert.Nil(t, district)
+	})
+}
+
+func TestGazetteer_FindLocation(t *testing.T) {
+	g := New()
+
+	if err := g.Load("testdata"); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("BerlinerFernsehturm", func(t *testing.T) {
+		l, err := g.FindLocation(s2.Token(52.5208, 13.40953))
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "de:gn2950159-6545310", l.PlaceID())
+		assert.Equal(t, "Mitte", l.District())
+		assert.Equal(t, "Berlin", l.City())
+		assert.Equal(t, "Berlin", l.State())
+		assert.Equal(t, "de", l.CountryCode())
+		assert.Equal(t, "Mitte, Berlin, Germany", l.Label())
+	})
+	t.Run("MexicoCity", func(t *testing.T) {
+		l, err := g.FindLocation(s2.Token(19.4326, -99.1332))
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "mx:gn3530597", l.PlaceID())
+		assert.Equal(t, "", l.District())
+		assert.Equal(t, "Mexico City, Mexico", l.Label())
+	})
+	t.Run("Ocean", func(t *testing.T) {
+		_, err := g.FindLocation("0a3c25fcffad")
+		assert.Error(t, err)
+	})
+	t.Run("InvalidID", func(t *testing.T) {
+		_, err := g.FindLocation("ab")
+		assert.Error(t, err)
+	})
+	t.Run("EmptyID", func(t *testing.T) {
+		_, err := g.FindLocation("")
+		assert.Error(t, err)
+	})
+}
+
+func TestFindLocation(t *testing.T) {
+	l, err := FindLocation(s2.Token(48.1351, 11.5820))
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, "Munich", l.City())
+	assert.Equal(t, "Bayern", l.State())
+	assert.Equal(t, "Munich, Bayern, Germany", l.Label())
+	assert.Equal(t, ApiName, l.Source())
+}
+
+func TestDistance(t *testing.T) {
+	assert.InDelta(t, 504, Distance(52.52437, 13.41053, 48.13743, 11.57549), 1)
+	assert.Equal(t, 0.0, Distance(52.52437, 13.41053, 52.52437, 13.41053))
+}
+
+func TestWrapLng(t *testing.T) {
+	assert.Equal(t, 0, wrapLng(0))
+	assert.Equal(t, 179, wrapLng(179))
+	assert.Equal(t, -180, wrapLng(180))
+	assert.Equal(t, 179, wrapLng(-181))
+}
@@ -0,0 +1,105 @@
+package gazetteer
+
+import (
+	"strings"
+
+	"github.com/photoprism/photoprism/internal/maps"
+	"github.com/photoprism/photoprism/pkg/clean"
+)
+
+// Location represents a geolocation resolved from the local dataset.
+type Location struct {
+	ID          string
+	LocLat      float64
+	LocLng      float64
+	LocPlaceID  string
+	LocDistrict string
+	LocCity     string
+	LocState    string
+	LocCountry  string
+}
+
+// CellID returns the S2 cell identifier string.
+func (l Location) CellID() string {
+	return l.ID
+}
+
+// PlaceID returns the place identifier string.
+func (l Location) PlaceID() string {
+	return l.LocPlaceID
+}
+
+// Name returns an empty string, as the dataset does not contain points of interest.
+func (l Location) Name() string {
+	return ""
+}
+
+// Street returns an empty string, as the dataset does not contain addresses.
+func (l Location) Street() string {
+	return ""
+}
+
+// Postcode returns an empty string, as the dataset does not contain addresses.
+func (l Location) Postcode() string {
+	return ""
+}
+
+// Category returns an empty string, as the dataset does not contain points of interest.
+func (l Location) Category() string {
+	return ""
+}
+
+// Label returns the location label, e.g. "Mitte, Berlin, Germany".
+func (l Location) Label() string {
+	var parts []string
+
+	for _, s := range []string{l.LocDistrict, l.LocCity, l.State(), maps.CountryName(l.LocCountry)} {
+		if s == "" || (len(parts) > 0 && strings.EqualFold(parts[len(parts)-1], s)) {
+			continue
+		}
+
+		parts = append(parts, s)
+	}
+
+	return strings.Join(parts, ", ")
+}
+
+// District returns the city district name, if any.
+func (l Location) District() string {
+	return l.LocDistrict
+}
+
+// City returns the city name.
+func (l Location) City() string {
+	return l.LocCity
+}
+
+// State returns the state name.
+func (l Location) State() string {
+	return clean.State(l.LocState, l.LocCountry)
+}
+
+// CountryCode returns the country code.
+func (l Location) CountryCode() string {
+	return l.LocCountry
+}
+
+// Latitude returns the location position latitude.
+func (l Location) Latitude() float64 {
+	return l.LocLat
+}
+
+// Longitude returns the location position longitude.
+func (l Location) Longitude() float64 {
+	return l.LocLng
+}
+
+// Keywords returns location keywords, if any.
+func (l Location) Keywords() []string {
+	return []string{}
+}
+
+// Source returns the geocoder API name.
+func (l Location) Source() string {
+	return ApiName
+}
@@ -0,0 +1,26 @@
+package gazetteer
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLocation_Label(t *testing.T) {
+	t.Run("District", func(t *testing.T) {
+		l := Location{LocDistrict: "Mitte", LocCity: "Berlin", LocState: "Berlin", LocCountry: "de"}
+		assert.Equal(t, "Mitte, Berlin, Germany", l.Label())
+	})
+	t.Run("State", func(t *testing.T) {
+		l := Location{LocCity: "Munich", LocState: "Bavaria", LocCountry: "de"}
+		assert.Equal(t, "Munich, Bayern, Germany", l.Label())
+	})
+	t.Run("NoState", func(t *testing.T) {
+		l := Location{LocCity: "Auckland", LocCountry: "nz"}
+		assert.Equal(t, "Auckland, New Zealand", l.Label())
+	})
+}
+
+func TestLocation_Source(t *testing.T) {
+	assert.Equal(t, ApiName, Location{}.Source())
+}
@@ -0,0 +1,19 @@
+package get
+
+import (
+	"sync"
+
+	"github.com/photoprism/photoprism/internal/photoprism"
+)
+
+var onceBursts sync.Once
+
+func initBursts() {
+	services.Bursts = photoprism.NewBursts(Config())
+}
+
+func Bursts() *photoprism.Bursts {
+	onceBursts.Do(initBursts)
+
+	return services.Bursts
+}
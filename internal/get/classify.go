@@ -9,10 +9,17 @@ import (
 var onceClassify sync.Once
 
 func initClassify() {
-	services.Classify = classify.New(Config().AssetsPath(), Config().DisableClassification())
+	var modelPath string
+
+	if Config().ClassificationModel() != "" {
+		modelPath = Config().ClassificationModelPath()
+	}
+
+	classify.ONNXRuntime = Config().ONNXRuntime()
+	services.Classify = classify.NewClassifier(Config().AssetsPath(), modelPath, Config().DisableClassification())
 }
 
-func Classify() *classify.TensorFlow {
+func Classify() classify.Classifier {
 	onceClassify.Do(initClassify)
 
 	return services.Classify
@@ -0,0 +1,19 @@
+package get
+
+import (
+	"sync"
+
+	"github.com/photoprism/photoprism/internal/photoprism"
+)
+
+var onceGeotag sync.Once
+
+func initGeotag() {
+	services.Geotag = photoprism.NewGeotag(Config())
+}
+
+func Geotag() *photoprism.Geotag {
+	onceGeotag.Do(initGeotag)
+
+	return services.Geotag
+}
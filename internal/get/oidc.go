@@ -0,0 +1,19 @@
+package get
+
+import (
+	"sync"
+
+	"github.com/photoprism/photoprism/internal/oidc"
+)
+
+var onceOIDC sync.Once
+
+func initOIDC() {
+	services.OIDC = oidc.NewClient(Config())
+}
+
+func OIDC() *oidc.Client {
+	onceOIDC.Do(initOIDC)
+
+	return services.OIDC
+}
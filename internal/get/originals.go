@@ -0,0 +1,19 @@
+package get
+
+import (
+	"sync"
+
+	"github.com/photoprism/photoprism/internal/photoprism"
+)
+
+var onceOriginals sync.Once
+
+func initOriginals() {
+	services.Originals = photoprism.NewOriginals(Config())
+}
+
+func Originals() *photoprism.Originals {
+	onceOriginals.Do(initOriginals)
+
+	return services.Originals
+}
@@ -29,6 +29,7 @@ import (
 	"github.com/photoprism/photoprism/internal/config"
 	"github.com/photoprism/photoprism/internal/face"
 	"github.com/photoprism/photoprism/internal/nsfw"
+	"github.com/photoprism/photoprism/internal/oidc"
 	"github.com/photoprism/photoprism/internal/photoprism"
 	"github.com/photoprism/photoprism/internal/query"
 	"github.com/photoprism/photoprism/internal/session"
@@ -42,7 +43,7 @@ var services struct {
 	FolderCache *gc.Cache
 	CoverCache  *gc.Cache
 	ThumbCache  *gc.Cache
-	Classify    *classify.TensorFlow
+	Classify    classify.Classifier
 	Convert     *photoprism.Convert
 	Files       *photoprism.Files
 	Photos      *photoprism.Photos
@@ -51,6 +52,10 @@ var services struct {
 	Moments     *photoprism.Moments
 	Faces       *photoprism.Faces
 	Places      *photoprism.Places
+	Geotag      *photoprism.Geotag
+	Duplicates  *photoprism.Duplicates
+	Bursts      *photoprism.Bursts
+	Originals   *photoprism.Originals
 	Purge       *photoprism.Purge
 	CleanUp     *photoprism.CleanUp
 	Nsfw        *nsfw.Detector
@@ -58,6 +63,7 @@ var services struct {
 	Query       *query.Query
 	Thumbs      *photoprism.Thumbs
 	Session     *session.Session
+	OIDC        *oidc.Client
 }
 
 func SetConfig(c *config.Config) {
@@ -8,6 +8,7 @@ import (
 
 	"github.com/photoprism/photoprism/internal/classify"
 	"github.com/photoprism/photoprism/internal/nsfw"
+	"github.com/photoprism/photoprism/internal/oidc"
 	"github.com/photoprism/photoprism/internal/photoprism"
 	"github.com/photoprism/photoprism/internal/query"
 	"github.com/photoprism/photoprism/internal/session"
@@ -30,7 +31,11 @@ func TestThumbCache(t *testing.T) {
 }
 
 func TestClassify(t *testing.T) {
-	assert.IsType(t, &classify.TensorFlow{}, Classify())
+	assert.Implements(t, (*classify.Classifier)(nil), Classify())
+}
+
+func TestGeotag(t *testing.T) {
+	assert.IsType(t, &photoprism.Geotag{}, Geotag())
 }
 
 func TestConvert(t *testing.T) {
@@ -49,6 +54,18 @@ func TestMoments(t *testing.T) {
 	assert.IsType(t, &photoprism.Moments{}, Moments())
 }
 
+func TestDuplicates(t *testing.T) {
+	assert.IsType(t, &photoprism.Duplicates{}, Duplicates())
+}
+
+func TestBursts(t *testing.T) {
+	assert.IsType(t, &photoprism.Bursts{}, Bursts())
+}
+
+func TestOriginals(t *testing.T) {
+	assert.IsType(t, &photoprism.Originals{}, Originals())
+}
+
 func TestPurge(t *testing.T) {
 	assert.IsType(t, &photoprism.Purge{}, Purge())
 }
@@ -72,3 +89,7 @@ func TestResample(t *testing.T) {
 func TestSession(t *testing.T) {
 	assert.IsType(t, &session.Session{}, Session())
 }
+
+func TestOIDC(t *testing.T) {
+	assert.IsType(t, &oidc.Client{}, OIDC())
+}
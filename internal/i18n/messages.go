@@ -87,6 +87,7 @@ const (
 	MsgZipCreatedIn
 	MsgPermanentlyDeleted
 	MsgRestored
+	MsgSelectionStacked
 )
 
 var Messages = MessageMap{
@@ -178,4 +179,5 @@ var Messages = MessageMap{
 	MsgZipCreatedIn:          gettext("Zip created in %d s"),
 	MsgPermanentlyDeleted:    gettext("Permanently deleted"),
 	MsgRestored:              gettext("%s has been restored"),
+	MsgSelectionStacked:      gettext("Selection stacked"),
 }
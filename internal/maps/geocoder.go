@@ -0,0 +1,39 @@
+package maps
+
+import (
+	"fmt"
+
+	"github.com/photoprism/photoprism/internal/hub/places"
+	"github.com/photoprism/photoprism/pkg/clean"
+)
+
+// Geocoder resolves S2 cell IDs to location details, e.g. using a remote service or a local dataset.
+type Geocoder interface {
+	FindLocation(id string) (LocationSource, error)
+}
+
+// GeocoderFunc allows ordinary functions to be used as Geocoder.
+type GeocoderFunc func(id string) (LocationSource, error)
+
+// FindLocation calls f(id).
+func (f GeocoderFunc) FindLocation(id string) (LocationSource, error) {
+	return f(id)
+}
+
+// Geocoders maps API names to the available reverse geocoding backends.
+var Geocoders = map[string]Geocoder{
+	places.ApiName: GeocoderFunc(func(id string) (LocationSource, error) {
+		return places.FindLocation(id)
+	}),
+}
+
+// FindGeocoder returns the reverse geocoding backend with the specified API name.
+func FindGeocoder(api string) (Geocoder, error) {
+	if api == "" {
+		return nil, fmt.Errorf("maps: location lookup disabled")
+	} else if g, ok := Geocoders[api]; !ok || g == nil {
+		return nil, fmt.Errorf("maps: unknown geocoder %s", clean.Log(api))
+	} else {
+		return g, nil
+	}
+}
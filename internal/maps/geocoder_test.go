@@ -0,0 +1,73 @@
+package maps
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/hub/places"
+)
+
+func TestFindGeocoder(t *testing.T) {
+	t.Run("Places", func(t *testing.T) {
+		g, err := FindGeocoder(places.ApiName)
+
+		assert.NoError(t, err)
+		assert.NotNil(t, g)
+	})
+	t.Run("Disabled", func(t *testing.T) {
+		_, err := FindGeocoder("")
+		assert.Error(t, err)
+	})
+	t.Run("Unknown", func(t *testing.T) {
+		_, err := FindGeocoder("foo")
+		assert.Error(t, err)
+	})
+}
+
+func TestGeocoderFunc(t *testing.T) {
+	Geocoders["test"] = GeocoderFunc(func(id string) (LocationSource, error) {
+		if id == "" {
+			return nil, errors.New("empty cell id")
+		}
+
+		return places.Location{
+			ID:      id,
+			LocName: "Fernsehturm",
+			Place: places.Place{
+				PlaceID:     "de:test",
+				LocLabel:    "Mitte, Berlin, Germany",
+				LocDistrict: "Mitte",
+				LocCity:     "Berlin",
+				LocState:    "Berlin",
+				LocCountry:  "de",
+			},
+		}, nil
+	})
+
+	defer delete(Geocoders, "test")
+
+	t.Run("Success", func(t *testing.T) {
+		l := Location{ID: "47a85a624184"}
+
+		if err := l.QueryApi("test"); err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "de:test", l.PlaceID())
+		assert.Equal(t, "Fernsehturm", l.Name())
+		assert.Equal(t, "Mitte, Berlin, Germany", l.Label())
+		assert.Equal(t, "Berlin", l.City())
+		assert.Equal(t, "de", l.CountryCode())
+		assert.Equal(t, "places", l.Source())
+	})
+	t.Run("Error", func(t *testing.T) {
+		l := Location{}
+		assert.Error(t, l.QueryApi("test"))
+	})
+	t.Run("Disabled", func(t *testing.T) {
+		l := Location{ID: "47a85a624184"}
+		assert.Error(t, l.QueryApi(""))
+	})
+}
@@ -1,7 +1,6 @@
 package maps
 
 import (
-	"errors"
 	"strings"
 
 	"github.com/photoprism/photoprism/internal/hub/places"
@@ -34,6 +33,7 @@ type LocationSource interface {
 	Street() string
 	Category() string
 	Postcode() string
+	Label() string
 	District() string
 	City() string
 	State() string
@@ -42,22 +42,33 @@ type LocationSource interface {
 	Source() string
 }
 
+// QueryApi retrieves location details from the reverse geocoding backend with the specified API name.
 func (l *Location) QueryApi(api string) error {
-	switch api {
-	case places.ApiName:
-		return l.QueryPlaces()
-	}
+	g, err := FindGeocoder(api)
 
-	return errors.New("maps: location lookup disabled")
-}
+	if err != nil {
+		return err
+	}
 
-func (l *Location) QueryPlaces() error {
-	s, err := places.FindLocation(l.ID)
+	s, err := g.FindLocation(l.ID)
 
 	if err != nil {
 		return err
 	}
 
+	l.Assign(s)
+
+	return nil
+}
+
+// QueryPlaces retrieves location details from the places service.
+func (l *Location) QueryPlaces() error {
+	return l.QueryApi(places.ApiName)
+}
+
+// Assign sets the location details based on the specified source.
+func (l *Location) Assign(s LocationSource) {
+
 	l.placeID = s.PlaceID()
 	l.LocSource = s.Source()
 	l.LocName = s.Name()
@@ -70,8 +81,6 @@ func (l *Location) QueryPlaces() error {
 	l.LocState = s.State()
 	l.LocCountry = s.CountryCode()
 	l.LocKeywords = s.Keywords()
-
-	return nil
 }
 
 func (l *Location) Unknown() bool {
@@ -10,13 +10,24 @@ import (
 )
 
 type GPhoto struct {
-	Title       string `json:"title"`
-	Description string `json:"description"`
-	Views       int    `json:"imageViews,string"`
-	Geo         GGeo   `json:"geoData"`
-	TakenAt     GTime  `json:"photoTakenTime"`
-	CreatedAt   GTime  `json:"creationTime"`
-	UpdatedAt   GTime  `json:"modificationTime"`
+	Title       string    `json:"title"`
+	Description string    `json:"description"`
+	Views       int       `json:"imageViews,string"`
+	Geo         GGeo      `json:"geoData"`
+	GeoExif     GGeo      `json:"geoDataExif"`
+	TakenAt     GTime     `json:"photoTakenTime"`
+	CreatedAt   GTime     `json:"creationTime"`
+	UpdatedAt   GTime     `json:"modificationTime"`
+	People      []GPerson `json:"people"`
+	Favorited   bool      `json:"favorited"`
+	Archived    bool      `json:"archived"`
+	Trashed     bool      `json:"trashed"`
+}
+
+// NewGPhoto parses JSON photo sidecar data as created by Google Photos.
+func NewGPhoto(jsonData []byte) (p GPhoto, err error) {
+	err = json.Unmarshal(jsonData, &p)
+	return p, err
 }
 
 func (m GPhoto) SanitizedTitle() string {
@@ -27,6 +38,30 @@ func (m GPhoto) SanitizedDescription() string {
 	return SanitizeDescription(m.Description)
 }
 
+// Location returns the geo data, with the location found in the Exif data as fallback.
+func (m GPhoto) Location() GGeo {
+	if m.Geo.Exists() {
+		return m.Geo
+	}
+
+	return m.GeoExif
+}
+
+// PeopleNames returns the sanitized names of the people tagged in the photo.
+func (m GPhoto) PeopleNames() (names []string) {
+	for _, p := range m.People {
+		if name := SanitizeString(p.Name); name != "" {
+			names = append(names, name)
+		}
+	}
+
+	return names
+}
+
+type GPerson struct {
+	Name string `json:"name"`
+}
+
 type GMeta struct {
 	Album GAlbum `json:"albumData"`
 }
@@ -44,6 +79,21 @@ func (m GAlbum) Exists() bool {
 	return m.Title != ""
 }
 
+// NewGAlbum parses JSON album metadata as created by Google Photos, with or without "albumData" wrapper.
+func NewGAlbum(jsonData []byte) (a GAlbum, err error) {
+	p := GMeta{}
+
+	if err = json.Unmarshal(jsonData, &p); err != nil {
+		return a, err
+	} else if p.Album.Exists() {
+		return p.Album, nil
+	}
+
+	err = json.Unmarshal(jsonData, &a)
+
+	return a, err
+}
+
 type GGeo struct {
 	Lat      float64 `json:"latitude"`
 	Lng      float64 `json:"longitude"`
@@ -124,14 +174,14 @@ func (data *Data) GPhoto(jsonData []byte) (err error) {
 		}
 	}
 
-	if p.Geo.Exists() {
+	if geo := p.Location(); geo.Exists() {
 		if data.Lat == 0 && data.Lng == 0 {
-			data.Lat = float32(p.Geo.Lat)
-			data.Lng = float32(p.Geo.Lng)
+			data.Lat = float32(geo.Lat)
+			data.Lng = float32(geo.Lng)
 		}
 
 		if data.Altitude == 0 {
-			data.Altitude = p.Geo.Altitude
+			data.Altitude = geo.Altitude
 		}
 	}
 
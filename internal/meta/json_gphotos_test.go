@@ -0,0 +1,82 @@
+package meta
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewGPhoto(t *testing.T) {
+	t.Run("People", func(t *testing.T) {
+		jsonData, err := os.ReadFile("testdata/gphotos-people.json")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		p, err := NewGPhoto(jsonData)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "", p.SanitizedTitle())
+		assert.Equal(t, "Picnic in the park", p.SanitizedDescription())
+		assert.Equal(t, 12, p.Views)
+		assert.True(t, p.Favorited)
+		assert.False(t, p.Archived)
+		assert.False(t, p.Trashed)
+		assert.False(t, p.Geo.Exists())
+		assert.Equal(t, 48.858093, p.Location().Lat)
+		assert.Equal(t, 2.294694, p.Location().Lng)
+		assert.Equal(t, []string{"Jane Doe", "John Doe"}, p.PeopleNames())
+	})
+	t.Run("Invalid", func(t *testing.T) {
+		_, err := NewGPhoto([]byte("{"))
+		assert.Error(t, err)
+	})
+}
+
+func TestGPhoto_Location(t *testing.T) {
+	t.Run("GeoData", func(t *testing.T) {
+		p := GPhoto{Geo: GGeo{Lat: 1, Lng: 2}, GeoExif: GGeo{Lat: 3, Lng: 4}}
+		assert.Equal(t, float64(1), p.Location().Lat)
+	})
+	t.Run("Empty", func(t *testing.T) {
+		p := GPhoto{}
+		assert.False(t, p.Location().Exists())
+	})
+}
+
+func TestNewGAlbum(t *testing.T) {
+	t.Run("AlbumData", func(t *testing.T) {
+		jsonData, err := os.ReadFile("testdata/gphotos-album.json")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		a, err := NewGAlbum(jsonData)
+
+		assert.NoError(t, err)
+		assert.True(t, a.Exists())
+	})
+	t.Run("Metadata", func(t *testing.T) {
+		jsonData, err := os.ReadFile("testdata/gphotos-album-metadata.json")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		a, err := NewGAlbum(jsonData)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "Summer Trip", a.Title)
+		assert.Equal(t, "Beach and mountains", a.Description)
+	})
+	t.Run("Invalid", func(t *testing.T) {
+		_, err := NewGAlbum([]byte("["))
+		assert.Error(t, err)
+	})
+}
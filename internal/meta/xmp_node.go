@@ -0,0 +1,278 @@
+package meta
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"io"
+	"strings"
+)
+
+// XMP namespace URIs.
+const (
+	XmpNsMeta      = "adobe:ns:meta/"
+	XmpNsRDF       = "http://www.w3.org/1999/02/22-rdf-syntax-ns#"
+	XmpNsXML       = "http://www.w3.org/XML/1998/namespace"
+	XmpNsDC        = "http://purl.org/dc/elements/1.1/"
+	XmpNsXmp       = "http://ns.adobe.com/xap/1.0/"
+	XmpNsExif      = "http://ns.adobe.com/exif/1.0/"
+	XmpNsPhotoshop = "http://ns.adobe.com/photoshop/1.0/"
+	XmpNsIptcExt   = "http://iptc.org/std/Iptc4xmpExt/2008-02-29/"
+	XmpNsMwgRs     = "http://www.metadataworkinggroup.com/schemas/regions/"
+	XmpNsStDim     = "http://ns.adobe.com/xap/1.0/sType/Dimensions#"
+	XmpNsStArea    = "http://ns.adobe.com/xmp/sType/Area#"
+)
+
+// XmpPrefixes maps namespace URIs to their preferred prefixes.
+var XmpPrefixes = map[string]string{
+	XmpNsMeta:      "x",
+	XmpNsRDF:       "rdf",
+	XmpNsXML:       "xml",
+	XmpNsDC:        "dc",
+	XmpNsXmp:       "xmp",
+	XmpNsExif:      "exif",
+	XmpNsPhotoshop: "photoshop",
+	XmpNsIptcExt:   "Iptc4xmpExt",
+	XmpNsMwgRs:     "mwg-rs",
+	XmpNsStDim:     "stDim",
+	XmpNsStArea:    "stArea",
+}
+
+// xmpNode represents an element of an XMP document. Unknown elements and attributes are
+// kept so that existing content is preserved when the document is written back.
+type xmpNode struct {
+	Name  xml.Name
+	Attr  []xml.Attr
+	Nodes []*xmpNode
+	Text  string
+}
+
+// newXmpNode creates a new element with optional text content.
+func newXmpNode(space, local, text string) *xmpNode {
+	return &xmpNode{Name: xml.Name{Space: space, Local: local}, Text: text}
+}
+
+// parseXmpNode parses XML data and returns the root element.
+func parseXmpNode(data []byte) (*xmpNode, error) {
+	d := xml.NewDecoder(bytes.NewReader(data))
+
+	var root *xmpNode
+	var stack []*xmpNode
+
+	for {
+		token, err := d.Token()
+
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, err
+		}
+
+		switch t := token.(type) {
+		case xml.StartElement:
+			n := &xmpNode{Name: t.Name, Attr: append([]xml.Attr(nil), t.Attr...)}
+
+			if len(stack) > 0 {
+				parent := stack[len(stack)-1]
+				parent.Nodes = append(parent.Nodes, n)
+			} else if root == nil {
+				root = n
+			}
+
+			stack = append(stack, n)
+		case xml.EndElement:
+			if len(stack) > 0 {
+				stack = stack[:len(stack)-1]
+			}
+		case xml.CharData:
+			if len(stack) > 0 {
+				stack[len(stack)-1].Text += string(t)
+			}
+		}
+	}
+
+	if root == nil {
+		return nil, errors.New("root element not found")
+	}
+
+	return root, nil
+}
+
+// is tests if the element has the specified name.
+func (n *xmpNode) is(space, local string) bool {
+	return n.Name.Space == space && n.Name.Local == local
+}
+
+// find returns the first child element with the specified name.
+func (n *xmpNode) find(space, local string) *xmpNode {
+	for _, c := range n.Nodes {
+		if c.is(space, local) {
+			return c
+		}
+	}
+
+	return nil
+}
+
+// findAll returns all child elements with the specified name.
+func (n *xmpNode) findAll(space, local string) (result []*xmpNode) {
+	for _, c := range n.Nodes {
+		if c.is(space, local) {
+			result = append(result, c)
+		}
+	}
+
+	return result
+}
+
+// attr returns the value of the attribute with the specified name.
+func (n *xmpNode) attr(space, local string) string {
+	for _, a := range n.Attr {
+		if a.Name.Space == space && a.Name.Local == local {
+			return a.Value
+		}
+	}
+
+	return ""
+}
+
+// setAttr adds or replaces an attribute.
+func (n *xmpNode) setAttr(space, local, value string) *xmpNode {
+	for i := range n.Attr {
+		if n.Attr[i].Name.Space == space && n.Attr[i].Name.Local == local {
+			n.Attr[i].Value = value
+			return n
+		}
+	}
+
+	n.Attr = append(n.Attr, xml.Attr{Name: xml.Name{Space: space, Local: local}, Value: value})
+
+	return n
+}
+
+// add appends child elements.
+func (n *xmpNode) add(nodes ...*xmpNode) *xmpNode {
+	n.Nodes = append(n.Nodes, nodes...)
+	return n
+}
+
+// value returns a simple property value, which may be stored as attribute or element.
+func (n *xmpNode) value(space, local string) string {
+	if v := n.attr(space, local); v != "" {
+		return v
+	} else if c := n.find(space, local); c != nil {
+		return strings.TrimSpace(c.Text)
+	}
+
+	return ""
+}
+
+// remove deletes all child elements and attributes with the specified name.
+func (n *xmpNode) remove(space, local string) {
+	nodes := n.Nodes[:0]
+
+	for _, c := range n.Nodes {
+		if !c.is(space, local) {
+			nodes = append(nodes, c)
+		}
+	}
+
+	n.Nodes = nodes
+
+	attrs := n.Attr[:0]
+
+	for _, a := range n.Attr {
+		if a.Name.Space != space || a.Name.Local != local {
+			attrs = append(attrs, a)
+		}
+	}
+
+	n.Attr = attrs
+}
+
+// namespaces returns the namespace URIs declared by the element, mapped to their prefixes.
+func (n *xmpNode) namespaces(scope map[string]string) map[string]string {
+	result := make(map[string]string, len(scope)+len(n.Attr))
+
+	for uri, prefix := range scope {
+		result[uri] = prefix
+	}
+
+	for _, a := range n.Attr {
+		if a.Name.Space == "xmlns" {
+			result[a.Value] = a.Name.Local
+		} else if a.Name.Space == "" && a.Name.Local == "xmlns" {
+			result[a.Value] = ""
+		}
+	}
+
+	return result
+}
+
+// qualified returns the qualified name of an element or attribute based on the namespaces in scope.
+func qualified(name xml.Name, scope map[string]string, isAttr bool) string {
+	switch {
+	case name.Space == "":
+		return name.Local
+	case isAttr && name.Space == "xmlns":
+		return "xmlns:" + name.Local
+	case name.Space == XmpNsXML:
+		return "xml:" + name.Local
+	}
+
+	if prefix, ok := scope[name.Space]; !ok {
+		// Keep undeclared prefixes as they are.
+		return name.Space + ":" + name.Local
+	} else if prefix == "" {
+		return name.Local
+	} else {
+		return prefix + ":" + name.Local
+	}
+}
+
+// write adds the element and its children to the buffer.
+func (n *xmpNode) write(b *bytes.Buffer, scope map[string]string, depth int) {
+	scope = n.namespaces(scope)
+	indent := strings.Repeat(" ", depth)
+	name := qualified(n.Name, scope, false)
+
+	b.WriteString(indent)
+	b.WriteString("<")
+	b.WriteString(name)
+
+	for _, a := range n.Attr {
+		b.WriteString(" ")
+		b.WriteString(qualified(a.Name, scope, true))
+		b.WriteString(`="`)
+		_ = xml.EscapeText(b, []byte(a.Value))
+		b.WriteString(`"`)
+	}
+
+	switch {
+	case len(n.Nodes) > 0:
+		b.WriteString(">\n")
+
+		for _, c := range n.Nodes {
+			c.write(b, scope, depth+1)
+		}
+
+		b.WriteString(indent)
+	case n.Text != "":
+		b.WriteString(">")
+		_ = xml.EscapeText(b, []byte(n.Text))
+	default:
+		b.WriteString("/>\n")
+		return
+	}
+
+	b.WriteString("</")
+	b.WriteString(name)
+	b.WriteString(">\n")
+}
+
+// Bytes returns the element and its children as XML.
+func (n *xmpNode) Bytes() []byte {
+	b := &bytes.Buffer{}
+	n.write(b, map[string]string{}, 0)
+	return b.Bytes()
+}
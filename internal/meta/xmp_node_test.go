@@ -0,0 +1,89 @@
+package meta
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseXmpNode(t *testing.T) {
+	t.Run("Attributes", func(t *testing.T) {
+		data := []byte(`<x:xmpmeta xmlns:x="adobe:ns:meta/"><rdf:RDF xmlns:rdf="http://www.w3.org/1999/02/22-rdf-syntax-ns#">` +
+			`<rdf:Description rdf:about="" xmlns:xmp="http://ns.adobe.com/xap/1.0/" xmp:Rating="3">` +
+			`<dc:title xmlns:dc="http://purl.org/dc/elements/1.1/"><rdf:Alt><rdf:li xml:lang="x-default">Cat &amp; Dog</rdf:li></rdf:Alt></dc:title>` +
+			`</rdf:Description></rdf:RDF></x:xmpmeta>`)
+
+		root, err := parseXmpNode(data)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.True(t, root.is(XmpNsMeta, "xmpmeta"))
+
+		desc := root.find(XmpNsRDF, "RDF").find(XmpNsRDF, "Description")
+
+		if desc == nil {
+			t.Fatal("description must not be nil")
+		}
+
+		assert.Equal(t, "3", desc.value(XmpNsXmp, "Rating"))
+		assert.Equal(t, "Cat & Dog", desc.find(XmpNsDC, "title").find(XmpNsRDF, "Alt").find(XmpNsRDF, "li").Text)
+	})
+	t.Run("Empty", func(t *testing.T) {
+		root, err := parseXmpNode([]byte(""))
+
+		assert.Error(t, err)
+		assert.Nil(t, root)
+	})
+	t.Run("Invalid", func(t *testing.T) {
+		root, err := parseXmpNode([]byte("<x:xmpmeta><rdf:RDF></x:xmpmeta>"))
+
+		assert.Error(t, err)
+		assert.Nil(t, root)
+	})
+}
+
+func TestXmpNode_Remove(t *testing.T) {
+	n := newXmpNode(XmpNsRDF, "Description", "").
+		setAttr(XmpNsXmp, "Rating", "3").
+		setAttr(XmpNsRDF, "about", "").
+		add(newXmpNode(XmpNsXmp, "Rating", "4"), newXmpNode(XmpNsDC, "format", "image/jpeg"))
+
+	n.remove(XmpNsXmp, "Rating")
+
+	assert.Equal(t, "", n.value(XmpNsXmp, "Rating"))
+	assert.Equal(t, "image/jpeg", n.value(XmpNsDC, "format"))
+	assert.Len(t, n.Attr, 1)
+	assert.Len(t, n.Nodes, 1)
+}
+
+func TestXmpNode_Bytes(t *testing.T) {
+	t.Run("Namespaces", func(t *testing.T) {
+		data := []byte(`<x:xmpmeta xmlns:x="adobe:ns:meta/"><rdf:RDF xmlns:rdf="http://www.w3.org/1999/02/22-rdf-syntax-ns#">` +
+			`<rdf:Description rdf:about="" xmlns:foo="http://example.com/foo/" foo:bar="baz"><foo:empty/><foo:text>a &lt; b</foo:text></rdf:Description>` +
+			`</rdf:RDF></x:xmpmeta>`)
+
+		root, err := parseXmpNode(data)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		expected := `<x:xmpmeta xmlns:x="adobe:ns:meta/">
+ <rdf:RDF xmlns:rdf="http://www.w3.org/1999/02/22-rdf-syntax-ns#">
+  <rdf:Description rdf:about="" xmlns:foo="http://example.com/foo/" foo:bar="baz">
+   <foo:empty/>
+   <foo:text>a &lt; b</foo:text>
+  </rdf:Description>
+ </rdf:RDF>
+</x:xmpmeta>
+`
+
+		assert.Equal(t, expected, string(root.Bytes()))
+	})
+	t.Run("Undeclared", func(t *testing.T) {
+		n := newXmpNode("foo", "bar", "baz")
+		assert.Equal(t, "<foo:bar>baz</foo:bar>\n", string(n.Bytes()))
+	})
+}
@@ -0,0 +1,383 @@
+package meta
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/photoprism/photoprism/pkg/clean"
+	"github.com/photoprism/photoprism/pkg/fs"
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+const (
+	XmpPacketBegin = "<?xpacket begin=\"\ufeff\" id=\"W5M0MpCehiHzreSzNTczkc9d\"?>\n"
+	XmpPacketEnd   = "<?xpacket end=\"w\"?>\n"
+	XmpToolkit     = "PhotoPrism"
+	XmpDateTime    = "2006-01-02T15:04:05"
+	XmpDateTimeTz  = "2006-01-02T15:04:05Z07:00"
+	XmpRatingFav   = 5
+)
+
+var xmpMutex = sync.Mutex{}
+
+// XmpRegion represents a named image region in normalized coordinates, e.g. a face.
+// X and Y are the coordinates of the top left corner.
+type XmpRegion struct {
+	Name string
+	Type string
+	X    float64
+	Y    float64
+	W    float64
+	H    float64
+}
+
+// XmpUpdate contains metadata to be written to an XMP sidecar file. Empty values are
+// skipped so that existing metadata, e.g. added by other apps, is not removed. If Replace
+// is true, an empty title, description, keyword list, or list of faces removes existing
+// values instead, e.g. because they have been cleared by the user.
+type XmpUpdate struct {
+	Replace      bool
+	Title        string
+	Description  string
+	Keywords     []string
+	Favorite     bool
+	TakenAt      time.Time
+	TakenAtLocal time.Time
+	TimeZone     string
+	Lat          float64
+	Lng          float64
+	Altitude     float64
+	Width        int
+	Height       int
+	Regions      []XmpRegion
+}
+
+// Save merges the metadata with the contents of an existing XMP file, if any, and writes the result
+// to fileName. Existing contents are read from srcName, or from fileName if srcName is empty.
+func (u *XmpUpdate) Save(fileName, srcName string) error {
+	if fileName == "" {
+		return fmt.Errorf("metadata: missing xmp file name")
+	} else if srcName == "" {
+		srcName = fileName
+	}
+
+	xmpMutex.Lock()
+	defer xmpMutex.Unlock()
+
+	var root *xmpNode
+
+	if data, err := os.ReadFile(srcName); err == nil {
+		if root, err = parseXmpNode(data); err != nil {
+			return fmt.Errorf("metadata: cannot parse %s (%s)", clean.Log(filepath.Base(srcName)), err)
+		}
+	} else if !os.IsNotExist(err) {
+		return err
+	}
+
+	doc, err := newXmpTree(root)
+
+	if err != nil {
+		return fmt.Errorf("metadata: %s in %s", err, clean.Log(filepath.Base(srcName)))
+	}
+
+	u.apply(doc)
+
+	// Make sure directory exists.
+	if err = os.MkdirAll(filepath.Dir(fileName), fs.ModeDir); err != nil {
+		return err
+	}
+
+	data := bytes.NewBufferString(XmpPacketBegin)
+	data.Write(doc.root.Bytes())
+	data.WriteString(XmpPacketEnd)
+
+	return os.WriteFile(fileName, data.Bytes(), fs.ModeFile)
+}
+
+// apply updates the document with the metadata values.
+func (u *XmpUpdate) apply(doc *xmpTree) {
+	if s := SanitizeTitle(u.Title); s != "" {
+		doc.set(XmpNsDC, "title", xmpLangAlt(s))
+	} else if u.Replace {
+		doc.remove(XmpNsDC, "title")
+	}
+
+	if s := SanitizeDescription(u.Description); s != "" {
+		doc.set(XmpNsDC, "description", xmpLangAlt(s))
+	} else if u.Replace {
+		doc.remove(XmpNsDC, "description")
+	}
+
+	if keywords := txt.UniqueNames(u.Keywords); len(keywords) > 0 {
+		doc.set(XmpNsDC, "subject", xmpBag(keywords))
+	} else if u.Replace {
+		doc.remove(XmpNsDC, "subject")
+	}
+
+	// Favorites are rated with 5 stars, unless they have already been rated in another app.
+	if rating := doc.value(XmpNsXmp, "Rating"); u.Favorite {
+		if rating == "" || rating == "0" {
+			doc.set(XmpNsXmp, "Rating", xmpText(strconv.Itoa(XmpRatingFav)))
+		}
+	} else if rating == strconv.Itoa(XmpRatingFav) {
+		doc.remove(XmpNsXmp, "Rating")
+	}
+
+	if s := u.DateCreated(); s != "" {
+		doc.set(XmpNsPhotoshop, "DateCreated", xmpText(s))
+		doc.set(XmpNsExif, "DateTimeOriginal", xmpText(s))
+	}
+
+	if u.Lat != 0 || u.Lng != 0 {
+		doc.set(XmpNsExif, "GPSLatitude", xmpText(XmpGps(u.Lat, 'N', 'S')))
+		doc.set(XmpNsExif, "GPSLongitude", xmpText(XmpGps(u.Lng, 'E', 'W')))
+
+		if alt := math.Round(u.Altitude); alt != 0 {
+			ref := "0"
+
+			if alt < 0 {
+				ref = "1"
+			}
+
+			doc.set(XmpNsExif, "GPSAltitude", xmpText(fmt.Sprintf("%d/1", int(math.Abs(alt)))))
+			doc.set(XmpNsExif, "GPSAltitudeRef", xmpText(ref))
+		}
+	}
+
+	if regions := u.regions(); regions != nil {
+		doc.declare(XmpNsStDim)
+		doc.declare(XmpNsStArea)
+		doc.set(XmpNsMwgRs, "Regions", regions)
+	} else if u.Replace && len(u.Regions) == 0 {
+		doc.remove(XmpNsMwgRs, "Regions")
+	}
+
+	if names := u.People(); len(names) > 0 {
+		doc.set(XmpNsIptcExt, "PersonInImage", xmpBag(names))
+	} else if u.Replace {
+		doc.remove(XmpNsIptcExt, "PersonInImage")
+	}
+
+	doc.set(XmpNsXmp, "MetadataDate", xmpText(time.Now().UTC().Format(XmpDateTimeTz)))
+}
+
+// DateCreated returns the capture time in XMP format, including the time zone offset if known.
+func (u *XmpUpdate) DateCreated() string {
+	if u.TimeZone != "" && !u.TakenAt.IsZero() {
+		if loc, err := time.LoadLocation(u.TimeZone); err == nil {
+			return u.TakenAt.In(loc).Format(XmpDateTimeTz)
+		}
+	}
+
+	if u.TakenAtLocal.IsZero() {
+		return ""
+	}
+
+	return u.TakenAtLocal.Format(XmpDateTime)
+}
+
+// People returns the names of all people shown in the image.
+func (u *XmpUpdate) People() []string {
+	var names []string
+
+	for _, r := range u.Regions {
+		if r.Type == "Face" && r.Name != "" {
+			names = append(names, r.Name)
+		}
+	}
+
+	return txt.UniqueNames(names)
+}
+
+// regions returns the image regions as Metadata Working Group (MWG) element, or nil if there are none.
+func (u *XmpUpdate) regions() *xmpNode {
+	if len(u.Regions) == 0 || u.Width <= 0 || u.Height <= 0 {
+		return nil
+	}
+
+	bag := newXmpNode(XmpNsRDF, "Bag", "")
+
+	for _, r := range u.Regions {
+		// MWG areas are defined by their center.
+		area := newXmpNode(XmpNsMwgRs, "Area", "").
+			setAttr(XmpNsStArea, "x", xmpFloat(r.X+r.W/2)).
+			setAttr(XmpNsStArea, "y", xmpFloat(r.Y+r.H/2)).
+			setAttr(XmpNsStArea, "w", xmpFloat(r.W)).
+			setAttr(XmpNsStArea, "h", xmpFloat(r.H)).
+			setAttr(XmpNsStArea, "unit", "normalized")
+
+		li := newXmpNode(XmpNsRDF, "li", "").setAttr(XmpNsRDF, "parseType", "Resource")
+
+		if r.Type != "" {
+			li.add(newXmpNode(XmpNsMwgRs, "Type", r.Type))
+		}
+
+		if r.Name != "" {
+			li.add(newXmpNode(XmpNsMwgRs, "Name", r.Name))
+		}
+
+		bag.add(li.add(area))
+	}
+
+	dim := newXmpNode(XmpNsMwgRs, "AppliedToDimensions", "").
+		setAttr(XmpNsStDim, "w", strconv.Itoa(u.Width)).
+		setAttr(XmpNsStDim, "h", strconv.Itoa(u.Height)).
+		setAttr(XmpNsStDim, "unit", "pixel")
+
+	return (&xmpNode{}).
+		setAttr(XmpNsRDF, "parseType", "Resource").
+		add(dim, newXmpNode(XmpNsMwgRs, "RegionList", "").add(bag))
+}
+
+// XmpGps returns a coordinate in XMP GPS format, e.g. "52,27,34.8840N".
+func XmpGps(coord float64, pos, neg byte) string {
+	ref := pos
+
+	if coord < 0 {
+		ref = neg
+	}
+
+	// Round to 1/10000 arc second to avoid overflows, e.g. 60 seconds.
+	s := math.Round(math.Abs(coord)*36000000) / 10000
+	d := math.Floor(s / 3600)
+	s -= d * 3600
+	m := math.Floor(s / 60)
+	s -= m * 60
+
+	return fmt.Sprintf("%d,%d,%.4f%c", int(d), int(m), s, ref)
+}
+
+// xmpFloat formats a normalized coordinate.
+func xmpFloat(f float64) string {
+	return strconv.FormatFloat(f, 'f', 6, 64)
+}
+
+// xmpText returns a simple property value.
+func xmpText(s string) *xmpNode {
+	return &xmpNode{Text: s}
+}
+
+// xmpLangAlt returns a language alternative with a default value.
+func xmpLangAlt(s string) *xmpNode {
+	li := newXmpNode(XmpNsRDF, "li", s).setAttr(XmpNsXML, "lang", "x-default")
+	return (&xmpNode{}).add(newXmpNode(XmpNsRDF, "Alt", "").add(li))
+}
+
+// xmpBag returns an unordered list of values.
+func xmpBag(values []string) *xmpNode {
+	bag := newXmpNode(XmpNsRDF, "Bag", "")
+
+	for _, s := range values {
+		bag.add(newXmpNode(XmpNsRDF, "li", s))
+	}
+
+	return (&xmpNode{}).add(bag)
+}
+
+// xmpTree provides access to the RDF descriptions of an XMP document.
+type xmpTree struct {
+	root *xmpNode
+	rdf  *xmpNode
+	desc []*xmpNode
+}
+
+// newXmpTree returns a document for the root element, which is created if nil.
+func newXmpTree(root *xmpNode) (*xmpTree, error) {
+	switch {
+	case root == nil:
+		root = newXmpNode(XmpNsMeta, "xmpmeta", "")
+	case root.is(XmpNsRDF, "RDF"):
+		root = newXmpNode(XmpNsMeta, "xmpmeta", "").add(root)
+	case !root.is(XmpNsMeta, "xmpmeta"):
+		return nil, fmt.Errorf("unsupported root element %s", clean.Log(root.Name.Local))
+	}
+
+	doc := &xmpTree{root: root}
+
+	if root.attr("xmlns", "x") == "" {
+		root.setAttr("xmlns", "x", XmpNsMeta)
+	}
+
+	if root.attr(XmpNsMeta, "xmptk") == "" {
+		root.setAttr(XmpNsMeta, "xmptk", XmpToolkit)
+	}
+
+	if doc.rdf = root.find(XmpNsRDF, "RDF"); doc.rdf == nil {
+		doc.rdf = newXmpNode(XmpNsRDF, "RDF", "").setAttr("xmlns", "rdf", XmpNsRDF)
+		root.add(doc.rdf)
+	}
+
+	if doc.desc = doc.rdf.findAll(XmpNsRDF, "Description"); len(doc.desc) == 0 {
+		desc := newXmpNode(XmpNsRDF, "Description", "").setAttr(XmpNsRDF, "about", "")
+		doc.rdf.add(desc)
+		doc.desc = []*xmpNode{desc}
+	}
+
+	return doc, nil
+}
+
+// declare makes sure the namespace is declared, and returns its prefix.
+func (doc *xmpTree) declare(uri string) string {
+	scope := doc.desc[0].namespaces(doc.rdf.namespaces(doc.root.namespaces(nil)))
+
+	if prefix, ok := scope[uri]; ok {
+		return prefix
+	}
+
+	used := make(map[string]bool, len(scope))
+
+	for _, p := range scope {
+		used[p] = true
+	}
+
+	prefix := XmpPrefixes[uri]
+
+	if prefix == "" {
+		prefix = "ns"
+	}
+
+	// Avoid conflicts with prefixes bound to other namespaces.
+	for i := 2; used[prefix]; i++ {
+		prefix = strings.TrimRight(prefix, "0123456789") + strconv.Itoa(i)
+	}
+
+	doc.desc[0].setAttr("xmlns", prefix, uri)
+
+	return prefix
+}
+
+// value returns the first value of a simple property.
+func (doc *xmpTree) value(space, local string) string {
+	for _, d := range doc.desc {
+		if v := d.value(space, local); v != "" {
+			return v
+		}
+	}
+
+	return ""
+}
+
+// remove deletes a property from all descriptions.
+func (doc *xmpTree) remove(space, local string) {
+	for _, d := range doc.desc {
+		d.remove(space, local)
+	}
+}
+
+// set replaces a property and adds it to the first description.
+func (doc *xmpTree) set(space, local string, n *xmpNode) {
+	doc.remove(space, local)
+	doc.declare(space)
+
+	n.Name = xml.Name{Space: space, Local: local}
+
+	doc.desc[0].add(n)
+}
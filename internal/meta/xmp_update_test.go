@@ -0,0 +1,329 @@
+package meta
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/pkg/fs"
+)
+
+func TestXmpUpdate_Save(t *testing.T) {
+	t.Run("New", func(t *testing.T) {
+		fileName := filepath.Join(t.TempDir(), "new.xmp")
+
+		u := XmpUpdate{
+			Title:        "Cat on a Desk",
+			Description:  "Example photo",
+			Keywords:     []string{"cat", "desk", "cat"},
+			Favorite:     true,
+			TakenAt:      time.Date(2021, 3, 24, 12, 7, 29, 0, time.UTC),
+			TakenAtLocal: time.Date(2021, 3, 24, 13, 7, 29, 0, time.UTC),
+			TimeZone:     "Europe/Berlin",
+			Lat:          52.459551,
+			Lng:          -13.321832,
+			Altitude:     -10.4,
+			Width:        1000,
+			Height:       500,
+			Regions: []XmpRegion{
+				{Name: "Jens Mander", Type: "Face", X: 0.1, Y: 0.2, W: 0.2, H: 0.4},
+				{Type: "Face", X: 0.5, Y: 0.5, W: 0.1, H: 0.1},
+			},
+		}
+
+		if err := u.Save(fileName, ""); err != nil {
+			t.Fatal(err)
+		}
+
+		data, err := os.ReadFile(fileName)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		s := string(data)
+
+		assert.True(t, strings.HasPrefix(s, XmpPacketBegin))
+		assert.True(t, strings.HasSuffix(s, XmpPacketEnd))
+		assert.Contains(t, s, `x:xmptk="PhotoPrism"`)
+		assert.Contains(t, s, `<rdf:li xml:lang="x-default">Cat on a Desk</rdf:li>`)
+		assert.Contains(t, s, `<xmp:Rating>5</xmp:Rating>`)
+		assert.Contains(t, s, `<photoshop:DateCreated>2021-03-24T13:07:29+01:00</photoshop:DateCreated>`)
+		assert.Contains(t, s, `<exif:GPSLatitude>52,27,34.3836N</exif:GPSLatitude>`)
+		assert.Contains(t, s, `<exif:GPSLongitude>13,19,18.5952W</exif:GPSLongitude>`)
+		assert.Contains(t, s, `<exif:GPSAltitude>10/1</exif:GPSAltitude>`)
+		assert.Contains(t, s, `<exif:GPSAltitudeRef>1</exif:GPSAltitudeRef>`)
+		assert.Contains(t, s, `<mwg-rs:AppliedToDimensions stDim:w="1000" stDim:h="500" stDim:unit="pixel"/>`)
+		assert.Contains(t, s, `<mwg-rs:Name>Jens Mander</mwg-rs:Name>`)
+		assert.Contains(t, s, `<mwg-rs:Area stArea:x="0.200000" stArea:y="0.400000" stArea:w="0.200000" stArea:h="0.400000" stArea:unit="normalized"/>`)
+		assert.Contains(t, s, `xmlns:Iptc4xmpExt="http://iptc.org/std/Iptc4xmpExt/2008-02-29/"`)
+
+		doc := XmpDocument{}
+
+		if err = doc.Load(fileName); err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "Cat on a Desk", doc.Title())
+		assert.Equal(t, "Example photo", doc.Description())
+		assert.Equal(t, "cat, desk", doc.Keywords())
+		assert.Equal(t, time.Date(2021, 3, 24, 12, 7, 29, 0, time.UTC), doc.TakenAt("").UTC())
+		assert.InDelta(t, 52.459551, GpsToDecimal(doc.RDF.Description.GPSLatitude), 0.000001)
+		assert.InDelta(t, -13.321832, GpsToDecimal(doc.RDF.Description.GPSLongitude), 0.000001)
+	})
+	t.Run("Merge", func(t *testing.T) {
+		fileName := filepath.Join(t.TempDir(), "photoshop.xmp")
+
+		u := XmpUpdate{
+			Title:    "Day Shift",
+			Keywords: []string{"berlin"},
+		}
+
+		if err := u.Save(fileName, "testdata/photoshop.xmp"); err != nil {
+			t.Fatal(err)
+		}
+
+		data, err := XMP(fileName)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "Day Shift", data.Title)
+		assert.Equal(t, "Example file for development", data.Description)
+		assert.Equal(t, "Michael Mayer", data.Artist)
+		assert.Equal(t, "HUAWEI P30 Rear Main Camera", data.LensModel)
+		assert.Equal(t, time.Date(2020, 1, 1, 17, 28, 25, 729626112, time.UTC), data.TakenAt)
+		assert.Equal(t, Keywords{"berlin"}, data.Keywords)
+
+		doc := XmpDocument{}
+
+		if err = doc.Load(fileName); err != nil {
+			t.Fatal(err)
+		}
+
+		// Existing ratings are kept unless the photo was a favorite.
+		assert.Equal(t, "4", doc.RDF.Description.Rating)
+		assert.Equal(t, "Gopher", doc.RDF.Description.PersonInImage.Bag.Li)
+	})
+	t.Run("Replace", func(t *testing.T) {
+		fileName := filepath.Join(t.TempDir(), "photoshop.xmp")
+
+		u := XmpUpdate{
+			Replace: true,
+			Title:   "Day Shift",
+		}
+
+		if err := u.Save(fileName, "testdata/photoshop.xmp"); err != nil {
+			t.Fatal(err)
+		}
+
+		data, err := XMP(fileName)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// Cleared values are removed, while other metadata is kept.
+		assert.Equal(t, "Day Shift", data.Title)
+		assert.Equal(t, "", data.Description)
+		assert.Empty(t, data.Keywords)
+		assert.Equal(t, "Michael Mayer", data.Artist)
+		assert.Equal(t, "HUAWEI P30 Rear Main Camera", data.LensModel)
+
+		doc := XmpDocument{}
+
+		if err = doc.Load(fileName); err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "4", doc.RDF.Description.Rating)
+		assert.Equal(t, "", doc.RDF.Description.PersonInImage.Bag.Li)
+	})
+	t.Run("Clear", func(t *testing.T) {
+		fileName := filepath.Join(t.TempDir(), "clear.xmp")
+
+		u := XmpUpdate{
+			Replace:     true,
+			Title:       "Cat on a Desk",
+			Description: "Example photo",
+			Keywords:    []string{"cat"},
+			Width:       1000,
+			Height:      500,
+			Regions:     []XmpRegion{{Name: "Jens Mander", Type: "Face", X: 0.1, Y: 0.2, W: 0.2, H: 0.4}},
+		}
+
+		if err := u.Save(fileName, ""); err != nil {
+			t.Fatal(err)
+		}
+
+		// Remove title, description, the last keyword, and the last face.
+		u = XmpUpdate{Replace: true, Width: 1000, Height: 500}
+
+		if err := u.Save(fileName, ""); err != nil {
+			t.Fatal(err)
+		}
+
+		data, err := os.ReadFile(fileName)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		s := string(data)
+
+		assert.NotContains(t, s, "dc:title")
+		assert.NotContains(t, s, "dc:description")
+		assert.NotContains(t, s, "dc:subject")
+		assert.NotContains(t, s, "mwg-rs:Regions")
+		assert.NotContains(t, s, "PersonInImage")
+		assert.NotContains(t, s, "Jens Mander")
+		assert.Contains(t, s, "xmp:MetadataDate")
+	})
+	t.Run("Attributes", func(t *testing.T) {
+		fileName := filepath.Join(t.TempDir(), "darktable.xmp")
+
+		src := `<x:xmpmeta xmlns:x="adobe:ns:meta/" x:xmptk="XMP Core 4.4.0-Exiv2">
+ <rdf:RDF xmlns:rdf="http://www.w3.org/1999/02/22-rdf-syntax-ns#">
+  <rdf:Description rdf:about="" xmlns:xmp="http://ns.adobe.com/xap/1.0/" xmlns:darktable="http://darktable.sf.net/" xmp:Rating="5" darktable:xmp_version="4"/>
+ </rdf:RDF>
+</x:xmpmeta>`
+
+		if err := os.WriteFile(fileName, []byte(src), fs.ModeFile); err != nil {
+			t.Fatal(err)
+		}
+
+		u := XmpUpdate{Title: "Unrated"}
+
+		if err := u.Save(fileName, ""); err != nil {
+			t.Fatal(err)
+		}
+
+		data, err := os.ReadFile(fileName)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		s := string(data)
+
+		assert.Contains(t, s, `x:xmptk="XMP Core 4.4.0-Exiv2"`)
+		assert.Contains(t, s, `darktable:xmp_version="4"`)
+		assert.NotContains(t, s, `Rating`)
+		assert.Contains(t, s, `<dc:title>`)
+		assert.Contains(t, s, `xmlns:dc="http://purl.org/dc/elements/1.1/"`)
+	})
+	t.Run("Rating", func(t *testing.T) {
+		fileName := filepath.Join(t.TempDir(), "rating.xmp")
+
+		rating := func() string {
+			doc := XmpDocument{}
+
+			if err := doc.Load(fileName); err != nil {
+				t.Fatal(err)
+			}
+
+			return doc.RDF.Description.Rating
+		}
+
+		// Existing ratings are not replaced when adding a favorite.
+		if err := (&XmpUpdate{Favorite: true}).Save(fileName, "testdata/photoshop.xmp"); err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "4", rating())
+
+		// Ratings other than 5 stars are kept when removing a favorite.
+		if err := (&XmpUpdate{}).Save(fileName, ""); err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "4", rating())
+
+		// Unrated favorites are rated with 5 stars.
+		src := `<x:xmpmeta xmlns:x="adobe:ns:meta/">
+ <rdf:RDF xmlns:rdf="http://www.w3.org/1999/02/22-rdf-syntax-ns#">
+  <rdf:Description rdf:about="" xmlns:xmp="http://ns.adobe.com/xap/1.0/" xmp:Rating="0"/>
+ </rdf:RDF>
+</x:xmpmeta>`
+
+		if err := os.WriteFile(fileName, []byte(src), fs.ModeFile); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := (&XmpUpdate{Favorite: true}).Save(fileName, ""); err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "5", rating())
+
+		if err := (&XmpUpdate{}).Save(fileName, ""); err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "", rating())
+	})
+	t.Run("InvalidRoot", func(t *testing.T) {
+		fileName := filepath.Join(t.TempDir(), "invalid.xmp")
+
+		if err := os.WriteFile(fileName, []byte("<html><body></body></html>"), fs.ModeFile); err != nil {
+			t.Fatal(err)
+		}
+
+		u := XmpUpdate{Title: "Foo"}
+
+		assert.Error(t, u.Save(fileName, ""))
+	})
+	t.Run("NoFileName", func(t *testing.T) {
+		u := XmpUpdate{Title: "Foo"}
+
+		assert.Error(t, u.Save("", ""))
+	})
+}
+
+func TestXmpUpdate_DateCreated(t *testing.T) {
+	t.Run("TimeZone", func(t *testing.T) {
+		u := XmpUpdate{
+			TakenAt:      time.Date(2021, 7, 24, 12, 7, 29, 0, time.UTC),
+			TakenAtLocal: time.Date(2021, 7, 24, 14, 7, 29, 0, time.UTC),
+			TimeZone:     "Europe/Berlin",
+		}
+
+		assert.Equal(t, "2021-07-24T14:07:29+02:00", u.DateCreated())
+	})
+	t.Run("Local", func(t *testing.T) {
+		u := XmpUpdate{
+			TakenAt:      time.Date(2021, 7, 24, 12, 7, 29, 0, time.UTC),
+			TakenAtLocal: time.Date(2021, 7, 24, 14, 7, 29, 0, time.UTC),
+		}
+
+		assert.Equal(t, "2021-07-24T14:07:29", u.DateCreated())
+	})
+	t.Run("Unknown", func(t *testing.T) {
+		u := XmpUpdate{}
+
+		assert.Equal(t, "", u.DateCreated())
+	})
+}
+
+func TestXmpUpdate_People(t *testing.T) {
+	u := XmpUpdate{Regions: []XmpRegion{
+		{Name: "Jens Mander", Type: "Face"},
+		{Name: "Jens Mander", Type: "Face"},
+		{Name: "Cat", Type: "Pet"},
+		{Type: "Face"},
+	}}
+
+	assert.Equal(t, []string{"Jens Mander"}, u.People())
+}
+
+func TestXmpGps(t *testing.T) {
+	assert.Equal(t, "52,27,34.3836N", XmpGps(52.459551, 'N', 'S'))
+	assert.Equal(t, "33,51,0.0000S", XmpGps(-33.85, 'N', 'S'))
+	assert.Equal(t, "0,0,0.0000E", XmpGps(0, 'E', 'W'))
+	assert.Equal(t, "13,0,0.0000E", XmpGps(12.99999999, 'E', 'W'))
+}
@@ -0,0 +1,48 @@
+package migrate
+
+// Generated code, do not edit.
+
+var DialectPostgres = Migrations{
+	{
+		ID:         "20211121-094727",
+		Dialect:    "postgres",
+		Stage:      "main",
+		Statements: []string{"DROP INDEX IF EXISTS idx_places_place_label;"},
+	},
+	{
+		ID:         "20211124-120008",
+		Dialect:    "postgres",
+		Stage:      "main",
+		Statements: []string{"DROP INDEX IF EXISTS uix_places_place_label;", "DROP INDEX IF EXISTS uix_places_label;"},
+	},
+	{
+		ID:         "20220329-050000",
+		Dialect:    "postgres",
+		Stage:      "main",
+		Statements: []string{"CREATE INDEX IF NOT EXISTS idx_albums_album_filter ON albums (album_filter);"},
+	},
+	{
+		ID:         "20220329-061000",
+		Dialect:    "postgres",
+		Stage:      "main",
+		Statements: []string{"CREATE INDEX IF NOT EXISTS idx_files_photo_id ON files (photo_id, file_primary);"},
+	},
+	{
+		ID:         "20220329-081000",
+		Dialect:    "postgres",
+		Stage:      "main",
+		Statements: []string{"CREATE UNIQUE INDEX IF NOT EXISTS idx_files_search_media ON files (media_id);"},
+	},
+	{
+		ID:         "20220329-091000",
+		Dialect:    "postgres",
+		Stage:      "main",
+		Statements: []string{"CREATE UNIQUE INDEX IF NOT EXISTS idx_files_search_timeline ON files (time_index);"},
+	},
+	{
+		ID:         "20220421-200000",
+		Dialect:    "postgres",
+		Stage:      "main",
+		Statements: []string{"CREATE INDEX IF NOT EXISTS idx_files_missing_root ON files (file_missing, file_root);"},
+	},
+}
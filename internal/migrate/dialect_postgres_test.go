@@ -0,0 +1,25 @@
+package migrate
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDialectPostgres(t *testing.T) {
+	migrations, ok := Dialects[Postgres]
+
+	assert.True(t, ok)
+	assert.NotEmpty(t, migrations)
+
+	for _, m := range migrations {
+		assert.Equal(t, Postgres, m.Dialect)
+		assert.NotEmpty(t, m.Statements)
+
+		// Migrations must not change the behavior of built-in types, e.g. by adding global operators.
+		for _, s := range m.Statements {
+			assert.NotContains(t, strings.ToUpper(s), "CREATE OPERATOR")
+		}
+	}
+}
@@ -0,0 +1 @@
+DROP INDEX IF EXISTS idx_places_place_label;
\ No newline at end of file
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS uix_places_place_label;
+DROP INDEX IF EXISTS uix_places_label;
\ No newline at end of file
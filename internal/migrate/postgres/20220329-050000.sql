@@ -0,0 +1 @@
+CREATE INDEX IF NOT EXISTS idx_albums_album_filter ON albums (album_filter);
\ No newline at end of file
@@ -0,0 +1 @@
+CREATE INDEX IF NOT EXISTS idx_files_photo_id ON files (photo_id, file_primary);
\ No newline at end of file
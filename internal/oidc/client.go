@@ -0,0 +1,193 @@
+package oidc
+
+import (
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"path"
+	"sync"
+	"time"
+
+	"github.com/zitadel/oidc/pkg/client/rp"
+	httphelper "github.com/zitadel/oidc/pkg/http"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/pkg/clean"
+	"github.com/photoprism/photoprism/pkg/rnd"
+)
+
+// Cookie names used by the relying party to store the state and PKCE code verifier.
+const (
+	StateCookie = "state"
+	PkceCookie  = "pkce"
+)
+
+// CookieMaxAge is the time in seconds the user has to complete the authentication with the provider.
+var CookieMaxAge = 600
+
+// Client represents an OpenID Connect relying party.
+type Client struct {
+	conf     *config.Config
+	mutex    sync.Mutex
+	provider rp.RelyingParty
+}
+
+// NewClient creates a new OpenID Connect client based on the config values.
+func NewClient(conf *config.Config) *Client {
+	return &Client{conf: conf}
+}
+
+// Provider returns the relying party and performs issuer discovery if needed.
+func (c *Client) Provider() (rp.RelyingParty, error) {
+	if c.conf == nil {
+		return nil, errors.New("config is nil")
+	} else if !c.conf.OIDCEnabled() {
+		return nil, errors.New("oidc is disabled")
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	// Already initialized?
+	if c.provider != nil {
+		return c.provider, nil
+	}
+
+	redirectUrl := c.conf.OIDCRedirectUrl()
+
+	u, err := url.Parse(redirectUrl)
+
+	if err != nil {
+		return nil, err
+	}
+
+	hashKey, err := rnd.RandomBytes(32)
+
+	if err != nil {
+		return nil, err
+	}
+
+	encryptKey, err := rnd.RandomBytes(32)
+
+	if err != nil {
+		return nil, err
+	}
+
+	// The state and code verifier cookies must be sent with the redirect from the provider.
+	cookieOpts := []httphelper.CookieHandlerOpt{
+		httphelper.WithMaxAge(CookieMaxAge),
+		httphelper.WithPath(path.Dir(u.Path)),
+	}
+
+	if u.Scheme != "https" {
+		cookieOpts = append(cookieOpts, httphelper.WithUnsecure())
+	}
+
+	httpClient := &http.Client{Timeout: 30 * time.Second}
+
+	if c.conf.OIDCInsecure() {
+		httpClient.Transport = &http.Transport{
+			Proxy:           http.ProxyFromEnvironment,
+			TLSClientConfig: &tls.Config{InsecureSkipVerify: true},
+		}
+	}
+
+	provider, err := rp.NewRelyingPartyOIDC(
+		c.conf.OIDCUri(),
+		c.conf.OIDCClient(),
+		c.conf.OIDCSecret(),
+		redirectUrl,
+		c.conf.OIDCScopes(),
+		rp.WithPKCE(httphelper.NewCookieHandler(hashKey, encryptKey, cookieOpts...)),
+		rp.WithHTTPClient(httpClient),
+	)
+
+	if err != nil {
+		return nil, fmt.Errorf("discovery of %s failed (%s)", clean.Log(c.conf.OIDCUri()), err)
+	}
+
+	log.Debugf("oidc: discovered %s", clean.Log(c.conf.OIDCUri()))
+
+	c.provider = provider
+
+	return c.provider, nil
+}
+
+// AuthUrl stores a new state and PKCE code verifier in secure cookies and returns
+// the provider URL to which the user should be redirected for authentication.
+func (c *Client) AuthUrl(w http.ResponseWriter) (string, error) {
+	provider, err := c.Provider()
+
+	if err != nil {
+		return "", err
+	}
+
+	state := rnd.Base62(32)
+
+	if err = provider.CookieHandler().SetCookie(w, StateCookie, state); err != nil {
+		return "", err
+	}
+
+	challenge, err := rp.GenerateAndStoreCodeChallenge(w, provider)
+
+	if err != nil {
+		return "", err
+	}
+
+	return rp.AuthURL(state, provider, rp.WithCodeChallenge(challenge)), nil
+}
+
+// Identity verifies the state, exchanges the authorization code for tokens,
+// and returns the identity of the authenticated user.
+func (c *Client) Identity(w http.ResponseWriter, r *http.Request) (*Identity, error) {
+	provider, err := c.Provider()
+
+	if err != nil {
+		return nil, err
+	}
+
+	cookies := provider.CookieHandler()
+
+	// Check for errors returned by the provider.
+	if e := r.FormValue("error"); e != "" {
+		if desc := r.FormValue("error_description"); desc != "" {
+			return nil, fmt.Errorf("%s (%s)", clean.Log(e), clean.Log(desc))
+		}
+
+		return nil, errors.New(clean.Log(e))
+	}
+
+	// Compare state from cookie and query.
+	if _, err = cookies.CheckQueryCookie(r, StateCookie); err != nil {
+		return nil, fmt.Errorf("invalid state (%s)", err)
+	}
+
+	cookies.DeleteCookie(w, StateCookie)
+
+	// Get PKCE code verifier.
+	verifier, err := cookies.CheckCookie(r, PkceCookie)
+
+	if err != nil {
+		return nil, fmt.Errorf("invalid code verifier (%s)", err)
+	}
+
+	cookies.DeleteCookie(w, PkceCookie)
+
+	// Exchange code for tokens.
+	tokens, err := rp.CodeExchange(r.Context(), r.FormValue("code"), provider, rp.WithCodeVerifier(verifier))
+
+	if err != nil {
+		return nil, fmt.Errorf("code exchange failed (%s)", err)
+	}
+
+	// Fetch user info.
+	info, err := rp.Userinfo(tokens.AccessToken, tokens.TokenType, tokens.IDTokenClaims.GetSubject(), provider)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch user info (%s)", err)
+	}
+
+	return NewIdentity(info), nil
+}
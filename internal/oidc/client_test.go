@@ -0,0 +1,173 @@
+package oidc
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/config"
+)
+
+// newTestIssuer returns a test server that responds to OpenID Connect discovery requests.
+func newTestIssuer() *httptest.Server {
+	var s *httptest.Server
+
+	s = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/.well-known/openid-configuration" {
+			http.NotFound(w, r)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"issuer":                 s.URL,
+			"authorization_endpoint": s.URL + "/authorize",
+			"token_endpoint":         s.URL + "/oauth/token",
+			"userinfo_endpoint":      s.URL + "/userinfo",
+			"jwks_uri":               s.URL + "/keys",
+		})
+	}))
+
+	return s
+}
+
+func TestClient_Provider(t *testing.T) {
+	conf := config.TestConfig()
+
+	t.Run("Disabled", func(t *testing.T) {
+		c := NewClient(conf)
+
+		provider, err := c.Provider()
+
+		assert.Error(t, err)
+		assert.Nil(t, provider)
+	})
+	t.Run("Discovery", func(t *testing.T) {
+		issuer := newTestIssuer()
+		defer issuer.Close()
+
+		conf.SetAuthMode(config.AuthModePasswd)
+		conf.Options().OIDCUri = issuer.URL
+		conf.Options().OIDCClient = "photoprism"
+		defer func() {
+			conf.SetAuthMode(config.AuthModePublic)
+			conf.Options().OIDCUri = ""
+			conf.Options().OIDCClient = ""
+		}()
+
+		c := NewClient(conf)
+
+		provider, err := c.Provider()
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.True(t, provider.IsPKCE())
+		assert.Equal(t, issuer.URL, provider.Issuer())
+		assert.Equal(t, issuer.URL+"/userinfo", provider.UserinfoEndpoint())
+		assert.Equal(t, conf.OIDCRedirectUrl(), provider.OAuthConfig().RedirectURL)
+	})
+	t.Run("DiscoveryFailed", func(t *testing.T) {
+		conf.SetAuthMode(config.AuthModePasswd)
+		conf.Options().OIDCUri = "http://localhost:1/"
+		conf.Options().OIDCClient = "photoprism"
+		defer func() {
+			conf.SetAuthMode(config.AuthModePublic)
+			conf.Options().OIDCUri = ""
+			conf.Options().OIDCClient = ""
+		}()
+
+		c := NewClient(conf)
+
+		provider, err := c.Provider()
+
+		assert.Error(t, err)
+		assert.Nil(t, provider)
+	})
+}
+
+func TestClient_AuthUrl(t *testing.T) {
+	conf := config.TestConfig()
+	issuer := newTestIssuer()
+	defer issuer.Close()
+
+	conf.SetAuthMode(config.AuthModePasswd)
+	conf.Options().OIDCUri = issuer.URL
+	conf.Options().OIDCClient = "photoprism"
+	defer func() {
+		conf.SetAuthMode(config.AuthModePublic)
+		conf.Options().OIDCUri = ""
+		conf.Options().OIDCClient = ""
+	}()
+
+	c := NewClient(conf)
+	w := httptest.NewRecorder()
+
+	authUrl, err := c.AuthUrl(w)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	u, err := url.Parse(authUrl)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, issuer.URL+"/authorize", u.Scheme+"://"+u.Host+u.Path)
+	assert.Equal(t, "photoprism", u.Query().Get("client_id"))
+	assert.Equal(t, "code", u.Query().Get("response_type"))
+	assert.Equal(t, "S256", u.Query().Get("code_challenge_method"))
+	assert.NotEmpty(t, u.Query().Get("code_challenge"))
+	assert.NotEmpty(t, u.Query().Get("state"))
+
+	cookies := w.Result().Cookies()
+
+	if assert.Len(t, cookies, 2) {
+		assert.Equal(t, StateCookie, cookies[0].Name)
+		assert.Equal(t, PkceCookie, cookies[1].Name)
+		assert.Equal(t, "/api/v1/oidc", cookies[0].Path)
+	}
+}
+
+func TestClient_Identity(t *testing.T) {
+	conf := config.TestConfig()
+	issuer := newTestIssuer()
+	defer issuer.Close()
+
+	conf.SetAuthMode(config.AuthModePasswd)
+	conf.Options().OIDCUri = issuer.URL
+	conf.Options().OIDCClient = "photoprism"
+	defer func() {
+		conf.SetAuthMode(config.AuthModePublic)
+		conf.Options().OIDCUri = ""
+		conf.Options().OIDCClient = ""
+	}()
+
+	c := NewClient(conf)
+
+	t.Run("ProviderError", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodGet, "/api/v1/oidc/redirect?error=access_denied", nil)
+
+		id, err := c.Identity(w, r)
+
+		assert.EqualError(t, err, "access_denied")
+		assert.Nil(t, id)
+	})
+	t.Run("InvalidState", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodGet, "/api/v1/oidc/redirect?code=123&state=abc", nil)
+
+		id, err := c.Identity(w, r)
+
+		assert.Error(t, err)
+		assert.Nil(t, id)
+	})
+}
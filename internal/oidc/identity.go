@@ -0,0 +1,93 @@
+package oidc
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/zitadel/oidc/pkg/oidc"
+
+	"github.com/photoprism/photoprism/internal/acl"
+	"github.com/photoprism/photoprism/pkg/clean"
+)
+
+// Identity represents a user authenticated by an OpenID Connect provider.
+type Identity struct {
+	Subject       string
+	Username      string
+	Email         string
+	EmailVerified bool
+	Name          string
+	Claims        map[string]interface{}
+}
+
+// NewIdentity creates a new identity from the user info returned by the provider.
+func NewIdentity(info oidc.UserInfo) *Identity {
+	if info == nil {
+		return &Identity{}
+	}
+
+	return &Identity{
+		Subject:       info.GetSubject(),
+		Username:      info.GetPreferredUsername(),
+		Email:         info.GetEmail(),
+		EmailVerified: info.IsEmailVerified(),
+		Name:          info.GetName(),
+		Claims:        info.GetClaims(),
+	}
+}
+
+// String returns an identifier that can be used in logs.
+func (id *Identity) String() string {
+	if n := id.UserName(); n != "" {
+		return clean.LogQuote(n)
+	}
+
+	return clean.Log(id.Subject)
+}
+
+// UserName returns the preferred username or the email address if no username was provided.
+func (id *Identity) UserName() string {
+	if n := clean.Username(id.Username); n != "" {
+		return n
+	}
+
+	return clean.Email(id.Email)
+}
+
+// VerifiedEmail returns the email address if it has been verified by the provider.
+func (id *Identity) VerifiedEmail() string {
+	if !id.EmailVerified {
+		return ""
+	}
+
+	return clean.Email(id.Email)
+}
+
+// Role returns the user role found in the specified claim, which may contain
+// a single value or a list of values, e.g. group names.
+func (id *Identity) Role(claim string) acl.Role {
+	if claim == "" || id.Claims == nil {
+		return acl.RoleUnknown
+	}
+
+	var values []string
+
+	switch v := id.Claims[claim].(type) {
+	case string:
+		values = strings.Fields(strings.ReplaceAll(v, ",", " "))
+	case []string:
+		values = v
+	case []interface{}:
+		for _, s := range v {
+			values = append(values, fmt.Sprintf("%v", s))
+		}
+	}
+
+	for _, s := range values {
+		if role := acl.ValidRoles[clean.Role(s)]; role != acl.RoleUnknown {
+			return role
+		}
+	}
+
+	return acl.RoleUnknown
+}
@@ -0,0 +1,81 @@
+package oidc
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/zitadel/oidc/pkg/oidc"
+
+	"github.com/photoprism/photoprism/internal/acl"
+)
+
+func TestNewIdentity(t *testing.T) {
+	t.Run("UserInfo", func(t *testing.T) {
+		info := oidc.NewUserInfo()
+		info.SetSubject("5a3c1b2e-7f5d-4b9a-8c1e-2f6d3a4b5c6d")
+		info.SetPreferredUsername("Jens.Mander")
+		info.SetEmail("jens.mander@example.com", true)
+		info.SetName("Jens Mander")
+		info.AppendClaims("role", "admin")
+
+		id := NewIdentity(info)
+
+		assert.Equal(t, "5a3c1b2e-7f5d-4b9a-8c1e-2f6d3a4b5c6d", id.Subject)
+		assert.Equal(t, "jens.mander", id.UserName())
+		assert.Equal(t, "jens.mander@example.com", id.VerifiedEmail())
+		assert.Equal(t, "Jens Mander", id.Name)
+		assert.Equal(t, acl.RoleAdmin, id.Role("role"))
+	})
+	t.Run("Nil", func(t *testing.T) {
+		id := NewIdentity(nil)
+
+		assert.Equal(t, "", id.Subject)
+		assert.Equal(t, "", id.UserName())
+	})
+}
+
+func TestIdentity_UserName(t *testing.T) {
+	t.Run("PreferredUsername", func(t *testing.T) {
+		id := Identity{Username: "Jens", Email: "jens.mander@example.com"}
+		assert.Equal(t, "jens", id.UserName())
+	})
+	t.Run("Email", func(t *testing.T) {
+		id := Identity{Email: "Jens.Mander@example.com"}
+		assert.Equal(t, "jens.mander@example.com", id.UserName())
+	})
+	t.Run("Empty", func(t *testing.T) {
+		id := Identity{}
+		assert.Equal(t, "", id.UserName())
+	})
+}
+
+func TestIdentity_VerifiedEmail(t *testing.T) {
+	t.Run("Verified", func(t *testing.T) {
+		id := Identity{Email: "jens.mander@example.com", EmailVerified: true}
+		assert.Equal(t, "jens.mander@example.com", id.VerifiedEmail())
+	})
+	t.Run("NotVerified", func(t *testing.T) {
+		id := Identity{Email: "jens.mander@example.com"}
+		assert.Equal(t, "", id.VerifiedEmail())
+	})
+}
+
+func TestIdentity_Role(t *testing.T) {
+	t.Run("String", func(t *testing.T) {
+		id := Identity{Claims: map[string]interface{}{"role": "Admin"}}
+		assert.Equal(t, acl.RoleAdmin, id.Role("role"))
+	})
+	t.Run("List", func(t *testing.T) {
+		id := Identity{Claims: map[string]interface{}{"groups": []interface{}{"staff", "admin"}}}
+		assert.Equal(t, acl.RoleAdmin, id.Role("groups"))
+	})
+	t.Run("Invalid", func(t *testing.T) {
+		id := Identity{Claims: map[string]interface{}{"role": "superuser"}}
+		assert.Equal(t, acl.RoleUnknown, id.Role("role"))
+	})
+	t.Run("Missing", func(t *testing.T) {
+		id := Identity{Claims: map[string]interface{}{"role": "admin"}}
+		assert.Equal(t, acl.RoleUnknown, id.Role("groups"))
+		assert.Equal(t, acl.RoleUnknown, id.Role(""))
+	})
+}
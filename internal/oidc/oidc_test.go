@@ -0,0 +1,24 @@
+package oidc
+
+import (
+	"os"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/event"
+)
+
+func TestMain(m *testing.M) {
+	log = logrus.StandardLogger()
+	log.SetLevel(logrus.TraceLevel)
+	event.AuditLog = log
+
+	c := config.TestConfig()
+	defer c.CloseDb()
+
+	code := m.Run()
+
+	os.Exit(code)
+}
@@ -0,0 +1,114 @@
+package oidc
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/photoprism/photoprism/internal/acl"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/event"
+	"github.com/photoprism/photoprism/pkg/authn"
+	"github.com/photoprism/photoprism/pkg/clean"
+)
+
+// User returns the account linked to the identity. Existing accounts with a matching username
+// and verified email are linked on first login if enabled, and new accounts are created if registration is enabled.
+func (c *Client) User(id *Identity) (m *entity.User, err error) {
+	if id == nil || id.Subject == "" {
+		return nil, errors.New("subject is missing")
+	}
+
+	// Find account linked to the subject.
+	if m = entity.FindUser(entity.User{AuthProvider: authn.ProviderOIDC.String(), AuthID: id.Subject}); m != nil {
+		// Account found.
+	} else if m, err = c.linkUser(id); err != nil {
+		return nil, err
+	} else if m == nil {
+		if m, err = c.createUser(id); err != nil {
+			return nil, err
+		}
+	}
+
+	// Update role if a role claim is configured. Logins without a valid role are rejected
+	// like for new accounts, so that existing accounts are never demoted by mistake.
+	if claim := c.conf.OIDCRole(); claim != "" && !m.SuperAdmin {
+		if role := id.Role(claim); role == acl.RoleUnknown {
+			event.AuditWarn([]string{"user %s", "claim %s does not contain a valid role", "keeping role %s"}, m.RefID, clean.Log(claim), clean.LogQuote(m.AclRole().String()))
+			return nil, fmt.Errorf("claim %s of %s does not contain a valid role", clean.Log(claim), id.String())
+		} else if role.NotEqual(m.AclRole().String()) {
+			if err = m.Updates(entity.Values{"user_role": role.String()}); err != nil {
+				return nil, err
+			}
+
+			event.AuditInfo([]string{"user %s", "role changed to %s"}, m.RefID, clean.LogQuote(role.String()))
+		}
+	}
+
+	return m, nil
+}
+
+// linkUser links an existing account with a matching username and verified email address to the identity.
+func (c *Client) linkUser(id *Identity) (*entity.User, error) {
+	name := id.UserName()
+
+	if name == "" {
+		return nil, errors.New("username is missing")
+	}
+
+	m := entity.FindUserByName(name)
+
+	if m == nil {
+		return nil, nil
+	} else if !c.conf.OIDCLink() {
+		return nil, fmt.Errorf("account %s already exists and linking is disabled", m.String())
+	} else if m.HasProvider(authn.ProviderOIDC) {
+		return nil, fmt.Errorf("account %s is linked to another identity", m.String())
+	} else if email := id.VerifiedEmail(); email == "" || email != m.Email() {
+		return nil, fmt.Errorf("account %s cannot be linked because the verified email does not match", m.String())
+	}
+
+	values := entity.Values{
+		"auth_provider": authn.ProviderOIDC.String(),
+		"auth_id":       id.Subject,
+	}
+
+	if err := m.Updates(values); err != nil {
+		return nil, err
+	}
+
+	event.AuditInfo([]string{"user %s", "linked to %s identity %s"}, m.RefID, authn.ProviderOIDC.Pretty(), clean.Log(id.Subject))
+
+	return m, nil
+}
+
+// createUser creates a new account for the identity if registration is enabled.
+func (c *Client) createUser(id *Identity) (*entity.User, error) {
+	if !c.conf.OIDCRegister() {
+		return nil, fmt.Errorf("account %s not found and registration is disabled", id.String())
+	}
+
+	m := entity.OidcUser(id.UserName(), id.Subject)
+
+	m.UserEmail = id.VerifiedEmail()
+	m.DisplayName = clean.Name(id.Name)
+	m.CanLogin = true
+
+	// New accounts get the least privileged role unless a role claim is configured.
+	if claim := c.conf.OIDCRole(); claim == "" {
+		m.UserRole = acl.RoleGuest.String()
+	} else if role := id.Role(claim); role == acl.RoleUnknown {
+		return nil, fmt.Errorf("claim %s of %s does not contain a valid role", clean.Log(claim), id.String())
+	} else {
+		m.UserRole = role.String()
+	}
+
+	if err := m.Validate(); err != nil {
+		return nil, err
+	} else if err = m.Create(); err != nil {
+		return nil, err
+	}
+
+	event.AuditInfo([]string{"user %s", "created by %s identity %s"}, m.RefID, authn.ProviderOIDC.Pretty(), clean.Log(id.Subject))
+
+	return &m, nil
+}
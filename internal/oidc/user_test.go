@@ -0,0 +1,211 @@
+package oidc
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/acl"
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/pkg/authn"
+)
+
+func TestClient_User(t *testing.T) {
+	conf := config.TestConfig()
+	c := NewClient(conf)
+
+	t.Run("Create", func(t *testing.T) {
+		conf.Options().OIDCRegister = true
+		defer func() { conf.Options().OIDCRegister = false }()
+
+		id := &Identity{
+			Subject:       "c1d2e3f4-0001-4000-8000-000000000001",
+			Username:      "oidc-create",
+			Email:         "oidc-create@example.com",
+			EmailVerified: true,
+			Name:          "Created User",
+		}
+
+		m, err := c.User(id)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.NotEmpty(t, m.UserUID)
+		assert.Equal(t, "oidc-create", m.UserName)
+		assert.Equal(t, "oidc-create@example.com", m.UserEmail)
+		assert.Equal(t, "Created User", m.DisplayName)
+		assert.Equal(t, authn.ProviderOIDC, m.Provider())
+		assert.Equal(t, id.Subject, m.AuthID)
+		assert.Equal(t, acl.RoleGuest, m.AclRole())
+		assert.True(t, m.CanLogIn())
+
+		// Find existing account.
+		found, err := c.User(id)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, m.ID, found.ID)
+	})
+	t.Run("RegistrationDisabled", func(t *testing.T) {
+		id := &Identity{
+			Subject:  "c1d2e3f4-0002-4000-8000-000000000002",
+			Username: "oidc-unknown",
+		}
+
+		m, err := c.User(id)
+
+		assert.Error(t, err)
+		assert.Nil(t, m)
+	})
+	t.Run("LinkDisabled", func(t *testing.T) {
+		id := &Identity{
+			Subject:       "c1d2e3f4-0008-4000-8000-000000000008",
+			Username:      "friend",
+			Email:         "friend@example.com",
+			EmailVerified: true,
+		}
+
+		m, err := c.User(id)
+
+		assert.EqualError(t, err, "account 'friend' already exists and linking is disabled")
+		assert.Nil(t, m)
+	})
+	t.Run("Link", func(t *testing.T) {
+		conf.Options().OIDCLink = true
+		defer func() { conf.Options().OIDCLink = false }()
+
+		id := &Identity{
+			Subject:       "c1d2e3f4-0003-4000-8000-000000000003",
+			Username:      "friend",
+			Email:         "friend@example.com",
+			EmailVerified: true,
+		}
+
+		m, err := c.User(id)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "friend", m.UserName)
+		assert.Equal(t, authn.ProviderOIDC, m.Provider())
+		assert.Equal(t, id.Subject, m.AuthID)
+
+		// Another identity must not take over the account.
+		other := &Identity{
+			Subject:       "c1d2e3f4-0004-4000-8000-000000000004",
+			Username:      "friend",
+			Email:         "friend@example.com",
+			EmailVerified: true,
+		}
+
+		m, err = c.User(other)
+
+		assert.Error(t, err)
+		assert.Nil(t, m)
+	})
+	t.Run("EmailMismatch", func(t *testing.T) {
+		conf.Options().OIDCLink = true
+		defer func() { conf.Options().OIDCLink = false }()
+
+		id := &Identity{
+			Subject:       "c1d2e3f4-0005-4000-8000-000000000005",
+			Username:      "bob",
+			Email:         "someone@example.com",
+			EmailVerified: true,
+		}
+
+		m, err := c.User(id)
+
+		assert.Error(t, err)
+		assert.Nil(t, m)
+	})
+	t.Run("EmailNotVerified", func(t *testing.T) {
+		conf.Options().OIDCLink = true
+		defer func() { conf.Options().OIDCLink = false }()
+
+		id := &Identity{
+			Subject:  "c1d2e3f4-0006-4000-8000-000000000006",
+			Username: "bob",
+			Email:    "bob@example.com",
+		}
+
+		m, err := c.User(id)
+
+		assert.Error(t, err)
+		assert.Nil(t, m)
+	})
+	t.Run("RoleClaim", func(t *testing.T) {
+		conf.Options().OIDCRegister = true
+		conf.Options().OIDCRole = "groups"
+		defer func() {
+			conf.Options().OIDCRegister = false
+			conf.Options().OIDCRole = ""
+		}()
+
+		id := &Identity{
+			Subject:  "c1d2e3f4-0007-4000-8000-000000000007",
+			Username: "oidc-role",
+			Claims:   map[string]interface{}{"groups": []interface{}{"staff"}},
+		}
+
+		// No account is created without a valid role.
+		m, err := c.User(id)
+
+		assert.Error(t, err)
+		assert.Nil(t, m)
+
+		id.Claims["groups"] = []interface{}{"staff", "admin"}
+
+		m, err = c.User(id)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, acl.RoleAdmin, m.AclRole())
+		assert.True(t, m.CanLogIn())
+
+		// Existing accounts are not demoted if the claim contains no valid role.
+		id.Claims["groups"] = []interface{}{"staff"}
+
+		m, err = c.User(id)
+
+		assert.Error(t, err)
+		assert.Nil(t, m)
+
+		if m = entity.FindUserByName("oidc-role"); assert.NotNil(t, m) {
+			assert.Equal(t, acl.RoleAdmin, m.AclRole())
+		}
+
+		delete(id.Claims, "groups")
+
+		m, err = c.User(id)
+
+		assert.Error(t, err)
+		assert.Nil(t, m)
+
+		// Role is updated on the next login with a valid role.
+		id.Claims["groups"] = []interface{}{"guest"}
+
+		m, err = c.User(id)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, acl.RoleGuest, m.AclRole())
+		assert.True(t, m.CanLogIn())
+	})
+	t.Run("NoSubject", func(t *testing.T) {
+		m, err := c.User(&Identity{Username: "oidc-nosubject"})
+
+		assert.Error(t, err)
+		assert.Nil(t, m)
+	})
+}
@@ -0,0 +1,405 @@
+package photoprism
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/pkg/clean"
+	"github.com/photoprism/photoprism/pkg/fs"
+)
+
+// Index backup file extensions.
+const (
+	BackupExt    = ".sql"
+	BackupGzExt  = ".sql.gz"
+	BackupIncExt = ".sql.inc.gz"
+)
+
+// backupTrailers contains the lines that complete a valid dump for each supported database.
+var backupTrailers = []string{
+	"-- Dump completed",                    // MariaDB and MySQL
+	"-- PostgreSQL database dump complete", // PostgreSQL
+	"COMMIT;",                              // SQLite
+}
+
+// BackupFile represents an index backup file.
+type BackupFile struct {
+	FileName    string
+	Size        int64
+	ModTime     time.Time
+	Compressed  bool
+	Incremental bool
+	Base        string
+}
+
+// Name returns the backup file name without path.
+func (b BackupFile) Name() string {
+	return filepath.Base(b.FileName)
+}
+
+// BackupFiles represents a list of index backup files.
+type BackupFiles []BackupFile
+
+// BackupFileName returns a new index backup file name based on the current time.
+func BackupFileName(backupPath string, compress bool) string {
+	if compress {
+		return filepath.Join(backupPath, time.Now().UTC().Format("2006-01-02-150405")+BackupGzExt)
+	}
+
+	return filepath.Join(backupPath, time.Now().UTC().Format("2006-01-02")+BackupExt)
+}
+
+// backupCmd returns the command that creates an index database dump.
+func backupCmd(c *config.Config) (cmd *exec.Cmd, err error) {
+	switch c.DatabaseDriver() {
+	case config.MySQL, config.MariaDB:
+		cmd = exec.Command(
+			c.MysqldumpBin(),
+			"--protocol", "tcp",
+			"-h", c.DatabaseHost(),
+			"-P", c.DatabasePortString(),
+			"-u", c.DatabaseUser(),
+			"-p"+c.DatabasePassword(),
+		)
+
+		// Write one row per line, so that incremental backups only contain the changed rows.
+		if c.BackupIncremental() > 0 {
+			cmd.Args = append(cmd.Args, "--skip-extended-insert")
+		}
+
+		cmd.Args = append(cmd.Args, c.DatabaseName())
+	case config.Postgres:
+		cmd = exec.Command(
+			c.PgDumpBin(),
+			"--clean",
+			"--if-exists",
+			"--no-owner",
+			"-h", c.DatabaseHost(),
+			"-p", c.DatabasePortString(),
+			"-U", c.DatabaseUser(),
+			c.DatabaseName(),
+		)
+		cmd.Env = append(os.Environ(), "PGPASSWORD="+c.DatabasePassword())
+	case config.SQLite3:
+		cmd = exec.Command(
+			c.SqliteBin(),
+			c.DatabaseFile(),
+			".dump",
+		)
+	default:
+		return nil, fmt.Errorf("unsupported database type: %s", c.DatabaseDriver())
+	}
+
+	return cmd, nil
+}
+
+// BackupIndex writes an index database dump to w.
+func BackupIndex(w io.Writer) error {
+	cmd, err := backupCmd(Config())
+
+	if err != nil {
+		return err
+	}
+
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	cmd.Stdout = w
+
+	// Log exact command for debugging in trace mode.
+	log.Trace(cmd.String())
+
+	// Run backup command.
+	if err = cmd.Run(); err != nil {
+		if s := strings.TrimSpace(stderr.String()); s != "" {
+			return errors.New(s)
+		}
+
+		return err
+	}
+
+	return nil
+}
+
+// BackupIndexFile creates a full index backup file, which is gzip compressed if the
+// file name ends with ".gz", and verifies its integrity.
+func BackupIndexFile(fileName string, force bool) (err error) {
+	if _, err = os.Stat(fileName); err == nil && !force {
+		return fmt.Errorf("%s already exists", clean.Log(fileName))
+	} else if err == nil {
+		log.Warnf("backup: replacing %s", clean.Log(filepath.Base(fileName)))
+	}
+
+	// Create backup directory if not exists.
+	if err = os.MkdirAll(filepath.Dir(fileName), fs.ModeDir); err != nil {
+		return err
+	}
+
+	// Write to a temporary file first, so that incomplete backups never replace existing files.
+	tmpName := fileName + ".tmp"
+
+	f, err := os.OpenFile(tmpName, os.O_TRUNC|os.O_RDWR|os.O_CREATE, fs.ModeFile)
+
+	if err != nil {
+		return fmt.Errorf("failed to create %s: %s", clean.Log(tmpName), err)
+	}
+
+	defer os.Remove(tmpName)
+
+	log.Infof("backup: writing index to %s", clean.Log(fileName))
+
+	if strings.HasSuffix(fileName, ".gz") {
+		zw := gzip.NewWriter(f)
+
+		if err = BackupIndex(zw); err == nil {
+			err = zw.Close()
+		}
+	} else {
+		err = BackupIndex(f)
+	}
+
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+
+	if err != nil {
+		return err
+	} else if err = VerifyBackup(tmpName); err != nil {
+		return fmt.Errorf("%s (verify %s)", err, clean.Log(filepath.Base(fileName)))
+	}
+
+	return os.Rename(tmpName, fileName)
+}
+
+// backupCheck inspects the content of an index backup.
+type backupCheck struct {
+	size   int64
+	tables bool
+	tail   []byte
+}
+
+// Write implements io.Writer.
+func (b *backupCheck) Write(p []byte) (n int, err error) {
+	b.size += int64(len(p))
+
+	buf := append(b.tail, p...)
+
+	if !b.tables && bytes.Contains(buf, []byte("CREATE TABLE")) {
+		b.tables = true
+	}
+
+	if len(buf) > 256 {
+		buf = buf[len(buf)-256:]
+	}
+
+	b.tail = append(b.tail[:0], buf...)
+
+	return len(p), nil
+}
+
+// complete checks if the last line of the backup indicates a complete dump.
+func (b *backupCheck) complete() bool {
+	lines := strings.Split(strings.TrimSpace(string(b.tail)), "\n")
+	last := strings.TrimSpace(lines[len(lines)-1])
+
+	for _, s := range backupTrailers {
+		if strings.HasPrefix(last, s) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// backupReader reads the complete database dump of an index backup.
+type backupReader struct {
+	io.Reader
+	closers []io.Closer
+}
+
+// Close closes the backup file.
+func (b *backupReader) Close() (err error) {
+	for _, c := range b.closers {
+		if closeErr := c.Close(); err == nil {
+			err = closeErr
+		}
+	}
+
+	return err
+}
+
+// OpenBackup opens an index backup file and returns the complete database dump, i.e. compressed backups
+// are decompressed and incremental backups are combined with their base in the same folder.
+func OpenBackup(fileName string) (io.ReadCloser, error) {
+	f, err := os.Open(fileName)
+
+	if err != nil {
+		return nil, err
+	}
+
+	result := &backupReader{closers: []io.Closer{f}}
+	br := bufio.NewReader(f)
+
+	// Detect gzip compression based on the file header.
+	if magic, _ := br.Peek(2); bytes.Equal(magic, []byte{0x1f, 0x8b}) {
+		zr, err := gzip.NewReader(br)
+
+		if err != nil {
+			f.Close()
+			return nil, fmt.Errorf("invalid gzip file (%s)", err)
+		}
+
+		result.closers = append([]io.Closer{zr}, result.closers...)
+		br = bufio.NewReader(zr)
+	}
+
+	base, checksum, err := backupIncInfo(br)
+
+	if err != nil {
+		result.Close()
+		return nil, err
+	} else if base == "" {
+		result.Reader = br
+		return result, nil
+	}
+
+	// Restore the complete dump from the base and the changes in the incremental backup.
+	pr, pw := io.Pipe()
+
+	go func() {
+		pw.CloseWithError(restoreIncrement(pw, br, filepath.Join(filepath.Dir(fileName), base), checksum))
+	}()
+
+	result.Reader = pr
+	result.closers = append([]io.Closer{pr}, result.closers...)
+
+	return result, nil
+}
+
+// VerifyBackup checks the integrity of an index backup file.
+func VerifyBackup(fileName string) error {
+	r, err := OpenBackup(fileName)
+
+	if err != nil {
+		return err
+	}
+
+	defer r.Close()
+
+	check := &backupCheck{}
+
+	// Reading a backup until the end also verifies the gzip and incremental backup checksums.
+	if _, err = io.Copy(check, r); err != nil {
+		return err
+	} else if check.size == 0 {
+		return fmt.Errorf("backup is empty")
+	} else if !check.tables {
+		return fmt.Errorf("backup contains no tables")
+	} else if !check.complete() {
+		return fmt.Errorf("backup is incomplete")
+	}
+
+	return nil
+}
+
+// Backups returns the index backup files in the specified path, newest first.
+func Backups(backupPath string) (result BackupFiles, err error) {
+	entries, err := os.ReadDir(backupPath)
+
+	if os.IsNotExist(err) {
+		return result, nil
+	} else if err != nil {
+		return result, err
+	}
+
+	for _, entry := range entries {
+		name := entry.Name()
+
+		if entry.IsDir() || strings.HasPrefix(name, ".") {
+			continue
+		} else if !strings.HasSuffix(name, BackupExt) && !strings.HasSuffix(name, BackupGzExt) && !strings.HasSuffix(name, BackupIncExt) {
+			continue
+		}
+
+		info, err := entry.Info()
+
+		if err != nil {
+			continue
+		}
+
+		b := BackupFile{
+			FileName:    filepath.Join(backupPath, name),
+			Size:        info.Size(),
+			ModTime:     info.ModTime(),
+			Compressed:  !strings.HasSuffix(name, BackupExt),
+			Incremental: strings.HasSuffix(name, BackupIncExt),
+		}
+
+		if b.Incremental {
+			if b.Base, _, err = readBackupIncInfo(b.FileName); err != nil {
+				log.Warnf("backup: %s in %s", err, clean.Log(name))
+			}
+		}
+
+		result = append(result, b)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].ModTime.Equal(result[j].ModTime) {
+			return result[i].FileName > result[j].FileName
+		}
+
+		return result[i].ModTime.After(result[j].ModTime)
+	})
+
+	return result, nil
+}
+
+// RotateBackups removes index backup files in the specified path so that only the newest full backups
+// and the incremental backups based on them remain.
+func RotateBackups(backupPath string, retain int) (removed int, err error) {
+	if retain <= 0 {
+		return 0, nil
+	}
+
+	backups, err := Backups(backupPath)
+
+	if err != nil {
+		return 0, err
+	}
+
+	// Find the full backups to keep.
+	keep := make(map[string]bool, retain)
+
+	for _, b := range backups {
+		if len(keep) >= retain {
+			break
+		} else if !b.Incremental {
+			keep[b.Name()] = true
+		}
+	}
+
+	for _, b := range backups {
+		if b.Incremental && keep[b.Base] || !b.Incremental && keep[b.Name()] {
+			continue
+		} else if err = os.Remove(b.FileName); err != nil {
+			return removed, err
+		}
+
+		log.Infof("backup: removed %s", clean.Log(b.Name()))
+
+		removed++
+	}
+
+	return removed, nil
+}
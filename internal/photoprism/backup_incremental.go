@@ -0,0 +1,359 @@
+package photoprism
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/photoprism/photoprism/pkg/clean"
+	"github.com/photoprism/photoprism/pkg/fs"
+)
+
+// Incremental index backups only contain the lines of a database dump that have changed compared
+// to a full backup, which is called the base. They start with a header followed by a list of operations:
+//
+//	-- PhotoPrism incremental index backup
+//	-- Base: 2023-01-02-030000.sql.gz
+//	-- Checksum: <SHA-256 of the complete dump>
+//	=<n> copies the next n lines of the base
+//	-<n> skips the next n lines of the base
+//	+<line> adds a line that is not in the base
+const (
+	backupIncHeader   = "-- PhotoPrism incremental index backup"
+	backupIncBase     = "-- Base: "
+	backupIncChecksum = "-- Checksum: "
+)
+
+// BackupIncFileName returns a new incremental index backup file name based on the current time.
+func BackupIncFileName(backupPath string) string {
+	return filepath.Join(backupPath, time.Now().UTC().Format("2006-01-02-150405")+BackupIncExt)
+}
+
+// BackupBase returns the file name of the most recent full index backup in the specified path,
+// which can be used as base for incremental backups, and the number of incremental backups created since.
+func BackupBase(backupPath string) (base string, increments int, err error) {
+	backups, err := Backups(backupPath)
+
+	if err != nil {
+		return "", 0, err
+	}
+
+	for _, b := range backups {
+		if !b.Incremental {
+			return b.FileName, increments, nil
+		}
+
+		increments++
+	}
+
+	return "", 0, nil
+}
+
+// BackupIndexIncrement creates an incremental index backup that only contains the changes
+// compared to the specified full backup, and verifies its integrity.
+func BackupIndexIncrement(fileName, baseName string, force bool) (err error) {
+	if _, err = os.Stat(fileName); err == nil && !force {
+		return fmt.Errorf("%s already exists", clean.Log(fileName))
+	} else if err == nil {
+		log.Warnf("backup: replacing %s", clean.Log(filepath.Base(fileName)))
+	}
+
+	if filepath.Dir(fileName) != filepath.Dir(baseName) {
+		return fmt.Errorf("incremental backups must be in the same folder as %s", clean.Log(filepath.Base(baseName)))
+	}
+
+	// Create backup directory if not exists.
+	if err = os.MkdirAll(filepath.Dir(fileName), fs.ModeDir); err != nil {
+		return err
+	}
+
+	// Create a complete database dump first, which is then compared with the base.
+	dumpName := fileName + ".sql.tmp"
+
+	dump, err := os.OpenFile(dumpName, os.O_TRUNC|os.O_RDWR|os.O_CREATE, fs.ModeFile)
+
+	if err != nil {
+		return fmt.Errorf("failed to create %s: %s", clean.Log(dumpName), err)
+	}
+
+	defer os.Remove(dumpName)
+
+	log.Infof("backup: writing index changes since %s to %s", clean.Log(filepath.Base(baseName)), clean.Log(fileName))
+
+	checksum := sha256.New()
+
+	err = BackupIndex(io.MultiWriter(dump, checksum))
+
+	if closeErr := dump.Close(); err == nil {
+		err = closeErr
+	}
+
+	if err != nil {
+		return err
+	} else if err = VerifyBackup(dumpName); err != nil {
+		return fmt.Errorf("%s (verify dump)", err)
+	}
+
+	// Write the changes to a temporary file, so that incomplete backups never replace existing files.
+	tmpName := fileName + ".tmp"
+
+	defer os.Remove(tmpName)
+
+	if err = writeBackupIncrement(tmpName, dumpName, baseName, hex.EncodeToString(checksum.Sum(nil))); err != nil {
+		return err
+	} else if err = VerifyBackup(tmpName); err != nil {
+		return fmt.Errorf("%s (verify %s)", err, clean.Log(filepath.Base(fileName)))
+	}
+
+	return os.Rename(tmpName, fileName)
+}
+
+// backupLineHashes returns the hashes of all lines in a full index backup, and the index of each line that is unique.
+func backupLineHashes(baseName string) (hashes []uint64, unique map[uint64]int, err error) {
+	f, err := OpenBackup(baseName)
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	defer f.Close()
+
+	r := bufio.NewReader(f)
+	unique = make(map[uint64]int)
+
+	for {
+		line, readErr := r.ReadBytes('\n')
+
+		if len(line) > 0 {
+			h := lineHash(line)
+
+			if _, found := unique[h]; found {
+				unique[h] = -1
+			} else {
+				unique[h] = len(hashes)
+			}
+
+			hashes = append(hashes, h)
+		}
+
+		if readErr == io.EOF {
+			return hashes, unique, nil
+		} else if readErr != nil {
+			return nil, nil, readErr
+		}
+	}
+}
+
+// writeBackupIncrement compares a database dump with a full backup and writes the changes to a compressed file.
+func writeBackupIncrement(fileName, dumpName, baseName, checksum string) error {
+	base, unique, err := backupLineHashes(baseName)
+
+	if err != nil {
+		return fmt.Errorf("%s (read %s)", err, clean.Log(filepath.Base(baseName)))
+	}
+
+	dump, err := os.Open(dumpName)
+
+	if err != nil {
+		return err
+	}
+
+	defer dump.Close()
+
+	f, err := os.OpenFile(fileName, os.O_TRUNC|os.O_RDWR|os.O_CREATE, fs.ModeFile)
+
+	if err != nil {
+		return fmt.Errorf("failed to create %s: %s", clean.Log(fileName), err)
+	}
+
+	defer f.Close()
+
+	zw := gzip.NewWriter(f)
+	w := bufio.NewWriter(zw)
+
+	fmt.Fprintf(w, "%s\n%s%s\n%s%s\n", backupIncHeader, backupIncBase, filepath.Base(baseName), backupIncChecksum, checksum)
+
+	r := bufio.NewReader(dump)
+
+	// Position in the base and number of base lines that have not been written yet.
+	pos, copied := 0, 0
+
+	flush := func() {
+		if copied > 0 {
+			fmt.Fprintf(w, "=%d\n", copied)
+			copied = 0
+		}
+	}
+
+	for {
+		line, readErr := r.ReadBytes('\n')
+
+		if len(line) > 0 {
+			h := lineHash(line)
+
+			if pos < len(base) && base[pos] == h {
+				// Line is unchanged.
+				copied++
+				pos++
+			} else if i, found := unique[h]; found && i > pos {
+				// Lines were removed from the base.
+				flush()
+				fmt.Fprintf(w, "-%d\n", i-pos)
+				copied = 1
+				pos = i + 1
+			} else {
+				// Line was added or changed.
+				flush()
+				w.WriteByte('+')
+				w.Write(line)
+			}
+		}
+
+		if readErr == io.EOF {
+			break
+		} else if readErr != nil {
+			return readErr
+		}
+	}
+
+	flush()
+
+	if err = w.Flush(); err != nil {
+		return err
+	} else if err = zw.Close(); err != nil {
+		return err
+	}
+
+	return f.Close()
+}
+
+// lineHash returns a 64-bit hash of a line for comparing backups.
+func lineHash(line []byte) uint64 {
+	h := fnv.New64a()
+	_, _ = h.Write(line)
+	return h.Sum64()
+}
+
+// backupIncInfo returns the base file name and checksum from the header of an incremental backup,
+// or an empty base if it is not an incremental backup.
+func backupIncInfo(r *bufio.Reader) (base, checksum string, err error) {
+	if header, _ := r.Peek(len(backupIncHeader)); string(header) != backupIncHeader {
+		return "", "", nil
+	}
+
+	for _, prefix := range []string{backupIncHeader, backupIncBase, backupIncChecksum} {
+		line, err := r.ReadString('\n')
+
+		if err != nil || !strings.HasPrefix(line, prefix) {
+			return "", "", fmt.Errorf("invalid incremental backup header")
+		}
+
+		switch prefix {
+		case backupIncBase:
+			base = strings.TrimSpace(strings.TrimPrefix(line, prefix))
+		case backupIncChecksum:
+			checksum = strings.TrimSpace(strings.TrimPrefix(line, prefix))
+		}
+	}
+
+	if base == "" || base != filepath.Base(base) || strings.HasSuffix(base, BackupIncExt) {
+		return "", "", fmt.Errorf("invalid incremental backup base %s", clean.Log(base))
+	}
+
+	return base, checksum, nil
+}
+
+// readBackupIncInfo returns the base file name and checksum of an incremental backup file.
+func readBackupIncInfo(fileName string) (base, checksum string, err error) {
+	f, err := os.Open(fileName)
+
+	if err != nil {
+		return "", "", err
+	}
+
+	defer f.Close()
+
+	zr, err := gzip.NewReader(f)
+
+	if err != nil {
+		return "", "", fmt.Errorf("invalid gzip file (%s)", err)
+	}
+
+	defer zr.Close()
+
+	return backupIncInfo(bufio.NewReader(zr))
+}
+
+// restoreIncrement writes the complete database dump of an incremental backup to w, and
+// returns an error if the result does not match the checksum.
+func restoreIncrement(w io.Writer, ops *bufio.Reader, baseName, checksum string) error {
+	f, err := OpenBackup(baseName)
+
+	if err != nil {
+		return fmt.Errorf("%s (open base)", err)
+	}
+
+	defer f.Close()
+
+	base := bufio.NewReader(f)
+	hash := sha256.New()
+	out := bufio.NewWriter(io.MultiWriter(w, hash))
+
+	for {
+		op, readErr := ops.ReadBytes('\n')
+
+		if len(op) > 0 {
+			switch op[0] {
+			case '+':
+				if _, err = out.Write(op[1:]); err != nil {
+					return err
+				}
+			case '=', '-':
+				n, err := strconv.Atoi(string(bytes.TrimSpace(op[1:])))
+
+				if err != nil || n < 0 {
+					return fmt.Errorf("invalid incremental backup operation")
+				}
+
+				for i := 0; i < n; i++ {
+					line, err := base.ReadBytes('\n')
+
+					if len(line) == 0 && err != nil {
+						return fmt.Errorf("base does not match incremental backup")
+					} else if op[0] == '-' {
+						continue
+					} else if _, err = out.Write(line); err != nil {
+						return err
+					}
+				}
+			default:
+				return fmt.Errorf("invalid incremental backup operation")
+			}
+		}
+
+		if readErr == io.EOF {
+			break
+		} else if readErr != nil {
+			return readErr
+		}
+	}
+
+	if err = out.Flush(); err != nil {
+		return err
+	} else if hex.EncodeToString(hash.Sum(nil)) != checksum {
+		return errors.New("checksum does not match")
+	}
+
+	return nil
+}
@@ -0,0 +1,195 @@
+package photoprism
+
+import (
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// writeTestIncrement creates an incremental backup of the dump based on the specified full backup.
+func writeTestIncrement(t *testing.T, fileName, baseName, dump string, modTime time.Time) {
+	dumpName := fileName + ".sql.tmp"
+	writeTestBackup(t, dumpName, dump, modTime)
+
+	defer os.Remove(dumpName)
+
+	checksum := sha256.Sum256([]byte(dump))
+
+	if err := writeBackupIncrement(fileName, dumpName, baseName, hex.EncodeToString(checksum[:])); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := os.Chtimes(fileName, modTime, modTime); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// readTestBackup returns the complete database dump of a backup.
+func readTestBackup(t *testing.T, fileName string) string {
+	r, err := OpenBackup(fileName)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer r.Close()
+
+	data, err := io.ReadAll(r)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return string(data)
+}
+
+// readTestGzip returns the uncompressed content of a file.
+func readTestGzip(t *testing.T, fileName string) string {
+	f, err := os.Open(fileName)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer f.Close()
+
+	zr, err := gzip.NewReader(f)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := io.ReadAll(zr)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return string(data)
+}
+
+func TestBackupIncFileName(t *testing.T) {
+	assert.True(t, strings.HasSuffix(BackupIncFileName("/backup"), BackupIncExt))
+	assert.Equal(t, "/backup", filepath.Dir(BackupIncFileName("/backup")))
+}
+
+func TestBackupIncrement(t *testing.T) {
+	dir := t.TempDir()
+	now := time.Now()
+
+	baseSql := "PRAGMA foreign_keys=OFF;\nBEGIN TRANSACTION;\nCREATE TABLE `photos` (`id` integer, `title` text);\n" +
+		"INSERT INTO photos VALUES(1,'One');\nINSERT INTO photos VALUES(2,'Two');\nINSERT INTO photos VALUES(3,'Three');\n" +
+		"INSERT INTO photos VALUES(4,'Four');\nCREATE TABLE `labels` (`id` integer);\nINSERT INTO labels VALUES(1);\nCOMMIT;\n"
+	baseName := filepath.Join(dir, "2023-01-01-030000.sql.gz")
+	writeTestBackup(t, baseName, baseSql, now.Add(-2*time.Hour))
+
+	t.Run("Changes", func(t *testing.T) {
+		dump := strings.Replace(baseSql, "INSERT INTO photos VALUES(2,'Two');\nINSERT INTO photos VALUES(3,'Three');\n", "", 1)
+		dump = strings.Replace(dump, "(4,'Four')", "(4,'Changed')", 1)
+		dump = strings.Replace(dump, "INSERT INTO labels VALUES(1);\n", "INSERT INTO labels VALUES(1);\nINSERT INTO labels VALUES(2);\n", 1)
+
+		fileName := filepath.Join(dir, "2023-01-02-030000"+BackupIncExt)
+		writeTestIncrement(t, fileName, baseName, dump, now.Add(-time.Hour))
+
+		assert.NoError(t, VerifyBackup(fileName))
+		assert.Equal(t, dump, readTestBackup(t, fileName))
+
+		base, checksum, err := readBackupIncInfo(fileName)
+
+		assert.NoError(t, err)
+		assert.Equal(t, filepath.Base(baseName), base)
+		assert.Len(t, checksum, 64)
+
+		// Only changed lines are stored.
+		ops := readTestGzip(t, fileName)
+		assert.Contains(t, ops, "+INSERT INTO photos VALUES(4,'Changed');\n")
+		assert.Contains(t, ops, "+INSERT INTO labels VALUES(2);\n")
+		assert.Contains(t, ops, "-3\n")
+		assert.NotContains(t, ops, "+INSERT INTO photos VALUES(1,'One');")
+	})
+	t.Run("Unchanged", func(t *testing.T) {
+		fileName := filepath.Join(dir, "unchanged"+BackupIncExt)
+		writeTestIncrement(t, fileName, baseName, baseSql, now)
+
+		defer os.Remove(fileName)
+
+		assert.NoError(t, VerifyBackup(fileName))
+		assert.Equal(t, baseSql, readTestBackup(t, fileName))
+	})
+	t.Run("BaseChanged", func(t *testing.T) {
+		otherDir := t.TempDir()
+		otherBase := filepath.Join(otherDir, filepath.Base(baseName))
+		writeTestBackup(t, otherBase, baseSql, now)
+
+		fileName := filepath.Join(otherDir, "changed"+BackupIncExt)
+		writeTestIncrement(t, fileName, otherBase, strings.Replace(baseSql, "'One'", "'First'", 1), now)
+
+		assert.NoError(t, VerifyBackup(fileName))
+
+		writeTestBackup(t, otherBase, strings.Replace(baseSql, "'Two'", "'Second'", 1), now)
+
+		assert.Error(t, VerifyBackup(fileName))
+	})
+	t.Run("BaseMissing", func(t *testing.T) {
+		otherDir := t.TempDir()
+		otherBase := filepath.Join(otherDir, filepath.Base(baseName))
+		writeTestBackup(t, otherBase, baseSql, now)
+
+		fileName := filepath.Join(otherDir, "missing"+BackupIncExt)
+		writeTestIncrement(t, fileName, otherBase, baseSql, now)
+
+		assert.NoError(t, os.Remove(otherBase))
+		assert.Error(t, VerifyBackup(fileName))
+	})
+	t.Run("BackupBase", func(t *testing.T) {
+		base, increments, err := BackupBase(dir)
+
+		assert.NoError(t, err)
+		assert.Equal(t, baseName, base)
+		assert.Equal(t, 1, increments)
+
+		base, increments, err = BackupBase(filepath.Join(dir, "missing"))
+
+		assert.NoError(t, err)
+		assert.Equal(t, "", base)
+		assert.Equal(t, 0, increments)
+	})
+	t.Run("List", func(t *testing.T) {
+		result, err := Backups(dir)
+
+		assert.NoError(t, err)
+
+		if assert.Len(t, result, 2) {
+			assert.True(t, result[0].Incremental)
+			assert.True(t, result[0].Compressed)
+			assert.Equal(t, filepath.Base(baseName), result[0].Base)
+			assert.False(t, result[1].Incremental)
+			assert.Equal(t, "", result[1].Base)
+		}
+	})
+	t.Run("Rotate", func(t *testing.T) {
+		newBase := filepath.Join(dir, "2023-01-03-030000.sql.gz")
+		writeTestBackup(t, newBase, baseSql, now)
+
+		removed, err := RotateBackups(dir, 2)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 0, removed)
+
+		removed, err = RotateBackups(dir, 1)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 2, removed)
+		assert.FileExists(t, newBase)
+		assert.NoFileExists(t, baseName)
+		assert.NoFileExists(t, filepath.Join(dir, "2023-01-02-030000"+BackupIncExt))
+	})
+}
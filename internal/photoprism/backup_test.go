@@ -0,0 +1,150 @@
+package photoprism
+
+import (
+	"compress/gzip"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const testBackupSql = "PRAGMA foreign_keys=OFF;\nBEGIN TRANSACTION;\nCREATE TABLE `photos` (`id` integer);\nINSERT INTO photos VALUES(1);\nCOMMIT;\n"
+
+// writeTestBackup creates a backup file with the specified content and modification time.
+func writeTestBackup(t *testing.T, fileName, content string, modTime time.Time) {
+	f, err := os.Create(fileName)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if strings.HasSuffix(fileName, ".gz") {
+		zw := gzip.NewWriter(f)
+		_, err = zw.Write([]byte(content))
+		_ = zw.Close()
+	} else {
+		_, err = f.Write([]byte(content))
+	}
+
+	_ = f.Close()
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err = os.Chtimes(fileName, modTime, modTime); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestBackupFileName(t *testing.T) {
+	assert.True(t, strings.HasSuffix(BackupFileName("/backup", false), BackupExt))
+	assert.True(t, strings.HasSuffix(BackupFileName("/backup", true), BackupGzExt))
+	assert.Equal(t, "/backup", filepath.Dir(BackupFileName("/backup", true)))
+	assert.Len(t, filepath.Base(BackupFileName("/backup", true)), len("2006-01-02-150405.sql.gz"))
+}
+
+func TestVerifyBackup(t *testing.T) {
+	dir := t.TempDir()
+	now := time.Now()
+
+	t.Run("Plain", func(t *testing.T) {
+		fileName := filepath.Join(dir, "plain.sql")
+		writeTestBackup(t, fileName, testBackupSql, now)
+		assert.NoError(t, VerifyBackup(fileName))
+	})
+	t.Run("Gzip", func(t *testing.T) {
+		fileName := filepath.Join(dir, "compressed.sql.gz")
+		writeTestBackup(t, fileName, testBackupSql, now)
+		assert.NoError(t, VerifyBackup(fileName))
+	})
+	t.Run("GzipTemp", func(t *testing.T) {
+		fileName := filepath.Join(dir, "compressed.sql.gz")
+		tmpName := fileName + ".tmp"
+		assert.NoError(t, os.Rename(fileName, tmpName))
+		assert.NoError(t, VerifyBackup(tmpName))
+	})
+	t.Run("MySQL", func(t *testing.T) {
+		fileName := filepath.Join(dir, "mysql.sql")
+		writeTestBackup(t, fileName, "CREATE TABLE `photos` (`id` int);\n\n-- Dump completed on 2023-01-31 10:20:30\n", now)
+		assert.NoError(t, VerifyBackup(fileName))
+	})
+	t.Run("Empty", func(t *testing.T) {
+		fileName := filepath.Join(dir, "empty.sql")
+		writeTestBackup(t, fileName, "", now)
+		assert.Error(t, VerifyBackup(fileName))
+	})
+	t.Run("NoTables", func(t *testing.T) {
+		fileName := filepath.Join(dir, "notables.sql")
+		writeTestBackup(t, fileName, "BEGIN TRANSACTION;\nCOMMIT;\n", now)
+		assert.Error(t, VerifyBackup(fileName))
+	})
+	t.Run("Incomplete", func(t *testing.T) {
+		fileName := filepath.Join(dir, "incomplete.sql")
+		writeTestBackup(t, fileName, testBackupSql[:60], now)
+		assert.Error(t, VerifyBackup(fileName))
+	})
+	t.Run("Corrupted", func(t *testing.T) {
+		fileName := filepath.Join(dir, "corrupted.sql.gz")
+		writeTestBackup(t, fileName, testBackupSql, now)
+
+		data, _ := os.ReadFile(fileName)
+		data[len(data)-6] ^= 0xff
+
+		assert.NoError(t, os.WriteFile(fileName, data, 0644))
+		assert.Error(t, VerifyBackup(fileName))
+	})
+	t.Run("NotFound", func(t *testing.T) {
+		assert.Error(t, VerifyBackup(filepath.Join(dir, "missing.sql")))
+	})
+}
+
+func TestBackups(t *testing.T) {
+	dir := t.TempDir()
+	now := time.Now()
+
+	writeTestBackup(t, filepath.Join(dir, "2023-01-01.sql"), testBackupSql, now.Add(-3*time.Hour))
+	writeTestBackup(t, filepath.Join(dir, "2023-01-02-030000.sql.gz"), testBackupSql, now.Add(-2*time.Hour))
+	writeTestBackup(t, filepath.Join(dir, "2023-01-03-030000.sql.gz"), testBackupSql, now.Add(-1*time.Hour))
+	writeTestBackup(t, filepath.Join(dir, "notes.txt"), "foo", now)
+
+	t.Run("List", func(t *testing.T) {
+		result, err := Backups(dir)
+
+		assert.NoError(t, err)
+
+		if assert.Len(t, result, 3) {
+			assert.Equal(t, "2023-01-03-030000.sql.gz", result[0].Name())
+			assert.True(t, result[0].Compressed)
+			assert.Equal(t, "2023-01-01.sql", result[2].Name())
+			assert.False(t, result[2].Compressed)
+		}
+	})
+	t.Run("NotFound", func(t *testing.T) {
+		result, err := Backups(filepath.Join(dir, "missing"))
+
+		assert.NoError(t, err)
+		assert.Empty(t, result)
+	})
+	t.Run("Rotate", func(t *testing.T) {
+		removed, err := RotateBackups(dir, 0)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 0, removed)
+
+		removed, err = RotateBackups(dir, 2)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 1, removed)
+		assert.NoFileExists(t, filepath.Join(dir, "2023-01-01.sql"))
+		assert.FileExists(t, filepath.Join(dir, "notes.txt"))
+
+		removed, err = RotateBackups(dir, 2)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 0, removed)
+	})
+}
@@ -0,0 +1,154 @@
+package photoprism
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/dustin/go-humanize/english"
+	gc "github.com/patrickmn/go-cache"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/pkg/clean"
+)
+
+// sharpnessCache caches the sharpness scores of burst pictures by file hash, so that
+// they don't have to be calculated again each time bursts are listed.
+var sharpnessCache = gc.New(24*time.Hour, 15*time.Minute)
+
+// Bursts represents a worker that finds bursts and bracketed exposures that may be stacked.
+type Bursts struct {
+	conf *config.Config
+}
+
+// NewBursts returns a new Bursts worker.
+func NewBursts(conf *config.Config) *Bursts {
+	instance := &Bursts{
+		conf: conf,
+	}
+
+	return instance
+}
+
+// Find returns groups of visually similar pictures taken by the same camera within the specified
+// number of seconds, with the best picture first, so that they can be reviewed before stacking.
+func (w *Bursts) Find(seconds, maxDistance int, pathName string) (query.Bursts, error) {
+	bursts, err := query.BurstFiles(seconds, maxDistance, pathName)
+
+	if err != nil {
+		return bursts, err
+	}
+
+	for i := range bursts {
+		w.Rank(&bursts[i])
+	}
+
+	return bursts, nil
+}
+
+// Rank sorts the files of a burst so that the best picture comes first, based on the sharpness
+// of its thumbnail, the photo quality, and the resolution.
+func (w *Bursts) Rank(b *query.Burst) {
+	scores := make(map[uint]float64, len(b.Files))
+	quality := make(map[uint]int, len(b.Files))
+
+	for _, f := range b.Files {
+		if p := entity.FindPhoto(entity.Photo{ID: f.PhotoID}); p != nil {
+			quality[f.ID] = p.PhotoQuality
+		}
+
+		if score, err := w.sharpness(f); err != nil {
+			log.Debugf("bursts: %s in %s", err, clean.Log(f.FileName))
+		} else {
+			scores[f.ID] = score
+		}
+	}
+
+	sort.SliceStable(b.Files, func(i, j int) bool {
+		x, y := b.Files[i], b.Files[j]
+
+		if scores[x.ID] != scores[y.ID] {
+			return scores[x.ID] > scores[y.ID]
+		} else if quality[x.ID] != quality[y.ID] {
+			return quality[x.ID] > quality[y.ID]
+		} else if px, py := x.FileWidth*x.FileHeight, y.FileWidth*y.FileHeight; px != py {
+			return px > py
+		}
+
+		return x.ID < y.ID
+	})
+}
+
+// sharpness returns the sharpness score of a file, using the cached score if possible.
+func (w *Bursts) sharpness(f entity.File) (float64, error) {
+	if f.FileHash != "" {
+		if score, ok := sharpnessCache.Get(f.FileHash); ok {
+			return score.(float64), nil
+		}
+	}
+
+	m, err := NewMediaFile(FileName(f.FileRoot, f.FileName))
+
+	if err != nil {
+		return 0, err
+	}
+
+	score, err := m.Sharpness(w.conf.ThumbCachePath())
+
+	if err != nil {
+		return score, err
+	} else if f.FileHash != "" {
+		sharpnessCache.SetDefault(f.FileHash, score)
+	}
+
+	return score, nil
+}
+
+// Stack moves the other pictures of a burst to the photo of the best picture.
+func (w *Bursts) Stack(b query.Burst) (stacked entity.Photos, err error) {
+	best := b.Best()
+
+	if best.PhotoID == 0 {
+		return stacked, fmt.Errorf("bursts: best picture not found")
+	}
+
+	photo := entity.FindPhoto(entity.Photo{ID: best.PhotoID})
+
+	if photo == nil {
+		return stacked, fmt.Errorf("bursts: photo %d not found", best.PhotoID)
+	}
+
+	var others entity.Photos
+
+	done := map[uint]bool{best.PhotoID: true}
+
+	for _, f := range b.Others() {
+		if done[f.PhotoID] {
+			continue
+		}
+
+		done[f.PhotoID] = true
+
+		if p := entity.FindPhoto(entity.Photo{ID: f.PhotoID}); p != nil {
+			others = append(others, *p)
+		}
+	}
+
+	if stacked, err = photo.Stack(others); err != nil {
+		return stacked, err
+	}
+
+	log.Infof("bursts: stacked %s with %s", english.Plural(len(stacked), "picture", "pictures"), clean.Log(photo.PhotoUID))
+
+	if err = entity.UpdateCounts(); err != nil {
+		log.Warnf("bursts: %s (update counts)", err)
+	}
+
+	if err = query.UpdateCovers(); err != nil {
+		log.Warnf("bursts: %s (update covers)", err)
+	}
+
+	return stacked, nil
+}
@@ -0,0 +1,151 @@
+package photoprism
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/pkg/phash"
+)
+
+// createBurst creates photos taken by the same camera with a primary file each for testing.
+func createBurst(t *testing.T, pathName string, offsets []int, widths []int) (photos entity.Photos) {
+	takenAt := time.Date(2021, 5, 1, 12, 0, 0, 0, time.UTC)
+
+	for i, w := range widths {
+		p := entity.NewPhoto(true)
+		p.PhotoPath = pathName
+		p.TakenAt = takenAt.Add(time.Duration(offsets[i]) * time.Second)
+		p.TakenAtLocal = p.TakenAt
+		p.TakenSrc = entity.SrcMeta
+		p.CameraID = entity.CameraFixtures.Pointer("canon-eos-6d").ID
+
+		if err := p.Save(); err != nil {
+			t.Fatal(err)
+		}
+
+		f := entity.File{
+			PhotoID:     p.ID,
+			PhotoUID:    p.PhotoUID,
+			FileName:    pathName + "/" + p.PhotoUID + ".jpg",
+			FileRoot:    entity.RootOriginals,
+			FileType:    "jpg",
+			MediaType:   entity.MediaImage,
+			FilePrimary: true,
+			FileWidth:   w,
+			FileHeight:  w,
+			FilePHash:   (phash.Hash(0x0f0f0f0f0f0f0f0f) + phash.Hash(i)).Hex(),
+		}
+
+		if err := f.Create(); err != nil {
+			t.Fatal(err)
+		}
+
+		photos = append(photos, p)
+	}
+
+	return photos
+}
+
+func TestNewBursts(t *testing.T) {
+	conf := config.TestConfig()
+
+	w := NewBursts(conf)
+
+	assert.IsType(t, &Bursts{}, w)
+}
+
+func TestBursts_Find(t *testing.T) {
+	conf := config.TestConfig()
+
+	w := NewBursts(conf)
+
+	photos := createBurst(t, "bursts-find", []int{0, 1, 2, 60}, []int{100, 300, 200, 400})
+
+	bursts, err := w.Find(2, query.BurstDistance, "bursts-find")
+
+	if err != nil {
+		t.Fatal(err)
+	} else if !assert.Len(t, bursts, 1) {
+		return
+	}
+
+	assert.Equal(t, 2, bursts[0].Seconds)
+
+	if assert.Len(t, bursts[0].Files, 3) {
+		// Falls back to the resolution without thumbnails.
+		assert.Equal(t, photos[1].ID, bursts[0].Best().PhotoID)
+		assert.Equal(t, photos[2].ID, bursts[0].Others()[0].PhotoID)
+		assert.Equal(t, photos[0].ID, bursts[0].Others()[1].PhotoID)
+	}
+
+	bursts, err = w.Find(60, query.BurstDistance, "bursts-find")
+
+	assert.NoError(t, err)
+
+	if assert.Len(t, bursts, 1) {
+		assert.Len(t, bursts[0].Files, 4)
+	}
+}
+
+func TestBursts_Rank(t *testing.T) {
+	conf := config.TestConfig()
+
+	w := NewBursts(conf)
+
+	t.Run("CachedSharpness", func(t *testing.T) {
+		sharpnessCache.SetDefault("bursts-rank-blurry", 10.0)
+		sharpnessCache.SetDefault("bursts-rank-sharp", 50.0)
+
+		defer sharpnessCache.Flush()
+
+		b := query.Burst{Files: entity.Files{
+			{ID: 1, FileHash: "bursts-rank-blurry", FileName: "bursts-rank/blurry.jpg", FileWidth: 400, FileHeight: 400},
+			{ID: 2, FileHash: "bursts-rank-sharp", FileName: "bursts-rank/sharp.jpg", FileWidth: 100, FileHeight: 100},
+			{ID: 3, FileName: "bursts-rank/missing.jpg", FileWidth: 200, FileHeight: 200},
+		}}
+
+		w.Rank(&b)
+
+		assert.Equal(t, uint(2), b.Files[0].ID)
+		assert.Equal(t, uint(1), b.Files[1].ID)
+		assert.Equal(t, uint(3), b.Files[2].ID)
+	})
+}
+
+func TestBursts_Stack(t *testing.T) {
+	conf := config.TestConfig()
+
+	w := NewBursts(conf)
+
+	photos := createBurst(t, "bursts-stack", []int{0, 1}, []int{100, 200})
+
+	bursts, err := w.Find(2, query.BurstDistance, "bursts-stack")
+
+	if err != nil {
+		t.Fatal(err)
+	} else if len(bursts) != 1 {
+		t.Fatalf("expected one burst, found %d", len(bursts))
+	}
+
+	stacked, err := w.Stack(bursts[0])
+
+	assert.NoError(t, err)
+
+	if assert.Len(t, stacked, 1) {
+		assert.Equal(t, photos[0].ID, stacked[0].ID)
+	}
+
+	bursts, err = w.Find(2, query.BurstDistance, "bursts-stack")
+
+	assert.NoError(t, err)
+	assert.Empty(t, bursts)
+
+	_, err = w.Stack(query.Burst{})
+
+	assert.Error(t, err)
+}
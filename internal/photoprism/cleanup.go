@@ -196,11 +196,46 @@ func (w *CleanUp) Cache(opt CleanUpOptions) (deleted int, err error) {
 		})
 	}
 
+	// Remove video segments of files that no longer exist.
+	deleted += w.hlsCache(fileHashes, opt)
+
 	log.Infof("cleanup: removed %s from cache [%s]", english.Plural(deleted, "file", "files"), time.Since(cleanupStart))
 
 	return deleted, err
 }
 
+// hlsCache removes cached HLS video segments of files that no longer exist and returns the number of removed segments.
+func (w *CleanUp) hlsCache(fileHashes query.HashMap, opt CleanUpOptions) (deleted int) {
+	// Example: cache/hls/a/c/a/acad9168fa6acc5c5c2965ddf6ec465ca42fd831/720p/0.ts
+	dirs, err := filepath.Glob(filepath.Join(w.conf.HlsCachePath(), "*", "*", "*", "*"))
+
+	if err != nil {
+		log.Warnf("cleanup: %s", err)
+		return 0
+	}
+
+	for _, dir := range dirs {
+		if ok := fileHashes[filepath.Base(dir)]; ok {
+			continue
+		}
+
+		segments, _ := filepath.Glob(filepath.Join(dir, "*", "*.ts"))
+		logName := clean.Log(fs.RelName(dir, filepath.Dir(w.conf.HlsCachePath())))
+
+		if opt.Dry {
+			deleted += len(segments)
+			log.Debugf("cleanup: %s would be removed", logName)
+		} else if err = os.RemoveAll(dir); err != nil {
+			log.Warnf("cleanup: %s in %s", err, logName)
+		} else {
+			deleted += len(segments)
+			log.Debugf("cleanup: removed %s from cache", logName)
+		}
+	}
+
+	return deleted
+}
+
 // Cancel stops the current operation.
 func (w *CleanUp) Cancel() {
 	mutex.MainWorker.Cancel()
@@ -0,0 +1,47 @@
+package photoprism
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/ffmpeg"
+	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/pkg/fs"
+)
+
+func TestCleanUp_hlsCache(t *testing.T) {
+	conf := config.TestConfig()
+	convert := NewConvert(conf)
+	w := NewCleanUp(conf)
+
+	r, _ := ffmpeg.FindRendition("360p")
+
+	existing := convert.HlsPath("acad9168fa6acc5c5c2965ddf6ec465ca42fd831", r)
+	orphaned := convert.HlsPath("1111111111111111111111111111111111111111", r)
+
+	for _, dir := range []string{existing, orphaned} {
+		if err := os.MkdirAll(dir, fs.ModeDir); err != nil {
+			t.Fatal(err)
+		} else if err = os.WriteFile(filepath.Join(dir, "0.ts"), []byte("segment"), fs.ModeFile); err != nil {
+			t.Fatal(err)
+		}
+
+		defer os.RemoveAll(filepath.Dir(dir))
+	}
+
+	hashes := query.HashMap{"acad9168fa6acc5c5c2965ddf6ec465ca42fd831": true}
+
+	t.Run("Dry", func(t *testing.T) {
+		assert.Equal(t, 1, w.hlsCache(hashes, CleanUpOptions{Dry: true}))
+		assert.True(t, fs.PathExists(orphaned))
+	})
+	t.Run("Remove", func(t *testing.T) {
+		assert.Equal(t, 1, w.hlsCache(hashes, CleanUpOptions{}))
+		assert.True(t, fs.PathExists(existing))
+		assert.False(t, fs.PathExists(orphaned))
+	})
+}
@@ -0,0 +1,158 @@
+package photoprism
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"time"
+
+	"github.com/photoprism/photoprism/internal/event"
+	"github.com/photoprism/photoprism/internal/ffmpeg"
+
+	"github.com/photoprism/photoprism/pkg/clean"
+	"github.com/photoprism/photoprism/pkg/fs"
+)
+
+// ToVideo converts a single video file to the specified codec. Animated images are always converted to AVC.
+func (c *Convert) ToVideo(f *MediaFile, codec ffmpeg.Codec, encoder ffmpeg.AvcEncoder, noMutex, force bool) (file *MediaFile, err error) {
+	if f == nil {
+		return nil, fmt.Errorf("convert: file is nil - possible bug")
+	}
+
+	if !f.Exists() {
+		return nil, fmt.Errorf("convert: %s not found", clean.Log(f.RootRelName()))
+	} else if f.Empty() {
+		return nil, fmt.Errorf("convert: %s is empty", clean.Log(f.RootRelName()))
+	}
+
+	if f.IsAnimatedImage() {
+		codec = ffmpeg.CodecAvc
+	}
+
+	videoName := codec.FileType().FindFirst(f.FileName(), []string{c.conf.SidecarPath(), fs.HiddenPath}, c.conf.OriginalsPath(), false)
+
+	mediaFile, err := NewMediaFile(videoName)
+
+	if err == nil && mediaFile.IsVideo() {
+		return mediaFile, nil
+	}
+
+	if !c.conf.SidecarWritable() {
+		return nil, fmt.Errorf("convert: transcoding disabled in read-only mode (%s)", f.RootRelName())
+	}
+
+	fileName := f.RelName(c.conf.OriginalsPath())
+
+	if f.IsAnimatedImage() {
+		videoName = fs.FileName(f.FileName(), c.conf.SidecarPath(), c.conf.OriginalsPath(), fs.ExtMP4)
+	} else {
+		videoName = fs.FileName(f.FileName(), c.conf.SidecarPath(), c.conf.OriginalsPath(), codec.Ext())
+	}
+
+	cmd, useMutex, err := c.VideoConvertCommand(f, videoName, codec, encoder)
+
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	// Make sure only one convert command runs at a time.
+	if useMutex && !noMutex {
+		c.cmdMutex.Lock()
+		defer c.cmdMutex.Unlock()
+	}
+
+	if fs.FileExists(videoName) {
+		videoFile, videoErr := NewMediaFile(videoName)
+		if videoErr != nil {
+			return videoFile, videoErr
+		} else if !force || !videoFile.InSidecar() {
+			return videoFile, nil
+		} else if err = videoFile.Remove(); err != nil {
+			return videoFile, fmt.Errorf("convert: failed removing %s (%s)", clean.Log(videoFile.RootRelName()), err)
+		} else {
+			log.Infof("convert: replacing %s", clean.Log(videoFile.RootRelName()))
+		}
+	}
+
+	// Fetch command output.
+	var out bytes.Buffer
+	var stderr bytes.Buffer
+	cmd.Stdout = &out
+	cmd.Stderr = &stderr
+	cmd.Env = []string{fmt.Sprintf("HOME=%s", c.conf.CmdCachePath())}
+
+	event.Publish("index.converting", event.Data{
+		"fileType": f.FileType(),
+		"fileName": fileName,
+		"baseName": filepath.Base(fileName),
+		"xmpName":  "",
+	})
+
+	log.Infof("%s: transcoding %s to %s", codec.Encoder(encoder), fileName, codec.FileType())
+
+	// Log exact command for debugging in trace mode.
+	log.Trace(cmd.String())
+
+	// Run convert command.
+	start := time.Now()
+	if err = cmd.Run(); err != nil {
+		if stderr.String() != "" {
+			err = errors.New(stderr.String())
+		}
+
+		// Log ffmpeg output for debugging.
+		if err.Error() != "" {
+			log.Debug(err)
+		}
+
+		// Log filename and transcoding time.
+		log.Warnf("%s: failed transcoding %s [%s]", codec.Encoder(encoder), fileName, time.Since(start))
+
+		// Remove broken video file.
+		if !fs.FileExists(videoName) {
+			// Do nothing.
+		} else if err = os.Remove(videoName); err != nil {
+			return nil, fmt.Errorf("convert: failed removing %s (%s)", clean.Log(RootRelName(videoName)), err)
+		}
+
+		// Try again using software encoder.
+		if encoder != ffmpeg.SoftwareEncoder {
+			return c.ToVideo(f, codec, ffmpeg.SoftwareEncoder, true, false)
+		} else {
+			return nil, err
+		}
+	}
+
+	// Log transcoding time.
+	log.Infof("%s: created %s [%s]", codec.Encoder(encoder), filepath.Base(videoName), time.Since(start))
+
+	return NewMediaFile(videoName)
+}
+
+// VideoConvertCommand returns the command for converting video files to the specified codec.
+func (c *Convert) VideoConvertCommand(f *MediaFile, videoName string, codec ffmpeg.Codec, encoder ffmpeg.AvcEncoder) (result *exec.Cmd, useMutex bool, err error) {
+	if codec == ffmpeg.CodecAvc {
+		return c.AvcConvertCommand(f, videoName, encoder)
+	}
+
+	switch {
+	case f.FileName() == "":
+		return nil, false, fmt.Errorf("convert: %s video filename is empty - possible bug", f.FileType())
+	case !f.IsVideo():
+		return nil, false, fmt.Errorf("convert: file type %s of %s cannot be transcoded to %s", f.FileType(), clean.Log(f.BaseName()), codec)
+	}
+
+	var opt ffmpeg.Options
+
+	if opt, err = c.conf.FFmpegOptions(encoder, c.AvcBitrate(f)); err != nil {
+		return nil, false, fmt.Errorf("convert: failed to transcode %s (%s)", clean.Log(f.BaseName()), err)
+	}
+
+	opt.Codec = codec
+
+	return ffmpeg.TranscodeCommand(f.FileName(), videoName, opt)
+}
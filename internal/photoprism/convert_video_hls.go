@@ -0,0 +1,165 @@
+package photoprism
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/ffmpeg"
+	"github.com/photoprism/photoprism/pkg/clean"
+	"github.com/photoprism/photoprism/pkg/fs"
+)
+
+// HlsWorkers limits the number of HLS video segments that are transcoded at the same time.
+var HlsWorkers = make(chan struct{}, 2)
+
+// hlsLocks prevents the same segment from being transcoded more than once at the same time.
+var hlsLocks sync.Map
+
+// HlsPath returns the cache folder for the HLS segments of a video rendition.
+func (c *Convert) HlsPath(hash string, r ffmpeg.Rendition) string {
+	if len(hash) < 4 {
+		return ""
+	}
+
+	return filepath.Join(c.conf.HlsCachePath(), hash[0:1], hash[1:2], hash[2:3], hash, r.Name)
+}
+
+// ToHls returns the file name of an HLS video segment and transcodes it on demand, so that a
+// video can be streamed and seeked without transcoding the entire file first.
+func (c *Convert) ToHls(fileName string, f *entity.File, r ffmpeg.Rendition, index int) (string, error) {
+	if f == nil {
+		return "", fmt.Errorf("convert: file is nil - possible bug")
+	} else if index < 0 || index >= ffmpeg.HlsSegments(f.FileDuration) {
+		return "", fmt.Errorf("convert: invalid segment %d of %s", index, clean.Log(f.FileName))
+	}
+
+	dir := c.HlsPath(f.FileHash, r)
+
+	if dir == "" {
+		return "", fmt.Errorf("convert: invalid hash of %s", clean.Log(f.FileName))
+	}
+
+	segmentName := filepath.Join(dir, ffmpeg.HlsSegmentName(index))
+
+	// Return cached segment, if it exists.
+	if fs.FileExists(segmentName) {
+		return segmentName, nil
+	} else if !fs.FileExists(fileName) {
+		return "", fmt.Errorf("convert: %s not found", clean.Log(f.FileName))
+	}
+
+	mu, _ := hlsLocks.LoadOrStore(segmentName, &sync.Mutex{})
+	mu.(*sync.Mutex).Lock()
+	defer mu.(*sync.Mutex).Unlock()
+	defer hlsLocks.Delete(segmentName)
+
+	// Another request may have transcoded the segment in the meantime.
+	if fs.FileExists(segmentName) {
+		return segmentName, nil
+	}
+
+	HlsWorkers <- struct{}{}
+	defer func() { <-HlsWorkers }()
+
+	if err := c.transcodeHls(fileName, segmentName, f, r, index); err != nil {
+		return "", err
+	}
+
+	// Transcode the next segment in the background, so that it is ready when requested.
+	if next := index + 1; next < ffmpeg.HlsSegments(f.FileDuration) {
+		go c.prefetchHls(fileName, f, r, next)
+	}
+
+	return segmentName, nil
+}
+
+// prefetchHls transcodes an HLS video segment, unless it already exists or all workers are busy.
+func (c *Convert) prefetchHls(fileName string, f *entity.File, r ffmpeg.Rendition, index int) {
+	segmentName := filepath.Join(c.HlsPath(f.FileHash, r), ffmpeg.HlsSegmentName(index))
+
+	if fs.FileExists(segmentName) {
+		return
+	}
+
+	mu, _ := hlsLocks.LoadOrStore(segmentName, &sync.Mutex{})
+	mu.(*sync.Mutex).Lock()
+	defer mu.(*sync.Mutex).Unlock()
+	defer hlsLocks.Delete(segmentName)
+
+	select {
+	case HlsWorkers <- struct{}{}:
+		defer func() { <-HlsWorkers }()
+	default:
+		return
+	}
+
+	if fs.FileExists(segmentName) {
+		return
+	} else if err := c.transcodeHls(fileName, segmentName, f, r, index); err != nil {
+		log.Debugf("convert: %s", err)
+	}
+}
+
+// transcodeHls runs the ffmpeg command to transcode a single HLS video segment.
+func (c *Convert) transcodeHls(fileName, segmentName string, f *entity.File, r ffmpeg.Rendition, index int) (err error) {
+	opt, err := c.conf.FFmpegOptions(ffmpeg.SoftwareEncoder, fmt.Sprintf("%dk", r.Bitrate))
+
+	if err != nil {
+		return fmt.Errorf("convert: failed to transcode %s (%s)", clean.Log(f.FileName), err)
+	}
+
+	if err = os.MkdirAll(filepath.Dir(segmentName), fs.ModeDir); err != nil {
+		return fmt.Errorf("convert: failed to create cache directory")
+	}
+
+	// Write to a temporary file first, so that incomplete segments are never served.
+	tmpName := segmentName + ".tmp"
+
+	cmd, err := ffmpeg.HlsSegmentCommand(fileName, tmpName, f.FileDuration, r, f.FileWidth, f.FileHeight, index, opt)
+
+	if err != nil {
+		return fmt.Errorf("convert: %s", err)
+	}
+
+	// Fetch command output.
+	var out bytes.Buffer
+	var stderr bytes.Buffer
+	cmd.Stdout = &out
+	cmd.Stderr = &stderr
+	cmd.Env = []string{fmt.Sprintf("HOME=%s", c.conf.CmdCachePath())}
+
+	// Log exact command for debugging in trace mode.
+	log.Trace(cmd.String())
+
+	start := time.Now()
+
+	if err = cmd.Run(); err != nil {
+		if stderr.String() != "" {
+			err = errors.New(stderr.String())
+		}
+
+		log.Debug(err)
+
+		_ = os.Remove(tmpName)
+
+		return fmt.Errorf("convert: failed transcoding segment %d of %s", index, clean.Log(f.FileName))
+	} else if !fs.FileExistsNotEmpty(tmpName) {
+		_ = os.Remove(tmpName)
+
+		return fmt.Errorf("convert: segment %d of %s is empty", index, clean.Log(f.FileName))
+	} else if err = os.Rename(tmpName, segmentName); err != nil {
+		_ = os.Remove(tmpName)
+
+		return err
+	}
+
+	log.Debugf("convert: transcoded segment %d of %s to %s [%s]", index, clean.Log(f.FileName), r.Name, time.Since(start))
+
+	return nil
+}
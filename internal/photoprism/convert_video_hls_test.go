@@ -0,0 +1,105 @@
+package photoprism
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/ffmpeg"
+	"github.com/photoprism/photoprism/pkg/fs"
+)
+
+func TestConvert_HlsPath(t *testing.T) {
+	conf := config.TestConfig()
+	convert := NewConvert(conf)
+
+	r, _ := ffmpeg.FindRendition("720p")
+
+	t.Run("Success", func(t *testing.T) {
+		result := convert.HlsPath("acad9168fa6acc5c5c2965ddf6ec465ca42fd831", r)
+		assert.Equal(t, filepath.Join(conf.HlsCachePath(), "a", "c", "a", "acad9168fa6acc5c5c2965ddf6ec465ca42fd831", "720p"), result)
+	})
+	t.Run("InvalidHash", func(t *testing.T) {
+		assert.Equal(t, "", convert.HlsPath("abc", r))
+	})
+}
+
+func TestConvert_ToHls(t *testing.T) {
+	conf := config.TestConfig()
+
+	defer fakeFFmpeg(t, conf)()
+
+	convert := NewConvert(conf)
+	videoName := filepath.Join(conf.ExamplesPath(), "gopher-video.mp4")
+	r, _ := ffmpeg.FindRendition("360p")
+
+	f := &entity.File{
+		FileName:     "gopher-video.mp4",
+		FileHash:     "ba0a6ee3fc0d8b5ec0c84e2a1b3e3f0c6b6ce9b4",
+		FileDuration: 8 * time.Second,
+		FileWidth:    1280,
+		FileHeight:   720,
+	}
+
+	_ = os.RemoveAll(convert.HlsPath(f.FileHash, r))
+	defer os.RemoveAll(convert.HlsPath(f.FileHash, r))
+
+	t.Run("Success", func(t *testing.T) {
+		segmentName, err := convert.ToHls(videoName, f, r, 1)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, filepath.Join(convert.HlsPath(f.FileHash, r), "1.ts"), segmentName)
+		assert.True(t, fs.FileExistsNotEmpty(segmentName))
+		assert.False(t, fs.FileExists(segmentName+".tmp"))
+
+		data, err := os.ReadFile(segmentName)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Contains(t, string(data), "-ss 6.000 -i "+videoName+" -t 2.000")
+		assert.Contains(t, string(data), "-c:v libx264")
+		assert.Contains(t, string(data), "-output_ts_offset 6.000")
+	})
+	t.Run("Cached", func(t *testing.T) {
+		segmentName := filepath.Join(convert.HlsPath(f.FileHash, r), "0.ts")
+
+		if err := os.MkdirAll(filepath.Dir(segmentName), fs.ModeDir); err != nil {
+			t.Fatal(err)
+		} else if err = os.WriteFile(segmentName, []byte("cached"), fs.ModeFile); err != nil {
+			t.Fatal(err)
+		}
+
+		result, err := convert.ToHls(videoName, f, r, 0)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		data, _ := os.ReadFile(result)
+		assert.Equal(t, "cached", string(data))
+	})
+	t.Run("InvalidIndex", func(t *testing.T) {
+		_, err := convert.ToHls(videoName, f, r, 2)
+		assert.Error(t, err)
+	})
+	t.Run("NotFound", func(t *testing.T) {
+		m := *f
+		m.FileHash = "0000000000000000000000000000000000000000"
+		_, err := convert.ToHls(filepath.Join(t.TempDir(), "missing.mp4"), &m, r, 1)
+		assert.Error(t, err)
+	})
+	t.Run("FileNil", func(t *testing.T) {
+		_, err := convert.ToHls(videoName, nil, r, 0)
+		assert.Error(t, err)
+	})
+}
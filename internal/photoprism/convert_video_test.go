@@ -0,0 +1,114 @@
+package photoprism
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/ffmpeg"
+	"github.com/photoprism/photoprism/pkg/fs"
+)
+
+// fakeFFmpeg configures an ffmpeg binary that writes its arguments to the output file,
+// and returns a function to restore the previous options.
+func fakeFFmpeg(t *testing.T, conf *config.Config) func() {
+	binName := filepath.Join(t.TempDir(), "ffmpeg")
+
+	if err := os.WriteFile(binName, []byte("#!/bin/sh\nfor last; do true; done\necho \"$@\" > \"$last\"\n"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	bin, disabled := conf.Options().FFmpegBin, conf.Options().DisableFFmpeg
+	conf.Options().FFmpegBin = binName
+	conf.Options().DisableFFmpeg = false
+
+	return func() {
+		conf.Options().FFmpegBin = bin
+		conf.Options().DisableFFmpeg = disabled
+	}
+}
+
+func TestConvert_ToVideo(t *testing.T) {
+	conf := config.TestConfig()
+	defer fakeFFmpeg(t, conf)()
+
+	convert := NewConvert(conf)
+
+	t.Run("Hevc", func(t *testing.T) {
+		fileName := filepath.Join(conf.ExamplesPath(), "gopher-video.mp4")
+		outputName := filepath.Join(conf.SidecarPath(), conf.ExamplesPath(), "gopher-video.mp4.hevc")
+
+		_ = os.Remove(outputName)
+		defer os.Remove(outputName)
+
+		mf, err := NewMediaFile(fileName)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		videoFile, err := convert.ToVideo(mf, ffmpeg.CodecHevc, ffmpeg.SoftwareEncoder, false, false)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, outputName, videoFile.FileName())
+		assert.True(t, fs.FileExists(outputName))
+
+		data, err := os.ReadFile(outputName)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Contains(t, string(data), "-c:v libx265")
+		assert.Contains(t, string(data), "-tag:v hvc1")
+	})
+	t.Run("FileNil", func(t *testing.T) {
+		_, err := convert.ToVideo(nil, ffmpeg.CodecAv1, ffmpeg.SoftwareEncoder, false, false)
+		assert.Error(t, err)
+	})
+}
+
+func TestConvert_VideoConvertCommand(t *testing.T) {
+	conf := config.TestConfig()
+	defer fakeFFmpeg(t, conf)()
+
+	convert := NewConvert(conf)
+
+	t.Run("Av1", func(t *testing.T) {
+		fileName := filepath.Join(conf.ExamplesPath(), "gopher-video.mp4")
+		mf, err := NewMediaFile(fileName)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		r, useMutex, err := convert.VideoConvertCommand(mf, "gopher-video.mp4.av1", ffmpeg.CodecAv1, ffmpeg.SoftwareEncoder)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.True(t, useMutex)
+		assert.Contains(t, r.Args, "libsvtav1")
+		assert.Contains(t, r.Args, "gopher-video.mp4.av1")
+	})
+	t.Run("JPEG", func(t *testing.T) {
+		fileName := filepath.Join(conf.ExamplesPath(), "cat_black.jpg")
+		mf, err := NewMediaFile(fileName)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		r, _, err := convert.VideoConvertCommand(mf, "cat_black.jpg.hevc", ffmpeg.CodecHevc, ffmpeg.SoftwareEncoder)
+
+		assert.Error(t, err)
+		assert.Nil(t, r)
+	})
+}
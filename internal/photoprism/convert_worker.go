@@ -27,12 +27,14 @@ func ConvertWorker(jobs <-chan ConvertJob) {
 		case job.file.IsAnimated():
 			_, _ = job.convert.ToJson(job.file, false)
 
-			// Create JPEG preview and AVC encoded version for videos.
+			codec := job.convert.conf.FFmpegCodec()
+
+			// Create JPEG preview and transcoded version for videos, unless they are compatible.
 			if _, err := job.convert.ToImage(job.file, job.force); err != nil {
 				logError(err, job)
-			} else if metaData := job.file.MetaData(); metaData.CodecAvc() {
+			} else if metaData := job.file.MetaData(); metaData.CodecAvc() || metaData.Codec == string(codec.Video()) {
 				continue
-			} else if _, err := job.convert.ToAvc(job.file, job.convert.conf.FFmpegEncoder(), false, false); err != nil {
+			} else if _, err := job.convert.ToVideo(job.file, codec, job.convert.conf.FFmpegEncoder(), false, false); err != nil {
 				logError(err, job)
 			}
 		default:
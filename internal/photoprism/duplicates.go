@@ -0,0 +1,174 @@
+package photoprism
+
+import (
+	"fmt"
+	"runtime/debug"
+
+	"github.com/dustin/go-humanize/english"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/mutex"
+	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/pkg/clean"
+)
+
+// Duplicates represents a worker that finds visually similar pictures based on their perceptual hash.
+type Duplicates struct {
+	conf *config.Config
+}
+
+// NewDuplicates returns a new Duplicates worker.
+func NewDuplicates(conf *config.Config) *Duplicates {
+	instance := &Duplicates{
+		conf: conf,
+	}
+
+	return instance
+}
+
+// Hashes creates missing perceptual hashes for indexed pictures based on existing thumbnails.
+func (w *Duplicates) Hashes() (updated int, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("duplicates: %s (panic)\nstack: %s", r, debug.Stack())
+			log.Error(err)
+		}
+	}()
+
+	if err = mutex.MainWorker.Start(); err != nil {
+		log.Warnf("duplicates: %s (update hashes)", err.Error())
+		return updated, err
+	}
+
+	defer mutex.MainWorker.Stop()
+
+	limit := 1000
+	var afterId uint
+
+	for {
+		files, err := query.FilesWithoutPHash(limit, afterId)
+
+		if err != nil {
+			return updated, err
+		} else if len(files) == 0 {
+			break
+		}
+
+		for _, f := range files {
+			afterId = f.ID
+
+			if mutex.MainWorker.Canceled() {
+				return updated, nil
+			}
+
+			m, err := NewMediaFile(FileName(f.FileRoot, f.FileName))
+
+			if err != nil {
+				log.Debugf("duplicates: %s in %s", err, clean.Log(f.FileName))
+				continue
+			}
+
+			h, err := m.PerceptualHash(w.conf.ThumbCachePath())
+
+			if err != nil {
+				log.Debugf("duplicates: %s in %s", err, clean.Log(f.FileName))
+				continue
+			}
+
+			if err = f.Update("FilePHash", h.Hex()); err != nil {
+				log.Errorf("duplicates: %s in %s", err, clean.Log(f.FileName))
+			} else {
+				updated++
+			}
+		}
+
+		log.Infof("duplicates: updated %s", english.Plural(updated, "hash", "hashes"))
+	}
+
+	return updated, nil
+}
+
+// Similar returns groups of visually similar pictures with the best copy first.
+func (w *Duplicates) Similar(maxDistance int, pathName string) (query.SimilarGroups, error) {
+	return query.SimilarFiles(maxDistance, pathName)
+}
+
+// Stack moves the lesser copies of similar pictures to the photo of the best copy.
+func (w *Duplicates) Stack(s query.Similar) (stacked entity.Photos, err error) {
+	best := s.Best()
+
+	if best.PhotoID == 0 {
+		return stacked, fmt.Errorf("duplicates: best copy not found")
+	}
+
+	photo := entity.FindPhoto(entity.Photo{ID: best.PhotoID})
+
+	if photo == nil {
+		return stacked, fmt.Errorf("duplicates: photo %d not found", best.PhotoID)
+	}
+
+	if stacked, err = photo.Stack(w.copies(s)); err != nil {
+		return stacked, err
+	}
+
+	log.Infof("duplicates: stacked %s with %s", english.Plural(len(stacked), "copy", "copies"), clean.Log(photo.PhotoUID))
+
+	w.updateCounts()
+
+	return stacked, nil
+}
+
+// Archive moves the photos of lesser copies to the archive.
+func (w *Duplicates) Archive(s query.Similar) (archived entity.Photos, err error) {
+	for _, p := range w.copies(s) {
+		if err = p.Archive(); err != nil {
+			return archived, err
+		}
+
+		if w.conf.BackupYaml() {
+			if yamlErr := p.SaveAsYaml(p.YamlFileName(w.conf.OriginalsPath(), w.conf.SidecarPath())); yamlErr != nil {
+				log.Errorf("duplicates: %s (update yaml)", yamlErr)
+			}
+		}
+
+		archived = append(archived, p)
+	}
+
+	log.Infof("duplicates: archived %s", english.Plural(len(archived), "copy", "copies"))
+
+	w.updateCounts()
+
+	return archived, nil
+}
+
+// copies returns the photos of the lesser copies, excluding the photo of the best copy.
+func (w *Duplicates) copies(s query.Similar) (photos entity.Photos) {
+	best := s.Best()
+	done := map[uint]bool{best.PhotoID: true}
+
+	for _, f := range s.Copies() {
+		if done[f.PhotoID] {
+			continue
+		}
+
+		done[f.PhotoID] = true
+
+		if p := entity.FindPhoto(entity.Photo{ID: f.PhotoID}); p != nil {
+			photos = append(photos, *p)
+		}
+	}
+
+	return photos
+}
+
+// updateCounts updates precalculated counts and cover thumbs.
+func (w *Duplicates) updateCounts() {
+	if err := entity.UpdateCounts(); err != nil {
+		log.Warnf("duplicates: %s (update counts)", err)
+	}
+
+	if err := query.UpdateCovers(); err != nil {
+		log.Warnf("duplicates: %s (update covers)", err)
+	}
+}
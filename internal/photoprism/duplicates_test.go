@@ -0,0 +1,128 @@
+package photoprism
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/pkg/phash"
+)
+
+// createSimilar creates photos with a primary file each for testing.
+func createSimilar(t *testing.T, pathName string, widths ...int) (photos entity.Photos, files entity.Files) {
+	for i, w := range widths {
+		p := entity.NewPhoto(false)
+		p.PhotoPath = pathName
+
+		if err := p.Save(); err != nil {
+			t.Fatal(err)
+		}
+
+		f := entity.File{
+			PhotoID:     p.ID,
+			PhotoUID:    p.PhotoUID,
+			FileName:    pathName + "/" + p.PhotoUID + ".jpg",
+			FileRoot:    entity.RootOriginals,
+			FileType:    "jpg",
+			MediaType:   entity.MediaImage,
+			FilePrimary: true,
+			FileWidth:   w,
+			FileHeight:  w,
+			FilePHash:   (phash.Hash(0xf0f0f0f0f0f0f0f0) + phash.Hash(i)).Hex(),
+		}
+
+		if err := f.Create(); err != nil {
+			t.Fatal(err)
+		}
+
+		photos = append(photos, p)
+		files = append(files, f)
+	}
+
+	return photos, files
+}
+
+func TestNewDuplicates(t *testing.T) {
+	conf := config.TestConfig()
+
+	w := NewDuplicates(conf)
+
+	assert.IsType(t, &Duplicates{}, w)
+}
+
+func TestDuplicates_Hashes(t *testing.T) {
+	conf := config.TestConfig()
+
+	w := NewDuplicates(conf)
+
+	_, err := w.Hashes()
+
+	assert.NoError(t, err)
+}
+
+func TestDuplicates_Stack(t *testing.T) {
+	conf := config.TestConfig()
+
+	w := NewDuplicates(conf)
+
+	photos, _ := createSimilar(t, "duplicates-stack", 100, 200)
+
+	groups, err := w.Similar(phash.DefaultDistance, "duplicates-stack")
+
+	if err != nil {
+		t.Fatal(err)
+	} else if len(groups) != 1 {
+		t.Fatalf("expected one group, found %d", len(groups))
+	}
+
+	stacked, err := w.Stack(groups[0])
+
+	assert.NoError(t, err)
+
+	if assert.Len(t, stacked, 1) {
+		assert.Equal(t, photos[0].ID, stacked[0].ID)
+	}
+
+	groups, err = w.Similar(phash.DefaultDistance, "duplicates-stack")
+
+	assert.NoError(t, err)
+	assert.Empty(t, groups)
+
+	_, err = w.Stack(query.Similar{})
+
+	assert.Error(t, err)
+}
+
+func TestDuplicates_Archive(t *testing.T) {
+	conf := config.TestConfig()
+
+	w := NewDuplicates(conf)
+
+	photos, _ := createSimilar(t, "duplicates-archive", 300, 100, 200)
+
+	groups, err := w.Similar(phash.DefaultDistance, "duplicates-archive")
+
+	if err != nil {
+		t.Fatal(err)
+	} else if len(groups) != 1 {
+		t.Fatalf("expected one group, found %d", len(groups))
+	}
+
+	archived, err := w.Archive(groups[0])
+
+	assert.NoError(t, err)
+
+	if assert.Len(t, archived, 2) {
+		assert.Equal(t, photos[2].ID, archived[0].ID)
+		assert.Equal(t, photos[1].ID, archived[1].ID)
+		assert.NotNil(t, archived[0].DeletedAt)
+	}
+
+	groups, err = w.Similar(phash.DefaultDistance, "duplicates-archive")
+
+	assert.NoError(t, err)
+	assert.Empty(t, groups)
+}
@@ -0,0 +1,150 @@
+package photoprism
+
+import (
+	"errors"
+	"fmt"
+	"runtime/debug"
+	"time"
+
+	"github.com/dustin/go-humanize/english"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/mutex"
+	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/pkg/geo"
+)
+
+// DefaultGeotagMaxGap is the default maximum time between recorded track positions.
+const DefaultGeotagMaxGap = 10 * time.Minute
+
+// GeotagOptions represents options for geotagging photos based on recorded tracks.
+type GeotagOptions struct {
+	Offset time.Duration // Camera clock offset, i.e. the camera time minus the actual time.
+	MaxGap time.Duration // Maximum time between recorded positions.
+}
+
+// NewGeotagOptions returns new geotagging options.
+func NewGeotagOptions(offset, maxGap time.Duration) GeotagOptions {
+	if maxGap <= 0 {
+		maxGap = DefaultGeotagMaxGap
+	}
+
+	return GeotagOptions{Offset: offset, MaxGap: maxGap}
+}
+
+// Geotag represents a worker that sets the location of photos based on recorded tracks.
+type Geotag struct {
+	conf *config.Config
+}
+
+// NewGeotag returns a new Geotag worker.
+func NewGeotag(conf *config.Config) *Geotag {
+	return &Geotag{conf: conf}
+}
+
+// Start sets the location of photos taken while the track was recorded and returns the UIDs of updated photos.
+// Photos with a location from a source with a higher priority, e.g. Exif metadata, are not changed.
+func (w *Geotag) Start(track geo.Track, opt GeotagOptions) (updated []string, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("geotag: %s (panic)\nstack: %s", r, debug.Stack())
+			log.Error(err)
+		}
+	}()
+
+	if track.Empty() {
+		return updated, errors.New("geotag: track is empty")
+	} else if opt.MaxGap <= 0 {
+		opt.MaxGap = DefaultGeotagMaxGap
+	}
+
+	// Check if a worker is already running.
+	if err = mutex.MainWorker.Start(); err != nil {
+		log.Warnf("geotag: %s", err)
+		return updated, err
+	}
+
+	defer mutex.MainWorker.Stop()
+
+	start := time.Now()
+
+	// Find photos taken while the track was recorded, based on the camera time.
+	photos, err := query.PhotosTakenBetween(
+		track.Start().Add(opt.Offset-opt.MaxGap),
+		track.End().Add(opt.Offset+opt.MaxGap))
+
+	if err != nil {
+		return updated, err
+	} else if len(photos) == 0 {
+		log.Infof("geotag: found no photos taken between %s and %s", track.Start().Format(time.RFC3339), track.End().Format(time.RFC3339))
+		return updated, nil
+	}
+
+	log.Infof("geotag: found %s taken while the track was recorded", english.Plural(len(photos), "photo", "photos"))
+
+	for i := range photos {
+		if mutex.MainWorker.Canceled() {
+			break
+		}
+
+		p := &photos[i]
+
+		if ok, updateErr := w.Update(p, track, opt); updateErr != nil {
+			log.Errorf("geotag: %s while updating %s", updateErr, p.String())
+		} else if ok {
+			updated = append(updated, p.PhotoUID)
+		}
+	}
+
+	if len(updated) > 0 {
+		// Update precalculated photo and file counts.
+		if err = entity.UpdateCounts(); err != nil {
+			log.Warnf("geotag: %s (update counts)", err)
+		}
+	}
+
+	log.Infof("geotag: updated location of %s [%s]", english.Plural(len(updated), "photo", "photos"), time.Since(start))
+
+	return updated, nil
+}
+
+// Update sets the photo location based on the track position at the time the photo was taken,
+// and returns true if the location has been changed.
+func (w *Geotag) Update(p *entity.Photo, track geo.Track, opt GeotagOptions) (bool, error) {
+	if p == nil {
+		return false, errors.New("photo is nil")
+	} else if entity.SrcPriority[p.TakenSrc] <= entity.SrcPriority[entity.SrcName] {
+		// Skip photos without reliable time.
+		log.Debugf("geotag: %s has no reliable time", p.String())
+		return false, nil
+	} else if entity.SrcPriority[p.PlaceSrc] > entity.SrcPriority[entity.SrcTrack] && p.HasLatLng() {
+		// Keep location from a source with a higher priority.
+		log.Debugf("geotag: %s keeps %s location", p.String(), entity.SrcString(p.PlaceSrc))
+		return false, nil
+	}
+
+	pos, ok := track.Position(p.TakenAt.Add(-1*opt.Offset), opt.MaxGap)
+
+	if !ok {
+		log.Debugf("geotag: no track position for %s", p.String())
+		return false, nil
+	}
+
+	lat, lng := float32(pos.Lat), float32(pos.Lng)
+
+	if p.PlaceSrc == entity.SrcTrack && p.PhotoLat == lat && p.PhotoLng == lng {
+		return false, nil
+	}
+
+	p.SetCoordinates(lat, lng, pos.Altitude, entity.SrcTrack)
+	p.CellAccuracy = pos.Accuracy
+
+	if err := p.SaveLocation(); err != nil {
+		return false, err
+	}
+
+	log.Debugf("geotag: %s taken at %s", p.String(), pos.String())
+
+	return true, nil
+}
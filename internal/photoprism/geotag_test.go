@@ -0,0 +1,130 @@
+package photoprism
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/pkg/geo"
+)
+
+func TestNewGeotagOptions(t *testing.T) {
+	t.Run("Default", func(t *testing.T) {
+		opt := NewGeotagOptions(0, 0)
+		assert.Equal(t, time.Duration(0), opt.Offset)
+		assert.Equal(t, DefaultGeotagMaxGap, opt.MaxGap)
+	})
+	t.Run("Custom", func(t *testing.T) {
+		opt := NewGeotagOptions(time.Hour, time.Minute)
+		assert.Equal(t, time.Hour, opt.Offset)
+		assert.Equal(t, time.Minute, opt.MaxGap)
+	})
+}
+
+func TestGeotag_Start(t *testing.T) {
+	w := NewGeotag(config.TestConfig())
+
+	t.Run("CameraClockOffset", func(t *testing.T) {
+		photo := entity.NewPhoto(false)
+		photo.PhotoName = "geotag-test"
+		photo.PhotoTitle = "Geotag Test"
+		photo.TitleSrc = entity.SrcManual
+		photo.TakenAt = time.Date(1970, 7, 17, 15, 42, 12, 0, time.UTC)
+		photo.TakenAtLocal = photo.TakenAt
+		photo.TakenSrc = entity.SrcMeta
+
+		if err := photo.Create(); err != nil {
+			t.Fatal(err)
+		}
+
+		defer photo.DeletePermanently()
+
+		// The camera clock is one hour ahead of the actual time.
+		track := geo.NewTrack(
+			geo.Position{Time: time.Date(1970, 7, 17, 14, 40, 0, 0, time.UTC), Lat: 49.4120, Lng: 8.7090, Altitude: 110},
+			geo.Position{Time: time.Date(1970, 7, 17, 14, 44, 24, 0, time.UTC), Lat: 49.4140, Lng: 8.7110, Altitude: 120},
+		)
+
+		updated, err := w.Start(track, NewGeotagOptions(time.Hour, 10*time.Minute))
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, []string{photo.PhotoUID}, updated)
+
+		p, err := query.PhotoByUID(photo.PhotoUID)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, entity.SrcTrack, p.PlaceSrc)
+		assert.InDelta(t, 49.4130, p.PhotoLat, 0.0001)
+		assert.InDelta(t, 8.7100, p.PhotoLng, 0.0001)
+		assert.Equal(t, 115, p.PhotoAltitude)
+		assert.Equal(t, "Geotag Test", p.PhotoTitle)
+
+		// Unchanged when the same track is imported again.
+		updated, err = w.Start(track, NewGeotagOptions(time.Hour, 10*time.Minute))
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Empty(t, updated)
+	})
+	t.Run("NoPhotos", func(t *testing.T) {
+		track := geo.NewTrack(geo.Position{Time: time.Date(1800, 1, 1, 0, 0, 0, 0, time.UTC), Lat: 49.4, Lng: 8.7})
+
+		updated, err := w.Start(track, NewGeotagOptions(0, 0))
+
+		assert.NoError(t, err)
+		assert.Empty(t, updated)
+	})
+	t.Run("EmptyTrack", func(t *testing.T) {
+		_, err := w.Start(geo.Track{}, NewGeotagOptions(0, 0))
+		assert.Error(t, err)
+	})
+}
+
+func TestGeotag_Update(t *testing.T) {
+	w := NewGeotag(config.TestConfig())
+	taken := time.Date(2016, 11, 11, 9, 7, 18, 0, time.UTC)
+	track := geo.NewTrack(geo.Position{Time: taken, Lat: 49.4, Lng: 8.7})
+	opt := NewGeotagOptions(0, 0)
+
+	t.Run("MetaLocation", func(t *testing.T) {
+		p := &entity.Photo{TakenAt: taken, TakenSrc: entity.SrcMeta, PhotoLat: 1.234, PhotoLng: 4.321, PlaceSrc: entity.SrcMeta}
+
+		ok, err := w.Update(p, track, opt)
+
+		assert.NoError(t, err)
+		assert.False(t, ok)
+		assert.Equal(t, float32(1.234), p.PhotoLat)
+	})
+	t.Run("UnreliableTime", func(t *testing.T) {
+		p := &entity.Photo{TakenAt: taken, TakenSrc: entity.SrcName}
+
+		ok, err := w.Update(p, track, opt)
+
+		assert.NoError(t, err)
+		assert.False(t, ok)
+	})
+	t.Run("OutOfRange", func(t *testing.T) {
+		p := &entity.Photo{TakenAt: taken.Add(time.Hour), TakenSrc: entity.SrcMeta}
+
+		ok, err := w.Update(p, track, opt)
+
+		assert.NoError(t, err)
+		assert.False(t, ok)
+	})
+	t.Run("Nil", func(t *testing.T) {
+		_, err := w.Update(nil, track, opt)
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,91 @@
+package photoprism
+
+import (
+	"bytes"
+	"crypto/sha1"
+	"encoding/hex"
+	"errors"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/photoprism/photoprism/pkg/fs"
+	"github.com/photoprism/photoprism/pkg/geo"
+)
+
+// TrackExt is the file extension of stored tracks.
+const TrackExt = ".geojson"
+
+// SaveTrack stores a track as GeoJSON file in the specified folder, so that it can be displayed on the map.
+// Tracks with the same positions are only stored once.
+func SaveTrack(dir, name string, track geo.Track) (fileName string, err error) {
+	if dir == "" {
+		return "", errors.New("geotag: tracks folder is empty")
+	} else if track.Empty() {
+		return "", errors.New("geotag: track is empty")
+	}
+
+	data, err := track.GeoJSON(name)
+
+	if err != nil {
+		return "", err
+	}
+
+	if err = os.MkdirAll(dir, fs.ModeDir); err != nil {
+		return "", err
+	}
+
+	// Use a hash of the positions as file name to avoid duplicates.
+	hash := sha1.New()
+
+	for _, p := range track {
+		hash.Write([]byte(p.String() + p.Time.String()))
+	}
+
+	fileName = filepath.Join(dir, hex.EncodeToString(hash.Sum(nil))+TrackExt)
+
+	if err = os.WriteFile(fileName, data, fs.ModeFile); err != nil {
+		return "", err
+	}
+
+	return fileName, nil
+}
+
+// TracksGeoJSON returns the tracks stored in the specified folder as GeoJSON feature collection.
+func TracksGeoJSON(dir string) ([]byte, error) {
+	var b bytes.Buffer
+
+	b.WriteString(`{"type":"FeatureCollection","features":[`)
+
+	matches, err := filepath.Glob(filepath.Join(dir, "*"+TrackExt))
+
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Strings(matches)
+
+	n := 0
+
+	for _, fileName := range matches {
+		data, readErr := os.ReadFile(fileName)
+
+		if readErr != nil {
+			log.Warnf("geotag: %s", readErr)
+			continue
+		} else if data = bytes.TrimSpace(data); len(data) == 0 {
+			continue
+		}
+
+		if n > 0 {
+			b.WriteByte(',')
+		}
+
+		b.Write(data)
+		n++
+	}
+
+	b.WriteString(`]}`)
+
+	return b.Bytes(), nil
+}
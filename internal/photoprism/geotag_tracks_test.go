@@ -0,0 +1,88 @@
+package photoprism
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/pkg/geo"
+)
+
+func TestSaveTrack(t *testing.T) {
+	dir := t.TempDir()
+	start := time.Date(2023, 5, 17, 10, 0, 0, 0, time.UTC)
+	track := geo.NewTrack(
+		geo.Position{Time: start, Lat: 52.50, Lng: 13.40},
+		geo.Position{Time: start.Add(time.Minute), Lat: 52.51, Lng: 13.41},
+	)
+
+	t.Run("Success", func(t *testing.T) {
+		fileName, err := SaveTrack(dir, "hike.gpx", track)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, dir, filepath.Dir(fileName))
+		assert.Equal(t, TrackExt, filepath.Ext(fileName))
+
+		// Same positions are stored in the same file.
+		again, err := SaveTrack(dir, "copy.gpx", track)
+
+		assert.NoError(t, err)
+		assert.Equal(t, fileName, again)
+	})
+	t.Run("EmptyTrack", func(t *testing.T) {
+		_, err := SaveTrack(dir, "empty.gpx", geo.Track{})
+		assert.Error(t, err)
+	})
+	t.Run("EmptyPath", func(t *testing.T) {
+		_, err := SaveTrack("", "hike.gpx", track)
+		assert.Error(t, err)
+	})
+}
+
+func TestTracksGeoJSON(t *testing.T) {
+	t.Run("Tracks", func(t *testing.T) {
+		dir := t.TempDir()
+		start := time.Date(2023, 5, 17, 10, 0, 0, 0, time.UTC)
+
+		for i := 0; i < 2; i++ {
+			track := geo.NewTrack(
+				geo.Position{Time: start.Add(time.Duration(i) * time.Hour), Lat: 52.50, Lng: 13.40},
+				geo.Position{Time: start.Add(time.Duration(i)*time.Hour + time.Minute), Lat: 52.51, Lng: 13.41},
+			)
+
+			if _, err := SaveTrack(dir, "hike.gpx", track); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		data, err := TracksGeoJSON(dir)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var result struct {
+			Type     string            `json:"type"`
+			Features []json.RawMessage `json:"features"`
+		}
+
+		if err = json.Unmarshal(data, &result); err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "FeatureCollection", result.Type)
+		assert.Len(t, result.Features, 2)
+	})
+	t.Run("Empty", func(t *testing.T) {
+		data, err := TracksGeoJSON(t.TempDir())
+
+		assert.NoError(t, err)
+		assert.Equal(t, `{"type":"FeatureCollection","features":[]}`, string(data))
+	})
+}
@@ -84,7 +84,7 @@ func (imp *Import) Start(opt ImportOptions) fs.Done {
 		defer mutex.MainWorker.Stop()
 	}
 
-	if err := ind.tensorFlow.Init(); err != nil {
+	if err := ind.classifier.Init(); err != nil {
 		log.Errorf("import: %s", err.Error())
 		return done
 	}
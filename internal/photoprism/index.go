@@ -27,7 +27,7 @@ import (
 // Index represents an indexer that indexes files in the originals directory.
 type Index struct {
 	conf         *config.Config
-	tensorFlow   *classify.TensorFlow
+	classifier   classify.Classifier
 	nsfwDetector *nsfw.Detector
 	faceNet      *face.Net
 	convert      *Convert
@@ -40,7 +40,7 @@ type Index struct {
 }
 
 // NewIndex returns a new indexer and expects its dependencies as arguments.
-func NewIndex(conf *config.Config, tensorFlow *classify.TensorFlow, nsfwDetector *nsfw.Detector, faceNet *face.Net, convert *Convert, files *Files, photos *Photos) *Index {
+func NewIndex(conf *config.Config, classifier classify.Classifier, nsfwDetector *nsfw.Detector, faceNet *face.Net, convert *Convert, files *Files, photos *Photos) *Index {
 	if conf == nil {
 		log.Errorf("index: config is not set")
 		return nil
@@ -48,7 +48,7 @@ func NewIndex(conf *config.Config, tensorFlow *classify.TensorFlow, nsfwDetector
 
 	i := &Index{
 		conf:         conf,
-		tensorFlow:   tensorFlow,
+		classifier:   classifier,
 		nsfwDetector: nsfwDetector,
 		faceNet:      faceNet,
 		convert:      convert,
@@ -92,6 +92,22 @@ func (ind *Index) Start(o IndexOptions) (found fs.Done, updated int) {
 	originalsPath := ind.originalsPath()
 	optionsPath := filepath.Join(originalsPath, o.Path)
 
+	// Fetch new and modified originals from a remote bucket, if configured.
+	if ind.conf.S3Originals() {
+		originals := NewOriginals(ind.conf)
+
+		if fetched, err := originals.Fetch(o.Path, o.Rescan); err != nil {
+			event.Error(fmt.Sprintf("index: %s", err))
+			return found, updated
+		} else if len(fetched) == 0 {
+			log.Infof("index: found no new or modified originals in %s", originals.Storage())
+			return found, updated
+		} else {
+			// Remove local copies once indexing is complete.
+			defer originals.Evict(fetched)
+		}
+	}
+
 	if !fs.PathExists(optionsPath) {
 		event.Error(fmt.Sprintf("index: directory %s not found", clean.Log(optionsPath)))
 		return found, updated
@@ -107,7 +123,7 @@ func (ind *Index) Start(o IndexOptions) (found fs.Done, updated int) {
 
 	defer mutex.MainWorker.Stop()
 
-	if err := ind.tensorFlow.Init(); err != nil {
+	if err := ind.classifier.Init(); err != nil {
 		log.Errorf("index: %s", err.Error())
 
 		return found, updated
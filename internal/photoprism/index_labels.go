@@ -31,7 +31,7 @@ func (ind *Index) Labels(jpeg *MediaFile) (results classify.Labels) {
 			continue
 		}
 
-		imageLabels, err := ind.tensorFlow.File(filename)
+		imageLabels, err := ind.classifier.File(filename)
 
 		if err != nil {
 			log.Debugf("%s in %s", err, clean.Log(jpeg.BaseName()))
@@ -150,7 +150,7 @@ func (ind *Index) UserMediaFile(m *MediaFile, o IndexOptions, originalName, phot
 		} else if photoQuery = entity.UnscopedDb().First(&photo, "photo_path = ? AND photo_name = ? AND photo_stack > -1", filePath, fileBase); photoQuery.Error == nil {
 			// Found.
 			fileStacked = true
-		} else if photoQuery = entity.UnscopedDb().First(&photo, "id IN (SELECT photo_id FROM files WHERE file_name = LIKE ? AND file_root = ? AND file_sidecar = 0 AND file_missing = 0) AND photo_path = ? AND photo_stack > -1", fs.StripKnownExt(fileName)+".%", entity.RootOriginals, filePath); photoQuery.Error == nil {
+		} else if photoQuery = entity.UnscopedDb().First(&photo, "id IN (SELECT photo_id FROM files WHERE file_name = LIKE ? AND file_root = ? AND file_sidecar = ? AND file_missing = ?) AND photo_path = ? AND photo_stack > -1", fs.StripKnownExt(fileName)+".%", entity.RootOriginals, false, false, filePath); photoQuery.Error == nil {
 			// Found.
 			fileStacked = true
 		}
@@ -276,7 +276,7 @@ func (ind *Index) UserMediaFile(m *MediaFile, o IndexOptions, originalName, phot
 	// Flag first JPEG as primary file for this photo.
 	if !file.FilePrimary {
 		if photoExists {
-			if res := entity.UnscopedDb().Where("photo_id = ? AND file_primary = 1 AND file_type IN (?) AND file_error = ''", photo.ID, media.PreviewExpr).First(&primaryFile); res.Error != nil {
+			if res := entity.UnscopedDb().Where("photo_id = ? AND file_primary = ? AND file_type IN (?) AND file_error = ''", photo.ID, true, media.PreviewExpr).First(&primaryFile); res.Error != nil {
 				file.FilePrimary = m.IsPreviewImage()
 			}
 		} else {
@@ -379,6 +379,13 @@ func (ind *Index) UserMediaFile(m *MediaFile, o IndexOptions, originalName, phot
 			}
 		}
 
+		// Perceptual hash to find visually similar pictures.
+		if h, err := m.PerceptualHash(Config().ThumbCachePath()); err != nil {
+			log.Debugf("%s while creating perceptual hash", err.Error())
+		} else {
+			file.FilePHash = h.Hex()
+		}
+
 		if m.Width() > 0 && m.Height() > 0 {
 			file.FileWidth = m.Width()
 			file.FileHeight = m.Height()
@@ -902,6 +909,13 @@ func (ind *Index) UserMediaFile(m *MediaFile, o IndexOptions, originalName, phot
 		}
 	}
 
+	// Create sprite sheet and thumbnail track for scrubbing previews.
+	if photo.PhotoType == entity.MediaVideo && file.FileVideo && file.FileDuration > 0 && ind.conf.FFmpegEnabled() {
+		if err := CreateSprite(m.FileName(), file.FileHash, ind.thumbPath(), file.FileDuration, file.FileWidth, file.FileHeight, false); err != nil {
+			log.Warnf("index: %s in %s (create sprite)", err, logName)
+		}
+	}
+
 	result.FileID = file.ID
 	result.FileUID = file.FileUID
 
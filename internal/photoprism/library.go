@@ -0,0 +1,342 @@
+package photoprism
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v2"
+
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/pkg/fs"
+)
+
+// Library archive format, see ExportLibrary and ImportLibrary.
+const (
+	LibraryFormat   = "photoprism-library"
+	LibraryVersion  = 1
+	LibraryManifest = "manifest"
+	LibraryYaml     = "yaml"
+	LibraryJson     = "json"
+)
+
+// Library archive folder names, in the order they are imported.
+const (
+	LibraryLabels   = "labels"
+	LibrarySubjects = "subjects"
+	LibraryFaces    = "faces"
+	LibraryPhotos   = "photos"
+	LibraryAlbums   = "albums"
+)
+
+// LibraryFolders lists the library archive folders in import order.
+var LibraryFolders = []string{LibraryLabels, LibrarySubjects, LibraryFaces, LibraryPhotos, LibraryAlbums}
+
+// LibraryInfo represents the manifest that describes a library archive.
+type LibraryInfo struct {
+	Format        string        `json:"Format" yaml:"Format"`
+	Version       int           `json:"Version" yaml:"Version"`
+	Encoding      string        `json:"Encoding" yaml:"Encoding"`
+	Software      string        `json:"Software,omitempty" yaml:"Software,omitempty"`
+	Database      string        `json:"Database,omitempty" yaml:"Database,omitempty"`
+	OriginalsPath string        `json:"OriginalsPath,omitempty" yaml:"OriginalsPath,omitempty"`
+	CreatedAt     time.Time     `json:"CreatedAt" yaml:"CreatedAt"`
+	Counts        LibraryCounts `json:"Counts" yaml:"Counts"`
+}
+
+// LibraryCounts represents the number of entities in a library archive.
+type LibraryCounts struct {
+	Labels    int `json:"Labels" yaml:"Labels"`
+	Subjects  int `json:"Subjects" yaml:"Subjects"`
+	Faces     int `json:"Faces" yaml:"Faces"`
+	Photos    int `json:"Photos" yaml:"Photos"`
+	Files     int `json:"Files" yaml:"Files"`
+	Markers   int `json:"Markers" yaml:"Markers"`
+	Reactions int `json:"Reactions" yaml:"Reactions"`
+	Albums    int `json:"Albums" yaml:"Albums"`
+}
+
+// String returns a human-readable summary of the counts.
+func (c LibraryCounts) String() string {
+	return fmt.Sprintf("%d labels, %d subjects, %d faces, %d photos, %d files, %d markers, %d reactions, %d albums",
+		c.Labels, c.Subjects, c.Faces, c.Photos, c.Files, c.Markers, c.Reactions, c.Albums)
+}
+
+// LibraryFace represents a face cluster including its embedding.
+type LibraryFace struct {
+	ID              string     `json:"ID" yaml:"ID"`
+	Src             string     `json:"Src,omitempty" yaml:"Src,omitempty"`
+	Kind            int        `json:"Kind,omitempty" yaml:"Kind,omitempty"`
+	Hidden          bool       `json:"Hidden,omitempty" yaml:"Hidden,omitempty"`
+	SubjUID         string     `json:"SubjUID,omitempty" yaml:"SubjUID,omitempty"`
+	Samples         int        `json:"Samples,omitempty" yaml:"Samples,omitempty"`
+	SampleRadius    float64    `json:"SampleRadius,omitempty" yaml:"SampleRadius,omitempty"`
+	Collisions      int        `json:"Collisions,omitempty" yaml:"Collisions,omitempty"`
+	CollisionRadius float64    `json:"CollisionRadius,omitempty" yaml:"CollisionRadius,omitempty"`
+	Embedding       string     `json:"Embedding,omitempty" yaml:"Embedding,omitempty"`
+	MatchedAt       *time.Time `json:"MatchedAt,omitempty" yaml:"MatchedAt,omitempty"`
+	CreatedAt       time.Time  `json:"CreatedAt" yaml:"CreatedAt"`
+}
+
+// NewLibraryFace returns the archive representation of a face cluster.
+func NewLibraryFace(m entity.Face) LibraryFace {
+	return LibraryFace{
+		ID:              m.ID,
+		Src:             m.FaceSrc,
+		Kind:            m.FaceKind,
+		Hidden:          m.FaceHidden,
+		SubjUID:         m.SubjUID,
+		Samples:         m.Samples,
+		SampleRadius:    m.SampleRadius,
+		Collisions:      m.Collisions,
+		CollisionRadius: m.CollisionRadius,
+		Embedding:       string(m.EmbeddingJSON),
+		MatchedAt:       m.MatchedAt,
+		CreatedAt:       m.CreatedAt,
+	}
+}
+
+// Entity returns the face cluster entity.
+func (f LibraryFace) Entity() *entity.Face {
+	return &entity.Face{
+		ID:              f.ID,
+		FaceSrc:         f.Src,
+		FaceKind:        f.Kind,
+		FaceHidden:      f.Hidden,
+		SubjUID:         f.SubjUID,
+		Samples:         f.Samples,
+		SampleRadius:    f.SampleRadius,
+		Collisions:      f.Collisions,
+		CollisionRadius: f.CollisionRadius,
+		EmbeddingJSON:   json.RawMessage(f.Embedding),
+		MatchedAt:       f.MatchedAt,
+		CreatedAt:       f.CreatedAt,
+	}
+}
+
+// LibraryMarker represents a face or label marker including its embeddings.
+type LibraryMarker struct {
+	UID        string     `json:"UID" yaml:"UID"`
+	Type       string     `json:"Type" yaml:"Type"`
+	Src        string     `json:"Src,omitempty" yaml:"Src,omitempty"`
+	Name       string     `json:"Name,omitempty" yaml:"Name,omitempty"`
+	Review     bool       `json:"Review,omitempty" yaml:"Review,omitempty"`
+	Invalid    bool       `json:"Invalid,omitempty" yaml:"Invalid,omitempty"`
+	SubjUID    string     `json:"SubjUID,omitempty" yaml:"SubjUID,omitempty"`
+	SubjSrc    string     `json:"SubjSrc,omitempty" yaml:"SubjSrc,omitempty"`
+	FaceID     string     `json:"FaceID,omitempty" yaml:"FaceID,omitempty"`
+	FaceDist   float64    `json:"FaceDist,omitempty" yaml:"FaceDist,omitempty"`
+	Embeddings string     `json:"Embeddings,omitempty" yaml:"Embeddings,omitempty"`
+	Landmarks  string     `json:"Landmarks,omitempty" yaml:"Landmarks,omitempty"`
+	X          float32    `json:"X" yaml:"X"`
+	Y          float32    `json:"Y" yaml:"Y"`
+	W          float32    `json:"W" yaml:"W"`
+	H          float32    `json:"H" yaml:"H"`
+	Q          int        `json:"Q,omitempty" yaml:"Q,omitempty"`
+	Size       int        `json:"Size,omitempty" yaml:"Size,omitempty"`
+	Score      int        `json:"Score,omitempty" yaml:"Score,omitempty"`
+	Thumb      string     `json:"Thumb,omitempty" yaml:"Thumb,omitempty"`
+	MatchedAt  *time.Time `json:"MatchedAt,omitempty" yaml:"MatchedAt,omitempty"`
+}
+
+// NewLibraryMarker returns the archive representation of a marker.
+func NewLibraryMarker(m entity.Marker) LibraryMarker {
+	return LibraryMarker{
+		UID:        m.MarkerUID,
+		Type:       m.MarkerType,
+		Src:        m.MarkerSrc,
+		Name:       m.MarkerName,
+		Review:     m.MarkerReview,
+		Invalid:    m.MarkerInvalid,
+		SubjUID:    m.SubjUID,
+		SubjSrc:    m.SubjSrc,
+		FaceID:     m.FaceID,
+		FaceDist:   m.FaceDist,
+		Embeddings: string(m.EmbeddingsJSON),
+		Landmarks:  string(m.LandmarksJSON),
+		X:          m.X,
+		Y:          m.Y,
+		W:          m.W,
+		H:          m.H,
+		Q:          m.Q,
+		Size:       m.Size,
+		Score:      m.Score,
+		Thumb:      m.Thumb,
+		MatchedAt:  m.MatchedAt,
+	}
+}
+
+// Entity returns the marker entity for the specified file.
+func (m LibraryMarker) Entity(fileUID string) *entity.Marker {
+	return &entity.Marker{
+		MarkerUID:      m.UID,
+		FileUID:        fileUID,
+		MarkerType:     m.Type,
+		MarkerSrc:      m.Src,
+		MarkerName:     m.Name,
+		MarkerReview:   m.Review,
+		MarkerInvalid:  m.Invalid,
+		SubjUID:        m.SubjUID,
+		SubjSrc:        m.SubjSrc,
+		FaceID:         m.FaceID,
+		FaceDist:       m.FaceDist,
+		EmbeddingsJSON: json.RawMessage(m.Embeddings),
+		LandmarksJSON:  json.RawMessage(m.Landmarks),
+		X:              m.X,
+		Y:              m.Y,
+		W:              m.W,
+		H:              m.H,
+		Q:              m.Q,
+		Size:           m.Size,
+		Score:          m.Score,
+		Thumb:          m.Thumb,
+		MatchedAt:      m.MatchedAt,
+	}
+}
+
+// LibraryFile represents an indexed file, which is matched by hash or path when importing.
+type LibraryFile struct {
+	UID     string          `json:"UID" yaml:"UID"`
+	Root    string          `json:"Root" yaml:"Root"`
+	Name    string          `json:"Name" yaml:"Name"`
+	Hash    string          `json:"Hash,omitempty" yaml:"Hash,omitempty"`
+	Primary bool            `json:"Primary,omitempty" yaml:"Primary,omitempty"`
+	Markers []LibraryMarker `json:"Markers,omitempty" yaml:"Markers,omitempty"`
+}
+
+// LibraryPhotoLabel represents a label assigned to a photo.
+type LibraryPhotoLabel struct {
+	UID         string `json:"UID" yaml:"UID"`
+	Name        string `json:"Name" yaml:"Name"`
+	Src         string `json:"Src" yaml:"Src"`
+	Model       string `json:"Model,omitempty" yaml:"Model,omitempty"`
+	Uncertainty int    `json:"Uncertainty" yaml:"Uncertainty"`
+}
+
+// LibraryReaction represents a user reaction such as a like.
+type LibraryReaction struct {
+	User      string     `json:"User" yaml:"User"`
+	Reaction  string     `json:"Reaction" yaml:"Reaction"`
+	Reacted   int        `json:"Reacted,omitempty" yaml:"Reacted,omitempty"`
+	ReactedAt *time.Time `json:"ReactedAt,omitempty" yaml:"ReactedAt,omitempty"`
+}
+
+// LibraryPhoto represents a photo with its files, labels, and user reactions.
+type LibraryPhoto struct {
+	Photo     *entity.Photo       `json:"Photo" yaml:"Photo"`
+	Owner     string              `json:"Owner,omitempty" yaml:"Owner,omitempty"`
+	Files     []LibraryFile       `json:"Files" yaml:"Files"`
+	Labels    []LibraryPhotoLabel `json:"Labels,omitempty" yaml:"Labels,omitempty"`
+	Reactions []LibraryReaction   `json:"Reactions,omitempty" yaml:"Reactions,omitempty"`
+}
+
+// LibraryAlbumPhoto represents a photo in an album.
+type LibraryAlbumPhoto struct {
+	UID       string    `json:"UID" yaml:"UID"`
+	Order     int       `json:"Order,omitempty" yaml:"Order,omitempty"`
+	Hidden    bool      `json:"Hidden,omitempty" yaml:"Hidden,omitempty"`
+	CreatedAt time.Time `json:"CreatedAt" yaml:"CreatedAt"`
+}
+
+// LibraryAlbum represents an album with its photos.
+type LibraryAlbum struct {
+	Album     *entity.Album       `json:"Album" yaml:"Album"`
+	Owner     string              `json:"Owner,omitempty" yaml:"Owner,omitempty"`
+	Photos    []LibraryAlbumPhoto `json:"Photos,omitempty" yaml:"Photos,omitempty"`
+	Reactions []LibraryReaction   `json:"Reactions,omitempty" yaml:"Reactions,omitempty"`
+}
+
+// LibraryLabel represents a label including user reactions.
+type LibraryLabel struct {
+	Label     *entity.Label     `json:"Label" yaml:"Label"`
+	Reactions []LibraryReaction `json:"Reactions,omitempty" yaml:"Reactions,omitempty"`
+}
+
+// LibrarySubject represents a subject such as a person including user reactions.
+type LibrarySubject struct {
+	Subject   *entity.Subject   `json:"Subject" yaml:"Subject"`
+	Reactions []LibraryReaction `json:"Reactions,omitempty" yaml:"Reactions,omitempty"`
+}
+
+// LibraryExt returns the file extension for the specified archive encoding.
+func LibraryExt(encoding string) string {
+	if encoding == LibraryJson {
+		return fs.ExtJSON
+	}
+
+	return fs.ExtYAML
+}
+
+// LibraryEncoding returns the normalized archive encoding or an error if it is not supported.
+func LibraryEncoding(s string) (string, error) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "", "yml", LibraryYaml:
+		return LibraryYaml, nil
+	case LibraryJson:
+		return LibraryJson, nil
+	default:
+		return "", fmt.Errorf("unsupported encoding %s", s)
+	}
+}
+
+// writeLibraryFile encodes the value and writes it to the specified file.
+func writeLibraryFile(fileName, encoding string, v interface{}) (err error) {
+	var data []byte
+
+	if encoding == LibraryJson {
+		data, err = json.MarshalIndent(v, "", "  ")
+	} else {
+		data, err = yaml.Marshal(v)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	if err = os.MkdirAll(filepath.Dir(fileName), fs.ModeDir); err != nil {
+		return err
+	}
+
+	return os.WriteFile(fileName, data, fs.ModeFile)
+}
+
+// readLibraryFile reads the specified file and decodes it into the value based on the file extension.
+func readLibraryFile(fileName string, v interface{}) error {
+	data, err := os.ReadFile(fileName)
+
+	if err != nil {
+		return err
+	}
+
+	if strings.HasSuffix(fileName, fs.ExtJSON) {
+		return json.Unmarshal(data, v)
+	}
+
+	return yaml.Unmarshal(data, v)
+}
+
+// ReadLibraryInfo reads the manifest of the library archive in the specified path.
+func ReadLibraryInfo(archivePath string) (info LibraryInfo, err error) {
+	for _, encoding := range []string{LibraryYaml, LibraryJson} {
+		fileName := filepath.Join(archivePath, LibraryManifest+LibraryExt(encoding))
+
+		if !fs.FileExists(fileName) {
+			continue
+		}
+
+		if err = readLibraryFile(fileName, &info); err != nil {
+			return info, fmt.Errorf("invalid manifest (%s)", err)
+		} else if info.Format != LibraryFormat {
+			return info, fmt.Errorf("unknown archive format %s", info.Format)
+		} else if info.Version < 1 || info.Version > LibraryVersion {
+			return info, fmt.Errorf("unsupported archive version %d", info.Version)
+		}
+
+		return info, nil
+	}
+
+	return info, fmt.Errorf("no library archive found in %s", archivePath)
+}
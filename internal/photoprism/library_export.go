@@ -0,0 +1,399 @@
+package photoprism
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/pkg/clean"
+	"github.com/photoprism/photoprism/pkg/fs"
+)
+
+// LibraryExport represents a worker that exports the library metadata to a portable archive,
+// so that it can be imported by another instance regardless of the database driver.
+type LibraryExport struct {
+	conf      *config.Config
+	encoding  string
+	path      string
+	users     map[string]string
+	reactions map[string][]LibraryReaction
+	info      LibraryInfo
+}
+
+// LibraryExportOptions represents library export options.
+type LibraryExportOptions struct {
+	Path     string
+	Encoding string
+	Force    bool
+}
+
+// NewLibraryExport returns a new LibraryExport worker.
+func NewLibraryExport(conf *config.Config) *LibraryExport {
+	instance := &LibraryExport{
+		conf: conf,
+	}
+
+	return instance
+}
+
+// Start exports the library metadata to the specified path and returns the archive manifest.
+func (w *LibraryExport) Start(opt LibraryExportOptions) (info LibraryInfo, err error) {
+	if opt.Path == "" {
+		return info, fmt.Errorf("export path required")
+	}
+
+	if w.encoding, err = LibraryEncoding(opt.Encoding); err != nil {
+		return info, err
+	}
+
+	w.path = opt.Path
+
+	// Don't overwrite existing archives unless forced.
+	if _, err = ReadLibraryInfo(w.path); err == nil && !opt.Force {
+		return info, fmt.Errorf("%s already contains a library archive", clean.Log(w.path))
+	}
+
+	// Remove previously exported files so that the archive does not contain stale entities.
+	for _, folder := range LibraryFolders {
+		if err = os.RemoveAll(filepath.Join(w.path, folder)); err != nil {
+			return info, err
+		}
+	}
+
+	for _, encoding := range []string{LibraryYaml, LibraryJson} {
+		_ = os.Remove(filepath.Join(w.path, LibraryManifest+LibraryExt(encoding)))
+	}
+
+	if err = os.MkdirAll(w.path, fs.ModeDir); err != nil {
+		return info, err
+	}
+
+	start := time.Now()
+
+	w.info = LibraryInfo{
+		Format:        LibraryFormat,
+		Version:       LibraryVersion,
+		Encoding:      w.encoding,
+		Software:      w.conf.Version(),
+		Database:      w.conf.DatabaseDriver(),
+		OriginalsPath: w.conf.OriginalsPath(),
+		CreatedAt:     entity.TimeStamp(),
+	}
+
+	// Map user uids to usernames, as uids differ between instances.
+	w.users = make(map[string]string)
+
+	for _, u := range query.RegisteredUsers() {
+		w.users[u.UserUID] = u.Username()
+	}
+
+	log.Infof("export: writing library to %s", clean.Log(w.path))
+
+	if err = w.labels(); err != nil {
+		return info, fmt.Errorf("%s (export labels)", err)
+	} else if err = w.subjects(); err != nil {
+		return info, fmt.Errorf("%s (export subjects)", err)
+	} else if err = w.faces(); err != nil {
+		return info, fmt.Errorf("%s (export faces)", err)
+	} else if err = w.photos(); err != nil {
+		return info, fmt.Errorf("%s (export photos)", err)
+	} else if err = w.albums(); err != nil {
+		return info, fmt.Errorf("%s (export albums)", err)
+	}
+
+	// Write the manifest last, so that incomplete archives cannot be imported.
+	if err = w.write("", LibraryManifest, w.info); err != nil {
+		return info, err
+	}
+
+	log.Infof("export: exported %s [%s]", w.info.Counts, time.Since(start))
+
+	return w.info, nil
+}
+
+// write encodes the value and writes it to a file in the specified archive folder.
+func (w *LibraryExport) write(folder, name string, v interface{}) error {
+	return writeLibraryFile(filepath.Join(w.path, folder, name+LibraryExt(w.encoding)), w.encoding, v)
+}
+
+// owner returns the username of the specified user uid.
+func (w *LibraryExport) owner(userUID string) string {
+	return w.users[userUID]
+}
+
+// loadReactions loads the user reactions to the specified entities.
+func (w *LibraryExport) loadReactions(uids []string) error {
+	w.reactions = make(map[string][]LibraryReaction, len(uids))
+
+	results, err := query.Reactions(uids)
+
+	if err != nil {
+		return err
+	}
+
+	for _, r := range results {
+		userName := w.owner(r.UserUID)
+
+		if userName == "" {
+			continue
+		}
+
+		w.reactions[r.UID] = append(w.reactions[r.UID], LibraryReaction{
+			User:      userName,
+			Reaction:  r.Reaction,
+			Reacted:   r.Reacted,
+			ReactedAt: r.ReactedAt,
+		})
+
+		w.info.Counts.Reactions++
+	}
+
+	return nil
+}
+
+// labels exports all labels.
+func (w *LibraryExport) labels() error {
+	labels, err := query.LibraryLabels()
+
+	if err != nil {
+		return err
+	}
+
+	uids := make([]string, len(labels))
+
+	for i := range labels {
+		uids[i] = labels[i].LabelUID
+	}
+
+	if err = w.loadReactions(uids); err != nil {
+		return err
+	}
+
+	for i := range labels {
+		m := &labels[i]
+
+		if err = w.write(LibraryLabels, m.LabelUID, LibraryLabel{Label: m, Reactions: w.reactions[m.LabelUID]}); err != nil {
+			return err
+		}
+
+		w.info.Counts.Labels++
+	}
+
+	return nil
+}
+
+// subjects exports all subjects.
+func (w *LibraryExport) subjects() error {
+	subjects, err := query.LibrarySubjects()
+
+	if err != nil {
+		return err
+	}
+
+	uids := make([]string, len(subjects))
+
+	for i := range subjects {
+		uids[i] = subjects[i].SubjUID
+	}
+
+	if err = w.loadReactions(uids); err != nil {
+		return err
+	}
+
+	for i := range subjects {
+		m := &subjects[i]
+
+		if err = w.write(LibrarySubjects, m.SubjUID, LibrarySubject{Subject: m, Reactions: w.reactions[m.SubjUID]}); err != nil {
+			return err
+		}
+
+		w.info.Counts.Subjects++
+	}
+
+	return nil
+}
+
+// faces exports all face clusters.
+func (w *LibraryExport) faces() error {
+	faces, err := query.LibraryFaces()
+
+	if err != nil {
+		return err
+	}
+
+	for _, m := range faces {
+		if err = w.write(LibraryFaces, m.ID, NewLibraryFace(m)); err != nil {
+			return err
+		}
+
+		w.info.Counts.Faces++
+	}
+
+	return nil
+}
+
+// photos exports all photos, including archived photos, with their files, markers, labels, and reactions.
+func (w *LibraryExport) photos() error {
+	limit := 1000
+	offset := 0
+
+	for {
+		photos, err := query.LibraryPhotos(limit, offset)
+
+		if err != nil {
+			return err
+		} else if len(photos) == 0 {
+			break
+		}
+
+		photoUIDs := make([]string, 0, len(photos))
+		fileUIDs := make([]string, 0, len(photos))
+
+		for _, p := range photos {
+			photoUIDs = append(photoUIDs, p.PhotoUID)
+
+			for _, f := range p.Files {
+				fileUIDs = append(fileUIDs, f.FileUID)
+			}
+		}
+
+		markers, err := query.FileMarkers(fileUIDs)
+
+		if err != nil {
+			return err
+		}
+
+		fileMarkers := make(map[string][]LibraryMarker, len(markers))
+
+		for _, m := range markers {
+			fileMarkers[m.FileUID] = append(fileMarkers[m.FileUID], NewLibraryMarker(m))
+		}
+
+		if err = w.loadReactions(photoUIDs); err != nil {
+			return err
+		}
+
+		for i := range photos {
+			p := &photos[i]
+
+			doc := LibraryPhoto{
+				Photo:     p,
+				Owner:     w.owner(p.CreatedBy),
+				Files:     make([]LibraryFile, 0, len(p.Files)),
+				Labels:    make([]LibraryPhotoLabel, 0, len(p.Labels)),
+				Reactions: w.reactions[p.PhotoUID],
+			}
+
+			for _, f := range p.Files {
+				doc.Files = append(doc.Files, LibraryFile{
+					UID:     f.FileUID,
+					Root:    f.FileRoot,
+					Name:    f.FileName,
+					Hash:    f.FileHash,
+					Primary: f.FilePrimary,
+					Markers: fileMarkers[f.FileUID],
+				})
+
+				w.info.Counts.Markers += len(fileMarkers[f.FileUID])
+			}
+
+			for _, l := range p.Labels {
+				if l.Label == nil {
+					continue
+				}
+
+				doc.Labels = append(doc.Labels, LibraryPhotoLabel{
+					UID:         l.Label.LabelUID,
+					Name:        l.Label.LabelName,
+					Src:         l.LabelSrc,
+					Model:       l.LabelModel,
+					Uncertainty: l.Uncertainty,
+				})
+			}
+
+			// Files and labels are exported separately with portable references.
+			p.Files = nil
+			p.Labels = nil
+
+			if err = w.write(LibraryPhotos, p.PhotoUID, doc); err != nil {
+				return err
+			}
+
+			w.info.Counts.Photos++
+			w.info.Counts.Files += len(doc.Files)
+		}
+
+		offset += limit
+	}
+
+	return nil
+}
+
+// albums exports all albums, including archived albums, with their photos.
+func (w *LibraryExport) albums() error {
+	limit := 1000
+	offset := 0
+
+	for {
+		albums, err := query.Albums(offset, limit)
+
+		if err != nil {
+			return err
+		} else if len(albums) == 0 {
+			break
+		}
+
+		uids := make([]string, len(albums))
+
+		for i := range albums {
+			uids[i] = albums[i].AlbumUID
+		}
+
+		if err = w.loadReactions(uids); err != nil {
+			return err
+		}
+
+		for i := range albums {
+			a := &albums[i]
+
+			entries, err := query.AlbumEntries(a.AlbumUID)
+
+			if err != nil {
+				return err
+			}
+
+			doc := LibraryAlbum{
+				Album:     a,
+				Owner:     w.owner(a.CreatedBy),
+				Photos:    make([]LibraryAlbumPhoto, 0, len(entries)),
+				Reactions: w.reactions[a.AlbumUID],
+			}
+
+			for _, e := range entries {
+				doc.Photos = append(doc.Photos, LibraryAlbumPhoto{
+					UID:       e.PhotoUID,
+					Order:     e.Order,
+					Hidden:    e.Hidden,
+					CreatedAt: e.CreatedAt,
+				})
+			}
+
+			// Album photos are exported separately with portable references.
+			a.Photos = nil
+
+			if err = w.write(LibraryAlbums, a.AlbumUID, doc); err != nil {
+				return err
+			}
+
+			w.info.Counts.Albums++
+		}
+
+		offset += limit
+	}
+
+	return nil
+}
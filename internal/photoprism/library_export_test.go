@@ -0,0 +1,109 @@
+package photoprism
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/query"
+)
+
+func TestNewLibraryExport(t *testing.T) {
+	w := NewLibraryExport(config.TestConfig())
+	assert.IsType(t, &LibraryExport{}, w)
+}
+
+func TestLibraryExport_Start(t *testing.T) {
+	t.Run("Yaml", func(t *testing.T) {
+		dir := t.TempDir()
+		w := NewLibraryExport(Config())
+
+		info, err := w.Start(LibraryExportOptions{Path: dir})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, LibraryFormat, info.Format)
+		assert.Equal(t, LibraryVersion, info.Version)
+		assert.Equal(t, LibraryYaml, info.Encoding)
+		assert.Greater(t, info.Counts.Labels, 0)
+		assert.Greater(t, info.Counts.Subjects, 0)
+		assert.Greater(t, info.Counts.Faces, 0)
+		assert.Greater(t, info.Counts.Photos, 0)
+		assert.Greater(t, info.Counts.Files, 0)
+		assert.Greater(t, info.Counts.Markers, 0)
+		assert.Greater(t, info.Counts.Albums, 0)
+
+		manifest, err := ReadLibraryInfo(dir)
+
+		assert.NoError(t, err)
+		assert.Equal(t, info.Counts, manifest.Counts)
+
+		photo := entity.PhotoFixtures.Get("Photo01")
+		doc := LibraryPhoto{}
+
+		assert.NoError(t, readLibraryFile(filepath.Join(dir, LibraryPhotos, photo.PhotoUID+".yml"), &doc))
+		assert.Equal(t, photo.PhotoTitle, doc.Photo.PhotoTitle)
+		assert.Nil(t, doc.Photo.Files)
+		assert.NotEmpty(t, doc.Files)
+		assert.NotEmpty(t, doc.Labels)
+
+		faces, err := query.LibraryFaces()
+
+		if err != nil || len(faces) == 0 {
+			t.Fatal("faces expected")
+		}
+
+		f := faces[0]
+		face := LibraryFace{}
+
+		assert.NoError(t, readLibraryFile(filepath.Join(dir, LibraryFaces, f.ID+".yml"), &face))
+		assert.Equal(t, string(f.EmbeddingJSON), face.Embedding)
+
+		// Existing archives are only replaced if forced.
+		_, err = w.Start(LibraryExportOptions{Path: dir})
+		assert.Error(t, err)
+	})
+	t.Run("JsonForce", func(t *testing.T) {
+		dir := t.TempDir()
+		w := NewLibraryExport(Config())
+
+		_, err := w.Start(LibraryExportOptions{Path: dir, Encoding: LibraryYaml})
+		assert.NoError(t, err)
+
+		info, err := w.Start(LibraryExportOptions{Path: dir, Encoding: LibraryJson, Force: true})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, LibraryJson, info.Encoding)
+		assert.FileExists(t, filepath.Join(dir, "manifest.json"))
+		assert.NoFileExists(t, filepath.Join(dir, "manifest.yml"))
+
+		album := entity.AlbumFixtures.Get("holiday-2030")
+		doc := LibraryAlbum{}
+
+		assert.NoError(t, readLibraryFile(filepath.Join(dir, LibraryAlbums, album.AlbumUID+".json"), &doc))
+		assert.Equal(t, album.AlbumTitle, doc.Album.AlbumTitle)
+		assert.NotEmpty(t, doc.Photos)
+
+		entries, err := os.ReadDir(filepath.Join(dir, LibraryAlbums))
+
+		assert.NoError(t, err)
+		assert.Len(t, entries, info.Counts.Albums)
+	})
+	t.Run("InvalidEncoding", func(t *testing.T) {
+		_, err := NewLibraryExport(Config()).Start(LibraryExportOptions{Path: t.TempDir(), Encoding: "xml"})
+		assert.Error(t, err)
+	})
+	t.Run("NoPath", func(t *testing.T) {
+		_, err := NewLibraryExport(Config()).Start(LibraryExportOptions{})
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,553 @@
+package photoprism
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v2"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/mutex"
+	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/pkg/clean"
+	"github.com/photoprism/photoprism/pkg/fs"
+)
+
+// LibraryImport represents a worker that imports library metadata from a portable archive
+// created with LibraryExport. Photos are matched with indexed files by hash or file name,
+// so originals must be indexed before their metadata can be imported.
+type LibraryImport struct {
+	conf     *config.Config
+	path     string
+	users    map[string]string
+	labels   map[string]*entity.Label
+	subjects map[string]string
+	photos   map[string]string
+	counts   LibraryCounts
+}
+
+// LibraryImportOptions represents library import options.
+type LibraryImportOptions struct {
+	Path string
+}
+
+// NewLibraryImport returns a new LibraryImport worker.
+func NewLibraryImport(conf *config.Config) *LibraryImport {
+	instance := &LibraryImport{
+		conf: conf,
+	}
+
+	return instance
+}
+
+// Start imports the library archive in the specified path and returns the number of imported entities.
+func (w *LibraryImport) Start(opt LibraryImportOptions) (counts LibraryCounts, err error) {
+	if err = mutex.MainWorker.Start(); err != nil {
+		return counts, err
+	}
+
+	defer mutex.MainWorker.Stop()
+
+	info, err := ReadLibraryInfo(opt.Path)
+
+	if err != nil {
+		return counts, err
+	}
+
+	start := time.Now()
+
+	w.path = opt.Path
+	w.counts = LibraryCounts{}
+	w.labels = make(map[string]*entity.Label)
+	w.subjects = make(map[string]string)
+	w.photos = make(map[string]string)
+
+	// Map usernames to local user uids.
+	w.users = make(map[string]string)
+
+	for _, u := range query.RegisteredUsers() {
+		w.users[u.Username()] = u.UserUID
+	}
+
+	log.Infof("import: reading library archive version %d created with %s on %s", info.Version, clean.Log(info.Software), info.CreatedAt.Format("2006-01-02"))
+
+	for _, folder := range LibraryFolders {
+		if mutex.MainWorker.Canceled() {
+			return w.counts, fmt.Errorf("canceled")
+		}
+
+		fileNames, err := w.files(folder)
+
+		if err != nil {
+			return w.counts, err
+		}
+
+		for _, fileName := range fileNames {
+			if err = w.importFile(folder, fileName); err != nil {
+				log.Errorf("import: %s in %s", err, clean.Log(filepath.Join(folder, filepath.Base(fileName))))
+			}
+		}
+	}
+
+	// Update precalculated counts and covers.
+	if err = entity.UpdateCounts(); err != nil {
+		log.Warnf("import: %s (update counts)", err)
+	}
+
+	if err = query.UpdateCovers(); err != nil {
+		log.Warnf("import: %s (update covers)", err)
+	}
+
+	log.Infof("import: imported %s [%s]", w.counts, time.Since(start))
+
+	return w.counts, nil
+}
+
+// files returns the names of the archive files in the specified folder.
+func (w *LibraryImport) files(folder string) (fileNames []string, err error) {
+	dir := filepath.Join(w.path, folder)
+	entries, err := os.ReadDir(dir)
+
+	if os.IsNotExist(err) {
+		return fileNames, nil
+	} else if err != nil {
+		return fileNames, err
+	}
+
+	for _, entry := range entries {
+		name := entry.Name()
+
+		if entry.IsDir() || strings.HasPrefix(name, ".") {
+			continue
+		} else if ext := filepath.Ext(name); ext != fs.ExtYAML && ext != fs.ExtJSON {
+			continue
+		}
+
+		fileNames = append(fileNames, filepath.Join(dir, name))
+	}
+
+	sort.Strings(fileNames)
+
+	return fileNames, nil
+}
+
+// importFile imports a single archive file.
+func (w *LibraryImport) importFile(folder, fileName string) error {
+	switch folder {
+	case LibraryLabels:
+		doc := LibraryLabel{}
+
+		if err := readLibraryFile(fileName, &doc); err != nil {
+			return err
+		}
+
+		return w.label(doc)
+	case LibrarySubjects:
+		doc := LibrarySubject{}
+
+		if err := readLibraryFile(fileName, &doc); err != nil {
+			return err
+		}
+
+		return w.subject(doc)
+	case LibraryFaces:
+		doc := LibraryFace{}
+
+		if err := readLibraryFile(fileName, &doc); err != nil {
+			return err
+		}
+
+		return w.face(doc)
+	case LibraryPhotos:
+		doc := LibraryPhoto{}
+
+		if err := readLibraryFile(fileName, &doc); err != nil {
+			return err
+		}
+
+		return w.photo(doc)
+	case LibraryAlbums:
+		doc := LibraryAlbum{}
+
+		if err := readLibraryFile(fileName, &doc); err != nil {
+			return err
+		}
+
+		return w.album(doc)
+	default:
+		return fmt.Errorf("unknown folder %s", clean.Log(folder))
+	}
+}
+
+// react restores the user reactions to an entity with the specified uid.
+func (w *LibraryImport) react(uid string, reactions []LibraryReaction) {
+	for _, r := range reactions {
+		userUID, ok := w.users[r.User]
+
+		if !ok {
+			log.Debugf("import: user %s not found", clean.Log(r.User))
+			continue
+		} else if entity.FindReaction(uid, userUID) != nil {
+			continue
+		}
+
+		m := entity.NewReaction(uid, userUID)
+		m.Reaction = r.Reaction
+		m.Reacted = r.Reacted
+
+		if err := m.Create(); err != nil {
+			log.Warnf("import: %s (react)", err)
+		} else {
+			w.counts.Reactions++
+		}
+	}
+}
+
+// label imports a label.
+func (w *LibraryImport) label(doc LibraryLabel) error {
+	if doc.Label == nil || doc.Label.LabelName == "" {
+		return fmt.Errorf("label name missing")
+	}
+
+	m, err := query.LabelByUID(doc.Label.LabelUID)
+
+	label := &m
+
+	if err != nil {
+		if label = entity.FirstOrCreateLabel(entity.NewLabel(doc.Label.LabelName, doc.Label.LabelPriority)); label == nil {
+			return fmt.Errorf("failed adding label %s", clean.Log(doc.Label.LabelName))
+		}
+	}
+
+	label.LabelPriority = doc.Label.LabelPriority
+	label.LabelFavorite = doc.Label.LabelFavorite
+	label.LabelDescription = doc.Label.LabelDescription
+	label.LabelNotes = doc.Label.LabelNotes
+	label.PublishedAt = doc.Label.PublishedAt
+
+	if err = label.Save(); err != nil {
+		return err
+	}
+
+	w.labels[doc.Label.LabelUID] = label
+	w.react(label.LabelUID, doc.Reactions)
+	w.counts.Labels++
+
+	return nil
+}
+
+// subject imports a subject such as a person.
+func (w *LibraryImport) subject(doc LibrarySubject) error {
+	if doc.Subject == nil || doc.Subject.SubjName == "" {
+		return fmt.Errorf("subject name missing")
+	}
+
+	s := doc.Subject
+	m := entity.FindSubject(s.SubjUID)
+
+	if m == nil {
+		m = entity.FindSubjectByName(s.SubjName)
+	}
+
+	if m == nil {
+		m = entity.NewSubject(s.SubjName, s.SubjType, s.SubjSrc)
+
+		if m = entity.FirstOrCreateSubject(m); m == nil {
+			return fmt.Errorf("failed adding subject %s", clean.Log(s.SubjName))
+		}
+	}
+
+	if err := m.Updates(entity.Values{
+		"SubjAlias":    s.SubjAlias,
+		"SubjAbout":    s.SubjAbout,
+		"SubjBio":      s.SubjBio,
+		"SubjNotes":    s.SubjNotes,
+		"SubjFavorite": s.SubjFavorite,
+		"SubjHidden":   s.SubjHidden,
+		"SubjPrivate":  s.SubjPrivate,
+		"SubjExcluded": s.SubjExcluded,
+	}); err != nil {
+		return err
+	}
+
+	w.subjects[s.SubjUID] = m.SubjUID
+	w.react(m.SubjUID, doc.Reactions)
+	w.counts.Subjects++
+
+	return nil
+}
+
+// subjUID returns the local uid of an archived subject, or an empty string if it is unknown.
+func (w *LibraryImport) subjUID(uid string) string {
+	if uid == "" {
+		return ""
+	}
+
+	return w.subjects[uid]
+}
+
+// face imports a face cluster.
+func (w *LibraryImport) face(doc LibraryFace) error {
+	if doc.ID == "" {
+		return fmt.Errorf("face id missing")
+	}
+
+	f := doc.Entity()
+	f.SubjUID = w.subjUID(doc.SubjUID)
+
+	if found := entity.FindFace(f.ID); found != nil {
+		if f.SubjUID != "" && found.SubjUID != f.SubjUID {
+			if err := found.SetSubjectUID(f.SubjUID); err != nil {
+				return err
+			}
+		}
+	} else if entity.FirstOrCreateFace(f) == nil {
+		return fmt.Errorf("failed adding face %s", clean.Log(f.ID))
+	}
+
+	w.counts.Faces++
+
+	return nil
+}
+
+// findFile returns the indexed file that matches an archived file.
+func (w *LibraryImport) findFile(f LibraryFile) *entity.File {
+	if f.Hash != "" {
+		if m, err := query.FileByHash(f.Hash); err == nil {
+			return m
+		}
+	}
+
+	if m, err := query.FileByName(f.Root, f.Name); err == nil {
+		return m
+	}
+
+	return nil
+}
+
+// restorePhoto replaces the portable fields of an indexed photo with the archived values.
+func restorePhoto(local, archived *entity.Photo) (*entity.Photo, error) {
+	// Only fields included in YAML sidecar files are considered portable.
+	data, err := yaml.Marshal(archived)
+
+	if err != nil {
+		return local, err
+	}
+
+	m := &entity.Photo{}
+
+	if err = yaml.Unmarshal(data, m); err != nil {
+		return local, err
+	}
+
+	// Keep the local uid, path, and related entities.
+	m.ID = local.ID
+	m.PhotoUID = local.PhotoUID
+	m.PhotoPath = local.PhotoPath
+	m.PhotoName = local.PhotoName
+	m.PlaceID = local.PlaceID
+	m.CellID = local.CellID
+	m.PhotoCountry = local.PhotoCountry
+	m.PhotoResolution = local.PhotoResolution
+	m.PhotoColor = local.PhotoColor
+	m.CameraID = local.CameraID
+	m.CameraSrc = local.CameraSrc
+	m.LensID = local.LensID
+	m.CheckedAt = local.CheckedAt
+	m.EstimatedAt = local.EstimatedAt
+
+	details := local.GetDetails()
+
+	if m.Details == nil {
+		m.Details = &entity.Details{}
+	}
+
+	m.Details.PhotoID = local.ID
+	m.Details.CreatedAt = details.CreatedAt
+
+	return m, nil
+}
+
+// photo imports the metadata, labels, markers, and reactions of an indexed photo.
+func (w *LibraryImport) photo(doc LibraryPhoto) error {
+	if doc.Photo == nil {
+		return fmt.Errorf("photo missing")
+	}
+
+	// Find indexed files.
+	files := make(map[string]*entity.File, len(doc.Files))
+	var local *entity.Photo
+
+	for _, f := range doc.Files {
+		m := w.findFile(f)
+
+		if m == nil {
+			continue
+		} else if local == nil {
+			// The primary file is exported first.
+			if p, err := query.PhotoByID(uint64(m.PhotoID)); err != nil {
+				continue
+			} else {
+				local = &p
+			}
+		}
+
+		if m.PhotoID == local.ID {
+			files[f.UID] = m
+		}
+	}
+
+	if local == nil {
+		log.Debugf("import: %s has not been indexed yet", clean.Log(doc.Photo.PhotoUID))
+		return nil
+	}
+
+	m, err := restorePhoto(local, doc.Photo)
+
+	if err != nil {
+		return err
+	}
+
+	if doc.Owner != "" {
+		m.CreatedBy = w.users[doc.Owner]
+	} else {
+		m.CreatedBy = ""
+	}
+
+	// Update location if the coordinates have changed.
+	if m.PhotoLat != local.PhotoLat || m.PhotoLng != local.PhotoLng {
+		m.UpdateLocation()
+	}
+
+	if err = m.Save(); err != nil {
+		return err
+	}
+
+	w.photos[doc.Photo.PhotoUID] = m.PhotoUID
+
+	// Restore labels.
+	for _, ref := range doc.Labels {
+		label, ok := w.labels[ref.UID]
+
+		if !ok {
+			if label = entity.FirstOrCreateLabel(entity.NewLabel(ref.Name, 0)); label == nil {
+				continue
+			}
+		}
+
+		photoLabel := entity.NewPhotoLabel(m.ID, label.ID, ref.Uncertainty, ref.Src)
+		photoLabel.LabelModel = ref.Model
+
+		if pl := entity.FirstOrCreatePhotoLabel(photoLabel); pl == nil {
+			continue
+		} else if pl.LabelSrc != ref.Src || pl.LabelModel != ref.Model || pl.Uncertainty != ref.Uncertainty {
+			if err = pl.Updates(entity.Values{"LabelSrc": ref.Src, "LabelModel": ref.Model, "Uncertainty": ref.Uncertainty}); err != nil {
+				log.Warnf("import: %s (update label)", err)
+			}
+		}
+	}
+
+	// Restore face and label markers.
+	for _, f := range doc.Files {
+		file, ok := files[f.UID]
+
+		if !ok {
+			continue
+		}
+
+		for _, marker := range f.Markers {
+			if err = w.marker(file.FileUID, marker); err != nil {
+				log.Warnf("import: %s (marker %s)", err, clean.Log(marker.UID))
+			}
+		}
+
+		w.counts.Files++
+	}
+
+	w.react(m.PhotoUID, doc.Reactions)
+	w.counts.Photos++
+
+	return nil
+}
+
+// marker imports a face or label marker of an indexed file.
+func (w *LibraryImport) marker(fileUID string, doc LibraryMarker) (err error) {
+	m := entity.FindMarker(doc.UID)
+
+	// Markers detected when indexing the same file have a different uid, but the same thumb.
+	if m == nil || m.FileUID != fileUID {
+		created := doc.Entity(fileUID)
+		created.MarkerUID = ""
+		created.SubjUID = w.subjUID(doc.SubjUID)
+
+		if m, err = entity.CreateMarkerIfNotExists(created); err != nil {
+			return err
+		}
+	}
+
+	w.counts.Markers++
+
+	return m.Updates(entity.Values{
+		"MarkerName":    doc.Name,
+		"MarkerReview":  doc.Review,
+		"MarkerInvalid": doc.Invalid,
+		"SubjUID":       w.subjUID(doc.SubjUID),
+		"SubjSrc":       doc.SubjSrc,
+	})
+}
+
+// album imports an album with its photos.
+func (w *LibraryImport) album(doc LibraryAlbum) error {
+	if doc.Album == nil || doc.Album.AlbumType == "" {
+		return fmt.Errorf("album type missing")
+	}
+
+	a := entity.FindAlbum(entity.Album{
+		AlbumUID:    doc.Album.AlbumUID,
+		AlbumType:   doc.Album.AlbumType,
+		AlbumSlug:   doc.Album.AlbumSlug,
+		AlbumTitle:  doc.Album.AlbumTitle,
+		AlbumFilter: doc.Album.AlbumFilter,
+	})
+
+	if a == nil {
+		a = doc.Album
+		a.ID = 0
+		a.Photos = nil
+		a.CreatedBy = w.users[doc.Owner]
+
+		if err := a.Create(); err != nil {
+			return err
+		}
+	}
+
+	for _, e := range doc.Photos {
+		photoUID, ok := w.photos[e.UID]
+
+		if !ok {
+			continue
+		}
+
+		entry := entity.PhotoAlbum{
+			AlbumUID:  a.AlbumUID,
+			PhotoUID:  photoUID,
+			Order:     e.Order,
+			Hidden:    e.Hidden,
+			CreatedAt: e.CreatedAt,
+		}
+
+		if err := entry.Save(); err != nil {
+			log.Warnf("import: %s (add to album %s)", err, a)
+		}
+	}
+
+	w.react(a.AlbumUID, doc.Reactions)
+	w.counts.Albums++
+
+	return nil
+}
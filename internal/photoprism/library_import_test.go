@@ -0,0 +1,79 @@
+package photoprism
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/query"
+)
+
+func TestNewLibraryImport(t *testing.T) {
+	w := NewLibraryImport(config.TestConfig())
+	assert.IsType(t, &LibraryImport{}, w)
+}
+
+func TestLibraryImport_Start(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		dir := t.TempDir()
+
+		info, err := NewLibraryExport(Config()).Start(LibraryExportOptions{Path: dir, Encoding: LibraryJson})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// Change the title, which should be restored by the import.
+		photo := entity.PhotoFixtures.Get("Photo04")
+		title := photo.PhotoTitle
+
+		if err = entity.Db().Model(&photo).UpdateColumn("photo_title", "Changed").Error; err != nil {
+			t.Fatal(err)
+		}
+
+		counts, err := NewLibraryImport(Config()).Start(LibraryImportOptions{Path: dir})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, info.Counts.Labels, counts.Labels)
+		assert.Equal(t, info.Counts.Subjects, counts.Subjects)
+		assert.Equal(t, info.Counts.Faces, counts.Faces)
+		assert.Equal(t, info.Counts.Albums, counts.Albums)
+		assert.Greater(t, counts.Photos, 0)
+		assert.Greater(t, counts.Markers, 0)
+
+		result, err := query.PhotoByUID(photo.PhotoUID)
+
+		assert.NoError(t, err)
+		assert.Equal(t, title, result.PhotoTitle)
+	})
+	t.Run("NotFound", func(t *testing.T) {
+		_, err := NewLibraryImport(Config()).Start(LibraryImportOptions{Path: filepath.Join(t.TempDir(), "missing")})
+		assert.Error(t, err)
+	})
+}
+
+func TestRestorePhoto(t *testing.T) {
+	local := entity.PhotoFixtures.Get("Photo04")
+	archived := entity.PhotoFixtures.Get("Photo01")
+
+	result, err := restorePhoto(&local, &archived)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, local.ID, result.ID)
+	assert.Equal(t, local.PhotoUID, result.PhotoUID)
+	assert.Equal(t, local.PhotoPath, result.PhotoPath)
+	assert.Equal(t, local.CellID, result.CellID)
+	assert.Equal(t, local.ID, result.Details.PhotoID)
+	assert.Equal(t, archived.PhotoTitle, result.PhotoTitle)
+	assert.Equal(t, archived.PhotoFavorite, result.PhotoFavorite)
+	assert.Equal(t, archived.PhotoLat, result.PhotoLat)
+}
@@ -0,0 +1,106 @@
+package photoprism
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/pkg/fs"
+)
+
+func TestLibraryEncoding(t *testing.T) {
+	t.Run("Default", func(t *testing.T) {
+		result, err := LibraryEncoding("")
+		assert.NoError(t, err)
+		assert.Equal(t, LibraryYaml, result)
+	})
+	t.Run("Yml", func(t *testing.T) {
+		result, err := LibraryEncoding("YML")
+		assert.NoError(t, err)
+		assert.Equal(t, LibraryYaml, result)
+	})
+	t.Run("Json", func(t *testing.T) {
+		result, err := LibraryEncoding(" json ")
+		assert.NoError(t, err)
+		assert.Equal(t, LibraryJson, result)
+	})
+	t.Run("Unsupported", func(t *testing.T) {
+		_, err := LibraryEncoding("xml")
+		assert.Error(t, err)
+	})
+}
+
+func TestLibraryExt(t *testing.T) {
+	assert.Equal(t, fs.ExtYAML, LibraryExt(LibraryYaml))
+	assert.Equal(t, fs.ExtJSON, LibraryExt(LibraryJson))
+}
+
+func TestLibraryCounts_String(t *testing.T) {
+	c := LibraryCounts{Labels: 1, Subjects: 2, Faces: 3, Photos: 4, Files: 5, Markers: 6, Reactions: 7, Albums: 8}
+	assert.Equal(t, "1 labels, 2 subjects, 3 faces, 4 photos, 5 files, 6 markers, 7 reactions, 8 albums", c.String())
+}
+
+func TestNewLibraryFace(t *testing.T) {
+	m := entity.FaceFixtures.Get("john-doe")
+	f := NewLibraryFace(m)
+
+	assert.Equal(t, m.ID, f.ID)
+	assert.Equal(t, m.SubjUID, f.SubjUID)
+	assert.Equal(t, string(m.EmbeddingJSON), f.Embedding)
+	assert.NotEmpty(t, f.Embedding)
+
+	result := f.Entity()
+
+	assert.Equal(t, m.ID, result.ID)
+	assert.Equal(t, m.FaceSrc, result.FaceSrc)
+	assert.Equal(t, m.EmbeddingJSON, result.EmbeddingJSON)
+	assert.Equal(t, m.Embedding(), result.Embedding())
+}
+
+func TestNewLibraryMarker(t *testing.T) {
+	m := entity.MarkerFixtures.Get("1000003-4")
+	marker := NewLibraryMarker(m)
+
+	assert.Equal(t, m.MarkerUID, marker.UID)
+	assert.Equal(t, m.Thumb, marker.Thumb)
+	assert.Equal(t, string(m.EmbeddingsJSON), marker.Embeddings)
+
+	result := marker.Entity("fs6sg6bqhhinlplk")
+
+	assert.Equal(t, "fs6sg6bqhhinlplk", result.FileUID)
+	assert.Equal(t, m.MarkerUID, result.MarkerUID)
+	assert.Equal(t, m.SubjUID, result.SubjUID)
+	assert.Equal(t, m.X, result.X)
+	assert.Equal(t, m.EmbeddingsJSON, result.EmbeddingsJSON)
+}
+
+func TestReadLibraryInfo(t *testing.T) {
+	t.Run("NotFound", func(t *testing.T) {
+		_, err := ReadLibraryInfo(t.TempDir())
+		assert.Error(t, err)
+	})
+	t.Run("UnknownFormat", func(t *testing.T) {
+		dir := t.TempDir()
+		assert.NoError(t, writeLibraryFile(filepath.Join(dir, "manifest.yml"), LibraryYaml, LibraryInfo{Format: "foo", Version: 1}))
+
+		_, err := ReadLibraryInfo(dir)
+		assert.EqualError(t, err, "unknown archive format foo")
+	})
+	t.Run("UnsupportedVersion", func(t *testing.T) {
+		dir := t.TempDir()
+		assert.NoError(t, writeLibraryFile(filepath.Join(dir, "manifest.json"), LibraryJson, LibraryInfo{Format: LibraryFormat, Version: LibraryVersion + 1}))
+
+		_, err := ReadLibraryInfo(dir)
+		assert.EqualError(t, err, "unsupported archive version 2")
+	})
+	t.Run("Invalid", func(t *testing.T) {
+		dir := t.TempDir()
+		assert.NoError(t, os.WriteFile(filepath.Join(dir, "manifest.json"), []byte("{"), fs.ModeFile))
+
+		_, err := ReadLibraryInfo(dir)
+		assert.Error(t, err)
+	})
+}
@@ -255,6 +255,11 @@ func (w *Moments) Start() (err error) {
 		}
 	}
 
+	// Create moments based on trips away from home.
+	if err := w.UpdateTrips(); err != nil {
+		log.Errorf("moments: %s (update trips)", err.Error())
+	}
+
 	// UpdateFolderDates updates folder year, month and day based on indexed photo metadata.
 	if err := query.UpdateFolderDates(); err != nil {
 		log.Errorf("moments: %s (update folder dates)", err.Error())
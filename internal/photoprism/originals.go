@@ -0,0 +1,331 @@
+package photoprism
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/dustin/go-humanize/english"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/mutex"
+	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/internal/storage"
+	"github.com/photoprism/photoprism/pkg/clean"
+	"github.com/photoprism/photoprism/pkg/fs"
+)
+
+// Originals represents a worker that stages originals stored in a remote bucket,
+// so that they can be indexed and processed like local files.
+//
+// Staging complete files is required because metadata extraction, thumbnail generation, and
+// transcoding rely on external tools such as ExifTool, libvips, and FFmpeg, which can only read
+// local files. Downloads don't need a local copy and are streamed from the bucket with ranged reads.
+type Originals struct {
+	conf    *config.Config
+	storage storage.Storage
+}
+
+// NewOriginals returns a new Originals worker.
+func NewOriginals(conf *config.Config) *Originals {
+	instance := &Originals{
+		conf:    conf,
+		storage: conf.OriginalsStorage(),
+	}
+
+	return instance
+}
+
+// Remote checks if originals are stored in a remote bucket.
+func (w *Originals) Remote() bool {
+	return w.conf.S3Originals()
+}
+
+// Storage returns the storage backend for originals.
+func (w *Originals) Storage() storage.Storage {
+	return w.storage
+}
+
+// Names returns the names of all stored originals in the specified subfolder.
+func (w *Originals) Names(subPath string) (names map[string]bool, err error) {
+	objects, err := w.storage.List(subPath)
+
+	if err != nil {
+		return names, err
+	}
+
+	names = make(map[string]bool, len(objects))
+
+	for _, obj := range objects {
+		names[obj.Name] = true
+	}
+
+	return names, nil
+}
+
+// Fetch stages new and modified originals in the specified subfolder for indexing,
+// along with their related files, and returns the local file names.
+func (w *Originals) Fetch(subPath string, rescan bool) (fetched []string, err error) {
+	if !w.Remote() {
+		return fetched, nil
+	}
+
+	start := time.Now()
+
+	objects, err := w.storage.List(subPath)
+
+	if err != nil {
+		return fetched, fmt.Errorf("%s (list %s)", err, w.storage)
+	}
+
+	indexed, err := query.IndexedFiles()
+
+	if err != nil {
+		return fetched, err
+	}
+
+	stackSequences := w.conf.Settings().StackSequences()
+	modified := make(map[string]bool)
+
+	// Find groups of related files with new or modified objects.
+	for _, obj := range objects {
+		if hidden(obj.Name) {
+			continue
+		}
+
+		modTime, found := indexed[path.Join(entity.RootOriginals, obj.Name)]
+
+		if rescan || !found || modTime != obj.ModTime.Unix() {
+			modified[fs.AbsPrefix(obj.Name, stackSequences)] = true
+		}
+	}
+
+	if len(modified) == 0 {
+		log.Debugf("originals: found no new or modified objects in %s", w.storage)
+		return fetched, nil
+	}
+
+	for _, obj := range objects {
+		if mutex.MainWorker.Canceled() {
+			return fetched, fmt.Errorf("canceled")
+		} else if hidden(obj.Name) || !modified[fs.AbsPrefix(obj.Name, stackSequences)] {
+			continue
+		}
+
+		if fileName, err := w.fetch(obj); err != nil {
+			log.Errorf("originals: %s (fetch %s)", err, clean.Log(obj.Name))
+		} else {
+			fetched = append(fetched, fileName)
+		}
+	}
+
+	log.Infof("originals: fetched %s from %s [%s]", english.Plural(len(fetched), "file", "files"), w.storage, time.Since(start))
+
+	return fetched, nil
+}
+
+// FetchFile stages a single original, e.g. to create thumbnails on demand, and returns its local file name.
+func (w *Originals) FetchFile(name string) (fileName string, err error) {
+	if !w.Remote() {
+		return "", fmt.Errorf("originals are not stored remotely")
+	}
+
+	obj, err := w.storage.Stat(name)
+
+	if err != nil {
+		return "", err
+	}
+
+	return w.fetch(obj)
+}
+
+// StageTime is the time local copies of originals fetched on demand are kept after their last use,
+// so that subsequent requests, e.g. for video segments, don't download them again.
+var StageTime = 5 * time.Minute
+
+// stagedFile counts the requests that use an original fetched on demand.
+type stagedFile struct {
+	mutex sync.Mutex
+	refs  int
+	timer *time.Timer
+}
+
+// staged holds the originals that have been fetched on demand, see Originals.Stage.
+var (
+	staged      = make(map[string]*stagedFile)
+	stagedMutex sync.Mutex
+)
+
+// Staged checks if a local copy of the specified original has been fetched on demand.
+func (w *Originals) Staged(name string) bool {
+	stagedMutex.Lock()
+	defer stagedMutex.Unlock()
+
+	return staged[name] != nil
+}
+
+// Stage fetches a single original on demand, e.g. to create thumbnails, and returns its local file name
+// along with a release function that removes the local copy once it has not been used for StageTime.
+func (w *Originals) Stage(name string) (fileName string, release func(), err error) {
+	stagedMutex.Lock()
+	f := staged[name]
+
+	if f == nil {
+		f = &stagedFile{}
+		staged[name] = f
+	} else if f.timer != nil {
+		f.timer.Stop()
+		f.timer = nil
+	}
+
+	f.refs++
+	stagedMutex.Unlock()
+
+	// Prevent the same original from being downloaded more than once at the same time.
+	f.mutex.Lock()
+	fileName, err = w.FetchFile(name)
+	f.mutex.Unlock()
+
+	release = func() {
+		stagedMutex.Lock()
+		defer stagedMutex.Unlock()
+
+		if f.refs--; f.refs > 0 {
+			return
+		}
+
+		f.timer = time.AfterFunc(StageTime, func() { w.unstage(name, f, fileName) })
+	}
+
+	if err != nil {
+		release()
+		return "", func() {}, err
+	}
+
+	return fileName, release, nil
+}
+
+// unstage removes the local copy of an original fetched on demand unless it is used again.
+func (w *Originals) unstage(name string, f *stagedFile, fileName string) {
+	stagedMutex.Lock()
+	defer stagedMutex.Unlock()
+
+	if f.refs > 0 || staged[name] != f {
+		return
+	}
+
+	if fileName != "" {
+		// Keep the copy while indexing, as the index worker may use it, and try again later.
+		if mutex.MainWorker.Running() {
+			f.timer = time.AfterFunc(StageTime, func() { w.unstage(name, f, fileName) })
+			return
+		}
+
+		if err := os.Remove(fileName); err == nil {
+			log.Debugf("originals: removed local copy of %s", clean.Log(name))
+		} else if !os.IsNotExist(err) {
+			log.Warnf("originals: %s", err)
+			f.timer = time.AfterFunc(StageTime, func() { w.unstage(name, f, fileName) })
+			return
+		}
+	}
+
+	delete(staged, name)
+}
+
+// fetch downloads an object to the local originals folder unless an identical copy exists.
+func (w *Originals) fetch(obj storage.Object) (fileName string, err error) {
+	fileName = filepath.Join(w.conf.OriginalsPath(), filepath.FromSlash(storage.CleanName(obj.Name)))
+
+	if info, err := os.Stat(fileName); err == nil && info.Size() == obj.Size && info.ModTime().Unix() == obj.ModTime.Unix() {
+		return fileName, nil
+	}
+
+	// Read current object headers, as the object may have been modified after listing it.
+	if obj, err = w.storage.Stat(obj.Name); err != nil {
+		return "", err
+	}
+
+	if err = os.MkdirAll(filepath.Dir(fileName), os.ModePerm); err != nil {
+		return "", err
+	}
+
+	r, err := w.storage.Open(obj.Name)
+
+	if err != nil {
+		return "", err
+	}
+
+	defer r.Close()
+
+	// Download to a temporary file first, so that incomplete files are never indexed.
+	tmpName := filepath.Join(filepath.Dir(fileName), "."+filepath.Base(fileName)+".tmp")
+
+	f, err := os.Create(tmpName)
+
+	if err != nil {
+		return "", err
+	}
+
+	if _, err = io.Copy(f, r); err != nil {
+		_ = f.Close()
+		_ = os.Remove(tmpName)
+		return "", err
+	} else if err = f.Close(); err != nil {
+		_ = os.Remove(tmpName)
+		return "", err
+	}
+
+	if !obj.ModTime.IsZero() {
+		if err = os.Chtimes(tmpName, obj.ModTime, obj.ModTime); err != nil {
+			log.Warnf("originals: %s", err)
+		}
+	}
+
+	if err = os.Rename(tmpName, fileName); err != nil {
+		_ = os.Remove(tmpName)
+		return "", err
+	}
+
+	log.Debugf("originals: fetched %s", clean.Log(obj.Name))
+
+	return fileName, nil
+}
+
+// Evict removes staged copies of remote originals from the local originals folder.
+func (w *Originals) Evict(fileNames []string) (evicted int) {
+	if !w.Remote() {
+		return 0
+	}
+
+	for _, fileName := range fileNames {
+		if err := os.Remove(fileName); err == nil {
+			evicted++
+		} else if !os.IsNotExist(err) {
+			log.Warnf("originals: %s", err)
+		}
+	}
+
+	if evicted > 0 {
+		log.Infof("originals: removed %s from local staging cache", english.Plural(evicted, "file", "files"))
+	}
+
+	return evicted
+}
+
+// hidden checks if an object name contains a hidden file or folder name.
+func hidden(name string) bool {
+	for _, s := range strings.Split(name, "/") {
+		if strings.HasPrefix(s, ".") || strings.HasPrefix(s, "@") {
+			return true
+		}
+	}
+
+	return false
+}
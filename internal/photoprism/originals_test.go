@@ -0,0 +1,178 @@
+package photoprism
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/mutex"
+	"github.com/photoprism/photoprism/internal/storage"
+	"github.com/photoprism/photoprism/pkg/fs"
+)
+
+// newTestOriginals returns a worker that uses a local folder as bucket and a temporary staging folder.
+func newTestOriginals(t *testing.T) (w *Originals, bucket *storage.Local) {
+	conf := config.NewConfig(config.CliTestContext())
+	conf.Options().OriginalsPath = t.TempDir()
+	conf.Options().S3Endpoint = "http://localhost:9000"
+	conf.Options().S3Bucket = "photos"
+	conf.Options().S3Originals = true
+
+	bucket = storage.NewLocal(t.TempDir())
+
+	return &Originals{conf: conf, storage: bucket}, bucket
+}
+
+func TestNewOriginals(t *testing.T) {
+	w := NewOriginals(config.TestConfig())
+
+	assert.IsType(t, &Originals{}, w)
+	assert.False(t, w.Remote())
+	assert.IsType(t, &storage.Local{}, w.Storage())
+}
+
+func TestOriginals_Names(t *testing.T) {
+	w, bucket := newTestOriginals(t)
+
+	assert.NoError(t, bucket.Put("2023/cat.jpg", strings.NewReader("cat"), 3))
+	assert.NoError(t, bucket.Put("2023/cat.xmp", strings.NewReader("xmp"), 3))
+
+	names, err := w.Names("")
+
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]bool{"2023/cat.jpg": true, "2023/cat.xmp": true}, names)
+}
+
+func TestOriginals_Fetch(t *testing.T) {
+	w, bucket := newTestOriginals(t)
+
+	assert.NoError(t, bucket.Put("originals-fetch/dog.jpg", strings.NewReader("dog"), 3))
+	assert.NoError(t, bucket.Put("originals-fetch/dog.xmp", strings.NewReader("<xmp/>"), 6))
+	assert.NoError(t, bucket.Put("originals-fetch/.hidden/dog.jpg", strings.NewReader("x"), 1))
+
+	t.Run("Success", func(t *testing.T) {
+		fetched, err := w.Fetch("originals-fetch", false)
+
+		assert.NoError(t, err)
+		assert.Len(t, fetched, 2)
+
+		fileName := filepath.Join(w.conf.OriginalsPath(), "originals-fetch", "dog.jpg")
+		data, err := os.ReadFile(fileName)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "dog", string(data))
+
+		obj, _ := bucket.Stat("originals-fetch/dog.jpg")
+		info, _ := os.Stat(fileName)
+
+		assert.Equal(t, obj.ModTime.Unix(), info.ModTime().Unix())
+		assert.NoFileExists(t, filepath.Join(w.conf.OriginalsPath(), "originals-fetch", ".dog.jpg.tmp"))
+
+		assert.Equal(t, 2, w.Evict(fetched))
+		assert.NoFileExists(t, fileName)
+		assert.Equal(t, 0, w.Evict(fetched))
+	})
+	t.Run("NotRemote", func(t *testing.T) {
+		w.conf.Options().S3Originals = false
+		defer func() { w.conf.Options().S3Originals = true }()
+
+		fetched, err := w.Fetch("originals-fetch", false)
+
+		assert.NoError(t, err)
+		assert.Empty(t, fetched)
+	})
+}
+
+func TestOriginals_FetchFile(t *testing.T) {
+	w, bucket := newTestOriginals(t)
+
+	assert.NoError(t, bucket.Put("2023/bird.jpg", strings.NewReader("bird"), 4))
+
+	t.Run("Success", func(t *testing.T) {
+		fileName, err := w.FetchFile("2023/bird.jpg")
+
+		assert.NoError(t, err)
+		assert.Equal(t, filepath.Join(w.conf.OriginalsPath(), "2023", "bird.jpg"), fileName)
+		assert.FileExists(t, fileName)
+	})
+	t.Run("NotFound", func(t *testing.T) {
+		_, err := w.FetchFile("2023/missing.jpg")
+		assert.Equal(t, storage.ErrNotFound, err)
+	})
+}
+
+func TestOriginals_Stage(t *testing.T) {
+	w, bucket := newTestOriginals(t)
+
+	assert.NoError(t, bucket.Put("2023/fish.jpg", strings.NewReader("fish"), 4))
+
+	stageTime := StageTime
+	StageTime = 10 * time.Millisecond
+	defer func() { StageTime = stageTime }()
+
+	t.Run("Success", func(t *testing.T) {
+		fileName, release, err := w.Stage("2023/fish.jpg")
+
+		assert.NoError(t, err)
+		assert.Equal(t, filepath.Join(w.conf.OriginalsPath(), "2023", "fish.jpg"), fileName)
+		assert.FileExists(t, fileName)
+		assert.True(t, w.Staged("2023/fish.jpg"))
+
+		release()
+		assert.Eventually(t, func() bool { return !fs.FileExists(fileName) }, time.Second, 5*time.Millisecond)
+		assert.False(t, w.Staged("2023/fish.jpg"))
+	})
+	t.Run("InUse", func(t *testing.T) {
+		fileName, release1, err := w.Stage("2023/fish.jpg")
+		assert.NoError(t, err)
+
+		_, release2, err := w.Stage("2023/fish.jpg")
+		assert.NoError(t, err)
+
+		release1()
+		time.Sleep(50 * time.Millisecond)
+		assert.FileExists(t, fileName)
+
+		release2()
+		assert.Eventually(t, func() bool { return !fs.FileExists(fileName) }, time.Second, 5*time.Millisecond)
+	})
+	t.Run("Indexing", func(t *testing.T) {
+		fileName, release, err := w.Stage("2023/fish.jpg")
+		assert.NoError(t, err)
+
+		if err = mutex.MainWorker.Start(); err != nil {
+			t.Fatal(err)
+		}
+
+		release()
+		time.Sleep(50 * time.Millisecond)
+
+		// The copy is kept while indexing and removed afterwards.
+		assert.FileExists(t, fileName)
+		assert.True(t, w.Staged("2023/fish.jpg"))
+
+		mutex.MainWorker.Stop()
+
+		assert.Eventually(t, func() bool { return !fs.FileExists(fileName) }, time.Second, 5*time.Millisecond)
+		assert.Eventually(t, func() bool { return !w.Staged("2023/fish.jpg") }, time.Second, 5*time.Millisecond)
+	})
+	t.Run("NotFound", func(t *testing.T) {
+		_, release, err := w.Stage("2023/missing.jpg")
+
+		assert.Equal(t, storage.ErrNotFound, err)
+		assert.NotNil(t, release)
+		assert.Eventually(t, func() bool { return !w.Staged("2023/missing.jpg") }, time.Second, 5*time.Millisecond)
+	})
+}
+
+func TestHidden(t *testing.T) {
+	assert.False(t, hidden("2023/cat.jpg"))
+	assert.True(t, hidden(".cache/cat.jpg"))
+	assert.True(t, hidden("2023/@eaDir/cat.jpg"))
+	assert.True(t, hidden("2023/.cat.jpg"))
+}
@@ -0,0 +1,25 @@
+package photoprism
+
+import (
+	"fmt"
+
+	"github.com/photoprism/photoprism/internal/thumb"
+	"github.com/photoprism/photoprism/pkg/clean"
+	"github.com/photoprism/photoprism/pkg/phash"
+)
+
+// PerceptualHash returns the perceptual hash of an image based on an existing thumbnail.
+func (m *MediaFile) PerceptualHash(thumbPath string) (hash phash.Hash, err error) {
+	if !m.IsPreviewImage() {
+		return hash, fmt.Errorf("%s is not a jpeg", clean.Log(m.BaseName()))
+	}
+
+	img, err := m.Resample(thumbPath, thumb.Tile224)
+
+	if err != nil {
+		log.Debugf("phash: %s in %s (resample)", err, clean.Log(m.BaseName()))
+		return hash, err
+	}
+
+	return phash.Difference(img), nil
+}
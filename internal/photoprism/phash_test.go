@@ -0,0 +1,71 @@
+package photoprism
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/pkg/phash"
+)
+
+func TestMediaFile_PerceptualHash(t *testing.T) {
+	conf := config.TestConfig()
+
+	t.Run("IMG_4120.JPG", func(t *testing.T) {
+		original, err := NewMediaFile(conf.ExamplesPath() + "/IMG_4120.JPG")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		duplicate, err := NewMediaFile(conf.ExamplesPath() + "/IMG_4120 copy.JPG")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		h1, err := original.PerceptualHash(conf.ThumbCachePath())
+
+		assert.NoError(t, err)
+
+		h2, err := duplicate.PerceptualHash(conf.ThumbCachePath())
+
+		assert.NoError(t, err)
+		assert.Equal(t, 0, h1.Distance(h2))
+		assert.Len(t, h1.Hex(), 16)
+	})
+	t.Run("cat_brown.jpg", func(t *testing.T) {
+		cat, err := NewMediaFile(conf.ExamplesPath() + "/cat_brown.jpg")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		fern, err := NewMediaFile(conf.ExamplesPath() + "/fern_green.jpg")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		h1, err := cat.PerceptualHash(conf.ThumbCachePath())
+
+		assert.NoError(t, err)
+
+		h2, err := fern.PerceptualHash(conf.ThumbCachePath())
+
+		assert.NoError(t, err)
+		assert.Greater(t, h1.Distance(h2), phash.DefaultDistance)
+	})
+	t.Run("Random.docx", func(t *testing.T) {
+		mediaFile, err := NewMediaFile(conf.ExamplesPath() + "/Random.docx")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		_, err = mediaFile.PerceptualHash(conf.ThumbCachePath())
+
+		assert.Error(t, err)
+	})
+}
@@ -43,11 +43,28 @@ func (w *Purge) Start(opt PurgeOptions) (purgedFiles map[string]bool, purgedPhot
 
 	originalsPath := w.conf.OriginalsPath()
 
-	// Check if originals folder is empty.
-	if fs.DirIsEmpty(originalsPath) {
+	// Names of originals stored in a remote bucket, as local copies may have been removed after indexing.
+	var remote map[string]bool
+
+	if w.conf.S3Originals() {
+		if remote, err = NewOriginals(w.conf).Names(opt.Path); err != nil {
+			log.Errorf("purge: %s (list originals)", err)
+			return purgedFiles, purgedPhotos, 0, err
+		}
+	} else if fs.DirIsEmpty(originalsPath) {
+		// Originals folder is empty.
 		return purgedFiles, purgedPhotos, 0, err
 	}
 
+	// exists checks if an indexed file still exists.
+	exists := func(fileRoot, relName, fileName string) bool {
+		if remote != nil && fileRoot == entity.RootOriginals && remote[relName] {
+			return true
+		}
+
+		return fs.FileExists(fileName)
+	}
+
 	var ignore fs.Done
 
 	if opt.Ignore != nil {
@@ -103,7 +120,7 @@ func (w *Purge) Start(opt PurgeOptions) (purgedFiles map[string]bool, purgedPhot
 			}
 
 			if file.FileMissing {
-				if fs.FileExists(fileName) {
+				if exists(file.FileRoot, file.FileName, fileName) {
 					if opt.Dry {
 						log.Infof("purge: found %s", clean.Log(file.FileName))
 						continue
@@ -117,7 +134,7 @@ func (w *Purge) Start(opt PurgeOptions) (purgedFiles map[string]bool, purgedPhot
 						log.Infof("purge: found %s", clean.Log(file.FileName))
 					}
 				}
-			} else if !fs.FileExists(fileName) {
+			} else if !exists(file.FileRoot, file.FileName, fileName) {
 				if opt.Dry {
 					purgedFiles[fileName] = true
 					log.Infof("purge: file %s would be flagged as missing", clean.Log(file.FileName))
@@ -182,7 +199,7 @@ func (w *Purge) Start(opt PurgeOptions) (purgedFiles map[string]bool, purgedPhot
 				continue
 			}
 
-			if !fs.FileExists(fileName) {
+			if !exists(file.FileRoot, file.FileName, fileName) {
 				if opt.Dry {
 					purgedFiles[fileName] = true
 					log.Infof("purge: duplicate %s would be removed from index", clean.Log(file.FileName))
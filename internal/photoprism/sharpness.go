@@ -0,0 +1,61 @@
+package photoprism
+
+import (
+	"fmt"
+	"image"
+
+	"github.com/disintegration/imaging"
+
+	"github.com/photoprism/photoprism/internal/thumb"
+	"github.com/photoprism/photoprism/pkg/clean"
+)
+
+// Sharpness returns a sharpness score of an image based on an existing thumbnail, so that
+// the best picture of a burst can be selected. Higher scores indicate sharper images.
+func (m *MediaFile) Sharpness(thumbPath string) (score float64, err error) {
+	if !m.IsPreviewImage() {
+		return score, fmt.Errorf("%s is not a jpeg", clean.Log(m.BaseName()))
+	}
+
+	img, err := m.Resample(thumbPath, thumb.Fit720)
+
+	if err != nil {
+		log.Debugf("sharpness: %s in %s (resample)", err, clean.Log(m.BaseName()))
+		return score, err
+	}
+
+	return Sharpness(img), nil
+}
+
+// Sharpness returns the variance of the Laplacian of an image in grayscale, which is
+// low for blurry images with few edges and high for sharp images.
+func Sharpness(img image.Image) float64 {
+	gray := imaging.Grayscale(img)
+	b := gray.Bounds()
+	w, h := b.Dx(), b.Dy()
+
+	if w < 3 || h < 3 {
+		return 0
+	}
+
+	// Grayscale images have identical red, green, and blue values.
+	px := func(x, y int) float64 {
+		return float64(gray.Pix[y*gray.Stride+x*4])
+	}
+
+	var sum, sumSq float64
+
+	n := float64((w - 2) * (h - 2))
+
+	for y := 1; y < h-1; y++ {
+		for x := 1; x < w-1; x++ {
+			v := px(x-1, y) + px(x+1, y) + px(x, y-1) + px(x, y+1) - 4*px(x, y)
+			sum += v
+			sumSq += v * v
+		}
+	}
+
+	mean := sum / n
+
+	return sumSq/n - mean*mean
+}
@@ -0,0 +1,66 @@
+package photoprism
+
+import (
+	"image"
+	"image/color"
+	"testing"
+
+	"github.com/disintegration/imaging"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/config"
+)
+
+func TestMediaFile_Sharpness(t *testing.T) {
+	conf := config.TestConfig()
+
+	t.Run("IMG_4120.JPG", func(t *testing.T) {
+		m, err := NewMediaFile(conf.ExamplesPath() + "/IMG_4120.JPG")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		score, err := m.Sharpness(conf.ThumbCachePath())
+
+		assert.NoError(t, err)
+		assert.Greater(t, score, 0.0)
+	})
+	t.Run("NotAnImage", func(t *testing.T) {
+		m, err := NewMediaFile(conf.ExamplesPath() + "/Random.docx")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		_, err = m.Sharpness(conf.ThumbCachePath())
+
+		assert.Error(t, err)
+	})
+}
+
+func TestSharpness(t *testing.T) {
+	t.Run("Blur", func(t *testing.T) {
+		img := imaging.New(64, 64, color.White)
+
+		for y := 0; y < 64; y++ {
+			for x := 0; x < 64; x++ {
+				if (x/8+y/8)%2 == 0 {
+					img.Set(x, y, color.Black)
+				}
+			}
+		}
+
+		sharp := Sharpness(img)
+		blurred := Sharpness(imaging.Blur(img, 2))
+
+		assert.Greater(t, sharp, blurred)
+		assert.Greater(t, blurred, 0.0)
+	})
+	t.Run("Uniform", func(t *testing.T) {
+		assert.Equal(t, 0.0, Sharpness(imaging.New(16, 16, color.White)))
+	})
+	t.Run("TooSmall", func(t *testing.T) {
+		assert.Equal(t, 0.0, Sharpness(image.NewGray(image.Rect(0, 0, 2, 2))))
+	})
+}
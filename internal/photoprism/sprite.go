@@ -0,0 +1,130 @@
+package photoprism
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/photoprism/photoprism/internal/ffmpeg"
+	"github.com/photoprism/photoprism/pkg/clean"
+	"github.com/photoprism/photoprism/pkg/fs"
+)
+
+// SpriteImage is the relative URL of the sprite sheet image in video thumbnail tracks.
+const SpriteImage = "sprite.jpg"
+
+// spriteLocks prevents the sprite sheet of the same video from being created more than once at the same time.
+var spriteLocks sync.Map
+
+// SpriteFileName returns the file name of a video sprite sheet image or thumbnail track in the thumbnail cache,
+// e.g. "a/c/a/acad9168fa6acc5c5c2965ddf6ec465ca42fd831_sprite.vtt".
+func SpriteFileName(hash, thumbPath, ext string) (string, error) {
+	if len(hash) < 4 {
+		return "", fmt.Errorf("sprite: file hash is empty or too short (%s)", clean.Log(hash))
+	} else if thumbPath == "" {
+		return "", errors.New("sprite: folder is empty")
+	}
+
+	dir := path.Join(thumbPath, hash[0:1], hash[1:2], hash[2:3])
+
+	if err := os.MkdirAll(dir, fs.ModeDir); err != nil {
+		return "", err
+	}
+
+	return path.Join(dir, hash+"_sprite"+ext), nil
+}
+
+// CreateSprite creates a sprite sheet image with frames taken at regular intervals and a matching
+// WebVTT thumbnail track for a video, unless they already exist (except force is true).
+func CreateSprite(fileName, hash, thumbPath string, d time.Duration, width, height int, force bool) (err error) {
+	if !Config().FFmpegEnabled() {
+		return fmt.Errorf("sprite: ffmpeg is disabled")
+	}
+
+	s := ffmpeg.NewSprite(d, width, height)
+
+	if s.Empty() {
+		return fmt.Errorf("sprite: %s has no duration", clean.Log(fs.RelName(fileName, Config().OriginalsPath())))
+	}
+
+	imageName, err := SpriteFileName(hash, thumbPath, fs.ExtJPEG)
+
+	if err != nil {
+		return err
+	}
+
+	trackName, err := SpriteFileName(hash, thumbPath, ".vtt")
+
+	if err != nil {
+		return err
+	}
+
+	if !force && fs.FileExists(imageName) && fs.FileExists(trackName) {
+		return nil
+	}
+
+	mu, _ := spriteLocks.LoadOrStore(hash, &sync.Mutex{})
+	mu.(*sync.Mutex).Lock()
+	defer mu.(*sync.Mutex).Unlock()
+	defer spriteLocks.Delete(hash)
+
+	// Another request may have created the sprite sheet in the meantime.
+	if !force && fs.FileExists(imageName) && fs.FileExists(trackName) {
+		return nil
+	}
+
+	// Write to temporary files first and rename them afterwards, so that incomplete
+	// files are never served. Keep the extension so that ffmpeg can detect the codec.
+	tmpImage := strings.TrimSuffix(imageName, fs.ExtJPEG) + ".tmp" + fs.ExtJPEG
+	tmpTrack := trackName + ".tmp"
+
+	cmd, err := ffmpeg.SpriteCommand(fileName, tmpImage, s, Config().FFmpegBin())
+
+	if err != nil {
+		return fmt.Errorf("sprite: %s", err)
+	}
+
+	// Fetch command output.
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	cmd.Env = []string{fmt.Sprintf("HOME=%s", Config().CmdCachePath())}
+
+	// Log exact command for debugging in trace mode.
+	log.Trace(cmd.String())
+
+	start := time.Now()
+
+	if err = cmd.Run(); err != nil {
+		if stderr.String() != "" {
+			log.Debug(stderr.String())
+		}
+
+		_ = os.Remove(tmpImage)
+
+		return fmt.Errorf("sprite: failed creating sprite sheet for %s", clean.Log(fs.RelName(fileName, Config().OriginalsPath())))
+	} else if !fs.FileExistsNotEmpty(tmpImage) {
+		_ = os.Remove(tmpImage)
+		return fmt.Errorf("sprite: sprite sheet for %s is empty", clean.Log(fs.RelName(fileName, Config().OriginalsPath())))
+	} else if err = os.Rename(tmpImage, imageName); err != nil {
+		_ = os.Remove(tmpImage)
+		return fmt.Errorf("sprite: %s", err)
+	}
+
+	// Create thumbnail track after the sprite sheet image, so that it always refers to an existing image.
+	if err = os.WriteFile(tmpTrack, []byte(s.VTT(d, SpriteImage)), fs.ModeFile); err != nil {
+		_ = os.Remove(tmpTrack)
+		return err
+	} else if err = os.Rename(tmpTrack, trackName); err != nil {
+		_ = os.Remove(tmpTrack)
+		return fmt.Errorf("sprite: %s", err)
+	}
+
+	log.Debugf("sprite: created %d frames for %s [%s]", s.Frames, clean.Log(fs.RelName(fileName, Config().OriginalsPath())), time.Since(start))
+
+	return nil
+}
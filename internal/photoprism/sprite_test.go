@@ -0,0 +1,76 @@
+package photoprism
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/pkg/fs"
+)
+
+func TestSpriteFileName(t *testing.T) {
+	thumbPath := t.TempDir()
+
+	t.Run("Success", func(t *testing.T) {
+		result, err := SpriteFileName("acad9168fa6acc5c5c2965ddf6ec465ca42fd831", thumbPath, ".vtt")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, filepath.Join(thumbPath, "a/c/a/acad9168fa6acc5c5c2965ddf6ec465ca42fd831_sprite.vtt"), result)
+	})
+	t.Run("InvalidHash", func(t *testing.T) {
+		_, err := SpriteFileName("ac", thumbPath, ".jpg")
+		assert.Error(t, err)
+	})
+	t.Run("EmptyPath", func(t *testing.T) {
+		_, err := SpriteFileName("acad9168fa6acc5c5c2965ddf6ec465ca42fd831", "", ".jpg")
+		assert.Error(t, err)
+	})
+}
+
+func TestCreateSprite(t *testing.T) {
+	conf := Config()
+	defer fakeFFmpeg(t, conf)()
+
+	thumbPath := t.TempDir()
+	videoName := filepath.Join(conf.ExamplesPath(), "gopher-video.mp4")
+	hash := "ba0a6ee3fc0d8b5ec0c84e2a1b3e3f0c6b6ce9b4"
+
+	t.Run("Success", func(t *testing.T) {
+		if err := CreateSprite(videoName, hash, thumbPath, 5*time.Second, 1280, 720, false); err != nil {
+			t.Fatal(err)
+		}
+
+		imageName, _ := SpriteFileName(hash, thumbPath, fs.ExtJPEG)
+		trackName, _ := SpriteFileName(hash, thumbPath, ".vtt")
+
+		data, err := os.ReadFile(imageName)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Contains(t, string(data), "-skip_frame nokey -i "+videoName)
+		assert.Contains(t, string(data), "tile=3x1")
+		assert.False(t, fs.FileExists(strings.TrimSuffix(imageName, fs.ExtJPEG)+".tmp"+fs.ExtJPEG))
+		assert.False(t, fs.FileExists(trackName+".tmp"))
+
+		data, err = os.ReadFile(trackName)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.True(t, strings.HasPrefix(string(data), "WEBVTT\n"))
+		assert.Contains(t, string(data), "sprite.jpg#xywh=160,0,160,90")
+	})
+	t.Run("NoDuration", func(t *testing.T) {
+		assert.Error(t, CreateSprite(videoName, hash, thumbPath, 0, 1280, 720, true))
+	})
+}
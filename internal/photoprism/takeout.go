@@ -0,0 +1,400 @@
+package photoprism
+
+import (
+	"archive/zip"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/photoprism/photoprism/internal/meta"
+	"github.com/photoprism/photoprism/pkg/fs"
+	"github.com/photoprism/photoprism/pkg/media"
+)
+
+// TakeoutTruncated is the minimum length of sidecar names that may have been truncated by Google Takeout.
+const TakeoutTruncated = 30
+
+// TakeoutSupplemental is the suffix of sidecar file names in newer Google Takeout archives.
+const TakeoutSupplemental = ".supplemental-metadata"
+
+// TakeoutIgnore lists JSON files in Google Takeout archives that do not contain photo metadata.
+var TakeoutIgnore = map[string]bool{
+	"print-subscriptions.json":          true,
+	"shared_album_comments.json":        true,
+	"user-generated-memory-titles.json": true,
+}
+
+// TakeoutArchived lists folder names that contain archived or deleted photos.
+var TakeoutArchived = map[string]bool{
+	"archive": true,
+	"trash":   true,
+	"bin":     true,
+}
+
+// TakeoutRoots lists folder names that are part of the archive structure, not albums.
+var TakeoutRoots = map[string]bool{
+	"takeout":       true,
+	"google photos": true,
+	"google fotos":  true,
+}
+
+// TakeoutEdited lists file name suffixes of edited copies, which share the sidecar file with the original.
+var TakeoutEdited = []string{"-edited", "-bearbeitet", "-modifié", "-editado", "-modificato"}
+
+var takeoutCounterRegexp = regexp.MustCompile(`\s?\((\d+)\)$`)
+var takeoutDateRegexp = regexp.MustCompile(`^(Photos from \d{4}|\d{4}(-\d{2}(-\d{2})?)?( #\d+)?)$`)
+
+// TakeoutAlbum represents an album folder in a Google Takeout archive.
+type TakeoutAlbum struct {
+	Title       string
+	Description string
+	Path        string
+}
+
+// TakeoutFile represents a media file in a Google Takeout archive and the metadata found in its sidecar file.
+type TakeoutFile struct {
+	FileName string
+	Archive  string
+	JsonName string
+	Hash     string
+	Archived bool
+	Albums   []string
+	Meta     meta.GPhoto
+	Data     meta.Data
+}
+
+// Matched tests if a sidecar file was found for the media file.
+func (f *TakeoutFile) Matched() bool {
+	return f.JsonName != ""
+}
+
+// TakeoutIndex represents the media files, sidecar files, and albums found in a Google Takeout archive.
+// File names are relative to the archives if the index was created with NewTakeoutArchiveIndex.
+type TakeoutIndex struct {
+	Root     string
+	Files    []*TakeoutFile
+	Albums   map[string]*TakeoutAlbum
+	Orphaned []string
+}
+
+// takeoutSidecar represents a JSON sidecar file with photo metadata.
+type takeoutSidecar struct {
+	FileName string
+	Key      string
+	Counter  string
+	Meta     meta.GPhoto
+	Data     meta.Data
+	Used     bool
+}
+
+// takeoutEntry represents a file in a Google Takeout folder or zip archive.
+type takeoutEntry struct {
+	FileName string
+	Archive  string
+	Read     func() ([]byte, error)
+}
+
+// NewTakeoutIndex scans the specified Google Takeout folder and matches media files with their sidecar files.
+func NewTakeoutIndex(root string) (*TakeoutIndex, error) {
+	idx := &TakeoutIndex{
+		Root:   root,
+		Albums: make(map[string]*TakeoutAlbum),
+	}
+
+	err := filepath.Walk(root, func(fileName string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		} else if !info.IsDir() {
+			return nil
+		} else if fileName != root && strings.HasPrefix(info.Name(), ".") {
+			return filepath.SkipDir
+		}
+
+		dirEntries, err := os.ReadDir(fileName)
+
+		if err != nil {
+			return err
+		}
+
+		entries := make([]takeoutEntry, 0, len(dirEntries))
+
+		for _, e := range dirEntries {
+			if !e.IsDir() {
+				entryName := filepath.Join(fileName, e.Name())
+				entries = append(entries, takeoutEntry{
+					FileName: entryName,
+					Read:     func() ([]byte, error) { return os.ReadFile(entryName) },
+				})
+			}
+		}
+
+		idx.scan(fileName, entries)
+
+		return nil
+	})
+
+	return idx, err
+}
+
+// NewTakeoutArchiveIndex matches media files with their sidecar files in the specified Google Takeout
+// zip archives without extracting them. Sidecar files may be stored in a different archive than the media file.
+func NewTakeoutArchiveIndex(archives []string) (*TakeoutIndex, error) {
+	idx := &TakeoutIndex{
+		Albums: make(map[string]*TakeoutAlbum),
+	}
+
+	var dirs []string
+	entries := make(map[string][]takeoutEntry)
+
+	for _, zipName := range archives {
+		r, err := zip.OpenReader(zipName)
+
+		if err != nil {
+			return idx, err
+		}
+
+		// Keep archives open until sidecar files have been read.
+		defer r.Close()
+
+		for _, f := range r.File {
+			// Skip folders, directories like __MACOSX, and potentially malicious file names containing "..".
+			if f.FileInfo().IsDir() || strings.HasPrefix(f.Name, "__") || strings.Contains(f.Name, "..") || hidden(f.Name) {
+				continue
+			}
+
+			dir := path.Dir(f.Name)
+
+			if dir == "." {
+				dir = ""
+			}
+
+			if _, ok := entries[dir]; !ok {
+				dirs = append(dirs, dir)
+			}
+
+			zipFile := f
+
+			entries[dir] = append(entries[dir], takeoutEntry{
+				FileName: f.Name,
+				Archive:  zipName,
+				Read: func() ([]byte, error) {
+					rc, err := zipFile.Open()
+
+					if err != nil {
+						return nil, err
+					}
+
+					defer rc.Close()
+
+					return io.ReadAll(rc)
+				},
+			})
+		}
+	}
+
+	sort.Strings(dirs)
+
+	for _, dir := range dirs {
+		idx.scan(dir, entries[dir])
+	}
+
+	return idx, nil
+}
+
+// RelName returns the file name relative to the Google Takeout folder.
+func (idx *TakeoutIndex) RelName(fileName string) string {
+	return fs.RelName(fileName, idx.Root)
+}
+
+// scan adds the media files in a folder to the index.
+func (idx *TakeoutIndex) scan(dir string, entries []takeoutEntry) {
+	var mediaFiles []takeoutEntry
+	var sidecars []*takeoutSidecar
+	var album *TakeoutAlbum
+
+	for _, e := range entries {
+		fileName := e.FileName
+		name := filepath.Base(fileName)
+
+		if strings.HasPrefix(name, ".") {
+			continue
+		}
+
+		if strings.ToLower(filepath.Ext(name)) != fs.ExtJSON {
+			if media.MainFile(fileName) {
+				mediaFiles = append(mediaFiles, e)
+			}
+
+			continue
+		} else if TakeoutIgnore[strings.ToLower(name)] {
+			continue
+		}
+
+		jsonData, err := e.Read()
+
+		if err != nil {
+			log.Warnf("takeout: %s", err)
+			continue
+		}
+
+		p, err := meta.NewGPhoto(jsonData)
+
+		if err != nil {
+			log.Debugf("takeout: %s in %s", err, idx.RelName(fileName))
+			continue
+		}
+
+		// Sidecar files of photos contain the time they were taken, album metadata does not.
+		if !p.TakenAt.Exists() && !p.CreatedAt.Exists() {
+			if a, err := meta.NewGAlbum(jsonData); err == nil && a.Exists() {
+				album = &TakeoutAlbum{Title: a.Title, Description: a.Description}
+			}
+
+			continue
+		}
+
+		key, counter := TakeoutJsonKey(name)
+		sidecar := &takeoutSidecar{FileName: fileName, Key: key, Counter: counter, Meta: p}
+
+		if err = sidecar.Data.GPhoto(jsonData); err != nil {
+			log.Debugf("takeout: %s in %s", err, idx.RelName(fileName))
+		}
+
+		sidecars = append(sidecars, sidecar)
+	}
+
+	if len(mediaFiles) == 0 && len(sidecars) == 0 {
+		return
+	}
+
+	folder := filepath.Base(dir)
+	archived := TakeoutArchived[strings.ToLower(folder)]
+
+	// Folders are albums unless they only group photos by date or are part of the archive structure.
+	if album == nil && dir != idx.Root && !archived && !TakeoutRoots[strings.ToLower(folder)] && !takeoutDateRegexp.MatchString(folder) {
+		album = &TakeoutAlbum{Title: folder}
+	}
+
+	if album != nil {
+		album.Path = dir
+		idx.Albums[dir] = album
+	}
+
+	sort.Slice(mediaFiles, func(i, j int) bool { return mediaFiles[i].FileName < mediaFiles[j].FileName })
+
+	for _, e := range mediaFiles {
+		f := &TakeoutFile{FileName: e.FileName, Archive: e.Archive, Archived: archived}
+
+		if sidecar := TakeoutMatch(filepath.Base(e.FileName), sidecars); sidecar != nil {
+			sidecar.Used = true
+			f.JsonName = sidecar.FileName
+			f.Meta = sidecar.Meta
+			f.Data = sidecar.Data
+		}
+
+		if album != nil {
+			f.Albums = append(f.Albums, dir)
+		}
+
+		idx.Files = append(idx.Files, f)
+	}
+
+	for _, sidecar := range sidecars {
+		if !sidecar.Used {
+			idx.Orphaned = append(idx.Orphaned, sidecar.FileName)
+		}
+	}
+}
+
+// Unmatched returns the media files for which no sidecar file was found.
+func (idx *TakeoutIndex) Unmatched() (fileNames []string) {
+	for _, f := range idx.Files {
+		if !f.Matched() {
+			fileNames = append(fileNames, f.FileName)
+		}
+	}
+
+	return fileNames
+}
+
+// TakeoutJsonKey returns the media file name a sidecar file name refers to and the duplicate counter, if any.
+// For example, "IMG_1234.jpg(1).json" refers to "IMG_1234(1).jpg".
+func TakeoutJsonKey(jsonName string) (key, counter string) {
+	key = filepath.Base(jsonName)
+
+	if strings.EqualFold(filepath.Ext(key), fs.ExtJSON) {
+		key = key[:len(key)-len(fs.ExtJSON)]
+	}
+
+	key, counter = takeoutCounter(key)
+
+	// Remove the supplemental metadata suffix, which may be truncated.
+	if i := strings.LastIndex(key, "."); i > 0 {
+		if suffix := key[i:]; len(suffix) > 1 && strings.HasPrefix(TakeoutSupplemental, suffix) {
+			key = key[:i]
+		}
+	}
+
+	return key, counter
+}
+
+// TakeoutMediaKey returns the original name of a media file without edit suffix and duplicate counter,
+// the same name without file extension, and the duplicate counter, if any.
+func TakeoutMediaKey(fileName string) (base, stem, counter string) {
+	name := filepath.Base(fileName)
+	ext := filepath.Ext(name)
+	stem = strings.TrimSuffix(name, ext)
+
+	stem, counter = takeoutCounter(stem)
+
+	for _, suffix := range TakeoutEdited {
+		if strings.HasSuffix(strings.ToLower(stem), suffix) {
+			stem = stem[:len(stem)-len(suffix)]
+			break
+		}
+	}
+
+	stem, c := takeoutCounter(stem)
+
+	if counter == "" {
+		counter = c
+	}
+
+	return stem + ext, stem, counter
+}
+
+// TakeoutMatch returns the sidecar file that belongs to the media file, or nil if none was found.
+func TakeoutMatch(fileName string, sidecars []*takeoutSidecar) *takeoutSidecar {
+	base, stem, counter := TakeoutMediaKey(fileName)
+
+	// Matching rules, in order of priority.
+	rules := []func(s *takeoutSidecar) bool{
+		func(s *takeoutSidecar) bool { return s.Key == base },
+		func(s *takeoutSidecar) bool { return s.Key == stem },
+		func(s *takeoutSidecar) bool { return s.Meta.Title == base },
+		func(s *takeoutSidecar) bool { return len(s.Key) >= TakeoutTruncated && strings.HasPrefix(base, s.Key) },
+	}
+
+	for _, rule := range rules {
+		for _, s := range sidecars {
+			if s.Counter == counter && rule(s) {
+				return s
+			}
+		}
+	}
+
+	return nil
+}
+
+// takeoutCounter splits a name into the name without duplicate counter and the counter.
+func takeoutCounter(name string) (string, string) {
+	if m := takeoutCounterRegexp.FindStringSubmatch(name); len(m) == 2 {
+		return strings.TrimSuffix(name, m[0]), m[1]
+	}
+
+	return name, ""
+}
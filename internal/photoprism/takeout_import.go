@@ -0,0 +1,354 @@
+package photoprism
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/pkg/clean"
+	"github.com/photoprism/photoprism/pkg/fs"
+)
+
+// TakeoutImport represents a worker that imports Google Photos Takeout archives,
+// including the metadata stored in sidecar files and the album structure.
+type TakeoutImport struct {
+	conf *config.Config
+	imp  *Import
+}
+
+// TakeoutImportOptions represents Google Takeout import options.
+type TakeoutImportOptions struct {
+	UID        string
+	Path       string
+	DestFolder string
+	Move       bool
+}
+
+// TakeoutReport represents the result of a Google Takeout import.
+type TakeoutReport struct {
+	Files     int
+	Matched   int
+	Imported  int
+	Albums    int
+	Unmatched []string
+	Orphaned  []string
+	Failed    []string
+}
+
+// String returns a summary of the import result.
+func (r TakeoutReport) String() string {
+	return fmt.Sprintf("%d files, %d with metadata, %d imported, %d albums, %d unmatched, %d orphaned sidecars, %d failed",
+		r.Files, r.Matched, r.Imported, r.Albums, len(r.Unmatched), len(r.Orphaned), len(r.Failed))
+}
+
+// NewTakeoutImport returns a new TakeoutImport worker.
+func NewTakeoutImport(conf *config.Config, imp *Import) *TakeoutImport {
+	instance := &TakeoutImport{
+		conf: conf,
+		imp:  imp,
+	}
+
+	return instance
+}
+
+// Start imports the Google Takeout folder or zip archives in the specified path.
+func (w *TakeoutImport) Start(opt TakeoutImportOptions) (report TakeoutReport, err error) {
+	if opt.Path == "" {
+		return report, fmt.Errorf("takeout path required")
+	}
+
+	start := time.Now()
+	archives := TakeoutArchives(opt.Path)
+
+	var idx *TakeoutIndex
+
+	// Read zip archives directly, so that they don't need to be extracted all at once.
+	if len(archives) > 0 {
+		idx, err = NewTakeoutArchiveIndex(archives)
+	} else if !fs.PathExists(opt.Path) {
+		return report, fmt.Errorf("%s not found", clean.Log(opt.Path))
+	} else {
+		idx, err = NewTakeoutIndex(opt.Path)
+	}
+
+	if err != nil {
+		return report, err
+	}
+
+	report.Files = len(idx.Files)
+
+	for _, f := range idx.Files {
+		if f.Matched() {
+			report.Matched++
+		}
+	}
+
+	for _, fileName := range idx.Unmatched() {
+		report.Unmatched = append(report.Unmatched, idx.RelName(fileName))
+	}
+
+	for _, fileName := range idx.Orphaned {
+		report.Orphaned = append(report.Orphaned, idx.RelName(fileName))
+	}
+
+	log.Infof("takeout: found %d files, %d with metadata, in %d albums", report.Files, report.Matched, len(idx.Albums))
+
+	// Extracted files can always be moved.
+	if len(archives) > 0 {
+		opt.Move = true
+	}
+
+	importOpt := w.importOptions(opt)
+
+	if len(archives) > 0 {
+		// Extract and import one archive at a time to limit the required storage space.
+		for _, zipName := range archives {
+			if err = w.importArchive(idx, zipName, importOpt); err != nil {
+				return report, err
+			}
+		}
+	} else {
+		// Calculate file hashes before the files are moved, so that they can be found after importing.
+		for _, f := range idx.Files {
+			f.Hash = fs.Hash(f.FileName)
+		}
+
+		importOpt.Path = opt.Path
+		w.imp.Start(importOpt)
+	}
+
+	// Apply metadata and collect the photos that belong to each album.
+	albumPhotos := make(map[string][]string)
+	photoUIDs := make(map[string]bool)
+	var archived []string
+
+	for _, f := range idx.Files {
+		relName := idx.RelName(f.FileName)
+
+		file, err := query.FileByHash(f.Hash)
+
+		if err != nil || file.PhotoUID == "" {
+			report.Failed = append(report.Failed, relName)
+			continue
+		}
+
+		p := entity.FindPhoto(entity.Photo{PhotoUID: file.PhotoUID})
+
+		if p == nil {
+			report.Failed = append(report.Failed, relName)
+			continue
+		}
+
+		if f.Matched() {
+			if err = w.apply(p, file, f); err != nil {
+				log.Errorf("takeout: %s in %s", err, clean.Log(relName))
+			}
+		}
+
+		if !photoUIDs[p.PhotoUID] {
+			photoUIDs[p.PhotoUID] = true
+			report.Imported++
+		}
+
+		for _, dir := range f.Albums {
+			albumPhotos[dir] = append(albumPhotos[dir], p.PhotoUID)
+		}
+
+		if f.Archived || f.Meta.Archived || f.Meta.Trashed {
+			archived = append(archived, p.PhotoUID)
+		}
+	}
+
+	// Recreate albums.
+	for dir, uids := range albumPhotos {
+		a := idx.Albums[dir]
+
+		if a == nil {
+			continue
+		}
+
+		album := entity.NewUserAlbum(a.Title, entity.AlbumManual, importOpt.UID)
+
+		if found := album.Find(); found != nil {
+			album = found
+		} else {
+			album.AlbumDescription = a.Description
+
+			if err = album.Create(); err != nil {
+				log.Errorf("takeout: %s (create album %s)", err, clean.Log(a.Title))
+				continue
+			}
+		}
+
+		album.AddPhotos(uids)
+		report.Albums++
+	}
+
+	// Archive photos last, as this also hides them in albums.
+	for _, uid := range archived {
+		if p := entity.FindPhoto(entity.Photo{PhotoUID: uid}); p != nil && p.DeletedAt == nil {
+			if err = p.Archive(); err != nil {
+				log.Errorf("takeout: %s (archive %s)", err, p.String())
+			}
+		}
+	}
+
+	if err = entity.UpdateCounts(); err != nil {
+		log.Warnf("takeout: %s (update counts)", err)
+	}
+
+	log.Infof("takeout: imported %s [%s]", report, time.Since(start))
+
+	return report, nil
+}
+
+// importOptions returns the options for importing files, without the import path.
+func (w *TakeoutImport) importOptions(opt TakeoutImportOptions) (importOpt ImportOptions) {
+	if opt.Move {
+		importOpt = ImportOptionsMove("", opt.DestFolder)
+	} else {
+		importOpt = ImportOptionsCopy("", opt.DestFolder)
+	}
+
+	if opt.UID != "" {
+		importOpt.UID = opt.UID
+	}
+
+	return importOpt
+}
+
+// importArchive extracts the media files in a zip archive to a temporary folder, imports them,
+// and removes the folder when done.
+func (w *TakeoutImport) importArchive(idx *TakeoutIndex, zipName string, importOpt ImportOptions) error {
+	names := make(map[string]bool)
+
+	for _, f := range idx.Files {
+		if f.Archive == zipName {
+			names[f.FileName] = true
+		}
+	}
+
+	if len(names) == 0 {
+		return nil
+	}
+
+	if err := os.MkdirAll(w.conf.TempPath(), fs.ModeDir); err != nil {
+		return err
+	}
+
+	extractPath, err := os.MkdirTemp(w.conf.TempPath(), "takeout-")
+
+	if err != nil {
+		return err
+	}
+
+	defer os.RemoveAll(extractPath)
+
+	log.Infof("takeout: extracting %s", clean.Log(filepath.Base(zipName)))
+
+	if _, err = fs.UnzipFiles(zipName, extractPath, names); err != nil {
+		return fmt.Errorf("%s (extract %s)", err, clean.Log(filepath.Base(zipName)))
+	}
+
+	// Calculate file hashes before the files are moved, so that they can be found after importing.
+	for _, f := range idx.Files {
+		if f.Archive == zipName {
+			f.Hash = fs.Hash(filepath.Join(extractPath, filepath.FromSlash(f.FileName)))
+		}
+	}
+
+	importOpt.Path = extractPath
+	w.imp.Start(importOpt)
+
+	return nil
+}
+
+// apply updates the photo with the metadata found in the Google Takeout sidecar file.
+func (w *TakeoutImport) apply(p *entity.Photo, file *entity.File, f *TakeoutFile) error {
+	data := f.Data
+	lat, lng := p.PhotoLat, p.PhotoLng
+	details := p.GetDetails()
+	keywords := details.Keywords
+
+	p.SetTakenAt(data.TakenAt, data.TakenAtLocal, data.TimeZone, entity.SrcMeta)
+	p.SetCoordinates(data.Lat, data.Lng, data.Altitude, entity.SrcMeta)
+	p.SetDescription(data.Description, entity.SrcMeta)
+
+	people := f.Meta.PeopleNames()
+
+	if len(people) > 0 {
+		details.SetSubject(strings.Join(people, ", "), entity.SrcMeta)
+		details.SetKeywords(strings.Join(people, ", "), entity.SrcMeta)
+	}
+
+	var err error
+
+	// Update location and keywords if the coordinates or people have changed.
+	if p.PhotoLat != lat || p.PhotoLng != lng || details.Keywords != keywords {
+		err = p.SaveLocation()
+	} else {
+		err = p.Save()
+	}
+
+	if err != nil {
+		return err
+	}
+
+	if f.Meta.Favorited && !p.PhotoFavorite {
+		if err = p.SetFavorite(true); err != nil {
+			return err
+		}
+	}
+
+	// Name the face if exactly one person is tagged and one unnamed face was detected.
+	if len(people) != 1 {
+		return nil
+	}
+
+	var unnamed []*entity.Marker
+
+	markers := *file.Markers()
+
+	for i := range markers {
+		if m := &markers[i]; m.ValidFace() {
+			if m.SubjUID != "" {
+				return nil
+			}
+
+			unnamed = append(unnamed, m)
+		}
+	}
+
+	if len(unnamed) == 1 {
+		if _, err = unnamed[0].SetName(people[0], entity.SrcMeta); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// TakeoutArchives returns the Google Takeout zip archives in the specified path,
+// which may also be the name of a single archive.
+func TakeoutArchives(path string) (archives []string) {
+	if fs.FileExists(path) {
+		if strings.EqualFold(filepath.Ext(path), fs.ExtZip) {
+			return []string{path}
+		}
+
+		return nil
+	}
+
+	matches, err := filepath.Glob(filepath.Join(path, "*"+fs.ExtZip))
+
+	if err != nil {
+		return nil
+	}
+
+	return matches
+}
@@ -0,0 +1,144 @@
+package photoprism
+
+import (
+	"archive/zip"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/classify"
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/face"
+	"github.com/photoprism/photoprism/internal/nsfw"
+	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/pkg/fs"
+)
+
+func TestNewTakeoutImport(t *testing.T) {
+	w := NewTakeoutImport(config.TestConfig(), nil)
+	assert.IsType(t, &TakeoutImport{}, w)
+}
+
+func TestTakeoutReport_String(t *testing.T) {
+	r := TakeoutReport{Files: 5, Matched: 4, Imported: 3, Albums: 2, Unmatched: []string{"a.jpg"}, Failed: []string{"b.jpg"}}
+	assert.Equal(t, "5 files, 4 with metadata, 3 imported, 2 albums, 1 unmatched, 0 orphaned sidecars, 1 failed", r.String())
+}
+
+func TestTakeoutArchives(t *testing.T) {
+	dir := t.TempDir()
+
+	writeTakeoutFile(t, filepath.Join(dir, "takeout-001.zip"), "")
+	writeTakeoutFile(t, filepath.Join(dir, "takeout-002.ZIP"), "")
+	writeTakeoutFile(t, filepath.Join(dir, "notes.txt"), "")
+
+	t.Run("Folder", func(t *testing.T) {
+		assert.Equal(t, []string{filepath.Join(dir, "takeout-001.zip")}, TakeoutArchives(dir))
+	})
+	t.Run("File", func(t *testing.T) {
+		assert.Equal(t, []string{filepath.Join(dir, "takeout-002.ZIP")}, TakeoutArchives(filepath.Join(dir, "takeout-002.ZIP")))
+		assert.Empty(t, TakeoutArchives(filepath.Join(dir, "notes.txt")))
+	})
+}
+
+func TestTakeoutImport_Start(t *testing.T) {
+	conf := config.TestConfig()
+
+	// Image classification is not required to test metadata import.
+	tf := classify.New(conf.AssetsPath(), true)
+	nd := nsfw.New(conf.NSFWModelPath())
+	fn := face.NewNet(conf.FaceNetModelPath(), "", true)
+	convert := NewConvert(conf)
+	ind := NewIndex(conf, tf, nd, fn, convert, NewFiles(), NewPhotos())
+	imp := NewImport(conf, ind, convert)
+
+	t.Run("Zip", func(t *testing.T) {
+		jpeg, err := os.ReadFile(filepath.Join(conf.ExamplesPath(), "beach_wood.jpg"))
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		zipName := filepath.Join(t.TempDir(), "takeout-20220101T000000Z-001.zip")
+		zipFile, err := os.Create(zipName)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		zipWriter := zip.NewWriter(zipFile)
+
+		files := map[string][]byte{
+			"Takeout/Google Photos/Takeout Test Album/beach_wood.jpg":      jpeg,
+			"Takeout/Google Photos/Takeout Test Album/beach_wood.jpg.json": []byte(`{"title": "beach_wood.jpg", "description": "Imported from Takeout", "photoTakenTime": {"timestamp": "1563105600"}, "favorited": true}`),
+			"Takeout/Google Photos/Takeout Test Album/metadata.json":       []byte(`{"title": "Takeout Test Album", "description": "Album description"}`),
+			"Takeout/Google Photos/Takeout Test Album/missing.jpg.json":    []byte(`{"title": "missing.jpg", "photoTakenTime": {"timestamp": "1563105600"}}`),
+		}
+
+		for name, data := range files {
+			w, err := zipWriter.Create(name)
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if _, err = w.Write(data); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		assert.NoError(t, zipWriter.Close())
+		assert.NoError(t, zipFile.Close())
+
+		report, err := NewTakeoutImport(conf, imp).Start(TakeoutImportOptions{Path: zipName})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, 1, report.Files)
+		assert.Equal(t, 1, report.Matched)
+		assert.Equal(t, 1, report.Imported)
+		assert.Equal(t, 1, report.Albums)
+		assert.Empty(t, report.Unmatched)
+		assert.Empty(t, report.Failed)
+		assert.Equal(t, []string{"Takeout/Google Photos/Takeout Test Album/missing.jpg.json"}, report.Orphaned)
+
+		// Extracted files are removed after importing them.
+		extracted, _ := filepath.Glob(filepath.Join(conf.TempPath(), "takeout-*"))
+		assert.Empty(t, extracted)
+
+		file, err := query.FileByHash(fs.Hash(filepath.Join(conf.ExamplesPath(), "beach_wood.jpg")))
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		photo := entity.FindPhoto(entity.Photo{PhotoUID: file.PhotoUID})
+
+		if photo == nil {
+			t.Fatal("photo expected")
+		}
+
+		assert.Equal(t, "Imported from Takeout", photo.PhotoDescription)
+		assert.True(t, photo.PhotoFavorite)
+
+		album := entity.NewUserAlbum("Takeout Test Album", entity.AlbumManual, entity.Admin.UID()).Find()
+
+		if album == nil {
+			t.Fatal("album expected")
+		}
+
+		assert.Equal(t, "Album description", album.AlbumDescription)
+	})
+	t.Run("NotFound", func(t *testing.T) {
+		_, err := NewTakeoutImport(conf, imp).Start(TakeoutImportOptions{Path: filepath.Join(t.TempDir(), "missing")})
+		assert.Error(t, err)
+	})
+	t.Run("NoPath", func(t *testing.T) {
+		_, err := NewTakeoutImport(conf, imp).Start(TakeoutImportOptions{})
+		assert.Error(t, err)
+	})
+}
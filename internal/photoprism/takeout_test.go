@@ -0,0 +1,234 @@
+package photoprism
+
+import (
+	"archive/zip"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/meta"
+	"github.com/photoprism/photoprism/pkg/fs"
+)
+
+// writeTakeoutFile creates a file and its parent folders for testing.
+func writeTakeoutFile(t *testing.T, fileName, data string) {
+	if err := os.MkdirAll(filepath.Dir(fileName), fs.ModeDir); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := os.WriteFile(fileName, []byte(data), fs.ModeFile); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// writeTakeoutZip creates a zip archive with the specified files for testing.
+func writeTakeoutZip(t *testing.T, zipName string, files map[string]string) {
+	f, err := os.Create(zipName)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer f.Close()
+
+	w := zip.NewWriter(f)
+
+	for name, data := range files {
+		fw, err := w.Create(name)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if _, err = fw.Write([]byte(data)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err = w.Close(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestTakeoutJsonKey(t *testing.T) {
+	t.Run("Default", func(t *testing.T) {
+		key, counter := TakeoutJsonKey("IMG_1234.jpg.json")
+		assert.Equal(t, "IMG_1234.jpg", key)
+		assert.Equal(t, "", counter)
+	})
+	t.Run("Counter", func(t *testing.T) {
+		key, counter := TakeoutJsonKey("IMG_1234.jpg(1).json")
+		assert.Equal(t, "IMG_1234.jpg", key)
+		assert.Equal(t, "1", counter)
+	})
+	t.Run("Supplemental", func(t *testing.T) {
+		key, counter := TakeoutJsonKey("IMG_1234.jpg.supplemental-metadata(2).json")
+		assert.Equal(t, "IMG_1234.jpg", key)
+		assert.Equal(t, "2", counter)
+	})
+	t.Run("SupplementalTruncated", func(t *testing.T) {
+		key, _ := TakeoutJsonKey("IMG_1234.jpg.supplemental-met.json")
+		assert.Equal(t, "IMG_1234.jpg", key)
+	})
+	t.Run("Truncated", func(t *testing.T) {
+		key, _ := TakeoutJsonKey("Screenshot_20190504-123456_Instagram_Sto.json")
+		assert.Equal(t, "Screenshot_20190504-123456_Instagram_Sto", key)
+	})
+}
+
+func TestTakeoutMediaKey(t *testing.T) {
+	t.Run("Default", func(t *testing.T) {
+		base, stem, counter := TakeoutMediaKey("/takeout/IMG_1234.jpg")
+		assert.Equal(t, "IMG_1234.jpg", base)
+		assert.Equal(t, "IMG_1234", stem)
+		assert.Equal(t, "", counter)
+	})
+	t.Run("Counter", func(t *testing.T) {
+		base, stem, counter := TakeoutMediaKey("IMG_1234(1).jpg")
+		assert.Equal(t, "IMG_1234.jpg", base)
+		assert.Equal(t, "IMG_1234", stem)
+		assert.Equal(t, "1", counter)
+	})
+	t.Run("Edited", func(t *testing.T) {
+		base, _, counter := TakeoutMediaKey("IMG_1234-edited.jpg")
+		assert.Equal(t, "IMG_1234.jpg", base)
+		assert.Equal(t, "", counter)
+	})
+	t.Run("EditedCounter", func(t *testing.T) {
+		base, _, counter := TakeoutMediaKey("IMG_1234(3)-edited.jpg")
+		assert.Equal(t, "IMG_1234.jpg", base)
+		assert.Equal(t, "3", counter)
+	})
+}
+
+func TestTakeoutMatch(t *testing.T) {
+	sidecars := []*takeoutSidecar{
+		{FileName: "IMG_1234.jpg.json", Key: "IMG_1234.jpg"},
+		{FileName: "IMG_1234.jpg(1).json", Key: "IMG_1234.jpg", Counter: "1"},
+		{FileName: "VID_0001.json", Key: "VID_0001"},
+		{FileName: "Screenshot_20190504-123456_Instagram_Sto.json", Key: "Screenshot_20190504-123456_Instagram_Sto"},
+		{FileName: "renamed.json", Key: "renamed", Meta: meta.GPhoto{Title: "Holiday.jpg"}},
+	}
+
+	t.Run("Exact", func(t *testing.T) {
+		assert.Equal(t, sidecars[0], TakeoutMatch("IMG_1234.jpg", sidecars))
+	})
+	t.Run("Counter", func(t *testing.T) {
+		assert.Equal(t, sidecars[1], TakeoutMatch("IMG_1234(1).jpg", sidecars))
+	})
+	t.Run("Edited", func(t *testing.T) {
+		assert.Equal(t, sidecars[0], TakeoutMatch("IMG_1234-edited.jpg", sidecars))
+	})
+	t.Run("Stem", func(t *testing.T) {
+		assert.Equal(t, sidecars[2], TakeoutMatch("VID_0001.mp4", sidecars))
+	})
+	t.Run("Truncated", func(t *testing.T) {
+		assert.Equal(t, sidecars[3], TakeoutMatch("Screenshot_20190504-123456_Instagram_Story.jpg", sidecars))
+	})
+	t.Run("Title", func(t *testing.T) {
+		assert.Equal(t, sidecars[4], TakeoutMatch("Holiday.jpg", sidecars))
+	})
+	t.Run("NotFound", func(t *testing.T) {
+		assert.Nil(t, TakeoutMatch("IMG_1234(2).jpg", sidecars))
+		assert.Nil(t, TakeoutMatch("IMG_9999.jpg", sidecars))
+	})
+}
+
+func TestNewTakeoutIndex(t *testing.T) {
+	root := t.TempDir()
+	photos := filepath.Join(root, "Takeout", "Google Photos")
+	sidecar := `{"title": "IMG_0001.jpg", "description": "Beach", "photoTakenTime": {"timestamp": "1563105600"}, "favorited": true}`
+
+	writeTakeoutFile(t, filepath.Join(photos, "Photos from 2019", "IMG_0001.jpg"), "a")
+	writeTakeoutFile(t, filepath.Join(photos, "Photos from 2019", "IMG_0001.jpg.json"), sidecar)
+	writeTakeoutFile(t, filepath.Join(photos, "Photos from 2019", "IMG_0002.jpg"), "b")
+	writeTakeoutFile(t, filepath.Join(photos, "Photos from 2019", "IMG_0003.jpg.json"), sidecar)
+	writeTakeoutFile(t, filepath.Join(photos, "Summer Trip", "IMG_0001.jpg"), "a")
+	writeTakeoutFile(t, filepath.Join(photos, "Summer Trip", "IMG_0001.jpg.supplemental-metadata.json"), sidecar)
+	writeTakeoutFile(t, filepath.Join(photos, "Summer Trip", "metadata.json"), `{"title": "Summer 2019", "description": "Sun"}`)
+	writeTakeoutFile(t, filepath.Join(photos, "Bike Tour", "IMG_0004.jpg"), "c")
+	writeTakeoutFile(t, filepath.Join(photos, "Trash", "IMG_0005.jpg"), "d")
+	writeTakeoutFile(t, filepath.Join(photos, "print-subscriptions.json"), `{}`)
+
+	idx, err := NewTakeoutIndex(root)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Len(t, idx.Files, 5)
+	assert.Len(t, idx.Albums, 2)
+	assert.Len(t, idx.Unmatched(), 3)
+	assert.Equal(t, []string{filepath.Join(photos, "Photos from 2019", "IMG_0003.jpg.json")}, idx.Orphaned)
+
+	if a := idx.Albums[filepath.Join(photos, "Summer Trip")]; a == nil {
+		t.Fatal("album expected")
+	} else {
+		assert.Equal(t, "Summer 2019", a.Title)
+		assert.Equal(t, "Sun", a.Description)
+	}
+
+	if a := idx.Albums[filepath.Join(photos, "Bike Tour")]; a == nil {
+		t.Fatal("album expected")
+	} else {
+		assert.Equal(t, "Bike Tour", a.Title)
+	}
+
+	for _, f := range idx.Files {
+		switch filepath.Base(f.FileName) {
+		case "IMG_0001.jpg":
+			assert.True(t, f.Matched())
+			assert.Equal(t, "Beach", f.Data.Description)
+			assert.True(t, f.Meta.Favorited)
+			assert.Equal(t, int64(1563105600), f.Data.TakenAt.Unix())
+		case "IMG_0005.jpg":
+			assert.True(t, f.Archived)
+			assert.Empty(t, f.Albums)
+		}
+	}
+}
+
+func TestNewTakeoutArchiveIndex(t *testing.T) {
+	dir := t.TempDir()
+	sidecar := `{"title": "IMG_0001.jpg", "description": "Beach", "photoTakenTime": {"timestamp": "1563105600"}}`
+
+	// Google Takeout may store sidecar files in a different archive than the media file.
+	writeTakeoutZip(t, filepath.Join(dir, "takeout-001.zip"), map[string]string{
+		"Takeout/Google Photos/Summer Trip/IMG_0001.jpg":      "a",
+		"Takeout/Google Photos/Photos from 2019/IMG_0002.jpg": "b",
+		"Takeout/Google Photos/.hidden/IMG_0003.jpg":          "c",
+	})
+	writeTakeoutZip(t, filepath.Join(dir, "takeout-002.zip"), map[string]string{
+		"Takeout/Google Photos/Summer Trip/IMG_0001.jpg.json": sidecar,
+		"Takeout/Google Photos/Summer Trip/metadata.json":     `{"title": "Summer 2019", "description": "Sun"}`,
+	})
+
+	idx, err := NewTakeoutArchiveIndex(TakeoutArchives(dir))
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, "", idx.Root)
+	assert.Len(t, idx.Files, 2)
+	assert.Empty(t, idx.Orphaned)
+	assert.Equal(t, []string{"Takeout/Google Photos/Photos from 2019/IMG_0002.jpg"}, idx.Unmatched())
+
+	if a := idx.Albums["Takeout/Google Photos/Summer Trip"]; a == nil {
+		t.Fatal("album expected")
+	} else {
+		assert.Equal(t, "Summer 2019", a.Title)
+	}
+
+	for _, f := range idx.Files {
+		assert.Equal(t, filepath.Join(dir, "takeout-001.zip"), f.Archive)
+
+		if f.FileName == "Takeout/Google Photos/Summer Trip/IMG_0001.jpg" {
+			assert.True(t, f.Matched())
+			assert.Equal(t, "Takeout/Google Photos/Summer Trip/IMG_0001.jpg.json", f.JsonName)
+			assert.Equal(t, "Beach", f.Data.Description)
+		}
+	}
+}
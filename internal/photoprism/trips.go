@@ -0,0 +1,578 @@
+package photoprism
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/dustin/go-humanize/english"
+
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/internal/maps"
+	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/pkg/clean"
+	"github.com/photoprism/photoprism/pkg/geo"
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+// TripMaxGap is the maximum time between two pictures taken away from home that belong to the same trip.
+var TripMaxGap = 72 * time.Hour
+
+// TripMinPhotos is the minimum number of pictures taken away from home to create a trip album.
+var TripMinPhotos = 5
+
+// TripMaxStops is the maximum number of places shown in the route summary.
+var TripMaxStops = 8
+
+// Trip represents a contiguous period of time spent away from home.
+type Trip struct {
+	Photos query.TripPhotos
+}
+
+// Trips represents a list of trips.
+type Trips []Trip
+
+// TripDay represents the pictures taken on a single day of a trip.
+type TripDay struct {
+	Day    int
+	Date   time.Time
+	Places []string
+	Photos int
+	Km     float64
+}
+
+// DetectTrips finds contiguous sequences of pictures taken at least km away from home.
+func DetectTrips(photos query.TripPhotos, home geo.Position, km float64) (result Trips) {
+	return detectTrips(photos, home, km, tripHomeCountry(photos, home, km))
+}
+
+// detectTrips finds contiguous sequences of pictures taken at least km away from home,
+// using the country code of home for pictures without coordinates.
+func detectTrips(photos query.TripPhotos, home geo.Position, km float64, homeCountry string) (result Trips) {
+	if len(photos) == 0 || home.Lat == 0 && home.Lng == 0 {
+		return result
+	}
+
+	var current Trip
+	var last time.Time
+
+	done := func() {
+		if len(current.Photos) >= TripMinPhotos {
+			result = append(result, current)
+		}
+
+		current = Trip{}
+	}
+
+	for _, p := range photos {
+		away, ok := tripAway(p, home, km, homeCountry)
+
+		if !ok {
+			// Location too inaccurate to decide.
+			continue
+		} else if !away {
+			done()
+			continue
+		}
+
+		if len(current.Photos) > 0 && p.TakenAt.Sub(last) > TripMaxGap {
+			done()
+		}
+
+		current.Photos = append(current.Photos, p)
+		last = p.TakenAt
+	}
+
+	done()
+
+	return result
+}
+
+// tripAway checks if a picture was taken away from home, ok is false if its location is too inaccurate to decide.
+func tripAway(p query.TripPhoto, home geo.Position, km float64, homeCountry string) (away, ok bool) {
+	if p.HasLatLng() {
+		return home.Km(tripPosition(p)) >= km, true
+	} else if p.HasCountry() && homeCountry != "" {
+		return p.PhotoCountry != homeCountry, true
+	}
+
+	return false, false
+}
+
+// tripHomeCountry returns the country in which most pictures near home were taken.
+func tripHomeCountry(photos query.TripPhotos, home geo.Position, km float64) (country string) {
+	counts := make(map[string]int)
+	max := 0
+
+	for _, p := range photos {
+		if !p.HasLatLng() || !p.HasCountry() || home.Km(tripPosition(p)) >= km {
+			continue
+		}
+
+		counts[p.PhotoCountry]++
+
+		if counts[p.PhotoCountry] > max {
+			max = counts[p.PhotoCountry]
+			country = p.PhotoCountry
+		}
+	}
+
+	return country
+}
+
+// tripPosition returns the geo position of a picture.
+func tripPosition(p query.TripPhoto) geo.Position {
+	return geo.Position{Lat: float64(p.PhotoLat), Lng: float64(p.PhotoLng), Time: p.TakenAt}
+}
+
+// Start returns the time when the first picture was taken.
+func (t Trip) Start() time.Time {
+	if len(t.Photos) == 0 {
+		return time.Time{}
+	}
+
+	return t.Photos[0].TakenAt
+}
+
+// End returns the time when the last picture was taken.
+func (t Trip) End() time.Time {
+	if len(t.Photos) == 0 {
+		return time.Time{}
+	}
+
+	return t.Photos[len(t.Photos)-1].TakenAt
+}
+
+// Slug returns an identifier string based on the local start date.
+func (t Trip) Slug() string {
+	if len(t.Photos) == 0 {
+		return ""
+	}
+
+	return query.TripSlugPrefix + tripDate(t.Photos[0].TakenAtLocal).Format("2006-01-02")
+}
+
+// Filter returns the search filter for the album, covering the exact time from the first to the last picture.
+// The end is one second after the last picture, as capture times are compared with second precision.
+func (t Trip) Filter() string {
+	if len(t.Photos) == 0 {
+		return ""
+	}
+
+	f := form.SearchPhotos{
+		After:  t.Start().UTC().Truncate(time.Second),
+		Before: t.End().UTC().Truncate(time.Second).Add(time.Second),
+		Public: true,
+	}
+
+	return f.Serialize()
+}
+
+// tripDate returns the calendar date of a local time at midnight.
+func tripDate(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// Country returns the country code in which most pictures of the trip were taken.
+func (t Trip) Country() (country string) {
+	counts := make(map[string]int)
+	max := 0
+
+	for _, p := range t.Photos {
+		if !p.HasCountry() {
+			continue
+		}
+
+		counts[p.PhotoCountry]++
+
+		if counts[p.PhotoCountry] > max {
+			max = counts[p.PhotoCountry]
+			country = p.PhotoCountry
+		}
+	}
+
+	return country
+}
+
+// Destinations returns up to n place names, ordered by the number of pictures taken there.
+func (t Trip) Destinations(n int) (result []string) {
+	counts := make(map[string]int)
+
+	for _, p := range t.Photos {
+		if place := p.Place(); place != "" {
+			if counts[place] == 0 {
+				result = append(result, place)
+			}
+
+			counts[place]++
+		}
+	}
+
+	sort.SliceStable(result, func(i, j int) bool {
+		return counts[result[i]] > counts[result[j]]
+	})
+
+	if len(result) > n {
+		return result[:n]
+	}
+
+	return result
+}
+
+// Title returns an english title for the trip album.
+func (t Trip) Title() string {
+	if len(t.Photos) == 0 {
+		return ""
+	}
+
+	name := strings.Join(t.Destinations(2), " & ")
+
+	if name == "" {
+		if country := t.Country(); country != "" {
+			name = maps.CountryName(country)
+		} else {
+			name = "Trip"
+		}
+	}
+
+	return fmt.Sprintf("%s / %s", name, t.Photos[0].TakenAtLocal.Format("January 2006"))
+}
+
+// Stops returns the visited places in chronological order, without consecutive duplicates.
+func (t Trip) Stops() (result []string) {
+	for _, p := range t.Photos {
+		if place := p.Place(); place == "" {
+			continue
+		} else if len(result) == 0 || result[len(result)-1] != place {
+			result = append(result, place)
+		}
+	}
+
+	return result
+}
+
+// Km returns the distance traveled between pictures in km.
+func (t Trip) Km() (km float64) {
+	var prev *geo.Position
+
+	for _, p := range t.Photos {
+		if !p.HasLatLng() {
+			continue
+		}
+
+		pos := tripPosition(p)
+
+		if prev != nil {
+			km += prev.Km(pos)
+		}
+
+		prev = &pos
+	}
+
+	return km
+}
+
+// Days returns the pictures grouped by local calendar day.
+func (t Trip) Days() (result []TripDay) {
+	if len(t.Photos) == 0 {
+		return result
+	}
+
+	first := tripDate(t.Photos[0].TakenAtLocal)
+
+	var prev *geo.Position
+
+	for _, p := range t.Photos {
+		date := tripDate(p.TakenAtLocal)
+
+		if len(result) == 0 || !result[len(result)-1].Date.Equal(date) {
+			result = append(result, TripDay{
+				Day:  int(math.Round(date.Sub(first).Hours()/24)) + 1,
+				Date: date,
+			})
+		}
+
+		day := &result[len(result)-1]
+		day.Photos++
+
+		if place := p.Place(); place != "" && (len(day.Places) == 0 || day.Places[len(day.Places)-1] != place) {
+			day.Places = append(day.Places, place)
+		}
+
+		if p.HasLatLng() {
+			pos := tripPosition(p)
+
+			if prev != nil {
+				day.Km += prev.Km(pos)
+			}
+
+			prev = &pos
+		}
+	}
+
+	return result
+}
+
+// Route returns a summary of the route, e.g. "Munich → Salzburg → Vienna · 4 days · 435 km".
+func (t Trip) Route() string {
+	if len(t.Photos) == 0 {
+		return ""
+	}
+
+	var parts []string
+
+	if stops := t.Stops(); len(stops) > TripMaxStops {
+		parts = append(parts, strings.Join(stops[:TripMaxStops-1], " → ")+" → … → "+stops[len(stops)-1])
+	} else if len(stops) > 0 {
+		parts = append(parts, strings.Join(stops, " → "))
+	}
+
+	days := t.Days()
+	parts = append(parts, english.Plural(days[len(days)-1].Day, "day", "days"))
+
+	if km := int(math.Round(t.Km())); km > 0 {
+		parts = append(parts, fmt.Sprintf("%d km", km))
+	}
+
+	return strings.Join(parts, " · ")
+}
+
+// Description returns a day-by-day itinerary of the trip.
+func (t Trip) Description() string {
+	var lines []string
+
+	for _, day := range t.Days() {
+		line := fmt.Sprintf("Day %d · %s", day.Day, day.Date.Format("Monday, January 2"))
+
+		if len(day.Places) > 0 {
+			line += ": " + strings.Join(day.Places, ", ")
+		}
+
+		details := english.Plural(day.Photos, "picture", "pictures")
+
+		if km := int(math.Round(day.Km)); km > 0 {
+			details += fmt.Sprintf(", %d km", km)
+		}
+
+		lines = append(lines, fmt.Sprintf("%s (%s)", line, details))
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// Apply sets the title, filter, dates, location, and itinerary of an album based on the trip.
+func (t Trip) Apply(a *entity.Album) {
+	start := t.Photos[0].TakenAtLocal
+
+	a.AlbumSlug = t.Slug()
+	a.AlbumFilter = t.Filter()
+	a.AlbumYear = start.Year()
+	a.AlbumMonth = int(start.Month())
+	a.AlbumDay = start.Day()
+	a.AlbumCaption = txt.Clip(t.Route(), txt.ClipShortText)
+	a.AlbumDescription = txt.Clip(t.Description(), txt.ClipText)
+	a.SetTitle(t.Title())
+	a.SetLocation(strings.Join(t.Destinations(3), ", "), "", t.Country())
+}
+
+// tripFilterRange returns the date range of an existing trip album based on its search filter.
+func tripFilterRange(a entity.Album) (after, before time.Time) {
+	f := form.SearchPhotos{}
+
+	if err := form.Unserialize(&f, a.AlbumFilter); err != nil {
+		log.Debugf("moments: %s in filter of %s", err, clean.Log(a.AlbumTitle))
+	}
+
+	return f.After, f.Before
+}
+
+// tripsChecked remembers when and with which settings pictures were last checked for trips,
+// so that subsequent runs only need to check pictures that have been added or changed since.
+var tripsChecked struct {
+	time     time.Time
+	settings string
+	country  string
+}
+
+// UpdateTrips detects trips away from home and creates or updates matching moment albums.
+func (w *Moments) UpdateTrips() (err error) {
+	if !w.conf.DetectTrips() {
+		return nil
+	}
+
+	// Timestamps are stored with second precision, so changes made in the same second are checked again.
+	start := time.Now().UTC().Truncate(time.Second)
+	public := w.conf.Settings().Features.Private
+	home := w.conf.TripsHomePosition()
+	km := float64(w.conf.TripsDistance())
+	settings := fmt.Sprintf("%t %f %f %f", public, home.Lat, home.Lng, km)
+
+	var photos query.TripPhotos
+	var country string
+
+	if tripsChecked.time.IsZero() || tripsChecked.settings != settings {
+		if photos, err = query.TripPhotosByTime(public, time.Time{}, time.Time{}); err != nil {
+			return err
+		}
+
+		country = tripHomeCountry(photos, home, km)
+	} else {
+		country = tripsChecked.country
+
+		if photos, err = changedTripPhotos(public, home, km, country, tripsChecked.time); err != nil {
+			return err
+		}
+	}
+
+	albums, err := query.TripAlbums()
+
+	if err != nil {
+		return err
+	}
+
+	trips := detectTrips(photos, home, km, country)
+
+	log.Debugf("moments: found %s in %s", english.Plural(len(trips), "trip", "trips"), english.Plural(len(photos), "picture", "pictures"))
+
+	matched := make(map[string]bool, len(albums))
+
+	for _, trip := range trips {
+		filter := trip.Filter()
+
+		var found *entity.Album
+
+		// Find an existing album that overlaps with the trip, so that it can be updated.
+		for i := range albums {
+			if matched[albums[i].AlbumUID] {
+				continue
+			}
+
+			if a, b := tripFilterRange(albums[i]); !a.After(trip.End()) && trip.Start().Before(b) {
+				found = &albums[i]
+				break
+			}
+		}
+
+		if found == nil {
+			a := entity.NewMomentsAlbum(trip.Title(), trip.Slug(), filter)
+
+			if a == nil {
+				log.Errorf("moments: failed to create new trip %s (%s)", trip.Title(), filter)
+				continue
+			}
+
+			trip.Apply(a)
+
+			if err = a.Create(); err != nil {
+				log.Errorf("moments: %s", err)
+			} else {
+				log.Infof("moments: added %s (%s)", clean.Log(a.AlbumTitle), a.AlbumFilter)
+			}
+
+			continue
+		}
+
+		matched[found.AlbumUID] = true
+
+		if found.Deleted() {
+			log.Tracef("moments: %s was deleted (%s)", clean.Log(found.AlbumTitle), found.AlbumFilter)
+			continue
+		} else if found.AlbumFilter == filter {
+			log.Tracef("moments: %s already exists (%s)", clean.Log(found.AlbumTitle), found.AlbumFilter)
+			continue
+		}
+
+		trip.Apply(found)
+
+		if err = found.Updates(entity.Values{
+			"album_title":       found.AlbumTitle,
+			"album_slug":        found.AlbumSlug,
+			"album_filter":      found.AlbumFilter,
+			"album_caption":     found.AlbumCaption,
+			"album_description": found.AlbumDescription,
+			"album_location":    found.AlbumLocation,
+			"album_country":     found.AlbumCountry,
+			"album_year":        found.AlbumYear,
+			"album_month":       found.AlbumMonth,
+			"album_day":         found.AlbumDay,
+		}); err != nil {
+			log.Errorf("moments: %s (update)", err)
+		} else {
+			log.Infof("moments: updated %s (%s)", clean.Log(found.AlbumTitle), found.AlbumFilter)
+		}
+	}
+
+	tripsChecked.time = start
+	tripsChecked.settings = settings
+	tripsChecked.country = country
+
+	return nil
+}
+
+// changedTripPhotos returns the pictures that may belong to the same trip as pictures that have been
+// added, changed, or deleted since the specified time.
+func changedTripPhotos(public bool, home geo.Position, km float64, homeCountry string, since time.Time) (photos query.TripPhotos, err error) {
+	first, last, err := query.TripPhotosChanged(since)
+
+	if err != nil || first.IsZero() {
+		return photos, err
+	}
+
+	after, before := first.Add(-TripMaxGap), last.Add(TripMaxGap+time.Second)
+
+	if photos, err = query.TripPhotosByTime(public, after, before); err != nil || len(photos) == 0 {
+		return photos, err
+	}
+
+	// Include earlier pictures as long as the first picture may belong to a trip that started before.
+	for {
+		if away, ok := tripEdge(photos, home, km, homeCountry, false); ok && !away {
+			break
+		} else if from := photos[0].TakenAt.Add(-TripMaxGap); !from.Before(after) {
+			break
+		} else if more, err := query.TripPhotosByTime(public, from, after); err != nil {
+			return photos, err
+		} else if after = from; len(more) == 0 {
+			break
+		} else {
+			photos = append(more, photos...)
+		}
+	}
+
+	// Include later pictures as long as the last picture may belong to a trip that ended after.
+	for {
+		if away, ok := tripEdge(photos, home, km, homeCountry, true); ok && !away {
+			break
+		} else if to := photos[len(photos)-1].TakenAt.Add(TripMaxGap + time.Second); !before.Before(to) {
+			break
+		} else if more, err := query.TripPhotosByTime(public, before, to); err != nil {
+			return photos, err
+		} else if before = to; len(more) == 0 {
+			break
+		} else {
+			photos = append(photos, more...)
+		}
+	}
+
+	return photos, nil
+}
+
+// tripEdge checks if the first or last picture with an accurate location was taken away from home.
+func tripEdge(photos query.TripPhotos, home geo.Position, km float64, homeCountry string, last bool) (away, ok bool) {
+	for i := range photos {
+		p := photos[i]
+
+		if last {
+			p = photos[len(photos)-1-i]
+		}
+
+		if away, ok = tripAway(p, home, km, homeCountry); ok {
+			return away, ok
+		}
+	}
+
+	return false, false
+}
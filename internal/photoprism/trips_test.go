@@ -0,0 +1,183 @@
+package photoprism
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/pkg/geo"
+)
+
+var tripHome = geo.Position{Lat: 52.52, Lng: 13.405}
+
+func tripTestPhoto(uid string, takenAt string, lat, lng float32, country, city string) query.TripPhoto {
+	t, _ := time.Parse("2006-01-02 15:04", takenAt)
+
+	return query.TripPhoto{
+		PhotoUID:     uid,
+		TakenAt:      t.Add(-2 * time.Hour),
+		TakenAtLocal: t,
+		PhotoLat:     lat,
+		PhotoLng:     lng,
+		PhotoCountry: country,
+		PlaceCity:    city,
+	}
+}
+
+func tripTestPhotos() query.TripPhotos {
+	return query.TripPhotos{
+		tripTestPhoto("pt1", "2023-06-01 10:00", 52.51, 13.40, "de", "Berlin"),
+		tripTestPhoto("pt2", "2023-06-01 18:00", 52.53, 13.41, "de", "Berlin"),
+		tripTestPhoto("pt3", "2023-06-05 10:00", 48.137, 11.575, "de", "Munich"),
+		tripTestPhoto("pt4", "2023-06-05 14:00", 48.14, 11.58, "de", "Munich"),
+		tripTestPhoto("pt5", "2023-06-06 09:00", 47.80, 13.04, "at", "Salzburg"),
+		tripTestPhoto("pt6", "2023-06-06 18:00", 0, 0, "at", ""),
+		tripTestPhoto("pt7", "2023-06-07 12:00", 48.20, 16.37, "at", "Vienna"),
+		tripTestPhoto("pt8", "2023-06-07 15:00", 48.21, 16.36, "at", "Vienna"),
+		tripTestPhoto("pt9", "2023-06-08 20:00", 52.52, 13.40, "de", "Berlin"),
+		tripTestPhoto("pt10", "2023-06-20 10:00", 53.55, 9.99, "de", "Hamburg"),
+		tripTestPhoto("pt11", "2023-06-20 11:00", 53.55, 9.99, "de", "Hamburg"),
+		tripTestPhoto("pt12", "2023-06-20 12:00", 53.55, 9.99, "de", "Hamburg"),
+	}
+}
+
+func TestDetectTrips(t *testing.T) {
+	t.Run("Itinerary", func(t *testing.T) {
+		trips := DetectTrips(tripTestPhotos(), tripHome, 100)
+
+		if assert.Len(t, trips, 1) {
+			trip := trips[0]
+
+			assert.Len(t, trip.Photos, 6)
+			assert.Equal(t, "pt3", trip.Photos[0].PhotoUID)
+			assert.Equal(t, "pt8", trip.Photos[5].PhotoUID)
+			assert.Equal(t, "trip-2023-06-05", trip.Slug())
+			assert.Equal(t, "at", trip.Country())
+			assert.Equal(t, "Munich & Vienna / June 2023", trip.Title())
+			assert.Equal(t, []string{"Munich", "Salzburg", "Vienna"}, trip.Stops())
+			assert.Contains(t, trip.Filter(), `after:"2023-06-05 08:00:00 +0000 UTC"`)
+			assert.Contains(t, trip.Filter(), `before:"2023-06-07 13:00:01 +0000 UTC"`)
+			assert.True(t, strings.HasPrefix(trip.Route(), "Munich → Salzburg → Vienna · 3 days · "))
+			assert.InDelta(t, 370, trip.Km(), 10)
+
+			days := trip.Days()
+
+			if assert.Len(t, days, 3) {
+				assert.Equal(t, 1, days[0].Day)
+				assert.Equal(t, 2, days[0].Photos)
+				assert.Equal(t, []string{"Salzburg"}, days[1].Places)
+				assert.Equal(t, 3, days[2].Day)
+			}
+
+			desc := strings.Split(trip.Description(), "\n")
+
+			if assert.Len(t, desc, 3) {
+				assert.Equal(t, "Day 1 · Monday, June 5: Munich (2 pictures)", desc[0])
+				assert.True(t, strings.HasPrefix(desc[2], "Day 3 · Wednesday, June 7: Vienna (2 pictures, "))
+			}
+		}
+	})
+	t.Run("MaxGap", func(t *testing.T) {
+		photos := tripTestPhotos()
+
+		for i := 6; i < len(photos); i++ {
+			photos[i].TakenAt = photos[i].TakenAt.Add(TripMaxGap)
+		}
+
+		assert.Len(t, DetectTrips(photos, tripHome, 100), 0)
+
+		TripMinPhotos = 2
+		defer func() { TripMinPhotos = 5 }()
+
+		assert.Len(t, DetectTrips(photos, tripHome, 100), 3)
+		assert.Len(t, DetectTrips(photos, tripHome, 1000), 0)
+	})
+	t.Run("NoHome", func(t *testing.T) {
+		assert.Len(t, DetectTrips(tripTestPhotos(), geo.Position{}, 100), 0)
+	})
+	t.Run("Empty", func(t *testing.T) {
+		assert.Len(t, DetectTrips(query.TripPhotos{}, tripHome, 100), 0)
+	})
+}
+
+func TestTripAway(t *testing.T) {
+	photos := tripTestPhotos()
+
+	away, ok := tripAway(photos[0], tripHome, 100, "de")
+	assert.True(t, ok)
+	assert.False(t, away)
+
+	away, ok = tripAway(photos[5], tripHome, 100, "de")
+	assert.True(t, ok)
+	assert.True(t, away)
+
+	_, ok = tripAway(photos[5], tripHome, 100, "")
+	assert.False(t, ok)
+}
+
+func TestTripEdge(t *testing.T) {
+	photos := tripTestPhotos()
+
+	away, ok := tripEdge(photos[2:9], tripHome, 100, "de", false)
+	assert.True(t, ok)
+	assert.True(t, away)
+
+	away, ok = tripEdge(photos[2:9], tripHome, 100, "de", true)
+	assert.True(t, ok)
+	assert.False(t, away)
+
+	_, ok = tripEdge(query.TripPhotos{}, tripHome, 100, "de", true)
+	assert.False(t, ok)
+}
+
+func TestTrip_Route(t *testing.T) {
+	t.Run("MaxStops", func(t *testing.T) {
+		var trip Trip
+
+		for i := 0; i < 10; i++ {
+			trip.Photos = append(trip.Photos, tripTestPhoto("pt", "2023-06-05 10:00", 0, 0, "fr", string(rune('A'+i))))
+		}
+
+		assert.Equal(t, "A → B → C → D → E → F → G → … → J · 1 day", trip.Route())
+	})
+	t.Run("Empty", func(t *testing.T) {
+		assert.Equal(t, "", Trip{}.Route())
+		assert.Equal(t, "", Trip{}.Title())
+		assert.Equal(t, "", Trip{}.Filter())
+	})
+}
+
+func TestMoments_UpdateTrips(t *testing.T) {
+	conf := config.TestConfig()
+
+	t.Run("Disabled", func(t *testing.T) {
+		assert.NoError(t, NewMoments(conf).UpdateTrips())
+	})
+	t.Run("Home", func(t *testing.T) {
+		conf.Options().TripsHome = "52.52,13.405"
+
+		defer func() {
+			conf.Options().TripsHome = ""
+			tripsChecked.time = time.Time{}
+
+			if albums, err := query.TripAlbums(); err == nil {
+				for _, a := range albums {
+					_ = a.DeletePermanently()
+				}
+			}
+		}()
+
+		assert.NoError(t, NewMoments(conf).UpdateTrips())
+		assert.False(t, tripsChecked.time.IsZero())
+
+		checked := tripsChecked.time
+
+		// Subsequent runs only check pictures that have been added or changed.
+		assert.NoError(t, NewMoments(conf).UpdateTrips())
+		assert.False(t, tripsChecked.time.Before(checked))
+	})
+}
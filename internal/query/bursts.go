@@ -0,0 +1,171 @@
+package query
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/pkg/phash"
+)
+
+// BurstDistance is the default maximum perceptual hash distance for pictures of the same burst,
+// which is higher than for duplicates to allow for subject movement and exposure differences.
+const BurstDistance = 12
+
+// Burst represents a group of pictures taken in quick succession with the same camera.
+type Burst struct {
+	CameraID     uint         `json:"CameraID"`
+	CameraSerial string       `json:"CameraSerial"`
+	TakenAt      time.Time    `json:"TakenAt"`
+	Seconds      int          `json:"Seconds"`
+	Files        entity.Files `json:"Files"`
+}
+
+// Bursts represents a list of burst groups.
+type Bursts []Burst
+
+// Best returns the best picture.
+func (b Burst) Best() entity.File {
+	if len(b.Files) == 0 {
+		return entity.File{}
+	}
+
+	return b.Files[0]
+}
+
+// Others returns the remaining pictures.
+func (b Burst) Others() entity.Files {
+	if len(b.Files) < 2 {
+		return entity.Files{}
+	}
+
+	return b.Files[1:]
+}
+
+// burstCandidate represents a primary file with the capture time and camera of its photo.
+type burstCandidate struct {
+	FileID       uint
+	FilePHash    string `gorm:"column:file_phash"`
+	TakenAt      time.Time
+	CameraID     uint
+	CameraSerial string
+}
+
+// BurstFiles finds primary files of visually similar pictures that were taken by the same camera
+// within the specified number of seconds, e.g. bursts and bracketed exposures.
+func BurstFiles(seconds, maxDistance int, pathName string) (results Bursts, err error) {
+	if strings.HasPrefix(pathName, "/") {
+		pathName = pathName[1:]
+	}
+
+	results = Bursts{}
+
+	stmt := UnscopedDb().
+		Table("files").
+		Select("files.id AS file_id, files.file_phash, photos.taken_at, photos.camera_id, photos.camera_serial").
+		Joins("JOIN photos ON photos.id = files.photo_id AND photos.deleted_at IS NULL").
+		Where("files.file_primary = ? AND files.file_missing = ? AND files.deleted_at IS NULL", true, false).
+		Where("files.file_phash <> '' AND files.file_phash IS NOT NULL").
+		Where("photos.taken_src = ? AND photos.photo_stack > -1 AND photos.camera_id > 0 AND photos.camera_id <> ?", entity.SrcMeta, entity.UnknownCamera.ID)
+
+	if pathName != "" {
+		stmt = stmt.Where("files.file_name LIKE ?", pathName+"/%")
+	}
+
+	var candidates []burstCandidate
+
+	if err = stmt.Order("photos.camera_id, photos.camera_serial, photos.taken_at, files.id").Scan(&candidates).Error; err != nil {
+		return results, err
+	}
+
+	maxGap := time.Duration(seconds) * time.Second
+
+	// Split candidates into sequences of pictures taken with the same camera in quick succession.
+	for start, end := 0, 1; start < len(candidates); end++ {
+		if end < len(candidates) {
+			prev, next := candidates[end-1], candidates[end]
+
+			if next.CameraID == prev.CameraID && next.CameraSerial == prev.CameraSerial && next.TakenAt.Sub(prev.TakenAt) <= maxGap {
+				continue
+			}
+		}
+
+		if end-start > 1 {
+			bursts, burstErr := similarBursts(candidates[start:end], maxDistance)
+
+			if burstErr != nil {
+				return results, burstErr
+			}
+
+			results = append(results, bursts...)
+		}
+
+		start = end
+	}
+
+	return results, nil
+}
+
+// similarBursts returns visually similar pictures within a sequence as burst groups.
+func similarBursts(sequence []burstCandidate, maxDistance int) (results Bursts, err error) {
+	hashes := make(phash.Hashes, 0, len(sequence))
+	valid := make([]burstCandidate, 0, len(sequence))
+
+	for _, c := range sequence {
+		if h, hashErr := phash.Parse(c.FilePHash); hashErr == nil {
+			hashes = append(hashes, h)
+			valid = append(valid, c)
+		}
+	}
+
+	for _, indexes := range hashes.Groups(maxDistance) {
+		ids := make([]uint, len(indexes))
+
+		for i, index := range indexes {
+			ids[i] = valid[index].FileID
+		}
+
+		first, last := valid[indexes[0]], valid[indexes[0]]
+
+		for _, index := range indexes {
+			if t := valid[index].TakenAt; t.Before(first.TakenAt) {
+				first = valid[index]
+			} else if t.After(last.TakenAt) {
+				last = valid[index]
+			}
+		}
+
+		burst := Burst{
+			CameraID:     first.CameraID,
+			CameraSerial: first.CameraSerial,
+			TakenAt:      first.TakenAt,
+			Seconds:      int(last.TakenAt.Sub(first.TakenAt).Seconds()),
+		}
+
+		if err = Db().Where("id IN (?)", ids).Order("id").Find(&burst.Files).Error; err != nil {
+			return results, err
+		}
+
+		results = append(results, burst)
+	}
+
+	return results, nil
+}
+
+// BurstPhotos returns the primary files of the specified photos as a burst group, in the order of their ids.
+func BurstPhotos(photoUids []string) (result Burst, err error) {
+	if len(photoUids) < 2 {
+		return result, fmt.Errorf("at least two pictures required")
+	}
+
+	if err = Db().
+		Where("photo_uid IN (?) AND file_primary = ? AND file_missing = ?", photoUids, true, false).
+		Order("id").Find(&result.Files).Error; err != nil {
+		return result, err
+	} else if len(result.Files) < 2 {
+		return result, fmt.Errorf("at least two pictures required")
+	}
+
+	return result, nil
+}
@@ -0,0 +1,103 @@
+package query
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/entity"
+)
+
+func TestBurst_Best(t *testing.T) {
+	t.Run("Empty", func(t *testing.T) {
+		b := Burst{}
+
+		assert.Equal(t, entity.File{}, b.Best())
+		assert.Empty(t, b.Others())
+	})
+	t.Run("Files", func(t *testing.T) {
+		b := Burst{Files: entity.Files{{ID: 2}, {ID: 1}, {ID: 3}}}
+
+		assert.Equal(t, uint(2), b.Best().ID)
+		assert.Len(t, b.Others(), 2)
+	})
+}
+
+func TestBurstFiles(t *testing.T) {
+	takenAt := time.Date(2019, 8, 3, 9, 30, 0, 0, time.UTC)
+	offsets := []int{0, 1, 2, 30}
+	hashes := []string{"00ff00ff00ff00ff", "00ff00ff00ff00fe", "ff00ff00ff00ff00", "00ff00ff00ff00fc"}
+	photos := make(entity.Photos, len(hashes))
+
+	for i, h := range hashes {
+		photos[i] = entity.NewPhoto(true)
+		photos[i].PhotoPath = "bursts"
+		photos[i].TakenAt = takenAt.Add(time.Duration(offsets[i]) * time.Second)
+		photos[i].TakenAtLocal = photos[i].TakenAt
+		photos[i].TakenSrc = entity.SrcMeta
+		photos[i].CameraID = entity.CameraFixtures.Pointer("canon-eos-6d").ID
+
+		if err := photos[i].Save(); err != nil {
+			t.Fatal(err)
+		}
+
+		file := &entity.File{
+			PhotoID:     photos[i].ID,
+			PhotoUID:    photos[i].PhotoUID,
+			FileName:    "bursts/" + h + ".jpg",
+			FileRoot:    entity.RootOriginals,
+			FileType:    "jpg",
+			MediaType:   entity.MediaImage,
+			FilePrimary: true,
+			FileWidth:   100,
+			FileHeight:  100,
+			FilePHash:   h,
+		}
+
+		if err := file.Create(); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		bursts, err := BurstFiles(2, BurstDistance, "bursts")
+
+		assert.NoError(t, err)
+
+		if assert.Len(t, bursts, 1) {
+			assert.Len(t, bursts[0].Files, 2)
+			assert.Equal(t, 1, bursts[0].Seconds)
+			assert.Equal(t, takenAt, bursts[0].TakenAt.UTC())
+			assert.Equal(t, photos[0].ID, bursts[0].Files[0].PhotoID)
+			assert.Equal(t, photos[1].ID, bursts[0].Files[1].PhotoID)
+		}
+	})
+	t.Run("LongerInterval", func(t *testing.T) {
+		bursts, err := BurstFiles(60, BurstDistance, "/bursts")
+
+		assert.NoError(t, err)
+
+		if assert.Len(t, bursts, 1) {
+			assert.Len(t, bursts[0].Files, 3)
+			assert.Equal(t, 30, bursts[0].Seconds)
+		}
+	})
+	t.Run("OtherPath", func(t *testing.T) {
+		bursts, err := BurstFiles(2, BurstDistance, "/2790/07")
+
+		assert.NoError(t, err)
+		assert.Empty(t, bursts)
+	})
+	t.Run("BurstPhotos", func(t *testing.T) {
+		result, err := BurstPhotos([]string{photos[0].PhotoUID, photos[2].PhotoUID})
+
+		assert.NoError(t, err)
+		assert.Len(t, result.Files, 2)
+	})
+	t.Run("BurstPhotosInvalid", func(t *testing.T) {
+		_, err := BurstPhotos([]string{photos[0].PhotoUID})
+
+		assert.Error(t, err)
+	})
+}
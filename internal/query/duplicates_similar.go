@@ -0,0 +1,152 @@
+package query
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/pkg/phash"
+)
+
+// Similar represents a group of visually similar pictures with the best copy first.
+type Similar struct {
+	Distance int          `json:"Distance"`
+	Files    entity.Files `json:"Files"`
+}
+
+// SimilarGroups represents a list of similar picture groups.
+type SimilarGroups []Similar
+
+// NewSimilar creates a new group of similar files with the best copy first, i.e. the one with the highest
+// resolution, the largest file size, or the lowest id.
+func NewSimilar(files entity.Files) Similar {
+	sort.SliceStable(files, func(i, j int) bool {
+		a, b := files[i], files[j]
+
+		if pa, pb := a.FileWidth*a.FileHeight, b.FileWidth*b.FileHeight; pa != pb {
+			return pa > pb
+		} else if a.FileSize != b.FileSize {
+			return a.FileSize > b.FileSize
+		}
+
+		return a.ID < b.ID
+	})
+
+	result := Similar{Files: files}
+
+	if len(files) == 0 {
+		return result
+	}
+
+	best, err := phash.Parse(files[0].FilePHash)
+
+	if err != nil {
+		return result
+	}
+
+	for _, f := range files[1:] {
+		if h, err := phash.Parse(f.FilePHash); err != nil {
+			continue
+		} else if d := best.Distance(h); d > result.Distance {
+			result.Distance = d
+		}
+	}
+
+	return result
+}
+
+// Best returns the best copy.
+func (s Similar) Best() entity.File {
+	if len(s.Files) == 0 {
+		return entity.File{}
+	}
+
+	return s.Files[0]
+}
+
+// Copies returns the lesser copies.
+func (s Similar) Copies() entity.Files {
+	if len(s.Files) < 2 {
+		return entity.Files{}
+	}
+
+	return s.Files[1:]
+}
+
+// SimilarFiles finds primary files of visually similar pictures based on their perceptual hash,
+// with maxDistance as the maximum number of different bits.
+func SimilarFiles(maxDistance int, pathName string) (groups SimilarGroups, err error) {
+	if strings.HasPrefix(pathName, "/") {
+		pathName = pathName[1:]
+	}
+
+	stmt := UnscopedDb().
+		Table("files").Select("files.*").
+		Joins("JOIN photos ON photos.id = files.photo_id AND photos.deleted_at IS NULL").
+		Where("files.file_primary = ? AND files.file_missing = ? AND files.deleted_at IS NULL", true, false).
+		Where("files.file_phash <> '' AND files.file_phash IS NOT NULL")
+
+	if pathName != "" {
+		stmt = stmt.Where("files.file_name LIKE ?", pathName+"/%")
+	}
+
+	var files entity.Files
+
+	groups = SimilarGroups{}
+
+	if err = stmt.Order("files.id").Find(&files).Error; err != nil {
+		return groups, err
+	}
+
+	hashes := make(phash.Hashes, 0, len(files))
+	valid := make(entity.Files, 0, len(files))
+
+	for _, f := range files {
+		if h, hashErr := phash.Parse(f.FilePHash); hashErr == nil {
+			hashes = append(hashes, h)
+			valid = append(valid, f)
+		}
+	}
+
+	for _, indexes := range hashes.Groups(maxDistance) {
+		group := make(entity.Files, len(indexes))
+
+		for i, index := range indexes {
+			group[i] = valid[index]
+		}
+
+		groups = append(groups, NewSimilar(group))
+	}
+
+	return groups, nil
+}
+
+// SimilarPhotos returns the primary files of the specified photos as a group with the best copy first.
+func SimilarPhotos(photoUids []string) (result Similar, err error) {
+	if len(photoUids) < 2 {
+		return result, fmt.Errorf("at least two pictures required")
+	}
+
+	var files entity.Files
+
+	if err = Db().
+		Where("photo_uid IN (?) AND file_primary = ? AND file_missing = ?", photoUids, true, false).
+		Order("id").Find(&files).Error; err != nil {
+		return result, err
+	} else if len(files) < 2 {
+		return result, fmt.Errorf("at least two pictures required")
+	}
+
+	return NewSimilar(files), nil
+}
+
+// FilesWithoutPHash returns primary image files without perceptual hash, starting after the specified id.
+func FilesWithoutPHash(limit int, afterId uint) (files entity.Files, err error) {
+	err = Db().
+		Where("id > ? AND file_primary = ? AND file_missing = ? AND media_type = ?", afterId, true, false, entity.MediaImage).
+		Where("file_phash = '' OR file_phash IS NULL").
+		Order("id").Limit(limit).Find(&files).Error
+
+	return files, err
+}
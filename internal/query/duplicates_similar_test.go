@@ -0,0 +1,107 @@
+package query
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/pkg/phash"
+)
+
+func TestNewSimilar(t *testing.T) {
+	t.Run("Empty", func(t *testing.T) {
+		result := NewSimilar(entity.Files{})
+
+		assert.Equal(t, 0, result.Distance)
+		assert.Equal(t, entity.File{}, result.Best())
+		assert.Empty(t, result.Copies())
+	})
+	t.Run("Best", func(t *testing.T) {
+		result := NewSimilar(entity.Files{
+			{ID: 1, FileWidth: 800, FileHeight: 600, FileSize: 1000, FilePHash: "f0f0f0f0f0f0f0f1"},
+			{ID: 2, FileWidth: 1600, FileHeight: 1200, FileSize: 500, FilePHash: "f0f0f0f0f0f0f0f0"},
+			{ID: 3, FileWidth: 800, FileHeight: 600, FileSize: 2000, FilePHash: "f0f0f0f0f0f0f0f3"},
+		})
+
+		assert.Equal(t, uint(2), result.Best().ID)
+		assert.Len(t, result.Copies(), 2)
+		assert.Equal(t, uint(3), result.Copies()[0].ID)
+		assert.Equal(t, uint(1), result.Copies()[1].ID)
+		assert.Equal(t, 2, result.Distance)
+	})
+}
+
+func TestSimilarFiles(t *testing.T) {
+	hashes := []string{"0123456789abcdef", "0123456789abcdee", "fedcba9876543210"}
+	photos := make(entity.Photos, len(hashes))
+
+	for i, h := range hashes {
+		photos[i] = entity.NewPhoto(false)
+		photos[i].PhotoPath = "similar"
+
+		if err := photos[i].Save(); err != nil {
+			t.Fatal(err)
+		}
+
+		file := &entity.File{
+			PhotoID:     photos[i].ID,
+			PhotoUID:    photos[i].PhotoUID,
+			FileName:    "similar/" + h + ".jpg",
+			FileRoot:    entity.RootOriginals,
+			FileType:    "jpg",
+			MediaType:   entity.MediaImage,
+			FilePrimary: true,
+			FileWidth:   100 * (i + 1),
+			FileHeight:  100,
+			FilePHash:   h,
+		}
+
+		if err := file.Create(); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		groups, err := SimilarFiles(phash.DefaultDistance, "similar")
+
+		assert.NoError(t, err)
+
+		if assert.Len(t, groups, 1) {
+			assert.Equal(t, 1, groups[0].Distance)
+			assert.Len(t, groups[0].Files, 2)
+			assert.Equal(t, photos[1].ID, groups[0].Best().PhotoID)
+			assert.Equal(t, photos[0].ID, groups[0].Copies()[0].PhotoID)
+		}
+	})
+	t.Run("OtherPath", func(t *testing.T) {
+		groups, err := SimilarFiles(phash.DefaultDistance, "/2790/07")
+
+		assert.NoError(t, err)
+		assert.Empty(t, groups)
+	})
+	t.Run("SimilarPhotos", func(t *testing.T) {
+		result, err := SimilarPhotos([]string{photos[0].PhotoUID, photos[2].PhotoUID})
+
+		assert.NoError(t, err)
+		assert.Len(t, result.Files, 2)
+		assert.Equal(t, photos[2].ID, result.Best().PhotoID)
+	})
+	t.Run("SimilarPhotosInvalid", func(t *testing.T) {
+		_, err := SimilarPhotos([]string{photos[0].PhotoUID})
+
+		assert.Error(t, err)
+	})
+}
+
+func TestFilesWithoutPHash(t *testing.T) {
+	files, err := FilesWithoutPHash(10, 0)
+
+	assert.NoError(t, err)
+	assert.NotEmpty(t, files)
+
+	for _, f := range files {
+		assert.Empty(t, f.FilePHash)
+		assert.True(t, f.FilePrimary)
+	}
+}
@@ -101,7 +101,7 @@ func SelectedFiles(f form.Selection, o FileSelection) (results entity.Files, err
 	switch DbDialect() {
 	case MySQL:
 		concat = "CONCAT(a.path, '/%')"
-	case SQLite3:
+	case SQLite3, Postgres:
 		concat = "a.path || '/%'"
 	default:
 		return results, fmt.Errorf("unknown sql dialect: %s", DbDialect())
@@ -114,7 +114,7 @@ func SelectedFiles(f form.Selection, o FileSelection) (results entity.Files, err
 		OR photos.photo_path IN (
 			SELECT a.path FROM folders a WHERE a.folder_uid IN (?) UNION
 			SELECT b.path FROM folders a JOIN folders b ON b.path LIKE %s WHERE a.folder_uid IN (?))
-		OR photos.photo_uid IN (SELECT photo_uid FROM photos_albums WHERE hidden = 0 AND album_uid IN (?))
+		OR photos.photo_uid IN (SELECT photo_uid FROM photos_albums WHERE hidden = ? AND album_uid IN (?))
 		OR files.file_uid IN (SELECT file_uid FROM %s m WHERE m.subj_uid IN (?))
 		OR photos.id IN (SELECT pl.photo_id FROM photos_labels pl JOIN labels l ON pl.label_id = l.id AND pl.uncertainty < 100 AND l.deleted_at IS NULL WHERE l.label_uid IN (?))
 		OR photos.id IN (SELECT pl.photo_id FROM photos_labels pl JOIN categories c ON c.label_id = pl.label_id AND pl.uncertainty < 100 JOIN labels lc ON lc.id = c.category_id AND lc.deleted_at IS NULL WHERE lc.label_uid IN (?))`,
@@ -124,8 +124,8 @@ func SelectedFiles(f form.Selection, o FileSelection) (results entity.Files, err
 	s := UnscopedDb().Table("files").
 		Select("files.*").
 		Joins("JOIN photos ON photos.id = files.photo_id").
-		Where("files.file_missing = 0 AND files.file_name <> '' AND files.file_hash <> ''").
-		Where(where, f.Photos, f.Places, f.Files, f.Files, f.Files, f.Albums, f.Subjects, f.Labels, f.Labels).
+		Where("files.file_missing = ? AND files.file_name <> '' AND files.file_hash <> ''", false).
+		Where(where, f.Photos, f.Places, f.Files, f.Files, f.Files, false, f.Albums, f.Subjects, f.Labels, f.Labels).
 		Group("files.id")
 
 	// File size limit?
@@ -155,7 +155,7 @@ func SelectedFiles(f form.Selection, o FileSelection) (results entity.Files, err
 
 	// Previews files only?
 	if o.Primary {
-		s = s.Where("files.file_primary = 1")
+		s = s.Where("files.file_primary = ?", true)
 	}
 
 	// Files in originals only?
@@ -165,7 +165,7 @@ func SelectedFiles(f form.Selection, o FileSelection) (results entity.Files, err
 
 	// Exclude private?
 	if !o.Private {
-		s = s.Where("photos.photo_private <> 1")
+		s = s.Where("photos.photo_private <> ?", true)
 	}
 
 	// Exclude hidden photos?
@@ -30,3 +30,31 @@ func FileSyncs(accountId uint, status string, limit int) (result []entity.FileSy
 
 	return result, nil
 }
+
+// FileSyncByName finds a FileSync entity by account and remote file name.
+func FileSyncByName(accountId uint, remoteName string) (result entity.FileSync, err error) {
+	err = Db().Where("service_id = ? AND remote_name = ?", accountId, remoteName).Preload("File").First(&result).Error
+
+	return result, err
+}
+
+// ModifiedFileSyncs returns uploaded files that have been modified locally since the last sync.
+func ModifiedFileSyncs(accountId uint, limit int) (result []entity.FileSync, err error) {
+	s := Db().Joins("JOIN files ON files.id = files_sync.file_id").
+		Where("files_sync.service_id = ? AND files_sync.status IN (?)", accountId, []string{entity.FileSyncUploaded, entity.FileSyncConflict}).
+		Where("files_sync.local_hash <> '' AND files_sync.conflict_name = ''").
+		Where("files.file_missing = ? AND files.file_hash <> files_sync.local_hash", false).
+		Order("files_sync.remote_name ASC")
+
+	if limit > 0 {
+		s = s.Limit(limit).Offset(0)
+	}
+
+	s = s.Preload("File")
+
+	if err := s.Find(&result).Error; err != nil {
+		return result, err
+	}
+
+	return result, nil
+}
@@ -31,3 +31,57 @@ func TestFileSyncs(t *testing.T) {
 		}
 	})
 }
+
+func TestModifiedFileSyncs(t *testing.T) {
+	file := entity.FileFixtures.Get("exampleFileName.jpg")
+
+	modified := entity.NewFileSync(1000003, "/modified.jpg")
+	modified.FileID = file.ID
+	modified.Status = entity.FileSyncUploaded
+	modified.LocalHash = "0000000000000000000000000000000000000000"
+
+	unchanged := entity.NewFileSync(1000003, "/unchanged.jpg")
+	unchanged.FileID = file.ID
+	unchanged.Status = entity.FileSyncUploaded
+	unchanged.LocalHash = file.FileHash
+
+	resolved := entity.NewFileSync(1000003, "/resolved.jpg")
+	resolved.FileID = file.ID
+	resolved.Status = entity.FileSyncConflict
+	resolved.LocalHash = "0000000000000000000000000000000000000000"
+	resolved.ConflictName = "resolved.sync-conflict-20230102-150405.jpg"
+
+	for _, m := range []*entity.FileSync{modified, unchanged, resolved} {
+		if err := m.Save(); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	defer entity.Db().Where("service_id = ?", 1000003).Delete(entity.FileSync{})
+
+	r, err := ModifiedFileSyncs(1000003, 10)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if assert.Len(t, r, 1) {
+		assert.Equal(t, "/modified.jpg", r[0].RemoteName)
+		assert.NotNil(t, r[0].File)
+	}
+
+	t.Run("FileSyncByName", func(t *testing.T) {
+		m, err := FileSyncByName(1000003, "/resolved.jpg")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, entity.FileSyncConflict, m.Status)
+		assert.Equal(t, "resolved.sync-conflict-20230102-150405.jpg", m.ConflictName)
+
+		_, err = FileSyncByName(1000003, "/missing.jpg")
+
+		assert.Error(t, err)
+	})
+}
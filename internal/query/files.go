@@ -19,11 +19,11 @@ func FilesByPath(limit, offset int, rootName, pathName string, public bool) (fil
 	stmt := Db().
 		Table("files").Select("files.*").
 		Joins("JOIN photos ON photos.id = files.photo_id AND photos.deleted_at IS NULL").
-		Where("files.file_missing = 0 AND files.file_root = ?", rootName).
+		Where("files.file_missing = ? AND files.file_root = ?", false, rootName).
 		Where("photos.photo_path = ?", pathName)
 
 	if public {
-		stmt = stmt.Where("photos.photo_private = 0")
+		stmt = stmt.Where("photos.photo_private = ?", false)
 	}
 
 	err = stmt.Order("files.file_name").
@@ -42,7 +42,7 @@ func Files(limit, offset int, pathName string, includeMissing bool) (files entit
 	stmt := Db()
 
 	if !includeMissing {
-		stmt = stmt.Where("file_missing = 0")
+		stmt = stmt.Where("file_missing = ?", false)
 	}
 
 	if pathName != "" {
@@ -56,7 +56,7 @@ func Files(limit, offset int, pathName string, includeMissing bool) (files entit
 
 // FilesByUID finds files for the given UIDs.
 func FilesByUID(u []string, limit int, offset int) (files entity.Files, err error) {
-	if err := Db().Where("(photo_uid IN (?) AND file_primary = 1) OR file_uid IN (?)", u, u).Preload("Photo").Limit(limit).Offset(offset).Find(&files).Error; err != nil {
+	if err := Db().Where("(photo_uid IN (?) AND file_primary = ?) OR file_uid IN (?)", u, true, u).Preload("Photo").Limit(limit).Offset(offset).Find(&files).Error; err != nil {
 		return files, err
 	}
 
@@ -71,7 +71,7 @@ func FileByPhotoUID(photoUID string) (*entity.File, error) {
 		return &f, fmt.Errorf("photo uid required")
 	}
 
-	err := Db().Where("photo_uid = ? AND file_primary = 1", photoUID).Preload("Photo").First(&f).Error
+	err := Db().Where("photo_uid = ? AND file_primary = ?", photoUID, true).Preload("Photo").First(&f).Error
 
 	return &f, err
 }
@@ -84,14 +84,27 @@ func VideoByPhotoUID(photoUID string) (*entity.File, error) {
 		return &f, fmt.Errorf("photo uid required")
 	}
 
-	err := Db().Where("photo_uid = ? AND file_missing = 0", photoUID).
-		Where("file_video = 1 OR file_duration > 0 OR file_frames > 0 OR file_type = ?", fs.ImageGIF).
+	err := Db().Where("photo_uid = ? AND file_missing = ?", photoUID, false).
+		Where("file_video = ? OR file_duration > 0 OR file_frames > 0 OR file_type = ?", true, fs.ImageGIF).
 		Order("file_error ASC, file_video DESC, file_duration DESC, file_frames DESC").
 		Preload("Photo").First(&f).Error
 
 	return &f, err
 }
 
+// FileByID finds a file entity for the given id.
+func FileByID(id uint) (*entity.File, error) {
+	f := entity.File{}
+
+	if id == 0 {
+		return &f, fmt.Errorf("file id required")
+	}
+
+	err := Db().Where("id = ?", id).First(&f).Error
+
+	return &f, err
+}
+
 // FileByUID finds a file entity for the given UID.
 func FileByUID(fileUID string) (*entity.File, error) {
 	f := entity.File{}
@@ -118,13 +131,26 @@ func FileByHash(fileHash string) (*entity.File, error) {
 	return &f, err
 }
 
+// FileByName finds a file with the given root and name.
+func FileByName(fileRoot, fileName string) (*entity.File, error) {
+	f := entity.File{}
+
+	if fileRoot == "" || fileName == "" {
+		return &f, fmt.Errorf("file root and name required")
+	}
+
+	err := Db().Where("file_root = ? AND file_name = ?", fileRoot, fileName).Preload("Photo").First(&f).Error
+
+	return &f, err
+}
+
 // RenameFile renames an indexed file.
 func RenameFile(srcRoot, srcName, destRoot, destName string) error {
 	if srcRoot == "" || srcName == "" || destRoot == "" || destName == "" {
 		return fmt.Errorf("cannot rename %s/%s to %s/%s", srcRoot, srcName, destRoot, destName)
 	}
 
-	return Db().Exec("UPDATE files SET file_root = ?, file_name = ?, file_missing = 0, deleted_at = NULL WHERE file_root = ? AND file_name = ?", destRoot, destName, srcRoot, srcName).Error
+	return Db().Exec("UPDATE files SET file_root = ?, file_name = ?, file_missing = ?, deleted_at = NULL WHERE file_root = ? AND file_name = ?", destRoot, destName, false, srcRoot, srcName).Error
 }
 
 // SetPhotoPrimary sets a new primary image file for a photo.
@@ -137,7 +163,7 @@ func SetPhotoPrimary(photoUID, fileUID string) (err error) {
 
 	if fileUID != "" {
 		// Do nothing.
-	} else if err := Db().Model(entity.File{}).Where("photo_uid = ? AND file_missing = 0 AND file_type IN (?)", photoUID, media.PreviewExpr).Order("file_width DESC, file_hdr DESC").Limit(1).Pluck("file_uid", &files).Error; err != nil {
+	} else if err := Db().Model(entity.File{}).Where("photo_uid = ? AND file_missing = ? AND file_type IN (?)", photoUID, false, media.PreviewExpr).Order("file_width DESC, file_hdr DESC").Limit(1).Pluck("file_uid", &files).Error; err != nil {
 		return err
 	} else if len(files) == 0 {
 		return fmt.Errorf("cannot find primary file for %s", photoUID)
@@ -151,11 +177,11 @@ func SetPhotoPrimary(photoUID, fileUID string) (err error) {
 
 	if err = Db().Model(entity.File{}).
 		Where("photo_uid = ? AND file_uid <> ?", photoUID, fileUID).
-		UpdateColumn("file_primary", 0).Error; err != nil {
+		UpdateColumn("file_primary", false).Error; err != nil {
 		return err
 	} else if err = Db().
 		Model(entity.File{}).Where("photo_uid = ? AND file_uid = ?", photoUID, fileUID).
-		UpdateColumn("file_primary", 1).Error; err != nil {
+		UpdateColumn("file_primary", true).Error; err != nil {
 		return err
 	} else {
 		entity.File{PhotoUID: photoUID}.RegenerateIndex()
@@ -197,7 +223,7 @@ func IndexedFiles() (result FileMap, err error) {
 	// Query indexed files.
 	var files []File
 
-	if err := UnscopedDb().Raw("SELECT file_root, file_name, mod_time FROM files WHERE file_missing = 0 AND deleted_at IS NULL").Scan(&files).Error; err != nil {
+	if err := UnscopedDb().Raw("SELECT file_root, file_name, mod_time FROM files WHERE file_missing = ? AND deleted_at IS NULL", false).Scan(&files).Error; err != nil {
 		return result, err
 	}
 
@@ -12,7 +12,7 @@ type HashMap map[string]bool
 func CountFileHashes() (count int) {
 	if err := UnscopedDb().
 		Table(entity.File{}.TableName()).
-		Where("file_missing = 0 AND deleted_at IS NULL").
+		Where("file_missing = ? AND deleted_at IS NULL", false).
 		Select("COUNT(DISTINCT(file_hash))").Count(&count).Error; err != nil {
 		log.Errorf("files: %s (count hashes)", err)
 	}
@@ -49,7 +49,7 @@ func FileHashMap() (result HashMap, err error) {
 
 	if rows, err := UnscopedDb().
 		Table(entity.File{}.TableName()).
-		Where("file_missing = 0 AND deleted_at IS NULL").
+		Where("file_missing = ? AND deleted_at IS NULL", false).
 		Where("file_hash IS NOT NULL AND file_hash <> ''").
 		Select("file_hash").Rows(); err != nil {
 		return result, err
@@ -175,6 +175,25 @@ func TestFileByUID(t *testing.T) {
 	})
 }
 
+func TestFileByID(t *testing.T) {
+	t.Run("files found", func(t *testing.T) {
+		expected := entity.FileFixtures.Get("exampleFileName.jpg")
+		file, err := FileByID(expected.ID)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, expected.FileName, file.FileName)
+	})
+
+	t.Run("no files found", func(t *testing.T) {
+		_, err := FileByID(0)
+
+		assert.Error(t, err)
+	})
+}
+
 func TestFileByHash(t *testing.T) {
 	t.Run("files found", func(t *testing.T) {
 		file, err := FileByHash("2cad9168fa6acc5c5c2965ddf6ec465ca42fd818")
@@ -193,6 +212,29 @@ func TestFileByHash(t *testing.T) {
 	})
 }
 
+func TestFileByName(t *testing.T) {
+	t.Run("files found", func(t *testing.T) {
+		file, err := FileByName(entity.RootOriginals, "2790/07/27900704_070228_D6D51B6C.jpg")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.Equal(t, "2cad9168fa6acc5c5c2965ddf6ec465ca42fd818", file.FileHash)
+	})
+
+	t.Run("no files found", func(t *testing.T) {
+		_, err := FileByName(entity.RootOriginals, "2790/07/missing.jpg")
+
+		assert.Error(t, err)
+	})
+
+	t.Run("empty name", func(t *testing.T) {
+		_, err := FileByName(entity.RootOriginals, "")
+
+		assert.Error(t, err)
+	})
+}
+
 func TestSetPhotoPrimary(t *testing.T) {
 	t.Run("success", func(t *testing.T) {
 		assert.Equal(t, false, entity.FileFixturesExampleXMP.FilePrimary)
@@ -0,0 +1,71 @@
+package query
+
+import (
+	"github.com/jinzhu/gorm"
+
+	"github.com/photoprism/photoprism/internal/entity"
+)
+
+// LibraryLabels returns all labels sorted by id, e.g. to export the library metadata.
+func LibraryLabels() (result entity.Labels, err error) {
+	err = Db().Order("id").Find(&result).Error
+	return result, err
+}
+
+// LibrarySubjects returns all subjects sorted by name, e.g. to export the library metadata.
+func LibrarySubjects() (result entity.Subjects, err error) {
+	err = Db().Order("subj_name").Find(&result).Error
+	return result, err
+}
+
+// LibraryFaces returns all face clusters sorted by id, e.g. to export the library metadata.
+func LibraryFaces() (result entity.Faces, err error) {
+	err = Db().Order("id").Find(&result).Error
+	return result, err
+}
+
+// LibraryPhotos returns photos including archived ones with their files, labels, and details preloaded.
+func LibraryPhotos(limit, offset int) (result entity.Photos, err error) {
+	err = UnscopedDb().
+		Preload("Files", func(db *gorm.DB) *gorm.DB {
+			return db.Where("file_missing = ? AND deleted_at IS NULL", false).Order("file_primary DESC, id")
+		}).
+		Preload("Labels", func(db *gorm.DB) *gorm.DB {
+			return db.Order("photos_labels.uncertainty ASC, photos_labels.label_id DESC")
+		}).
+		Preload("Labels.Label").
+		Preload("Details").
+		Order("id").
+		Limit(limit).Offset(offset).
+		Find(&result).Error
+
+	return result, err
+}
+
+// FileMarkers returns the markers of the specified files sorted by id.
+func FileMarkers(fileUIDs []string) (result entity.Markers, err error) {
+	if len(fileUIDs) == 0 {
+		return result, nil
+	}
+
+	err = Db().Where("file_uid IN (?)", fileUIDs).Order("file_uid, marker_uid").Find(&result).Error
+
+	return result, err
+}
+
+// Reactions returns the user reactions to the specified entities.
+func Reactions(uids []string) (result []entity.Reaction, err error) {
+	if len(uids) == 0 {
+		return result, nil
+	}
+
+	err = Db().Where("uid IN (?)", uids).Order("uid, user_uid").Find(&result).Error
+
+	return result, err
+}
+
+// AlbumEntries returns the photo entries of the specified album, including hidden ones.
+func AlbumEntries(albumUID string) (result entity.PhotoAlbums, err error) {
+	err = Db().Where("album_uid = ?", albumUID).Order("created_at, photo_uid").Find(&result).Error
+	return result, err
+}
@@ -0,0 +1,106 @@
+package query
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/entity"
+)
+
+func TestLibraryLabels(t *testing.T) {
+	results, err := LibraryLabels()
+
+	assert.NoError(t, err)
+	assert.GreaterOrEqual(t, len(results), 5)
+}
+
+func TestLibrarySubjects(t *testing.T) {
+	results, err := LibrarySubjects()
+
+	assert.NoError(t, err)
+	assert.GreaterOrEqual(t, len(results), 3)
+}
+
+func TestLibraryFaces(t *testing.T) {
+	results, err := LibraryFaces()
+
+	assert.NoError(t, err)
+	assert.GreaterOrEqual(t, len(results), 3)
+}
+
+func TestLibraryPhotos(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		results, err := LibraryPhotos(1000, 0)
+
+		assert.NoError(t, err)
+		assert.GreaterOrEqual(t, len(results), 10)
+
+		var files, labels int
+
+		for _, p := range results {
+			files += len(p.Files)
+			labels += len(p.Labels)
+		}
+
+		assert.Greater(t, files, 0)
+		assert.Greater(t, labels, 0)
+	})
+	t.Run("Offset", func(t *testing.T) {
+		results, err := LibraryPhotos(1, 1)
+
+		assert.NoError(t, err)
+		assert.Len(t, results, 1)
+	})
+}
+
+func TestFileMarkers(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		m := entity.MarkerFixtures.Get("1000003-4")
+		results, err := FileMarkers([]string{m.FileUID})
+
+		assert.NoError(t, err)
+		assert.GreaterOrEqual(t, len(results), 1)
+
+		for _, r := range results {
+			assert.Equal(t, m.FileUID, r.FileUID)
+		}
+	})
+	t.Run("Empty", func(t *testing.T) {
+		results, err := FileMarkers(nil)
+
+		assert.NoError(t, err)
+		assert.Empty(t, results)
+	})
+}
+
+func TestReactions(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		r := entity.ReactionFixtures["SubjectJohnLike"]
+		results, err := Reactions([]string{r.UID})
+
+		assert.NoError(t, err)
+		assert.GreaterOrEqual(t, len(results), 1)
+	})
+	t.Run("Empty", func(t *testing.T) {
+		results, err := Reactions(nil)
+
+		assert.NoError(t, err)
+		assert.Empty(t, results)
+	})
+}
+
+func TestAlbumEntries(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		results, err := AlbumEntries(entity.AlbumFixtures.Get("holiday-2030").AlbumUID)
+
+		assert.NoError(t, err)
+		assert.GreaterOrEqual(t, len(results), 1)
+	})
+	t.Run("NotFound", func(t *testing.T) {
+		results, err := AlbumEntries("as6sg6bxpogaaxyz")
+
+		assert.NoError(t, err)
+		assert.Empty(t, results)
+	})
+}
@@ -76,7 +76,7 @@ func PhotoPreloadByUID(photoUID string) (photo entity.Photo, err error) {
 func PhotosMissing(limit int, offset int) (entities entity.Photos, err error) {
 	err = Db().
 		Select("photos.*").
-		Where("id NOT IN (SELECT photo_id FROM files WHERE file_missing = 0 AND file_root = '/' AND deleted_at IS NULL)").
+		Where("id NOT IN (SELECT photo_id FROM files WHERE file_missing = ? AND file_root = '/' AND deleted_at IS NULL)", false).
 		Where("photos.photo_type <> ?", entity.MediaText).
 		Group("photos.id").
 		Limit(limit).Offset(offset).Find(&entities).Error
@@ -104,6 +104,26 @@ func PhotosMetadataUpdate(limit, offset int, delay, interval time.Duration) (ent
 	return entities, err
 }
 
+// PhotosTakenBetween returns photos taken in the specified time range, ordered by time.
+func PhotosTakenBetween(start, end time.Time) (entities entity.Photos, err error) {
+	err = Db().
+		Preload("Labels", func(db *gorm.DB) *gorm.DB {
+			return db.Order("photos_labels.uncertainty ASC, photos_labels.label_id DESC")
+		}).
+		Preload("Labels.Label").
+		Preload("Camera").
+		Preload("Lens").
+		Preload("Details").
+		Preload("Place").
+		Preload("Cell").
+		Preload("Cell.Place").
+		Where("taken_at BETWEEN ? AND ?", start.UTC(), end.UTC()).
+		Order("photos.taken_at ASC, photos.id ASC").
+		Find(&entities).Error
+
+	return entities, err
+}
+
 // OrphanPhotos finds orphan index entries that may be removed.
 func OrphanPhotos() (photos entity.Photos, err error) {
 	err = UnscopedDb().
@@ -127,8 +147,8 @@ func FixPrimaries() error {
 
 	// Remove primary file flag from broken or missing files.
 	if err := UnscopedDb().Table(entity.File{}.TableName()).
-		Where("(file_error <> '' OR file_missing = 1) AND file_primary <> 0").
-		UpdateColumn("file_primary", 0).Error; err != nil {
+		Where("(file_error <> '' OR file_missing = ?) AND file_primary <> ?", true, false).
+		UpdateColumn("file_primary", false).Error; err != nil {
 		return err
 	}
 
@@ -136,7 +156,7 @@ func FixPrimaries() error {
 	if err := UnscopedDb().
 		Raw(`SELECT * FROM photos 
 			WHERE deleted_at IS NULL 
-			AND id NOT IN (SELECT photo_id FROM files WHERE file_primary = 1)`).
+			AND id NOT IN (SELECT photo_id FROM files WHERE file_primary = ?)`, true).
 		Find(&photos).Error; err != nil {
 		return err
 	}
@@ -173,7 +193,7 @@ func FlagHiddenPhotos() (err error) {
 
 	// Find and flag hidden photos.
 	if err = Db().Table(entity.Photo{}.TableName()).
-		Where("id NOT IN (SELECT photo_id FROM files WHERE file_primary = 1 AND file_missing = 0 AND file_error = '' AND deleted_at IS NULL) AND photo_quality > -1").
+		Where("id NOT IN (SELECT photo_id FROM files WHERE file_primary = ? AND file_missing = ? AND file_error = '' AND deleted_at IS NULL) AND photo_quality > -1", true, false).
 		Pluck("id", &hidden).Error; err != nil {
 		// Find failed.
 		return err
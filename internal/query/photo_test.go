@@ -78,6 +78,35 @@ func TestPhotosMetadataUpdate(t *testing.T) {
 	assert.IsType(t, entity.Photos{}, result)
 }
 
+func TestPhotosTakenBetween(t *testing.T) {
+	t.Run("Found", func(t *testing.T) {
+		start := time.Date(2008, 7, 1, 9, 0, 0, 0, time.UTC)
+		end := time.Date(2008, 7, 1, 11, 0, 0, 0, time.UTC)
+
+		result, err := PhotosTakenBetween(start, end)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.NotEmpty(t, result)
+
+		for _, p := range result {
+			assert.False(t, p.TakenAt.Before(start))
+			assert.False(t, p.TakenAt.After(end))
+		}
+	})
+	t.Run("NotFound", func(t *testing.T) {
+		result, err := PhotosTakenBetween(time.Date(1800, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(1800, 1, 2, 0, 0, 0, 0, time.UTC))
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Empty(t, result)
+	})
+}
+
 func TestOrphanPhotos(t *testing.T) {
 	result, err := OrphanPhotos()
 
@@ -71,7 +71,7 @@ func PurgeOrphanDuplicates() error {
 
 	return UnscopedDb().Delete(
 		entity.Duplicate{},
-		"file_hash NOT IN (SELECT file_hash FROM files WHERE file_missing = 0 AND deleted_at IS NULL)").Error
+		"file_hash NOT IN (SELECT file_hash FROM files WHERE file_missing = ? AND deleted_at IS NULL)", false).Error
 }
 
 // PurgeOrphanCountries removes countries without any photos.
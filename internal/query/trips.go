@@ -0,0 +1,112 @@
+package query
+
+import (
+	"strings"
+	"time"
+
+	"github.com/photoprism/photoprism/internal/entity"
+)
+
+// TripSlugPrefix is the slug prefix of moment albums created for trips.
+const TripSlugPrefix = "trip-"
+
+// TripPhoto represents the capture time and location of a picture for detecting trips.
+type TripPhoto struct {
+	PhotoUID     string    `json:"UID"`
+	TakenAt      time.Time `json:"TakenAt"`
+	TakenAtLocal time.Time `json:"TakenAtLocal"`
+	PhotoLat     float32   `json:"Lat"`
+	PhotoLng     float32   `json:"Lng"`
+	PhotoCountry string    `json:"Country"`
+	CellID       string    `json:"CellID"`
+	PlaceCity    string    `json:"City"`
+	PlaceState   string    `json:"State"`
+}
+
+// TripPhotos represents a list of pictures with capture time and location.
+type TripPhotos []TripPhoto
+
+// HasLatLng checks if the picture has coordinates.
+func (m TripPhoto) HasLatLng() bool {
+	return m.PhotoLat != 0.0 || m.PhotoLng != 0.0
+}
+
+// HasCountry checks if the picture has a known country.
+func (m TripPhoto) HasCountry() bool {
+	return m.PhotoCountry != "" && m.PhotoCountry != entity.UnknownID
+}
+
+// Place returns the most specific place name, if any.
+func (m TripPhoto) Place() string {
+	if city := strings.TrimSpace(m.PlaceCity); city != "" && city != entity.UnknownPlace.PlaceCity {
+		return city
+	} else if state := strings.TrimSpace(m.PlaceState); state != entity.UnknownPlace.PlaceState {
+		return state
+	}
+
+	return ""
+}
+
+// TripPhotosByTime returns pictures with a known capture time and location, ordered by capture time.
+// If not zero, only pictures taken at or after "after" and before "before" are returned.
+func TripPhotosByTime(public bool, after, before time.Time) (results TripPhotos, err error) {
+	stmt := UnscopedDb().Table("photos").
+		Select("photos.photo_uid, photos.taken_at, photos.taken_at_local, photos.photo_lat, photos.photo_lng, "+
+			"photos.photo_country, photos.cell_id, p.place_city, p.place_state").
+		Joins("LEFT JOIN places p ON p.id = photos.place_id").
+		Where("photos.photo_quality >= 3 AND photos.deleted_at IS NULL AND photos.taken_src <> ?", entity.SrcAuto).
+		Where("photos.photo_lat <> 0 OR photos.photo_lng <> 0 OR photos.photo_country <> ?", entity.UnknownID)
+
+	// Ignore private pictures?
+	if public {
+		stmt = stmt.Where("photos.photo_private = ?", false)
+	}
+
+	if !after.IsZero() {
+		stmt = stmt.Where("photos.taken_at >= ?", after.UTC())
+	}
+
+	if !before.IsZero() {
+		stmt = stmt.Where("photos.taken_at < ?", before.UTC())
+	}
+
+	stmt = stmt.Order("photos.taken_at, photos.photo_uid")
+
+	if err = stmt.Scan(&results).Error; err != nil {
+		return results, err
+	}
+
+	return results, nil
+}
+
+// TripPhotosChanged returns the capture time of the first and last picture that has been added, changed,
+// or deleted since the specified time, or zero values if there are none.
+func TripPhotosChanged(since time.Time) (first, last time.Time, err error) {
+	var results TripPhotos
+
+	stmt := UnscopedDb().Table("photos").Select("photos.taken_at").
+		Where("photos.created_at >= ? OR photos.updated_at >= ? OR photos.deleted_at >= ?", since, since, since)
+
+	if err = stmt.Order("photos.taken_at").Limit(1).Scan(&results).Error; err != nil || len(results) == 0 {
+		return first, last, err
+	}
+
+	first = results[0].TakenAt
+	results = nil
+
+	if err = stmt.Order("photos.taken_at DESC", true).Limit(1).Scan(&results).Error; err != nil || len(results) == 0 {
+		return first, first, err
+	}
+
+	return first, results[0].TakenAt, nil
+}
+
+// TripAlbums returns all moment albums that have been created for trips, including deleted albums.
+func TripAlbums() (results entity.Albums, err error) {
+	err = UnscopedDb().
+		Where("album_type = ? AND album_slug LIKE ?", entity.AlbumMoment, TripSlugPrefix+"%").
+		Order("album_slug").
+		Find(&results).Error
+
+	return results, err
+}
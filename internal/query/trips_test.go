@@ -0,0 +1,136 @@
+package query
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/entity"
+)
+
+func TestTripPhoto_HasLatLng(t *testing.T) {
+	assert.True(t, TripPhoto{PhotoLat: 48.5, PhotoLng: 0}.HasLatLng())
+	assert.False(t, TripPhoto{}.HasLatLng())
+}
+
+func TestTripPhoto_HasCountry(t *testing.T) {
+	assert.True(t, TripPhoto{PhotoCountry: "de"}.HasCountry())
+	assert.False(t, TripPhoto{PhotoCountry: "zz"}.HasCountry())
+	assert.False(t, TripPhoto{}.HasCountry())
+}
+
+func TestTripPhoto_Place(t *testing.T) {
+	assert.Equal(t, "Berlin", TripPhoto{PlaceCity: "Berlin", PlaceState: "Berlin"}.Place())
+	assert.Equal(t, "Bayern", TripPhoto{PlaceCity: "Unknown", PlaceState: "Bayern"}.Place())
+	assert.Equal(t, "", TripPhoto{PlaceCity: "Unknown", PlaceState: "Unknown"}.Place())
+}
+
+func TestTripPhotosByTime(t *testing.T) {
+	t.Run("PublicOnly", func(t *testing.T) {
+		results, err := TripPhotosByTime(true, time.Time{}, time.Time{})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.NotEmpty(t, results)
+
+		for i, p := range results {
+			assert.True(t, p.HasLatLng() || p.HasCountry())
+
+			if i > 0 {
+				assert.False(t, p.TakenAt.Before(results[i-1].TakenAt))
+			}
+		}
+	})
+	t.Run("IncludePrivate", func(t *testing.T) {
+		public, err := TripPhotosByTime(true, time.Time{}, time.Time{})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		results, err := TripPhotosByTime(false, time.Time{}, time.Time{})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.GreaterOrEqual(t, len(results), len(public))
+	})
+	t.Run("TimeRange", func(t *testing.T) {
+		all, err := TripPhotosByTime(false, time.Time{}, time.Time{})
+
+		if err != nil {
+			t.Fatal(err)
+		} else if len(all) == 0 {
+			t.Skip("no pictures")
+		}
+
+		after := all[0].TakenAt
+		before := all[len(all)-1].TakenAt
+
+		results, err := TripPhotosByTime(false, after, before)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		for _, p := range results {
+			assert.False(t, p.TakenAt.Before(after))
+			assert.True(t, p.TakenAt.Before(before))
+		}
+	})
+}
+
+func TestTripPhotosChanged(t *testing.T) {
+	t.Run("All", func(t *testing.T) {
+		first, last, err := TripPhotosChanged(time.Time{})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.False(t, first.IsZero())
+		assert.False(t, last.Before(first))
+	})
+	t.Run("None", func(t *testing.T) {
+		first, last, err := TripPhotosChanged(time.Now().Add(time.Hour))
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.True(t, first.IsZero())
+		assert.True(t, last.IsZero())
+	})
+}
+
+func TestTripAlbums(t *testing.T) {
+	album := entity.NewMomentsAlbum("Trip Test", TripSlugPrefix+"test-query", "after:2000-01-01 before:2000-01-03")
+
+	if err := album.Create(); err != nil {
+		t.Fatal(err)
+	}
+
+	defer album.DeletePermanently()
+
+	results, err := TripAlbums()
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	found := false
+
+	for _, a := range results {
+		assert.Equal(t, entity.AlbumMoment, a.AlbumType)
+
+		if a.AlbumUID == album.AlbumUID {
+			found = true
+		}
+	}
+
+	assert.True(t, found)
+}
@@ -0,0 +1,74 @@
+package remote
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/photoprism/photoprism/internal/remote/webdav"
+	"github.com/photoprism/photoprism/pkg/clean"
+	"github.com/photoprism/photoprism/pkg/fs"
+)
+
+// Client represents a remote service client for uploading, downloading and syncing files.
+type Client interface {
+	// Files returns information about files in a directory, optionally recursively.
+	Files(dir string, recursive bool) (fs.FileInfos, error)
+	// Directories returns all subdirectories in a path.
+	Directories(dir string, recursive bool, timeout time.Duration) (fs.FileInfos, error)
+	// MkdirAll recursively creates remote directories.
+	MkdirAll(dir string) error
+	// Upload uploads a single file to the remote service.
+	Upload(src, dest string) error
+	// Download downloads a single file to the given location.
+	Download(src, dest string, force bool) error
+	// Delete deletes a single file.
+	Delete(name string) error
+	// Close releases the resources held by the client, e.g. open connections.
+	Close() error
+}
+
+// SyncServices lists the service types for which a client is available.
+var SyncServices = []string{ServiceWebDAV, ServiceS3, ServiceSFTP, ServiceLocal}
+
+// Supported checks if a client is available for the specified service type.
+func Supported(serviceType string) bool {
+	for _, s := range SyncServices {
+		if s == serviceType {
+			return true
+		}
+	}
+
+	return false
+}
+
+// webdavClient wraps the WebDAV client, which does not need to be closed.
+type webdavClient struct {
+	*webdav.Client
+}
+
+// Close implements Client.
+func (c webdavClient) Close() error {
+	return nil
+}
+
+// NewClient returns a new client for the specified service account.
+func NewClient(acc Account, timeout webdav.Timeout) (Client, error) {
+	switch acc.AccType {
+	case ServiceWebDAV:
+		client, err := webdav.NewClient(acc.AccURL, acc.AccUser, acc.AccPass, timeout)
+
+		if err != nil {
+			return nil, err
+		}
+
+		return webdavClient{Client: client}, nil
+	case ServiceS3:
+		return NewS3Client(acc)
+	case ServiceSFTP:
+		return NewSFTPClient(acc, webdav.Durations[timeout])
+	case ServiceLocal:
+		return NewLocalClient(acc)
+	default:
+		return nil, fmt.Errorf("service type %s is not supported", clean.Log(acc.AccType))
+	}
+}
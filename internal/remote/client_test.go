@@ -0,0 +1,65 @@
+package remote
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/remote/webdav"
+)
+
+func TestSupported(t *testing.T) {
+	assert.True(t, Supported(ServiceWebDAV))
+	assert.True(t, Supported(ServiceS3))
+	assert.True(t, Supported(ServiceSFTP))
+	assert.True(t, Supported(ServiceLocal))
+	assert.False(t, Supported(ServiceFacebook))
+	assert.False(t, Supported(""))
+}
+
+func TestNewClient(t *testing.T) {
+	t.Run("WebDAV", func(t *testing.T) {
+		c, err := NewClient(Account{AccType: ServiceWebDAV, AccURL: "http://dummy-webdav/", AccUser: "admin", AccPass: "photoprism"}, webdav.TimeoutLow)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.IsType(t, webdavClient{}, c)
+		assert.NoError(t, c.Close())
+	})
+	t.Run("S3", func(t *testing.T) {
+		c, err := NewClient(Account{AccType: ServiceS3, AccURL: "s3://s3.amazonaws.com/photos/originals", AccUser: "key", AccPass: "secret"}, webdav.TimeoutDefault)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.IsType(t, &StorageClient{}, c)
+	})
+	t.Run("SFTP", func(t *testing.T) {
+		c, err := NewClient(Account{AccType: ServiceSFTP, AccURL: "sftp://example.com/photos", AccUser: "admin"}, webdav.TimeoutDefault)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.IsType(t, &StorageClient{}, c)
+		assert.NoError(t, c.Close())
+	})
+	t.Run("Local", func(t *testing.T) {
+		c, err := NewClient(Account{AccType: ServiceLocal, AccURL: "file://" + t.TempDir()}, webdav.TimeoutDefault)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.IsType(t, &StorageClient{}, c)
+	})
+	t.Run("Unsupported", func(t *testing.T) {
+		c, err := NewClient(Account{AccType: ServiceFacebook, AccURL: "https://www.facebook.com/"}, webdav.TimeoutDefault)
+
+		assert.Error(t, err)
+		assert.Nil(t, c)
+	})
+}
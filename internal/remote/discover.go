@@ -3,6 +3,7 @@ package remote
 import (
 	"errors"
 	"net/url"
+	"path"
 	"strings"
 
 	"github.com/photoprism/photoprism/pkg/txt"
@@ -46,6 +47,21 @@ func Discover(rawUrl, user, pass string) (result Account, err error) {
 		u.User = url.UserPassword(result.AccUser, result.AccPass)
 	}
 
+	// Storage services are detected based on the URL scheme.
+	switch {
+	case u.Scheme == ServiceS3 || u.Scheme == ServiceSFTP:
+		u.User = nil
+		result.AccName = u.Host
+		result.AccType = u.Scheme
+		result.AccURL = u.String()
+		return result, nil
+	case u.Scheme == "file" || u.Scheme == "" && path.IsAbs(u.Path):
+		result.AccName = path.Base(u.Path)
+		result.AccType = ServiceLocal
+		result.AccURL = "file://" + path.Clean(u.Path)
+		return result, nil
+	}
+
 	// Set default scheme
 	if u.Scheme == "" {
 		u.Scheme = "https"
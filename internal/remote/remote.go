@@ -27,10 +27,18 @@ package remote
 import (
 	"net/http"
 	"time"
+
+	"github.com/photoprism/photoprism/internal/event"
 )
 
+// Global log instance.
+var log = event.Log
+
 const (
 	ServiceWebDAV    = "webdav"
+	ServiceS3        = "s3"
+	ServiceSFTP      = "sftp"
+	ServiceLocal     = "local"
 	ServiceFacebook  = "facebook"
 	ServiceTwitter   = "twitter"
 	ServiceFlickr    = "flickr"
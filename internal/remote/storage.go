@@ -0,0 +1,304 @@
+package remote
+
+import (
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/photoprism/photoprism/internal/storage"
+	"github.com/photoprism/photoprism/pkg/clean"
+	"github.com/photoprism/photoprism/pkg/fs"
+)
+
+// StorageClient implements a remote service client based on a storage backend,
+// e.g. an S3-compatible bucket, an SFTP server or a mounted local folder.
+type StorageClient struct {
+	storage storage.Storage
+}
+
+// NewStorageClient returns a new client for the specified storage backend.
+func NewStorageClient(s storage.Storage) *StorageClient {
+	return &StorageClient{storage: s}
+}
+
+// NewS3Client returns a new client for an S3-compatible bucket, e.g. "s3://s3.amazonaws.com/bucket/prefix?region=eu-central-1".
+// The s3 scheme uses HTTPS, while http and https URLs may be used for self-hosted servers. The username and password
+// are used as access key and secret key.
+func NewS3Client(acc Account) (*StorageClient, error) {
+	u, err := url.Parse(acc.AccURL)
+
+	if err != nil {
+		return nil, err
+	}
+
+	scheme := u.Scheme
+
+	switch scheme {
+	case "s3":
+		scheme = "https"
+	case "http", "https":
+	default:
+		return nil, fmt.Errorf("s3: invalid service url %s", clean.Log(acc.AccURL))
+	}
+
+	bucket, prefix := strings.Trim(u.Path, "/"), ""
+
+	if i := strings.Index(bucket, "/"); i > 0 {
+		bucket, prefix = bucket[:i], bucket[i+1:]
+	}
+
+	opt := storage.S3Options{
+		Endpoint:  scheme + "://" + u.Host,
+		Region:    u.Query().Get("region"),
+		Bucket:    bucket,
+		Prefix:    prefix,
+		AccessKey: acc.AccUser,
+		SecretKey: acc.AccPass,
+		PathStyle: true,
+	}
+
+	if opt.AccessKey == "" && u.User != nil {
+		opt.AccessKey = u.User.Username()
+		opt.SecretKey, _ = u.User.Password()
+	}
+
+	s, err := storage.NewS3(opt)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return NewStorageClient(s), nil
+}
+
+// NewSFTPClient returns a new client for a folder on an SFTP server, e.g. "sftp://example.com:22/photos".
+// The API key must match the SHA256 fingerprint of the server's host key, otherwise the connection is refused.
+func NewSFTPClient(acc Account, timeout time.Duration) (*StorageClient, error) {
+	u, err := url.Parse(acc.AccURL)
+
+	if err != nil {
+		return nil, err
+	} else if u.Scheme != ServiceSFTP {
+		return nil, fmt.Errorf("sftp: invalid service url %s", clean.Log(acc.AccURL))
+	}
+
+	opt := storage.SFTPOptions{
+		Host:     u.Host,
+		User:     acc.AccUser,
+		Password: acc.AccPass,
+		Root:     u.Path,
+		HostKey:  acc.AccKey,
+		Timeout:  timeout,
+	}
+
+	if opt.User == "" && u.User != nil {
+		opt.User = u.User.Username()
+		opt.Password, _ = u.User.Password()
+	}
+
+	s, err := storage.NewSFTP(opt)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return NewStorageClient(s), nil
+}
+
+// NewLocalClient returns a new client for a mounted local folder, e.g. "file:///mnt/photos".
+func NewLocalClient(acc Account) (*StorageClient, error) {
+	dir := acc.AccURL
+
+	if strings.HasPrefix(dir, "file:") {
+		u, err := url.Parse(dir)
+
+		if err != nil {
+			return nil, err
+		}
+
+		dir = u.Path
+	}
+
+	if !filepath.IsAbs(dir) {
+		return nil, fmt.Errorf("local: folder %s must be an absolute path", clean.Log(dir))
+	} else if !fs.PathExists(dir) {
+		return nil, fmt.Errorf("local: folder %s not found", clean.Log(dir))
+	}
+
+	return NewStorageClient(storage.NewLocal(dir)), nil
+}
+
+// String returns the storage location.
+func (c *StorageClient) String() string {
+	return c.storage.String()
+}
+
+// hidden checks if the object name contains a hidden file or folder.
+func (c *StorageClient) hidden(name string) bool {
+	for _, s := range strings.Split(name, "/") {
+		if strings.HasPrefix(s, ".") {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Files returns information about files in a directory, optionally recursively.
+func (c *StorageClient) Files(dir string, recursive bool) (result fs.FileInfos, err error) {
+	dir = storage.CleanName(dir)
+
+	found, err := c.storage.List(dir)
+
+	if err != nil {
+		return result, err
+	}
+
+	result = make(fs.FileInfos, 0, len(found))
+
+	for _, obj := range found {
+		if obj.Name == "" || c.hidden(obj.Name) {
+			continue
+		} else if !recursive && storage.CleanName(path.Dir(obj.Name)) != dir {
+			continue
+		}
+
+		result = append(result, fs.FileInfo{
+			Name: path.Base(obj.Name),
+			Abs:  "/" + obj.Name,
+			Size: obj.Size,
+			Date: obj.ModTime,
+		})
+	}
+
+	return result, nil
+}
+
+// Directories returns all subdirectories in a path that contain files.
+func (c *StorageClient) Directories(dir string, recursive bool, timeout time.Duration) (result fs.FileInfos, err error) {
+	dir = storage.CleanName(dir)
+
+	files, err := c.Files(dir, true)
+
+	if err != nil {
+		return result, err
+	}
+
+	found := make(map[string]bool)
+
+	for _, f := range files {
+		for d := storage.CleanName(path.Dir(f.Abs)); d != dir && d != ""; d = storage.CleanName(path.Dir(d)) {
+			if recursive || storage.CleanName(path.Dir(d)) == dir {
+				found[d] = true
+			}
+		}
+	}
+
+	result = make(fs.FileInfos, 0, len(found))
+
+	for d := range found {
+		result = append(result, fs.FileInfo{
+			Name: path.Base(d),
+			Abs:  "/" + d,
+			Dir:  true,
+		})
+	}
+
+	sort.Sort(result)
+
+	return result, nil
+}
+
+// MkdirAll recursively creates remote directories, if the storage backend supports them.
+func (c *StorageClient) MkdirAll(dir string) error {
+	if s, ok := c.storage.(interface{ MkdirAll(string) error }); ok {
+		return s.MkdirAll(dir)
+	}
+
+	return nil
+}
+
+// Upload uploads a single file to the storage backend.
+func (c *StorageClient) Upload(src, dest string) error {
+	f, err := os.Open(src)
+
+	if err != nil {
+		return fmt.Errorf("file %s not found", clean.Log(path.Base(src)))
+	}
+
+	defer f.Close()
+
+	info, err := f.Stat()
+
+	if err != nil {
+		return err
+	}
+
+	if err = c.storage.Put(dest, f, info.Size()); err != nil {
+		log.Errorf("remote: %s", clean.Log(err.Error()))
+		return fmt.Errorf("failed to upload %s", clean.Log(dest))
+	}
+
+	return nil
+}
+
+// Download downloads a single file to the given location.
+func (c *StorageClient) Download(src, dest string, force bool) error {
+	// Skip if file already exists.
+	if _, err := os.Stat(dest); err == nil && !force {
+		return fmt.Errorf("download skipped, %s already exists", clean.Log(dest))
+	}
+
+	if err := os.MkdirAll(filepath.Dir(dest), fs.ModeDir); err != nil {
+		return fmt.Errorf("cannot create folder %s (%s)", clean.Log(filepath.Dir(dest)), err)
+	}
+
+	reader, err := c.storage.Open(src)
+
+	if err != nil {
+		log.Errorf("remote: %s", clean.Log(err.Error()))
+		return fmt.Errorf("failed to download %s", clean.Log(src))
+	}
+
+	defer reader.Close()
+
+	f, err := os.OpenFile(dest, os.O_TRUNC|os.O_RDWR|os.O_CREATE, fs.ModeFile)
+
+	if err != nil {
+		log.Errorf("remote: %s", clean.Log(err.Error()))
+		return fmt.Errorf("failed to create %s", clean.Log(path.Base(dest)))
+	}
+
+	defer f.Close()
+
+	if _, err = io.Copy(f, reader); err != nil {
+		log.Errorf("remote: %s", clean.Log(err.Error()))
+		return fmt.Errorf("failed writing to %s", clean.Log(path.Base(dest)))
+	}
+
+	return nil
+}
+
+// Delete deletes a single file, files that do not exist are ignored.
+func (c *StorageClient) Delete(name string) error {
+	if err := c.storage.Delete(name); err != nil && err != storage.ErrNotFound {
+		return err
+	}
+
+	return nil
+}
+
+// Close closes the storage backend connection, if any.
+func (c *StorageClient) Close() error {
+	if s, ok := c.storage.(io.Closer); ok {
+		return s.Close()
+	}
+
+	return nil
+}
@@ -1,6 +1,7 @@
 package search
 
 import (
+	"fmt"
 	"strings"
 	"time"
 
@@ -32,7 +33,7 @@ func UserAlbums(f form.SearchAlbums, sess *entity.Session) (results AlbumResults
 	// Base query.
 	s := UnscopedDb().Table("albums").
 		Select("albums.*, cp.photo_count, cl.link_count, CASE WHEN albums.album_year = 0 THEN 0 ELSE 1 END AS has_year, CASE WHEN albums.album_location = '' THEN 1 ELSE 0 END AS no_location").
-		Joins("LEFT JOIN (SELECT album_uid, count(photo_uid) AS photo_count FROM photos_albums WHERE hidden = 0 AND missing = 0 GROUP BY album_uid) AS cp ON cp.album_uid = albums.album_uid").
+		Joins("LEFT JOIN (SELECT album_uid, count(photo_uid) AS photo_count FROM photos_albums WHERE hidden = ? AND missing = ? GROUP BY album_uid) AS cp ON cp.album_uid = albums.album_uid", false, false).
 		Joins("LEFT JOIN (SELECT share_uid, count(share_uid) AS link_count FROM links GROUP BY share_uid) AS cl ON cl.share_uid = albums.album_uid").
 		Where("albums.deleted_at IS NULL")
 
@@ -71,6 +72,19 @@ func UserAlbums(f form.SearchAlbums, sess *entity.Session) (results AlbumResults
 				s = s.Where("albums.album_uid IN (?) OR albums.created_by = ? OR albums.published_at > ? OR albums.album_type = ? AND (albums.album_path = ? OR albums.album_path LIKE ?)",
 					sess.SharedUIDs(), user.UserUID, entity.TimeStamp(), entity.AlbumFolder, basePath, basePath+"/%")
 			}
+		} else if user.HasPrivateLibrary() {
+			// Limit results to the private library of the user, including albums without an owner except user folders.
+			usersPath := entity.UsersPath
+			where := "albums.album_uid IN (?) OR albums.created_by = ? OR albums.published_at > ? OR " +
+				"(albums.created_by = '' OR albums.created_by IS NULL) AND (albums.album_type <> ? OR albums.album_path <> ? AND albums.album_path NOT LIKE ?)"
+			values := []interface{}{sess.SharedUIDs(), user.UserUID, entity.TimeStamp(), entity.AlbumFolder, usersPath, usersPath + "/%"}
+
+			if basePath := user.GetBasePath(); basePath != "" {
+				where += " OR albums.album_type = ? AND (albums.album_path = ? OR albums.album_path LIKE ?)"
+				values = append(values, entity.AlbumFolder, basePath, basePath+"/%")
+			}
+
+			s = s.Where(where, values...)
 		}
 
 		// Exclude private content?
@@ -149,7 +163,8 @@ func UserAlbums(f form.SearchAlbums, sess *entity.Session) (results AlbumResults
 	if txt.NotEmpty(f.Query) {
 		if f.Type != entity.AlbumFolder {
 			likeString := "%" + f.Query + "%"
-			s = s.Where("albums.album_title LIKE ? OR albums.album_location LIKE ?", likeString, likeString)
+			like := LikeOp()
+			s = s.Where(fmt.Sprintf("albums.album_title %s ? OR albums.album_location %s ?", like, like), likeString, likeString)
 		} else {
 			searchQuery := strings.Trim(strings.ReplaceAll(f.Query, "\\", "/"), "/")
 			for _, where := range LikeAllNames(Cols{"albums.album_title", "albums.album_location", "albums.album_path"}, searchQuery) {
@@ -160,7 +175,7 @@ func UserAlbums(f form.SearchAlbums, sess *entity.Session) (results AlbumResults
 
 	// Albums with public pictures only?
 	if f.Public {
-		s = s.Where("albums.album_private = 0 AND (albums.album_type <> 'folder' OR albums.album_path IN (SELECT photo_path FROM photos WHERE photo_private = 0 AND photo_quality > -1 AND deleted_at IS NULL))")
+		s = s.Where("albums.album_private = ? AND (albums.album_type <> 'folder' OR albums.album_path IN (SELECT photo_path FROM photos WHERE photo_private = ? AND photo_quality > -1 AND deleted_at IS NULL))", false, false)
 	} else {
 		s = s.Where("albums.album_type <> 'folder' OR albums.album_path IN (SELECT photo_path FROM photos WHERE photo_quality > -1 AND deleted_at IS NULL)")
 	}
@@ -183,7 +198,7 @@ func UserAlbums(f form.SearchAlbums, sess *entity.Session) (results AlbumResults
 
 	// Favorites only?
 	if f.Favorite {
-		s = s.Where("albums.album_favorite = 1")
+		s = s.Where("albums.album_favorite = ?", true)
 	}
 
 	// Filter by year?
@@ -192,8 +207,8 @@ func UserAlbums(f form.SearchAlbums, sess *entity.Session) (results AlbumResults
 		// year assigned to them, unlike calendar albums and moments for example.
 		if f.Type == entity.AlbumManual {
 			s = s.Where("? OR albums.album_uid IN (SELECT DISTINCT pay.album_uid FROM photos_albums pay "+
-				"JOIN photos py ON pay.photo_uid = py.photo_uid WHERE py.photo_year IN (?) AND pay.hidden = 0 AND pay.missing = 0)",
-				gorm.Expr(AnyInt("albums.album_year", f.Year, txt.Or, entity.UnknownYear, txt.YearMax)), strings.Split(f.Year, txt.Or))
+				"JOIN photos py ON pay.photo_uid = py.photo_uid WHERE py.photo_year IN (?) AND pay.hidden = ? AND pay.missing = ?)",
+				gorm.Expr(AnyInt("albums.album_year", f.Year, txt.Or, entity.UnknownYear, txt.YearMax)), strings.Split(f.Year, txt.Or), false, false)
 		} else {
 			s = s.Where(AnyInt("albums.album_year", f.Year, txt.Or, entity.UnknownYear, txt.YearMax))
 		}
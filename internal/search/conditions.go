@@ -3,13 +3,28 @@ package search
 import (
 	"fmt"
 	"strings"
+	"time"
 
+	"github.com/photoprism/photoprism/internal/entity"
 	"github.com/photoprism/photoprism/pkg/clean"
 	"github.com/photoprism/photoprism/pkg/txt"
 
 	"github.com/jinzhu/inflection"
 )
 
+// dbDialect returns the name of the database dialect in use.
+var dbDialect = entity.DbDialect
+
+// LikeOp returns the operator for case-insensitive pattern matching, since LIKE is
+// case-sensitive with PostgreSQL, unlike with MySQL, MariaDB, and SQLite.
+func LikeOp() string {
+	if dbDialect() == entity.Postgres {
+		return "ILIKE"
+	}
+
+	return "LIKE"
+}
+
 // Like escapes a string for use in a query.
 func Like(s string) string {
 	return strings.Trim(clean.SqlString(s), " |&*%")
@@ -22,6 +37,7 @@ func LikeAny(col, s string, keywords, exact bool) (wheres []string) {
 	}
 
 	s = txt.StripOr(clean.SearchQuery(s))
+	op := LikeOp()
 
 	var wildcardThreshold int
 
@@ -49,9 +65,9 @@ func LikeAny(col, s string, keywords, exact bool) (wheres []string) {
 
 		for _, w := range words {
 			if wildcardThreshold > 0 && len(w) >= wildcardThreshold {
-				orWheres = append(orWheres, fmt.Sprintf("%s LIKE '%s%%'", col, Like(w)))
+				orWheres = append(orWheres, fmt.Sprintf("%s %s '%s%%'", col, op, Like(w)))
 			} else {
-				orWheres = append(orWheres, fmt.Sprintf("%s LIKE '%s'", col, Like(w)))
+				orWheres = append(orWheres, fmt.Sprintf("%s %s '%s'", col, op, Like(w)))
 			}
 
 			if !keywords || !txt.ContainsASCIILetters(w) {
@@ -61,7 +77,7 @@ func LikeAny(col, s string, keywords, exact bool) (wheres []string) {
 			singular := inflection.Singular(w)
 
 			if singular != w {
-				orWheres = append(orWheres, fmt.Sprintf("%s LIKE '%s'", col, Like(singular)))
+				orWheres = append(orWheres, fmt.Sprintf("%s %s '%s'", col, op, Like(singular)))
 			}
 		}
 
@@ -106,11 +122,13 @@ func LikeAll(col, s string, keywords, exact bool) (wheres []string) {
 		wildcardThreshold = -1
 	}
 
+	op := LikeOp()
+
 	for _, w := range words {
 		if wildcardThreshold > 0 && len(w) >= wildcardThreshold {
-			wheres = append(wheres, fmt.Sprintf("%s LIKE '%s%%'", col, Like(w)))
+			wheres = append(wheres, fmt.Sprintf("%s %s '%s%%'", col, op, Like(w)))
 		} else {
-			wheres = append(wheres, fmt.Sprintf("%s LIKE '%s'", col, Like(w)))
+			wheres = append(wheres, fmt.Sprintf("%s %s '%s'", col, op, Like(w)))
 		}
 	}
 
@@ -133,6 +151,8 @@ func LikeAllNames(cols Cols, s string) (wheres []string) {
 		return wheres
 	}
 
+	op := LikeOp()
+
 	for _, k := range strings.Split(s, txt.And) {
 		var orWheres []string
 
@@ -145,9 +165,9 @@ func LikeAllNames(cols Cols, s string) (wheres []string) {
 
 			for _, c := range cols {
 				if strings.Contains(w, txt.Space) {
-					orWheres = append(orWheres, fmt.Sprintf("%s LIKE '%s%%'", c, Like(w)))
+					orWheres = append(orWheres, fmt.Sprintf("%s %s '%s%%'", c, op, Like(w)))
 				} else {
-					orWheres = append(orWheres, fmt.Sprintf("%s LIKE '%%%s%%'", c, Like(w)))
+					orWheres = append(orWheres, fmt.Sprintf("%s %s '%%%s%%'", c, op, Like(w)))
 				}
 			}
 		}
@@ -256,7 +276,7 @@ func OrLike(col, s string) (where string, values []interface{}) {
 		}
 	}
 
-	like := fmt.Sprintf("%s LIKE ?", col)
+	like := fmt.Sprintf("%s %s ?", col, LikeOp())
 	where = like + strings.Repeat(" OR "+like, len(terms)-1)
 
 	return where, values
@@ -296,3 +316,12 @@ func SplitOr(s string) (values []string) {
 func SplitAnd(s string) (values []string) {
 	return Split(s, txt.And)
 }
+
+// TakenAt returns the value to compare the capture time with, which is a date unless a time of day was specified.
+func TakenAt(t time.Time) string {
+	if t = t.UTC(); t.Hour() == 0 && t.Minute() == 0 && t.Second() == 0 {
+		return t.Format("2006-01-02")
+	}
+
+	return t.Format("2006-01-02 15:04:05")
+}
@@ -2,6 +2,7 @@ package search
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 
@@ -29,7 +30,39 @@ func TestLike(t *testing.T) {
 	})
 }
 
+// testDialect sets the database dialect for which conditions are built until the test has finished.
+func testDialect(t *testing.T, name string) {
+	dialect := dbDialect
+	dbDialect = func() string { return name }
+	t.Cleanup(func() { dbDialect = dialect })
+}
+
+func TestLikeOp(t *testing.T) {
+	t.Run("MySQL", func(t *testing.T) {
+		testDialect(t, entity.MySQL)
+		assert.Equal(t, "LIKE", LikeOp())
+	})
+	t.Run("SQLite3", func(t *testing.T) {
+		testDialect(t, entity.SQLite3)
+		assert.Equal(t, "LIKE", LikeOp())
+	})
+	t.Run("Postgres", func(t *testing.T) {
+		testDialect(t, entity.Postgres)
+		assert.Equal(t, "ILIKE", LikeOp())
+	})
+}
+
 func TestLikeAny(t *testing.T) {
+	t.Run("Postgres", func(t *testing.T) {
+		testDialect(t, entity.Postgres)
+
+		if w := LikeAny("k.keyword", "table spoon & usa | img json", true, false); len(w) != 2 {
+			t.Fatal("two where conditions expected")
+		} else {
+			assert.Equal(t, "k.keyword ILIKE 'spoon%' OR k.keyword ILIKE 'table%'", w[0])
+			assert.Equal(t, "k.keyword ILIKE 'json%' OR k.keyword ILIKE 'usa'", w[1])
+		}
+	})
 	t.Run("and_or_search", func(t *testing.T) {
 		if w := LikeAny("k.keyword", "table spoon & usa | img json", true, false); len(w) != 2 {
 			t.Fatal("two where conditions expected")
@@ -146,6 +179,16 @@ func TestLikeAnyWord(t *testing.T) {
 }
 
 func TestLikeAll(t *testing.T) {
+	t.Run("Postgres", func(t *testing.T) {
+		testDialect(t, entity.Postgres)
+
+		if w := LikeAll("k.keyword", "Jo Mander 李", true, false); len(w) == 2 {
+			assert.Equal(t, "k.keyword ILIKE 'mander%'", w[0])
+			assert.Equal(t, "k.keyword ILIKE '李'", w[1])
+		} else {
+			t.Fatalf("unexpected result: %#v", w)
+		}
+	})
 	t.Run("keywords", func(t *testing.T) {
 		if w := LikeAll("k.keyword", "Jo Mander 李", true, false); len(w) == 2 {
 			assert.Equal(t, "k.keyword LIKE 'mander%'", w[0])
@@ -198,6 +241,15 @@ func TestLikeAllWords(t *testing.T) {
 }
 
 func TestLikeAllNames(t *testing.T) {
+	t.Run("Postgres", func(t *testing.T) {
+		testDialect(t, entity.Postgres)
+
+		if w := LikeAllNames(Cols{"a.col1", "b.col2"}, "Mo Mander | Jo"); len(w) == 1 {
+			assert.Equal(t, "a.col1 ILIKE 'Mo Mander%' OR b.col2 ILIKE 'Mo Mander%' OR a.col1 ILIKE '%Jo%' OR b.col2 ILIKE '%Jo%'", w[0])
+		} else {
+			t.Fatalf("unexpected result: %#v", w)
+		}
+	})
 	t.Run("MultipleNames", func(t *testing.T) {
 		if w := LikeAllNames(Cols{"k.name"}, "j Mander 王"); len(w) == 1 {
 			assert.Equal(t, "k.name LIKE 'j Mander 王%'", w[0])
@@ -326,6 +378,14 @@ func TestAnyInt(t *testing.T) {
 }
 
 func TestOrLike(t *testing.T) {
+	t.Run("Postgres", func(t *testing.T) {
+		testDialect(t, entity.Postgres)
+
+		where, values := OrLike("k.keyword", "foo*%|bar")
+
+		assert.Equal(t, "k.keyword ILIKE ? OR k.keyword ILIKE ?", where)
+		assert.Equal(t, []interface{}{"foo%", "bar"}, values)
+	})
 	t.Run("Empty", func(t *testing.T) {
 		where, values := OrLike("k.keyword", "")
 
@@ -433,3 +493,15 @@ func TestSplitAnd(t *testing.T) {
 		assert.Equal(t, []string{"foo", "Bar", "BAZ"}, values)
 	})
 }
+
+func TestTakenAt(t *testing.T) {
+	t.Run("Date", func(t *testing.T) {
+		assert.Equal(t, "2023-06-05", TakenAt(time.Date(2023, 6, 5, 0, 0, 0, 0, time.UTC)))
+	})
+	t.Run("Time", func(t *testing.T) {
+		assert.Equal(t, "2023-06-05 08:30:01", TakenAt(time.Date(2023, 6, 5, 8, 30, 1, 0, time.UTC)))
+	})
+	t.Run("Zone", func(t *testing.T) {
+		assert.Equal(t, "2023-06-05 08:30:00", TakenAt(time.Date(2023, 6, 5, 10, 30, 0, 0, time.FixedZone("CEST", 7200))))
+	})
+}
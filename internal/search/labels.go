@@ -1,6 +1,7 @@
 package search
 
 import (
+	"fmt"
 	"strings"
 
 	"github.com/photoprism/photoprism/internal/entity"
@@ -11,6 +12,11 @@ import (
 
 // Labels searches labels based on their name.
 func Labels(f form.SearchLabels) (results []Label, err error) {
+	return UserLabels(f, nil)
+}
+
+// UserLabels searches labels based on their name and the user session.
+func UserLabels(f form.SearchLabels, sess *entity.Session) (results []Label, err error) {
 	if err := f.ParseQueryString(); err != nil {
 		return results, err
 	}
@@ -25,6 +31,12 @@ func Labels(f form.SearchLabels) (results []Label, err error) {
 		Where("labels.photo_count > 0").
 		Group("labels.id")
 
+	// Limit results to labels in the private library of the user.
+	if where, values := PrivateLibrary(sess); where != "" {
+		s = s.Where("labels.id IN (SELECT pl.label_id FROM photos_labels pl JOIN photos ON photos.id = pl.photo_id "+
+			"WHERE pl.uncertainty < 100 AND photos.deleted_at IS NULL AND ("+where+"))", values...)
+	}
+
 	// Limit result count.
 	if f.Count > 0 && f.Count <= MaxResults {
 		s = s.Limit(f.Count).Offset(f.Offset)
@@ -61,7 +73,7 @@ func Labels(f form.SearchLabels) (results []Label, err error) {
 		if result := Db().First(&label, "label_slug = ? OR custom_slug = ?", slugString, slugString); result.Error != nil {
 			log.Infof("search: label %s not found", clean.Log(f.Query))
 
-			s = s.Where("labels.label_name LIKE ?", likeString)
+			s = s.Where(fmt.Sprintf("labels.label_name %s ?", LikeOp()), likeString)
 		} else {
 			labelIds = append(labelIds, label.ID)
 
@@ -78,11 +90,11 @@ func Labels(f form.SearchLabels) (results []Label, err error) {
 	}
 
 	if f.Favorite {
-		s = s.Where("labels.label_favorite = 1")
+		s = s.Where("labels.label_favorite = ?", true)
 	}
 
 	if !f.All {
-		s = s.Where("labels.label_priority >= 0 OR labels.label_favorite = 1")
+		s = s.Where("labels.label_priority >= 0 OR labels.label_favorite = ?", true)
 	}
 
 	if result := s.Scan(&results); result.Error != nil {
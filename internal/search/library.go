@@ -0,0 +1,151 @@
+package search
+
+import (
+	"github.com/photoprism/photoprism/internal/acl"
+	"github.com/photoprism/photoprism/internal/entity"
+)
+
+// SharedPhotos returns an SQL condition with values that matches photos in the shared albums, the shared photos,
+// and photos with the shared labels, including labels in the same category as when searching for a label.
+func SharedPhotos(uids entity.UIDs) (where string, values []interface{}) {
+	where = "photos.photo_uid IN (SELECT photo_uid FROM photos_albums WHERE hidden = ? AND missing = ? AND album_uid IN (?)) OR " +
+		"photos.photo_uid IN (?) OR " +
+		"photos.id IN (SELECT pl.photo_id FROM photos_labels pl WHERE pl.uncertainty < 100 AND pl.label_id IN " +
+		"(SELECT l.id FROM labels l WHERE l.label_uid IN (?) UNION " +
+		"SELECT c.label_id FROM categories c JOIN labels l ON l.id = c.category_id WHERE l.label_uid IN (?)))"
+	values = []interface{}{false, false, uids, uids, uids, uids}
+
+	return where, values
+}
+
+// PrivateLibrary returns an SQL condition with values that limits photos to the private library of the session user,
+// i.e. their own pictures, pictures in albums shared with them, published pictures, and the family library, which
+// includes all pictures without an owner outside the user folders. The condition is empty if access is not limited.
+func PrivateLibrary(sess *entity.Session) (where string, values []interface{}) {
+	if sess == nil {
+		return "", nil
+	}
+
+	user := sess.User()
+
+	if !user.HasPrivateLibrary() {
+		return "", nil
+	}
+
+	usersPath := entity.UsersPath
+
+	where, values = SharedPhotos(sess.SharedUIDs())
+	where += " OR photos.created_by = ? OR photos.published_at > ? OR " +
+		"(photos.created_by = '' OR photos.created_by IS NULL) AND photos.photo_path <> ? AND photos.photo_path NOT LIKE ?"
+	values = append(values, user.UserUID, entity.TimeStamp(), usersPath, usersPath+"/%")
+
+	if basePath := user.GetBasePath(); basePath != "" {
+		where += " OR photos.photo_path = ? OR photos.photo_path LIKE ?"
+		values = append(values, basePath, basePath+"/%")
+	}
+
+	return where, values
+}
+
+// InLibrary checks if the photo with the specified UID is part of the session user's library.
+func InLibrary(sess *entity.Session, photoUid string) bool {
+	where, values := PrivateLibrary(sess)
+
+	if where == "" {
+		return true
+	} else if photoUid == "" {
+		return false
+	}
+
+	var count int
+
+	if err := UnscopedDb().Table("photos").
+		Where("photos.photo_uid = ?", photoUid).
+		Where(where, values...).
+		Count(&count).Error; err != nil {
+		log.Errorf("search: %s (check library)", err)
+		return false
+	}
+
+	return count > 0
+}
+
+// SharedOnly checks if the session user can only access shared content, e.g. as a visitor with a share link.
+func SharedOnly(sess *entity.Session) bool {
+	if sess == nil {
+		return false
+	}
+
+	return acl.Resources.DenyAll(acl.ResourcePhotos, sess.User().AclRole(), acl.Permissions{acl.AccessAll, acl.AccessLibrary, acl.AccessOwn})
+}
+
+// Viewable checks if the session may view the photo with the specified UID, e.g. as thumbnail or video. Users who
+// can only access shared content may view photos shared with active links, other users photos in their library.
+func Viewable(sess *entity.Session, photoUid string) bool {
+	if sess == nil {
+		return true
+	} else if !SharedOnly(sess) {
+		return InLibrary(sess, photoUid)
+	}
+
+	return inShares(sess.SharedUIDs(), photoUid)
+}
+
+// Downloadable checks if the session may download the photo with the specified UID. Users who can only access
+// shared content may download photos shared with download permission, other users photos in their library.
+func Downloadable(sess *entity.Session, photoUid string) bool {
+	if sess == nil {
+		return true
+	} else if !SharedOnly(sess) {
+		return InLibrary(sess, photoUid)
+	}
+
+	return inShares(sess.DownloadUIDs(), photoUid)
+}
+
+// DownloadShares returns the UIDs of the downloadable shares that contain at least one of the specified photos.
+func DownloadShares(sess *entity.Session, photoUids []string) (result entity.UIDs) {
+	result = entity.UIDs{}
+
+	if sess == nil || len(photoUids) == 0 {
+		return result
+	}
+
+	for _, uid := range sess.DownloadUIDs() {
+		where, values := SharedPhotos(entity.UIDs{uid})
+
+		var count int
+
+		if err := UnscopedDb().Table("photos").
+			Where("photos.photo_uid IN (?)", photoUids).
+			Where(where, values...).
+			Count(&count).Error; err != nil {
+			log.Errorf("search: %s (find download shares)", err)
+		} else if count > 0 {
+			result = append(result, uid)
+		}
+	}
+
+	return result
+}
+
+// inShares checks if the photo with the specified UID is part of the shared albums, photos, or labels.
+func inShares(uids entity.UIDs, photoUid string) bool {
+	if photoUid == "" || len(uids) == 0 {
+		return false
+	}
+
+	where, values := SharedPhotos(uids)
+
+	var count int
+
+	if err := UnscopedDb().Table("photos").
+		Where("photos.photo_uid = ?", photoUid).
+		Where(where, values...).
+		Count(&count).Error; err != nil {
+		log.Errorf("search: %s (check shares)", err)
+		return false
+	}
+
+	return count > 0
+}
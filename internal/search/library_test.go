@@ -0,0 +1,193 @@
+package search
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/form"
+)
+
+func TestPrivateLibrary(t *testing.T) {
+	t.Run("Disabled", func(t *testing.T) {
+		where, values := PrivateLibrary(entity.SessionFixtures.Pointer("bob"))
+		assert.Equal(t, "", where)
+		assert.Empty(t, values)
+	})
+	t.Run("NoSession", func(t *testing.T) {
+		entity.PrivateLibraries = true
+		defer func() { entity.PrivateLibraries = false }()
+
+		where, values := PrivateLibrary(nil)
+		assert.Equal(t, "", where)
+		assert.Empty(t, values)
+	})
+	t.Run("SuperAdmin", func(t *testing.T) {
+		entity.PrivateLibraries = true
+		defer func() { entity.PrivateLibraries = false }()
+
+		where, _ := PrivateLibrary(entity.SessionFixtures.Pointer("alice"))
+		assert.Equal(t, "", where)
+	})
+	t.Run("Admin", func(t *testing.T) {
+		entity.PrivateLibraries = true
+		defer func() { entity.PrivateLibraries = false }()
+
+		where, values := PrivateLibrary(entity.SessionFixtures.Pointer("bob"))
+		assert.Contains(t, where, "photos.created_by = ?")
+		assert.Len(t, values, 10)
+		assert.Equal(t, entity.UserFixtures.Pointer("bob").UserUID, values[6])
+	})
+}
+
+func TestSharedPhotos(t *testing.T) {
+	uids := entity.UIDs{"at9lxuqxpogaaba8"}
+
+	where, values := SharedPhotos(uids)
+
+	assert.Contains(t, where, "photos_albums")
+	assert.Contains(t, where, "photos_labels")
+	assert.Len(t, values, 6)
+
+	var count int
+
+	if err := UnscopedDb().Table("photos").Where(where, values...).Count(&count).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	assert.GreaterOrEqual(t, count, 1)
+}
+
+func TestDownloadable(t *testing.T) {
+	visitor := entity.SessionFixtures.Pointer("visitor")
+
+	t.Run("NoSession", func(t *testing.T) {
+		assert.True(t, Downloadable(nil, "pt9jtdre2lvl0yh7"))
+	})
+	t.Run("Admin", func(t *testing.T) {
+		assert.True(t, Downloadable(entity.SessionFixtures.Pointer("alice"), "pt9jtdre2lvl0yh7"))
+	})
+	t.Run("SharedAlbum", func(t *testing.T) {
+		assert.True(t, Downloadable(visitor, "pt9jtdre2lvl0yh7"))
+	})
+	t.Run("NotShared", func(t *testing.T) {
+		assert.False(t, Downloadable(visitor, "pt9jtdre2lvl0y11"))
+	})
+	t.Run("ViewOnly", func(t *testing.T) {
+		link := entity.NewLink("at9lxuqxpogaaba9", false, false)
+		link.Perm = entity.PermView
+
+		if err := link.Save(); err != nil {
+			t.Fatal(err)
+		}
+
+		defer link.Delete()
+
+		sess := entity.NewSession(0, 0)
+		sess.SetUser(&entity.Visitor)
+		sess.SetData(&entity.SessionData{Tokens: []string{link.LinkToken}})
+
+		assert.False(t, Downloadable(sess, "pt9jtdre2lvl0yh8"))
+	})
+}
+
+func TestSharedOnly(t *testing.T) {
+	assert.False(t, SharedOnly(nil))
+	assert.False(t, SharedOnly(entity.SessionFixtures.Pointer("alice")))
+	assert.True(t, SharedOnly(entity.SessionFixtures.Pointer("visitor")))
+}
+
+func TestViewable(t *testing.T) {
+	visitor := entity.SessionFixtures.Pointer("visitor")
+
+	assert.True(t, Viewable(nil, "pt9jtdre2lvl0y11"))
+	assert.True(t, Viewable(entity.SessionFixtures.Pointer("alice"), "pt9jtdre2lvl0y11"))
+	assert.True(t, Viewable(visitor, "pt9jtdre2lvl0yh7"))
+	assert.False(t, Viewable(visitor, "pt9jtdre2lvl0y11"))
+	assert.False(t, Viewable(visitor, ""))
+}
+
+func TestDownloadShares(t *testing.T) {
+	visitor := entity.SessionFixtures.Pointer("visitor")
+
+	assert.Equal(t, entity.UIDs{"at9lxuqxpogaaba8"}, DownloadShares(visitor, []string{"pt9jtdre2lvl0yh7", "pt9jtdre2lvl0y11"}))
+	assert.Empty(t, DownloadShares(visitor, []string{"pt9jtdre2lvl0y11"}))
+	assert.Empty(t, DownloadShares(visitor, nil))
+	assert.Empty(t, DownloadShares(nil, []string{"pt9jtdre2lvl0yh7"}))
+}
+
+func TestInLibrary(t *testing.T) {
+	bob := entity.SessionFixtures.Pointer("bob")
+	alice := entity.SessionFixtures.Pointer("alice")
+
+	owned := entity.NewUserPhoto(false, alice.UserUID)
+	owned.PhotoPath = "2020/01"
+	owned.PhotoName = "private-library-owned"
+
+	if err := owned.Create(); err != nil {
+		t.Fatal(err)
+	}
+
+	defer owned.DeletePermanently()
+
+	userFolder := entity.NewUserPhoto(false, entity.OwnerUnknown)
+	userFolder.PhotoPath = entity.UsersPath + "/alice"
+	userFolder.PhotoName = "private-library-folder"
+
+	if err := userFolder.Create(); err != nil {
+		t.Fatal(err)
+	}
+
+	defer userFolder.DeletePermanently()
+
+	family := entity.PhotoFixtures.Get("Photo01").PhotoUID
+
+	t.Run("Disabled", func(t *testing.T) {
+		assert.True(t, InLibrary(bob, owned.PhotoUID))
+		assert.True(t, InLibrary(bob, userFolder.PhotoUID))
+		assert.True(t, InLibrary(bob, family))
+	})
+	t.Run("Enabled", func(t *testing.T) {
+		entity.PrivateLibraries = true
+		defer func() { entity.PrivateLibraries = false }()
+
+		assert.False(t, InLibrary(bob, owned.PhotoUID))
+		assert.False(t, InLibrary(bob, userFolder.PhotoUID))
+		assert.True(t, InLibrary(bob, family))
+		assert.False(t, InLibrary(bob, ""))
+		assert.True(t, InLibrary(alice, owned.PhotoUID))
+		assert.True(t, InLibrary(nil, owned.PhotoUID))
+	})
+	t.Run("UserPhotos", func(t *testing.T) {
+		entity.PrivateLibraries = true
+		defer func() { entity.PrivateLibraries = false }()
+
+		f := form.SearchPhotos{UID: "pt9jtdre2lvl0yh0", Merged: true}
+
+		results, _, err := UserPhotos(f, bob)
+
+		assert.NoError(t, err)
+		assert.Len(t, results, 1)
+
+		f = form.SearchPhotos{UID: owned.PhotoUID, Merged: true}
+
+		results, _, err = UserPhotos(f, bob)
+
+		assert.NoError(t, err)
+		assert.Len(t, results, 0)
+	})
+	t.Run("UserAlbumsLabelsSubjects", func(t *testing.T) {
+		entity.PrivateLibraries = true
+		defer func() { entity.PrivateLibraries = false }()
+
+		_, err := UserAlbums(form.SearchAlbums{Type: entity.AlbumManual, Count: 10}, bob)
+		assert.NoError(t, err)
+
+		_, err = UserLabels(form.SearchLabels{Count: 10}, bob)
+		assert.NoError(t, err)
+
+		_, err = UserSubjects(form.SearchSubjects{Count: 10}, bob)
+		assert.NoError(t, err)
+	})
+}
@@ -75,18 +75,31 @@ func searchPhotos(f form.SearchPhotos, sess *entity.Session, resultCols string)
 	if txt.NotEmpty(f.Scope) {
 		f.Scope = strings.ToLower(f.Scope)
 
-		if idType, idPrefix := rnd.IdType(f.Scope); idType != rnd.TypeUID || idPrefix != entity.AlbumUID {
+		if idType, idPrefix := rnd.IdType(f.Scope); idType != rnd.TypeUID {
+			return PhotoResults{}, 0, ErrInvalidId
+		} else if idPrefix == entity.PhotoUID {
+			// Limit results to a shared photo.
+			s = s.Where("photos.photo_uid = ?", f.Scope)
+		} else if idPrefix == entity.LabelUID {
+			// Limit results to photos with a shared label.
+			if err := Db().Where("label_uid = ?", f.Scope).First(&entity.Label{}).Error; err != nil {
+				return PhotoResults{}, 0, ErrInvalidId
+			}
+
+			where, values := SharedPhotos(entity.UIDs{f.Scope})
+			s = s.Where(where, values...)
+		} else if idPrefix != entity.AlbumUID {
 			return PhotoResults{}, 0, ErrInvalidId
 		} else if a, err := entity.CachedAlbumByUID(f.Scope); err != nil || a.AlbumUID == "" {
 			return PhotoResults{}, 0, ErrInvalidId
 		} else if a.AlbumFilter == "" {
 			s = s.Joins("JOIN photos_albums ON photos_albums.photo_uid = files.photo_uid").
-				Where("photos_albums.hidden = 0 AND photos_albums.album_uid = ?", a.AlbumUID)
+				Where("photos_albums.hidden = ? AND photos_albums.album_uid = ?", false, a.AlbumUID)
 		} else if err = form.Unserialize(&f, a.AlbumFilter); err != nil {
 			return PhotoResults{}, 0, ErrBadFilter
 		} else {
 			f.Filter = a.AlbumFilter
-			s = s.Where("files.photo_uid NOT IN (SELECT photo_uid FROM photos_albums pa WHERE pa.hidden = 1 AND pa.album_uid = ?)", a.AlbumUID)
+			s = s.Where("files.photo_uid NOT IN (SELECT photo_uid FROM photos_albums pa WHERE pa.hidden = ? AND pa.album_uid = ?)", true, a.AlbumUID)
 		}
 	} else {
 		f.Scope = ""
@@ -115,7 +128,8 @@ func searchPhotos(f form.SearchPhotos, sess *entity.Session, resultCols string)
 		}
 
 		// Visitors and other restricted users can only access shared content.
-		if f.Scope != "" && !sess.HasShare(f.Scope) && (sess.IsVisitor() || sess.NotRegistered()) ||
+		if f.Scope != "" && !sess.HasShare(f.Scope) && (sess.IsVisitor() || sess.NotRegistered() ||
+			acl.Resources.DenyAll(acl.ResourcePhotos, aclRole, acl.Permissions{acl.AccessAll, acl.AccessLibrary})) ||
 			f.Scope == "" && acl.Resources.Deny(acl.ResourcePhotos, aclRole, acl.ActionSearch) {
 			event.AuditErr([]string{sess.IP(), "session %s", "%s %s as %s", "denied"}, sess.RefID, acl.ActionSearch.String(), string(acl.ResourcePhotos), aclRole)
 			return PhotoResults{}, 0, ErrForbidden
@@ -123,16 +137,19 @@ func searchPhotos(f form.SearchPhotos, sess *entity.Session, resultCols string)
 
 		// Limit results for external users.
 		if f.Scope == "" && acl.Resources.DenyAll(acl.ResourcePhotos, aclRole, acl.Permissions{acl.AccessAll, acl.AccessLibrary}) {
-			sharedAlbums := "photos.photo_uid IN (SELECT photo_uid FROM photos_albums WHERE hidden = 0 AND missing = 0 AND album_uid IN (?)) OR "
+			shared, values := SharedPhotos(sess.SharedUIDs())
 
 			if sess.IsVisitor() || sess.NotRegistered() {
-				s = s.Where(sharedAlbums+"photos.published_at > ?", sess.SharedUIDs(), entity.TimeStamp())
+				s = s.Where(shared+" OR photos.published_at > ?", append(values, entity.TimeStamp())...)
 			} else if basePath := user.GetBasePath(); basePath == "" {
-				s = s.Where(sharedAlbums+"photos.created_by = ? OR photos.published_at > ?", sess.SharedUIDs(), user.UserUID, entity.TimeStamp())
+				s = s.Where(shared+" OR photos.created_by = ? OR photos.published_at > ?", append(values, user.UserUID, entity.TimeStamp())...)
 			} else {
-				s = s.Where(sharedAlbums+"photos.created_by = ? OR photos.published_at > ? OR photos.photo_path = ? OR photos.photo_path LIKE ?",
-					sess.SharedUIDs(), user.UserUID, entity.TimeStamp(), basePath, basePath+"/%")
+				s = s.Where(shared+" OR photos.created_by = ? OR photos.published_at > ? OR photos.photo_path = ? OR photos.photo_path LIKE ?",
+					append(values, user.UserUID, entity.TimeStamp(), basePath, basePath+"/%")...)
 			}
+		} else if where, values := PrivateLibrary(sess); where != "" && (f.Scope == "" || !sess.HasShare(f.Scope)) {
+			// Limit results to the private library of the user.
+			s = s.Where(where, values...)
 		}
 	}
 
@@ -180,7 +197,7 @@ func searchPhotos(f form.SearchPhotos, sess *entity.Session, resultCols string)
 
 	// Primary files only.
 	if f.Primary {
-		s = s.Where("files.file_primary = 1")
+		s = s.Where("files.file_primary = ?", true)
 	}
 
 	// Find specific UIDs only.
@@ -383,38 +400,38 @@ func searchPhotos(f form.SearchPhotos, sess *entity.Session, resultCols string)
 		// Do nothing.
 	} else if len(f.Face) >= 32 {
 		for _, f := range SplitAnd(strings.ToUpper(f.Face)) {
-			s = s.Where(fmt.Sprintf("files.photo_id IN (SELECT photo_id FROM files f JOIN %s m ON f.file_uid = m.file_uid AND m.marker_invalid = 0 WHERE face_id IN (?))",
-				entity.Marker{}.TableName()), SplitOr(f))
+			s = s.Where(fmt.Sprintf("files.photo_id IN (SELECT photo_id FROM files f JOIN %s m ON f.file_uid = m.file_uid AND m.marker_invalid = ? WHERE face_id IN (?))",
+				entity.Marker{}.TableName()), false, SplitOr(f))
 		}
 	} else if txt.New(f.Face) {
-		s = s.Where(fmt.Sprintf("files.photo_id IN (SELECT photo_id FROM files f JOIN %s m ON f.file_uid = m.file_uid AND m.marker_invalid = 0 AND m.marker_type = ? WHERE subj_uid IS NULL OR subj_uid = '')",
-			entity.Marker{}.TableName()), entity.MarkerFace)
+		s = s.Where(fmt.Sprintf("files.photo_id IN (SELECT photo_id FROM files f JOIN %s m ON f.file_uid = m.file_uid AND m.marker_invalid = ? AND m.marker_type = ? WHERE subj_uid IS NULL OR subj_uid = '')",
+			entity.Marker{}.TableName()), false, entity.MarkerFace)
 	} else if txt.No(f.Face) {
-		s = s.Where(fmt.Sprintf("files.photo_id IN (SELECT photo_id FROM files f JOIN %s m ON f.file_uid = m.file_uid AND m.marker_invalid = 0 AND m.marker_type = ? WHERE face_id IS NULL OR face_id = '')",
-			entity.Marker{}.TableName()), entity.MarkerFace)
+		s = s.Where(fmt.Sprintf("files.photo_id IN (SELECT photo_id FROM files f JOIN %s m ON f.file_uid = m.file_uid AND m.marker_invalid = ? AND m.marker_type = ? WHERE face_id IS NULL OR face_id = '')",
+			entity.Marker{}.TableName()), false, entity.MarkerFace)
 	} else if txt.Yes(f.Face) {
-		s = s.Where(fmt.Sprintf("files.photo_id IN (SELECT photo_id FROM files f JOIN %s m ON f.file_uid = m.file_uid AND m.marker_invalid = 0 AND m.marker_type = ? WHERE face_id IS NOT NULL AND face_id <> '')",
-			entity.Marker{}.TableName()), entity.MarkerFace)
+		s = s.Where(fmt.Sprintf("files.photo_id IN (SELECT photo_id FROM files f JOIN %s m ON f.file_uid = m.file_uid AND m.marker_invalid = ? AND m.marker_type = ? WHERE face_id IS NOT NULL AND face_id <> '')",
+			entity.Marker{}.TableName()), false, entity.MarkerFace)
 	} else if txt.IsUInt(f.Face) {
-		s = s.Where("files.photo_id IN (SELECT photo_id FROM files f JOIN markers m ON f.file_uid = m.file_uid AND m.marker_invalid = 0 AND m.marker_type = ? JOIN faces ON faces.id = m.face_id WHERE m.face_id IS NOT NULL AND m.face_id <> '' AND faces.face_kind = ?)",
-			entity.MarkerFace, txt.Int(f.Face))
+		s = s.Where("files.photo_id IN (SELECT photo_id FROM files f JOIN markers m ON f.file_uid = m.file_uid AND m.marker_invalid = ? AND m.marker_type = ? JOIN faces ON faces.id = m.face_id WHERE m.face_id IS NOT NULL AND m.face_id <> '' AND faces.face_kind = ?)",
+			false, entity.MarkerFace, txt.Int(f.Face))
 	}
 
 	// Filter for one or more subjects.
 	if txt.NotEmpty(f.Subject) {
 		for _, subj := range SplitAnd(strings.ToLower(f.Subject)) {
 			if subjects := SplitOr(subj); rnd.ContainsUID(subjects, 'j') {
-				s = s.Where(fmt.Sprintf("files.photo_id IN (SELECT photo_id FROM files f JOIN %s m ON f.file_uid = m.file_uid AND m.marker_invalid = 0 WHERE subj_uid IN (?))",
-					entity.Marker{}.TableName()), subjects)
+				s = s.Where(fmt.Sprintf("files.photo_id IN (SELECT photo_id FROM files f JOIN %s m ON f.file_uid = m.file_uid AND m.marker_invalid = ? WHERE subj_uid IN (?))",
+					entity.Marker{}.TableName()), false, subjects)
 			} else {
-				s = s.Where(fmt.Sprintf("files.photo_id IN (SELECT photo_id FROM files f JOIN %s m ON f.file_uid = m.file_uid AND m.marker_invalid = 0 JOIN %s s ON s.subj_uid = m.subj_uid WHERE (?))",
-					entity.Marker{}.TableName(), entity.Subject{}.TableName()), gorm.Expr(AnySlug("s.subj_slug", subj, txt.Or)))
+				s = s.Where(fmt.Sprintf("files.photo_id IN (SELECT photo_id FROM files f JOIN %s m ON f.file_uid = m.file_uid AND m.marker_invalid = ? JOIN %s s ON s.subj_uid = m.subj_uid WHERE (?))",
+					entity.Marker{}.TableName(), entity.Subject{}.TableName()), false, gorm.Expr(AnySlug("s.subj_slug", subj, txt.Or)))
 			}
 		}
 	} else if txt.NotEmpty(f.Subjects) {
 		for _, where := range LikeAllNames(Cols{"subj_name", "subj_alias"}, f.Subjects) {
-			s = s.Where(fmt.Sprintf("files.photo_id IN (SELECT photo_id FROM files f JOIN %s m ON f.file_uid = m.file_uid AND m.marker_invalid = 0 JOIN %s s ON s.subj_uid = m.subj_uid WHERE (?))",
-				entity.Marker{}.TableName(), entity.Subject{}.TableName()), gorm.Expr(where))
+			s = s.Where(fmt.Sprintf("files.photo_id IN (SELECT photo_id FROM files f JOIN %s m ON f.file_uid = m.file_uid AND m.marker_invalid = ? JOIN %s s ON s.subj_uid = m.subj_uid WHERE (?))",
+				entity.Marker{}.TableName(), entity.Subject{}.TableName()), false, gorm.Expr(where))
 		}
 	}
 
@@ -429,9 +446,9 @@ func searchPhotos(f form.SearchPhotos, sess *entity.Session, resultCols string)
 		s = s.Where("photos.deleted_at IS NULL")
 
 		if f.Private {
-			s = s.Where("photos.photo_private = 1")
+			s = s.Where("photos.photo_private = ?", true)
 		} else if f.Public {
-			s = s.Where("photos.photo_private = 0")
+			s = s.Where("photos.photo_private = ?", false)
 		}
 
 		if f.Review {
@@ -446,7 +463,8 @@ func searchPhotos(f form.SearchPhotos, sess *entity.Session, resultCols string)
 		s = s.Where("photos.camera_id = ?", txt.UInt(f.Camera))
 	} else if txt.NotEmpty(f.Camera) {
 		v := strings.Trim(f.Camera, "*%") + "%"
-		s = s.Where("cameras.camera_name LIKE ? OR cameras.camera_model LIKE ? OR cameras.camera_slug LIKE ?", v, v, v)
+		like := LikeOp()
+		s = s.Where(fmt.Sprintf("cameras.camera_name %s ? OR cameras.camera_model %s ? OR cameras.camera_slug %s ?", like, like, like), v, v, v)
 	}
 
 	// Filter by lens id or name.
@@ -454,7 +472,8 @@ func searchPhotos(f form.SearchPhotos, sess *entity.Session, resultCols string)
 		s = s.Where("photos.lens_id = ?", txt.UInt(f.Lens))
 	} else if txt.NotEmpty(f.Lens) {
 		v := strings.Trim(f.Lens, "*%") + "%"
-		s = s.Where("lenses.lens_name LIKE ? OR lenses.lens_model LIKE ? OR lenses.lens_slug LIKE ?", v, v, v)
+		like := LikeOp()
+		s = s.Where(fmt.Sprintf("lenses.lens_name %s ? OR lenses.lens_model %s ? OR lenses.lens_slug %s ?", like, like, like), v, v, v)
 	}
 
 	// Filter by year.
@@ -479,22 +498,22 @@ func searchPhotos(f form.SearchPhotos, sess *entity.Session, resultCols string)
 
 	// Find favorites only.
 	if f.Favorite {
-		s = s.Where("photos.photo_favorite = 1")
+		s = s.Where("photos.photo_favorite = ?", true)
 	}
 
 	// Find scans only.
 	if f.Scan {
-		s = s.Where("photos.photo_scan = 1")
+		s = s.Where("photos.photo_scan = ?", true)
 	}
 
 	// Find panoramas only.
 	if f.Panorama {
-		s = s.Where("photos.photo_panorama = 1")
+		s = s.Where("photos.photo_panorama = ?", true)
 	}
 
 	// Find portrait/landscape/square pictures only.
 	if f.Portrait {
-		s = s.Where("files.file_portrait = 1")
+		s = s.Where("files.file_portrait = ?", true)
 	} else if f.Landscape {
 		s = s.Where("files.file_aspect_ratio > 1.25")
 	} else if f.Square {
@@ -636,11 +655,11 @@ func searchPhotos(f form.SearchPhotos, sess *entity.Session, resultCols string)
 	}
 
 	if !f.Before.IsZero() {
-		s = s.Where("photos.taken_at <= ?", f.Before.Format("2006-01-02"))
+		s = s.Where("photos.taken_at <= ?", TakenAt(f.Before))
 	}
 
 	if !f.After.IsZero() {
-		s = s.Where("photos.taken_at >= ?", f.After.Format("2006-01-02"))
+		s = s.Where("photos.taken_at >= ?", TakenAt(f.After))
 	}
 
 	// Find stacks only.
@@ -651,13 +670,14 @@ func searchPhotos(f form.SearchPhotos, sess *entity.Session, resultCols string)
 	// Find photos in albums or not in an album, unless search results are limited to a scope.
 	if f.Scope == "" {
 		if f.Unsorted {
-			s = s.Where("photos.photo_uid NOT IN (SELECT photo_uid FROM photos_albums pa JOIN albums a ON a.album_uid = pa.album_uid WHERE pa.hidden = 0 AND a.deleted_at IS NULL)")
+			s = s.Where("photos.photo_uid NOT IN (SELECT photo_uid FROM photos_albums pa JOIN albums a ON a.album_uid = pa.album_uid WHERE pa.hidden = ? AND a.deleted_at IS NULL)", false)
 		} else if txt.NotEmpty(f.Album) {
 			v := strings.Trim(f.Album, "*%") + "%"
-			s = s.Where("photos.photo_uid IN (SELECT pa.photo_uid FROM photos_albums pa JOIN albums a ON a.album_uid = pa.album_uid AND pa.hidden = 0 WHERE (a.album_title LIKE ? OR a.album_slug LIKE ?))", v, v)
+			like := LikeOp()
+			s = s.Where(fmt.Sprintf("photos.photo_uid IN (SELECT pa.photo_uid FROM photos_albums pa JOIN albums a ON a.album_uid = pa.album_uid AND pa.hidden = ? WHERE (a.album_title %s ? OR a.album_slug %s ?))", like, like), false, v, v)
 		} else if txt.NotEmpty(f.Albums) {
 			for _, where := range LikeAnyWord("a.album_title", f.Albums) {
-				s = s.Where("photos.photo_uid IN (SELECT pa.photo_uid FROM photos_albums pa JOIN albums a ON a.album_uid = pa.album_uid AND pa.hidden = 0 WHERE (?))", gorm.Expr(where))
+				s = s.Where("photos.photo_uid IN (SELECT pa.photo_uid FROM photos_albums pa JOIN albums a ON a.album_uid = pa.album_uid AND pa.hidden = ? WHERE (?))", false, gorm.Expr(where))
 			}
 		}
 	}
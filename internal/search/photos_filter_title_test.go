@@ -21,6 +21,19 @@ func TestPhotosFilterTitle(t *testing.T) {
 		}
 		assert.Equal(t, len(photos), 1)
 	})
+	t.Run("CaseInsensitive", func(t *testing.T) {
+		var f form.SearchPhotos
+
+		f.Title = "lAKE*"
+		f.Merged = true
+
+		photos, _, err := Photos(f)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.Equal(t, len(photos), 2)
+	})
 	t.Run("Lake*", func(t *testing.T) {
 		var f form.SearchPhotos
 
@@ -1779,3 +1779,43 @@ func TestPhotos(t *testing.T) {
 		assert.Equal(t, photos[0].PhotoTitle, "Neckarbrücke")
 	})
 }
+
+func TestPhotosScope(t *testing.T) {
+	t.Run("Photo", func(t *testing.T) {
+		f := form.SearchPhotos{Scope: "pt9jtdre2lvl0yh7", Count: 10, Merged: true}
+
+		photos, _, err := Photos(f)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Len(t, photos, 1)
+		assert.Equal(t, "pt9jtdre2lvl0yh7", photos[0].PhotoUID)
+	})
+	t.Run("Label", func(t *testing.T) {
+		f := form.SearchPhotos{Scope: "lt9k3pw1wowuy3c2", Count: 100, Merged: true}
+
+		photos, _, err := Photos(f)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.GreaterOrEqual(t, len(photos), 1)
+	})
+	t.Run("LabelNotFound", func(t *testing.T) {
+		f := form.SearchPhotos{Scope: "lt9k3pw1wowuy3x9", Count: 10}
+
+		_, _, err := Photos(f)
+
+		assert.Equal(t, ErrInvalidId, err)
+	})
+	t.Run("InvalidType", func(t *testing.T) {
+		f := form.SearchPhotos{Scope: "st9lxuqxpogaaba1", Count: 10}
+
+		_, _, err := Photos(f)
+
+		assert.Equal(t, ErrInvalidId, err)
+	})
+}
@@ -1,6 +1,7 @@
 package search
 
 import (
+	"fmt"
 	"strings"
 
 	"github.com/photoprism/photoprism/internal/entity"
@@ -24,7 +25,8 @@ func Sessions(f form.SearchSessions) (result entity.Sessions, err error) {
 	} else if rnd.IsUID(uid, entity.UserUID) {
 		stmt = stmt.Where("user_uid = ?", search)
 	} else if search != "" {
-		stmt = stmt.Where("user_name LIKE ? OR auth_provider LIKE ?", search+"%", search+"%")
+		like := LikeOp()
+		stmt = stmt.Where(fmt.Sprintf("user_name %s ? OR auth_provider %s ?", like, like), search+"%", search+"%")
 	}
 
 	if sortOrder == "" {
@@ -15,6 +15,11 @@ import (
 
 // Subjects searches subjects and returns them.
 func Subjects(f form.SearchSubjects) (results SubjectResults, err error) {
+	return UserSubjects(f, nil)
+}
+
+// UserSubjects searches subjects based on the search form and user session and returns them.
+func UserSubjects(f form.SearchSubjects, sess *entity.Session) (results SubjectResults, err error) {
 	if err := f.ParseQueryString(); err != nil {
 		return results, err
 	}
@@ -25,6 +30,14 @@ func Subjects(f form.SearchSubjects) (results SubjectResults, err error) {
 	s := UnscopedDb().Table(subjTable).
 		Select(fmt.Sprintf("%s.*", subjTable))
 
+	// Limit results to subjects in the private library of the user.
+	if where, values := PrivateLibrary(sess); where != "" {
+		s = s.Where(fmt.Sprintf("%s.subj_uid IN (SELECT m.subj_uid FROM markers m "+
+			"JOIN files ON files.file_uid = m.file_uid JOIN photos ON photos.id = files.photo_id "+
+			"WHERE m.marker_invalid = ? AND photos.deleted_at IS NULL AND (%s))", subjTable, where),
+			append([]interface{}{false}, values...)...)
+	}
+
 	// Limit result count.
 	if f.Count > 0 && f.Count <= MaxResults {
 		s = s.Limit(f.Count).Offset(f.Offset)
@@ -76,25 +89,25 @@ func Subjects(f form.SearchSubjects) (results SubjectResults, err error) {
 
 	if !f.All {
 		if txt.Yes(f.Favorite) {
-			s = s.Where("subj_favorite = 1")
+			s = s.Where("subj_favorite = ?", true)
 		} else if txt.No(f.Favorite) {
-			s = s.Where("subj_favorite = 0")
+			s = s.Where("subj_favorite = ?", false)
 		}
 
 		if !txt.Yes(f.Hidden) {
-			s = s.Where("subj_hidden = 0")
+			s = s.Where("subj_hidden = ?", false)
 		}
 
 		if txt.Yes(f.Private) {
-			s = s.Where("subj_private = 1")
+			s = s.Where("subj_private = ?", true)
 		} else if txt.No(f.Private) {
-			s = s.Where("subj_private = 0")
+			s = s.Where("subj_private = ?", false)
 		}
 
 		if txt.Yes(f.Excluded) {
-			s = s.Where("subj_excluded = 1")
+			s = s.Where("subj_excluded = ?", true)
 		} else if txt.No(f.Excluded) {
-			s = s.Where("subj_excluded = 0")
+			s = s.Where("subj_excluded = ?", false)
 		}
 	}
 
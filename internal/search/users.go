@@ -1,6 +1,7 @@
 package search
 
 import (
+	"fmt"
 	"strings"
 
 	"github.com/photoprism/photoprism/internal/entity"
@@ -26,7 +27,8 @@ func Users(f form.SearchUsers) (result entity.Users, err error) {
 	} else if rnd.IsUID(search, entity.UserUID) {
 		stmt = stmt.Where("user_uid = ?", search)
 	} else if search != "" {
-		stmt = stmt.Where("user_name LIKE ? OR user_email LIKE ? OR display_name LIKE ?", search+"%", search+"%", search+"%")
+		like := LikeOp()
+		stmt = stmt.Where(fmt.Sprintf("user_name %s ? OR user_email %s ? OR display_name %s ?", like, like, like), search+"%", search+"%", search+"%")
 	} else {
 		stmt = stmt.Where("id > 0")
 	}
@@ -32,6 +32,8 @@ func registerRoutes(router *gin.Engine, conf *config.Config) {
 	api.CreateSession(APIv1)
 	api.GetSession(APIv1)
 	api.DeleteSession(APIv1)
+	api.OIDCLogin(APIv1)
+	api.OIDCRedirect(APIv1)
 
 	// Server Config.
 	api.GetConfigOptions(APIv1)
@@ -46,6 +48,10 @@ func registerRoutes(router *gin.Engine, conf *config.Config) {
 	// Profile and Uploads.
 	api.UploadUserFiles(APIv1)
 	api.ProcessUserUpload(APIv1)
+	api.CreateResumableUpload(APIv1)
+	api.GetResumableUpload(APIv1)
+	api.UploadResumableChunk(APIv1)
+	api.DeleteResumableUpload(APIv1)
 	api.UploadUserAvatar(APIv1)
 	api.UpdateUserPassword(APIv1)
 	api.UpdateUser(APIv1)
@@ -55,6 +61,8 @@ func registerRoutes(router *gin.Engine, conf *config.Config) {
 	api.GetService(APIv1)
 	api.GetServiceFolders(APIv1)
 	api.UploadToService(APIv1)
+	api.GetServiceConflicts(APIv1)
+	api.ResolveServiceConflict(APIv1)
 	api.AddService(APIv1)
 	api.DeleteService(APIv1)
 	api.UpdateService(APIv1)
@@ -64,6 +72,10 @@ func registerRoutes(router *gin.Engine, conf *config.Config) {
 
 	// Video Streaming.
 	api.GetVideo(APIv1)
+	api.GetVideoPlaylist(APIv1)
+	api.GetVideoStream(APIv1)
+	api.GetVideoSprite(APIv1)
+	api.GetVideoSpriteTrack(APIv1)
 
 	// Downloads.
 	api.GetDownload(APIv1)
@@ -79,14 +91,16 @@ func registerRoutes(router *gin.Engine, conf *config.Config) {
 	// Photo Search and Organization.
 	api.SearchPhotos(APIv1)
 	api.SearchGeo(APIv1)
+	api.GetTracks(APIv1)
+	api.Geotag(APIv1)
 	api.GetPhoto(APIv1)
 	api.GetPhotoYaml(APIv1)
 	api.UpdatePhoto(APIv1)
 	api.GetPhotoDownload(APIv1)
-	// api.GetPhotoLinks(APIv1)
-	// api.CreatePhotoLink(APIv1)
-	// api.UpdatePhotoLink(APIv1)
-	// api.DeletePhotoLink(APIv1)
+	api.GetPhotoLinks(APIv1)
+	api.CreatePhotoLink(APIv1)
+	api.UpdatePhotoLink(APIv1)
+	api.DeletePhotoLink(APIv1)
 	api.ApprovePhoto(APIv1)
 	api.LikePhoto(APIv1)
 	api.DislikePhoto(APIv1)
@@ -124,10 +138,10 @@ func registerRoutes(router *gin.Engine, conf *config.Config) {
 	api.SearchLabels(APIv1)
 	api.LabelCover(APIv1)
 	api.UpdateLabel(APIv1)
-	// api.GetLabelLinks(APIv1)
-	// api.CreateLabelLink(APIv1)
-	// api.UpdateLabelLink(APIv1)
-	// api.DeleteLabelLink(APIv1)
+	api.GetLabelLinks(APIv1)
+	api.CreateLabelLink(APIv1)
+	api.UpdateLabelLink(APIv1)
+	api.DeleteLabelLink(APIv1)
 	api.LikeLabel(APIv1)
 	api.DislikeLabel(APIv1)
 
@@ -157,6 +171,13 @@ func registerRoutes(router *gin.Engine, conf *config.Config) {
 	api.BatchAlbumsDelete(APIv1)
 	api.BatchLabelsDelete(APIv1)
 
+	// Visually Similar Pictures.
+	api.GetDuplicates(APIv1)
+	api.DuplicatesStack(APIv1)
+	api.DuplicatesArchive(APIv1)
+	api.GetBursts(APIv1)
+	api.BurstsStack(APIv1)
+
 	// Technical Endpoints.
 	api.GetSvg(APIv1)
 	api.GetStatus(APIv1)
@@ -13,5 +13,6 @@ func registerSharingRoutes(router *gin.Engine, conf *config.Config) {
 	{
 		api.Shares(s)
 		api.SharePreview(s)
+		api.ShareUpload(s)
 	}
 }
@@ -0,0 +1,151 @@
+package storage
+
+import (
+	"fmt"
+	"io"
+	"io/fs"
+	"mime"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/photoprism/photoprism/pkg/clean"
+)
+
+// Local represents a storage backend based on a local folder.
+type Local struct {
+	root string
+}
+
+// NewLocal returns a new local folder storage.
+func NewLocal(root string) *Local {
+	return &Local{root: filepath.Clean(root)}
+}
+
+// String returns the storage folder.
+func (s *Local) String() string {
+	return s.root
+}
+
+// fileName returns the absolute file name of an object.
+func (s *Local) fileName(name string) string {
+	return filepath.Join(s.root, filepath.FromSlash(CleanName(name)))
+}
+
+// List returns all files in the specified directory, including subdirectories.
+func (s *Local) List(dir string) (result Objects, err error) {
+	start := s.fileName(dir)
+
+	err = filepath.WalkDir(start, func(fileName string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if fileName == start && os.IsNotExist(err) {
+				return filepath.SkipDir
+			}
+
+			return err
+		} else if d.IsDir() {
+			if fileName != start && strings.HasPrefix(d.Name(), ".") {
+				return filepath.SkipDir
+			}
+
+			return nil
+		} else if strings.HasPrefix(d.Name(), ".") || !d.Type().IsRegular() {
+			return nil
+		}
+
+		info, err := d.Info()
+
+		if err != nil {
+			return err
+		}
+
+		relName, err := filepath.Rel(s.root, fileName)
+
+		if err != nil {
+			return err
+		}
+
+		result = append(result, s.object(filepath.ToSlash(relName), info))
+
+		return nil
+	})
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Name < result[j].Name
+	})
+
+	return result, err
+}
+
+// object returns the object properties based on the file info.
+func (s *Local) object(name string, info os.FileInfo) Object {
+	return Object{
+		Name:        name,
+		Size:        info.Size(),
+		ModTime:     info.ModTime().UTC(),
+		ContentType: mime.TypeByExtension(filepath.Ext(name)),
+	}
+}
+
+// Stat returns the file properties.
+func (s *Local) Stat(name string) (Object, error) {
+	info, err := os.Stat(s.fileName(name))
+
+	if os.IsNotExist(err) {
+		return Object{}, ErrNotFound
+	} else if err != nil {
+		return Object{}, err
+	} else if info.IsDir() {
+		return Object{}, fmt.Errorf("%s is a directory", clean.Log(name))
+	}
+
+	return s.object(CleanName(name), info), nil
+}
+
+// Open returns a reader for the file content.
+func (s *Local) Open(name string) (io.ReadCloser, error) {
+	f, err := os.Open(s.fileName(name))
+
+	if os.IsNotExist(err) {
+		return nil, ErrNotFound
+	}
+
+	return f, err
+}
+
+// MkdirAll creates a directory and all missing parent directories.
+func (s *Local) MkdirAll(dir string) error {
+	return os.MkdirAll(s.fileName(dir), os.ModePerm)
+}
+
+// Put stores the content read from r as file.
+func (s *Local) Put(name string, r io.Reader, size int64) error {
+	fileName := s.fileName(name)
+
+	if err := os.MkdirAll(filepath.Dir(fileName), os.ModePerm); err != nil {
+		return err
+	}
+
+	f, err := os.Create(fileName)
+
+	if err != nil {
+		return err
+	}
+
+	if _, err = io.Copy(f, r); err != nil {
+		_ = f.Close()
+		return err
+	}
+
+	return f.Close()
+}
+
+// Delete removes a file.
+func (s *Local) Delete(name string) error {
+	if err := os.Remove(s.fileName(name)); os.IsNotExist(err) {
+		return ErrNotFound
+	} else {
+		return err
+	}
+}
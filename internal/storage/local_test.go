@@ -0,0 +1,94 @@
+package storage
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLocal(t *testing.T) {
+	dir := t.TempDir()
+	s := NewLocal(dir)
+
+	assert.Equal(t, dir, s.String())
+
+	t.Run("Put", func(t *testing.T) {
+		assert.NoError(t, s.Put("2023/01/cat.jpg", strings.NewReader("cat"), 3))
+		assert.NoError(t, s.Put("/2023/02/dog.jpg", strings.NewReader("dog"), 3))
+		assert.NoError(t, s.Put(".hidden/ignore.jpg", strings.NewReader("x"), 1))
+		assert.FileExists(t, filepath.Join(dir, "2023", "01", "cat.jpg"))
+	})
+	t.Run("List", func(t *testing.T) {
+		result, err := s.List("")
+
+		assert.NoError(t, err)
+
+		if assert.Len(t, result, 2) {
+			assert.Equal(t, "2023/01/cat.jpg", result[0].Name)
+			assert.Equal(t, int64(3), result[0].Size)
+			assert.Equal(t, "2023/02/dog.jpg", result[1].Name)
+		}
+
+		result, err = s.List("2023/02")
+
+		assert.NoError(t, err)
+		assert.Len(t, result, 1)
+
+		result, err = s.List("missing")
+
+		assert.NoError(t, err)
+		assert.Empty(t, result)
+	})
+	t.Run("Stat", func(t *testing.T) {
+		obj, err := s.Stat("2023/01/cat.jpg")
+
+		assert.NoError(t, err)
+		assert.Equal(t, "2023/01/cat.jpg", obj.Name)
+		assert.Equal(t, "image/jpeg", obj.ContentType)
+
+		_, err = s.Stat("missing.jpg")
+		assert.Equal(t, ErrNotFound, err)
+
+		_, err = s.Stat("2023")
+		assert.Error(t, err)
+	})
+	t.Run("Open", func(t *testing.T) {
+		r, err := s.Open("2023/01/cat.jpg")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		data, _ := io.ReadAll(r)
+		_ = r.Close()
+
+		assert.Equal(t, "cat", string(data))
+
+		_, err = s.Open("missing.jpg")
+		assert.Equal(t, ErrNotFound, err)
+	})
+	t.Run("MkdirAll", func(t *testing.T) {
+		assert.NoError(t, s.MkdirAll("2023/03/04"))
+		assert.DirExists(t, filepath.Join(dir, "2023", "03", "04"))
+	})
+	t.Run("Delete", func(t *testing.T) {
+		assert.NoError(t, s.Delete("2023/02/dog.jpg"))
+		assert.Equal(t, ErrNotFound, s.Delete("2023/02/dog.jpg"))
+
+		_, err := os.Stat(filepath.Join(dir, "2023", "02", "dog.jpg"))
+		assert.True(t, os.IsNotExist(err))
+	})
+}
+
+func TestCleanName(t *testing.T) {
+	assert.Equal(t, "", CleanName(""))
+	assert.Equal(t, "", CleanName("/"))
+	assert.Equal(t, "foo/bar.jpg", CleanName("/foo/bar.jpg"))
+	assert.Equal(t, "foo/bar.jpg", CleanName("foo\\bar.jpg"))
+	assert.Equal(t, "bar.jpg", CleanName("../../bar.jpg"))
+	assert.Equal(t, "foo", CleanName("foo/"))
+}
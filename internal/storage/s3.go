@@ -0,0 +1,207 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/url"
+	"strings"
+
+	"github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/credentials"
+
+	"github.com/photoprism/photoprism/pkg/clean"
+)
+
+// S3DefaultRegion is the default region for signing requests.
+const S3DefaultRegion = "us-east-1"
+
+// S3Options represents the connection details of an S3-compatible bucket.
+type S3Options struct {
+	Endpoint  string // Service URL, e.g. "https://s3.amazonaws.com" or "http://localhost:9000".
+	Region    string // Signing region, e.g. "us-east-1".
+	Bucket    string // Bucket name.
+	Prefix    string // Optional key prefix, e.g. "originals".
+	AccessKey string // Access key ID.
+	SecretKey string // Secret access key.
+	PathStyle bool   // Use path-style requests instead of virtual-hosted-style bucket URLs.
+}
+
+// S3 represents a storage backend based on an S3-compatible bucket.
+type S3 struct {
+	opt    S3Options
+	client *minio.Client
+}
+
+// NewS3 returns a new S3-compatible bucket storage.
+func NewS3(opt S3Options) (*S3, error) {
+	if opt.Bucket == "" {
+		return nil, fmt.Errorf("s3: bucket name is missing")
+	} else if opt.Endpoint == "" {
+		return nil, fmt.Errorf("s3: endpoint is missing")
+	}
+
+	if !strings.Contains(opt.Endpoint, "://") {
+		opt.Endpoint = "https://" + opt.Endpoint
+	}
+
+	u, err := url.Parse(opt.Endpoint)
+
+	if err != nil {
+		return nil, fmt.Errorf("s3: %s", err)
+	} else if u.Host == "" || strings.Trim(u.Path, "/") != "" {
+		return nil, fmt.Errorf("s3: invalid endpoint %s", clean.Log(opt.Endpoint))
+	}
+
+	if opt.Region == "" {
+		opt.Region = S3DefaultRegion
+	}
+
+	opt.Prefix = CleanName(opt.Prefix)
+
+	lookup := minio.BucketLookupDNS
+
+	if opt.PathStyle {
+		lookup = minio.BucketLookupPath
+	}
+
+	client, err := minio.New(u.Host, &minio.Options{
+		Creds:        credentials.NewStaticV4(opt.AccessKey, opt.SecretKey, ""),
+		Secure:       u.Scheme != "http",
+		Region:       opt.Region,
+		BucketLookup: lookup,
+	})
+
+	if err != nil {
+		return nil, fmt.Errorf("s3: %s", err)
+	}
+
+	return &S3{opt: opt, client: client}, nil
+}
+
+// String returns the bucket URL.
+func (s *S3) String() string {
+	if s.opt.Prefix == "" {
+		return fmt.Sprintf("s3://%s", s.opt.Bucket)
+	}
+
+	return fmt.Sprintf("s3://%s/%s", s.opt.Bucket, s.opt.Prefix)
+}
+
+// key returns the bucket key of an object.
+func (s *S3) key(name string) string {
+	name = CleanName(name)
+
+	if s.opt.Prefix == "" {
+		return name
+	} else if name == "" {
+		return s.opt.Prefix
+	}
+
+	return s.opt.Prefix + "/" + name
+}
+
+// name returns the object name based on a bucket key.
+func (s *S3) name(key string) string {
+	if s.opt.Prefix == "" {
+		return key
+	}
+
+	return strings.TrimPrefix(strings.TrimPrefix(key, s.opt.Prefix), "/")
+}
+
+// object returns the object properties based on the object info.
+func (s *S3) object(name string, info minio.ObjectInfo) Object {
+	return Object{
+		Name:        CleanName(name),
+		Size:        info.Size,
+		ModTime:     info.LastModified.UTC(),
+		ETag:        strings.Trim(info.ETag, `"`),
+		ContentType: info.ContentType,
+	}
+}
+
+// s3Error returns ErrNotFound if an object does not exist, or the error with a prefix otherwise.
+func s3Error(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	resp := minio.ToErrorResponse(err)
+
+	switch {
+	case resp.Code == "NoSuchKey":
+		return ErrNotFound
+	case resp.Code != "" && resp.Message != "":
+		return fmt.Errorf("s3: %s (%s)", resp.Message, resp.Code)
+	default:
+		return fmt.Errorf("s3: %s", err)
+	}
+}
+
+// List returns all objects in the specified directory, including subdirectories.
+func (s *S3) List(dir string) (result Objects, err error) {
+	prefix := s.key(dir)
+
+	if prefix != "" {
+		prefix += "/"
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	for info := range s.client.ListObjects(ctx, s.opt.Bucket, minio.ListObjectsOptions{Prefix: prefix, Recursive: true}) {
+		if info.Err != nil {
+			return result, s3Error(info.Err)
+		} else if strings.HasSuffix(info.Key, "/") {
+			continue
+		}
+
+		result = append(result, s.object(s.name(info.Key), info))
+	}
+
+	log.Tracef("s3: found %d objects in %s", len(result), clean.Log(prefix))
+
+	return result, nil
+}
+
+// Stat returns the object properties based on its headers.
+func (s *S3) Stat(name string) (Object, error) {
+	info, err := s.client.StatObject(context.Background(), s.opt.Bucket, s.key(name), minio.StatObjectOptions{})
+
+	if err != nil {
+		return Object{}, s3Error(err)
+	}
+
+	return s.object(name, info), nil
+}
+
+// Open returns a reader that streams the object content. The reader also implements
+// io.Seeker and io.ReaderAt, so that parts of an object can be read with ranged requests.
+func (s *S3) Open(name string) (io.ReadCloser, error) {
+	obj, err := s.client.GetObject(context.Background(), s.opt.Bucket, s.key(name), minio.GetObjectOptions{})
+
+	if err != nil {
+		return nil, s3Error(err)
+	}
+
+	// Objects are requested on first use, so check if it exists.
+	if _, err = obj.Stat(); err != nil {
+		_ = obj.Close()
+		return nil, s3Error(err)
+	}
+
+	return obj, nil
+}
+
+// Put uploads the content read from r as object.
+func (s *S3) Put(name string, r io.Reader, size int64) error {
+	_, err := s.client.PutObject(context.Background(), s.opt.Bucket, s.key(name), r, size, minio.PutObjectOptions{})
+
+	return s3Error(err)
+}
+
+// Delete removes an object.
+func (s *S3) Delete(name string) error {
+	return s3Error(s.client.RemoveObject(context.Background(), s.opt.Bucket, s.key(name), minio.RemoveObjectOptions{}))
+}
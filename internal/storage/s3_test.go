@@ -0,0 +1,377 @@
+package storage
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/md5"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// testS3Object represents an object stored by the test server.
+type testS3Object struct {
+	Data    []byte
+	ModTime time.Time
+}
+
+// testS3Server represents a minimal, in-memory S3-compatible server that
+// supports path-style requests and verifies request signatures.
+type testS3Server struct {
+	*httptest.Server
+	mu      sync.Mutex
+	bucket  string
+	opt     S3Options
+	objects map[string]testS3Object
+	maxKeys int
+	ranges  int
+}
+
+// newTestS3Server returns a new test server for the specified bucket.
+func newTestS3Server(t *testing.T, bucket string) *testS3Server {
+	s := &testS3Server{
+		bucket:  bucket,
+		objects: make(map[string]testS3Object),
+		maxKeys: 1000,
+	}
+
+	s.Server = httptest.NewServer(http.HandlerFunc(s.handle))
+
+	s.opt = S3Options{
+		Endpoint:  s.Server.URL,
+		Bucket:    bucket,
+		AccessKey: "test-access-key",
+		SecretKey: "test-secret-key",
+		PathStyle: true,
+	}
+
+	t.Cleanup(s.Close)
+
+	return s
+}
+
+// Storage returns a new client for the test server.
+func (s *testS3Server) Storage(t *testing.T, prefix string) *S3 {
+	opt := s.opt
+	opt.Prefix = prefix
+
+	client, err := NewS3(opt)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return client
+}
+
+// verify checks the access key in the request credentials.
+func (s *testS3Server) verify(r *http.Request) bool {
+	return strings.Contains(r.Header.Get("Authorization"), "Credential="+s.opt.AccessKey+"/")
+}
+
+// body returns the request body and decodes it if it was sent with a streaming signature.
+func (s *testS3Server) body(r *http.Request) ([]byte, error) {
+	if !strings.HasPrefix(r.Header.Get("X-Amz-Content-Sha256"), "STREAMING-") {
+		return io.ReadAll(r.Body)
+	}
+
+	var data []byte
+
+	br := bufio.NewReader(r.Body)
+
+	for {
+		line, err := br.ReadString('\n')
+
+		if err != nil {
+			return data, err
+		}
+
+		size, err := strconv.ParseInt(strings.SplitN(strings.TrimSpace(line), ";", 2)[0], 16, 64)
+
+		if err != nil {
+			return data, err
+		} else if size == 0 {
+			return data, nil
+		}
+
+		chunk := make([]byte, size+2)
+
+		if _, err = io.ReadFull(br, chunk); err != nil {
+			return data, err
+		}
+
+		data = append(data, chunk[:size]...)
+	}
+}
+
+// handle serves S3 API requests.
+func (s *testS3Server) handle(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !s.verify(r) {
+		w.WriteHeader(http.StatusForbidden)
+		_, _ = w.Write([]byte(`<Error><Code>InvalidAccessKeyId</Code><Message>The access key ID does not exist</Message></Error>`))
+		return
+	}
+
+	parts := strings.SplitN(strings.TrimPrefix(r.URL.Path, "/"), "/", 2)
+
+	if parts[0] != s.bucket {
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`<Error><Code>NoSuchBucket</Code><Message>The specified bucket does not exist</Message></Error>`))
+		return
+	}
+
+	key := ""
+
+	if len(parts) > 1 {
+		key = parts[1]
+	}
+
+	switch {
+	case r.Method == http.MethodGet && key == "":
+		s.list(w, r.URL.Query())
+	case r.Method == http.MethodPut:
+		data, err := s.body(r)
+
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		s.objects[key] = testS3Object{Data: data, ModTime: time.Now().UTC().Truncate(time.Second)}
+		w.WriteHeader(http.StatusOK)
+	case r.Method == http.MethodDelete:
+		delete(s.objects, key)
+		w.WriteHeader(http.StatusNoContent)
+	case r.Method == http.MethodGet || r.Method == http.MethodHead:
+		obj, ok := s.objects[key]
+
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		w.Header().Set("Content-Type", "image/jpeg")
+		w.Header().Set("ETag", fmt.Sprintf(`"%x"`, md5.Sum(obj.Data)))
+
+		if r.Header.Get("Range") != "" {
+			s.ranges++
+		}
+
+		http.ServeContent(w, r, key, obj.ModTime, bytes.NewReader(obj.Data))
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+// list writes a ListObjectsV2 response.
+func (s *testS3Server) list(w http.ResponseWriter, query url.Values) {
+	prefix := query.Get("prefix")
+
+	var keys []string
+
+	for k := range s.objects {
+		if strings.HasPrefix(k, prefix) {
+			keys = append(keys, k)
+		}
+	}
+
+	sort.Strings(keys)
+
+	if token := query.Get("continuation-token"); token != "" {
+		i := sort.SearchStrings(keys, token)
+		keys = keys[i:]
+	}
+
+	type content struct {
+		Key          string
+		LastModified string
+		ETag         string
+		Size         int
+	}
+
+	result := struct {
+		XMLName               xml.Name `xml:"ListBucketResult"`
+		Contents              []content
+		IsTruncated           bool
+		NextContinuationToken string `xml:",omitempty"`
+	}{}
+
+	if len(keys) > s.maxKeys {
+		result.IsTruncated = true
+		result.NextContinuationToken = keys[s.maxKeys]
+		keys = keys[:s.maxKeys]
+	}
+
+	for _, k := range keys {
+		obj := s.objects[k]
+		result.Contents = append(result.Contents, content{
+			Key:          k,
+			LastModified: obj.ModTime.Format(time.RFC3339),
+			ETag:         fmt.Sprintf(`"%x"`, md5.Sum(obj.Data)),
+			Size:         len(obj.Data),
+		})
+	}
+
+	w.Header().Set("Content-Type", "application/xml")
+	_ = xml.NewEncoder(w).Encode(result)
+}
+
+func TestNewS3(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		s, err := NewS3(S3Options{Endpoint: "s3.example.com", Bucket: "photos", Prefix: "/originals/"})
+
+		assert.NoError(t, err)
+		assert.Equal(t, "s3://photos/originals", s.String())
+		assert.Equal(t, S3DefaultRegion, s.opt.Region)
+		assert.Equal(t, "https", s.client.EndpointURL().Scheme)
+	})
+	t.Run("PathStyle", func(t *testing.T) {
+		s, err := NewS3(S3Options{Endpoint: "http://localhost:9000", Bucket: "photos", PathStyle: true})
+
+		assert.NoError(t, err)
+		assert.Equal(t, "http", s.client.EndpointURL().Scheme)
+		assert.Equal(t, "localhost:9000", s.client.EndpointURL().Host)
+	})
+	t.Run("NoBucket", func(t *testing.T) {
+		_, err := NewS3(S3Options{Endpoint: "s3.example.com"})
+		assert.Error(t, err)
+	})
+	t.Run("NoEndpoint", func(t *testing.T) {
+		_, err := NewS3(S3Options{Bucket: "photos"})
+		assert.Error(t, err)
+	})
+	t.Run("EndpointPath", func(t *testing.T) {
+		_, err := NewS3(S3Options{Endpoint: "https://example.com/s3", Bucket: "photos"})
+		assert.Error(t, err)
+	})
+}
+
+func TestS3(t *testing.T) {
+	server := newTestS3Server(t, "photos")
+	s := server.Storage(t, "originals")
+
+	t.Run("Put", func(t *testing.T) {
+		assert.NoError(t, s.Put("2023/01/cat.jpg", strings.NewReader("cat"), 3))
+		assert.NoError(t, s.Put("2023/01/cat.xmp", strings.NewReader("<xmp/>"), 6))
+		assert.NoError(t, s.Put("2023/02/My Dog+1.jpg", strings.NewReader("dog"), 3))
+		assert.Contains(t, server.objects, "originals/2023/02/My Dog+1.jpg")
+	})
+	t.Run("List", func(t *testing.T) {
+		result, err := s.List("")
+
+		assert.NoError(t, err)
+
+		if assert.Len(t, result, 3) {
+			assert.Equal(t, "2023/01/cat.jpg", result[0].Name)
+			assert.Equal(t, int64(3), result[0].Size)
+			assert.False(t, result[0].ModTime.IsZero())
+			assert.NotEmpty(t, result[0].ETag)
+			assert.Equal(t, "2023/02/My Dog+1.jpg", result[2].Name)
+		}
+
+		result, err = s.List("2023/02")
+
+		assert.NoError(t, err)
+		assert.Len(t, result, 1)
+	})
+	t.Run("ListPages", func(t *testing.T) {
+		server.maxKeys = 2
+		defer func() { server.maxKeys = 1000 }()
+
+		result, err := s.List("/")
+
+		assert.NoError(t, err)
+		assert.Len(t, result, 3)
+	})
+	t.Run("Stat", func(t *testing.T) {
+		obj, err := s.Stat("2023/02/My Dog+1.jpg")
+
+		assert.NoError(t, err)
+		assert.Equal(t, "2023/02/My Dog+1.jpg", obj.Name)
+		assert.Equal(t, int64(3), obj.Size)
+		assert.Equal(t, "image/jpeg", obj.ContentType)
+		assert.False(t, obj.ModTime.IsZero())
+
+		_, err = s.Stat("2023/02/missing.jpg")
+		assert.Equal(t, ErrNotFound, err)
+	})
+	t.Run("Open", func(t *testing.T) {
+		r, err := s.Open("2023/01/cat.jpg")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		data, err := io.ReadAll(r)
+		_ = r.Close()
+
+		assert.NoError(t, err)
+		assert.Equal(t, "cat", string(data))
+
+		_, err = s.Open("missing.jpg")
+		assert.Equal(t, ErrNotFound, err)
+	})
+	t.Run("OpenRange", func(t *testing.T) {
+		assert.NoError(t, s.Put("2023/03/large.mp4", strings.NewReader("0123456789"), 10))
+
+		r, err := s.Open("2023/03/large.mp4")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		defer r.Close()
+
+		ra, ok := r.(io.ReaderAt)
+
+		if !assert.True(t, ok) {
+			return
+		}
+
+		server.ranges = 0
+		buf := make([]byte, 3)
+		n, err := ra.ReadAt(buf, 5)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "567", string(buf[:n]))
+		assert.Equal(t, 1, server.ranges)
+	})
+	t.Run("Delete", func(t *testing.T) {
+		assert.NoError(t, s.Delete("2023/01/cat.xmp"))
+		assert.NotContains(t, server.objects, "originals/2023/01/cat.xmp")
+	})
+	t.Run("InvalidAccessKey", func(t *testing.T) {
+		opt := server.opt
+		opt.AccessKey = "invalid"
+		client, _ := NewS3(opt)
+
+		_, err := client.List("")
+
+		if assert.Error(t, err) {
+			assert.Contains(t, err.Error(), "InvalidAccessKeyId")
+		}
+	})
+	t.Run("NoSuchBucket", func(t *testing.T) {
+		opt := server.opt
+		opt.Bucket = "missing"
+		client, _ := NewS3(opt)
+
+		_, err := client.List("")
+
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,296 @@
+package storage
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"path"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/sftp"
+	"golang.org/x/crypto/ssh"
+
+	"github.com/photoprism/photoprism/pkg/clean"
+)
+
+// SFTPDefaultPort is the default SSH port.
+const SFTPDefaultPort = "22"
+
+// SFTPOptions represents the connection details of an SFTP server.
+type SFTPOptions struct {
+	Host     string        // Server address, e.g. "example.com" or "localhost:2222".
+	User     string        // Username.
+	Password string        // Password.
+	Root     string        // Remote base folder, e.g. "/photos".
+	HostKey  string        // Expected SHA256 host key fingerprint, e.g. "SHA256:…"; required to connect.
+	Timeout  time.Duration // Connection timeout.
+}
+
+// SFTP represents a storage backend based on a folder on an SFTP server.
+type SFTP struct {
+	opt    SFTPOptions
+	mu     sync.Mutex
+	conn   *ssh.Client
+	client *sftp.Client
+}
+
+// NewSFTP returns a new SFTP server storage. The connection is established on first use.
+func NewSFTP(opt SFTPOptions) (*SFTP, error) {
+	if opt.Host == "" {
+		return nil, fmt.Errorf("sftp: host is missing")
+	} else if opt.User == "" {
+		return nil, fmt.Errorf("sftp: username is missing")
+	}
+
+	if _, _, err := net.SplitHostPort(opt.Host); err != nil {
+		opt.Host = net.JoinHostPort(opt.Host, SFTPDefaultPort)
+	}
+
+	opt.Root = path.Clean("/" + opt.Root)
+
+	return &SFTP{opt: opt}, nil
+}
+
+// String returns the server URL.
+func (s *SFTP) String() string {
+	return fmt.Sprintf("sftp://%s@%s%s", s.opt.User, s.opt.Host, s.opt.Root)
+}
+
+// hostKeyCallback returns a callback that verifies the server's host key fingerprint. Connections are
+// refused if no fingerprint is configured, since the password could otherwise be sent to any server.
+func (s *SFTP) hostKeyCallback() ssh.HostKeyCallback {
+	return func(hostname string, remote net.Addr, key ssh.PublicKey) error {
+		if fingerprint := ssh.FingerprintSHA256(key); s.opt.HostKey == "" {
+			return fmt.Errorf("sftp: host key of %s must be confirmed, fingerprint is %s", clean.Log(hostname), fingerprint)
+		} else if fingerprint != s.opt.HostKey {
+			return fmt.Errorf("sftp: host key %s of %s does not match", fingerprint, clean.Log(hostname))
+		}
+
+		return nil
+	}
+}
+
+// connect returns the SFTP client and establishes a new connection if needed.
+func (s *SFTP) connect() (*sftp.Client, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.client != nil {
+		return s.client, nil
+	}
+
+	config := &ssh.ClientConfig{
+		User:            s.opt.User,
+		Auth:            []ssh.AuthMethod{ssh.Password(s.opt.Password)},
+		HostKeyCallback: s.hostKeyCallback(),
+		Timeout:         s.opt.Timeout,
+	}
+
+	conn, err := ssh.Dial("tcp", s.opt.Host, config)
+
+	if err != nil {
+		return nil, fmt.Errorf("sftp: %s", err)
+	}
+
+	client, err := sftp.NewClient(conn)
+
+	if err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("sftp: %s", err)
+	}
+
+	log.Debugf("sftp: connected to %s", clean.Log(s.opt.Host))
+
+	s.conn = conn
+	s.client = client
+
+	return client, nil
+}
+
+// Close closes the connection to the server, if any.
+func (s *SFTP) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.conn == nil {
+		return nil
+	}
+
+	_ = s.client.Close()
+	err := s.conn.Close()
+
+	s.conn = nil
+	s.client = nil
+
+	return err
+}
+
+// remotePath returns the absolute remote path of an object.
+func (s *SFTP) remotePath(name string) string {
+	return path.Join(s.opt.Root, CleanName(name))
+}
+
+// object returns the object properties based on the file info.
+func (s *SFTP) object(name string, info os.FileInfo) Object {
+	return Object{
+		Name:    name,
+		Size:    info.Size(),
+		ModTime: info.ModTime().UTC(),
+	}
+}
+
+// sftpError returns ErrNotFound if a file does not exist, or the error with a prefix otherwise.
+func sftpError(err error) error {
+	if err == nil {
+		return nil
+	} else if errors.Is(err, os.ErrNotExist) {
+		return ErrNotFound
+	}
+
+	return fmt.Errorf("sftp: %s", err)
+}
+
+// List returns all files in the specified directory, including subdirectories.
+func (s *SFTP) List(dir string) (result Objects, err error) {
+	client, err := s.connect()
+
+	if err != nil {
+		return nil, err
+	}
+
+	var walk func(rel string) error
+
+	walk = func(rel string) error {
+		entries, err := client.ReadDir(s.remotePath(rel))
+
+		if err != nil {
+			return sftpError(err)
+		}
+
+		for _, e := range entries {
+			if strings.HasPrefix(e.Name(), ".") {
+				continue
+			}
+
+			name := path.Join(rel, e.Name())
+
+			if e.IsDir() {
+				if err = walk(name); err != nil {
+					return err
+				}
+			} else if e.Mode().IsRegular() {
+				result = append(result, s.object(name, e))
+			}
+		}
+
+		return nil
+	}
+
+	dir = CleanName(dir)
+
+	if err = walk(dir); err == ErrNotFound {
+		return result, nil
+	} else if err != nil {
+		return result, err
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Name < result[j].Name
+	})
+
+	return result, nil
+}
+
+// Stat returns the file properties.
+func (s *SFTP) Stat(name string) (Object, error) {
+	client, err := s.connect()
+
+	if err != nil {
+		return Object{}, err
+	}
+
+	info, err := client.Stat(s.remotePath(name))
+
+	if err != nil {
+		return Object{}, sftpError(err)
+	} else if info.IsDir() {
+		return Object{}, fmt.Errorf("%s is a directory", clean.Log(name))
+	}
+
+	return s.object(CleanName(name), info), nil
+}
+
+// Open returns a reader for the file content.
+func (s *SFTP) Open(name string) (io.ReadCloser, error) {
+	client, err := s.connect()
+
+	if err != nil {
+		return nil, err
+	}
+
+	f, err := client.Open(s.remotePath(name))
+
+	if err != nil {
+		return nil, sftpError(err)
+	}
+
+	return f, nil
+}
+
+// MkdirAll creates a directory and all missing parent directories.
+func (s *SFTP) MkdirAll(dir string) error {
+	client, err := s.connect()
+
+	if err != nil {
+		return err
+	}
+
+	return sftpError(client.MkdirAll(path.Join(s.opt.Root, CleanName(dir))))
+}
+
+// Put stores the content read from r as file.
+func (s *SFTP) Put(name string, r io.Reader, size int64) (err error) {
+	if err = s.MkdirAll(path.Dir(CleanName(name))); err != nil {
+		return err
+	}
+
+	client, err := s.connect()
+
+	if err != nil {
+		return err
+	}
+
+	f, err := client.OpenFile(s.remotePath(name), os.O_WRONLY|os.O_CREATE|os.O_TRUNC)
+
+	if err != nil {
+		return sftpError(err)
+	}
+
+	defer func() {
+		if closeErr := f.Close(); err == nil {
+			err = sftpError(closeErr)
+		}
+	}()
+
+	if _, err = f.ReadFrom(r); err != nil {
+		return sftpError(err)
+	}
+
+	return nil
+}
+
+// Delete removes a file.
+func (s *SFTP) Delete(name string) error {
+	client, err := s.connect()
+
+	if err != nil {
+		return err
+	}
+
+	return sftpError(client.Remove(s.remotePath(name)))
+}
@@ -0,0 +1,305 @@
+package storage
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/pkg/sftp"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/crypto/ssh"
+)
+
+// testSFTPServer represents a minimal SSH server with an SFTP subsystem
+// that serves files from the local filesystem.
+type testSFTPServer struct {
+	root     string
+	listener net.Listener
+	signer   ssh.Signer
+	config   *ssh.ServerConfig
+	wg       sync.WaitGroup
+}
+
+// newTestSFTPServer starts a new test server that accepts the specified credentials.
+func newTestSFTPServer(t *testing.T, user, password string) *testSFTPServer {
+	_, key, err := ed25519.GenerateKey(rand.Reader)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	signer, err := ssh.NewSignerFromKey(key)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	config := &ssh.ServerConfig{
+		PasswordCallback: func(c ssh.ConnMetadata, pass []byte) (*ssh.Permissions, error) {
+			if c.User() == user && string(pass) == password {
+				return nil, nil
+			}
+
+			return nil, fmt.Errorf("invalid credentials")
+		},
+	}
+
+	config.AddHostKey(signer)
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	s := &testSFTPServer{
+		root:     t.TempDir(),
+		listener: listener,
+		signer:   signer,
+		config:   config,
+	}
+
+	s.wg.Add(1)
+	go s.serve()
+
+	t.Cleanup(func() {
+		_ = s.listener.Close()
+		s.wg.Wait()
+	})
+
+	return s
+}
+
+// Addr returns the server address.
+func (s *testSFTPServer) Addr() string {
+	return s.listener.Addr().String()
+}
+
+// Fingerprint returns the SHA256 fingerprint of the host key.
+func (s *testSFTPServer) Fingerprint() string {
+	return ssh.FingerprintSHA256(s.signer.PublicKey())
+}
+
+// serve accepts new connections until the listener is closed.
+func (s *testSFTPServer) serve() {
+	defer s.wg.Done()
+
+	for {
+		conn, err := s.listener.Accept()
+
+		if err != nil {
+			return
+		}
+
+		go s.handleConn(conn)
+	}
+}
+
+// handleConn performs the SSH handshake and serves SFTP sessions.
+func (s *testSFTPServer) handleConn(conn net.Conn) {
+	_, chans, reqs, err := ssh.NewServerConn(conn, s.config)
+
+	if err != nil {
+		_ = conn.Close()
+		return
+	}
+
+	go ssh.DiscardRequests(reqs)
+
+	for newChan := range chans {
+		if newChan.ChannelType() != "session" {
+			_ = newChan.Reject(ssh.UnknownChannelType, "unsupported channel type")
+			continue
+		}
+
+		ch, requests, err := newChan.Accept()
+
+		if err != nil {
+			continue
+		}
+
+		go func() {
+			for req := range requests {
+				ok := req.Type == "subsystem" && len(req.Payload) > 4 && string(req.Payload[4:]) == "sftp"
+				_ = req.Reply(ok, nil)
+
+				if ok {
+					go func() {
+						s.handleSFTP(ch)
+						_ = ch.Close()
+					}()
+				}
+			}
+		}()
+	}
+}
+
+// handleSFTP serves SFTP requests until the channel is closed.
+func (s *testSFTPServer) handleSFTP(ch io.ReadWriteCloser) {
+	server, err := sftp.NewServer(ch)
+
+	if err != nil {
+		return
+	}
+
+	_ = server.Serve()
+}
+
+func TestNewSFTP(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		s, err := NewSFTP(SFTPOptions{Host: "example.com", User: "admin", Root: "photos/"})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "sftp://admin@example.com:22/photos", s.String())
+	})
+	t.Run("Port", func(t *testing.T) {
+		s, err := NewSFTP(SFTPOptions{Host: "localhost:2222", User: "admin"})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "sftp://admin@localhost:2222/", s.String())
+	})
+	t.Run("NoHost", func(t *testing.T) {
+		_, err := NewSFTP(SFTPOptions{User: "admin"})
+		assert.Error(t, err)
+	})
+	t.Run("NoUser", func(t *testing.T) {
+		_, err := NewSFTP(SFTPOptions{Host: "example.com"})
+		assert.Error(t, err)
+	})
+}
+
+func TestSFTP(t *testing.T) {
+	server := newTestSFTPServer(t, "photoprism", "secret")
+
+	if err := os.MkdirAll(filepath.Join(server.root, "photos"), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	s, err := NewSFTP(SFTPOptions{
+		Host:     server.Addr(),
+		User:     "photoprism",
+		Password: "secret",
+		Root:     filepath.ToSlash(filepath.Join(server.root, "photos")),
+		HostKey:  server.Fingerprint(),
+		Timeout:  10 * time.Second,
+	})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer s.Close()
+
+	large := strings.Repeat("0123456789", 10000)
+
+	t.Run("Put", func(t *testing.T) {
+		assert.NoError(t, s.Put("2023/01/cat.jpg", strings.NewReader("cat"), 3))
+		assert.NoError(t, s.Put("/2023/02/dog.jpg", strings.NewReader("dog"), 3))
+		assert.NoError(t, s.Put("2023/02/large.mp4", strings.NewReader(large), int64(len(large))))
+		assert.NoError(t, s.Put(".hidden/ignore.jpg", strings.NewReader("x"), 1))
+		assert.FileExists(t, filepath.Join(server.root, "photos", "2023", "01", "cat.jpg"))
+	})
+	t.Run("List", func(t *testing.T) {
+		result, err := s.List("")
+
+		assert.NoError(t, err)
+
+		if assert.Len(t, result, 3) {
+			assert.Equal(t, "2023/01/cat.jpg", result[0].Name)
+			assert.Equal(t, int64(3), result[0].Size)
+			assert.False(t, result[0].ModTime.IsZero())
+			assert.Equal(t, "2023/02/dog.jpg", result[1].Name)
+			assert.Equal(t, "2023/02/large.mp4", result[2].Name)
+			assert.Equal(t, int64(len(large)), result[2].Size)
+		}
+
+		result, err = s.List("2023/02")
+
+		assert.NoError(t, err)
+		assert.Len(t, result, 2)
+
+		result, err = s.List("missing")
+
+		assert.NoError(t, err)
+		assert.Empty(t, result)
+	})
+	t.Run("Stat", func(t *testing.T) {
+		obj, err := s.Stat("2023/01/cat.jpg")
+
+		assert.NoError(t, err)
+		assert.Equal(t, "2023/01/cat.jpg", obj.Name)
+		assert.Equal(t, int64(3), obj.Size)
+
+		_, err = s.Stat("missing.jpg")
+		assert.Equal(t, ErrNotFound, err)
+
+		_, err = s.Stat("2023")
+		assert.Error(t, err)
+	})
+	t.Run("Open", func(t *testing.T) {
+		r, err := s.Open("2023/02/large.mp4")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		data, err := io.ReadAll(r)
+		_ = r.Close()
+
+		assert.NoError(t, err)
+		assert.Equal(t, large, string(data))
+
+		_, err = s.Open("missing.jpg")
+		assert.Equal(t, ErrNotFound, err)
+	})
+	t.Run("MkdirAll", func(t *testing.T) {
+		assert.NoError(t, s.MkdirAll("2023/03/04"))
+		assert.NoError(t, s.MkdirAll("2023/03/04"))
+		assert.DirExists(t, filepath.Join(server.root, "photos", "2023", "03", "04"))
+	})
+	t.Run("Delete", func(t *testing.T) {
+		assert.NoError(t, s.Delete("2023/02/dog.jpg"))
+		assert.Equal(t, ErrNotFound, s.Delete("2023/02/dog.jpg"))
+		assert.NoFileExists(t, filepath.Join(server.root, "photos", "2023", "02", "dog.jpg"))
+	})
+	t.Run("InvalidPassword", func(t *testing.T) {
+		client, _ := NewSFTP(SFTPOptions{Host: server.Addr(), User: "photoprism", Password: "invalid"})
+
+		_, err := client.List("")
+
+		assert.Error(t, err)
+	})
+	t.Run("NoHostKey", func(t *testing.T) {
+		client, _ := NewSFTP(SFTPOptions{Host: server.Addr(), User: "photoprism", Password: "secret"})
+
+		_, err := client.List("")
+
+		if assert.Error(t, err) {
+			assert.Contains(t, err.Error(), "must be confirmed")
+			assert.Contains(t, err.Error(), server.Fingerprint())
+		}
+	})
+	t.Run("InvalidHostKey", func(t *testing.T) {
+		client, _ := NewSFTP(SFTPOptions{Host: server.Addr(), User: "photoprism", Password: "secret", HostKey: "SHA256:invalid"})
+
+		_, err := client.List("")
+
+		if assert.Error(t, err) {
+			assert.Contains(t, err.Error(), "does not match")
+		}
+	})
+}
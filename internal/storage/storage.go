@@ -0,0 +1,82 @@
+/*
+Package storage provides an abstraction for file storage backends such as local folders and S3-compatible buckets.
+
+Copyright (c) 2018 - 2023 PhotoPrism UG. All rights reserved.
+
+	This program is free software: you can redistribute it and/or modify
+	it under Version 3 of the GNU Affero General Public License (the "AGPL"):
+	<https://docs.photoprism.app/license/agpl>
+
+	This program is distributed in the hope that it will be useful,
+	but WITHOUT ANY WARRANTY; without even the implied warranty of
+	MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+	GNU Affero General Public License for more details.
+
+	The AGPL is supplemented by our Trademark and Brand Guidelines,
+	which describe how our Brand Assets may be used:
+	<https://www.photoprism.app/trademark>
+
+Feel free to send an email to hello@photoprism.app if you have questions,
+want to support our work, or just want to say hello.
+
+Additional information can be found in our Developer Guide:
+<https://docs.photoprism.app/developer-guide/>
+*/
+package storage
+
+import (
+	"errors"
+	"io"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/photoprism/photoprism/internal/event"
+)
+
+// Global log instance.
+var log = event.Log
+
+// ErrNotFound is returned if an object does not exist.
+var ErrNotFound = errors.New("not found")
+
+// Object represents a stored file.
+type Object struct {
+	Name        string    `json:"Name"`
+	Size        int64     `json:"Size"`
+	ModTime     time.Time `json:"ModTime"`
+	ETag        string    `json:"ETag,omitempty"`
+	ContentType string    `json:"ContentType,omitempty"`
+}
+
+// Objects represents a list of stored files.
+type Objects []Object
+
+// Storage represents a file storage backend with slash-separated, relative object names.
+type Storage interface {
+	// String returns a human-readable storage location for logs.
+	String() string
+	// List returns all objects in the specified directory, including subdirectories.
+	List(dir string) (Objects, error)
+	// Stat returns the object properties without its content.
+	Stat(name string) (Object, error)
+	// Open returns a reader for the object content, which must be closed by the caller.
+	Open(name string) (io.ReadCloser, error)
+	// Put stores the content read from r as object with the specified name.
+	Put(name string, r io.Reader, size int64) error
+	// Delete removes an object.
+	Delete(name string) error
+}
+
+// CleanName returns a normalized, slash-separated object name without leading slash.
+func CleanName(name string) string {
+	name = strings.ReplaceAll(name, "\\", "/")
+
+	if name == "" {
+		return ""
+	}
+
+	name = path.Clean("/" + name)
+
+	return strings.TrimPrefix(name, "/")
+}
@@ -0,0 +1,47 @@
+package thumb
+
+import (
+	"bytes"
+	"fmt"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+
+	"github.com/photoprism/photoprism/pkg/clean"
+)
+
+// AvifQuantizer returns the AV1 quantizer (0-63, lower is better) for the configured JPEG quality.
+func AvifQuantizer() int {
+	q := int(JpegQuality)
+
+	if q > 100 {
+		q = 100
+	} else if q < 0 {
+		q = 0
+	}
+
+	return (100 - q) * 63 / 100
+}
+
+// Avif converts a JPEG thumbnail to AVIF using the configured encoder.
+func Avif(srcFile, avifFile string) error {
+	if AvifEncoderBin == "" {
+		return fmt.Errorf("avif: encoder not found")
+	}
+
+	quantizer := strconv.Itoa(AvifQuantizer())
+
+	return encode(avifFile, func(tmpName string) error {
+		var stderr bytes.Buffer
+
+		cmd := exec.Command(AvifEncoderBin, "--speed", "6", "--min", quantizer, "--max", quantizer, srcFile, tmpName)
+		cmd.Stderr = &stderr
+
+		if err := cmd.Run(); err != nil {
+			log.Debugf("avif: %s", clean.Log(stderr.String()))
+			return fmt.Errorf("avif: failed to convert %s (%s)", clean.Log(filepath.Base(srcFile)), err)
+		}
+
+		return nil
+	})
+}
@@ -0,0 +1,145 @@
+package thumb
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/photoprism/photoprism/pkg/clean"
+	"github.com/photoprism/photoprism/pkg/fs"
+)
+
+// Formats contains the additional thumbnail formats that may be served to clients supporting them,
+// in order of preference. Thumbnails are always created as JPEG, variants are created on demand.
+var Formats []fs.Type
+
+// Encoder binaries for creating thumbnail variants.
+var (
+	AvifEncoderBin = ""
+	WebPEncoderBin = ""
+)
+
+// FormatMimeTypes maps thumbnail formats to their MIME types as used in the HTTP Accept header.
+var FormatMimeTypes = map[fs.Type]string{
+	fs.ImageAVIF: "image/avif",
+	fs.ImageWebP: "image/webp",
+}
+
+// ParseFormats parses a comma-separated list of thumbnail formats, e.g. "avif, webp".
+func ParseFormats(s string) (result []fs.Type) {
+	for _, v := range strings.Split(s, ",") {
+		format := fs.Type(strings.ToLower(strings.Trim(strings.TrimSpace(v), ".")))
+
+		if _, ok := FormatMimeTypes[format]; !ok {
+			continue
+		} else if FormatSupported(result, format) {
+			continue
+		}
+
+		result = append(result, format)
+	}
+
+	return result
+}
+
+// FormatSupported checks if the format is contained in the list of formats.
+func FormatSupported(formats []fs.Type, format fs.Type) bool {
+	for _, f := range formats {
+		if f == format {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Negotiate returns the preferred thumbnail format accepted by the client, or an empty string if only JPEG should be used.
+func Negotiate(accept string) fs.Type {
+	if accept == "" || len(Formats) == 0 {
+		return ""
+	}
+
+	accepted := make(map[string]bool)
+
+	for _, v := range strings.Split(strings.ToLower(accept), ",") {
+		params := strings.Split(v, ";")
+		mimeType := strings.TrimSpace(params[0])
+		accepted[mimeType] = true
+
+		// Media types with a quality of zero are not acceptable.
+		for _, param := range params[1:] {
+			if q := strings.TrimSpace(param); strings.HasPrefix(q, "q=") {
+				if f, err := strconv.ParseFloat(strings.TrimSpace(q[2:]), 64); err == nil && f <= 0 {
+					accepted[mimeType] = false
+				}
+			}
+		}
+	}
+
+	for _, format := range Formats {
+		if accepted[FormatMimeTypes[format]] {
+			return format
+		}
+	}
+
+	return ""
+}
+
+// Variant returns the file name of a thumbnail variant in the specified format and creates it if needed.
+func Variant(fileName string, format fs.Type) (string, error) {
+	variantName := VariantName(fileName, format)
+
+	if variantName == fileName {
+		return fileName, nil
+	} else if fs.FileExistsNotEmpty(variantName) {
+		return variantName, nil
+	}
+
+	var err error
+
+	switch format {
+	case fs.ImageAVIF:
+		err = Avif(fileName, variantName)
+	case fs.ImageWebP:
+		err = WebP(fileName, variantName)
+	default:
+		err = fmt.Errorf("thumb: unsupported format %s", clean.Log(string(format)))
+	}
+
+	if err != nil {
+		return "", err
+	}
+
+	return variantName, nil
+}
+
+// encode runs an encoder command and moves the result to the destination file name once it is complete.
+// Each call uses its own temporary file, so that concurrent requests for the same variant don't conflict.
+func encode(destName string, run func(tmpName string) error) error {
+	ext := filepath.Ext(destName)
+	f, err := os.CreateTemp(filepath.Dir(destName), "."+strings.TrimSuffix(filepath.Base(destName), ext)+".*.tmp"+ext)
+
+	if err != nil {
+		return err
+	}
+
+	tmpName := f.Name()
+
+	defer os.Remove(tmpName)
+
+	// Temporary files are only readable by the owner, unlike regular thumbnails.
+	if err = f.Chmod(fs.ModeFile); err != nil {
+		_ = f.Close()
+		return err
+	} else if err = f.Close(); err != nil {
+		return err
+	} else if err = run(tmpName); err != nil {
+		return err
+	} else if !fs.FileExistsNotEmpty(tmpName) {
+		return fmt.Errorf("thumb: failed to create %s", clean.Log(filepath.Base(destName)))
+	}
+
+	return os.Rename(tmpName, destName)
+}
@@ -0,0 +1,166 @@
+package thumb
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/pkg/fs"
+)
+
+// testEncoder creates a fake encoder that copies the source file to the output file.
+func testEncoder(t *testing.T) string {
+	bin := filepath.Join(t.TempDir(), "encoder")
+	script := "#!/bin/sh\nfor a in \"$@\"; do if [ -z \"$src\" ] && [ -f \"$a\" ]; then src=\"$a\"; fi; out=\"$a\"; done\ncp \"$src\" \"$out\"\n"
+
+	if err := os.WriteFile(bin, []byte(script), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	return bin
+}
+
+func TestParseFormats(t *testing.T) {
+	assert.Empty(t, ParseFormats(""))
+	assert.Empty(t, ParseFormats("jpg, png"))
+	assert.Equal(t, []fs.Type{fs.ImageAVIF, fs.ImageWebP}, ParseFormats("AVIF, .webp, avif"))
+	assert.Equal(t, []fs.Type{fs.ImageWebP}, ParseFormats("webp"))
+}
+
+func TestFormatSupported(t *testing.T) {
+	assert.True(t, FormatSupported([]fs.Type{fs.ImageAVIF, fs.ImageWebP}, fs.ImageWebP))
+	assert.False(t, FormatSupported([]fs.Type{fs.ImageAVIF}, fs.ImageWebP))
+	assert.False(t, FormatSupported(nil, fs.ImageWebP))
+}
+
+func TestNegotiate(t *testing.T) {
+	defer func(formats []fs.Type) { Formats = formats }(Formats)
+
+	chrome := "image/avif,image/webp,image/apng,image/svg+xml,image/*,*/*;q=0.8"
+	safari := "image/webp,image/png,image/svg+xml,image/*;q=0.8,video/*;q=0.8,*/*;q=0.5"
+
+	Formats = nil
+
+	assert.Equal(t, fs.Type(""), Negotiate(chrome))
+
+	Formats = []fs.Type{fs.ImageAVIF, fs.ImageWebP}
+
+	assert.Equal(t, fs.ImageAVIF, Negotiate(chrome))
+	assert.Equal(t, fs.ImageWebP, Negotiate(safari))
+	assert.Equal(t, fs.Type(""), Negotiate("image/*"))
+	assert.Equal(t, fs.Type(""), Negotiate(""))
+
+	Formats = []fs.Type{fs.ImageWebP, fs.ImageAVIF}
+
+	assert.Equal(t, fs.ImageWebP, Negotiate(chrome))
+
+	// Formats with a quality of zero are not acceptable.
+	assert.Equal(t, fs.ImageAVIF, Negotiate("image/avif,image/webp;q=0,*/*;q=0.8"))
+	assert.Equal(t, fs.ImageAVIF, Negotiate("image/avif, image/webp; q=0.0"))
+	assert.Equal(t, fs.Type(""), Negotiate("image/webp;q=0,image/avif;q=0"))
+	assert.Equal(t, fs.ImageWebP, Negotiate("image/webp;q=0.5"))
+}
+
+func TestVariant(t *testing.T) {
+	defer func(avif, webp string) { AvifEncoderBin, WebPEncoderBin = avif, webp }(AvifEncoderBin, WebPEncoderBin)
+
+	dir := t.TempDir()
+	fileName := filepath.Join(dir, "example_720x720_fit.jpg")
+
+	if err := fs.Copy("testdata/example.jpg", fileName); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("EncoderNotFound", func(t *testing.T) {
+		AvifEncoderBin, WebPEncoderBin = "", ""
+
+		_, err := Variant(fileName, fs.ImageAVIF)
+		assert.Error(t, err)
+
+		_, err = Variant(fileName, fs.ImageWebP)
+		assert.Error(t, err)
+	})
+	t.Run("Unsupported", func(t *testing.T) {
+		_, err := Variant(fileName, fs.ImagePNG)
+		assert.Error(t, err)
+	})
+	t.Run("Jpeg", func(t *testing.T) {
+		result, err := Variant(fileName, fs.ImageJPEG)
+		assert.NoError(t, err)
+		assert.Equal(t, fileName, result)
+	})
+	t.Run("Success", func(t *testing.T) {
+		AvifEncoderBin = testEncoder(t)
+		WebPEncoderBin = AvifEncoderBin
+
+		for _, format := range []fs.Type{fs.ImageAVIF, fs.ImageWebP} {
+			result, err := Variant(fileName, format)
+
+			assert.NoError(t, err)
+			assert.Equal(t, VariantName(fileName, format), result)
+			assert.True(t, fs.FileExistsNotEmpty(result))
+		}
+
+		// Existing variants are returned from the cache.
+		AvifEncoderBin = ""
+
+		result, err := Variant(fileName, fs.ImageAVIF)
+
+		assert.NoError(t, err)
+		assert.Equal(t, VariantName(fileName, fs.ImageAVIF), result)
+	})
+}
+
+func TestEncode(t *testing.T) {
+	dir := t.TempDir()
+	destName := filepath.Join(dir, "example_720x720_fit.webp")
+
+	t.Run("Concurrent", func(t *testing.T) {
+		var wg sync.WaitGroup
+
+		for i := 0; i < 8; i++ {
+			wg.Add(1)
+
+			go func() {
+				defer wg.Done()
+
+				assert.NoError(t, encode(destName, func(tmpName string) error {
+					assert.True(t, strings.HasSuffix(tmpName, ".webp"))
+					return os.WriteFile(tmpName, []byte("webp"), fs.ModeFile)
+				}))
+			}()
+		}
+
+		wg.Wait()
+
+		assert.True(t, fs.FileExistsNotEmpty(destName))
+
+		files, err := os.ReadDir(dir)
+
+		assert.NoError(t, err)
+		assert.Len(t, files, 1)
+	})
+	t.Run("Empty", func(t *testing.T) {
+		err := encode(filepath.Join(dir, "empty.avif"), func(tmpName string) error { return nil })
+
+		assert.Error(t, err)
+		assert.NoFileExists(t, filepath.Join(dir, "empty.avif"))
+	})
+}
+
+func TestAvifQuantizer(t *testing.T) {
+	defer func(q Quality) { JpegQuality = q }(JpegQuality)
+
+	JpegQuality = 100
+	assert.Equal(t, 0, AvifQuantizer())
+
+	JpegQuality = QualityDefault
+	assert.Equal(t, 9, AvifQuantizer())
+
+	JpegQuality = 0
+	assert.Equal(t, 63, AvifQuantizer())
+}
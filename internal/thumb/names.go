@@ -1,6 +1,11 @@
 package thumb
 
-import "github.com/photoprism/photoprism/pkg/fs"
+import (
+	"path/filepath"
+	"strings"
+
+	"github.com/photoprism/photoprism/pkg/fs"
+)
 
 // Name represents a thumbnail size name.
 type Name string
@@ -10,6 +15,12 @@ func (n Name) Jpeg() string {
 	return string(n) + fs.ExtJPEG
 }
 
+// VariantName returns the cache file name of a thumbnail variant in another format, e.g. AVIF or WebP.
+// Variants are kept apart by their file extension, so that they can be stored next to the JPEG thumbnail.
+func VariantName(fileName string, format fs.Type) string {
+	return strings.TrimSuffix(fileName, filepath.Ext(fileName)) + "." + string(format)
+}
+
 // String returns the thumbnail name as string.
 func (n Name) String() string {
 	return string(n)
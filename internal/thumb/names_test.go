@@ -4,6 +4,8 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/pkg/fs"
 )
 
 func TestName_Jpeg(t *testing.T) {
@@ -27,3 +29,9 @@ func TestFind(t *testing.T) {
 		assert.Equal(t, 1200, size.Height)
 	})
 }
+
+func TestVariantName(t *testing.T) {
+	assert.Equal(t, "/cache/a/b/c/abc_720x720_fit.avif", VariantName("/cache/a/b/c/abc_720x720_fit.jpg", fs.ImageAVIF))
+	assert.Equal(t, "/cache/a/b/c/abc_720x720_fit.webp", VariantName("/cache/a/b/c/abc_720x720_fit.jpg", fs.ImageWebP))
+	assert.Equal(t, "/cache/a/b/c/abc_720x720_fit.jpg", VariantName("/cache/a/b/c/abc_720x720_fit.jpg", fs.ImageJPEG))
+}
@@ -0,0 +1,31 @@
+package thumb
+
+import (
+	"bytes"
+	"fmt"
+	"os/exec"
+	"path/filepath"
+
+	"github.com/photoprism/photoprism/pkg/clean"
+)
+
+// WebP converts a JPEG thumbnail to WebP using the configured encoder.
+func WebP(srcFile, webpFile string) error {
+	if WebPEncoderBin == "" {
+		return fmt.Errorf("webp: encoder not found")
+	}
+
+	return encode(webpFile, func(tmpName string) error {
+		var stderr bytes.Buffer
+
+		cmd := exec.Command(WebPEncoderBin, "-quiet", "-metadata", "icc", "-q", JpegQuality.String(), srcFile, "-o", tmpName)
+		cmd.Stderr = &stderr
+
+		if err := cmd.Run(); err != nil {
+			log.Debugf("webp: %s", clean.Log(stderr.String()))
+			return fmt.Errorf("webp: failed to convert %s (%s)", clean.Log(filepath.Base(srcFile)), err)
+		}
+
+		return nil
+	})
+}
@@ -0,0 +1,78 @@
+package upload
+
+import (
+	"fmt"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/photoprism/photoprism/pkg/clean"
+	"github.com/photoprism/photoprism/pkg/rnd"
+)
+
+// Session represents a resumable file upload.
+type Session struct {
+	ID        string    `json:"ID"`
+	UserUID   string    `json:"UserUID"`
+	Folder    string    `json:"Folder"`
+	FileName  string    `json:"FileName"`
+	FileSize  int64     `json:"FileSize"`
+	FileHash  string    `json:"FileHash,omitempty"`
+	Offset    int64     `json:"Offset"`
+	CreatedAt time.Time `json:"CreatedAt"`
+	UpdatedAt time.Time `json:"UpdatedAt"`
+}
+
+// NewSession returns a new upload session for a file with the specified size and optional SHA1 hash.
+func NewSession(userUid, folder, fileName string, fileSize int64, fileHash string) (*Session, error) {
+	fileName = path.Base(filepath.ToSlash(strings.TrimSpace(fileName)))
+	fileHash = strings.ToLower(strings.TrimSpace(fileHash))
+
+	if fileName == "" || fileName == "." || fileName == "/" || strings.HasPrefix(fileName, ".") {
+		return nil, fmt.Errorf("invalid file name %s", clean.Log(fileName))
+	} else if fileSize <= 0 {
+		return nil, ErrInvalidSize
+	} else if fileHash != "" && !rnd.IsSHA1(fileHash) {
+		return nil, fmt.Errorf("invalid file hash %s", clean.Log(fileHash))
+	}
+
+	now := time.Now().UTC()
+
+	return &Session{
+		UserUID:   userUid,
+		Folder:    folder,
+		FileName:  fileName,
+		FileSize:  fileSize,
+		FileHash:  fileHash,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}, nil
+}
+
+// Complete checks if all data has been received.
+func (m *Session) Complete() bool {
+	return m.Offset >= m.FileSize
+}
+
+// Remaining returns the number of bytes that have not been received yet.
+func (m *Session) Remaining() int64 {
+	if m.Complete() {
+		return 0
+	}
+
+	return m.FileSize - m.Offset
+}
+
+// BelongsTo checks if the upload belongs to the specified user and upload folder.
+func (m *Session) BelongsTo(userUid, folder string) bool {
+	return m.UserUID != "" && m.UserUID == userUid && m.Folder == folder
+}
+
+// Matches checks if the upload is for the same file, so that it can be resumed.
+func (m *Session) Matches(other *Session) bool {
+	return m.BelongsTo(other.UserUID, other.Folder) &&
+		m.FileName == other.FileName &&
+		m.FileSize == other.FileSize &&
+		m.FileHash == other.FileHash
+}
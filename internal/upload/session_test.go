@@ -0,0 +1,66 @@
+package upload
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewSession(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		m, err := NewSession("uqxetse3cy5eo9z2", "abc123", "../2023/video.mp4", 100, "  2CAD9168FA6ACC5C5C2965DDF6EC465CA42FD818 ")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "video.mp4", m.FileName)
+		assert.Equal(t, int64(100), m.FileSize)
+		assert.Equal(t, "2cad9168fa6acc5c5c2965ddf6ec465ca42fd818", m.FileHash)
+		assert.Equal(t, int64(0), m.Offset)
+		assert.False(t, m.CreatedAt.IsZero())
+	})
+	t.Run("InvalidName", func(t *testing.T) {
+		_, err := NewSession("uqxetse3cy5eo9z2", "abc123", "", 100, "")
+		assert.Error(t, err)
+		_, err = NewSession("uqxetse3cy5eo9z2", "abc123", "/foo/.hidden", 100, "")
+		assert.Error(t, err)
+	})
+	t.Run("InvalidSize", func(t *testing.T) {
+		_, err := NewSession("uqxetse3cy5eo9z2", "abc123", "video.mp4", 0, "")
+		assert.Equal(t, ErrInvalidSize, err)
+	})
+	t.Run("InvalidHash", func(t *testing.T) {
+		_, err := NewSession("uqxetse3cy5eo9z2", "abc123", "video.mp4", 100, "foo")
+		assert.Error(t, err)
+	})
+}
+
+func TestSession_Complete(t *testing.T) {
+	m := Session{FileSize: 10, Offset: 4}
+
+	assert.False(t, m.Complete())
+	assert.Equal(t, int64(6), m.Remaining())
+
+	m.Offset = 10
+
+	assert.True(t, m.Complete())
+	assert.Equal(t, int64(0), m.Remaining())
+}
+
+func TestSession_BelongsTo(t *testing.T) {
+	m := Session{UserUID: "uqxetse3cy5eo9z2", Folder: "abc123"}
+
+	assert.True(t, m.BelongsTo("uqxetse3cy5eo9z2", "abc123"))
+	assert.False(t, m.BelongsTo("uqxetse3cy5eo9z2", "xyz"))
+	assert.False(t, m.BelongsTo("urjult03ceelhw6k", "abc123"))
+	assert.False(t, (&Session{}).BelongsTo("", ""))
+}
+
+func TestSession_Matches(t *testing.T) {
+	m := &Session{UserUID: "uqxetse3cy5eo9z2", Folder: "abc123", FileName: "video.mp4", FileSize: 100}
+
+	assert.True(t, m.Matches(&Session{UserUID: "uqxetse3cy5eo9z2", Folder: "abc123", FileName: "video.mp4", FileSize: 100}))
+	assert.False(t, m.Matches(&Session{UserUID: "uqxetse3cy5eo9z2", Folder: "abc123", FileName: "video.mp4", FileSize: 101}))
+	assert.False(t, m.Matches(&Session{UserUID: "uqxetse3cy5eo9z2", Folder: "abc123", FileName: "photo.jpg", FileSize: 100}))
+}
@@ -0,0 +1,349 @@
+package upload
+
+import (
+	"bytes"
+	"crypto/sha1"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"hash"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/photoprism/photoprism/pkg/clean"
+	"github.com/photoprism/photoprism/pkg/fs"
+	"github.com/photoprism/photoprism/pkg/rnd"
+)
+
+// locks prevents concurrent writes to the same upload.
+var locks sync.Map
+
+// Store keeps incomplete uploads in a folder until all chunks have been received.
+type Store struct {
+	dir string
+}
+
+// NewStore returns a new upload store for the specified folder.
+func NewStore(dir string) *Store {
+	return &Store{dir: dir}
+}
+
+// lock locks the upload with the specified id and returns a function to unlock it.
+func (s *Store) lock(id string) func() {
+	mu, _ := locks.LoadOrStore(filepath.Join(s.dir, id), &sync.Mutex{})
+	mu.(*sync.Mutex).Lock()
+	return mu.(*sync.Mutex).Unlock
+}
+
+// infoName returns the file name of the upload metadata.
+func (s *Store) infoName(id string) string {
+	return filepath.Join(s.dir, id+fs.ExtJSON)
+}
+
+// partName returns the file name of the data received so far.
+func (s *Store) partName(id string) string {
+	return filepath.Join(s.dir, id+".part")
+}
+
+// validID checks if the upload id is valid.
+func (s *Store) validID(id string) bool {
+	return id != "" && clean.Token(id) == id
+}
+
+// Create adds a new upload, or returns the existing upload of the same file so that it can be resumed.
+func (s *Store) Create(m *Session) (*Session, error) {
+	if err := os.MkdirAll(s.dir, fs.ModeDir); err != nil {
+		return nil, err
+	}
+
+	if found, err := s.Sessions(); err != nil {
+		return nil, err
+	} else {
+		for _, existing := range found {
+			if existing.Matches(m) {
+				log.Debugf("upload: resuming %s at offset %d", clean.Log(existing.FileName), existing.Offset)
+				return existing, nil
+			}
+		}
+	}
+
+	m.ID = rnd.Base36(32)
+	m.Offset = 0
+
+	if err := s.save(m); err != nil {
+		return nil, err
+	}
+
+	if err := os.WriteFile(s.partName(m.ID), nil, fs.ModeFile); err != nil {
+		_ = os.Remove(s.infoName(m.ID))
+		return nil, err
+	}
+
+	return m, nil
+}
+
+// save writes the upload metadata to disk.
+func (s *Store) save(m *Session) error {
+	data, err := json.Marshal(m)
+
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(s.infoName(m.ID), data, fs.ModeFile)
+}
+
+// Find returns the upload with the specified id.
+func (s *Store) Find(id string) (*Session, error) {
+	if !s.validID(id) {
+		return nil, ErrNotFound
+	}
+
+	data, err := os.ReadFile(s.infoName(id))
+
+	if err != nil {
+		return nil, ErrNotFound
+	}
+
+	m := &Session{}
+
+	if err = json.Unmarshal(data, m); err != nil {
+		return nil, err
+	}
+
+	// The size and modification time of the data file reflect the upload progress.
+	info, err := os.Stat(s.partName(id))
+
+	if err != nil {
+		return nil, ErrNotFound
+	}
+
+	m.ID = id
+	m.Offset = info.Size()
+	m.UpdatedAt = info.ModTime().UTC()
+
+	return m, nil
+}
+
+// Sessions returns all uploads in the store.
+func (s *Store) Sessions() (result []*Session, err error) {
+	entries, err := os.ReadDir(s.dir)
+
+	if os.IsNotExist(err) {
+		return result, nil
+	} else if err != nil {
+		return result, err
+	}
+
+	for _, e := range entries {
+		if e.IsDir() || filepath.Ext(e.Name()) != fs.ExtJSON {
+			continue
+		}
+
+		if m, findErr := s.Find(strings.TrimSuffix(e.Name(), fs.ExtJSON)); findErr == nil {
+			result = append(result, m)
+		}
+	}
+
+	return result, nil
+}
+
+// Write appends a chunk at the specified offset, which must match the number of bytes received so far.
+// If a checksum is provided, e.g. "sha1 Kq5sNclPz7QV2+lfQIuc6R7oRu0=", the chunk is discarded if it does not match.
+func (s *Store) Write(id string, offset int64, r io.Reader, checksum string) (*Session, error) {
+	unlock := s.lock(id)
+	defer unlock()
+
+	m, err := s.Find(id)
+
+	if err != nil {
+		return nil, err
+	} else if offset != m.Offset {
+		return m, ErrInvalidOffset
+	}
+
+	h, expected, err := parseChecksum(checksum)
+
+	if err != nil {
+		return m, err
+	}
+
+	f, err := os.OpenFile(s.partName(id), os.O_WRONLY, fs.ModeFile)
+
+	if err != nil {
+		return m, err
+	}
+
+	defer f.Close()
+
+	if _, err = f.Seek(offset, io.SeekStart); err != nil {
+		return m, err
+	}
+
+	var w io.Writer = f
+
+	if h != nil {
+		w = io.MultiWriter(f, h)
+	}
+
+	// Read at most one byte more than expected to detect chunks that exceed the file size.
+	n, err := io.Copy(w, io.LimitReader(r, m.Remaining()+1))
+
+	// Keep the data received so far unless it must be verified.
+	if err != nil {
+		if h != nil {
+			_ = f.Truncate(offset)
+		} else {
+			m.Offset += n
+		}
+
+		return m, err
+	} else if n > m.Remaining() {
+		_ = f.Truncate(offset)
+		return m, ErrTooLarge
+	} else if h != nil && !bytes.Equal(h.Sum(nil), expected) {
+		_ = f.Truncate(offset)
+		return m, ErrChecksum
+	}
+
+	m.Offset += n
+	m.UpdatedAt = time.Now().UTC()
+
+	return m, nil
+}
+
+// Finish verifies a complete upload, moves the file to the specified folder and returns its name.
+func (s *Store) Finish(id, dir string) (fileName string, err error) {
+	unlock := s.lock(id)
+	defer unlock()
+
+	m, err := s.Find(id)
+
+	if err != nil {
+		return "", err
+	} else if !m.Complete() {
+		return "", ErrIncomplete
+	}
+
+	partName := s.partName(id)
+
+	if m.FileHash != "" && fs.Hash(partName) != m.FileHash {
+		s.remove(id)
+		return "", ErrChecksum
+	}
+
+	fileName = filepath.Join(dir, m.FileName)
+
+	if err = fs.Move(partName, fileName); err != nil {
+		return "", err
+	}
+
+	s.remove(id)
+
+	return fileName, nil
+}
+
+// Delete cancels an upload and removes the data received so far.
+func (s *Store) Delete(id string) error {
+	unlock := s.lock(id)
+	defer unlock()
+
+	if _, err := s.Find(id); err != nil {
+		return err
+	}
+
+	s.remove(id)
+
+	return nil
+}
+
+// remove deletes the upload files.
+func (s *Store) remove(id string) {
+	for _, fileName := range []string{s.partName(id), s.infoName(id)} {
+		if err := os.Remove(fileName); err != nil && !os.IsNotExist(err) {
+			log.Warnf("upload: %s", err)
+		}
+	}
+
+	locks.Delete(filepath.Join(s.dir, id))
+}
+
+// CleanUp removes uploads that have not received any data within the specified duration
+// and returns the number of removed uploads.
+func (s *Store) CleanUp(maxAge time.Duration) (removed int, err error) {
+	entries, err := os.ReadDir(s.dir)
+
+	if os.IsNotExist(err) {
+		return 0, nil
+	} else if err != nil {
+		return 0, err
+	}
+
+	expired := time.Now().Add(-1 * maxAge)
+
+	for _, e := range entries {
+		if e.IsDir() {
+			continue
+		}
+
+		info, infoErr := e.Info()
+
+		if infoErr != nil || info.ModTime().After(expired) {
+			continue
+		}
+
+		id := strings.TrimSuffix(e.Name(), filepath.Ext(e.Name()))
+
+		switch filepath.Ext(e.Name()) {
+		case ".part":
+			log.Debugf("upload: removed abandoned upload %s", clean.Log(id))
+			s.remove(id)
+			removed++
+		case fs.ExtJSON:
+			// Remove metadata without data.
+			if !fs.FileExists(s.partName(id)) {
+				s.remove(id)
+			}
+		}
+	}
+
+	return removed, nil
+}
+
+// parseChecksum parses an upload checksum header, e.g. "sha1 Kq5sNclPz7QV2+lfQIuc6R7oRu0=".
+func parseChecksum(checksum string) (h hash.Hash, expected []byte, err error) {
+	if checksum = strings.TrimSpace(checksum); checksum == "" {
+		return nil, nil, nil
+	}
+
+	values := strings.SplitN(checksum, " ", 2)
+
+	if len(values) != 2 {
+		return nil, nil, ErrInvalidHeader
+	}
+
+	alg := values[0]
+
+	if expected, err = base64.StdEncoding.DecodeString(strings.TrimSpace(values[1])); err != nil {
+		return nil, nil, ErrInvalidHeader
+	}
+
+	switch strings.ToLower(alg) {
+	case "sha1":
+		h = sha1.New()
+	case "sha256":
+		h = sha256.New()
+	default:
+		return nil, nil, ErrUnsupportedAlg
+	}
+
+	if len(expected) != h.Size() {
+		return nil, nil, ErrInvalidHeader
+	}
+
+	return h, expected, nil
+}
@@ -0,0 +1,259 @@
+package upload
+
+import (
+	"crypto/sha1"
+	"encoding/base64"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/pkg/fs"
+)
+
+func checksum(s string) string {
+	h := sha1.Sum([]byte(s))
+	return "sha1 " + base64.StdEncoding.EncodeToString(h[:])
+}
+
+func newTestSession(t *testing.T, s *Store, content string) *Session {
+	m, err := NewSession("uqxetse3cy5eo9z2", "abc123", "video.mp4", int64(len(content)), "")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if m, err = s.Create(m); err != nil {
+		t.Fatal(err)
+	}
+
+	return m
+}
+
+func TestStore_Create(t *testing.T) {
+	s := NewStore(filepath.Join(t.TempDir(), "uploads"))
+
+	m := newTestSession(t, s, "0123456789")
+
+	assert.Len(t, m.ID, 32)
+
+	t.Run("Resume", func(t *testing.T) {
+		if _, err := s.Write(m.ID, 0, strings.NewReader("0123"), ""); err != nil {
+			t.Fatal(err)
+		}
+
+		resumed := newTestSession(t, s, "0123456789")
+
+		assert.Equal(t, m.ID, resumed.ID)
+		assert.Equal(t, int64(4), resumed.Offset)
+	})
+	t.Run("Sessions", func(t *testing.T) {
+		found, err := s.Sessions()
+
+		assert.NoError(t, err)
+		assert.Len(t, found, 1)
+	})
+}
+
+func TestStore_Find(t *testing.T) {
+	s := NewStore(t.TempDir())
+
+	m := newTestSession(t, s, "0123456789")
+
+	found, err := s.Find(m.ID)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, m.ID, found.ID)
+	assert.Equal(t, "uqxetse3cy5eo9z2", found.UserUID)
+	assert.Equal(t, int64(10), found.FileSize)
+
+	_, err = s.Find("../foo")
+	assert.Equal(t, ErrNotFound, err)
+
+	_, err = s.Find("foo")
+	assert.Equal(t, ErrNotFound, err)
+}
+
+func TestStore_Write(t *testing.T) {
+	t.Run("Chunks", func(t *testing.T) {
+		s := NewStore(t.TempDir())
+		m := newTestSession(t, s, "0123456789")
+
+		m, err := s.Write(m.ID, 0, strings.NewReader("01234"), checksum("01234"))
+
+		assert.NoError(t, err)
+		assert.Equal(t, int64(5), m.Offset)
+		assert.False(t, m.Complete())
+
+		m, err = s.Write(m.ID, 5, strings.NewReader("56789"), "")
+
+		assert.NoError(t, err)
+		assert.Equal(t, int64(10), m.Offset)
+		assert.True(t, m.Complete())
+	})
+	t.Run("InvalidOffset", func(t *testing.T) {
+		s := NewStore(t.TempDir())
+		m := newTestSession(t, s, "0123456789")
+
+		m, err := s.Write(m.ID, 3, strings.NewReader("3456"), "")
+
+		assert.Equal(t, ErrInvalidOffset, err)
+		assert.Equal(t, int64(0), m.Offset)
+	})
+	t.Run("ChecksumMismatch", func(t *testing.T) {
+		s := NewStore(t.TempDir())
+		m := newTestSession(t, s, "0123456789")
+
+		_, err := s.Write(m.ID, 0, strings.NewReader("01234"), checksum("foo"))
+
+		assert.Equal(t, ErrChecksum, err)
+
+		m, err = s.Find(m.ID)
+
+		assert.NoError(t, err)
+		assert.Equal(t, int64(0), m.Offset)
+	})
+	t.Run("InvalidChecksum", func(t *testing.T) {
+		s := NewStore(t.TempDir())
+		m := newTestSession(t, s, "0123456789")
+
+		_, err := s.Write(m.ID, 0, strings.NewReader("01234"), "sha1")
+		assert.Equal(t, ErrInvalidHeader, err)
+
+		_, err = s.Write(m.ID, 0, strings.NewReader("01234"), "md4 Kq5sNclPz7QV2+lfQIuc6R7oRu0=")
+		assert.Equal(t, ErrUnsupportedAlg, err)
+	})
+	t.Run("TooLarge", func(t *testing.T) {
+		s := NewStore(t.TempDir())
+		m := newTestSession(t, s, "0123")
+
+		_, err := s.Write(m.ID, 0, strings.NewReader("01234"), "")
+
+		assert.Equal(t, ErrTooLarge, err)
+
+		m, err = s.Find(m.ID)
+
+		assert.NoError(t, err)
+		assert.Equal(t, int64(0), m.Offset)
+	})
+}
+
+func TestStore_Finish(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		s := NewStore(t.TempDir())
+		dest := t.TempDir()
+		m, err := NewSession("uqxetse3cy5eo9z2", "abc123", "video.mp4", 10, "87acec17cd9dcd20a716cc2cf67417b71c8a7016")
+
+		if err != nil {
+			t.Fatal(err)
+		} else if m, err = s.Create(m); err != nil {
+			t.Fatal(err)
+		}
+
+		_, err = s.Finish(m.ID, dest)
+
+		assert.Equal(t, ErrIncomplete, err)
+
+		if _, err = s.Write(m.ID, 0, strings.NewReader("0123456789"), ""); err != nil {
+			t.Fatal(err)
+		}
+
+		fileName, err := s.Finish(m.ID, dest)
+
+		assert.NoError(t, err)
+		assert.Equal(t, filepath.Join(dest, "video.mp4"), fileName)
+
+		if data, err := os.ReadFile(fileName); err != nil {
+			t.Fatal(err)
+		} else {
+			assert.Equal(t, "0123456789", string(data))
+		}
+
+		_, err = s.Find(m.ID)
+
+		assert.Equal(t, ErrNotFound, err)
+	})
+	t.Run("ChecksumMismatch", func(t *testing.T) {
+		s := NewStore(t.TempDir())
+		dest := t.TempDir()
+		m, err := NewSession("uqxetse3cy5eo9z2", "abc123", "video.mp4", 10, "2cad9168fa6acc5c5c2965ddf6ec465ca42fd818")
+
+		if err != nil {
+			t.Fatal(err)
+		} else if m, err = s.Create(m); err != nil {
+			t.Fatal(err)
+		}
+
+		if _, err = s.Write(m.ID, 0, strings.NewReader("0123456789"), ""); err != nil {
+			t.Fatal(err)
+		}
+
+		_, err = s.Finish(m.ID, dest)
+
+		assert.Equal(t, ErrChecksum, err)
+		assert.False(t, fs.FileExists(filepath.Join(dest, "video.mp4")))
+
+		_, err = s.Find(m.ID)
+
+		assert.Equal(t, ErrNotFound, err)
+	})
+}
+
+func TestStore_Delete(t *testing.T) {
+	s := NewStore(t.TempDir())
+	m := newTestSession(t, s, "0123456789")
+
+	assert.NoError(t, s.Delete(m.ID))
+	assert.Equal(t, ErrNotFound, s.Delete(m.ID))
+}
+
+func TestStore_CleanUp(t *testing.T) {
+	dir := t.TempDir()
+	s := NewStore(dir)
+
+	abandoned := newTestSession(t, s, "0123456789")
+
+	m, err := NewSession("uqxetse3cy5eo9z2", "abc123", "photo.jpg", 10, "")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	active, err := s.Create(m)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	past := time.Now().Add(-48 * time.Hour)
+
+	for _, fileName := range []string{abandoned.ID + ".json", abandoned.ID + ".part"} {
+		if err = os.Chtimes(filepath.Join(dir, fileName), past, past); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	removed, err := s.CleanUp(24 * time.Hour)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, removed)
+
+	_, err = s.Find(abandoned.ID)
+	assert.Equal(t, ErrNotFound, err)
+
+	_, err = s.Find(active.ID)
+	assert.NoError(t, err)
+
+	t.Run("NotExists", func(t *testing.T) {
+		removed, err := NewStore(filepath.Join(dir, "missing")).CleanUp(time.Hour)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 0, removed)
+	})
+}
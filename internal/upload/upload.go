@@ -0,0 +1,50 @@
+/*
+Package upload provides resumable uploads that are transferred in multiple chunks.
+
+Copyright (c) 2018 - 2023 PhotoPrism UG. All rights reserved.
+
+	This program is free software: you can redistribute it and/or modify
+	it under Version 3 of the GNU Affero General Public License (the "AGPL"):
+	<https://docs.photoprism.app/license/agpl>
+
+	This program is distributed in the hope that it will be useful,
+	but WITHOUT ANY WARRANTY; without even the implied warranty of
+	MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+	GNU Affero General Public License for more details.
+
+	The AGPL is supplemented by our Trademark and Brand Guidelines,
+	which describe how our Brand Assets may be used:
+	<https://www.photoprism.app/trademark>
+
+Feel free to send an email to hello@photoprism.app if you have questions,
+want to support our work, or just want to say hello.
+
+Additional information can be found in our Developer Guide:
+<https://docs.photoprism.app/developer-guide/>
+*/
+package upload
+
+import (
+	"errors"
+	"time"
+
+	"github.com/photoprism/photoprism/internal/event"
+)
+
+// Global log instance.
+var log = event.Log
+
+// MaxAge is the time after which incomplete uploads without activity are considered abandoned.
+var MaxAge = 24 * time.Hour
+
+// Upload errors.
+var (
+	ErrNotFound       = errors.New("upload not found")
+	ErrInvalidOffset  = errors.New("invalid upload offset")
+	ErrInvalidSize    = errors.New("invalid upload size")
+	ErrTooLarge       = errors.New("upload exceeds file size")
+	ErrChecksum       = errors.New("checksum mismatch")
+	ErrIncomplete     = errors.New("upload incomplete")
+	ErrInvalidHeader  = errors.New("invalid checksum header")
+	ErrUnsupportedAlg = errors.New("unsupported checksum algorithm")
+)
@@ -0,0 +1,102 @@
+package workers
+
+import (
+	"fmt"
+	"runtime/debug"
+	"time"
+
+	"github.com/dustin/go-humanize/english"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/mutex"
+	"github.com/photoprism/photoprism/internal/photoprism"
+	"github.com/photoprism/photoprism/pkg/clean"
+)
+
+// Backup represents a background index backup worker.
+type Backup struct {
+	conf *config.Config
+}
+
+// NewBackup returns a new Backup worker.
+func NewBackup(conf *config.Config) *Backup {
+	return &Backup{conf: conf}
+}
+
+// Start creates a compressed index backup, verifies it, and removes old backups. If enabled, incremental
+// backups that only contain the changes since the last full backup are created between full backups.
+func (w *Backup) Start() (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("backup: %s (worker panic)\nstack: %s", r, debug.Stack())
+			log.Error(err)
+		}
+	}()
+
+	if err = mutex.BackupWorker.Start(); err != nil {
+		return err
+	}
+
+	defer mutex.BackupWorker.Stop()
+
+	start := time.Now()
+	backupPath := w.conf.BackupIndexPath()
+
+	if fileName, ok := w.increment(backupPath); ok {
+		log.Infof("backup: index changes saved as %s [%s]", clean.Log(fileName), time.Since(start))
+	} else {
+		fileName = photoprism.BackupFileName(backupPath, true)
+
+		if err = photoprism.BackupIndexFile(fileName, false); err != nil {
+			return err
+		}
+
+		log.Infof("backup: index saved as %s [%s]", clean.Log(fileName), time.Since(start))
+	}
+
+	// Remove old backups.
+	if removed, err := photoprism.RotateBackups(backupPath, w.conf.BackupRetain()); err != nil {
+		log.Errorf("backup: %s (rotate)", err)
+	} else if removed > 0 {
+		log.Infof("backup: removed %s", english.Plural(removed, "old index backup", "old index backups"))
+	}
+
+	// Update album YAML files, if enabled.
+	if w.conf.BackupYaml() {
+		if count, err := photoprism.BackupAlbums(w.conf.AlbumsPath(), false); err != nil {
+			log.Errorf("backup: %s (albums)", err)
+		} else {
+			log.Debugf("backup: saved %s", english.Plural(count, "album file", "album files"))
+		}
+	}
+
+	return nil
+}
+
+// increment creates an incremental index backup if enabled and the maximum number of
+// incremental backups since the last full backup has not been reached yet.
+func (w *Backup) increment(backupPath string) (fileName string, ok bool) {
+	max := w.conf.BackupIncremental()
+
+	if max <= 0 {
+		return "", false
+	}
+
+	base, increments, err := photoprism.BackupBase(backupPath)
+
+	if err != nil {
+		log.Warnf("backup: %s (find base)", err)
+		return "", false
+	} else if base == "" || increments >= max {
+		return "", false
+	}
+
+	fileName = photoprism.BackupIncFileName(backupPath)
+
+	if err = photoprism.BackupIndexIncrement(fileName, base, false); err != nil {
+		log.Warnf("backup: %s (incremental), creating a full backup instead", err)
+		return "", false
+	}
+
+	return fileName, true
+}
@@ -0,0 +1,29 @@
+package workers
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/mutex"
+)
+
+func TestBackup_Start(t *testing.T) {
+	conf := config.TestConfig()
+
+	worker := NewBackup(conf)
+
+	assert.IsType(t, &Backup{}, worker)
+
+	if err := mutex.BackupWorker.Start(); err != nil {
+		t.Fatal(err)
+	}
+
+	// Mutex should prevent worker from starting.
+	if err := worker.Start(); err == nil {
+		t.Fatal("error expected")
+	}
+
+	mutex.BackupWorker.Stop()
+}
@@ -0,0 +1,182 @@
+package workers
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/mutex"
+	"github.com/photoprism/photoprism/internal/photoprism"
+	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/internal/remote"
+	"github.com/photoprism/photoprism/pkg/clean"
+	"github.com/photoprism/photoprism/pkg/fs"
+)
+
+// localFileName returns the local file name of a synced file, or an empty string if it is unknown.
+func (w *Sync) localFileName(a entity.Service, f entity.FileSync) string {
+	if f.FileID > 0 {
+		file, err := query.FileByID(f.FileID)
+
+		if err != nil || file.FileMissing {
+			return ""
+		} else if file.FileRoot == "" || file.FileRoot == entity.RootOriginals {
+			return filepath.Join(w.conf.OriginalsPath(), file.FileName)
+		}
+
+		return photoprism.FileName(file.FileRoot, file.FileName)
+	} else if a.SyncFilenames {
+		return filepath.Join(w.conf.OriginalsPath(), f.RemoteName)
+	}
+
+	return ""
+}
+
+// localHash returns the current hash of the local file, or an empty string if it is unknown.
+func (w *Sync) localHash(a entity.Service, f entity.FileSync) string {
+	if f.FileID > 0 {
+		if file, err := query.FileByID(f.FileID); err == nil && !file.FileMissing {
+			return file.FileHash
+		}
+
+		return ""
+	}
+
+	if fileName := w.localFileName(a, f); fileName != "" && fs.FileExists(fileName) {
+		return fs.Hash(fileName)
+	}
+
+	return ""
+}
+
+// updateRemoteInfo sets the remote size and modification date of files after they have been uploaded.
+func (w *Sync) updateRemoteInfo(client remote.Client, files []*entity.FileSync) {
+	dirs := make(map[string]fs.FileInfos)
+
+	for _, f := range files {
+		dir := path.Dir(f.RemoteName)
+
+		if _, ok := dirs[dir]; !ok {
+			found, err := client.Files(dir, false)
+
+			if err != nil {
+				log.Debugf("sync: %s", err)
+			}
+
+			dirs[dir] = found
+		}
+
+		for _, info := range dirs[dir] {
+			if info.Abs != f.RemoteName {
+				continue
+			}
+
+			f.RemoteDate = info.Date
+			f.RemoteSize = info.Size
+
+			w.logError(f.Updates(map[string]interface{}{
+				"RemoteDate": info.Date,
+				"RemoteSize": info.Size,
+			}))
+
+			break
+		}
+	}
+}
+
+// Resolve resolves a sync conflict by keeping the local version, the remote version, or both.
+func (w *Sync) Resolve(a entity.Service, remoteName, keep string) error {
+	switch keep {
+	case entity.KeepBoth, entity.KeepLocal, entity.KeepRemote:
+	default:
+		return fmt.Errorf("invalid option %s", clean.Log(keep))
+	}
+
+	// Prevent the sync worker from running at the same time.
+	if err := mutex.SyncWorker.Start(); err != nil {
+		return fmt.Errorf("sync %s", err)
+	}
+
+	defer mutex.SyncWorker.Stop()
+
+	f, err := query.FileSyncByName(a.ID, remoteName)
+
+	if err != nil {
+		return fmt.Errorf("file %s not found", clean.Log(remoteName))
+	} else if !f.Conflicting() || f.ConflictName == "" {
+		return fmt.Errorf("%s has no conflicting copy", clean.Log(remoteName))
+	}
+
+	localName := w.localFileName(a, f)
+
+	if localName == "" || !fs.FileExists(localName) {
+		return fmt.Errorf("local file for %s not found", clean.Log(remoteName))
+	}
+
+	client, err := a.Client()
+
+	if err != nil {
+		return err
+	}
+
+	defer client.Close()
+
+	localCopy := filepath.Join(filepath.Dir(localName), f.ConflictName)
+	remoteCopy := path.Join(path.Dir(f.RemoteName), f.ConflictName)
+
+	if fs.FileExists(localCopy) {
+		// The remote version was downloaded next to the local file.
+		switch keep {
+		case entity.KeepLocal:
+			if err = client.Upload(localName, f.RemoteName); err != nil {
+				return err
+			} else if err = os.Remove(localCopy); err != nil {
+				return err
+			}
+		case entity.KeepRemote:
+			if err = os.Rename(localCopy, localName); err != nil {
+				return err
+			}
+		}
+	} else {
+		// The local version was uploaded next to the remote file.
+		switch keep {
+		case entity.KeepLocal:
+			if err = client.Upload(localName, f.RemoteName); err != nil {
+				return err
+			} else if err = client.Delete(remoteCopy); err != nil {
+				return err
+			}
+		case entity.KeepRemote:
+			if err = client.Download(f.RemoteName, localName, true); err != nil {
+				return err
+			} else if err = client.Delete(remoteCopy); err != nil {
+				return err
+			}
+		}
+	}
+
+	log.Infof("sync: resolved conflict for %s, kept %s version", clean.Log(remoteName), keep)
+
+	status := entity.FileSyncDownloaded
+
+	if f.FileID > 0 {
+		status = entity.FileSyncUploaded
+	}
+
+	if err = f.Updates(map[string]interface{}{
+		"Status":       status,
+		"LocalHash":    fs.Hash(localName),
+		"ConflictName": "",
+		"Error":        "",
+		"Errors":       0,
+	}); err != nil {
+		return err
+	}
+
+	w.updateRemoteInfo(client, []*entity.FileSync{&f})
+
+	return nil
+}
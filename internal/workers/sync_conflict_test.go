@@ -0,0 +1,186 @@
+package workers
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/internal/mutex"
+	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/pkg/fs"
+)
+
+func TestSync_Resolve(t *testing.T) {
+	conf := config.TestConfig()
+	worker := NewSync(conf)
+	remoteDir := t.TempDir()
+	remoteName := "/SyncConflicts/notes.txt"
+	remoteFile := filepath.Join(remoteDir, filepath.FromSlash(remoteName))
+	localFile := filepath.Join(conf.OriginalsPath(), filepath.FromSlash(remoteName))
+
+	defer os.RemoveAll(filepath.Dir(localFile))
+
+	write := func(fileName, content string, modTime time.Time) {
+		if err := os.MkdirAll(filepath.Dir(fileName), os.ModePerm); err != nil {
+			t.Fatal(err)
+		} else if err = os.WriteFile(fileName, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		} else if err = os.Chtimes(fileName, modTime, modTime); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	f, err := form.NewService(entity.Service{AccName: "Conflicts", AccURL: remoteDir, AccType: "local", AccSync: true, SyncDownload: true, SyncFilenames: true, SyncPath: "/SyncConflicts"})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	account, err := entity.AddService(f)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer account.Delete()
+	defer entity.Db().Where("service_id = ?", account.ID).Delete(entity.FileSync{})
+
+	synced := time.Date(2023, 1, 2, 15, 4, 5, 0, time.UTC)
+
+	// Simulate a previous download.
+	write(remoteFile, "version 1", synced)
+	write(localFile, "version 1", synced)
+
+	m := entity.NewFileSync(account.ID, remoteName)
+	m.Status = entity.FileSyncDownloaded
+	m.RemoteDate = synced
+	m.RemoteSize = 9
+	m.LocalHash = fs.Hash(localFile)
+
+	if err = m.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("NoConflict", func(t *testing.T) {
+		assert.Error(t, worker.Resolve(*account, remoteName, entity.KeepLocal))
+	})
+	t.Run("InvalidOption", func(t *testing.T) {
+		assert.Error(t, worker.Resolve(*account, remoteName, "foo"))
+	})
+	t.Run("SyncRunning", func(t *testing.T) {
+		if err := mutex.SyncWorker.Start(); err != nil {
+			t.Fatal(err)
+		}
+
+		defer mutex.SyncWorker.Stop()
+
+		assert.EqualError(t, worker.Resolve(*account, remoteName, entity.KeepLocal), "sync already running")
+	})
+	t.Run("Conflict", func(t *testing.T) {
+		// Modify both versions.
+		write(remoteFile, "remote version 2", synced.Add(time.Hour))
+		write(localFile, "local version 2", synced.Add(time.Hour))
+
+		complete, err := worker.refresh(*account)
+
+		assert.NoError(t, err)
+		assert.True(t, complete)
+
+		result, err := query.FileSyncByName(account.ID, remoteName)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, entity.FileSyncConflict, result.Status)
+		assert.Equal(t, int64(16), result.RemoteSize)
+
+		// Download the remote version next to the local file.
+		pending, err := worker.pendingConflicts(*account)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Len(t, pending, 1)
+
+		client, err := account.Client()
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		defer client.Close()
+
+		assert.Equal(t, 0, worker.downloadConflicts(*account, client, pending, nil))
+
+		result, err = query.FileSyncByName(account.ID, remoteName)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.True(t, fs.IsConflictName(result.ConflictName))
+
+		localCopy := filepath.Join(filepath.Dir(localFile), result.ConflictName)
+
+		if data, err := os.ReadFile(localCopy); err != nil {
+			t.Fatal(err)
+		} else {
+			assert.Equal(t, "remote version 2", string(data))
+		}
+
+		// Conflicting copies are ignored when refreshing.
+		write(filepath.Join(filepath.Dir(remoteFile), result.ConflictName), "ignored", synced)
+
+		if _, err = worker.refresh(*account); err != nil {
+			t.Fatal(err)
+		}
+
+		if _, err = query.FileSyncByName(account.ID, "/SyncConflicts/"+result.ConflictName); err == nil {
+			t.Error("conflicting copy should be ignored")
+		}
+
+		// Keep the remote version.
+		assert.NoError(t, worker.Resolve(*account, remoteName, entity.KeepRemote))
+
+		assert.False(t, fs.FileExists(localCopy))
+
+		if data, err := os.ReadFile(localFile); err != nil {
+			t.Fatal(err)
+		} else {
+			assert.Equal(t, "remote version 2", string(data))
+		}
+
+		result, err = query.FileSyncByName(account.ID, remoteName)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, entity.FileSyncDownloaded, result.Status)
+		assert.Equal(t, "", result.ConflictName)
+		assert.Equal(t, fs.Hash(localFile), result.LocalHash)
+	})
+	t.Run("RemoteModified", func(t *testing.T) {
+		write(remoteFile, "remote version 3", synced.Add(2*time.Hour))
+
+		if _, err := worker.refresh(*account); err != nil {
+			t.Fatal(err)
+		}
+
+		result, err := query.FileSyncByName(account.ID, remoteName)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, entity.FileSyncNew, result.Status)
+	})
+}
@@ -3,6 +3,9 @@ package workers
 import (
 	"fmt"
 	"os"
+	"path/filepath"
+	"strings"
+	"time"
 
 	"github.com/dustin/go-humanize/english"
 
@@ -12,7 +15,7 @@ import (
 	"github.com/photoprism/photoprism/internal/mutex"
 	"github.com/photoprism/photoprism/internal/photoprism"
 	"github.com/photoprism/photoprism/internal/query"
-	"github.com/photoprism/photoprism/internal/remote/webdav"
+	"github.com/photoprism/photoprism/internal/remote"
 	"github.com/photoprism/photoprism/pkg/clean"
 	"github.com/photoprism/photoprism/pkg/fs"
 )
@@ -51,6 +54,79 @@ func (w *Sync) relatedDownloads(a entity.Service) (result Downloads, err error)
 	return result, nil
 }
 
+// pendingConflicts returns conflicting files for which the remote version has not been downloaded yet.
+func (w *Sync) pendingConflicts(a entity.Service) (result []entity.FileSync, err error) {
+	files, err := query.FileSyncs(a.ID, entity.FileSyncConflict, 100)
+
+	if err != nil {
+		return result, err
+	}
+
+	for _, file := range files {
+		if file.ConflictName != "" {
+			continue
+		} else if a.RetryLimit > 0 && file.Errors > a.RetryLimit {
+			continue
+		} else if w.localFileName(a, file) == "" {
+			continue
+		}
+
+		result = append(result, file)
+	}
+
+	return result, nil
+}
+
+// downloadConflicts downloads the remote versions of conflicting files next to the local files,
+// so that both versions are kept until the conflict is resolved.
+func (w *Sync) downloadConflicts(a entity.Service, client remote.Client, files []entity.FileSync, indexJobs chan photoprism.IndexJob) (indexed int) {
+	for _, file := range files {
+		if mutex.SyncWorker.Canceled() {
+			return indexed
+		}
+
+		localName := w.localFileName(a, file)
+		copyName := fs.ConflictName(localName, time.Now())
+
+		if err := client.Download(file.RemoteName, copyName, false); err != nil {
+			w.logError(file.Updates(map[string]interface{}{"Error": err.Error(), "Errors": file.Errors + 1}))
+			continue
+		}
+
+		log.Infof("sync: downloaded conflicting version of %s as %s", clean.Log(file.RemoteName), clean.Log(filepath.Base(copyName)))
+
+		w.logError(file.Updates(map[string]interface{}{"ConflictName": filepath.Base(copyName), "Error": "", "Errors": 0}))
+
+		if !strings.HasPrefix(copyName, w.conf.OriginalsPath()) {
+			continue
+		}
+
+		mf, err := photoprism.NewMediaFile(copyName)
+
+		if err != nil || !mf.IsMedia() || mf.Empty() {
+			continue
+		}
+
+		related, err := mf.RelatedFiles(w.conf.Settings().StackSequences())
+
+		if err != nil {
+			w.logWarn(err)
+			continue
+		}
+
+		indexed++
+
+		indexJobs <- photoprism.IndexJob{
+			FileName: mf.FileName(),
+			Related:  related,
+			IndexOpt: photoprism.IndexOptionsAll(),
+			Ind:      get.Index(),
+		}
+	}
+
+	return indexed
+}
+
 // Downloads remote files in batches and imports / indexes them
 func (w *Sync) download(a entity.Service) (complete bool, err error) {
 	// Set up index worker
@@ -71,8 +147,15 @@ func (w *Sync) download(a entity.Service) (complete bool, err error) {
 		return false, err
 	}
 
+	conflicts, err := w.pendingConflicts(a)
+
+	if err != nil {
+		w.logError(err)
+		return false, err
+	}
+
 	// Check if files must and can be downloaded.
-	if l := len(relatedFiles); l == 0 {
+	if l := len(relatedFiles) + len(conflicts); l == 0 {
 		log.Infof("sync: no files to download from %s", clean.Log(a.AccName))
 		event.Publish("sync.downloaded", event.Data{"account": a})
 		return true, nil
@@ -88,12 +171,14 @@ func (w *Sync) download(a entity.Service) (complete bool, err error) {
 	// Display log message.
 	log.Infof("sync: downloading from %s", a.AccName)
 
-	client, err := webdav.NewClient(a.AccURL, a.AccUser, a.AccPass, webdav.Timeout(a.AccTimeout))
+	client, err := a.Client()
 
 	if err != nil {
 		return false, err
 	}
 
+	defer client.Close()
+
 	var baseDir string
 
 	if a.SyncFilenames {
@@ -104,6 +189,9 @@ func (w *Sync) download(a entity.Service) (complete bool, err error) {
 
 	done := make(map[string]bool)
 
+	// Keep both versions of files that have been modified locally and remotely.
+	indexed := w.downloadConflicts(a, client, conflicts, indexJobs)
+
 	for _, files := range relatedFiles {
 		for i, file := range files {
 			if mutex.SyncWorker.Canceled() {
@@ -118,18 +206,24 @@ func (w *Sync) download(a entity.Service) (complete bool, err error) {
 
 			localName := baseDir + file.RemoteName
 
-			if _, err := os.Stat(localName); err == nil {
+			// Replace existing files only if they have not been modified since the last download.
+			_, err := os.Stat(localName)
+			exists := err == nil
+			replace := exists && file.LocalHash != "" && !file.LocalModified(fs.Hash(localName))
+
+			if exists && !replace {
 				log.Warnf("sync: download skipped, %s already exists", localName)
 				file.Status = entity.FileSyncExists
 				file.Error = ""
 				file.Errors = 0
 			} else {
-				if err := client.Download(file.RemoteName, localName, false); err != nil {
+				if err := client.Download(file.RemoteName, localName, replace); err != nil {
 					file.Errors++
 					file.Error = err.Error()
 				} else {
 					log.Infof("sync: downloaded %s from %s", file.RemoteName, a.AccName)
 					file.Status = entity.FileSyncDownloaded
+					file.LocalHash = fs.Hash(localName)
 					file.Error = ""
 					file.Errors = 0
 				}
@@ -200,7 +294,7 @@ func (w *Sync) download(a entity.Service) (complete bool, err error) {
 	}
 
 	// Any files downloaded?
-	if len(done) > 0 {
+	if len(done) > 0 || indexed > 0 {
 		// Update precalculated photo and file counts.
 		w.logWarn(entity.UpdateCounts())
 
@@ -5,21 +5,25 @@ import (
 	"github.com/photoprism/photoprism/internal/mutex"
 	"github.com/photoprism/photoprism/internal/remote"
 	"github.com/photoprism/photoprism/internal/remote/webdav"
+	"github.com/photoprism/photoprism/pkg/clean"
+	"github.com/photoprism/photoprism/pkg/fs"
 	"github.com/photoprism/photoprism/pkg/media"
 )
 
 // Updates the local list of remote files so that they can be downloaded in batches
 func (w *Sync) refresh(a entity.Service) (complete bool, err error) {
-	if a.AccType != remote.ServiceWebDAV {
+	if !remote.Supported(a.AccType) {
 		return false, nil
 	}
 
-	client, err := webdav.NewClient(a.AccURL, a.AccUser, a.AccPass, webdav.Timeout(a.AccTimeout))
+	client, err := a.Client()
 
 	if err != nil {
 		return false, err
 	}
 
+	defer client.Close()
+
 	// Ensure remote folder exists.
 	if err = client.MkdirAll(a.SyncPath); err != nil {
 		log.Debugf("sync: %s", err)
@@ -51,6 +55,11 @@ func (w *Sync) refresh(a entity.Service) (complete bool, err error) {
 				return false, nil
 			}
 
+			// Skip conflicting copies.
+			if fs.IsConflictName(file.Name) {
+				continue
+			}
+
 			f := entity.NewFileSync(a.ID, file.Abs)
 
 			f.Status = entity.FileSyncIgnore
@@ -79,7 +88,22 @@ func (w *Sync) refresh(a entity.Service) (complete bool, err error) {
 				w.logError(f.Update("Status", entity.FileSyncNew))
 			}
 
-			if f.Status == entity.FileSyncDownloaded && !f.RemoteDate.Equal(file.Date) {
+			if f.Status != entity.FileSyncDownloaded && f.Status != entity.FileSyncUploaded {
+				continue
+			} else if !f.RemoteModified(file.Date, file.Size) {
+				continue
+			}
+
+			// Keep both versions if the file has also been modified locally.
+			if f.LocalModified(w.localHash(a, *f)) {
+				log.Warnf("sync: %s has been modified locally and on %s", clean.Log(f.RemoteName), clean.Log(a.AccName))
+
+				w.logError(f.Updates(map[string]interface{}{
+					"Status":     entity.FileSyncConflict,
+					"RemoteDate": file.Date,
+					"RemoteSize": file.Size,
+				}))
+			} else if f.Status == entity.FileSyncDownloaded {
 				w.logError(f.Updates(map[string]interface{}{
 					"Status":     entity.FileSyncNew,
 					"RemoteDate": file.Date,
@@ -0,0 +1,63 @@
+package workers
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/internal/query"
+)
+
+func TestSync_refresh(t *testing.T) {
+	t.Run("Local", func(t *testing.T) {
+		conf := config.TestConfig()
+		worker := NewSync(conf)
+		dir := t.TempDir()
+
+		for _, name := range []string{"Photos/cat.jpg", "Photos/2023/dog.jpg", "Photos/2023/notes.txt", "Photos/2023/video.mp4"} {
+			fileName := filepath.Join(dir, filepath.FromSlash(name))
+
+			if err := os.MkdirAll(filepath.Dir(fileName), os.ModePerm); err != nil {
+				t.Fatal(err)
+			} else if err = os.WriteFile(fileName, []byte(name), 0644); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		f, err := form.NewService(entity.Service{AccName: "Local Sync", AccURL: dir, AccType: "local", AccSync: true, SyncDownload: true, SyncPath: "/Photos"})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		account, err := entity.AddService(f)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		defer account.Delete()
+
+		complete, err := worker.refresh(*account)
+
+		assert.NoError(t, err)
+		assert.True(t, complete)
+
+		files, err := query.FileSyncs(account.ID, entity.FileSyncNew, 100)
+
+		assert.NoError(t, err)
+
+		var names []string
+
+		for _, file := range files {
+			names = append(names, file.RemoteName)
+		}
+
+		assert.ElementsMatch(t, []string{"/Photos/cat.jpg", "/Photos/2023/dog.jpg", "/Photos/2023/notes.txt"}, names)
+	})
+}
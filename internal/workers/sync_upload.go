@@ -9,10 +9,61 @@ import (
 	"github.com/photoprism/photoprism/internal/mutex"
 	"github.com/photoprism/photoprism/internal/photoprism"
 	"github.com/photoprism/photoprism/internal/query"
-	"github.com/photoprism/photoprism/internal/remote/webdav"
+	"github.com/photoprism/photoprism/internal/remote"
 	"github.com/photoprism/photoprism/pkg/clean"
+	"github.com/photoprism/photoprism/pkg/fs"
 )
 
+// uploadModified uploads files that have been modified locally since the last sync. If the remote file
+// has been modified as well, the local version is uploaded as conflicting copy so that both versions are kept.
+func (w *Sync) uploadModified(a entity.Service, client remote.Client, files []entity.FileSync) {
+	var uploaded []*entity.FileSync
+
+	defer func() {
+		w.updateRemoteInfo(client, uploaded)
+	}()
+
+	for i := range files {
+		if mutex.SyncWorker.Canceled() {
+			return
+		}
+
+		f := &files[i]
+
+		if f.File == nil {
+			continue
+		}
+
+		fileName := photoprism.FileName(f.File.FileRoot, f.File.FileName)
+
+		if f.Conflicting() {
+			copyName := fs.ConflictName(f.RemoteName, time.Now())
+
+			if err := client.Upload(fileName, copyName); err != nil {
+				w.logError(err)
+				continue
+			}
+
+			log.Infof("sync: uploaded conflicting version of %s as %s (%s)", clean.Log(f.RemoteName), clean.Log(path.Base(copyName)), a.AccName)
+
+			w.logError(f.Updates(map[string]interface{}{"ConflictName": path.Base(copyName), "Error": "", "Errors": 0}))
+
+			continue
+		}
+
+		if err := client.Upload(fileName, f.RemoteName); err != nil {
+			w.logError(err)
+			continue
+		}
+
+		log.Infof("sync: uploaded modified %s to %s (%s)", clean.Log(f.File.FileName), clean.Log(f.RemoteName), a.AccName)
+
+		w.logError(f.Updates(map[string]interface{}{"LocalHash": f.File.FileHash, "RemoteSize": f.File.FileSize, "Error": "", "Errors": 0}))
+
+		uploaded = append(uploaded, f)
+	}
+}
+
 // Uploads local files to a remote account
 func (w *Sync) upload(a entity.Service) (complete bool, err error) {
 	maxResults := 250
@@ -24,18 +75,35 @@ func (w *Sync) upload(a entity.Service) (complete bool, err error) {
 		return false, err
 	}
 
-	if len(files) == 0 {
+	// Find uploaded files that have been modified locally.
+	modified, err := query.ModifiedFileSyncs(a.ID, maxResults)
+
+	if err != nil {
+		return false, err
+	}
+
+	if len(files) == 0 && len(modified) == 0 {
 		log.Infof("sync: upload complete for %s", a.AccName)
 		event.Publish("sync.uploaded", event.Data{"account": a})
 		return true, nil
 	}
 
-	client, err := webdav.NewClient(a.AccURL, a.AccUser, a.AccPass, webdav.Timeout(a.AccTimeout))
+	client, err := a.Client()
 
 	if err != nil {
 		return false, err
 	}
 
+	defer client.Close()
+
+	w.uploadModified(a, client, modified)
+
+	var uploaded []*entity.FileSync
+
+	defer func() {
+		w.updateRemoteInfo(client, uploaded)
+	}()
+
 	for _, file := range files {
 		if mutex.SyncWorker.Canceled() {
 			return false, nil
@@ -62,6 +130,7 @@ func (w *Sync) upload(a entity.Service) (complete bool, err error) {
 		fileSync.RemoteDate = time.Now()
 		fileSync.RemoteSize = file.FileSize
 		fileSync.FileID = file.ID
+		fileSync.LocalHash = file.FileHash
 		fileSync.Error = ""
 		fileSync.Errors = 0
 
@@ -69,7 +138,11 @@ func (w *Sync) upload(a entity.Service) (complete bool, err error) {
 			return false, nil
 		}
 
-		w.logError(entity.Db().Save(&fileSync).Error)
+		if err := entity.Db().Save(&fileSync).Error; err != nil {
+			w.logError(err)
+		} else {
+			uploaded = append(uploaded, fileSync)
+		}
 	}
 
 	return false, nil
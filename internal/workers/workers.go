@@ -27,25 +27,33 @@ package workers
 import (
 	"time"
 
+	"github.com/dustin/go-humanize/english"
+
 	"github.com/photoprism/photoprism/internal/config"
 	"github.com/photoprism/photoprism/internal/entity"
 	"github.com/photoprism/photoprism/internal/event"
 	"github.com/photoprism/photoprism/internal/mutex"
+	"github.com/photoprism/photoprism/internal/upload"
+	"github.com/photoprism/photoprism/pkg/clean"
+	"github.com/photoprism/photoprism/pkg/cron"
 )
 
 var log = event.Log
 var stop = make(chan bool, 1)
+var stopBackups = make(chan bool, 1)
 
-// Start runs the metadata, share & sync background workers at regular intervals.
+// Start runs the metadata, share & sync background workers at regular intervals, and the index backup worker.
 func Start(conf *config.Config) {
 	interval := conf.WakeupInterval()
 
 	// Disabled in safe mode?
 	if interval.Seconds() <= 0 {
-		log.Warnf("config: disabled metadata, share & sync background workers")
+		log.Warnf("config: disabled metadata, share, sync & backup background workers")
 		return
 	}
 
+	StartBackups(conf)
+
 	ticker := time.NewTicker(interval)
 
 	go func() {
@@ -62,6 +70,7 @@ func Start(conf *config.Config) {
 				RunMeta(conf)
 				RunShare(conf)
 				RunSync(conf)
+				CleanUploads(conf)
 			}
 		}
 	}()
@@ -70,6 +79,47 @@ func Start(conf *config.Config) {
 // Stop shuts down all service workers.
 func Stop() {
 	stop <- true
+	stopBackups <- true
+}
+
+// StartBackups runs the index backup worker based on the configured schedule.
+func StartBackups(conf *config.Config) {
+	spec := conf.BackupSchedule()
+
+	if spec == "" {
+		return
+	}
+
+	schedule, err := cron.Parse(spec)
+
+	if err != nil {
+		log.Errorf("backup: %s", err)
+		return
+	}
+
+	log.Infof("backup: index backup schedule is %s", clean.Log(schedule.String()))
+
+	go func() {
+		for {
+			next := schedule.Next(time.Now())
+
+			if next.IsZero() {
+				log.Warnf("backup: schedule %s has no next run", clean.Log(schedule.String()))
+				return
+			}
+
+			timer := time.NewTimer(time.Until(next))
+
+			select {
+			case <-stopBackups:
+				timer.Stop()
+				mutex.BackupWorker.Cancel()
+				return
+			case <-timer.C:
+				RunBackup(conf)
+			}
+		}
+	}()
 }
 
 // RunMeta runs the metadata worker once.
@@ -88,6 +138,18 @@ func RunMeta(conf *config.Config) {
 	}
 }
 
+// RunBackup runs the index backup worker once.
+func RunBackup(conf *config.Config) {
+	if !mutex.BackupWorker.Running() {
+		go func() {
+			worker := NewBackup(conf)
+			if err := worker.Start(); err != nil {
+				log.Errorf("backup: %s", err)
+			}
+		}()
+	}
+}
+
 // RunShare runs the share worker once.
 func RunShare(conf *config.Config) {
 	if !mutex.ShareWorker.Running() {
@@ -111,3 +173,12 @@ func RunSync(conf *config.Config) {
 		}()
 	}
 }
+
+// CleanUploads removes resumable uploads that have been abandoned.
+func CleanUploads(conf *config.Config) {
+	if removed, err := upload.NewStore(conf.PartialUploadPath()).CleanUp(upload.MaxAge); err != nil {
+		log.Warnf("upload: %s", err)
+	} else if removed > 0 {
+		log.Infof("upload: removed %s", english.Plural(removed, "abandoned upload", "abandoned uploads"))
+	}
+}
@@ -2,12 +2,16 @@ package workers
 
 import (
 	"os"
+	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
 
 	"github.com/photoprism/photoprism/internal/config"
 	"github.com/photoprism/photoprism/internal/event"
+	"github.com/photoprism/photoprism/internal/upload"
 )
 
 func TestMain(m *testing.M) {
@@ -22,3 +26,30 @@ func TestMain(m *testing.M) {
 
 	os.Exit(code)
 }
+
+func TestCleanUploads(t *testing.T) {
+	conf := config.TestConfig()
+	store := upload.NewStore(conf.PartialUploadPath())
+
+	m, err := upload.NewSession("uqxetse3cy5eo9z2", "abc123", "abandoned.mp4", 100, "")
+
+	if err != nil {
+		t.Fatal(err)
+	} else if m, err = store.Create(m); err != nil {
+		t.Fatal(err)
+	}
+
+	past := time.Now().Add(-2 * upload.MaxAge)
+
+	for _, ext := range []string{".json", ".part"} {
+		if err = os.Chtimes(filepath.Join(conf.PartialUploadPath(), m.ID+ext), past, past); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	CleanUploads(conf)
+
+	_, err = store.Find(m.ID)
+
+	assert.Equal(t, upload.ErrNotFound, err)
+}
@@ -14,6 +14,7 @@ const (
 	ProviderDefault ProviderType = "default"
 	ProviderLocal   ProviderType = "local"
 	ProviderLDAP    ProviderType = "ldap"
+	ProviderOIDC    ProviderType = "oidc"
 	ProviderLink    ProviderType = "link"
 	ProviderNone    ProviderType = "none"
 	ProviderUnknown ProviderType = ""
@@ -22,6 +23,7 @@ const (
 // RemoteProviders lists all remote auth providers.
 var RemoteProviders = list.List{
 	string(ProviderLDAP),
+	string(ProviderOIDC),
 }
 
 // LocalProviders lists all local auth providers.
@@ -63,6 +65,8 @@ func (t ProviderType) Pretty() string {
 	switch t {
 	case ProviderLDAP:
 		return "LDAP/AD"
+	case ProviderOIDC:
+		return "OpenID Connect"
 	default:
 		return txt.UpperFirst(t.String())
 	}
@@ -79,6 +83,8 @@ func Provider(s string) ProviderType {
 		return ProviderLocal
 	case "ldap", "ad", "ldap/ad", "ldap\\ad":
 		return ProviderLDAP
+	case "oidc", "openid", "openid-connect", "openidconnect":
+		return ProviderOIDC
 	default:
 		return ProviderType(clean.TypeLower(s))
 	}
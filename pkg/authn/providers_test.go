@@ -13,3 +13,25 @@ func TestProviderType_String(t *testing.T) {
 	assert.Equal(t, "local", ProviderLocal.String())
 	assert.Equal(t, "ldap", ProviderLDAP.String())
 }
+
+func TestProviderType_IsRemote(t *testing.T) {
+	assert.False(t, ProviderDefault.IsRemote())
+	assert.False(t, ProviderLocal.IsRemote())
+	assert.True(t, ProviderLDAP.IsRemote())
+	assert.True(t, ProviderOIDC.IsRemote())
+}
+
+func TestProviderType_Pretty(t *testing.T) {
+	assert.Equal(t, "Local", ProviderLocal.Pretty())
+	assert.Equal(t, "LDAP/AD", ProviderLDAP.Pretty())
+	assert.Equal(t, "OpenID Connect", ProviderOIDC.Pretty())
+}
+
+func TestProvider(t *testing.T) {
+	assert.Equal(t, ProviderDefault, Provider(""))
+	assert.Equal(t, ProviderLocal, Provider("password"))
+	assert.Equal(t, ProviderLDAP, Provider("ad"))
+	assert.Equal(t, ProviderOIDC, Provider("oidc"))
+	assert.Equal(t, ProviderOIDC, Provider("openid"))
+	assert.Equal(t, ProviderOIDC, Provider("openid-connect"))
+}
@@ -0,0 +1,246 @@
+/*
+Package cron parses cron schedule expressions and calculates their next activation time.
+
+Copyright (c) 2018 - 2023 PhotoPrism UG. All rights reserved.
+
+	This program is free software: you can redistribute it and/or modify
+	it under Version 3 of the GNU Affero General Public License (the "AGPL"):
+	<https://docs.photoprism.app/license/agpl>
+
+	This program is distributed in the hope that it will be useful,
+	but WITHOUT ANY WARRANTY; without even the implied warranty of
+	MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+	GNU Affero General Public License for more details.
+
+	The AGPL is supplemented by our Trademark and Brand Guidelines,
+	which describe how our Brand Assets may be used:
+	<https://www.photoprism.app/trademark>
+
+Feel free to send an email to hello@photoprism.app if you have questions,
+want to support our work, or just want to say hello.
+
+Additional information can be found in our Developer Guide:
+<https://docs.photoprism.app/developer-guide/>
+*/
+package cron
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Descriptors maps predefined schedules to the equivalent cron expression.
+var Descriptors = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@hourly":   "0 * * * *",
+}
+
+// field represents the range and names of a schedule field.
+type field struct {
+	name  string
+	min   int
+	max   int
+	names map[string]int
+}
+
+var fields = []field{
+	{name: "minute", min: 0, max: 59},
+	{name: "hour", min: 0, max: 23},
+	{name: "day of month", min: 1, max: 31},
+	{name: "month", min: 1, max: 12, names: map[string]int{
+		"jan": 1, "feb": 2, "mar": 3, "apr": 4, "may": 5, "jun": 6,
+		"jul": 7, "aug": 8, "sep": 9, "oct": 10, "nov": 11, "dec": 12,
+	}},
+	{name: "day of week", min: 0, max: 7, names: map[string]int{
+		"sun": 0, "mon": 1, "tue": 2, "wed": 3, "thu": 4, "fri": 5, "sat": 6,
+	}},
+}
+
+// Schedule represents a parsed cron expression with the fields
+// minute, hour, day of month, month, and day of week.
+type Schedule struct {
+	spec   string
+	minute uint64
+	hour   uint64
+	dom    uint64
+	month  uint64
+	dow    uint64
+	anyDom bool
+	anyDow bool
+}
+
+// Parse parses a standard cron expression with five fields, e.g. "30 3 * * *",
+// or a predefined schedule such as "@daily".
+func Parse(spec string) (*Schedule, error) {
+	spec = strings.TrimSpace(spec)
+
+	if spec == "" {
+		return nil, fmt.Errorf("cron: empty schedule")
+	}
+
+	expr := strings.ToLower(spec)
+
+	if strings.HasPrefix(expr, "@") {
+		if d, ok := Descriptors[expr]; ok {
+			expr = d
+		} else {
+			return nil, fmt.Errorf("cron: unknown descriptor %s", spec)
+		}
+	}
+
+	values := strings.Fields(expr)
+
+	if len(values) != len(fields) {
+		return nil, fmt.Errorf("cron: expected %d fields, found %d in %s", len(fields), len(values), spec)
+	}
+
+	s := &Schedule{spec: spec}
+	bits := make([]uint64, len(fields))
+
+	for i, f := range fields {
+		b, err := f.parse(values[i])
+
+		if err != nil {
+			return nil, err
+		}
+
+		bits[i] = b
+	}
+
+	s.minute, s.hour, s.dom, s.month, s.dow = bits[0], bits[1], bits[2], bits[3], bits[4]
+
+	// Sunday may be specified as 0 or 7.
+	if s.dow&(1<<7) != 0 {
+		s.dow = (s.dow | 1) &^ (1 << 7)
+	}
+
+	s.anyDom = strings.HasPrefix(values[2], "*")
+	s.anyDow = strings.HasPrefix(values[4], "*")
+
+	return s, nil
+}
+
+// MustParse parses a cron expression and panics if it is invalid.
+func MustParse(spec string) *Schedule {
+	s, err := Parse(spec)
+
+	if err != nil {
+		panic(err)
+	}
+
+	return s
+}
+
+// String returns the schedule expression.
+func (s *Schedule) String() string {
+	return s.spec
+}
+
+// parse returns the bit set of values matching a comma-separated list.
+func (f field) parse(expr string) (result uint64, err error) {
+	for _, item := range strings.Split(expr, ",") {
+		start, end, step := f.min, f.max, 1
+
+		rangeExpr, stepExpr, hasStep := strings.Cut(item, "/")
+
+		if hasStep {
+			if step, err = strconv.Atoi(stepExpr); err != nil || step < 1 {
+				return 0, fmt.Errorf("cron: invalid %s step %s", f.name, item)
+			}
+		}
+
+		switch {
+		case rangeExpr == "*":
+			if f.name == "day of week" {
+				end = 6
+			}
+		case strings.Contains(rangeExpr, "-"):
+			from, to, _ := strings.Cut(rangeExpr, "-")
+
+			if start, err = f.value(from); err != nil {
+				return 0, err
+			} else if end, err = f.value(to); err != nil {
+				return 0, err
+			} else if start > end {
+				return 0, fmt.Errorf("cron: invalid %s range %s", f.name, item)
+			}
+		default:
+			if start, err = f.value(rangeExpr); err != nil {
+				return 0, err
+			}
+
+			// A single value with a step, e.g. "5/15", runs until the end of the range.
+			if !hasStep {
+				end = start
+			}
+		}
+
+		for i := start; i <= end; i += step {
+			result |= 1 << uint(i)
+		}
+	}
+
+	return result, nil
+}
+
+// value returns the numeric value of a name or number.
+func (f field) value(s string) (int, error) {
+	if v, ok := f.names[s]; ok {
+		return v, nil
+	}
+
+	v, err := strconv.Atoi(s)
+
+	if err != nil || v < f.min || v > f.max {
+		return 0, fmt.Errorf("cron: invalid %s %s", f.name, s)
+	}
+
+	return v, nil
+}
+
+// match checks if the value is contained in the bit set.
+func match(bits uint64, v int) bool {
+	return bits&(1<<uint(v)) != 0
+}
+
+// dayMatches checks if the day matches the schedule. As with the standard cron
+// implementation, a day matches either field if both are restricted.
+func (s *Schedule) dayMatches(t time.Time) bool {
+	dom := match(s.dom, t.Day())
+	dow := match(s.dow, int(t.Weekday()))
+
+	if s.anyDom || s.anyDow {
+		return dom && dow
+	}
+
+	return dom || dow
+}
+
+// Next returns the next activation time after t, or the zero time if there is none within five years.
+func (s *Schedule) Next(t time.Time) time.Time {
+	t = t.Truncate(time.Minute).Add(time.Minute)
+	limit := t.AddDate(5, 0, 0)
+
+	for t.Before(limit) {
+		if !match(s.month, int(t.Month())) {
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, t.Location())
+		} else if !s.dayMatches(t) {
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, t.Location())
+		} else if !match(s.hour, t.Hour()) {
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, t.Location())
+		} else if !match(s.minute, t.Minute()) {
+			t = t.Add(time.Minute)
+		} else {
+			return t
+		}
+	}
+
+	return time.Time{}
+}
@@ -0,0 +1,83 @@
+package cron
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParse(t *testing.T) {
+	t.Run("Daily", func(t *testing.T) {
+		s, err := Parse("30 3 * * *")
+
+		assert.NoError(t, err)
+		assert.Equal(t, "30 3 * * *", s.String())
+		assert.Equal(t, uint64(1<<30), s.minute)
+		assert.Equal(t, uint64(1<<3), s.hour)
+		assert.True(t, s.anyDom)
+		assert.True(t, s.anyDow)
+	})
+	t.Run("Descriptor", func(t *testing.T) {
+		s, err := Parse("@Daily")
+
+		assert.NoError(t, err)
+		assert.Equal(t, "@Daily", s.String())
+		assert.Equal(t, uint64(1), s.minute)
+	})
+	t.Run("ListsAndSteps", func(t *testing.T) {
+		s, err := Parse("*/15 1,13 1-10/3 jan-mar mon-fri")
+
+		assert.NoError(t, err)
+		assert.Equal(t, uint64(1|1<<15|1<<30|1<<45), s.minute)
+		assert.Equal(t, uint64(1<<1|1<<13), s.hour)
+		assert.Equal(t, uint64(1<<1|1<<4|1<<7|1<<10), s.dom)
+		assert.Equal(t, uint64(1<<1|1<<2|1<<3), s.month)
+		assert.Equal(t, uint64(0x3e), s.dow)
+	})
+	t.Run("Sunday", func(t *testing.T) {
+		s, err := Parse("0 0 * * 7")
+
+		assert.NoError(t, err)
+		assert.Equal(t, uint64(1), s.dow)
+	})
+	t.Run("Invalid", func(t *testing.T) {
+		for _, spec := range []string{"", "* * * *", "60 * * * *", "* 24 * * *", "* * 0 * *", "* * * 13 *", "* * * * 8", "5-1 * * * *", "*/0 * * * *", "@never", "x * * * *"} {
+			_, err := Parse(spec)
+			assert.Error(t, err, spec)
+		}
+	})
+}
+
+func TestMustParse(t *testing.T) {
+	assert.NotNil(t, MustParse("@hourly"))
+	assert.Panics(t, func() { MustParse("invalid") })
+}
+
+func TestSchedule_Next(t *testing.T) {
+	start := time.Date(2023, 1, 31, 10, 20, 30, 0, time.UTC)
+
+	t.Run("Hourly", func(t *testing.T) {
+		assert.Equal(t, time.Date(2023, 1, 31, 11, 0, 0, 0, time.UTC), MustParse("@hourly").Next(start))
+	})
+	t.Run("Daily", func(t *testing.T) {
+		assert.Equal(t, time.Date(2023, 2, 1, 3, 30, 0, 0, time.UTC), MustParse("30 3 * * *").Next(start))
+	})
+	t.Run("SameHour", func(t *testing.T) {
+		assert.Equal(t, time.Date(2023, 1, 31, 10, 30, 0, 0, time.UTC), MustParse("*/15 * * * *").Next(start))
+	})
+	t.Run("Weekly", func(t *testing.T) {
+		// January 31, 2023 was a Tuesday.
+		assert.Equal(t, time.Date(2023, 2, 5, 0, 0, 0, 0, time.UTC), MustParse("@weekly").Next(start))
+	})
+	t.Run("LeapDay", func(t *testing.T) {
+		assert.Equal(t, time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC), MustParse("0 0 29 2 *").Next(start))
+	})
+	t.Run("DayOfMonthOrWeek", func(t *testing.T) {
+		// Runs on the 15th and on every Friday.
+		assert.Equal(t, time.Date(2023, 2, 3, 0, 0, 0, 0, time.UTC), MustParse("0 0 15 * fri").Next(start))
+	})
+	t.Run("Never", func(t *testing.T) {
+		assert.True(t, MustParse("0 0 31 2 *").Next(start).IsZero())
+	})
+}
@@ -0,0 +1,24 @@
+package fs
+
+import (
+	"path"
+	"strings"
+	"time"
+)
+
+// ConflictSuffix is inserted before the file extension to mark conflicting copies.
+const ConflictSuffix = ".sync-conflict-"
+
+// ConflictName returns the name of a conflicting copy of a file, e.g. "IMG_1234.sync-conflict-20230102-150405.jpg".
+// Slash-separated remote names and local file names are both supported.
+func ConflictName(fileName string, t time.Time) string {
+	dir, base := path.Split(fileName)
+	ext := path.Ext(base)
+
+	return dir + strings.TrimSuffix(base, ext) + ConflictSuffix + t.UTC().Format("20060102-150405") + ext
+}
+
+// IsConflictName checks if the file name belongs to a conflicting copy.
+func IsConflictName(fileName string) bool {
+	return strings.Contains(path.Base(fileName), ConflictSuffix)
+}
@@ -0,0 +1,23 @@
+package fs
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConflictName(t *testing.T) {
+	ts := time.Date(2023, 1, 2, 15, 4, 5, 0, time.UTC)
+
+	assert.Equal(t, "/Photos/IMG_1234.sync-conflict-20230102-150405.jpg", ConflictName("/Photos/IMG_1234.jpg", ts))
+	assert.Equal(t, "IMG_1234.sync-conflict-20230102-150405.JPG", ConflictName("IMG_1234.JPG", ts))
+	assert.Equal(t, "/notes.sync-conflict-20230102-150405", ConflictName("/notes", ts))
+	assert.Equal(t, "/a.b/c.sync-conflict-20230102-150405.xmp", ConflictName("/a.b/c.xmp", ts))
+}
+
+func TestIsConflictName(t *testing.T) {
+	assert.True(t, IsConflictName("/Photos/IMG_1234.sync-conflict-20230102-150405.jpg"))
+	assert.False(t, IsConflictName("/Photos/IMG_1234.jpg"))
+	assert.False(t, IsConflictName("/a.sync-conflict-1/IMG_1234.jpg"))
+}
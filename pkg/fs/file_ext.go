@@ -7,12 +7,15 @@ import (
 
 const (
 	ExtYAML = ".yml"
+	ExtJSON = ".json"
+	ExtXMP  = ".xmp"
 	ExtJPEG = ".jpg"
 	ExtPNG  = ".png"
 	ExtDNG  = ".dng"
 	ExtTHM  = ".thm"
 	ExtAVC  = ".avc"
 	ExtMP4  = ".mp4"
+	ExtZip  = ".zip"
 )
 
 // Ext returns all extension of a file name including the dots.
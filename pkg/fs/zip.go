@@ -88,3 +88,31 @@ func Unzip(src, dest string) (fileNames []string, err error) {
 
 	return fileNames, nil
 }
+
+// UnzipFiles extracts the files with the specified names from a zip archive to the destination directory.
+func UnzipFiles(src, dest string, names map[string]bool) (fileNames []string, err error) {
+	r, err := zip.OpenReader(src)
+
+	if err != nil {
+		return fileNames, err
+	}
+
+	defer r.Close()
+
+	for _, f := range r.File {
+		// Skip files that were not requested and potentially malicious file names containing "..".
+		if !names[f.Name] || strings.Contains(f.Name, "..") {
+			continue
+		}
+
+		fn, err := copyToFile(f, dest)
+
+		if err != nil {
+			return fileNames, err
+		}
+
+		fileNames = append(fileNames, fn)
+	}
+
+	return fileNames, nil
+}
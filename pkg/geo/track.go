@@ -0,0 +1,156 @@
+package geo
+
+import (
+	"math"
+	"sort"
+	"time"
+)
+
+// Track represents a recorded path of positions in chronological order, e.g. from a GPS logger.
+type Track []Position
+
+// NewTrack returns a new track with the specified positions in chronological order,
+// positions without time or coordinates are skipped.
+func NewTrack(positions ...Position) Track {
+	result := make(Track, 0, len(positions))
+
+	for _, p := range positions {
+		if p.Time.IsZero() || p.Lat == 0 && p.Lng == 0 {
+			continue
+		}
+
+		p.Time = p.Time.UTC()
+		result = append(result, p)
+	}
+
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].Time.Before(result[j].Time)
+	})
+
+	return result
+}
+
+// Merge returns a new track that contains the positions of both tracks.
+func (t Track) Merge(other Track) Track {
+	positions := make([]Position, 0, len(t)+len(other))
+	positions = append(positions, t...)
+	positions = append(positions, other...)
+
+	return NewTrack(positions...)
+}
+
+// Empty checks if the track has no positions.
+func (t Track) Empty() bool {
+	return len(t) == 0
+}
+
+// Start returns the time of the first position.
+func (t Track) Start() time.Time {
+	if t.Empty() {
+		return time.Time{}
+	}
+
+	return t[0].Time
+}
+
+// End returns the time of the last position.
+func (t Track) End() time.Time {
+	if t.Empty() {
+		return time.Time{}
+	}
+
+	return t[len(t)-1].Time
+}
+
+// Km returns the track length in km.
+func (t Track) Km() (km float64) {
+	for i := 1; i < len(t); i++ {
+		km += math.Abs(Km(t[i-1], t[i]))
+	}
+
+	return km
+}
+
+// Position returns the position at the specified time, interpolated between the recorded positions.
+// Recorded positions are only used if they are less than maxGap apart from each other or the specified time.
+func (t Track) Position(at time.Time, maxGap time.Duration) (pos Position, ok bool) {
+	if t.Empty() || maxGap <= 0 {
+		return pos, false
+	}
+
+	at = at.UTC()
+
+	// Find the first position recorded at or after the specified time.
+	i := sort.Search(len(t), func(i int) bool {
+		return !t[i].Time.Before(at)
+	})
+
+	switch {
+	case i < len(t) && t[i].Time.Equal(at):
+		pos = t[i]
+	case i == 0:
+		if t[0].Time.Sub(at) > maxGap {
+			return pos, false
+		}
+
+		pos = t[0]
+	case i == len(t):
+		if at.Sub(t[i-1].Time) > maxGap {
+			return pos, false
+		}
+
+		pos = t[i-1]
+	default:
+		prev, next := t[i-1], t[i]
+
+		if gap := next.Time.Sub(prev.Time); gap <= maxGap {
+			pos = interpolate(prev, next, at)
+		} else if d := at.Sub(prev.Time); d <= next.Time.Sub(at) && d <= maxGap {
+			pos = prev
+		} else if d = next.Time.Sub(at); d <= maxGap {
+			pos = next
+		} else {
+			return pos, false
+		}
+	}
+
+	pos.Name = "track"
+	pos.Time = at
+	pos.Estimate = false
+
+	return pos, true
+}
+
+// interpolate returns the linearly interpolated position between two positions at the specified time.
+func interpolate(prev, next Position, at time.Time) Position {
+	d := next.Time.Sub(prev.Time)
+
+	if d <= 0 {
+		return prev
+	}
+
+	f := float64(at.Sub(prev.Time)) / float64(d)
+
+	pos := Position{
+		Lat: prev.Lat + (next.Lat-prev.Lat)*f,
+		Lng: prev.Lng + (next.Lng-prev.Lng)*f,
+	}
+
+	// Interpolate altitude only if both positions have one.
+	if prev.Altitude != 0 && next.Altitude != 0 {
+		pos.Altitude = prev.Altitude + (next.Altitude-prev.Altitude)*f
+	} else if prev.Altitude != 0 {
+		pos.Altitude = prev.Altitude
+	} else {
+		pos.Altitude = next.Altitude
+	}
+
+	// Use the lower accuracy of both positions.
+	if prev.Accuracy > next.Accuracy {
+		pos.Accuracy = prev.Accuracy
+	} else {
+		pos.Accuracy = next.Accuracy
+	}
+
+	return pos
+}
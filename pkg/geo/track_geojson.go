@@ -0,0 +1,205 @@
+package geo
+
+import (
+	"encoding/json"
+	"io"
+	"time"
+)
+
+// geoJSON represents a GeoJSON object, i.e. a feature collection, feature, or geometry.
+type geoJSON struct {
+	Type        string                     `json:"type"`
+	Features    []geoJSON                  `json:"features,omitempty"`
+	Geometry    *geoJSON                   `json:"geometry,omitempty"`
+	Geometries  []geoJSON                  `json:"geometries,omitempty"`
+	Coordinates json.RawMessage            `json:"coordinates,omitempty"`
+	Properties  map[string]json.RawMessage `json:"properties,omitempty"`
+}
+
+// ReadGeoJSON reads the timestamped positions of a GeoJSON file. Timestamps are expected in the
+// "coordTimes" or "times" property of line features, and in the "time" property of point features.
+func ReadGeoJSON(r io.Reader) (Track, error) {
+	var doc geoJSON
+
+	if err := json.NewDecoder(r).Decode(&doc); err != nil {
+		return nil, err
+	}
+
+	var positions []Position
+
+	doc.positions(&positions, nil)
+
+	return NewTrack(positions...), nil
+}
+
+// positions appends the timestamped positions of the object and its children.
+func (g *geoJSON) positions(result *[]Position, props map[string]json.RawMessage) {
+	switch g.Type {
+	case "FeatureCollection":
+		for i := range g.Features {
+			g.Features[i].positions(result, nil)
+		}
+	case "Feature":
+		if g.Geometry != nil {
+			g.Geometry.positions(result, g.Properties)
+		}
+	case "GeometryCollection":
+		for i := range g.Geometries {
+			g.Geometries[i].positions(result, props)
+		}
+	case "Point":
+		var c []float64
+
+		if err := json.Unmarshal(g.Coordinates, &c); err != nil {
+			return
+		} else if t := geoJSONTime(props, "time", "timestamp"); !t.IsZero() {
+			if pos, ok := geoJSONPosition(c); ok {
+				pos.Time = t
+				*result = append(*result, pos)
+			}
+		}
+	case "LineString":
+		var c [][]float64
+
+		if err := json.Unmarshal(g.Coordinates, &c); err != nil {
+			return
+		}
+
+		appendGeoJSONLine(result, c, geoJSONTimes(props))
+	case "MultiLineString":
+		var c [][][]float64
+
+		if err := json.Unmarshal(g.Coordinates, &c); err != nil {
+			return
+		}
+
+		var times [][]json.RawMessage
+
+		for _, key := range []string{"coordTimes", "times"} {
+			if raw, ok := props[key]; ok {
+				_ = json.Unmarshal(raw, &times)
+				break
+			}
+		}
+
+		for i, line := range c {
+			var lineTimes []time.Time
+
+			if i < len(times) {
+				lineTimes = parseGeoJSONTimes(times[i])
+			}
+
+			appendGeoJSONLine(result, line, lineTimes)
+		}
+	}
+}
+
+// appendGeoJSONLine appends the positions of a line that have a matching timestamp.
+func appendGeoJSONLine(result *[]Position, coords [][]float64, times []time.Time) {
+	for i := 0; i < len(coords) && i < len(times); i++ {
+		if pos, ok := geoJSONPosition(coords[i]); ok {
+			pos.Time = times[i]
+			*result = append(*result, pos)
+		}
+	}
+}
+
+// geoJSONPosition returns the position of GeoJSON coordinates in the order longitude, latitude, and altitude.
+func geoJSONPosition(c []float64) (pos Position, ok bool) {
+	if len(c) < 2 {
+		return pos, false
+	}
+
+	pos.Lng, pos.Lat = c[0], c[1]
+
+	if len(c) > 2 {
+		pos.Altitude = c[2]
+	}
+
+	return pos, true
+}
+
+// geoJSONTimes returns the timestamps of line coordinates.
+func geoJSONTimes(props map[string]json.RawMessage) []time.Time {
+	for _, key := range []string{"coordTimes", "times"} {
+		if raw, ok := props[key]; ok {
+			var values []json.RawMessage
+
+			if err := json.Unmarshal(raw, &values); err == nil {
+				return parseGeoJSONTimes(values)
+			}
+		}
+	}
+
+	return nil
+}
+
+// geoJSONTime returns the timestamp in the first matching property.
+func geoJSONTime(props map[string]json.RawMessage, keys ...string) time.Time {
+	for _, key := range keys {
+		if raw, ok := props[key]; ok {
+			return parseGeoJSONTime(raw)
+		}
+	}
+
+	return time.Time{}
+}
+
+// parseGeoJSONTimes parses a list of timestamps, invalid timestamps are returned as zero time.
+func parseGeoJSONTimes(values []json.RawMessage) []time.Time {
+	result := make([]time.Time, len(values))
+
+	for i, raw := range values {
+		result[i] = parseGeoJSONTime(raw)
+	}
+
+	return result
+}
+
+// parseGeoJSONTime parses a timestamp string or a Unix timestamp in milliseconds.
+func parseGeoJSONTime(raw json.RawMessage) time.Time {
+	var s string
+	var ms int64
+
+	if err := json.Unmarshal(raw, &s); err == nil {
+		if t, err := parseTrackTime(s); err == nil {
+			return t
+		}
+	} else if err = json.Unmarshal(raw, &ms); err == nil && ms > 0 {
+		return time.UnixMilli(ms).UTC()
+	}
+
+	return time.Time{}
+}
+
+// GeoJSON returns the track as GeoJSON line feature with the timestamps in the "coordTimes" property.
+func (t Track) GeoJSON(name string) ([]byte, error) {
+	coords := make([][]float64, len(t))
+	times := make([]string, len(t))
+
+	for i, p := range t {
+		if p.Altitude != 0 {
+			coords[i] = []float64{p.Lng, p.Lat, p.Altitude}
+		} else {
+			coords[i] = []float64{p.Lng, p.Lat}
+		}
+
+		times[i] = p.Time.UTC().Format(time.RFC3339)
+	}
+
+	feature := map[string]interface{}{
+		"type": "Feature",
+		"geometry": map[string]interface{}{
+			"type":        "LineString",
+			"coordinates": coords,
+		},
+		"properties": map[string]interface{}{
+			"name":       name,
+			"start":      t.Start().Format(time.RFC3339),
+			"end":        t.End().Format(time.RFC3339),
+			"coordTimes": times,
+		},
+	}
+
+	return json.Marshal(feature)
+}
@@ -0,0 +1,69 @@
+package geo
+
+import (
+	"encoding/xml"
+	"io"
+)
+
+// gpxPoint represents a track, route, or waypoint in a GPX file.
+type gpxPoint struct {
+	Lat  float64 `xml:"lat,attr"`
+	Lon  float64 `xml:"lon,attr"`
+	Ele  float64 `xml:"ele"`
+	Time string  `xml:"time"`
+	Name string  `xml:"name"`
+}
+
+// gpxFile represents the contents of a GPX file, see https://www.topografix.com/GPX/1/1/.
+type gpxFile struct {
+	Waypoints []gpxPoint `xml:"wpt"`
+	Routes    []struct {
+		Points []gpxPoint `xml:"rtept"`
+	} `xml:"rte"`
+	Tracks []struct {
+		Segments []struct {
+			Points []gpxPoint `xml:"trkpt"`
+		} `xml:"trkseg"`
+	} `xml:"trk"`
+}
+
+// ReadGPX reads the timestamped track, route, and waypoints of a GPX file.
+func ReadGPX(r io.Reader) (Track, error) {
+	var doc gpxFile
+
+	if err := xml.NewDecoder(r).Decode(&doc); err != nil {
+		return nil, err
+	}
+
+	var points []gpxPoint
+
+	for _, trk := range doc.Tracks {
+		for _, seg := range trk.Segments {
+			points = append(points, seg.Points...)
+		}
+	}
+
+	for _, rte := range doc.Routes {
+		points = append(points, rte.Points...)
+	}
+
+	points = append(points, doc.Waypoints...)
+
+	positions := make([]Position, 0, len(points))
+
+	for _, p := range points {
+		if p.Time == "" {
+			continue
+		}
+
+		t, err := parseTrackTime(p.Time)
+
+		if err != nil {
+			continue
+		}
+
+		positions = append(positions, Position{Name: p.Name, Time: t, Lat: p.Lat, Lng: p.Lon, Altitude: p.Ele})
+	}
+
+	return NewTrack(positions...), nil
+}
@@ -0,0 +1,107 @@
+package geo
+
+import (
+	"encoding/xml"
+	"io"
+	"strings"
+	"time"
+)
+
+// ReadKML reads the timestamped positions of a KML file, i.e. gx:Track elements
+// and placemarks with a point and a timestamp.
+func ReadKML(r io.Reader) (Track, error) {
+	dec := xml.NewDecoder(r)
+
+	var positions []Position
+	var stack []string
+	var text strings.Builder
+
+	// Current gx:Track.
+	var whens []time.Time
+	var coords []Position
+
+	// Current placemark.
+	var pmTime time.Time
+	var pmPos *Position
+	var pmName string
+
+	parent := func() string {
+		if len(stack) < 2 {
+			return ""
+		}
+
+		return stack[len(stack)-2]
+	}
+
+	for {
+		tok, err := dec.Token()
+
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, err
+		}
+
+		switch el := tok.(type) {
+		case xml.StartElement:
+			stack = append(stack, el.Name.Local)
+			text.Reset()
+
+			switch el.Name.Local {
+			case "Track":
+				whens, coords = nil, nil
+			case "Placemark":
+				pmTime, pmPos, pmName = time.Time{}, nil, ""
+			}
+		case xml.CharData:
+			text.Write(el)
+		case xml.EndElement:
+			s := strings.TrimSpace(text.String())
+			text.Reset()
+
+			switch el.Name.Local {
+			case "when":
+				if t, err := parseTrackTime(s); err != nil {
+					// Ignore invalid timestamps.
+				} else if parent() == "Track" {
+					whens = append(whens, t)
+				} else if parent() == "TimeStamp" {
+					pmTime = t
+				}
+			case "coord":
+				if pos, err := parseCoordinates(s, ""); err == nil {
+					coords = append(coords, pos)
+				}
+			case "coordinates":
+				if parent() == "Point" {
+					if pos, err := parseCoordinates(s, ","); err == nil {
+						pmPos = &pos
+					}
+				}
+			case "name":
+				if parent() == "Placemark" {
+					pmName = s
+				}
+			case "Track":
+				for i := 0; i < len(whens) && i < len(coords); i++ {
+					pos := coords[i]
+					pos.Time = whens[i]
+					positions = append(positions, pos)
+				}
+			case "Placemark":
+				if pmPos != nil && !pmTime.IsZero() {
+					pos := *pmPos
+					pos.Name = pmName
+					pos.Time = pmTime
+					positions = append(positions, pos)
+				}
+			}
+
+			if len(stack) > 0 {
+				stack = stack[:len(stack)-1]
+			}
+		}
+	}
+
+	return NewTrack(positions...), nil
+}
@@ -0,0 +1,116 @@
+package geo
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Track file formats.
+const (
+	TrackGPX     = "gpx"
+	TrackKML     = "kml"
+	TrackGeoJSON = "geojson"
+)
+
+// TrackFormats maps file extensions to track file formats.
+var TrackFormats = map[string]string{
+	".gpx":     TrackGPX,
+	".kml":     TrackKML,
+	".geojson": TrackGeoJSON,
+	".json":    TrackGeoJSON,
+}
+
+// ErrNoTrack is returned if a file does not contain any positions with time information.
+var ErrNoTrack = errors.New("no positions with time information found")
+
+// TrackFormat returns the track file format based on the file extension, or an empty string if unsupported.
+func TrackFormat(fileName string) string {
+	return TrackFormats[strings.ToLower(filepath.Ext(fileName))]
+}
+
+// ReadTrackFile reads a track from a GPX, KML, or GeoJSON file.
+func ReadTrackFile(fileName string) (Track, error) {
+	format := TrackFormat(fileName)
+
+	if format == "" {
+		return nil, fmt.Errorf("unsupported track file format %s", filepath.Ext(fileName))
+	}
+
+	f, err := os.Open(fileName)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer f.Close()
+
+	return ReadTrack(f, format)
+}
+
+// ReadTrack reads a track in the specified format.
+func ReadTrack(r io.Reader, format string) (t Track, err error) {
+	switch format {
+	case TrackGPX:
+		t, err = ReadGPX(r)
+	case TrackKML:
+		t, err = ReadKML(r)
+	case TrackGeoJSON:
+		t, err = ReadGeoJSON(r)
+	default:
+		return nil, fmt.Errorf("unsupported track file format %s", format)
+	}
+
+	if err != nil {
+		return nil, err
+	} else if t.Empty() {
+		return nil, ErrNoTrack
+	}
+
+	return t, nil
+}
+
+// parseTrackTime parses a timestamp as found in track files.
+func parseTrackTime(s string) (time.Time, error) {
+	s = strings.TrimSpace(s)
+
+	for _, layout := range []string{time.RFC3339Nano, "2006-01-02T15:04:05", "2006-01-02 15:04:05"} {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t.UTC(), nil
+		}
+	}
+
+	return time.Time{}, fmt.Errorf("invalid time %s", s)
+}
+
+// parseCoordinates parses longitude, latitude, and optional altitude separated by sep, e.g. "13.4,52.5,34".
+func parseCoordinates(s, sep string) (pos Position, err error) {
+	var fields []string
+
+	if sep == "" {
+		fields = strings.Fields(s)
+	} else {
+		fields = strings.Split(strings.TrimSpace(s), sep)
+	}
+
+	if len(fields) < 2 {
+		return pos, fmt.Errorf("invalid coordinates %s", s)
+	}
+
+	if pos.Lng, err = strconv.ParseFloat(strings.TrimSpace(fields[0]), 64); err != nil {
+		return pos, fmt.Errorf("invalid longitude %s", fields[0])
+	} else if pos.Lat, err = strconv.ParseFloat(strings.TrimSpace(fields[1]), 64); err != nil {
+		return pos, fmt.Errorf("invalid latitude %s", fields[1])
+	}
+
+	if len(fields) > 2 {
+		pos.Altitude, _ = strconv.ParseFloat(strings.TrimSpace(fields[2]), 64)
+	}
+
+	return pos, nil
+}
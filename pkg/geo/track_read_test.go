@@ -0,0 +1,206 @@
+package geo
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const testGPX = `<?xml version="1.0" encoding="UTF-8"?>
+<gpx version="1.1" creator="Test" xmlns="http://www.topografix.com/GPX/1/1">
+  <wpt lat="52.5300" lon="13.4300"><name>Summit</name><time>2023-05-17T10:30:00Z</time></wpt>
+  <wpt lat="52.5400" lon="13.4400"><name>No Time</name></wpt>
+  <trk>
+    <name>Hike</name>
+    <trkseg>
+      <trkpt lat="52.5200" lon="13.4200"><ele>40</ele><time>2023-05-17T10:10:00Z</time></trkpt>
+      <trkpt lat="52.5000" lon="13.4000"><ele>30.5</ele><time>2023-05-17T10:00:00Z</time></trkpt>
+    </trkseg>
+  </trk>
+</gpx>`
+
+const testKML = `<?xml version="1.0" encoding="UTF-8"?>
+<kml xmlns="http://www.opengis.net/kml/2.2" xmlns:gx="http://www.google.com/kml/ext/2.2">
+  <Document>
+    <Folder>
+      <Placemark>
+        <name>Hike</name>
+        <gx:Track>
+          <when>2023-05-17T10:00:00Z</when>
+          <when>2023-05-17T10:10:00Z</when>
+          <gx:coord>13.40 52.50 30</gx:coord>
+          <gx:coord>13.42 52.52 40</gx:coord>
+        </gx:Track>
+      </Placemark>
+      <Placemark>
+        <name>Summit</name>
+        <TimeStamp><when>2023-05-17T10:30:00Z</when></TimeStamp>
+        <Point><coordinates>13.43,52.53,50</coordinates></Point>
+      </Placemark>
+      <Placemark>
+        <name>Route</name>
+        <LineString><coordinates>13.40,52.50 13.42,52.52</coordinates></LineString>
+      </Placemark>
+    </Folder>
+  </Document>
+</kml>`
+
+const testGeoJSON = `{
+  "type": "FeatureCollection",
+  "features": [
+    {
+      "type": "Feature",
+      "properties": {"name": "Hike", "coordTimes": ["2023-05-17T10:00:00Z", "2023-05-17T10:10:00Z"]},
+      "geometry": {"type": "LineString", "coordinates": [[13.40, 52.50, 30], [13.42, 52.52, 40]]}
+    },
+    {
+      "type": "Feature",
+      "properties": {"name": "Summit", "time": 1684319400000},
+      "geometry": {"type": "Point", "coordinates": [13.43, 52.53]}
+    },
+    {
+      "type": "Feature",
+      "properties": {"name": "No Time"},
+      "geometry": {"type": "Point", "coordinates": [13.44, 52.54]}
+    }
+  ]
+}`
+
+func assertTestTrack(t *testing.T, track Track) {
+	t.Helper()
+
+	if assert.Len(t, track, 3) {
+		assert.Equal(t, time.Date(2023, 5, 17, 10, 0, 0, 0, time.UTC), track[0].Time)
+		assert.Equal(t, 52.50, track[0].Lat)
+		assert.Equal(t, 13.40, track[0].Lng)
+		assert.InDelta(t, 30, track[0].Altitude, 1)
+		assert.Equal(t, 52.52, track[1].Lat)
+		assert.Equal(t, time.Date(2023, 5, 17, 10, 30, 0, 0, time.UTC), track[2].Time)
+		assert.Equal(t, 52.53, track[2].Lat)
+	}
+}
+
+func TestTrackFormat(t *testing.T) {
+	assert.Equal(t, TrackGPX, TrackFormat("/tracks/hike.GPX"))
+	assert.Equal(t, TrackKML, TrackFormat("hike.kml"))
+	assert.Equal(t, TrackGeoJSON, TrackFormat("hike.geojson"))
+	assert.Equal(t, "", TrackFormat("hike.kmz"))
+}
+
+func TestReadGPX(t *testing.T) {
+	track, err := ReadGPX(strings.NewReader(testGPX))
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assertTestTrack(t, track)
+	assert.Equal(t, "Summit", track[2].Name)
+}
+
+func TestReadKML(t *testing.T) {
+	track, err := ReadKML(strings.NewReader(testKML))
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assertTestTrack(t, track)
+	assert.Equal(t, "Summit", track[2].Name)
+}
+
+func TestReadGeoJSON(t *testing.T) {
+	t.Run("FeatureCollection", func(t *testing.T) {
+		track, err := ReadGeoJSON(strings.NewReader(testGeoJSON))
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assertTestTrack(t, track)
+	})
+	t.Run("MultiLineString", func(t *testing.T) {
+		data := `{"type": "Feature", "properties": {"coordTimes": [["2023-05-17T10:00:00Z"], ["2023-05-17T10:10:00Z"]]},
+			"geometry": {"type": "MultiLineString", "coordinates": [[[13.40, 52.50]], [[13.42, 52.52]]]}}`
+
+		track, err := ReadGeoJSON(strings.NewReader(data))
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Len(t, track, 2)
+	})
+	t.Run("InvalidJSON", func(t *testing.T) {
+		_, err := ReadGeoJSON(strings.NewReader("{"))
+		assert.Error(t, err)
+	})
+}
+
+func TestReadTrack(t *testing.T) {
+	t.Run("NoTime", func(t *testing.T) {
+		_, err := ReadTrack(strings.NewReader(`<gpx><wpt lat="52.5" lon="13.4"></wpt></gpx>`), TrackGPX)
+		assert.Equal(t, ErrNoTrack, err)
+	})
+	t.Run("Unsupported", func(t *testing.T) {
+		_, err := ReadTrack(strings.NewReader(testGPX), "kmz")
+		assert.Error(t, err)
+	})
+}
+
+func TestReadTrackFile(t *testing.T) {
+	dir := t.TempDir()
+
+	t.Run("GPX", func(t *testing.T) {
+		fileName := filepath.Join(dir, "hike.gpx")
+
+		if err := os.WriteFile(fileName, []byte(testGPX), 0o644); err != nil {
+			t.Fatal(err)
+		}
+
+		track, err := ReadTrackFile(fileName)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assertTestTrack(t, track)
+	})
+	t.Run("Unsupported", func(t *testing.T) {
+		_, err := ReadTrackFile(filepath.Join(dir, "hike.txt"))
+		assert.Error(t, err)
+	})
+	t.Run("NotFound", func(t *testing.T) {
+		_, err := ReadTrackFile(filepath.Join(dir, "missing.gpx"))
+		assert.Error(t, err)
+	})
+}
+
+func TestTrack_GeoJSON(t *testing.T) {
+	track, err := ReadGPX(strings.NewReader(testGPX))
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := track.GeoJSON("Hike")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Contains(t, string(data), `"name":"Hike"`)
+	assert.Contains(t, string(data), `"start":"2023-05-17T10:00:00Z"`)
+
+	result, err := ReadGeoJSON(strings.NewReader(string(data)))
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assertTestTrack(t, result)
+}
@@ -0,0 +1,118 @@
+package geo
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func testTrack() Track {
+	start := time.Date(2023, 5, 17, 10, 0, 0, 0, time.UTC)
+
+	return NewTrack(
+		Position{Time: start.Add(10 * time.Minute), Lat: 52.52, Lng: 13.42, Altitude: 40},
+		Position{Time: start, Lat: 52.50, Lng: 13.40, Altitude: 30},
+		Position{Time: start.Add(5 * time.Minute), Lat: 52.51, Lng: 13.41},
+		Position{Time: start.Add(2 * time.Hour), Lat: 52.60, Lng: 13.50, Altitude: 50},
+		Position{Lat: 52.70, Lng: 13.60},
+		Position{Time: start.Add(3 * time.Hour)},
+	)
+}
+
+func TestNewTrack(t *testing.T) {
+	track := testTrack()
+
+	assert.Len(t, track, 4)
+	assert.False(t, track.Empty())
+	assert.Equal(t, time.Date(2023, 5, 17, 10, 0, 0, 0, time.UTC), track.Start())
+	assert.Equal(t, time.Date(2023, 5, 17, 12, 0, 0, 0, time.UTC), track.End())
+	assert.Equal(t, 52.51, track[1].Lat)
+}
+
+func TestTrack_Merge(t *testing.T) {
+	track := testTrack()
+	other := NewTrack(Position{Time: time.Date(2023, 5, 17, 9, 0, 0, 0, time.UTC), Lat: 52.4, Lng: 13.3})
+
+	result := track.Merge(other)
+
+	assert.Len(t, result, 5)
+	assert.Equal(t, 52.4, result[0].Lat)
+	assert.Len(t, track, 4)
+}
+
+func TestTrack_Km(t *testing.T) {
+	assert.InDelta(t, 13.0, testTrack().Km(), 1.0)
+	assert.Equal(t, 0.0, Track{}.Km())
+}
+
+func TestTrack_Position(t *testing.T) {
+	track := testTrack()
+	start := track.Start()
+	maxGap := 15 * time.Minute
+
+	t.Run("Exact", func(t *testing.T) {
+		pos, ok := track.Position(start.Add(5*time.Minute), maxGap)
+
+		assert.True(t, ok)
+		assert.Equal(t, 52.51, pos.Lat)
+		assert.Equal(t, 13.41, pos.Lng)
+		assert.False(t, pos.Estimate)
+	})
+	t.Run("Interpolated", func(t *testing.T) {
+		pos, ok := track.Position(start.Add(150*time.Second), maxGap)
+
+		assert.True(t, ok)
+		assert.InDelta(t, 52.505, pos.Lat, 0.00001)
+		assert.InDelta(t, 13.405, pos.Lng, 0.00001)
+		assert.Equal(t, 30.0, pos.Altitude)
+		assert.Equal(t, start.Add(150*time.Second), pos.Time)
+	})
+	t.Run("InterpolatedAltitude", func(t *testing.T) {
+		pos, ok := track.Position(start.Add(7*time.Minute+30*time.Second), maxGap)
+
+		assert.True(t, ok)
+		assert.InDelta(t, 52.515, pos.Lat, 0.00001)
+		assert.Equal(t, 40.0, pos.Altitude)
+	})
+	t.Run("GapNearStart", func(t *testing.T) {
+		pos, ok := track.Position(start.Add(20*time.Minute), maxGap)
+
+		assert.True(t, ok)
+		assert.Equal(t, 52.52, pos.Lat)
+	})
+	t.Run("GapNearEnd", func(t *testing.T) {
+		pos, ok := track.Position(start.Add(110*time.Minute), maxGap)
+
+		assert.True(t, ok)
+		assert.Equal(t, 52.60, pos.Lat)
+	})
+	t.Run("GapTooLarge", func(t *testing.T) {
+		_, ok := track.Position(start.Add(time.Hour), maxGap)
+		assert.False(t, ok)
+	})
+	t.Run("BeforeStart", func(t *testing.T) {
+		pos, ok := track.Position(start.Add(-10*time.Minute), maxGap)
+
+		assert.True(t, ok)
+		assert.Equal(t, 52.50, pos.Lat)
+
+		_, ok = track.Position(start.Add(-20*time.Minute), maxGap)
+		assert.False(t, ok)
+	})
+	t.Run("AfterEnd", func(t *testing.T) {
+		_, ok := track.Position(track.End().Add(20*time.Minute), maxGap)
+		assert.False(t, ok)
+	})
+	t.Run("LocalTime", func(t *testing.T) {
+		loc := time.FixedZone("CEST", 2*3600)
+		pos, ok := track.Position(start.In(loc), maxGap)
+
+		assert.True(t, ok)
+		assert.Equal(t, 52.50, pos.Lat)
+	})
+	t.Run("Empty", func(t *testing.T) {
+		_, ok := Track{}.Position(start, maxGap)
+		assert.False(t, ok)
+	})
+}
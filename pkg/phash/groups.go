@@ -0,0 +1,92 @@
+package phash
+
+import (
+	"sort"
+)
+
+// Hashes represents a list of perceptual hashes.
+type Hashes []Hash
+
+// Groups returns the indexes of similar hashes grouped together, ignoring hashes without a similar match.
+func (list Hashes) Groups(maxDistance int) (groups [][]int) {
+	if len(list) < 2 {
+		return groups
+	}
+
+	if maxDistance < 0 {
+		maxDistance = 0
+	} else if maxDistance > MaxDistance {
+		maxDistance = MaxDistance
+	}
+
+	parent := make([]int, len(list))
+
+	for i := range parent {
+		parent[i] = i
+	}
+
+	var find func(i int) int
+
+	find = func(i int) int {
+		if parent[i] != i {
+			parent[i] = find(parent[i])
+		}
+
+		return parent[i]
+	}
+
+	union := func(a, b int) {
+		if ra, rb := find(a), find(b); ra < rb {
+			parent[rb] = ra
+		} else if rb < ra {
+			parent[ra] = rb
+		}
+	}
+
+	// If two hashes differ in no more than n bits, at least one of n+1 blocks
+	// must be identical, so only hashes with a common block need to be compared.
+	blocks := maxDistance + 1
+
+	for b := 0; b < blocks; b++ {
+		var mask Hash
+
+		for i := b * 64 / blocks; i < (b+1)*64/blocks; i++ {
+			mask |= 1 << uint(i)
+		}
+
+		buckets := make(map[Hash][]int)
+
+		for i, h := range list {
+			buckets[h&mask] = append(buckets[h&mask], i)
+		}
+
+		for _, bucket := range buckets {
+			for i := 0; i < len(bucket); i++ {
+				for j := i + 1; j < len(bucket); j++ {
+					if list[bucket[i]].Distance(list[bucket[j]]) <= maxDistance {
+						union(bucket[i], bucket[j])
+					}
+				}
+			}
+		}
+	}
+
+	members := make(map[int][]int)
+
+	for i := range list {
+		root := find(i)
+		members[root] = append(members[root], i)
+	}
+
+	for _, m := range members {
+		if len(m) > 1 {
+			groups = append(groups, m)
+		}
+	}
+
+	sort.Slice(groups, func(i, j int) bool {
+		return groups[i][0] < groups[j][0]
+	})
+
+	return groups
+}
@@ -0,0 +1,40 @@
+package phash
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHashes_Groups(t *testing.T) {
+	t.Run("Empty", func(t *testing.T) {
+		assert.Empty(t, Hashes{}.Groups(DefaultDistance))
+		assert.Empty(t, Hashes{0xff}.Groups(DefaultDistance))
+	})
+	t.Run("Similar", func(t *testing.T) {
+		list := Hashes{
+			0xf0f0f0f0f0f0f0f0,
+			0x0123456789abcdef,
+			0xf0f0f0f0f0f0f0f1,
+			0x0123456789abcdee,
+			0xffffffff00000000,
+			0xf0f0f0f0f0f0f0f3,
+		}
+
+		assert.Equal(t, [][]int{{0, 2, 5}, {1, 3}}, list.Groups(2))
+		assert.Empty(t, list.Groups(0))
+	})
+	t.Run("Transitive", func(t *testing.T) {
+		list := Hashes{0x0, 0x3, 0xf}
+
+		assert.Equal(t, [][]int{{0, 1, 2}}, list.Groups(2))
+		assert.Empty(t, list.Groups(1))
+	})
+	t.Run("Spread", func(t *testing.T) {
+		// Bits differ in every block, so only the full comparison finds them.
+		list := Hashes{0x0, 0x8080808080808080}
+
+		assert.Equal(t, [][]int{{0, 1}}, list.Groups(8))
+		assert.Empty(t, list.Groups(7))
+	})
+}
@@ -0,0 +1,100 @@
+/*
+Package phash provides perceptual image hashes to find visually similar pictures.
+
+Copyright (c) 2018 - 2023 PhotoPrism UG. All rights reserved.
+
+	This program is free software: you can redistribute it and/or modify
+	it under Version 3 of the GNU Affero General Public License (the "AGPL"):
+	<https://docs.photoprism.app/license/agpl>
+
+	This program is distributed in the hope that it will be useful,
+	but WITHOUT ANY WARRANTY; without even the implied warranty of
+	MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+	GNU Affero General Public License for more details.
+
+	The AGPL is supplemented by our Trademark and Brand Guidelines,
+	which describe how our Brand Assets may be used:
+	<https://www.photoprism.app/trademark>
+
+Feel free to send an email to hello@photoprism.app if you have questions,
+want to support our work, or just want to say hello.
+
+Additional information can be found in our Developer Guide:
+<https://docs.photoprism.app/developer-guide/>
+*/
+package phash
+
+import (
+	"fmt"
+	"image"
+	"math/bits"
+	"strconv"
+
+	"github.com/disintegration/imaging"
+)
+
+// Hash represents a 64-bit perceptual image hash.
+type Hash uint64
+
+// DefaultDistance is the default maximum number of different bits for pictures to be considered similar.
+const DefaultDistance = 6
+
+// MaxDistance is the maximum number of different bits that can be used to find similar pictures.
+const MaxDistance = 16
+
+// Difference returns the difference hash (dHash) of an image, which is based on the brightness
+// gradient between adjacent pixels of a 9x8 grayscale version and therefore stays the same
+// if a picture is resized, recompressed, or slightly edited.
+func Difference(img image.Image) Hash {
+	if img == nil {
+		return 0
+	}
+
+	small := imaging.Resize(img, 9, 8, imaging.Box)
+
+	var h Hash
+
+	for y := 0; y < 8; y++ {
+		for x := 0; x < 8; x++ {
+			h <<= 1
+
+			if luminance(small, x, y) > luminance(small, x+1, y) {
+				h |= 1
+			}
+		}
+	}
+
+	return h
+}
+
+// luminance returns the relative brightness of a pixel.
+func luminance(img *image.NRGBA, x, y int) float64 {
+	c := img.NRGBAAt(x, y)
+	return 0.299*float64(c.R) + 0.587*float64(c.G) + 0.114*float64(c.B)
+}
+
+// Parse returns the hash encoded in a hex string.
+func Parse(s string) (Hash, error) {
+	if s == "" {
+		return 0, fmt.Errorf("hash is empty")
+	}
+
+	h, err := strconv.ParseUint(s, 16, 64)
+
+	return Hash(h), err
+}
+
+// Hex returns the hash as a fixed length hex string.
+func (h Hash) Hex() string {
+	return fmt.Sprintf("%016x", uint64(h))
+}
+
+// String implements the Stringer interface.
+func (h Hash) String() string {
+	return h.Hex()
+}
+
+// Distance returns the number of different bits (Hamming distance).
+func (h Hash) Distance(other Hash) int {
+	return bits.OnesCount64(uint64(h ^ other))
+}
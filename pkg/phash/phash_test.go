@@ -0,0 +1,81 @@
+package phash
+
+import (
+	"image"
+	"image/color"
+	"testing"
+
+	"github.com/disintegration/imaging"
+	"github.com/stretchr/testify/assert"
+)
+
+// testImage returns a synthetic test image with a diagonal gradient and a bright rectangle.
+func testImage(width, height int) image.Image {
+	img := image.NewNRGBA(image.Rect(0, 0, width, height))
+
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			v := uint8((x*255/width + y*128/height) / 2)
+
+			if x > width/4 && x < width/2 && y > height/3 && y < height*2/3 {
+				v = 250
+			}
+
+			img.SetNRGBA(x, y, color.NRGBA{R: v, G: v / 2, B: 255 - v, A: 255})
+		}
+	}
+
+	return img
+}
+
+func TestDifference(t *testing.T) {
+	t.Run("Nil", func(t *testing.T) {
+		assert.Equal(t, Hash(0), Difference(nil))
+	})
+	t.Run("Resized", func(t *testing.T) {
+		img := testImage(640, 480)
+		h := Difference(img)
+		resized := Difference(imaging.Resize(img, 160, 120, imaging.Lanczos))
+		assert.NotEqual(t, Hash(0), h)
+		assert.LessOrEqual(t, h.Distance(resized), 2)
+	})
+	t.Run("Edited", func(t *testing.T) {
+		img := testImage(640, 480)
+		h := Difference(img)
+		edited := Difference(imaging.AdjustBrightness(img, 10))
+		assert.LessOrEqual(t, h.Distance(edited), DefaultDistance)
+	})
+	t.Run("Different", func(t *testing.T) {
+		img := testImage(640, 480)
+		h := Difference(img)
+		other := Difference(imaging.FlipH(img))
+		assert.Greater(t, h.Distance(other), DefaultDistance)
+	})
+}
+
+func TestParse(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		h, err := Parse("00ff00ff00ff00ff")
+		assert.NoError(t, err)
+		assert.Equal(t, Hash(0x00ff00ff00ff00ff), h)
+	})
+	t.Run("Empty", func(t *testing.T) {
+		_, err := Parse("")
+		assert.Error(t, err)
+	})
+	t.Run("Invalid", func(t *testing.T) {
+		_, err := Parse("xyz")
+		assert.Error(t, err)
+	})
+}
+
+func TestHash_Hex(t *testing.T) {
+	assert.Equal(t, "00000000000000ff", Hash(255).Hex())
+	assert.Equal(t, "00000000000000ff", Hash(255).String())
+}
+
+func TestHash_Distance(t *testing.T) {
+	assert.Equal(t, 0, Hash(0xff).Distance(0xff))
+	assert.Equal(t, 8, Hash(0xff).Distance(0))
+	assert.Equal(t, 64, Hash(0).Distance(^Hash(0)))
+}
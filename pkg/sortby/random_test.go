@@ -10,8 +10,10 @@ import (
 
 func TestRandomExpr(t *testing.T) {
 	mysql, _ := gorm.GetDialect(MySQL)
+	postgres, _ := gorm.GetDialect(Postgres)
 	sqlite3, _ := gorm.GetDialect(SQLite3)
 
 	assert.Equal(t, gorm.Expr("RAND()"), RandomExpr(mysql))
+	assert.Equal(t, gorm.Expr("RANDOM()"), RandomExpr(postgres))
 	assert.Equal(t, gorm.Expr("RANDOM()"), RandomExpr(sqlite3))
 }